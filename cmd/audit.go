@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/raystack/frontier/config"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/internal/store/postgres"
+	"github.com/raystack/frontier/pkg/db"
+	"github.com/raystack/salt/printer"
+	cli "github.com/spf13/cobra"
+)
+
+var errAuditChainBroken = errors.New("audit log chain is broken")
+
+func AuditCommand() *cli.Command {
+	cmd := &cli.Command{
+		Use:   "audit",
+		Short: "Audit log management",
+		Long: heredoc.Doc(`
+			Work with audit logs stored in the database.
+		`),
+		Example: heredoc.Doc(`
+			$ frontier audit verify --org <org-id> -c ./config.yaml
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+	}
+
+	cmd.AddCommand(auditVerifyCommand())
+	return cmd
+}
+
+func auditVerifyCommand() *cli.Command {
+	var configFile string
+	var orgIDs []string
+
+	cmd := &cli.Command{
+		Use:   "verify",
+		Short: "Verify the hash chain of audit logs",
+		Long: heredoc.Doc(`
+			Recompute the hash chain of the audit logs of organizations and report
+			the first broken link. A link breaks when a log is modified, deleted or
+			inserted out of band. Connects to the database configured for the server.
+
+			Deleting the latest logs leaves a valid chain behind, record the reported
+			head outside of frontier and compare it on the next run to detect it.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier audit verify --org <org-id> -c ./config.yaml
+			$ frontier audit verify --org <org-id> --org <org-id>
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			appConfig, err := config.Load(configFile)
+			if err != nil {
+				return err
+			}
+			dbc, err := db.New(appConfig.DB)
+			if err != nil {
+				return fmt.Errorf("failed to connect to db: %w", err)
+			}
+			defer dbc.Close()
+			repository := postgres.NewAuditRepository(dbc)
+
+			spinner := printer.Spin("")
+			defer spinner.Stop()
+
+			report := [][]string{{"ORG", "CHECKED", "VALID", "HEAD", "BROKEN LOG", "REASON"}}
+			broken := false
+			for _, orgID := range orgIDs {
+				result, err := audit.VerifyChain(cmd.Context(), repository, orgID)
+				if err != nil {
+					return fmt.Errorf("verifying %s: %w", orgID, err)
+				}
+				row := []string{orgID, strconv.Itoa(result.Checked), strconv.FormatBool(result.Valid),
+					fmt.Sprintf("%s (seq %d)", result.HeadHash, result.HeadSequence), "", ""}
+				if result.Break != nil {
+					broken = true
+					row[4] = fmt.Sprintf("%s (seq %d)", result.Break.LogID, result.Break.Sequence)
+					row[5] = result.Break.Reason
+				}
+				report = append(report, row)
+			}
+			spinner.Stop()
+			printer.Table(os.Stdout, report)
+
+			if broken {
+				return errAuditChainBroken
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&configFile, "config", "c", "", "config file path")
+	cmd.Flags().StringArrayVar(&orgIDs, "org", nil, "id of the organization to verify, can be repeated")
+	cmd.MarkFlagRequired("org")
+	return cmd
+}
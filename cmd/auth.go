@@ -0,0 +1,333 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/salt/cmdx"
+	cli "github.com/spf13/cobra"
+)
+
+const (
+	deviceAuthorizationPath = "/oauth2/device_authorization"
+	tokenPath               = "/oauth2/token"
+	revokePath              = "/oauth2/revoke"
+
+	grantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// tokenRefreshLeeway refreshes the access token a bit before it expires
+	// so it doesn't expire in flight
+	tokenRefreshLeeway = time.Minute
+)
+
+func AuthCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "auth",
+		Short: "Authenticate the client with frontier",
+		Long: heredoc.Doc(`
+			Log in to frontier from a browser with the OAuth2 device authorization
+			grant. The access token is stored in the client config and sent with
+			every request, it is refreshed when it expires.
+
+			An additional header with "key:value" format can be sent instead, it
+			takes precedence over the stored token.
+		`),
+		Example: heredoc.Doc(`
+			$ frontier auth login --url https://frontier.example.com --client-id <client-id>
+			$ frontier auth token
+			$ frontier auth logout
+			$ frontier user create -f user.yaml -H X-Frontier-Email:user@raystack.org
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+	}
+
+	cmd.AddCommand(authLoginCommand(cliConfig))
+	cmd.AddCommand(authLogoutCommand(cliConfig))
+	cmd.AddCommand(authTokenCommand(cliConfig))
+	return cmd
+}
+
+func authLoginCommand(cliConfig *Config) *cli.Command {
+	var frontierURL, clientID, scope string
+
+	cmd := &cli.Command{
+		Use:   "login",
+		Short: "Log in with a browser",
+		Long: heredoc.Doc(`
+			Start a device authorization and wait until the code shown is approved
+			in a browser. The client must be registered with "frontier oauth2 client
+			create", the url and client id are remembered for the next logins.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier auth login --url https://frontier.example.com --client-id <client-id>
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if frontierURL != "" {
+				cliConfig.Auth.URL = strings.TrimSuffix(frontierURL, "/")
+			}
+			if clientID != "" {
+				cliConfig.Auth.ClientID = clientID
+			}
+			if cliConfig.Auth.URL == "" || cliConfig.Auth.ClientID == "" {
+				return ErrClientAuthNotConfigured
+			}
+
+			device, err := authorizeDevice(cmd.Context(), cliConfig.Auth, scope)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Open %s in a browser and confirm the code %s\n", device.VerificationURIComplete, device.UserCode)
+
+			token, err := pollDeviceToken(cmd.Context(), cliConfig.Auth, device)
+			if err != nil {
+				return err
+			}
+			cliConfig.Auth.AccessToken = token.AccessToken
+			cliConfig.Auth.RefreshToken = token.RefreshToken
+			if err := saveAuthConfig(cliConfig.Auth); err != nil {
+				return err
+			}
+			fmt.Println("successfully logged in")
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&frontierURL, "url", "", "public url of the frontier http server")
+	cmd.Flags().StringVar(&clientID, "client-id", "", "id of the oauth2 client of the cli")
+	cmd.Flags().StringVar(&scope, "scope", "", "space delimited scopes, defaults to all the scopes of the client")
+	return cmd
+}
+
+func authLogoutCommand(cliConfig *Config) *cli.Command {
+	return &cli.Command{
+		Use:   "logout",
+		Short: "Log out and forget the stored token",
+		Args:  cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier auth logout
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if cliConfig.Auth.RefreshToken != "" {
+				// the token is forgotten even if the server can't be reached
+				if err := postForm(cmd.Context(), cliConfig.Auth.URL+revokePath, url.Values{
+					"token":           {cliConfig.Auth.RefreshToken},
+					"token_type_hint": {"refresh_token"},
+					"client_id":       {cliConfig.Auth.ClientID},
+				}, nil); err != nil {
+					fmt.Fprintf(os.Stderr, "failed to revoke the token: %v\n", err)
+				}
+			}
+			cliConfig.Auth.AccessToken = ""
+			cliConfig.Auth.RefreshToken = ""
+			if err := saveAuthConfig(cliConfig.Auth); err != nil {
+				return err
+			}
+			fmt.Println("successfully logged out")
+			return nil
+		},
+	}
+}
+
+func authTokenCommand(cliConfig *Config) *cli.Command {
+	return &cli.Command{
+		Use:   "token",
+		Short: "Print the access token of the logged in user",
+		Args:  cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ curl -H "Authorization: Bearer $(frontier auth token)" https://frontier.example.com/v1beta1/users/self
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			token, err := accessToken(cmd.Context(), cliConfig)
+			if err != nil {
+				return err
+			}
+			if token == "" {
+				return ErrClientNotLoggedIn
+			}
+			fmt.Println(token)
+			return nil
+		},
+	}
+}
+
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int64  `json:"expires_in"`
+	Interval                int64  `json:"interval"`
+}
+
+type oauth2Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+type oauth2Error struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e oauth2Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+func authorizeDevice(ctx context.Context, auth AuthConfig, scope string) (deviceAuthorization, error) {
+	form := url.Values{"client_id": {auth.ClientID}}
+	if scope != "" {
+		form.Set("scope", scope)
+	}
+	var device deviceAuthorization
+	if err := postForm(ctx, auth.URL+deviceAuthorizationPath, form, &device); err != nil {
+		return deviceAuthorization{}, err
+	}
+	if device.VerificationURIComplete == "" {
+		device.VerificationURIComplete = device.VerificationURI
+	}
+	return device, nil
+}
+
+// pollDeviceToken polls the token endpoint until the user decided as per
+// RFC 8628 section 3.5, backing off when asked to slow down
+func pollDeviceToken(ctx context.Context, auth AuthConfig, device deviceAuthorization) (oauth2Token, error) {
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(device.ExpiresIn) * time.Second)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return oauth2Token{}, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var token oauth2Token
+		err := postForm(ctx, auth.URL+tokenPath, url.Values{
+			"grant_type":  {grantTypeDeviceCode},
+			"device_code": {device.DeviceCode},
+			"client_id":   {auth.ClientID},
+		}, &token)
+		var oauthErr oauth2Error
+		switch {
+		case err == nil:
+			return token, nil
+		case errors.As(err, &oauthErr) && oauthErr.Code == "authorization_pending":
+		case errors.As(err, &oauthErr) && oauthErr.Code == "slow_down":
+			interval += 5 * time.Second
+		default:
+			return oauth2Token{}, err
+		}
+	}
+	return oauth2Token{}, ErrClientLoginExpired
+}
+
+// accessToken returns the stored access token, it is refreshed and saved
+// first if it is about to expire
+func accessToken(ctx context.Context, cliConfig *Config) (string, error) {
+	if cliConfig == nil || cliConfig.Auth.AccessToken == "" {
+		return "", nil
+	}
+	parsed, err := jwt.ParseInsecure([]byte(cliConfig.Auth.AccessToken))
+	if err == nil && time.Now().Add(tokenRefreshLeeway).Before(parsed.Expiration()) {
+		return cliConfig.Auth.AccessToken, nil
+	}
+	if cliConfig.Auth.RefreshToken == "" {
+		return "", ErrClientNotLoggedIn
+	}
+
+	var token oauth2Token
+	if err := postForm(ctx, cliConfig.Auth.URL+tokenPath, url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {cliConfig.Auth.RefreshToken},
+		"client_id":     {cliConfig.Auth.ClientID},
+	}, &token); err != nil {
+		var oauthErr oauth2Error
+		if errors.As(err, &oauthErr) && oauthErr.Code == "invalid_grant" {
+			// the session of the login has ended or the token was revoked
+			return "", ErrClientNotLoggedIn
+		}
+		return "", err
+	}
+	cliConfig.Auth.AccessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		cliConfig.Auth.RefreshToken = token.RefreshToken
+	}
+	if err := saveAuthConfig(cliConfig.Auth); err != nil {
+		return "", err
+	}
+	return cliConfig.Auth.AccessToken, nil
+}
+
+// postForm sends a form encoded request to an oauth2 endpoint and decodes
+// the json response in out, protocol errors are returned as oauth2Error
+func postForm(ctx context.Context, endpoint string, form url.Values, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var oauthErr oauth2Error
+		if err := json.NewDecoder(resp.Body).Decode(&oauthErr); err != nil || oauthErr.Code == "" {
+			return fmt.Errorf("unexpected response from %s: %s", endpoint, resp.Status)
+		}
+		return oauthErr
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// saveAuthConfig writes the auth settings to the client config file, leaving
+// the rest as it is on disk rather than persisting flags like --host. The
+// file holds tokens so only the current user may read it
+func saveAuthConfig(auth AuthConfig) error {
+	stored, err := LoadConfig()
+	if err != nil {
+		stored = &Config{}
+	}
+	stored.Auth = auth
+
+	cfg := cmdx.SetConfig("frontier")
+	if err := cfg.Write(stored); err != nil {
+		return err
+	}
+	return os.Chmod(cfg.File(), 0600)
+}
@@ -0,0 +1,67 @@
+package cmd_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/raystack/frontier/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAuthLogin(t *testing.T) {
+	t.Run("should require the url and client id", func(t *testing.T) {
+		t.Setenv("RAYSTACK_CONFIG_DIR", t.TempDir())
+		cli := cmd.New(&cmd.Config{})
+		cli.SetArgs([]string{"auth", "login"})
+
+		assert.Equal(t, cmd.ErrClientAuthNotConfigured, cli.Execute())
+	})
+
+	t.Run("should store the tokens once the device is approved", func(t *testing.T) {
+		configDir := t.TempDir()
+		t.Setenv("RAYSTACK_CONFIG_DIR", configDir)
+
+		var polls int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/oauth2/device_authorization":
+				assert.Equal(t, "cli-client", r.FormValue("client_id"))
+				_, _ = w.Write([]byte(`{"device_code":"the-device-code","user_code":"BCDF-GHJK",` +
+					`"verification_uri":"http://frontier/oauth2/device","expires_in":60,"interval":1}`))
+			case "/oauth2/token":
+				assert.Equal(t, "the-device-code", r.FormValue("device_code"))
+				if polls++; polls == 1 {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"access_token":"jwt","refresh_token":"refresh","expires_in":3600}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		cli := cmd.New(&cmd.Config{})
+		cli.SetArgs([]string{"auth", "login", "--url", server.URL, "--client-id", "cli-client"})
+		require.NoError(t, cli.Execute())
+		assert.Equal(t, 2, polls)
+
+		stored, err := cmd.LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, cmd.AuthConfig{
+			URL:          server.URL,
+			ClientID:     "cli-client",
+			AccessToken:  "jwt",
+			RefreshToken: "refresh",
+		}, stored.Auth)
+		info, err := os.Stat(filepath.Join(configDir, "frontier.yml"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+}
@@ -1,18 +1,24 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
 
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 
 	frontierv1beta1 "github.com/raystack/frontier/proto/v1beta1"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 )
 
-func createConnection(ctx context.Context, host string, caCertFile string) (*grpc.ClientConn, error) {
+func createConnection(ctx context.Context, host string, caCertFile string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	creds := insecure.NewCredentials()
 	if caCertFile != "" {
 		tlsCreds, err := credentials.NewClientTLSFromFile(caCertFile, "")
@@ -21,16 +27,34 @@ func createConnection(ctx context.Context, host string, caCertFile string) (*grp
 		}
 		creds = tlsCreds
 	}
-	opts := []grpc.DialOption{
+	opts = append(opts,
 		grpc.WithTransportCredentials(creds),
 		grpc.WithBlock(),
-	}
+	)
 	return grpc.DialContext(ctx, host, opts...)
 }
 
-func createClient(ctx context.Context, host string) (frontierv1beta1.FrontierServiceClient, func(), error) {
+// tokenInterceptor sends the access token stored by "frontier auth login"
+// with requests which don't carry a header set with --header already
+func tokenInterceptor(cliConfig *Config) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := metadata.FromOutgoingContext(ctx); !ok {
+			token, err := accessToken(ctx, cliConfig)
+			if err != nil {
+				return err
+			}
+			if token != "" {
+				ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+			}
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func createClient(ctx context.Context, cliConfig *Config) (frontierv1beta1.FrontierServiceClient, func(), error) {
 	dialTimeoutCtx, dialCancel := context.WithTimeout(ctx, time.Second*2)
-	conn, err := createConnection(dialTimeoutCtx, host, "")
+	conn, err := createConnection(dialTimeoutCtx, cliConfig.Host, "", grpc.WithUnaryInterceptor(tokenInterceptor(cliConfig)))
 	if err != nil {
 		dialCancel()
 		return nil, nil, err
@@ -44,9 +68,9 @@ func createClient(ctx context.Context, host string) (frontierv1beta1.FrontierSer
 	return client, cancel, nil
 }
 
-func createAdminClient(ctx context.Context, host string) (frontierv1beta1.AdminServiceClient, func(), error) {
+func createAdminClient(ctx context.Context, cliConfig *Config) (frontierv1beta1.AdminServiceClient, func(), error) {
 	dialTimeoutCtx, dialCancel := context.WithTimeout(ctx, time.Second*2)
-	conn, err := createConnection(dialTimeoutCtx, host, "")
+	conn, err := createConnection(dialTimeoutCtx, cliConfig.Host, "", grpc.WithUnaryInterceptor(tokenInterceptor(cliConfig)))
 	if err != nil {
 		dialCancel()
 		return nil, nil, err
@@ -90,3 +114,59 @@ func overrideClientConfigHost(cmd *cobra.Command, cliConfig *Config) error {
 func bindFlagsFromClientConfig(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringP("host", "h", "", "Frontier API service to connect to")
 }
+
+// doRequest calls a plain http endpoint of the server with the access token of
+// the login, the json body is sent if not nil and the json response decoded
+// in out if not nil. Responses other than wantStatus are returned as errors
+// carrying the message of the server.
+func doRequest(ctx context.Context, cliConfig *Config, method, path string, body, out any, wantStatus int) error {
+	if cliConfig == nil || cliConfig.Auth.URL == "" {
+		return ErrClientAuthNotConfigured
+	}
+	token, err := accessToken(ctx, cliConfig)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return ErrClientNotLoggedIn
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	endpoint := cliConfig.Auth.URL + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != wantStatus {
+		var errBody struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errBody); err != nil || errBody.Message == "" {
+			return fmt.Errorf("unexpected response from %s: %s", endpoint, resp.Status)
+		}
+		return fmt.Errorf("%s", errBody.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -9,7 +9,17 @@ import (
 )
 
 type Config struct {
-	Host string `mapstructure:"host"`
+	Host string     `yaml:"host" mapstructure:"host"`
+	Auth AuthConfig `yaml:"auth,omitempty" mapstructure:"auth"`
+}
+
+// AuthConfig is managed by "frontier auth login"
+type AuthConfig struct {
+	// URL is the public url of the frontier http server serving oauth2
+	URL          string `yaml:"url,omitempty" mapstructure:"url"`
+	ClientID     string `yaml:"client_id,omitempty" mapstructure:"client_id"`
+	AccessToken  string `yaml:"access_token,omitempty" mapstructure:"access_token"`
+	RefreshToken string `yaml:"refresh_token,omitempty" mapstructure:"refresh_token"`
 }
 
 func LoadConfig() (*Config, error) {
@@ -7,10 +7,13 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
+// setCtxHeader sets the header passed in "key:value" format, the stored
+// access token is sent instead when the header is empty
 func setCtxHeader(ctx context.Context, header string) context.Context {
-	s := strings.Split(header, ":")
-	key := s[0]
-	val := s[1]
+	key, val, found := strings.Cut(header, ":")
+	if !found {
+		return ctx
+	}
 
 	md := metadata.New(map[string]string{key: val})
 	ctx = metadata.NewOutgoingContext(ctx, md)
@@ -27,4 +27,18 @@ var (
 		
 		Run "frontier help auth" for more information.
 	`))
+	ErrClientAuthNotConfigured = errors.New(heredoc.Doc(`
+		Frontier client config "auth" not found.
+
+		Pass the frontier server url and the oauth2 client id with
+		"--url" and "--client-id" flags.
+
+		Run "frontier help auth" for more information.
+	`))
+	ErrClientNotLoggedIn = errors.New(heredoc.Doc(`
+		Frontier client is not logged in or the login has expired.
+
+		Run "frontier auth login" to log in again.
+	`))
+	ErrClientLoginExpired = errors.New("device code expired before the login was approved")
 )
@@ -68,7 +68,7 @@ func createGroupCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -90,7 +90,6 @@ func createGroupCommand(cliConfig *Config) *cli.Command {
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the group body file")
 	cmd.MarkFlagRequired("file")
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
@@ -122,7 +121,7 @@ func editGroupCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -166,7 +165,7 @@ func viewGroupCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -237,7 +236,7 @@ func listGroupCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
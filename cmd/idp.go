@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/raystack/salt/printer"
+	cli "github.com/spf13/cobra"
+)
+
+const (
+	idpProviderPath  = "/v1beta1/organizations/%s/idp"
+	idpProvidersPath = "/admin/idps"
+)
+
+// idpProvider is the identity provider as returned by the server, without
+// its client secret
+type idpProvider struct {
+	ID        string `json:"id"`
+	OrgID     string `json:"org_id"`
+	IssuerURL string `json:"issuer_url"`
+	ClientID  string `json:"client_id"`
+}
+
+func IDPCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "idp",
+		Short: "OIDC identity provider management",
+		Long: heredoc.Doc(`
+			Work with the oidc identity providers organizations log in with, users
+			are routed to the provider of the organization which verified the domain
+			of their email.
+
+			Providers are managed by the server on behalf of the user logged in with
+			"frontier auth login", who must be allowed to update the organization.
+			Changes are recorded in the audit logs of the organization.
+		`),
+		Example: heredoc.Doc(`
+			$ frontier idp provider create --org acme --issuer-url https://accounts.acme.org --client-id frontier --client-secret secret
+			$ frontier idp provider list
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+	}
+
+	providerCmd := &cli.Command{
+		Use:   "provider",
+		Short: "Manage oidc identity providers",
+	}
+	providerCmd.AddCommand(idpCreateProviderCommand(cliConfig))
+	providerCmd.AddCommand(idpListProviderCommand(cliConfig))
+	providerCmd.AddCommand(idpDeleteProviderCommand(cliConfig))
+	cmd.AddCommand(providerCmd)
+	return cmd
+}
+
+func idpCreateProviderCommand(cliConfig *Config) *cli.Command {
+	var orgID string
+	var req struct {
+		IssuerURL    string `json:"issuer_url"`
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+	}
+
+	cmd := &cli.Command{
+		Use:   "create",
+		Short: "Register the oidc identity provider of an organization",
+		Long: heredoc.Doc(`
+			Register the identity provider of an organization. Users whose email belongs
+			to a verified domain of the organization log in with it using the sso
+			strategy, the identity provider is configured with the callback url of the
+			frontend. The client secret is encrypted with the sso encryption key.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier idp provider create --org acme --issuer-url https://accounts.acme.org --client-id frontier --client-secret secret
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			var provider idpProvider
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodPost,
+				fmt.Sprintf(idpProviderPath, url.PathEscape(orgID)), req, &provider, http.StatusCreated); err != nil {
+				return err
+			}
+			report := [][]string{{"ID", "ORG ID", "ISSUER URL"}, {provider.ID, provider.OrgID, provider.IssuerURL}}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&orgID, "org", "", "id or name of the organization")
+	cmd.Flags().StringVar(&req.IssuerURL, "issuer-url", "", "issuer url of the identity provider")
+	cmd.Flags().StringVar(&req.ClientID, "client-id", "", "client id registered at the identity provider")
+	cmd.Flags().StringVar(&req.ClientSecret, "client-secret", "", "client secret registered at the identity provider")
+	cmd.MarkFlagRequired("org")
+	cmd.MarkFlagRequired("issuer-url")
+	cmd.MarkFlagRequired("client-id")
+	cmd.MarkFlagRequired("client-secret")
+	return cmd
+}
+
+func idpListProviderCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "list",
+		Short: "List oidc identity providers",
+		Long: heredoc.Doc(`
+			List the providers of every organization, only superusers are allowed to.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier idp provider list
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			var resp struct {
+				Providers []idpProvider `json:"providers"`
+			}
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodGet, idpProvidersPath, nil, &resp,
+				http.StatusOK); err != nil {
+				return err
+			}
+			report := [][]string{{"ID", "ORG ID", "ISSUER URL", "CLIENT ID"}}
+			for _, p := range resp.Providers {
+				report = append(report, []string{p.ID, p.OrgID, p.IssuerURL, p.ClientID})
+			}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func idpDeleteProviderCommand(cliConfig *Config) *cli.Command {
+	var orgID string
+
+	cmd := &cli.Command{
+		Use:   "delete",
+		Short: "Delete the oidc identity provider of an organization",
+		Long: heredoc.Doc(`
+			Delete the provider of an organization, its members can't log in with it
+			anymore. Existing sessions stay valid until they expire.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier idp provider delete --org acme
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodDelete,
+				fmt.Sprintf(idpProviderPath, url.PathEscape(orgID)), nil, nil, http.StatusNoContent); err != nil {
+				return err
+			}
+			fmt.Printf("deleted oidc identity provider of organization %s\n", orgID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&orgID, "org", "", "id or name of the organization")
+	cmd.MarkFlagRequired("org")
+	return cmd
+}
@@ -51,7 +51,7 @@ func viewNamespaceCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -104,7 +104,7 @@ func listNamespaceCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
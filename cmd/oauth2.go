@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/raystack/salt/printer"
+	cli "github.com/spf13/cobra"
+)
+
+const (
+	oauth2ClientsPath      = "/admin/oauth2/clients"
+	oauth2ClientPath       = "/admin/oauth2/clients/%s"
+	oauth2ClientSecretPath = "/admin/oauth2/clients/%s/secret/rotate"
+)
+
+// oauth2Client is the client as returned by the server, the secret is only
+// set when the client is created
+type oauth2Client struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Secret       string   `json:"secret"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	Public       bool     `json:"public"`
+}
+
+func OAuth2Command(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "oauth2",
+		Short: "OAuth2 client management",
+		Long: heredoc.Doc(`
+			Work with third party applications allowed to request access tokens
+			on behalf of users through the oauth2 authorization code flow.
+
+			Clients are managed by the server on behalf of the superuser logged in
+			with "frontier auth login", and recorded in the audit logs.
+		`),
+		Example: heredoc.Doc(`
+			$ frontier oauth2 client create --name app --redirect-uri https://app.example.com/callback
+			$ frontier oauth2 client list
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+	}
+
+	clientCmd := &cli.Command{
+		Use:   "client",
+		Short: "Manage oauth2 clients",
+	}
+	clientCmd.AddCommand(oauth2CreateClientCommand(cliConfig))
+	clientCmd.AddCommand(oauth2ListClientCommand(cliConfig))
+	clientCmd.AddCommand(oauth2RotateClientCommand(cliConfig))
+	clientCmd.AddCommand(oauth2DeleteClientCommand(cliConfig))
+	cmd.AddCommand(clientCmd)
+	return cmd
+}
+
+func oauth2CreateClientCommand(cliConfig *Config) *cli.Command {
+	var req struct {
+		Name         string   `json:"name"`
+		RedirectURIs []string `json:"redirect_uris"`
+		Scopes       []string `json:"scopes"`
+		Public       bool     `json:"public"`
+	}
+
+	cmd := &cli.Command{
+		Use:   "create",
+		Short: "Register an oauth2 client",
+		Long: heredoc.Doc(`
+			Register a client and print its credentials. The secret of confidential
+			clients is shown only once, public clients authenticate with PKCE alone.
+			Clients without a redirect uri can only use the device authorization grant.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier oauth2 client create --name app --redirect-uri https://app.example.com/callback --scope profile --scope frontier:read
+			$ frontier oauth2 client create --name spa --redirect-uri http://localhost:3000/callback --public
+			$ frontier oauth2 client create --name cli --public --scope frontier:read --scope frontier:write
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			var client oauth2Client
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodPost, oauth2ClientsPath, req, &client,
+				http.StatusCreated); err != nil {
+				return err
+			}
+			report := [][]string{{"CLIENT ID", "CLIENT SECRET"}, {client.ID, client.Secret}}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Name, "name", "", "name of the client shown to users on the consent page")
+	cmd.Flags().StringArrayVar(&req.RedirectURIs, "redirect-uri", nil, "allowed redirect uri, can be repeated")
+	cmd.Flags().StringArrayVar(&req.Scopes, "scope", nil, "scope the client can request, can be repeated")
+	cmd.Flags().BoolVar(&req.Public, "public", false, "client can't keep a secret, e.g. a SPA or a native app")
+	cmd.MarkFlagRequired("name")
+	return cmd
+}
+
+func oauth2ListClientCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "list",
+		Short: "List oauth2 clients",
+		Args:  cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier oauth2 client list
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			var resp struct {
+				Clients []oauth2Client `json:"clients"`
+			}
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodGet, oauth2ClientsPath, nil, &resp,
+				http.StatusOK); err != nil {
+				return err
+			}
+			report := [][]string{{"ID", "NAME", "PUBLIC", "REDIRECT URIS", "SCOPES"}}
+			for _, c := range resp.Clients {
+				report = append(report, []string{c.ID, c.Name, strconv.FormatBool(c.Public),
+					strings.Join(c.RedirectURIs, ","), strings.Join(c.Scopes, ",")})
+			}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func oauth2RotateClientCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "rotate <client-id>",
+		Short: "Rotate the secret of an oauth2 client",
+		Long: heredoc.Doc(`
+			Replace the secret of a confidential client and print the new one, the
+			previous secret stops working right away. Public clients have no secret.
+		`),
+		Args: cli.ExactArgs(1),
+		Example: heredoc.Doc(`
+			$ frontier oauth2 client rotate <client-id>
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			var resp struct {
+				Secret string `json:"secret"`
+			}
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodPost,
+				fmt.Sprintf(oauth2ClientSecretPath, url.PathEscape(args[0])), nil, &resp, http.StatusOK); err != nil {
+				return err
+			}
+			report := [][]string{{"CLIENT ID", "CLIENT SECRET"}, {args[0], resp.Secret}}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func oauth2DeleteClientCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "delete <client-id>",
+		Short: "Delete an oauth2 client",
+		Long: heredoc.Doc(`
+			Delete a client along with its pending authorizations, user consents and
+			refresh tokens. Access tokens already issued to the client stay valid
+			until they expire.
+		`),
+		Args: cli.ExactArgs(1),
+		Example: heredoc.Doc(`
+			$ frontier oauth2 client delete <client-id>
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodDelete,
+				fmt.Sprintf(oauth2ClientPath, url.PathEscape(args[0])), nil, nil, http.StatusNoContent); err != nil {
+				return err
+			}
+			fmt.Printf("deleted oauth2 client %s\n", args[0])
+			return nil
+		},
+	}
+	return cmd
+}
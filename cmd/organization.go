@@ -69,7 +69,7 @@ func createOrganizationCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -92,7 +92,6 @@ func createOrganizationCommand(cliConfig *Config) *cli.Command {
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the organization body file")
 	cmd.MarkFlagRequired("file")
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
@@ -124,7 +123,7 @@ func editOrganizationCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -168,7 +167,7 @@ func viewOrganizationCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -236,7 +235,7 @@ func listOrganizationCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -290,7 +289,7 @@ func admlistOrganizationCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -41,7 +41,7 @@ func TestClientOrganization(t *testing.T) {
 				name:        "`organization` create with host flag should throw error missing required flag",
 				want:        "",
 				subCommands: []string{"create", "-h", "test"},
-				err:         errors.New("required flag(s) \"file\", \"header\" not set"),
+				err:         errors.New("required flag(s) \"file\" not set"),
 			},
 			{
 				name:        "`organization` edit without host should throw error host not found",
@@ -68,7 +68,7 @@ func createPermissionCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createAdminClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createAdminClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -91,7 +91,6 @@ func createPermissionCommand(cliConfig *Config) *cli.Command {
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the permission body file")
 	cmd.MarkFlagRequired("file")
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
@@ -123,7 +122,7 @@ func editPermissionCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createAdminClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createAdminClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -165,7 +164,7 @@ func viewPermissionCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -215,7 +214,7 @@ func listPermissionCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
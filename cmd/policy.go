@@ -66,7 +66,7 @@ func createPolicyCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -89,7 +89,6 @@ func createPolicyCommand(cliConfig *Config) *cli.Command {
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the policy body file")
 	cmd.MarkFlagRequired("file")
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
@@ -121,7 +120,7 @@ func editPolicyCommand(cliConfig *Config) *cli.Command {
 				return err
 			}
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -163,7 +162,7 @@ func viewPolicyCommand(cliConfig *Config) *cli.Command {
 			spinner := printer.Spin("")
 			defer spinner.Stop()
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -53,7 +53,7 @@ func preferencesListCommand(cliConfig *Config) *cobra.Command {
 
 			var reqBody frontierv1beta1.ListPreferencesRequest
 
-			adminClient, cancel, err := createAdminClient(cmd.Context(), cliConfig.Host)
+			adminClient, cancel, err := createAdminClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -83,7 +83,6 @@ func preferencesListCommand(cliConfig *Config) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
@@ -115,7 +114,7 @@ func preferencesSetCommand(cliConfig *Config) *cobra.Command {
 				Value: value,
 			})
 
-			client, cancel, err := createAdminClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createAdminClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -138,7 +137,6 @@ func preferencesSetCommand(cliConfig *Config) *cobra.Command {
 	cmd.Flags().StringVarP(&name, "name", "n", "", "Name of the preference")
 	cmd.Flags().StringVarP(&value, "value", "v", "", "Value of the preference")
 
-	cmd.MarkFlagRequired("header")
 	return cmd
 }
 
@@ -161,7 +159,7 @@ func preferencesGetCommand(cliConfig *Config) *cobra.Command {
 
 			var reqBody frontierv1beta1.DescribePreferencesRequest
 
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -180,7 +178,6 @@ func preferencesGetCommand(cliConfig *Config) *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
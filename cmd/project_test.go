@@ -44,7 +44,7 @@ func TestClientProject(t *testing.T) {
 				name:        "`project` create with host flag should throw error missing required flag",
 				want:        "",
 				subCommands: []string{"create", "-h", "test"},
-				err:         errors.New("required flag(s) \"file\", \"header\" not set"),
+				err:         errors.New("required flag(s) \"file\" not set"),
 			},
 			{
 				name:        "`project` edit without host should throw error host not found",
@@ -41,7 +41,7 @@ func TestClientRole(t *testing.T) {
 				name:        "`role` create with host flag should throw error missing required flag",
 				want:        "",
 				subCommands: []string{"create", "-h", "test"},
-				err:         errors.New("required flag(s) \"file\", \"header\" not set"),
+				err:         errors.New("required flag(s) \"file\" not set"),
 			},
 			{
 				name:        "`role` edit without host should throw error host not found",
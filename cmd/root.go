@@ -52,12 +52,17 @@ func New(cliConfig *Config) *cli.Command {
 	cmd.AddCommand(configCommand())
 	cmd.AddCommand(versionCommand())
 	cmd.AddCommand(PreferencesCommand(cliConfig))
+	cmd.AddCommand(AuditCommand())
+	cmd.AddCommand(OAuth2Command(cliConfig))
+	cmd.AddCommand(SAMLCommand(cliConfig))
+	cmd.AddCommand(IDPCommand(cliConfig))
+	cmd.AddCommand(SessionCommand(cliConfig))
+	cmd.AddCommand(AuthCommand(cliConfig))
 
 	// Help topics
 	cmdx.SetHelp(cmd)
 	cmd.AddCommand(cmdx.SetCompletionCmd("frontier"))
 	cmd.AddCommand(cmdx.SetHelpTopicCmd("environment", envHelp))
-	cmd.AddCommand(cmdx.SetHelpTopicCmd("auth", authHelp))
 	cmd.AddCommand(cmdx.SetRefCmd(cmd))
 	return cmd
 }
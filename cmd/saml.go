@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/raystack/frontier/core/saml"
+	"github.com/raystack/salt/printer"
+	cli "github.com/spf13/cobra"
+)
+
+const (
+	samlConnectionPath  = "/v1beta1/organizations/%s/saml"
+	samlConnectionsPath = "/admin/saml/connections"
+)
+
+// samlConnection is the connection as returned by the server
+type samlConnection struct {
+	ID          string `json:"id"`
+	OrgID       string `json:"org_id"`
+	EntityID    string `json:"entity_id"`
+	MetadataURL string `json:"metadata_url"`
+}
+
+func SAMLCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "saml",
+		Short: "SAML connection management",
+		Long: heredoc.Doc(`
+			Work with the saml identity providers organizations log in with.
+
+			Connections are managed by the server on behalf of the user logged in with
+			"frontier auth login", who must be allowed to update the organization.
+			Changes are recorded in the audit logs of the organization.
+		`),
+		Example: heredoc.Doc(`
+			$ frontier saml connection create --org acme --metadata-url https://idp.acme.org/metadata
+			$ frontier saml connection list
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+	}
+
+	connectionCmd := &cli.Command{
+		Use:   "connection",
+		Short: "Manage saml connections",
+	}
+	connectionCmd.AddCommand(samlCreateConnectionCommand(cliConfig))
+	connectionCmd.AddCommand(samlListConnectionCommand(cliConfig))
+	connectionCmd.AddCommand(samlDeleteConnectionCommand(cliConfig))
+	cmd.AddCommand(connectionCmd)
+	return cmd
+}
+
+func samlCreateConnectionCommand(cliConfig *Config) *cli.Command {
+	var orgID, metadataFile, metadataURL string
+	var mapping saml.AttributeMapping
+
+	cmd := &cli.Command{
+		Use:   "create",
+		Short: "Register the saml identity provider of an organization",
+		Long: heredoc.Doc(`
+			Register the identity provider of an organization from its metadata, either
+			a file or an url it is fetched from. Members log in at /saml/<org-id>/login
+			and the identity provider is configured with the service provider metadata
+			served at /saml/<org-id>/metadata.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier saml connection create --org acme --metadata-file ./idp.xml
+			$ frontier saml connection create --org acme --metadata-url https://idp.acme.org/metadata --email-attribute upn
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if (metadataFile == "") == (metadataURL == "") {
+				return fmt.Errorf("either --metadata-file or --metadata-url is required")
+			}
+			req := struct {
+				Metadata         string                `json:"metadata,omitempty"`
+				MetadataURL      string                `json:"metadata_url,omitempty"`
+				AttributeMapping saml.AttributeMapping `json:"attribute_mapping"`
+			}{
+				MetadataURL:      metadataURL,
+				AttributeMapping: mapping,
+			}
+			if metadataFile != "" {
+				data, err := os.ReadFile(metadataFile)
+				if err != nil {
+					return err
+				}
+				req.Metadata = string(data)
+			}
+
+			var connection samlConnection
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodPost,
+				fmt.Sprintf(samlConnectionPath, url.PathEscape(orgID)), req, &connection, http.StatusCreated); err != nil {
+				return err
+			}
+			report := [][]string{{"ID", "ORG ID", "ENTITY ID"}, {connection.ID, connection.OrgID, connection.EntityID}}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&orgID, "org", "", "id or name of the organization")
+	cmd.Flags().StringVar(&metadataFile, "metadata-file", "", "path of the identity provider metadata")
+	cmd.Flags().StringVar(&metadataURL, "metadata-url", "", "https url the identity provider metadata is fetched from")
+	cmd.Flags().StringVar(&mapping.Email, "email-attribute", "", "assertion attribute holding the user email")
+	cmd.Flags().StringVar(&mapping.Name, "name-attribute", "", "assertion attribute holding the user name")
+	cmd.Flags().StringVar(&mapping.Avatar, "avatar-attribute", "", "assertion attribute holding the user avatar url")
+	cmd.MarkFlagRequired("org")
+	return cmd
+}
+
+func samlListConnectionCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "list",
+		Short: "List saml connections",
+		Long: heredoc.Doc(`
+			List the connections of every organization, only superusers are allowed to.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier saml connection list
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			var resp struct {
+				Connections []samlConnection `json:"connections"`
+			}
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodGet, samlConnectionsPath, nil, &resp,
+				http.StatusOK); err != nil {
+				return err
+			}
+			report := [][]string{{"ID", "ORG ID", "ENTITY ID", "METADATA URL"}}
+			for _, c := range resp.Connections {
+				report = append(report, []string{c.ID, c.OrgID, c.EntityID, c.MetadataURL})
+			}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func samlDeleteConnectionCommand(cliConfig *Config) *cli.Command {
+	var orgID string
+
+	cmd := &cli.Command{
+		Use:   "delete",
+		Short: "Delete the saml connection of an organization",
+		Long: heredoc.Doc(`
+			Delete the connection of an organization, its members can't log in with the
+			identity provider anymore. Existing sessions stay valid until they expire.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier saml connection delete --org acme
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if err := doRequest(cmd.Context(), cliConfig, http.MethodDelete,
+				fmt.Sprintf(samlConnectionPath, url.PathEscape(orgID)), nil, nil, http.StatusNoContent); err != nil {
+				return err
+			}
+			fmt.Printf("deleted saml connection of organization %s\n", orgID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&orgID, "org", "", "id or name of the organization")
+	cmd.MarkFlagRequired("org")
+	return cmd
+}
@@ -63,7 +63,7 @@ func SeedCommand(cliConfig *Config) *cli.Command {
 			}
 			header := fmt.Sprintf("%s:%s", header, sampleSeedEmail)
 			ctx := setCtxHeader(cmd.Context(), header)
-			adminClient, cancel, err := createAdminClient(ctx, cliConfig.Host)
+			adminClient, cancel, err := createAdminClient(ctx, cliConfig)
 			if err != nil {
 				return err
 			}
@@ -73,7 +73,7 @@ func SeedCommand(cliConfig *Config) *cli.Command {
 				return fmt.Errorf("failed to create custom permissions: %w", err)
 			}
 
-			client, cancel, err := createClient(ctx, cliConfig.Host)
+			client, cancel, err := createClient(ctx, cliConfig)
 			if err != nil {
 				return err
 			}
@@ -60,6 +60,7 @@ import (
 
 	"github.com/raystack/frontier/pkg/server"
 
+	"github.com/raystack/frontier/core/impersonation"
 	"github.com/raystack/frontier/core/invitation"
 
 	"github.com/raystack/frontier/pkg/mailer"
@@ -72,20 +73,33 @@ import (
 	_ "github.com/authzed/authzed-go/proto/authzed/api/v0"
 	_ "github.com/jackc/pgx/v4/stdlib"
 	newrelic "github.com/newrelic/go-agent"
+	"github.com/raystack/frontier/core/accessrequest"
 	"github.com/raystack/frontier/core/authenticate"
 	"github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/authenticate/strategy"
 	"github.com/raystack/frontier/core/metaschema"
+	_ "gocloud.dev/pubsub/kafkapubsub"
+	_ "gocloud.dev/pubsub/mempubsub"
 
 	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/raystack/frontier/config"
+	"github.com/raystack/frontier/core/explain"
 	"github.com/raystack/frontier/core/group"
+	"github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/core/mfa"
 	"github.com/raystack/frontier/core/namespace"
+	"github.com/raystack/frontier/core/oauth2"
 	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/passkey"
 	"github.com/raystack/frontier/core/policy"
 	"github.com/raystack/frontier/core/project"
+	"github.com/raystack/frontier/core/ratelimit"
 	"github.com/raystack/frontier/core/relation"
 	"github.com/raystack/frontier/core/resource"
 	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/core/scim"
+	"github.com/raystack/frontier/core/simulation"
 	"github.com/raystack/frontier/core/user"
 	"github.com/raystack/frontier/internal/api"
 	"github.com/raystack/frontier/internal/store/blob"
@@ -243,6 +257,16 @@ func StartServer(logger *log.Zap, cfg *config.Frontier) error {
 		}
 	}()
 
+	if err := deps.RateLimiter.Init(ctx); err != nil {
+		logger.Warn("rate limiter initialization failed", "err", err)
+	}
+	defer func() {
+		logger.Debug("cleaning up rate limits")
+		if err := deps.RateLimiter.Close(); err != nil {
+			logger.Warn("rate limiter cleanup failed", "err", err)
+		}
+	}()
+
 	if cfg.Billing.StripeKey != "" {
 		// billing services initialization and cleanup
 		if err := deps.CustomerService.Init(ctx); err != nil {
@@ -286,6 +310,65 @@ func StartServer(logger *log.Zap, cfg *config.Frontier) error {
 		}()
 	}
 
+	if err := deps.WebhookService.Init(ctx); err != nil {
+		logger.Warn("webhook dispatcher initialization failed", "err", err)
+	}
+	defer func() {
+		logger.Debug("cleaning up webhooks")
+		if err := deps.WebhookService.Close(); err != nil {
+			logger.Warn("webhook dispatcher cleanup failed", "err", err)
+		}
+	}()
+
+	if err := deps.AuditRetention.Init(ctx); err != nil {
+		logger.Warn("audit retention initialization failed", "err", err)
+	}
+	defer func() {
+		logger.Debug("cleaning up audit retention")
+		if err := deps.AuditRetention.Close(); err != nil {
+			logger.Warn("audit retention cleanup failed", "err", err)
+		}
+	}()
+
+	if err := deps.PolicyReaper.Init(audit.SetContextWithService(ctx, deps.AuditService)); err != nil {
+		logger.Warn("policy reaper initialization failed", "err", err)
+	}
+	defer func() {
+		logger.Debug("cleaning up policy reaper")
+		if err := deps.PolicyReaper.Close(); err != nil {
+			logger.Warn("policy reaper cleanup failed", "err", err)
+		}
+	}()
+
+	if deps.CheckCache != nil {
+		if err := deps.CheckCache.Init(ctx); err != nil {
+			logger.Warn("check cache initialization failed", "err", err)
+		}
+		defer func() {
+			logger.Debug("cleaning up check cache")
+			if err := deps.CheckCache.Close(); err != nil {
+				logger.Warn("check cache cleanup failed", "err", err)
+			}
+		}()
+	}
+
+	if err := deps.OAuth2Service.Init(ctx); err != nil {
+		logger.Warn("oauth2 service initialization failed", "err", err)
+	}
+	defer func() {
+		logger.Debug("cleaning up oauth2 authorizations")
+		if err := deps.OAuth2Service.Close(); err != nil {
+			logger.Warn("oauth2 service cleanup failed", "err", err)
+		}
+	}()
+
+	defer func() {
+		logger.Debug("flushing audit sinks")
+		if err := deps.AuditService.Close(); err != nil {
+			logger.Warn("audit sinks cleanup failed", "err", err)
+		}
+	}()
+
 	go func() {
 		if err := deps.LogListener.Listen(ctx); err != nil {
 			logger.Warn("log listener failed", "err", err)
@@ -328,7 +411,7 @@ func buildAPIDependencies(
 		}
 	}
 	tokenService := token.NewService(tokenKeySet, cfg.App.Authentication.Token.Issuer,
-		cfg.App.Authentication.Token.Validity)
+		cfg.App.Authentication.Token.Validity, token.WithDenylist(postgres.NewTokenDenylistRepository(dbc)))
 	sessionService := session.NewService(logger, postgres.NewSessionRepository(logger, dbc), cfg.App.Authentication.Session.Validity)
 
 	namespaceRepository := postgres.NewNamespaceRepository(dbc)
@@ -345,7 +428,17 @@ func buildAPIDependencies(
 		spicedb.ConsistencyLevelMinimizeLatency}, consistencyLevel) {
 		return api.Deps{}, fmt.Errorf("invalid consistency level: %s", consistencyLevel)
 	}
-	authzRelationRepository := spicedb.NewRelationRepository(sdb, consistencyLevel, cfg.SpiceDB.CheckTrace)
+	var checkCache *spicedb.CheckCache
+	var relationRepositoryOptions []spicedb.RelationRepositoryOption
+	if cfg.SpiceDB.CheckCache.Enabled {
+		var err error
+		if checkCache, err = spicedb.NewCheckCache(logger, sdb, cfg.SpiceDB.CheckCache); err != nil {
+			return api.Deps{}, err
+		}
+		relationRepositoryOptions = append(relationRepositoryOptions, spicedb.WithCheckCache(checkCache))
+	}
+	authzRelationRepository := spicedb.NewRelationRepository(sdb, consistencyLevel, cfg.SpiceDB.CheckTrace,
+		relationRepositoryOptions...)
 
 	permissionRepository := postgres.NewPermissionRepository(dbc)
 	permissionService := permission.NewService(permissionRepository)
@@ -358,9 +451,14 @@ func buildAPIDependencies(
 
 	policyPGRepository := postgres.NewPolicyRepository(dbc)
 	policyService := policy.NewService(policyPGRepository, relationService, roleService)
+	policyReaper := policy.NewReaper(logger, policyService)
+	explainService := explain.NewService(relationService, policyService, roleService)
+	simulationService := simulation.NewService(spicedb.NewSandboxRepository(sdb), policyService, roleService, permissionService)
+	accessRequestService := accessrequest.NewService(postgres.NewAccessRequestRepository(dbc), relationService,
+		roleService, policyService, cfg.App.AccessRequest)
 
 	userRepository := postgres.NewUserRepository(dbc)
-	userService := user.NewService(userRepository, relationService, policyService, roleService)
+	userService := user.NewService(userRepository, relationService, policyService, roleService, sessionService)
 
 	svUserRepo := postgres.NewServiceUserRepository(dbc)
 	scUserCredRepo := postgres.NewServiceUserCredentialRepository(dbc)
@@ -392,8 +490,28 @@ func buildAPIDependencies(
 			return api.Deps{}, fmt.Errorf("failed to parse passkey config: %w", err)
 		}
 	}
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.App.Authentication.RateLimit.Store == "postgres" {
+		rateLimitStore = postgres.NewRateLimitRepository(dbc)
+	}
+	rateLimiter := ratelimit.NewLimiter(logger, rateLimitStore)
+	var breachedPasswords strategy.BreachChecker
+	if path := cfg.App.Authentication.Password.BreachedPasswordsFile; path != "" {
+		// kept open for the lifetime of the server
+		breachedPasswordsFile, err := strategy.OpenHashPrefixFile(path)
+		if err != nil {
+			return api.Deps{}, err
+		}
+		breachedPasswords = breachedPasswordsFile
+	}
+	passkeyRepository := postgres.NewPasskeyRepository(dbc)
+	passkeyService := passkey.NewService(passkeyRepository)
+	idpService := idp.NewService(
+		postgres.NewOIDCProviderRepository(dbc, []byte(cfg.App.Authentication.SSO.EncryptionKey)),
+		postgres.NewDomainRepository(logger, dbc), preferenceService)
 	authnService := authenticate.NewService(logger, cfg.App.Authentication,
-		postgres.NewFlowRepository(logger, dbc), mailDialer, tokenService, sessionService, userService, serviceUserService, webAuthConfig)
+		postgres.NewFlowRepository(logger, dbc), mailDialer, tokenService, sessionService, userService, serviceUserService, webAuthConfig,
+		rateLimiter, postgres.NewUserPasswordRepository(dbc), breachedPasswords, passkeyRepository, idpService)
 
 	groupRepository := postgres.NewGroupRepository(dbc)
 	groupService := group.NewService(groupRepository, relationService, authnService, policyService)
@@ -496,53 +614,147 @@ func buildAPIDependencies(
 	default:
 		auditRepository = audit.NewNoopRepository()
 	}
+	if len(cfg.Log.AuditSinks) > 0 {
+		auditSinks, err := audit.NewSinks(context.Background(), logger, cfg.Log.AuditSinks)
+		if err != nil {
+			return api.Deps{}, err
+		}
+		auditRepository = audit.NewFanOutRepository(auditRepository, auditSinks...)
+	}
 	eventProcessor := event.NewService(cfg.Billing, organizationService, checkoutService, customerService,
 		planService, userService, subscriptionService, creditService, invoiceService)
 	eventChannel := make(chan audit.Log, 10) // buffered channel to avoid blocking the event processor
 	logPublisher := event.NewChanPublisher(eventChannel)
 	logListener := event.NewChanListener(eventChannel, eventProcessor)
 
-	webhookService := webhook.NewService(postgres.NewWebhookEndpointRepository(dbc, []byte(cfg.App.Webhook.EncryptionKey)))
+	webhookService := webhook.NewService(
+		postgres.NewWebhookEndpointRepository(dbc, []byte(cfg.App.Webhook.EncryptionKey)),
+		postgres.NewWebhookDeliveryRepository(dbc),
+		cfg.App.Webhook,
+	)
 	auditService := audit.NewService("frontier",
 		auditRepository, webhookService,
 		audit.WithLogPublisher(logPublisher),
 		audit.WithIgnoreList(cfg.Log.IgnoredAuditEvents),
 	)
 
+	if cfg.Log.AuditRetention.Enabled && cfg.Log.AuditRetention.ArchivePath == "" {
+		return api.Deps{}, errors.New("audit retention requires an archive path")
+	}
+	auditArchiveFS, err := blob.NewStore(context.Background(), cfg.Log.AuditRetention.ArchivePath, cfg.Log.AuditRetention.ArchivePathSecret)
+	if err != nil {
+		return api.Deps{}, fmt.Errorf("failed to open audit archive: %w", err)
+	}
+	auditRetentionService := audit.NewRetentionService(logger,
+		postgres.NewAuditRetentionRepository(dbc),
+		blob.NewAuditArchiveRepository(auditArchiveFS),
+		cfg.Log.AuditRetention,
+	)
+
+	oauth2Service := oauth2.NewService(logger,
+		postgres.NewOAuth2ClientRepository(dbc),
+		postgres.NewOAuth2AuthorizationRepository(dbc),
+		postgres.NewOAuth2ConsentRepository(dbc),
+		postgres.NewOAuth2RefreshTokenRepository(dbc),
+		postgres.NewOAuth2DeviceRepository(dbc),
+		userService,
+		organizationService,
+		sessionService,
+		authnService,
+		tokenService,
+		cfg.App.Authentication,
+	)
+	// ending a session revokes the refresh tokens bound to it
+	sessionService.TokenRevoker = oauth2Service
+
+	samlService := saml.NewService(
+		postgres.NewSAMLConnectionRepository(dbc),
+		postgres.NewSAMLAssertionRepository(dbc),
+		postgres.NewFlowRepository(logger, dbc),
+		organizationService,
+		userService,
+		domainService,
+		cfg.App.Authentication.SAML,
+	)
+
+	scimService := scim.NewService(
+		postgres.NewSCIMProfileRepository(dbc),
+		organizationService,
+		userService,
+		groupService,
+		cascadeDeleter,
+		serviceUserService,
+		resourceService,
+		domainService,
+	)
+
+	mfaService := mfa.NewService(
+		postgres.NewMFATOTPRepository(dbc, []byte(cfg.App.Authentication.MFA.EncryptionKey)),
+		userService,
+		organizationService,
+		preferenceService,
+		sessionService,
+		cfg.App.Authentication.MFA,
+	)
+
+	impersonationService := impersonation.NewService(
+		userService,
+		serviceUserService,
+		organizationService,
+		preferenceService,
+		sessionService,
+		authnService,
+		cfg.App.Authentication.Impersonation,
+	)
+
 	dependencies := api.Deps{
-		OrgService:          organizationService,
-		OrgKycService:       orgKycService,
-		ProjectService:      projectService,
-		GroupService:        groupService,
-		RoleService:         roleService,
-		PolicyService:       policyService,
-		UserService:         userService,
-		NamespaceService:    namespaceService,
-		PermissionService:   permissionService,
-		RelationService:     relationService,
-		ResourceService:     resourceService,
-		SessionService:      sessionService,
-		AuthnService:        authnService,
-		DeleterService:      cascadeDeleter,
-		MetaSchemaService:   metaschemaService,
-		BootstrapService:    bootstrapService,
-		InvitationService:   invitationService,
-		ServiceUserService:  serviceUserService,
-		AuditService:        auditService,
-		DomainService:       domainService,
-		PreferenceService:   preferenceService,
-		CustomerService:     customerService,
-		SubscriptionService: subscriptionService,
-		ProductService:      productService,
-		PlanService:         planService,
-		EntitlementService:  entitlementService,
-		CheckoutService:     checkoutService,
-		CreditService:       creditService,
-		UsageService:        usageService,
-		InvoiceService:      invoiceService,
-		LogListener:         logListener,
-		WebhookService:      webhookService,
-		EventService:        eventProcessor,
+		OrgService:           organizationService,
+		OrgKycService:        orgKycService,
+		ProjectService:       projectService,
+		GroupService:         groupService,
+		RoleService:          roleService,
+		PolicyService:        policyService,
+		UserService:          userService,
+		NamespaceService:     namespaceService,
+		PermissionService:    permissionService,
+		RelationService:      relationService,
+		ResourceService:      resourceService,
+		SessionService:       sessionService,
+		AuthnService:         authnService,
+		RateLimiter:          rateLimiter,
+		OAuth2Service:        oauth2Service,
+		SAMLService:          samlService,
+		IDPService:           idpService,
+		SCIMService:          scimService,
+		MFAService:           mfaService,
+		PasskeyService:       passkeyService,
+		ImpersonationService: impersonationService,
+		DeleterService:       cascadeDeleter,
+		MetaSchemaService:    metaschemaService,
+		BootstrapService:     bootstrapService,
+		InvitationService:    invitationService,
+		ServiceUserService:   serviceUserService,
+		AuditService:         auditService,
+		AuditRetention:       auditRetentionService,
+		PolicyReaper:         policyReaper,
+		CheckCache:           checkCache,
+		AccessRequestService: accessRequestService,
+		ExplainService:       explainService,
+		SimulationService:    simulationService,
+		DomainService:        domainService,
+		PreferenceService:    preferenceService,
+		CustomerService:      customerService,
+		SubscriptionService:  subscriptionService,
+		ProductService:       productService,
+		PlanService:          planService,
+		EntitlementService:   entitlementService,
+		CheckoutService:      checkoutService,
+		CreditService:        creditService,
+		UsageService:         usageService,
+		InvoiceService:       invoiceService,
+		LogListener:          logListener,
+		WebhookService:       webhookService,
+		EventService:         eventProcessor,
 	}
 	return dependencies, nil
 }
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/raystack/frontier/config"
+	"github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/store/postgres"
+	"github.com/raystack/frontier/pkg/db"
+	"github.com/raystack/salt/log"
+	"github.com/raystack/salt/printer"
+	cli "github.com/spf13/cobra"
+)
+
+const revokeSessionsPath = "/admin/users/%s/sessions"
+
+func SessionCommand(cliConfig *Config) *cli.Command {
+	cmd := &cli.Command{
+		Use:   "session",
+		Short: "User session management",
+		Long: heredoc.Doc(`
+			Work with the sessions users are logged in with.
+		`),
+		Example: heredoc.Doc(`
+			$ frontier session list --user john@example.com -c ./config.yaml
+			$ frontier session revoke --user john@example.com
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+	}
+
+	cmd.AddCommand(sessionListCommand())
+	cmd.AddCommand(sessionRevokeCommand(cliConfig))
+	return cmd
+}
+
+// sessionServices connects to the database configured for the server, users
+// are looked up to accept their email or name along with their id
+func sessionServices(configFile string) (*session.Service, *user.Service, func(), error) {
+	appConfig, err := config.Load(configFile)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	dbc, err := db.New(appConfig.DB)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to connect to db: %w", err)
+	}
+	logger := log.NewNoop()
+	sessionService := session.NewService(logger, postgres.NewSessionRepository(logger, dbc),
+		appConfig.App.Authentication.Session.Validity)
+	userService := user.NewService(postgres.NewUserRepository(dbc), nil, nil, nil, sessionService)
+	return sessionService, userService, func() { dbc.Close() }, nil
+}
+
+func sessionListCommand() *cli.Command {
+	var configFile, userID string
+
+	cmd := &cli.Command{
+		Use:   "list",
+		Short: "List the sessions of a user",
+		Args:  cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier session list --user john@example.com -c ./config.yaml
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			sessionService, userService, closeFn, err := sessionServices(configFile)
+			if err != nil {
+				return err
+			}
+			defer closeFn()
+
+			sessionUser, err := userService.GetByID(cmd.Context(), userID)
+			if err != nil {
+				return err
+			}
+			sessions, err := sessionService.ListByUser(cmd.Context(), sessionUser.ID)
+			if err != nil {
+				return err
+			}
+
+			report := [][]string{{"ID", "LABEL", "STATE", "AUTH METHOD", "IP ADDRESS", "USER AGENT", "LAST SEEN", "EXPIRES"}}
+			for _, s := range sessions {
+				lastSeenAt := s.LastSeenAt
+				if lastSeenAt.IsZero() {
+					lastSeenAt = s.AuthenticatedAt
+				}
+				report = append(report, []string{s.ID.String(), s.Label(), string(s.State), s.AuthMethod(), s.IPAddress(),
+					s.UserAgent(), lastSeenAt.Format(time.RFC3339), s.ExpiresAt.Format(time.RFC3339)})
+			}
+			printer.Table(os.Stdout, report)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&configFile, "config", "c", "", "config file path")
+	cmd.Flags().StringVar(&userID, "user", "", "id, email or name of the user")
+	cmd.MarkFlagRequired("user")
+	return cmd
+}
+
+func sessionRevokeCommand(cliConfig *Config) *cli.Command {
+	var userID string
+
+	cmd := &cli.Command{
+		Use:   "revoke",
+		Short: "Revoke all the sessions of a user",
+		Long: heredoc.Doc(`
+			Log a user out everywhere by deleting all of its sessions. Access tokens
+			already issued stay valid until they expire.
+
+			The sessions are revoked by the server on behalf of the superuser logged
+			in with "frontier auth login", and recorded in the audit logs.
+		`),
+		Args: cli.NoArgs,
+		Example: heredoc.Doc(`
+			$ frontier session revoke --user john@example.com
+		`),
+		Annotations: map[string]string{
+			"group": "core",
+		},
+		RunE: func(cmd *cli.Command, args []string) error {
+			if cliConfig == nil || cliConfig.Auth.URL == "" {
+				return ErrClientAuthNotConfigured
+			}
+			if err := revokeSessions(cmd.Context(), cliConfig, userID); err != nil {
+				return err
+			}
+			fmt.Printf("revoked sessions of user %s\n", userID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&userID, "user", "", "id, email or name of the user")
+	cmd.MarkFlagRequired("user")
+	return cmd
+}
+
+// revokeSessions asks the server to delete all the sessions of a user with
+// the access token of the logged in superuser
+func revokeSessions(ctx context.Context, cliConfig *Config, userID string) error {
+	token, err := accessToken(ctx, cliConfig)
+	if err != nil {
+		return err
+	}
+	if token == "" {
+		return ErrClientNotLoggedIn
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	endpoint := cliConfig.Auth.URL + fmt.Sprintf(revokeSessionsPath, url.PathEscape(userID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		var body struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil || body.Message == "" {
+			return fmt.Errorf("unexpected response from %s: %s", endpoint, resp.Status)
+		}
+		return fmt.Errorf("failed to revoke the sessions: %s", body.Message)
+	}
+	return nil
+}
@@ -75,7 +75,7 @@ func createUserCommand(cliConfig *Config) *cli.Command {
 			}
 
 			ctx := context.Background()
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -97,7 +97,6 @@ func createUserCommand(cliConfig *Config) *cli.Command {
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the user body file")
 	cmd.MarkFlagRequired("file")
 	cmd.Flags().StringVarP(&header, "header", "H", "", "Header <key>:<value>")
-	cmd.MarkFlagRequired("header")
 
 	return cmd
 }
@@ -131,7 +130,7 @@ func editUserCommand(cliConfig *Config) *cli.Command {
 			}
 
 			ctx := context.Background()
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -177,7 +176,7 @@ func viewUserCommand(cliConfig *Config) *cli.Command {
 			defer spinner.Stop()
 
 			ctx := context.Background()
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -243,7 +242,7 @@ func listUserCommand(cliConfig *Config) *cli.Command {
 			defer spinner.Stop()
 
 			ctx := context.Background()
-			client, cancel, err := createClient(cmd.Context(), cliConfig.Host)
+			client, cancel, err := createClient(cmd.Context(), cliConfig)
 			if err != nil {
 				return err
 			}
@@ -39,6 +39,10 @@ func TestLoad(t *testing.T) {
 					Port:         "50051",
 					PreSharedKey: "randomkey",
 					Consistency:  spicedb.ConsistencyLevelBestEffort.String(),
+					CheckCache: spicedb.CheckCacheConfig{
+						Size: 100000,
+						TTL:  30 * time.Second,
+					},
 				},
 			},
 			wantErr: false,
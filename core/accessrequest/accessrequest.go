@@ -0,0 +1,59 @@
+package accessrequest
+
+import (
+	"context"
+	"time"
+)
+
+type State string
+
+func (s State) String() string {
+	return string(s)
+}
+
+const (
+	StatePending   State = "pending"
+	StateApproved  State = "approved"
+	StateDenied    State = "denied"
+	StateCancelled State = "cancelled"
+)
+
+type Repository interface {
+	Create(ctx context.Context, request Request) (Request, error)
+	Get(ctx context.Context, id string) (Request, error)
+	List(ctx context.Context, flt Filter) ([]Request, error)
+	// UpdateState stores the review of a pending request, it fails with
+	// ErrNotPending if the request was reviewed in the meantime
+	UpdateState(ctx context.Context, request Request) (Request, error)
+}
+
+// Request is a principal asking for a role on a resource for a limited time,
+// once approved the role is granted by a policy expiring after Duration
+type Request struct {
+	ID            string
+	RoleID        string
+	ResourceID    string
+	ResourceType  string
+	PrincipalID   string
+	PrincipalType string
+	Justification string
+	Duration      time.Duration
+	State         State
+
+	ReviewerID string
+	ReviewNote string
+	ReviewedAt time.Time
+	// PolicyID is the policy granting the role of an approved request
+	PolicyID string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Filter struct {
+	ResourceID    string
+	ResourceType  string
+	PrincipalID   string
+	PrincipalType string
+	State         State
+}
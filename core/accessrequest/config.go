@@ -0,0 +1,12 @@
+package accessrequest
+
+import "time"
+
+type Config struct {
+	// ApproverPermission is the permission on the organization or project of a
+	// request its approvers are looked up with, requests on groups are
+	// reviewed by the admins of the group
+	ApproverPermission string `yaml:"approver_permission" mapstructure:"approver_permission" default:"policymanage"`
+	// MaxDuration caps how long access can be requested for
+	MaxDuration time.Duration `yaml:"max_duration" mapstructure:"max_duration" default:"24h"`
+}
@@ -0,0 +1,15 @@
+package accessrequest
+
+import "errors"
+
+var (
+	ErrNotExist              = errors.New("access request doesn't exist")
+	ErrInvalidDetail         = errors.New("invalid access request detail")
+	ErrJustificationRequired = errors.New("justification of the access request is required")
+	ErrInvalidDuration       = errors.New("duration of the access request is out of range")
+	ErrNoApprover            = errors.New("no one can approve access requests on the resource")
+	ErrNotApprover           = errors.New("principal can't review the access request")
+	ErrNotRequester          = errors.New("only the requester can cancel the access request")
+	ErrNotPending            = errors.New("access request is already reviewed")
+	ErrAlreadyGranted        = errors.New("principal already holds the role on the resource")
+)
@@ -0,0 +1,330 @@
+package accessrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/group"
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/metadata"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	maxJustificationLength = 1024
+	minDuration            = time.Minute
+)
+
+type RelationService interface {
+	LookupSubjects(ctx context.Context, rel relation.Relation) ([]string, error)
+}
+
+type RoleService interface {
+	Get(ctx context.Context, id string) (role.Role, error)
+}
+
+type PolicyService interface {
+	Create(ctx context.Context, policy policy.Policy) (policy.Policy, error)
+	List(ctx context.Context, flt policy.Filter) ([]policy.Policy, error)
+	Delete(ctx context.Context, id string) error
+	OrgID(ctx context.Context, pol policy.Policy) (string, error)
+}
+
+type Service struct {
+	repository      Repository
+	relationService RelationService
+	roleService     RoleService
+	policyService   PolicyService
+	config          Config
+	Now             func() time.Time
+}
+
+func NewService(repository Repository, relationService RelationService, roleService RoleService,
+	policyService PolicyService, config Config) *Service {
+	return &Service{
+		repository:      repository,
+		relationService: relationService,
+		roleService:     roleService,
+		policyService:   policyService,
+		config:          config,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (s Service) Get(ctx context.Context, id string) (Request, error) {
+	return s.repository.Get(ctx, id)
+}
+
+func (s Service) List(ctx context.Context, flt Filter) ([]Request, error) {
+	return s.repository.List(ctx, flt)
+}
+
+// Create files a pending request of the principal, it is rejected if no one
+// but the requester could approve it
+func (s Service) Create(ctx context.Context, request Request) (Request, error) {
+	request.Justification = strings.TrimSpace(request.Justification)
+	if request.Justification == "" {
+		return Request{}, ErrJustificationRequired
+	}
+	if len(request.Justification) > maxJustificationLength {
+		return Request{}, fmt.Errorf("%w: justification is longer than %d characters", ErrInvalidDetail, maxJustificationLength)
+	}
+	if request.Duration < minDuration || request.Duration > s.config.MaxDuration {
+		return Request{}, fmt.Errorf("%w: must be between %s and %s", ErrInvalidDuration, minDuration, s.config.MaxDuration)
+	}
+	if request.ResourceID == "" || request.PrincipalID == "" || request.PrincipalType == "" {
+		return Request{}, fmt.Errorf("%w: resource and principal are required", ErrInvalidDetail)
+	}
+	switch request.ResourceType {
+	case schema.OrganizationNamespace, schema.ProjectNamespace, schema.GroupNamespace:
+	default:
+		return Request{}, fmt.Errorf("%w: access can only be requested on organizations, projects and groups", ErrInvalidDetail)
+	}
+
+	// check if role exists and get its ID if it was passed by name
+	requestedRole, err := s.roleService.Get(ctx, request.RoleID)
+	if err != nil {
+		return Request{}, err
+	}
+	request.RoleID = requestedRole.ID
+	if err := s.checkNotGranted(ctx, request); err != nil {
+		return Request{}, err
+	}
+
+	approvers, err := s.Approvers(ctx, request)
+	if err != nil {
+		return Request{}, err
+	}
+	if len(approvers) == 0 {
+		return Request{}, ErrNoApprover
+	}
+
+	request.State = StatePending
+	created, err := s.repository.Create(ctx, request)
+	if err != nil {
+		return Request{}, err
+	}
+	s.audit(ctx, audit.AccessRequestCreatedEvent, created, map[string]string{
+		"justification": created.Justification,
+		"duration":      created.Duration.String(),
+	})
+	return created, nil
+}
+
+// Approvers returns the ids of the users who can review the request, they
+// have the approver permission of its resource. Requesters never review their
+// own requests.
+func (s Service) Approvers(ctx context.Context, request Request) ([]string, error) {
+	userIDs, err := s.relationService.LookupSubjects(ctx, relation.Relation{
+		Object: relation.Object{
+			ID:        request.ResourceID,
+			Namespace: request.ResourceType,
+		},
+		Subject: relation.Subject{
+			Namespace: schema.UserPrincipal,
+		},
+		RelationName: s.ApproverPermission(request.ResourceType),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(userIDs, func(id string) bool {
+		return request.PrincipalType == schema.UserPrincipal && id == request.PrincipalID
+	}), nil
+}
+
+// ApproverPermission returns the permission the approvers of requests hold on
+// resources of the namespace, groups have no policymanage permission
+func (s Service) ApproverPermission(namespace string) string {
+	if namespace == schema.GroupNamespace {
+		return group.AdminPermission
+	}
+	return s.config.ApproverPermission
+}
+
+// CanReview tells if the user can review the access requests on a resource
+func (s Service) CanReview(ctx context.Context, object relation.Object, userID string) (bool, error) {
+	approvers, err := s.Approvers(ctx, Request{
+		ResourceID:   object.ID,
+		ResourceType: object.Namespace,
+	})
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(approvers, userID), nil
+}
+
+// Approve grants the requested role with a policy expiring once the
+// requested duration elapsed
+func (s Service) Approve(ctx context.Context, id, reviewerID, note string) (Request, error) {
+	request, err := s.reviewable(ctx, id, reviewerID)
+	if err != nil {
+		return Request{}, err
+	}
+
+	if err := s.checkNotGranted(ctx, request); err != nil {
+		return Request{}, err
+	}
+
+	now := s.Now()
+	grant, err := s.policyService.Create(ctx, policy.Policy{
+		RoleID:        request.RoleID,
+		ResourceID:    request.ResourceID,
+		ResourceType:  request.ResourceType,
+		PrincipalID:   request.PrincipalID,
+		PrincipalType: request.PrincipalType,
+		Metadata: metadata.Metadata{
+			"access_request_id": request.ID,
+		},
+		ExpiresAt: now.Add(request.Duration),
+	})
+	if err != nil {
+		return Request{}, err
+	}
+
+	request.State = StateApproved
+	request.ReviewerID = reviewerID
+	request.ReviewNote = strings.TrimSpace(note)
+	request.ReviewedAt = now
+	request.PolicyID = grant.ID
+	approved, err := s.repository.UpdateState(ctx, request)
+	if err != nil {
+		// the request was reviewed concurrently, the role must only be
+		// granted by the review which was stored
+		if deleteErr := s.policyService.Delete(ctx, grant.ID); deleteErr != nil {
+			return Request{}, fmt.Errorf("%w: policy %s wasn't revoked: %w", err, grant.ID, deleteErr)
+		}
+		return Request{}, err
+	}
+	s.audit(ctx, audit.AccessRequestApprovedEvent, approved, map[string]string{
+		"policy_id":  approved.PolicyID,
+		"expires_at": grant.ExpiresAt.Format(time.RFC3339),
+		"note":       approved.ReviewNote,
+	})
+	return approved, nil
+}
+
+// Deny closes the request without granting the role
+func (s Service) Deny(ctx context.Context, id, reviewerID, note string) (Request, error) {
+	request, err := s.reviewable(ctx, id, reviewerID)
+	if err != nil {
+		return Request{}, err
+	}
+
+	request.State = StateDenied
+	request.ReviewerID = reviewerID
+	request.ReviewNote = strings.TrimSpace(note)
+	request.ReviewedAt = s.Now()
+	denied, err := s.repository.UpdateState(ctx, request)
+	if err != nil {
+		return Request{}, err
+	}
+	s.audit(ctx, audit.AccessRequestDeniedEvent, denied, map[string]string{
+		"note": denied.ReviewNote,
+	})
+	return denied, nil
+}
+
+// Cancel withdraws a pending request, only its requester can cancel it
+func (s Service) Cancel(ctx context.Context, id, principalID string) (Request, error) {
+	request, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return Request{}, err
+	}
+	if request.PrincipalID != principalID {
+		return Request{}, ErrNotRequester
+	}
+	if request.State != StatePending {
+		return Request{}, ErrNotPending
+	}
+
+	request.State = StateCancelled
+	request.ReviewedAt = s.Now()
+	cancelled, err := s.repository.UpdateState(ctx, request)
+	if err != nil {
+		return Request{}, err
+	}
+	s.audit(ctx, audit.AccessRequestCancelledEvent, cancelled, nil)
+	return cancelled, nil
+}
+
+// reviewable returns the request if it is pending and the reviewer is one of
+// its approvers
+func (s Service) reviewable(ctx context.Context, id, reviewerID string) (Request, error) {
+	request, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return Request{}, err
+	}
+	if request.State != StatePending {
+		return Request{}, ErrNotPending
+	}
+	approvers, err := s.Approvers(ctx, request)
+	if err != nil {
+		return Request{}, err
+	}
+	if !slices.Contains(approvers, reviewerID) {
+		return Request{}, ErrNotApprover
+	}
+	return request, nil
+}
+
+// checkNotGranted fails if the principal holds the role on the resource
+// without an expiry, approving the request would make the grant expire
+func (s Service) checkNotGranted(ctx context.Context, request Request) error {
+	flt := policy.Filter{
+		RoleID:        request.RoleID,
+		PrincipalID:   request.PrincipalID,
+		PrincipalType: request.PrincipalType,
+	}
+	switch request.ResourceType {
+	case schema.OrganizationNamespace:
+		flt.OrgID = request.ResourceID
+	case schema.ProjectNamespace:
+		flt.ProjectID = request.ResourceID
+	case schema.GroupNamespace:
+		flt.GroupID = request.ResourceID
+	}
+	policies, err := s.policyService.List(ctx, flt)
+	if err != nil {
+		return err
+	}
+	for _, p := range policies {
+		if p.ExpiresAt.IsZero() {
+			return ErrAlreadyGranted
+		}
+	}
+	return nil
+}
+
+// audit logs the transition of the request on the organization owning its
+// resource, where its approvers look for it
+func (s Service) audit(ctx context.Context, event audit.EventName, request Request, attrs map[string]string) {
+	orgID, err := s.policyService.OrgID(ctx, policy.Policy{
+		ResourceID:   request.ResourceID,
+		ResourceType: request.ResourceType,
+	})
+	if err != nil {
+		// requests of resources no org owns anymore are logged on the platform org
+		orgID = schema.PlatformOrgID.String()
+	}
+	if attrs == nil {
+		attrs = map[string]string{}
+	}
+	attrs["request_id"] = request.ID
+	attrs["role_id"] = request.RoleID
+	attrs["principal_id"] = request.PrincipalID
+	attrs["principal_type"] = request.PrincipalType
+	_ = audit.GetAuditor(ctx, orgID).
+		LogWithAttrs(event, audit.Target{
+			ID:   request.ResourceID,
+			Type: request.ResourceType,
+		}, attrs)
+}
@@ -0,0 +1,394 @@
+package accessrequest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/authzed/spicedb/pkg/development"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/raystack/frontier/core/accessrequest"
+	"github.com/raystack/frontier/core/accessrequest/mocks"
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	now    = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	config = accessrequest.Config{
+		ApproverPermission: schema.PolicyManagePermission,
+		MaxDuration:        24 * time.Hour,
+	}
+	pending = accessrequest.Request{
+		ID:            "request-id",
+		RoleID:        "role-id",
+		ResourceID:    "project-id",
+		ResourceType:  schema.ProjectNamespace,
+		PrincipalID:   "requester-id",
+		PrincipalType: schema.UserPrincipal,
+		Justification: "investigate the incident",
+		Duration:      4 * time.Hour,
+		State:         accessrequest.StatePending,
+	}
+	approverLookup = relation.Relation{
+		Object:       relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace},
+		Subject:      relation.Subject{Namespace: schema.UserPrincipal},
+		RelationName: schema.PolicyManagePermission,
+	}
+	// resource of the pending request whose owning org is looked up for audit logs
+	projectResource = policy.Policy{ResourceID: "project-id", ResourceType: schema.ProjectNamespace}
+	grantLookup     = policy.Filter{
+		ProjectID:     "project-id",
+		RoleID:        "role-id",
+		PrincipalID:   "requester-id",
+		PrincipalType: schema.UserPrincipal,
+	}
+)
+
+type serviceMocks struct {
+	repo      *mocks.Repository
+	relations *mocks.RelationService
+	roles     *mocks.RoleService
+	policies  *mocks.PolicyService
+}
+
+func newTestService(t *testing.T) (*accessrequest.Service, serviceMocks) {
+	m := serviceMocks{
+		repo:      mocks.NewRepository(t),
+		relations: mocks.NewRelationService(t),
+		roles:     mocks.NewRoleService(t),
+		policies:  mocks.NewPolicyService(t),
+	}
+	s := accessrequest.NewService(m.repo, m.relations, m.roles, m.policies, config)
+	s.Now = func() time.Time { return now }
+	return s, m
+}
+
+func TestService_Create(t *testing.T) {
+	toCreate := accessrequest.Request{
+		RoleID:        "app_project_manager",
+		ResourceID:    "project-id",
+		ResourceType:  schema.ProjectNamespace,
+		PrincipalID:   "requester-id",
+		PrincipalType: schema.UserPrincipal,
+		Justification: "  investigate the incident ",
+		Duration:      4 * time.Hour,
+	}
+
+	tests := []struct {
+		name    string
+		request func(r accessrequest.Request) accessrequest.Request
+		setup   func(m serviceMocks)
+		want    accessrequest.Request
+		wantErr error
+	}{
+		{
+			name: "should file a pending request",
+			setup: func(m serviceMocks) {
+				m.roles.EXPECT().Get(mock.Anything, "app_project_manager").Return(role.Role{ID: "role-id"}, nil)
+				m.policies.EXPECT().List(mock.Anything, grantLookup).Return(nil, nil)
+				m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"requester-id", "approver-id"}, nil)
+				request := pending
+				request.ID = ""
+				m.repo.EXPECT().Create(mock.Anything, request).Return(pending, nil)
+				m.policies.EXPECT().OrgID(mock.Anything, projectResource).Return("org-id", nil)
+			},
+			want: pending,
+		},
+		{
+			name: "should reject requests without justification",
+			request: func(r accessrequest.Request) accessrequest.Request {
+				r.Justification = "  "
+				return r
+			},
+			wantErr: accessrequest.ErrJustificationRequired,
+		},
+		{
+			name: "should reject requests longer than the max duration",
+			request: func(r accessrequest.Request) accessrequest.Request {
+				r.Duration = 25 * time.Hour
+				return r
+			},
+			wantErr: accessrequest.ErrInvalidDuration,
+		},
+		{
+			name: "should reject requests on other resources than organizations, projects and groups",
+			request: func(r accessrequest.Request) accessrequest.Request {
+				r.ResourceType = "compute/instance"
+				return r
+			},
+			wantErr: accessrequest.ErrInvalidDetail,
+		},
+		{
+			name: "should reject requests for a role the principal holds without expiry",
+			setup: func(m serviceMocks) {
+				m.roles.EXPECT().Get(mock.Anything, "app_project_manager").Return(role.Role{ID: "role-id"}, nil)
+				m.policies.EXPECT().List(mock.Anything, grantLookup).Return([]policy.Policy{{ID: "policy-id"}}, nil)
+			},
+			wantErr: accessrequest.ErrAlreadyGranted,
+		},
+		{
+			name: "should reject requests only the requester could approve",
+			setup: func(m serviceMocks) {
+				m.roles.EXPECT().Get(mock.Anything, "app_project_manager").Return(role.Role{ID: "role-id"}, nil)
+				m.policies.EXPECT().List(mock.Anything, grantLookup).Return(nil, nil)
+				m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"requester-id"}, nil)
+			},
+			wantErr: accessrequest.ErrNoApprover,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			request := toCreate
+			if tt.request != nil {
+				request = tt.request(request)
+			}
+			got, err := s.Create(context.Background(), request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// schemaRelationService looks subjects up like spicedb, the permission must
+// be defined on the namespace of the object in the schema
+type schemaRelationService struct {
+	definitions []*core.NamespaceDefinition
+	subjects    []string
+}
+
+func (r schemaRelationService) LookupSubjects(ctx context.Context, rel relation.Relation) ([]string, error) {
+	for _, definition := range r.definitions {
+		if definition.GetName() != rel.Object.Namespace {
+			continue
+		}
+		for _, permission := range definition.GetRelation() {
+			if permission.GetName() == rel.RelationName {
+				return r.subjects, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("relation/permission %s not found under definition %s", rel.RelationName, rel.Object.Namespace)
+}
+
+func TestService_Create_BaseSchema(t *testing.T) {
+	compiled, devErr, err := development.CompileSchema(schema.BaseSchemaZed)
+	require.NoError(t, err)
+	require.Nil(t, devErr)
+	relations := schemaRelationService{
+		definitions: compiled.ObjectDefinitions,
+		subjects:    []string{"approver-id"},
+	}
+
+	for _, namespace := range []string{schema.OrganizationNamespace, schema.ProjectNamespace, schema.GroupNamespace} {
+		t.Run("should look the approvers of a request on "+namespace+" up", func(t *testing.T) {
+			repo, roles, policies := mocks.NewRepository(t), mocks.NewRoleService(t), mocks.NewPolicyService(t)
+			roles.EXPECT().Get(mock.Anything, "role-id").Return(role.Role{ID: "role-id"}, nil)
+			policies.EXPECT().List(mock.Anything, mock.Anything).Return(nil, nil)
+			policies.EXPECT().OrgID(mock.Anything, mock.Anything).Return("org-id", nil)
+			repo.EXPECT().Create(mock.Anything, mock.Anything).RunAndReturn(
+				func(_ context.Context, r accessrequest.Request) (accessrequest.Request, error) {
+					return r, nil
+				})
+			s := accessrequest.NewService(repo, relations, roles, policies, accessrequest.Config{
+				ApproverPermission: schema.PolicyManagePermission,
+				MaxDuration:        24 * time.Hour,
+			})
+
+			_, err := s.Create(context.Background(), accessrequest.Request{
+				RoleID:        "role-id",
+				ResourceID:    "resource-id",
+				ResourceType:  namespace,
+				PrincipalID:   "requester-id",
+				PrincipalType: schema.UserPrincipal,
+				Justification: "investigate the incident",
+				Duration:      time.Hour,
+			})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Approve(t *testing.T) {
+	grant := policy.Policy{
+		RoleID:        "role-id",
+		ResourceID:    "project-id",
+		ResourceType:  schema.ProjectNamespace,
+		PrincipalID:   "requester-id",
+		PrincipalType: schema.UserPrincipal,
+		Metadata:      metadata.Metadata{"access_request_id": "request-id"},
+		ExpiresAt:     now.Add(4 * time.Hour),
+	}
+	approved := pending
+	approved.State = accessrequest.StateApproved
+	approved.ReviewerID = "approver-id"
+	approved.ReviewNote = "go ahead"
+	approved.ReviewedAt = now
+	approved.PolicyID = "policy-id"
+
+	tests := []struct {
+		name       string
+		reviewerID string
+		setup      func(m serviceMocks)
+		want       accessrequest.Request
+		wantErr    error
+	}{
+		{
+			name:       "should grant the role with a policy expiring after the duration",
+			reviewerID: "approver-id",
+			setup: func(m serviceMocks) {
+				m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+				m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"approver-id"}, nil)
+				m.policies.EXPECT().List(mock.Anything, grantLookup).Return(nil, nil)
+				created := grant
+				created.ID = "policy-id"
+				m.policies.EXPECT().Create(mock.Anything, grant).Return(created, nil)
+				m.repo.EXPECT().UpdateState(mock.Anything, approved).Return(approved, nil)
+				m.policies.EXPECT().OrgID(mock.Anything, projectResource).Return("org-id", nil)
+			},
+			want: approved,
+		},
+		{
+			name:       "should not let requesters approve their own requests",
+			reviewerID: "requester-id",
+			setup: func(m serviceMocks) {
+				m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+				m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"requester-id", "approver-id"}, nil)
+			},
+			wantErr: accessrequest.ErrNotApprover,
+		},
+		{
+			name:       "should not approve reviewed requests",
+			reviewerID: "approver-id",
+			setup: func(m serviceMocks) {
+				denied := pending
+				denied.State = accessrequest.StateDenied
+				m.repo.EXPECT().Get(mock.Anything, "request-id").Return(denied, nil)
+			},
+			wantErr: accessrequest.ErrNotPending,
+		},
+		{
+			name:       "should revoke the policy if the request was reviewed concurrently",
+			reviewerID: "approver-id",
+			setup: func(m serviceMocks) {
+				m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+				m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"approver-id"}, nil)
+				m.policies.EXPECT().List(mock.Anything, grantLookup).Return(nil, nil)
+				created := grant
+				created.ID = "policy-id"
+				m.policies.EXPECT().Create(mock.Anything, grant).Return(created, nil)
+				m.repo.EXPECT().UpdateState(mock.Anything, approved).Return(accessrequest.Request{}, accessrequest.ErrNotPending)
+				m.policies.EXPECT().Delete(mock.Anything, "policy-id").Return(nil)
+			},
+			wantErr: accessrequest.ErrNotPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newTestService(t)
+			tt.setup(m)
+			got, err := s.Approve(context.Background(), "request-id", tt.reviewerID, " go ahead ")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Deny(t *testing.T) {
+	t.Run("should log the review on the org owning the resource", func(t *testing.T) {
+		s, m := newTestService(t)
+		auditRepo := auditmocks.NewRepository(t)
+		ctx := audit.SetContextWithService(context.Background(), audit.NewService("frontier", auditRepo, audit.NewNoopWebhookService()))
+		denied := pending
+		denied.State = accessrequest.StateDenied
+		denied.ReviewerID = "approver-id"
+		denied.ReviewNote = "not needed"
+		denied.ReviewedAt = now
+		m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+		m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"approver-id"}, nil)
+		m.repo.EXPECT().UpdateState(mock.Anything, denied).Return(denied, nil)
+		m.policies.EXPECT().OrgID(mock.Anything, projectResource).Return("org-id", nil)
+		auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == "org-id" && l.Action == audit.AccessRequestDeniedEvent.String() &&
+				l.Target.ID == "project-id"
+		})).Return(nil)
+
+		got, err := s.Deny(ctx, "request-id", "approver-id", "not needed")
+		assert.NoError(t, err)
+		assert.Equal(t, denied, got)
+	})
+
+	t.Run("should log on the platform org if no org owns the resource", func(t *testing.T) {
+		s, m := newTestService(t)
+		auditRepo := auditmocks.NewRepository(t)
+		ctx := audit.SetContextWithService(context.Background(), audit.NewService("frontier", auditRepo, audit.NewNoopWebhookService()))
+		denied := pending
+		denied.State = accessrequest.StateDenied
+		denied.ReviewerID = "approver-id"
+		denied.ReviewedAt = now
+		m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+		m.relations.EXPECT().LookupSubjects(mock.Anything, approverLookup).Return([]string{"approver-id"}, nil)
+		m.repo.EXPECT().UpdateState(mock.Anything, denied).Return(denied, nil)
+		m.policies.EXPECT().OrgID(mock.Anything, projectResource).Return("", policy.ErrNotExist)
+		auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == schema.PlatformOrgID.String()
+		})).Return(nil)
+
+		_, err := s.Deny(ctx, "request-id", "approver-id", "")
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_Cancel(t *testing.T) {
+	t.Run("should cancel a pending request of the requester", func(t *testing.T) {
+		s, m := newTestService(t)
+		cancelled := pending
+		cancelled.State = accessrequest.StateCancelled
+		cancelled.ReviewedAt = now
+		m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+		m.repo.EXPECT().UpdateState(mock.Anything, cancelled).Return(cancelled, nil)
+		m.policies.EXPECT().OrgID(mock.Anything, projectResource).Return("org-id", nil)
+
+		got, err := s.Cancel(context.Background(), "request-id", "requester-id")
+		assert.NoError(t, err)
+		assert.Equal(t, cancelled, got)
+	})
+
+	t.Run("should not let others cancel the request", func(t *testing.T) {
+		s, m := newTestService(t)
+		m.repo.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+
+		_, err := s.Cancel(context.Background(), "request-id", "approver-id")
+		assert.ErrorIs(t, err, accessrequest.ErrNotRequester)
+	})
+
+	t.Run("should return the error of the repository", func(t *testing.T) {
+		s, m := newTestService(t)
+		m.repo.EXPECT().Get(mock.Anything, "request-id").Return(accessrequest.Request{}, errors.New("db down"))
+
+		_, err := s.Cancel(context.Background(), "request-id", "requester-id")
+		assert.Error(t, err)
+	})
+}
@@ -12,12 +12,17 @@ import (
 var (
 	ErrInvalidDetail = fmt.Errorf("invalid audit details")
 	ErrInvalidID     = fmt.Errorf("group id is invalid")
+	ErrInvalidSink   = fmt.Errorf("invalid audit sink config")
 )
 
 type Actor struct {
 	ID   string
 	Type string
 	Name string
+
+	// ImpersonatedBy is the superuser acting as the actor, omitted from the
+	// stored actor unless set so the chain hashes of older logs hold
+	ImpersonatedBy *Actor `json:",omitempty"`
 }
 
 type Target struct {
@@ -37,6 +42,11 @@ type Log struct {
 	Target   Target
 	Metadata map[string]string
 
+	// PrevHash is the hash of the previous log of the org, Hash covers
+	// the log content and PrevHash forming a tamper-evident chain
+	PrevHash string
+	Hash     string
+
 	CreatedAt time.Time
 }
 
@@ -48,12 +58,21 @@ func (e EventName) String() string {
 }
 
 const (
-	UserCreatedEvent        EventName = "app.user.created"
-	UserUpdatedEvent        EventName = "app.user.updated"
-	UserDeletedEvent        EventName = "app.user.deleted"
-	UserListedEvent         EventName = "app.user.listed"
-	ServiceUserCreatedEvent EventName = "app.serviceuser.created"
-	ServiceUserDeletedEvent EventName = "app.serviceuser.deleted"
+	UserCreatedEvent            EventName = "app.user.created"
+	UserUpdatedEvent            EventName = "app.user.updated"
+	UserDeletedEvent            EventName = "app.user.deleted"
+	UserListedEvent             EventName = "app.user.listed"
+	UserMFAEnabledEvent         EventName = "app.user.mfa.enabled"
+	UserMFADisabledEvent        EventName = "app.user.mfa.disabled"
+	UserImpersonatedEvent       EventName = "app.user.impersonated"
+	UserImpersonationEndedEvent EventName = "app.user.impersonation.ended"
+	UserPasswordChangedEvent    EventName = "app.user.password.changed"
+	UserPasswordResetEvent      EventName = "app.user.password.reset"
+	UserSessionsRevokedEvent    EventName = "app.user.sessions.revoked"
+	UserPasskeyAddedEvent       EventName = "app.user.passkey.added"
+	UserPasskeyDeletedEvent     EventName = "app.user.passkey.deleted"
+	ServiceUserCreatedEvent     EventName = "app.serviceuser.created"
+	ServiceUserDeletedEvent     EventName = "app.serviceuser.deleted"
 
 	GroupCreatedEvent       EventName = "app.group.created"
 	GroupUpdatedEvent       EventName = "app.group.updated"
@@ -73,6 +92,12 @@ const (
 
 	PolicyCreatedEvent EventName = "app.policy.created"
 	PolicyDeletedEvent EventName = "app.policy.deleted"
+	PolicyExpiredEvent EventName = "app.policy.expired"
+
+	AccessRequestCreatedEvent   EventName = "app.accessrequest.created"
+	AccessRequestApprovedEvent  EventName = "app.accessrequest.approved"
+	AccessRequestDeniedEvent    EventName = "app.accessrequest.denied"
+	AccessRequestCancelledEvent EventName = "app.accessrequest.cancelled"
 
 	OrgCreatedEvent       EventName = "app.organization.created"
 	OrgUpdatedEvent       EventName = "app.organization.updated"
@@ -81,6 +106,15 @@ const (
 	OrgMemberCreatedEvent EventName = "app.organization.member.created"
 	OrgMemberDeletedEvent EventName = "app.organization.member.deleted"
 
+	OrgSAMLConnectionCreatedEvent EventName = "app.organization.saml.created"
+	OrgSAMLConnectionDeletedEvent EventName = "app.organization.saml.deleted"
+	OrgIDPCreatedEvent            EventName = "app.organization.idp.created"
+	OrgIDPDeletedEvent            EventName = "app.organization.idp.deleted"
+
+	OAuth2ClientCreatedEvent       EventName = "app.oauth2.client.created"
+	OAuth2ClientSecretRotatedEvent EventName = "app.oauth2.client.secret.rotated"
+	OAuth2ClientDeletedEvent       EventName = "app.oauth2.client.deleted"
+
 	ProjectCreatedEvent EventName = "app.project.created"
 	ProjectUpdatedEvent EventName = "app.project.updated"
 	ProjectDeletedEvent EventName = "app.project.deleted"
@@ -109,6 +143,11 @@ func IsSystemEvent(event EventName) bool {
 	return slices.Contains(systemEvents, event)
 }
 
+// SystemEvents returns the events generated by the platform instead of users
+func SystemEvents() []EventName {
+	return slices.Clone(systemEvents)
+}
+
 func OrgTarget(id string) Target {
 	return Target{
 		ID:   id,
@@ -143,3 +182,10 @@ func GroupTarget(id string) Target {
 		Type: schema.GroupPrincipal,
 	}
 }
+
+func OAuth2ClientTarget(id string) Target {
+	return Target{
+		ID:   id,
+		Type: "app/oauth2_client",
+	}
+}
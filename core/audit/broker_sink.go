@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gocloud.dev/pubsub"
+)
+
+// MessageKeyMetadata is the metadata key holding the message key, kafka topics
+// opened with `?key_name=key` use it as the partition key
+const MessageKeyMetadata = "key"
+
+// Message is a single record published to a message broker
+type Message struct {
+	// Key is used by brokers to partition messages, logs of an org share the key
+	Key      string
+	Body     []byte
+	Metadata map[string]string
+}
+
+// Producer publishes messages to a message broker like kafka, nats or cloud pub/sub
+type Producer interface {
+	Send(ctx context.Context, msg Message) error
+	Close() error
+}
+
+// BrokerWriter publishes every log as a separate message
+type BrokerWriter struct {
+	producer Producer
+}
+
+func NewBrokerWriter(producer Producer) *BrokerWriter {
+	return &BrokerWriter{
+		producer: producer,
+	}
+}
+
+func (w *BrokerWriter) Write(ctx context.Context, logs []Log) error {
+	var errs []error
+	for _, l := range logs {
+		body, err := json.Marshal(l)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := w.producer.Send(ctx, Message{
+			Key:  l.OrgID,
+			Body: body,
+			Metadata: map[string]string{
+				"action": l.Action,
+				"source": l.Source,
+			},
+		}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *BrokerWriter) Close() error {
+	return w.producer.Close()
+}
+
+// PubSubProducer publishes messages to any broker supported by gocloud.dev/pubsub,
+// the driver of the topic url scheme must be registered by the caller
+// e.g. kafka://topic or mem://topic
+type PubSubProducer struct {
+	topic *pubsub.Topic
+}
+
+func NewPubSubProducer(ctx context.Context, topicURL string) (*PubSubProducer, error) {
+	if strings.TrimSpace(topicURL) == "" {
+		return nil, fmt.Errorf("%w: broker sink topic url is required", ErrInvalidSink)
+	}
+	topic, err := pubsub.OpenTopic(ctx, topicURL)
+	if err != nil {
+		return nil, err
+	}
+	return &PubSubProducer{
+		topic: topic,
+	}, nil
+}
+
+func (p *PubSubProducer) Send(ctx context.Context, msg Message) error {
+	metadata := make(map[string]string, len(msg.Metadata)+1)
+	for k, v := range msg.Metadata {
+		metadata[k] = v
+	}
+	metadata[MessageKeyMetadata] = msg.Key
+	return p.topic.Send(ctx, &pubsub.Message{
+		Body:     msg.Body,
+		Metadata: metadata,
+	})
+}
+
+func (p *PubSubProducer) Close() error {
+	return p.topic.Shutdown(context.Background())
+}
@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryChain []ChainLink
+
+func (m memoryChain) ChainAnchor(ctx context.Context, orgID string) (int64, string, error) {
+	return 0, "", nil
+}
+
+func (m memoryChain) ListChain(ctx context.Context, orgID string, afterSequence int64, limit int) ([]ChainLink, error) {
+	var links []ChainLink
+	for _, link := range m {
+		if link.Sequence > afterSequence && link.Log.OrgID == orgID && len(links) < limit {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+func buildChain(t *testing.T, legacy, count int) memoryChain {
+	t.Helper()
+	var chain memoryChain
+	var prevHash string
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < legacy+count; i++ {
+		l := Log{
+			ID:        "log-" + string(rune('a'+i)),
+			OrgID:     "org-1",
+			Source:    "frontier",
+			Action:    UserCreatedEvent.String(),
+			Actor:     Actor{ID: "user-1", Type: "app/user"},
+			Metadata:  map[string]string{"k": "v"},
+			CreatedAt: createdAt.Add(time.Duration(i) * time.Second),
+		}
+		if i >= legacy {
+			hash, err := ChainHash(prevHash, l)
+			assert.NoError(t, err)
+			l.PrevHash, l.Hash = prevHash, hash
+			prevHash = hash
+		}
+		chain = append(chain, ChainLink{Sequence: int64(i + 1), Log: l})
+	}
+	return chain
+}
+
+func TestVerifyChain(t *testing.T) {
+	tests := []struct {
+		name        string
+		chain       func(t *testing.T) memoryChain
+		wantChecked int
+		wantBreakAt string
+	}{
+		{
+			name: "should pass an intact chain after legacy logs",
+			chain: func(t *testing.T) memoryChain {
+				return buildChain(t, 2, 3)
+			},
+			wantChecked: 3,
+		},
+		{
+			name: "should detect a modified log",
+			chain: func(t *testing.T) memoryChain {
+				chain := buildChain(t, 0, 4)
+				chain[2].Log.Metadata["k"] = "tampered"
+				return chain
+			},
+			wantChecked: 3,
+			wantBreakAt: "log-c",
+		},
+		{
+			name: "should detect a deleted log",
+			chain: func(t *testing.T) memoryChain {
+				chain := buildChain(t, 0, 4)
+				return append(chain[:1], chain[2:]...)
+			},
+			wantChecked: 2,
+			wantBreakAt: "log-c",
+		},
+		{
+			name: "should detect a log with its hash removed",
+			chain: func(t *testing.T) memoryChain {
+				chain := buildChain(t, 0, 3)
+				chain[0].Log.PrevHash, chain[0].Log.Hash = "", ""
+				return chain
+			},
+			wantChecked: 1,
+			wantBreakAt: "log-b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := VerifyChain(context.Background(), tt.chain(t), "org-1")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantChecked, got.Checked)
+			assert.Equal(t, tt.wantBreakAt == "", got.Valid)
+			if tt.wantBreakAt != "" {
+				assert.Equal(t, tt.wantBreakAt, got.Break.LogID)
+			}
+		})
+	}
+}
+
+func TestVerifyChain_Head(t *testing.T) {
+	chain := buildChain(t, 0, 4)
+
+	got, err := VerifyChain(context.Background(), chain, "org-1")
+	assert.NoError(t, err)
+	assert.Equal(t, chain[3].Sequence, got.HeadSequence)
+	assert.Equal(t, chain[3].Log.Hash, got.HeadHash)
+
+	// deleting the latest logs goes unnoticed unless the head is compared
+	got, err = VerifyChain(context.Background(), chain[:2], "org-1")
+	assert.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, chain[1].Log.Hash, got.HeadHash)
+
+	chain[2].Log.Metadata["k"] = "tampered"
+	got, err = VerifyChain(context.Background(), chain, "org-1")
+	assert.NoError(t, err)
+	assert.False(t, got.Valid)
+	assert.Equal(t, chain[1].Sequence, got.HeadSequence)
+}
+
+func TestService_VerifyChain_NotSupported(t *testing.T) {
+	s := NewService("frontier", NewNoopRepository(), nil)
+	_, err := s.VerifyChain(context.Background(), "org-1")
+	assert.ErrorIs(t, err, ErrChainNotSupported)
+}
+
+type anchoredChain struct {
+	memoryChain
+	sequence int64
+	hash     string
+}
+
+func (a anchoredChain) ChainAnchor(ctx context.Context, orgID string) (int64, string, error) {
+	return a.sequence, a.hash, nil
+}
+
+func TestVerifyChain_AfterArchival(t *testing.T) {
+	chain := buildChain(t, 0, 5)
+	archivedTill := chain[1]
+
+	got, err := VerifyChain(context.Background(), anchoredChain{
+		memoryChain: chain[2:],
+		sequence:    archivedTill.Sequence,
+		hash:        archivedTill.Log.Hash,
+	}, "org-1")
+	assert.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, 3, got.Checked)
+
+	// without the anchor the remaining logs don't link to the start of the chain
+	got, err = VerifyChain(context.Background(), chain[2:], "org-1")
+	assert.NoError(t, err)
+	assert.False(t, got.Valid)
+}
@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"context"
+	"errors"
+)
+
+// Sink receives a copy of every audit log created, it must not block the caller
+type Sink interface {
+	Name() string
+	Enqueue(Log) error
+	Close() error
+}
+
+// FanOutRepository stores logs in the primary repository and exports a
+// copy to every sink. Reads are always served by the primary repository.
+// A failing sink never fails the creation of a log.
+type FanOutRepository struct {
+	primary Repository
+	sinks   []Sink
+}
+
+func NewFanOutRepository(primary Repository, sinks ...Sink) *FanOutRepository {
+	return &FanOutRepository{
+		primary: primary,
+		sinks:   sinks,
+	}
+}
+
+func (r FanOutRepository) Create(ctx context.Context, l *Log) error {
+	if err := r.primary.Create(ctx, l); err != nil {
+		return err
+	}
+	for _, sink := range r.sinks {
+		// sinks account for their own failures
+		_ = sink.Enqueue(*l)
+	}
+	return nil
+}
+
+func (r FanOutRepository) List(ctx context.Context, filter Filter) ([]Log, error) {
+	return r.primary.List(ctx, filter)
+}
+
+func (r FanOutRepository) Search(ctx context.Context, filter Filter) (SearchResult, error) {
+	return r.primary.Search(ctx, filter)
+}
+
+func (r FanOutRepository) GetByID(ctx context.Context, id string) (Log, error) {
+	return r.primary.GetByID(ctx, id)
+}
+
+// Close flushes and closes all the sinks
+func (r FanOutRepository) Close() error {
+	var errs []error
+	for _, sink := range r.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r FanOutRepository) ListChain(ctx context.Context, orgID string, afterSequence int64, limit int) ([]ChainLink, error) {
+	repo, ok := r.primary.(ChainRepository)
+	if !ok {
+		return nil, ErrChainNotSupported
+	}
+	return repo.ListChain(ctx, orgID, afterSequence, limit)
+}
+
+func (r FanOutRepository) ChainAnchor(ctx context.Context, orgID string) (int64, string, error) {
+	repo, ok := r.primary.(ChainRepository)
+	if !ok {
+		return 0, "", ErrChainNotSupported
+	}
+	return repo.ChainAnchor(ctx, orgID)
+}
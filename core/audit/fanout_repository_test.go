@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingSink struct {
+	logs   []Log
+	err    error
+	closed bool
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Enqueue(l Log) error {
+	s.logs = append(s.logs, l)
+	return s.err
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return s.err
+}
+
+func TestFanOutRepository(t *testing.T) {
+	healthy := &recordingSink{}
+	failing := &recordingSink{err: errors.New("sink is down")}
+	var primary bytes.Buffer
+	repo := NewFanOutRepository(NewWriteOnlyRepository(&primary), failing, healthy)
+
+	err := repo.Create(context.Background(), &Log{ID: "log-1", Action: "app.user.created"})
+	assert.NoError(t, err)
+	assert.Contains(t, primary.String(), "log-1")
+	assert.Len(t, failing.logs, 1)
+	assert.Equal(t, "log-1", healthy.logs[0].ID)
+
+	assert.ErrorContains(t, repo.Close(), "sink is down")
+	assert.True(t, failing.closed)
+	assert.True(t, healthy.closed)
+}
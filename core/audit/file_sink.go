@@ -0,0 +1,143 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fileSinkPrefix    = "audit-"
+	fileSinkExtension = ".ndjson"
+	fileSinkTimeFmt   = "20060102T150405.000000000"
+)
+
+type FileSinkConfig struct {
+	// Dir is the directory where the files are created
+	Dir string `yaml:"dir" mapstructure:"dir"`
+	// MaxSizeMB is the size after which a new file is started
+	MaxSizeMB int `yaml:"max_size_mb" mapstructure:"max_size_mb" default:"100"`
+	// MaxAge is the duration after which a new file is started even if it's not full
+	MaxAge time.Duration `yaml:"max_age" mapstructure:"max_age" default:"24h"`
+	// MaxFiles is the number of rotated files retained, older files are deleted. 0 retains all
+	MaxFiles int `yaml:"max_files" mapstructure:"max_files" default:"10"`
+}
+
+// RotatingFileWriter appends logs as newline delimited json to files
+// in a directory, starting a new file when the current one is too big or old
+type RotatingFileWriter struct {
+	cfg FileSinkConfig
+	Now func() time.Time
+
+	mu        sync.Mutex
+	file      *os.File
+	size      int64
+	openedAt  time.Time
+	maxSizeMB int64
+}
+
+func NewRotatingFileWriter(cfg FileSinkConfig) (*RotatingFileWriter, error) {
+	if strings.TrimSpace(cfg.Dir) == "" {
+		return nil, fmt.Errorf("%w: file sink dir is required", ErrInvalidSink)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &RotatingFileWriter{
+		cfg:       cfg,
+		maxSizeMB: int64(cfg.MaxSizeMB),
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}, nil
+}
+
+func (w *RotatingFileWriter) Write(ctx context.Context, logs []Log) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, l := range logs {
+		line, err := json.Marshal(l)
+		if err != nil {
+			return err
+		}
+		line = append(line, '\n')
+		if err := w.rotateIfNeeded(int64(len(line))); err != nil {
+			return err
+		}
+		n, err := w.file.Write(line)
+		w.size += int64(n)
+		if err != nil {
+			return err
+		}
+	}
+	return w.file.Sync()
+}
+
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+func (w *RotatingFileWriter) rotateIfNeeded(incoming int64) error {
+	if w.file != nil {
+		tooBig := w.maxSizeMB > 0 && w.size > 0 && w.size+incoming > w.maxSizeMB*1024*1024
+		tooOld := w.cfg.MaxAge > 0 && w.Now().Sub(w.openedAt) >= w.cfg.MaxAge
+		if !tooBig && !tooOld {
+			return nil
+		}
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+
+	w.openedAt = w.Now()
+	name := filepath.Join(w.cfg.Dir, fileSinkPrefix+w.openedAt.Format(fileSinkTimeFmt)+fileSinkExtension)
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o640)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.file = f
+	w.size = info.Size()
+	return w.prune()
+}
+
+// prune deletes the oldest files beyond MaxFiles
+func (w *RotatingFileWriter) prune() error {
+	if w.cfg.MaxFiles <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(filepath.Join(w.cfg.Dir, fileSinkPrefix+"*"+fileSinkExtension))
+	if err != nil {
+		return err
+	}
+	if len(matches) <= w.cfg.MaxFiles {
+		return nil
+	}
+	// timestamp in the names keeps lexical order same as creation order
+	sort.Strings(matches)
+	for _, name := range matches[:len(matches)-w.cfg.MaxFiles] {
+		if err := os.Remove(name); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
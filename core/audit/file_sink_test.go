@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotatingFileWriter(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewRotatingFileWriter(FileSinkConfig{
+		Dir:      dir,
+		MaxAge:   time.Hour,
+		MaxFiles: 2,
+	})
+	assert.NoError(t, err)
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	writer.Now = func() time.Time {
+		return now
+	}
+
+	// every write after an hour lands in a new file
+	for _, id := range []string{"1", "2", "3"} {
+		assert.NoError(t, writer.Write(context.Background(), []Log{{ID: id}, {ID: id + "-b"}}))
+		now = now.Add(time.Hour)
+	}
+	assert.NoError(t, writer.Close())
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.ndjson"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	f, err := os.Open(files[len(files)-1])
+	assert.NoError(t, err)
+	defer f.Close()
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var l Log
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &l))
+		ids = append(ids, l.ID)
+	}
+	assert.Equal(t, []string{"3", "3-b"}, ids)
+}
+
+func TestNewRotatingFileWriter_RequiresDir(t *testing.T) {
+	_, err := NewRotatingFileWriter(FileSinkConfig{})
+	assert.ErrorIs(t, err, ErrInvalidSink)
+}
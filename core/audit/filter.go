@@ -1,14 +1,84 @@
 package audit
 
-import "time"
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid audit log cursor")
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 1000
+)
 
 type Filter struct {
 	OrgID  string
 	Source string
 	Action string
 
+	ActorID    string
+	ActorType  string
+	TargetID   string
+	TargetType string
+	// Metadata matches logs having all the key value pairs
+	Metadata map[string]string
+	// Query is matched as free text against action, source, actor, target and metadata,
+	// supports quoted phrases, OR and -exclusions
+	Query string
+
 	StartTime time.Time
 	EndTime   time.Time
 
 	IgnoreSystem bool
+
+	// PageSize is the max number of logs returned by Search
+	PageSize int
+	// Cursor resumes Search after the last log of the previous page
+	Cursor string
+}
+
+// SearchResult is a page of logs matching a filter, newest first
+type SearchResult struct {
+	Logs []Log
+	// NextCursor fetches the next page, empty on the last page
+	NextCursor string
+	// ActionFacets counts all the logs matching the filter per action
+	ActionFacets map[string]int
+}
+
+// Cursor is the position of a log in the search order
+type Cursor struct {
+	CreatedAt time.Time `json:"t"`
+	ID        string    `json:"id"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(value string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// NormalizedPageSize returns the page size within the allowed bounds
+func (f Filter) NormalizedPageSize() int {
+	switch {
+	case f.PageSize <= 0:
+		return DefaultPageSize
+	case f.PageSize > MaxPageSize:
+		return MaxPageSize
+	}
+	return f.PageSize
 }
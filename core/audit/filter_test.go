@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	c := Cursor{
+		CreatedAt: time.Date(2026, 1, 1, 10, 0, 0, 123456000, time.UTC),
+		ID:        "9f2c1a6e-7b0e-4a55-8f3b-1f0e2d3c4b5a",
+	}
+	decoded, err := DecodeCursor(c.Encode())
+	assert.NoError(t, err)
+	assert.True(t, c.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, c.ID, decoded.ID)
+
+	for _, value := range []string{"not-base64!", "e30", ""} {
+		_, err := DecodeCursor(value)
+		assert.ErrorIs(t, err, ErrInvalidCursor, value)
+	}
+}
+
+func TestFilter_NormalizedPageSize(t *testing.T) {
+	assert.Equal(t, DefaultPageSize, Filter{}.NormalizedPageSize())
+	assert.Equal(t, 10, Filter{PageSize: 10}.NormalizedPageSize())
+	assert.Equal(t, MaxPageSize, Filter{PageSize: MaxPageSize + 1}.NormalizedPageSize())
+}
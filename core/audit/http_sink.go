@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+type HTTPSinkConfig struct {
+	// URL of the collector accepting a batch of logs as newline delimited json
+	URL string `yaml:"url" mapstructure:"url"`
+	// Headers sent with every request, e.g. authorization token of the collector
+	Headers map[string]string `yaml:"headers" mapstructure:"headers"`
+	// Timeout of a single request
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout" default:"10s"`
+}
+
+// HTTPWriter posts a batch of logs to a collector in a single request
+type HTTPWriter struct {
+	url    string
+	client *resty.Client
+}
+
+func NewHTTPWriter(cfg HTTPSinkConfig) (*HTTPWriter, error) {
+	if strings.TrimSpace(cfg.URL) == "" {
+		return nil, fmt.Errorf("%w: http sink url is required", ErrInvalidSink)
+	}
+	return &HTTPWriter{
+		url: cfg.URL,
+		client: resty.New().
+			SetTimeout(cfg.Timeout).
+			SetHeaders(cfg.Headers).
+			SetHeader("Content-Type", "application/x-ndjson"),
+	}, nil
+}
+
+func (w *HTTPWriter) Write(ctx context.Context, logs []Log) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, l := range logs {
+		if err := encoder.Encode(l); err != nil {
+			return err
+		}
+	}
+	resp, err := w.client.R().SetContext(ctx).SetBody(body.Bytes()).Post(w.url)
+	if err != nil {
+		return err
+	}
+	if resp.IsError() {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode())
+	}
+	return nil
+}
+
+func (w *HTTPWriter) Close() error {
+	return nil
+}
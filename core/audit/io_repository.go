@@ -24,6 +24,10 @@ func (r WriteOnlyRepository) List(ctx context.Context, filter Filter) ([]Log, er
 	return nil, fmt.Errorf("unsupported")
 }
 
+func (r WriteOnlyRepository) Search(ctx context.Context, filter Filter) (SearchResult, error) {
+	return SearchResult{}, fmt.Errorf("unsupported")
+}
+
 func (r WriteOnlyRepository) GetByID(ctx context.Context, s string) (Log, error) {
 	return Log{}, fmt.Errorf("unsupported")
 }
@@ -20,6 +20,10 @@ func (r NoopRepository) List(ctx context.Context, filter Filter) ([]Log, error)
 	return []Log{}, nil
 }
 
+func (r NoopRepository) Search(ctx context.Context, filter Filter) (SearchResult, error) {
+	return SearchResult{ActionFacets: map[string]int{}}, nil
+}
+
 func (r NoopRepository) GetByID(ctx context.Context, s string) (Log, error) {
 	return Log{}, nil
 }
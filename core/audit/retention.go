@@ -0,0 +1,309 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/raystack/salt/log"
+	"github.com/robfig/cron/v3"
+)
+
+var (
+	ErrRetentionPolicyNotFound = errors.New("audit retention policy doesn't exist")
+	ErrInvalidRetentionPolicy  = errors.New("invalid audit retention policy")
+	ErrInvalidRestoreRange     = errors.New("invalid audit restore range")
+)
+
+type RetentionConfig struct {
+	// Enabled schedules the job moving expired logs to the archive
+	Enabled bool `yaml:"enabled" mapstructure:"enabled" default:"false"`
+	// Schedule of the archival job in cron format
+	Schedule string `yaml:"schedule" mapstructure:"schedule" default:"@daily"`
+	// DefaultHotPeriod is how long logs of orgs without their own policy are kept
+	// in the database before being archived, 0 keeps them forever
+	DefaultHotPeriod time.Duration `yaml:"default_hot_period" mapstructure:"default_hot_period" default:"0s"`
+	// ArchivePath is the bucket where archived logs are written
+	// e.g. file:///var/lib/frontier/audit or gs://bucket/audit
+	ArchivePath string `yaml:"archive_path" mapstructure:"archive_path"`
+	// ArchivePathSecret is required to access the archive bucket
+	ArchivePathSecret string `yaml:"archive_path_secret" mapstructure:"archive_path_secret"`
+	// BatchSize is the max number of logs written to a single archive object
+	BatchSize int `yaml:"batch_size" mapstructure:"batch_size" default:"5000"`
+	// RestoreTTL is how long restored logs stay in the database
+	RestoreTTL time.Duration `yaml:"restore_ttl" mapstructure:"restore_ttl" default:"168h"`
+}
+
+// RetentionPolicy overrides the default hot period of an org
+type RetentionPolicy struct {
+	OrgID string
+	// HotPeriod is how long logs are kept in the database, 0 keeps them forever
+	HotPeriod time.Duration
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Archive is a contiguous range of the org chain moved to the archive store
+type Archive struct {
+	ID           string
+	OrgID        string
+	FromSequence int64
+	ToSequence   int64
+	StartTime    time.Time
+	EndTime      time.Time
+	Count        int
+	// LastHash is the hash of the last archived log, the chain in the
+	// database continues from it
+	LastHash  string
+	ObjectKey string
+
+	CreatedAt time.Time
+}
+
+type RetentionRepository interface {
+	UpsertPolicy(ctx context.Context, policy RetentionPolicy) (RetentionPolicy, error)
+	GetPolicy(ctx context.Context, orgID string) (RetentionPolicy, error)
+	ListPolicies(ctx context.Context) ([]RetentionPolicy, error)
+	DeletePolicy(ctx context.Context, orgID string) error
+
+	// ListArchivableOrgs returns orgs having logs created before the given time
+	ListArchivableOrgs(ctx context.Context, before time.Time) ([]string, error)
+	// ListArchivable returns the oldest logs of the org chain created before the given time
+	ListArchivable(ctx context.Context, orgID string, before time.Time, limit int) ([]ChainLink, error)
+	// CompleteArchive records the archive and deletes the archived logs
+	CompleteArchive(ctx context.Context, archive Archive) (Archive, error)
+	// ListArchives returns archives of the org overlapping the time range
+	ListArchives(ctx context.Context, orgID string, start, end time.Time) ([]Archive, error)
+	// Restore inserts archived logs back marking them as restored
+	Restore(ctx context.Context, links []ChainLink) (int, error)
+	// PurgeRestored deletes logs restored before the given time
+	PurgeRestored(ctx context.Context, before time.Time) (int, error)
+}
+
+// ArchiveStore persists archived logs as objects, e.g. in a bucket
+type ArchiveStore interface {
+	Write(ctx context.Context, key string, links []ChainLink) error
+	Read(ctx context.Context, key string) ([]ChainLink, error)
+}
+
+// RetentionService moves logs older than the retention policy of their org
+// from the database to the archive store and restores them on demand
+type RetentionService struct {
+	log   log.Logger
+	repo  RetentionRepository
+	store ArchiveStore
+	cfg   RetentionConfig
+	Now   func() time.Time
+
+	mu  sync.Mutex
+	job *cron.Cron
+}
+
+func NewRetentionService(logger log.Logger, repo RetentionRepository, store ArchiveStore, cfg RetentionConfig) *RetentionService {
+	return &RetentionService{
+		log:   logger,
+		repo:  repo,
+		store: store,
+		cfg:   cfg,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (s *RetentionService) SetPolicy(ctx context.Context, policy RetentionPolicy) (RetentionPolicy, error) {
+	if policy.OrgID == "" || policy.HotPeriod < 0 {
+		return RetentionPolicy{}, ErrInvalidRetentionPolicy
+	}
+	return s.repo.UpsertPolicy(ctx, policy)
+}
+
+func (s *RetentionService) GetPolicy(ctx context.Context, orgID string) (RetentionPolicy, error) {
+	return s.repo.GetPolicy(ctx, orgID)
+}
+
+func (s *RetentionService) ListPolicies(ctx context.Context) ([]RetentionPolicy, error) {
+	return s.repo.ListPolicies(ctx)
+}
+
+func (s *RetentionService) DeletePolicy(ctx context.Context, orgID string) error {
+	return s.repo.DeletePolicy(ctx, orgID)
+}
+
+func (s *RetentionService) ListArchives(ctx context.Context, orgID string, start, end time.Time) ([]Archive, error) {
+	return s.repo.ListArchives(ctx, orgID, start, end)
+}
+
+// Init schedules the archival job
+func (s *RetentionService) Init(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.cfg.Enabled {
+		return nil
+	}
+	if s.job != nil {
+		s.job.Stop()
+	}
+	s.job = cron.New(cron.WithChain(
+		cron.SkipIfStillRunning(cron.DefaultLogger),
+		cron.Recover(cron.DefaultLogger),
+	))
+	if _, err := s.job.AddFunc(s.cfg.Schedule, func() {
+		if err := s.Archive(ctx); err != nil {
+			s.log.Warn("audit archival failed", "err", err)
+		}
+	}); err != nil {
+		return err
+	}
+	s.job.Start()
+	return nil
+}
+
+// Close stops the archival job and releases the archive store
+func (s *RetentionService) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var err error
+	if s.job != nil {
+		err = s.job.Stop().Err()
+	}
+	if closer, ok := s.store.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+	return err
+}
+
+// Archive moves expired logs of every org to the archive store and
+// purges restored logs past their ttl
+func (s *RetentionService) Archive(ctx context.Context) error {
+	policies, err := s.repo.ListPolicies(ctx)
+	if err != nil {
+		return err
+	}
+	hotPeriods := make(map[string]time.Duration, len(policies))
+	shortest := s.cfg.DefaultHotPeriod
+	for _, p := range policies {
+		hotPeriods[p.OrgID] = p.HotPeriod
+		if p.HotPeriod > 0 && (shortest == 0 || p.HotPeriod < shortest) {
+			shortest = p.HotPeriod
+		}
+	}
+
+	now := s.Now()
+	var errs []error
+	if shortest > 0 {
+		orgIDs, err := s.repo.ListArchivableOrgs(ctx, now.Add(-shortest))
+		if err != nil {
+			return err
+		}
+		for _, orgID := range orgIDs {
+			hotPeriod, ok := hotPeriods[orgID]
+			if !ok {
+				hotPeriod = s.cfg.DefaultHotPeriod
+			}
+			if hotPeriod == 0 {
+				continue
+			}
+			if err := s.archiveOrg(ctx, orgID, now.Add(-hotPeriod)); err != nil {
+				errs = append(errs, fmt.Errorf("org %s: %w", orgID, err))
+			}
+		}
+	}
+
+	if s.cfg.RestoreTTL > 0 {
+		if _, err := s.repo.PurgeRestored(ctx, now.Add(-s.cfg.RestoreTTL)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *RetentionService) archiveOrg(ctx context.Context, orgID string, before time.Time) error {
+	batchSize := s.cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = 5000
+	}
+	for {
+		links, err := s.repo.ListArchivable(ctx, orgID, before, batchSize)
+		if err != nil {
+			return err
+		}
+		if len(links) == 0 {
+			return nil
+		}
+
+		first, last := links[0], links[len(links)-1]
+		archive := Archive{
+			OrgID:        orgID,
+			FromSequence: first.Sequence,
+			ToSequence:   last.Sequence,
+			StartTime:    first.Log.CreatedAt,
+			EndTime:      first.Log.CreatedAt,
+			Count:        len(links),
+			LastHash:     last.Log.Hash,
+			// keys are derived from the range so a retried run overwrites the same object
+			ObjectKey: fmt.Sprintf("%s/%s/%020d-%020d.ndjson.gz", orgID,
+				first.Log.CreatedAt.UTC().Format("2006/01/02"), first.Sequence, last.Sequence),
+		}
+		for _, link := range links {
+			if link.Log.CreatedAt.Before(archive.StartTime) {
+				archive.StartTime = link.Log.CreatedAt
+			}
+			if link.Log.CreatedAt.After(archive.EndTime) {
+				archive.EndTime = link.Log.CreatedAt
+			}
+		}
+
+		if err := s.store.Write(ctx, archive.ObjectKey, links); err != nil {
+			return err
+		}
+		if _, err := s.repo.CompleteArchive(ctx, archive); err != nil {
+			return err
+		}
+		s.log.Info("archived audit logs", "org_id", orgID, "count", archive.Count, "key", archive.ObjectKey)
+
+		if len(links) < batchSize {
+			return nil
+		}
+	}
+}
+
+// Restore rehydrates archived logs of an org created within the time range,
+// restored logs are visible in the audit apis until they are purged after RestoreTTL
+func (s *RetentionService) Restore(ctx context.Context, orgID string, start, end time.Time) (int, error) {
+	if orgID == "" || end.Before(start) {
+		return 0, ErrInvalidRestoreRange
+	}
+	archives, err := s.repo.ListArchives(ctx, orgID, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, archive := range archives {
+		links, err := s.store.Read(ctx, archive.ObjectKey)
+		if err != nil {
+			return restored, fmt.Errorf("reading archive %s: %w", archive.ObjectKey, err)
+		}
+		inRange := make([]ChainLink, 0, len(links))
+		for _, link := range links {
+			if link.Log.CreatedAt.Before(start) || link.Log.CreatedAt.After(end) {
+				continue
+			}
+			inRange = append(inRange, link)
+		}
+		if len(inRange) == 0 {
+			continue
+		}
+		count, err := s.repo.Restore(ctx, inRange)
+		if err != nil {
+			return restored, err
+		}
+		restored += count
+	}
+	return restored, nil
+}
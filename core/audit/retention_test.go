@@ -0,0 +1,127 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var retentionNow = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func newRetentionService(t *testing.T, cfg audit.RetentionConfig) (*audit.RetentionService, *mocks.RetentionRepository, *mocks.ArchiveStore) {
+	repo := mocks.NewRetentionRepository(t)
+	store := mocks.NewArchiveStore(t)
+	s := audit.NewRetentionService(log.NewNoop(), repo, store, cfg)
+	s.Now = func() time.Time {
+		return retentionNow
+	}
+	return s, repo, store
+}
+
+func chainLinks(orgID string, from int64, count int, start time.Time) []audit.ChainLink {
+	links := make([]audit.ChainLink, 0, count)
+	for i := 0; i < count; i++ {
+		links = append(links, audit.ChainLink{
+			Sequence: from + int64(i),
+			Log: audit.Log{
+				OrgID:     orgID,
+				Hash:      "hash",
+				CreatedAt: start.Add(time.Duration(i) * time.Hour),
+			},
+		})
+	}
+	return links
+}
+
+func TestRetentionService_Archive(t *testing.T) {
+	day := 24 * time.Hour
+	t.Run("should archive expired logs in batches using org policies over the default", func(t *testing.T) {
+		s, repo, store := newRetentionService(t, audit.RetentionConfig{
+			DefaultHotPeriod: 90 * day,
+			BatchSize:        2,
+			RestoreTTL:       7 * day,
+		})
+		repo.EXPECT().ListPolicies(mock.Anything).Return([]audit.RetentionPolicy{
+			{OrgID: "org-short", HotPeriod: 30 * day},
+			{OrgID: "org-forever", HotPeriod: 0},
+		}, nil)
+		repo.EXPECT().ListArchivableOrgs(mock.Anything, retentionNow.Add(-30*day)).
+			Return([]string{"org-short", "org-forever", "org-default"}, nil)
+
+		old := retentionNow.Add(-100 * day)
+		repo.EXPECT().ListArchivable(mock.Anything, "org-short", retentionNow.Add(-30*day), 2).
+			Return(chainLinks("org-short", 1, 2, old), nil).Once()
+		repo.EXPECT().ListArchivable(mock.Anything, "org-short", retentionNow.Add(-30*day), 2).
+			Return(chainLinks("org-short", 3, 1, old), nil).Once()
+		repo.EXPECT().ListArchivable(mock.Anything, "org-default", retentionNow.Add(-90*day), 2).
+			Return(nil, nil).Once()
+
+		store.EXPECT().Write(mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil).Times(2)
+		var archived []audit.Archive
+		repo.EXPECT().CompleteArchive(mock.Anything, mock.Anything).RunAndReturn(
+			func(ctx context.Context, a audit.Archive) (audit.Archive, error) {
+				archived = append(archived, a)
+				return a, nil
+			}).Times(2)
+		repo.EXPECT().PurgeRestored(mock.Anything, retentionNow.Add(-7*day)).Return(0, nil)
+
+		assert.NoError(t, s.Archive(context.Background()))
+		assert.Len(t, archived, 2)
+		assert.Equal(t, int64(1), archived[0].FromSequence)
+		assert.Equal(t, int64(2), archived[0].ToSequence)
+		assert.Equal(t, old.Add(time.Hour), archived[0].EndTime)
+		assert.Equal(t, int64(3), archived[1].FromSequence)
+		assert.Contains(t, archived[1].ObjectKey, "org-short/2026/02/21/")
+	})
+	t.Run("should not mark logs archived when the store write fails", func(t *testing.T) {
+		s, repo, store := newRetentionService(t, audit.RetentionConfig{
+			DefaultHotPeriod: day,
+			BatchSize:        10,
+		})
+		repo.EXPECT().ListPolicies(mock.Anything).Return(nil, nil)
+		repo.EXPECT().ListArchivableOrgs(mock.Anything, retentionNow.Add(-day)).Return([]string{"org-1"}, nil)
+		repo.EXPECT().ListArchivable(mock.Anything, "org-1", retentionNow.Add(-day), 10).
+			Return(chainLinks("org-1", 1, 2, retentionNow.Add(-2*day)), nil)
+		store.EXPECT().Write(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("bucket unavailable"))
+
+		assert.ErrorContains(t, s.Archive(context.Background()), "bucket unavailable")
+	})
+	t.Run("should keep logs forever without any hot period", func(t *testing.T) {
+		s, repo, _ := newRetentionService(t, audit.RetentionConfig{})
+		repo.EXPECT().ListPolicies(mock.Anything).Return(nil, nil)
+
+		assert.NoError(t, s.Archive(context.Background()))
+	})
+}
+
+func TestRetentionService_Restore(t *testing.T) {
+	start := retentionNow.Add(-48 * time.Hour)
+	end := retentionNow.Add(-45 * time.Hour)
+
+	s, repo, store := newRetentionService(t, audit.RetentionConfig{})
+	repo.EXPECT().ListArchives(mock.Anything, "org-1", start, end).Return([]audit.Archive{
+		{OrgID: "org-1", ObjectKey: "a.ndjson.gz"},
+	}, nil)
+	// logs every hour from 50h ago, only the ones within the range are restored
+	store.EXPECT().Read(mock.Anything, "a.ndjson.gz").Return(chainLinks("org-1", 1, 10, retentionNow.Add(-50*time.Hour)), nil)
+	repo.EXPECT().Restore(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, links []audit.ChainLink) (int, error) {
+			assert.Equal(t, int64(3), links[0].Sequence)
+			assert.Equal(t, int64(6), links[len(links)-1].Sequence)
+			return len(links), nil
+		})
+
+	count, err := s.Restore(context.Background(), "org-1", start, end)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	_, err = s.Restore(context.Background(), "org-1", end, start)
+	assert.ErrorIs(t, err, audit.ErrInvalidRestoreRange)
+}
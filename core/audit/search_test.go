@@ -0,0 +1,27 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/audit/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Search(t *testing.T) {
+	repo := mocks.NewRepository(t)
+	s := audit.NewService("frontier", repo, audit.NewNoopWebhookService())
+
+	_, err := s.Search(context.Background(), audit.Filter{OrgID: "org-1", Cursor: "garbage!"})
+	assert.ErrorIs(t, err, audit.ErrInvalidCursor)
+
+	flt := audit.Filter{OrgID: "org-1", ActorID: "user-1", Metadata: map[string]string{"ip": "10.0.0.1"}}
+	repo.EXPECT().Search(mock.Anything, flt).Return(audit.SearchResult{
+		ActionFacets: map[string]int{"app.project.updated": 3},
+	}, nil)
+	result, err := s.Search(context.Background(), flt)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.ActionFacets["app.project.updated"])
+}
@@ -2,6 +2,7 @@ package audit
 
 import (
 	"context"
+	"io"
 
 	"golang.org/x/exp/slices"
 
@@ -13,6 +14,7 @@ type Repository interface {
 	Create(context.Context, *Log) error
 	List(context.Context, Filter) ([]Log, error)
 	GetByID(context.Context, string) (Log, error)
+	Search(context.Context, Filter) (SearchResult, error)
 }
 
 type Publisher interface {
@@ -103,6 +105,16 @@ func (s *Service) List(ctx context.Context, flt Filter) ([]Log, error) {
 	return s.repository.List(ctx, flt)
 }
 
+// Search returns a page of logs matching the filter with counts per action
+func (s *Service) Search(ctx context.Context, flt Filter) (SearchResult, error) {
+	if flt.Cursor != "" {
+		if _, err := DecodeCursor(flt.Cursor); err != nil {
+			return SearchResult{}, err
+		}
+	}
+	return s.repository.Search(ctx, flt)
+}
+
 func (s *Service) GetByID(ctx context.Context, id string) (Log, error) {
 	return s.repository.GetByID(ctx, id)
 }
@@ -134,6 +146,12 @@ func TransformToEventData(l *Log) map[string]interface{} {
 	if l.Actor.Type != "" {
 		result["actor"].(map[string]any)["type"] = l.Actor.Type
 	}
+	if l.Actor.ImpersonatedBy != nil {
+		result["actor"].(map[string]any)["impersonated_by"] = map[string]any{
+			"id":   l.Actor.ImpersonatedBy.ID,
+			"type": l.Actor.ImpersonatedBy.Type,
+		}
+	}
 	if l.Source != "" {
 		result["source"] = l.Source
 	}
@@ -143,3 +161,11 @@ func TransformToEventData(l *Log) map[string]interface{} {
 	result["metadata"] = anyMap
 	return result
 }
+
+// Close releases the resources held by the repository e.g. flushes the export sinks
+func (s *Service) Close() error {
+	if closer, ok := s.repository.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mcuadros/go-defaults"
+	"github.com/raystack/frontier/internal/metrics"
+	"github.com/raystack/salt/log"
+)
+
+var ErrSinkClosed = errors.New("audit sink is closed")
+
+const (
+	sinkStatusWritten = "written"
+	sinkStatusFailed  = "failed"
+	sinkStatusDropped = "dropped"
+)
+
+// OverflowPolicy decides what happens to a log when the buffer of a sink is full
+type OverflowPolicy string
+
+const (
+	// OverflowDropNewest discards the incoming log
+	OverflowDropNewest OverflowPolicy = "drop_newest"
+	// OverflowDropOldest discards the oldest buffered log to make room for the incoming one
+	OverflowDropOldest OverflowPolicy = "drop_oldest"
+	// OverflowBlock waits for room in the buffer up to BlockTimeout before discarding the incoming log
+	OverflowBlock OverflowPolicy = "block"
+)
+
+// SinkWriter writes a batch of logs to an external system
+type SinkWriter interface {
+	Write(ctx context.Context, logs []Log) error
+	Close() error
+}
+
+type BufferConfig struct {
+	// Size is the max number of logs held in memory waiting to be written
+	Size int `yaml:"size" mapstructure:"size" default:"1000"`
+	// BatchSize is the max number of logs written in a single call
+	BatchSize int `yaml:"batch_size" mapstructure:"batch_size" default:"100"`
+	// FlushInterval is the max time a log waits in the buffer before being written
+	FlushInterval time.Duration `yaml:"flush_interval" mapstructure:"flush_interval" default:"1s"`
+	// Overflow is the policy applied when the buffer is full
+	Overflow OverflowPolicy `yaml:"overflow" mapstructure:"overflow" default:"drop_newest"`
+	// BlockTimeout is the max time a caller waits for room in the buffer with block policy
+	BlockTimeout time.Duration `yaml:"block_timeout" mapstructure:"block_timeout" default:"50ms"`
+	// MaxRetries is the number of times a failed batch is retried before it is discarded
+	MaxRetries int `yaml:"max_retries" mapstructure:"max_retries" default:"3"`
+	// RetryBackoff is the wait between two retries of a failed batch
+	RetryBackoff time.Duration `yaml:"retry_backoff" mapstructure:"retry_backoff" default:"1s"`
+}
+
+// BufferedSink decouples the audit log producer from a slow or unavailable
+// SinkWriter. Logs are queued in memory and written in batches by a background
+// worker, writes never block the caller beyond the configured overflow policy.
+type BufferedSink struct {
+	log    log.Logger
+	name   string
+	writer SinkWriter
+	cfg    BufferConfig
+
+	queue   chan Log
+	done    chan struct{}
+	closed  bool
+	closeMu sync.RWMutex
+	wg      sync.WaitGroup
+}
+
+func NewBufferedSink(logger log.Logger, name string, writer SinkWriter, cfg BufferConfig) *BufferedSink {
+	if cfg.Size <= 0 {
+		cfg.Size = 1
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 1
+	}
+	if cfg.FlushInterval <= 0 {
+		cfg.FlushInterval = time.Second
+	}
+	s := &BufferedSink{
+		log:    logger,
+		name:   name,
+		writer: writer,
+		cfg:    cfg,
+		queue:  make(chan Log, cfg.Size),
+		done:   make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s
+}
+
+func (s *BufferedSink) Name() string {
+	return s.name
+}
+
+// Enqueue adds the log to the buffer applying the overflow policy if it is full
+func (s *BufferedSink) Enqueue(l Log) error {
+	s.closeMu.RLock()
+	defer s.closeMu.RUnlock()
+	if s.closed {
+		return ErrSinkClosed
+	}
+	defer s.recordQueueLength()
+
+	select {
+	case s.queue <- l:
+		return nil
+	default:
+	}
+
+	switch s.cfg.Overflow {
+	case OverflowDropOldest:
+		select {
+		case <-s.queue:
+			s.record(sinkStatusDropped, 1)
+		default:
+		}
+		select {
+		case s.queue <- l:
+			return nil
+		default:
+		}
+	case OverflowBlock:
+		timer := time.NewTimer(s.cfg.BlockTimeout)
+		defer timer.Stop()
+		select {
+		case s.queue <- l:
+			return nil
+		case <-timer.C:
+		}
+	}
+	s.record(sinkStatusDropped, 1)
+	return nil
+}
+
+// Close stops accepting new logs, flushes the buffer and closes the writer
+func (s *BufferedSink) Close() error {
+	s.closeMu.Lock()
+	if s.closed {
+		s.closeMu.Unlock()
+		return nil
+	}
+	s.closed = true
+	close(s.done)
+	s.closeMu.Unlock()
+
+	s.wg.Wait()
+	return s.writer.Close()
+}
+
+func (s *BufferedSink) run() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.cfg.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Log, 0, s.cfg.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.write(batch)
+		batch = make([]Log, 0, s.cfg.BatchSize)
+		s.recordQueueLength()
+	}
+	for {
+		select {
+		case l := <-s.queue:
+			batch = append(batch, l)
+			if len(batch) >= s.cfg.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.done:
+			// drain whatever is left in the buffer
+			for {
+				select {
+				case l := <-s.queue:
+					batch = append(batch, l)
+					if len(batch) >= s.cfg.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *BufferedSink) write(batch []Log) {
+	var err error
+	for attempt := 0; attempt <= s.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(s.cfg.RetryBackoff):
+			case <-s.done:
+				// shutting down, don't wait for the backoff anymore
+			}
+		}
+		err = s.writeOnce(batch)
+		if err == nil {
+			s.record(sinkStatusWritten, len(batch))
+			return
+		}
+	}
+	s.record(sinkStatusFailed, len(batch))
+	s.log.Warn("failed to write audit logs to sink", "sink", s.name, "count", len(batch), "err", err)
+}
+
+func (s *BufferedSink) writeOnce(batch []Log) error {
+	if metrics.AuditSinkWriteLatency != nil {
+		record := metrics.AuditSinkWriteLatency(s.name)
+		defer record()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.FlushInterval+10*time.Second)
+	defer cancel()
+	if err := s.writer.Write(ctx, batch); err != nil {
+		return fmt.Errorf("%s: %w", s.name, err)
+	}
+	return nil
+}
+
+func (s *BufferedSink) record(status string, count int) {
+	if metrics.AuditSinkEvents != nil {
+		metrics.AuditSinkEvents.WithLabelValues(s.name, status).Add(float64(count))
+	}
+}
+
+func (s *BufferedSink) recordQueueLength() {
+	if metrics.AuditSinkQueueLength != nil {
+		metrics.AuditSinkQueueLength.WithLabelValues(s.name).Set(float64(len(s.queue)))
+	}
+}
+
+type SinkType string
+
+const (
+	SinkTypeFile   SinkType = "file"
+	SinkTypeHTTP   SinkType = "http"
+	SinkTypeBroker SinkType = "broker"
+)
+
+// SinkConfig configures an external destination where audit logs are
+// exported in addition to the primary audit repository
+type SinkConfig struct {
+	// Name identifies the sink in logs and metrics
+	Name string   `yaml:"name" mapstructure:"name"`
+	Type SinkType `yaml:"type" mapstructure:"type"`
+
+	File FileSinkConfig `yaml:"file" mapstructure:"file"`
+	HTTP HTTPSinkConfig `yaml:"http" mapstructure:"http"`
+	// TopicURL of the broker, e.g. kafka://audit-logs or mem://audit-logs
+	TopicURL string `yaml:"topic_url" mapstructure:"topic_url"`
+
+	Buffer BufferConfig `yaml:"buffer" mapstructure:"buffer"`
+}
+
+// NewSinks builds a buffered sink for every config, sinks created before
+// a failure are closed
+func NewSinks(ctx context.Context, logger log.Logger, cfgs []SinkConfig) ([]Sink, error) {
+	var sinks []Sink
+	for idx, cfg := range cfgs {
+		// defaults of list items are not applied while loading the config
+		defaults.SetDefaults(&cfg)
+		if cfg.Name == "" {
+			cfg.Name = fmt.Sprintf("%s-%d", cfg.Type, idx)
+		}
+
+		var writer SinkWriter
+		var err error
+		switch cfg.Type {
+		case SinkTypeFile:
+			writer, err = NewRotatingFileWriter(cfg.File)
+		case SinkTypeHTTP:
+			writer, err = NewHTTPWriter(cfg.HTTP)
+		case SinkTypeBroker:
+			var producer *PubSubProducer
+			if producer, err = NewPubSubProducer(ctx, cfg.TopicURL); err == nil {
+				writer = NewBrokerWriter(producer)
+			}
+		default:
+			err = fmt.Errorf("%w: unknown sink type %q", ErrInvalidSink, cfg.Type)
+		}
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return nil, fmt.Errorf("audit sink %s: %w", cfg.Name, err)
+		}
+		sinks = append(sinks, NewBufferedSink(logger, cfg.Name, writer, cfg.Buffer))
+	}
+	return sinks, nil
+}
@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingWriter holds the first write until released so the buffer can be filled
+type blockingWriter struct {
+	mu       sync.Mutex
+	written  []string
+	started  chan struct{}
+	release  chan struct{}
+	once     sync.Once
+	failures int
+	closed   bool
+}
+
+func newBlockingWriter() *blockingWriter {
+	return &blockingWriter{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (w *blockingWriter) Write(ctx context.Context, logs []Log) error {
+	w.once.Do(func() {
+		close(w.started)
+		<-w.release
+	})
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failures > 0 {
+		w.failures--
+		return errors.New("collector unavailable")
+	}
+	for _, l := range logs {
+		w.written = append(w.written, l.ID)
+	}
+	return nil
+}
+
+func (w *blockingWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestBufferedSink_Overflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy OverflowPolicy
+		want   []string
+	}{
+		{
+			name:   "should discard incoming log with drop_newest",
+			policy: OverflowDropNewest,
+			want:   []string{"0", "1", "2"},
+		},
+		{
+			name:   "should discard oldest buffered log with drop_oldest",
+			policy: OverflowDropOldest,
+			want:   []string{"0", "2", "3"},
+		},
+		{
+			name:   "should discard incoming log after block timeout",
+			policy: OverflowBlock,
+			want:   []string{"0", "1", "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := newBlockingWriter()
+			sink := NewBufferedSink(log.NewNoop(), "test", writer, BufferConfig{
+				Size:          2,
+				BatchSize:     1,
+				FlushInterval: time.Hour,
+				Overflow:      tt.policy,
+				BlockTimeout:  10 * time.Millisecond,
+			})
+
+			assert.NoError(t, sink.Enqueue(Log{ID: "0"}))
+			<-writer.started
+			for _, id := range []string{"1", "2", "3"} {
+				assert.NoError(t, sink.Enqueue(Log{ID: id}))
+			}
+			close(writer.release)
+
+			assert.NoError(t, sink.Close())
+			assert.Equal(t, tt.want, writer.written)
+			assert.True(t, writer.closed)
+			assert.ErrorIs(t, sink.Enqueue(Log{ID: "4"}), ErrSinkClosed)
+		})
+	}
+}
+
+func TestBufferedSink_Retry(t *testing.T) {
+	writer := newBlockingWriter()
+	writer.failures = 2
+	close(writer.release)
+	sink := NewBufferedSink(log.NewNoop(), "test", writer, BufferConfig{
+		Size:          10,
+		BatchSize:     10,
+		FlushInterval: time.Hour,
+		MaxRetries:    2,
+		RetryBackoff:  time.Millisecond,
+	})
+
+	assert.NoError(t, sink.Enqueue(Log{ID: "0"}))
+	assert.NoError(t, sink.Enqueue(Log{ID: "1"}))
+	assert.NoError(t, sink.Close())
+	assert.Equal(t, []string{"0", "1"}, writer.written)
+}
@@ -1,6 +1,7 @@
 package authenticate
 
 import (
+	"slices"
 	"time"
 
 	"github.com/raystack/frontier/core/authenticate/strategy"
@@ -8,17 +9,37 @@ import (
 	"github.com/raystack/frontier/core/serviceuser"
 	"github.com/raystack/frontier/core/user"
 
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
 	"github.com/raystack/frontier/pkg/metadata"
 
 	"github.com/google/uuid"
 )
 
+// scopes of the frontier api oauth2 clients are granted, operations
+// without a scope are never allowed to them
+const (
+	// ScopeRead allows the operations reading from the frontier service
+	ScopeRead = "frontier:read"
+	// ScopeWrite allows any operation of the frontier service, it covers
+	// ScopeRead
+	ScopeWrite = "frontier:write"
+	// ScopeAdmin allows the operations of the admin service to superusers
+	ScopeAdmin = "frontier:admin"
+)
+
 type AuthMethod string
 
 const (
 	MailOTPAuthMethod  = AuthMethod(strategy.MailOTPAuthMethod)
 	MailLinkAuthMethod = AuthMethod(strategy.MailLinkAuthMethod)
 	PassKeyAuthMethod  = AuthMethod(strategy.PasskeyAuthMethod)
+	PasswordAuthMethod = AuthMethod(strategy.PasswordAuthMethod)
+	// SSOAuthMethod logs in with the identity provider of the organization
+	// which verified the email domain
+	SSOAuthMethod = AuthMethod("sso")
+	// PasswordResetAuthMethod flows hold the password reset tokens sent to users
+	PasswordResetAuthMethod = AuthMethod("password_reset")
 )
 
 func (m AuthMethod) String() string {
@@ -105,15 +126,21 @@ type RegistrationStartRequest struct {
 	// For most cases it could be host of frontier but in case of proxies, this will be proxy public endpoint.
 	// callback_url should be one of the allowed urls configured at instance level
 	CallbackUrl string
+
+	// ClientIP is the address the request came from, used for rate limiting
+	ClientIP string
 }
 
 type RegistrationFinishRequest struct {
 	Method string
 
-	// used for OIDC & mail otp auth strategy
+	// used for OIDC, mail otp & password auth strategy
 	Code        string
 	State       string
 	StateConfig map[string]any
+
+	// ClientIP is the address the request came from, used for rate limiting
+	ClientIP string
 }
 
 type RegistrationStartResponse struct {
@@ -136,4 +163,52 @@ type Principal struct {
 
 	User        *user.User
 	ServiceUser *serviceuser.ServiceUser
+
+	// AuthenticatedAt is when the user last logged in, it is zero for
+	// principals which present their credentials on every request
+	AuthenticatedAt time.Time
+
+	// ImpersonatedBy is the superuser acting as this principal, nil unless
+	// the principal is authenticated by an impersonation session
+	ImpersonatedBy *Principal
+	// ImpersonationSessionID is the session the impersonation is bound to,
+	// tokens built for the principal carry it along
+	ImpersonationSessionID string
+
+	// ClientID is the oauth2 client acting on behalf of the user, it is only
+	// allowed the operations covered by the Scopes granted to it
+	ClientID string
+	Scopes   []string
+}
+
+// HasScope reports if the principal is allowed the operations of the scope,
+// principals not acting through an oauth2 client are allowed all of them
+func (p Principal) HasScope(scope string) bool {
+	if p.ClientID == "" {
+		return true
+	}
+	if scope == "" {
+		return false
+	}
+	if scope == ScopeRead && slices.Contains(p.Scopes, ScopeWrite) {
+		return true
+	}
+	return slices.Contains(p.Scopes, scope)
+}
+
+// CheckRecentAuthentication returns ErrReauthRequired if the user logged in
+// longer than maxAge ago. Service users present their credentials on every
+// request and always pass, impersonated users never do as they can't log in
+// again.
+func (p Principal) CheckRecentAuthentication(maxAge time.Duration, now time.Time) error {
+	if p.Type == schema.ServiceUserPrincipal {
+		return nil
+	}
+	if p.ImpersonatedBy != nil {
+		return errors.ErrForbidden
+	}
+	if !p.AuthenticatedAt.IsZero() && now.Sub(p.AuthenticatedAt) <= maxAge {
+		return nil
+	}
+	return ErrReauthRequired
 }
@@ -0,0 +1,67 @@
+package authenticate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_HasScope(t *testing.T) {
+	client := authenticate.Principal{ID: "user-id", ClientID: "client-id", Scopes: []string{authenticate.ScopeWrite}}
+	assert.True(t, client.HasScope(authenticate.ScopeRead))
+	assert.True(t, client.HasScope(authenticate.ScopeWrite))
+	assert.False(t, client.HasScope(authenticate.ScopeAdmin))
+	assert.False(t, client.HasScope(""))
+
+	user := authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal}
+	assert.True(t, user.HasScope(authenticate.ScopeAdmin))
+	assert.True(t, user.HasScope(""))
+}
+
+func TestPrincipal_CheckRecentAuthentication(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		principal authenticate.Principal
+		want      error
+	}{
+		{
+			name:      "should pass users who logged in recently",
+			principal: authenticate.Principal{Type: schema.UserPrincipal, AuthenticatedAt: now.Add(-time.Minute)},
+		},
+		{
+			name:      "should ask users who logged in long ago to log in again",
+			principal: authenticate.Principal{Type: schema.UserPrincipal, AuthenticatedAt: now.Add(-time.Hour)},
+			want:      authenticate.ErrReauthRequired,
+		},
+		{
+			name:      "should ask oauth2 clients to log in again",
+			principal: authenticate.Principal{Type: schema.UserPrincipal, ClientID: "client-id"},
+			want:      authenticate.ErrReauthRequired,
+		},
+		{
+			name:      "should pass service users",
+			principal: authenticate.Principal{Type: schema.ServiceUserPrincipal},
+		},
+		{
+			name: "should reject impersonated users",
+			principal: authenticate.Principal{Type: schema.UserPrincipal, AuthenticatedAt: now,
+				ImpersonatedBy: &authenticate.Principal{ID: "admin-id"}},
+			want: errors.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.principal.CheckRecentAuthentication(15*time.Minute, now)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -13,13 +13,25 @@ type Config struct {
 
 	AuthorizedRedirectURLs []string `yaml:"authorized_redirect_urls" mapstructure:"authorized_redirect_urls" `
 
-	OIDCConfig map[string]OIDCConfig `yaml:"oidc_config" mapstructure:"oidc_config"`
-	Session    SessionConfig         `yaml:"session" mapstructure:"session"`
-	Token      TokenConfig           `yaml:"token" mapstructure:"token"`
-	MailOTP    MailOTPConfig         `yaml:"mail_otp" mapstructure:"mail_otp"`
-	MailLink   MailLinkConfig        `yaml:"mail_link" mapstructure:"mail_link"`
-	PassKey    PassKeyConfig         `yaml:"passkey" mapstructure:"passkey"`
-	TestUsers  testusers.Config      `yaml:"test_users" mapstructure:"test_users"`
+	OIDCConfig    map[string]OIDCConfig `yaml:"oidc_config" mapstructure:"oidc_config"`
+	Session       SessionConfig         `yaml:"session" mapstructure:"session"`
+	Token         TokenConfig           `yaml:"token" mapstructure:"token"`
+	MailOTP       MailOTPConfig         `yaml:"mail_otp" mapstructure:"mail_otp"`
+	MailLink      MailLinkConfig        `yaml:"mail_link" mapstructure:"mail_link"`
+	PassKey       PassKeyConfig         `yaml:"passkey" mapstructure:"passkey"`
+	TestUsers     testusers.Config      `yaml:"test_users" mapstructure:"test_users"`
+	OAuth2        OAuth2Config          `yaml:"oauth2" mapstructure:"oauth2"`
+	SAML          SAMLConfig            `yaml:"saml" mapstructure:"saml"`
+	SSO           SSOConfig             `yaml:"sso" mapstructure:"sso"`
+	MFA           MFAConfig             `yaml:"mfa" mapstructure:"mfa"`
+	RateLimit     RateLimitConfig       `yaml:"rate_limit" mapstructure:"rate_limit"`
+	Impersonation ImpersonationConfig   `yaml:"impersonation" mapstructure:"impersonation"`
+	Password      PasswordConfig        `yaml:"password" mapstructure:"password"`
+
+	// RecentAuthentication lists the sensitive operations users must have
+	// logged in recently to perform, DefaultRecentAuthenticationRules are
+	// used if it isn't set
+	RecentAuthentication []RecentAuthenticationRule `yaml:"recent_authentication" mapstructure:"recent_authentication"`
 }
 
 type TokenConfig struct {
@@ -37,6 +49,11 @@ type TokenConfig struct {
 	// Validity is the duration for which the token is valid
 	Validity time.Duration `yaml:"validity" mapstructure:"validity" default:"1h"`
 
+	// RevocationSyncInterval is how often each instance reloads the revoked
+	// tokens, a token revoked through another instance is still accepted for
+	// up to this duration
+	RevocationSyncInterval time.Duration `yaml:"revocation_sync_interval" mapstructure:"revocation_sync_interval" default:"15s"`
+
 	Claims TokenClaimConfig `yaml:"claims" mapstructure:"claims"`
 }
 
@@ -45,6 +62,184 @@ type TokenClaimConfig struct {
 	AddUserEmailClaim bool `yaml:"add_user_email" mapstructure:"add_user_email" default:"true"`
 }
 
+// OAuth2Config configures frontier as an oauth2 authorization server for
+// registered third party clients
+type OAuth2Config struct {
+	// LoginURL is where users without a session are sent before authorizing a client,
+	// the authorization url is passed as return_to query param
+	LoginURL string `yaml:"login_url" mapstructure:"login_url"`
+	// ConsentURL is an external page asking users to approve a client, it receives
+	// the consent_challenge query param. If empty, a built-in page is served
+	ConsentURL string `yaml:"consent_url" mapstructure:"consent_url"`
+	// CodeValidity is the duration for which the authorization code and the
+	// pending consent are valid
+	CodeValidity time.Duration `yaml:"code_validity" mapstructure:"code_validity" default:"5m"`
+	// RefreshTokenValidity is the max lifetime of a refresh token, it is also
+	// bounded by the session the token was issued in
+	RefreshTokenValidity time.Duration `yaml:"refresh_token_validity" mapstructure:"refresh_token_validity" default:"720h"`
+	// DeviceVerificationURL is an external page where users enter the code shown
+	// by a device, it receives the user_code query param. If empty, a built-in
+	// page is served
+	DeviceVerificationURL string `yaml:"device_verification_url" mapstructure:"device_verification_url"`
+	// DeviceCodeValidity is the duration a user has to approve a device
+	DeviceCodeValidity time.Duration `yaml:"device_code_validity" mapstructure:"device_code_validity" default:"10m"`
+	// DevicePollInterval is the minimum time a device waits between two polls
+	// of the token endpoint
+	DevicePollInterval time.Duration `yaml:"device_poll_interval" mapstructure:"device_poll_interval" default:"5s"`
+}
+
+// SAMLConfig configures frontier as a saml service provider for the identity
+// providers registered by organizations
+type SAMLConfig struct {
+	// URL is the public url frontier http server is reachable at, the service
+	// provider entity id and assertion consumer service are derived from it.
+	// SAML logins are disabled if it's empty
+	URL string `yaml:"url" mapstructure:"url"`
+	// Validity is the duration a user has to finish a login at the identity provider
+	Validity time.Duration `yaml:"validity" mapstructure:"validity" default:"10m"`
+}
+
+// SSOConfig configures the OIDC identity providers registered by organizations
+type SSOConfig struct {
+	// EncryptionKey encrypts the client secrets of the providers at rest, it
+	// must be 32 chars
+	EncryptionKey string `yaml:"encryption_key" mapstructure:"encryption_key" default:"hash-secret-should-be-32-chars--"`
+	// Validity is the duration a user has to finish a login at the identity provider
+	Validity time.Duration `yaml:"validity" mapstructure:"validity" default:"10m"`
+}
+
+// MFAConfig configures the second factor users verify after a primary strategy
+type MFAConfig struct {
+	// Issuer is the name authenticator apps show the account under
+	Issuer string `yaml:"issuer" mapstructure:"issuer" default:"Frontier"`
+	// EncryptionKey encrypts the totp secrets at rest, it must be 32 chars
+	EncryptionKey string `yaml:"encryption_key" mapstructure:"encryption_key" default:"hash-secret-should-be-32-chars--"`
+	// Validity is the duration a user has to verify the second factor once
+	// a primary strategy succeeded
+	Validity time.Duration `yaml:"validity" mapstructure:"validity" default:"10m"`
+}
+
+// ImpersonationConfig configures the sessions superusers open to act as
+// another user
+type ImpersonationConfig struct {
+	// Validity is the lifespan of an impersonation session, it isn't refreshed
+	Validity time.Duration `yaml:"validity" mapstructure:"validity" default:"30m"`
+}
+
+// RecentAuthenticationRule asks users who logged in longer than MaxAge ago
+// to authenticate again before performing the operation, even with a valid
+// session. Operation is the full method name of an rpc, e.g.
+// /raystack.frontier.v1beta1.FrontierService/DeleteUser, or the route pattern
+// of an http endpoint, e.g. POST /password/change
+type RecentAuthenticationRule struct {
+	Operation string        `yaml:"operation" mapstructure:"operation"`
+	MaxAge    time.Duration `yaml:"max_age" mapstructure:"max_age"`
+}
+
+// DefaultRecentAuthenticationRules cover the operations deleting accounts,
+// issuing credentials or handing out access
+var DefaultRecentAuthenticationRules = []RecentAuthenticationRule{
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/DeleteOrganization", MaxAge: 5 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/DisableOrganization", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/DeleteUser", MaxAge: 5 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/CreateServiceUserCredential", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/CreateServiceUserToken", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/CreateServiceUserJWK", MaxAge: 15 * time.Minute},
+
+	// billing
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/UpdateBillingAccount", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/DeleteBillingAccount", MaxAge: 5 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/DisableBillingAccount", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/CancelSubscription", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/ChangeSubscription", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.FrontierService/UpdateSubscription", MaxAge: 15 * time.Minute},
+
+	// admin
+	{Operation: "/raystack.frontier.v1beta1.AdminService/CreateWebhook", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.AdminService/UpdateWebhook", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.AdminService/DeleteWebhook", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.AdminService/AddPlatformUser", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.AdminService/RemovePlatformUser", MaxAge: 15 * time.Minute},
+	{Operation: "/raystack.frontier.v1beta1.AdminService/UpdateBillingAccountLimits", MaxAge: 15 * time.Minute},
+
+	// http endpoints
+	{Operation: "POST /mfa/totp/disable", MaxAge: 15 * time.Minute},
+	{Operation: "POST /password/change", MaxAge: 15 * time.Minute},
+	{Operation: "POST /passkeys/register", MaxAge: 15 * time.Minute},
+	{Operation: "DELETE /passkeys/{id}", MaxAge: 15 * time.Minute},
+	{Operation: "POST /impersonations", MaxAge: 15 * time.Minute},
+	{Operation: "POST /v1beta1/policies/timebound", MaxAge: 15 * time.Minute},
+	{Operation: "POST /access-requests/{id}/approve", MaxAge: 15 * time.Minute},
+}
+
+// RecentAuthenticationRules indexes the max age of the configured rules by
+// operation, falling back to the default rules
+func (c Config) RecentAuthenticationRules() map[string]time.Duration {
+	rules := c.RecentAuthentication
+	if rules == nil {
+		rules = DefaultRecentAuthenticationRules
+	}
+	index := make(map[string]time.Duration, len(rules))
+	for _, rule := range rules {
+		index[rule.Operation] = rule.MaxAge
+	}
+	return index
+}
+
+// PasswordConfig configures the username/password strategy, passwords are
+// hashed with argon2id
+type PasswordConfig struct {
+	Enabled bool `yaml:"enabled" mapstructure:"enabled" default:"false"`
+	// MinLength and MaxLength bound the number of characters of a password,
+	// the max length avoids spending resources hashing huge inputs
+	MinLength int `yaml:"min_length" mapstructure:"min_length" default:"12"`
+	MaxLength int `yaml:"max_length" mapstructure:"max_length" default:"128"`
+	// Require* add composition rules on top of the length
+	RequireUppercase bool `yaml:"require_uppercase" mapstructure:"require_uppercase" default:"false"`
+	RequireLowercase bool `yaml:"require_lowercase" mapstructure:"require_lowercase" default:"false"`
+	RequireDigit     bool `yaml:"require_digit" mapstructure:"require_digit" default:"false"`
+	RequireSymbol    bool `yaml:"require_symbol" mapstructure:"require_symbol" default:"false"`
+	// BreachedPasswordsFile is the path to the pwned passwords sha1 hashes
+	// ordered by hash, new passwords found in it are rejected. The check is
+	// skipped if it's empty
+	BreachedPasswordsFile string `yaml:"breached_passwords_file" mapstructure:"breached_passwords_file"`
+	// Argon2 are the cost parameters of new hashes, existing hashes are
+	// upgraded when users log in
+	Argon2 Argon2Config `yaml:"argon2" mapstructure:"argon2"`
+	// ResetValidity is the duration a password reset token can be used for
+	ResetValidity time.Duration `yaml:"reset_validity" mapstructure:"reset_validity" default:"1h"`
+	ResetSubject  string        `yaml:"reset_subject" mapstructure:"reset_subject" default:"Frontier - Reset your password"`
+	ResetBody     string        `yaml:"reset_body" mapstructure:"reset_body" default:"Hi {{.Email}},<br> Use the following token to reset your password.<h3>{{.Token}}</h3>It will expire in 1 hour. If you didn't ask for a reset, ignore this mail."`
+}
+
+type Argon2Config struct {
+	// Memory is in KiB
+	Memory      uint32 `yaml:"memory" mapstructure:"memory" default:"65536"`
+	Iterations  uint32 `yaml:"iterations" mapstructure:"iterations" default:"3"`
+	Parallelism uint8  `yaml:"parallelism" mapstructure:"parallelism" default:"4"`
+}
+
+// RateLimitConfig limits how often mail otp and mail link flows are started
+// and finished, a limit of 0 disables it
+type RateLimitConfig struct {
+	// Store keeps the hits of rate limited keys, "memory" limits each replica
+	// on its own while "postgres" shares the limits between replicas
+	Store string `yaml:"store" mapstructure:"store" default:"memory"`
+	// Window is the sliding window requests are counted in
+	Window time.Duration `yaml:"window" mapstructure:"window" default:"15m"`
+	// StartEmail is the number of flows started for an email in a window
+	StartEmail int `yaml:"start_email" mapstructure:"start_email" default:"5"`
+	// StartIP is the number of flows started from an ip address in a window
+	StartIP int `yaml:"start_ip" mapstructure:"start_ip" default:"30"`
+	// StartOrg is the number of flows started for all the emails of the
+	// domains an organization verified in a window
+	StartOrg int `yaml:"start_org" mapstructure:"start_org" default:"200"`
+	// FinishEmail is the number of codes submitted for an email in a window
+	FinishEmail int `yaml:"finish_email" mapstructure:"finish_email" default:"10"`
+	// FinishIP is the number of codes submitted from an ip address in a window
+	FinishIP int `yaml:"finish_ip" mapstructure:"finish_ip" default:"50"`
+}
+
 type SessionConfig struct {
 	HashSecretKey  string `mapstructure:"hash_secret_key" yaml:"hash_secret_key" default:"hash-secret-should-be-32-chars--"`
 	BlockSecretKey string `mapstructure:"block_secret_key" yaml:"block_secret_key" default:"block-secret-should-be-32-chars-"`
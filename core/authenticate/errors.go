@@ -3,5 +3,6 @@ package authenticate
 import "errors"
 
 var (
-	ErrInvalidID = errors.New("user id is invalid")
+	ErrInvalidID      = errors.New("user id is invalid")
+	ErrPasswordNotSet = errors.New("password isn't set")
 )
@@ -0,0 +1,400 @@
+package authenticate
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/passkey"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/metadata"
+)
+
+const (
+	passkeySessionKey = "passkey_session"
+	passkeyTypeKey    = "passkey_type"
+	passkeyUserKey    = "user_id"
+	// legacyPasskeyKey is the user metadata passkeys were stored in before
+	// they got their own table, they are moved over on the next login
+	legacyPasskeyKey = "passkey_credentials"
+)
+
+// PasskeyRegistration is the challenge a logged in user signs with the new
+// passkey to add it to the account
+type PasskeyRegistration struct {
+	State   string
+	Options *protocol.CredentialCreation
+}
+
+func (s Service) passkeyEnabled() bool {
+	return s.webAuth != nil && s.passkeyRepo != nil
+}
+
+// startPasskey registers a new user, or logs in a user with one of the
+// passkeys of the account. Existing users without a passkey have to log in
+// with another method and add one, registering them here would let anyone
+// knowing the email take over the account.
+func (s Service) startPasskey(ctx context.Context, request RegistrationStartRequest, flow *Flow) (*RegistrationStartResponse, error) {
+	if !s.passkeyEnabled() {
+		return nil, ErrUnsupportedMethod
+	}
+	loggedInUser, err := s.userService.GetByID(ctx, request.Email)
+	if err != nil {
+		if errors.Is(err, user.ErrNotExist) {
+			return s.startPassKeyRegisterMethod(ctx, flow)
+		}
+		return nil, err
+	}
+	passkeys, err := s.userPasskeys(ctx, loggedInUser)
+	if err != nil {
+		return nil, err
+	}
+	if len(passkeys) == 0 {
+		return nil, passkey.ErrNotRegistered
+	}
+	return s.startPassKeyLoginMethod(ctx, flow, passkeys)
+}
+
+func (s Service) startPassKeyRegisterMethod(ctx context.Context, flow *Flow) (*RegistrationStartResponse, error) {
+	options, session, err := s.webAuth.BeginRegistration(strategy.NewPassKeyUser(flow.Email))
+	if err != nil {
+		return nil, err
+	}
+	if err = s.savePasskeySession(ctx, flow, strategy.PasskeyRegisterType, session); err != nil {
+		return nil, err
+	}
+	optionsBytes, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistrationStartResponse{
+		Flow:  flow,
+		State: flow.ID.String(),
+		StateConfig: map[string]any{
+			"options": optionsBytes,
+		},
+	}, nil
+}
+
+func (s Service) startPassKeyLoginMethod(ctx context.Context, flow *Flow, passkeys []passkey.Passkey) (*RegistrationStartResponse, error) {
+	options, session, err := s.webAuth.BeginLogin(
+		strategy.NewPasskeyUserWithCredentials(flow.Email, passkey.Credentials(passkeys)))
+	if err != nil {
+		return nil, err
+	}
+	if err = s.savePasskeySession(ctx, flow, strategy.PasskeyLoginType, session); err != nil {
+		return nil, err
+	}
+	optionsBytes, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RegistrationStartResponse{
+		Flow:  flow,
+		State: flow.ID.String(),
+		StateConfig: map[string]any{
+			"options": optionsBytes,
+		},
+	}, nil
+}
+
+func (s Service) applyPasskey(ctx context.Context, request RegistrationFinishRequest) (*RegistrationFinishResponse, error) {
+	if !s.passkeyEnabled() {
+		return nil, ErrUnsupportedMethod
+	}
+	flow, err := s.passkeyFlow(ctx, request.State)
+	if err != nil {
+		return nil, err
+	}
+	credentialResponse, ok := request.StateConfig["options"].(string)
+	if !ok {
+		return nil, ErrInvalidOIDCState
+	}
+
+	switch flow.Metadata[passkeyTypeKey] {
+	case strategy.PasskeyRegisterType:
+		return s.finishPassKeyRegisterMethod(ctx, flow, credentialResponse)
+	case strategy.PasskeyLoginType:
+		return s.finishPassKeyLoginMethod(ctx, flow, credentialResponse)
+	}
+	return nil, ErrFlowInvalid
+}
+
+func (s Service) finishPassKeyRegisterMethod(ctx context.Context, flow *Flow, credentialResponse string) (*RegistrationFinishResponse, error) {
+	response, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader([]byte(credentialResponse)))
+	if err != nil {
+		return nil, err
+	}
+	session, err := passkeySession(flow)
+	if err != nil {
+		return nil, err
+	}
+	credential, err := s.webAuth.CreateCredential(strategy.NewPassKeyUser(flow.Email), session, response)
+	if err != nil {
+		return nil, err
+	}
+
+	// the email may have signed up with another method since the flow started
+	if _, err = s.userService.GetByID(ctx, flow.Email); err == nil {
+		return nil, passkey.ErrNotRegistered
+	} else if !errors.Is(err, user.ErrNotExist) {
+		return nil, err
+	}
+	newUser, err := s.getOrCreateUser(ctx, flow.Email, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = s.passkeyRepo.Create(ctx, passkey.FromCredential(newUser.ID, *credential)); err != nil {
+		return nil, err
+	}
+	if err = s.consumeFlow(ctx, flow.ID); err != nil {
+		return nil, fmt.Errorf("failed to successfully register via passkey: %w", err)
+	}
+
+	return &RegistrationFinishResponse{
+		User: newUser,
+		Flow: flow,
+	}, nil
+}
+
+func (s Service) finishPassKeyLoginMethod(ctx context.Context, flow *Flow, credentialResponse string) (*RegistrationFinishResponse, error) {
+	response, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader([]byte(credentialResponse)))
+	if err != nil {
+		return nil, err
+	}
+	session, err := passkeySession(flow)
+	if err != nil {
+		return nil, err
+	}
+	existingUser, err := s.userService.GetByID(ctx, flow.Email)
+	if err != nil {
+		return nil, err
+	}
+	passkeys, err := s.passkeyRepo.ListByUser(ctx, existingUser.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.webAuth.ValidateLogin(
+		strategy.NewPasskeyUserWithCredentials(flow.Email, passkey.Credentials(passkeys)), session, response)
+	if err != nil {
+		return nil, err
+	}
+	if credential.Authenticator.CloneWarning {
+		return nil, passkey.ErrCloned
+	}
+	for _, p := range passkeys {
+		if bytes.Equal(p.CredentialID, credential.ID) {
+			if err = s.passkeyRepo.UpdateUsage(ctx, p.ID, credential.Authenticator.SignCount,
+				credential.Flags.BackupState, s.Now()); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	if err = s.consumeFlow(ctx, flow.ID); err != nil {
+		return nil, fmt.Errorf("failed to successfully login via passkey: %w", err)
+	}
+
+	return &RegistrationFinishResponse{
+		User: existingUser,
+		Flow: flow,
+	}, nil
+}
+
+// StartPasskeyRegistration starts adding a passkey to the account of the
+// logged in user, whatever method it logged in with
+func (s Service) StartPasskeyRegistration(ctx context.Context, principal Principal) (PasskeyRegistration, error) {
+	if !s.passkeyEnabled() {
+		return PasskeyRegistration{}, ErrUnsupportedMethod
+	}
+	if err := s.checkPasskeyPrincipal(principal); err != nil {
+		return PasskeyRegistration{}, err
+	}
+	passkeys, err := s.userPasskeys(ctx, *principal.User)
+	if err != nil {
+		return PasskeyRegistration{}, err
+	}
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(passkeys))
+	for _, p := range passkeys {
+		exclusions = append(exclusions, p.Credential().Descriptor())
+	}
+
+	options, session, err := s.webAuth.BeginRegistration(strategy.NewPassKeyUser(principal.User.Email),
+		webauthn.WithExclusions(exclusions))
+	if err != nil {
+		return PasskeyRegistration{}, err
+	}
+	flow := &Flow{
+		ID:        uuid.New(),
+		Method:    PassKeyAuthMethod.String(),
+		Email:     principal.User.Email,
+		CreatedAt: s.Now(),
+		ExpiresAt: s.Now().Add(defaultFlowExp),
+		Metadata: metadata.Metadata{
+			passkeyUserKey: principal.ID,
+		},
+	}
+	if err = s.savePasskeySession(ctx, flow, strategy.PasskeyAddType, session); err != nil {
+		return PasskeyRegistration{}, err
+	}
+	return PasskeyRegistration{
+		State:   flow.ID.String(),
+		Options: options,
+	}, nil
+}
+
+// FinishPasskeyRegistration verifies the credential signed by the new
+// passkey and adds it to the account of the logged in user
+func (s Service) FinishPasskeyRegistration(ctx context.Context, principal Principal, state string,
+	credentialResponse []byte, nickname string) (passkey.Passkey, error) {
+	if !s.passkeyEnabled() {
+		return passkey.Passkey{}, ErrUnsupportedMethod
+	}
+	if err := s.checkPasskeyPrincipal(principal); err != nil {
+		return passkey.Passkey{}, err
+	}
+	if nickname != "" {
+		var err error
+		if nickname, err = passkey.CleanNickname(nickname); err != nil {
+			return passkey.Passkey{}, err
+		}
+	}
+	flow, err := s.passkeyFlow(ctx, state)
+	if err != nil {
+		return passkey.Passkey{}, err
+	}
+	if flow.Metadata[passkeyTypeKey] != strategy.PasskeyAddType || flow.Metadata[passkeyUserKey] != principal.ID {
+		return passkey.Passkey{}, ErrFlowInvalid
+	}
+	response, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(credentialResponse))
+	if err != nil {
+		return passkey.Passkey{}, err
+	}
+	session, err := passkeySession(flow)
+	if err != nil {
+		return passkey.Passkey{}, err
+	}
+	credential, err := s.webAuth.CreateCredential(strategy.NewPassKeyUser(flow.Email), session, response)
+	if err != nil {
+		return passkey.Passkey{}, err
+	}
+
+	newPasskey := passkey.FromCredential(principal.ID, *credential)
+	newPasskey.Nickname = nickname
+	if newPasskey, err = s.passkeyRepo.Create(ctx, newPasskey); err != nil {
+		return passkey.Passkey{}, err
+	}
+	if err = s.consumeFlow(ctx, flow.ID); err != nil {
+		return passkey.Passkey{}, err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		LogWithAttrs(audit.UserPasskeyAddedEvent, audit.UserTarget(principal.ID), map[string]string{
+			"passkey_id": newPasskey.ID,
+			"nickname":   newPasskey.Nickname,
+		})
+	return newPasskey, nil
+}
+
+// checkPasskeyPrincipal allows users acting as themselves who logged in
+// recently to add a passkey, a stolen session shouldn't be enough to plant
+// a credential on the account
+func (s Service) checkPasskeyPrincipal(principal Principal) error {
+	if principal.Type != schema.UserPrincipal || principal.User == nil || principal.ImpersonatedBy != nil {
+		return errors.ErrForbidden
+	}
+	if principal.AuthenticatedAt.IsZero() || s.Now().Sub(principal.AuthenticatedAt) > credentialAddMaxAuthAge {
+		return ErrReauthRequired
+	}
+	return nil
+}
+
+// userPasskeys returns the passkeys of the user, moving the ones still kept
+// in the user metadata over to the passkey store
+func (s Service) userPasskeys(ctx context.Context, u user.User) ([]passkey.Passkey, error) {
+	legacy, ok := u.Metadata[legacyPasskeyKey].(string)
+	if !ok {
+		return s.passkeyRepo.ListByUser(ctx, u.ID)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(legacy)
+	if err != nil {
+		return nil, err
+	}
+	var credentials []webauthn.Credential
+	if err = json.Unmarshal(decoded, &credentials); err != nil {
+		return nil, err
+	}
+	for _, credential := range credentials {
+		if _, err = s.passkeyRepo.Create(ctx, passkey.FromCredential(u.ID, credential)); err != nil &&
+			!errors.Is(err, passkey.ErrConflict) {
+			return nil, err
+		}
+	}
+	delete(u.Metadata, legacyPasskeyKey)
+	if _, err = s.userService.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return s.passkeyRepo.ListByUser(ctx, u.ID)
+}
+
+func (s Service) savePasskeySession(ctx context.Context, flow *Flow, passkeyType string, session *webauthn.SessionData) error {
+	// webauthn library expects base64 encoded challenge when verifying the session
+	session.Challenge = base64.RawURLEncoding.EncodeToString([]byte(session.Challenge))
+	sessionInBytes, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	flow.Metadata[passkeySessionKey] = sessionInBytes
+	flow.Metadata[passkeyTypeKey] = passkeyType
+	return s.flowRepo.Set(ctx, flow)
+}
+
+func (s Service) passkeyFlow(ctx context.Context, state string) (*Flow, error) {
+	flowID, err := uuid.Parse(state)
+	if err != nil {
+		return nil, ErrFlowInvalid
+	}
+	flow, err := s.flowRepo.Get(ctx, flowID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state for passkey: %w", err)
+	}
+	if !flow.IsValid(s.Now()) || flow.Method != PassKeyAuthMethod.String() {
+		return nil, ErrFlowInvalid
+	}
+	return flow, nil
+}
+
+// passkeySession decodes the webauthn session stored in the flow, it is
+// read back from the flow store as a base64 string
+func passkeySession(flow *Flow) (webauthn.SessionData, error) {
+	var session webauthn.SessionData
+	var sessionBytes []byte
+	switch encoded := flow.Metadata[passkeySessionKey].(type) {
+	case string:
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return session, ErrFlowInvalid
+		}
+		sessionBytes = decoded
+	case []byte:
+		sessionBytes = encoded
+	default:
+		return session, ErrFlowInvalid
+	}
+	if err := json.Unmarshal(sessionBytes, &session); err != nil {
+		return session, ErrFlowInvalid
+	}
+	return session, nil
+}
@@ -0,0 +1,164 @@
+package authenticate_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/mocks"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/passkey"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	pkgMetadata "github.com/raystack/frontier/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type passkeyMocks struct {
+	flows    *mocks.FlowRepository
+	users    *mocks.UserService
+	passkeys *mocks.PasskeyRepository
+}
+
+func newPasskeyService(t *testing.T, now time.Time) (*authenticate.Service, passkeyMocks) {
+	t.Helper()
+	flows, users, _, sessions, _ := createMocks(t)
+	m := passkeyMocks{
+		flows:    flows,
+		users:    users,
+		passkeys: mocks.NewPasskeyRepository(t),
+	}
+	webAuth, err := webauthn.New(&webauthn.Config{
+		RPDisplayName: "Frontier",
+		RPID:          "localhost",
+		RPOrigins:     []string{"http://localhost:3000"},
+	})
+	require.NoError(t, err)
+	s := authenticate.NewService(nil, authenticate.Config{}, m.flows, nil, nil, sessions, m.users, nil, webAuth,
+		nil, nil, nil, m.passkeys, nil)
+	s.Now = func() time.Time {
+		return now
+	}
+	return s, m
+}
+
+func TestService_StartFlow_Passkey(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	testUser := user.User{ID: uuid.NewString(), Email: "john.doe@example.com", Metadata: pkgMetadata.Metadata{}}
+	request := authenticate.RegistrationStartRequest{
+		Method: authenticate.PassKeyAuthMethod.String(),
+		Email:  testUser.Email,
+	}
+
+	t.Run("should register unknown emails", func(t *testing.T) {
+		s, m := newPasskeyService(t, now)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(user.User{}, user.ErrNotExist)
+		m.flows.EXPECT().Set(mock.Anything, mock.MatchedBy(func(f *authenticate.Flow) bool {
+			return f.Metadata["passkey_type"] == strategy.PasskeyRegisterType
+		})).Return(nil)
+
+		got, err := s.StartFlow(context.Background(), request)
+		require.NoError(t, err)
+		assert.NotEmpty(t, got.StateConfig["options"])
+	})
+
+	t.Run("should log in users with a passkey", func(t *testing.T) {
+		s, m := newPasskeyService(t, now)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(testUser, nil)
+		m.passkeys.EXPECT().ListByUser(mock.Anything, testUser.ID).Return([]passkey.Passkey{
+			{ID: uuid.NewString(), UserID: testUser.ID, CredentialID: []byte("credential")},
+		}, nil)
+		m.flows.EXPECT().Set(mock.Anything, mock.MatchedBy(func(f *authenticate.Flow) bool {
+			return f.Metadata["passkey_type"] == strategy.PasskeyLoginType
+		})).Return(nil)
+
+		_, err := s.StartFlow(context.Background(), request)
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not register a passkey for existing users", func(t *testing.T) {
+		s, m := newPasskeyService(t, now)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(testUser, nil)
+		m.passkeys.EXPECT().ListByUser(mock.Anything, testUser.ID).Return([]passkey.Passkey{}, nil)
+
+		_, err := s.StartFlow(context.Background(), request)
+		assert.ErrorIs(t, err, passkey.ErrNotRegistered)
+	})
+
+	t.Run("should move passkeys out of the user metadata", func(t *testing.T) {
+		s, m := newPasskeyService(t, now)
+		legacy, err := json.Marshal([]webauthn.Credential{{ID: []byte("credential"), PublicKey: []byte("key")}})
+		require.NoError(t, err)
+		legacyUser := testUser
+		legacyUser.Metadata = pkgMetadata.Metadata{
+			"passkey_credentials": base64.StdEncoding.EncodeToString(legacy),
+		}
+		stored := passkey.Passkey{ID: uuid.NewString(), UserID: testUser.ID, CredentialID: []byte("credential")}
+
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(legacyUser, nil)
+		m.passkeys.EXPECT().Create(mock.Anything, mock.MatchedBy(func(p passkey.Passkey) bool {
+			return p.UserID == testUser.ID && string(p.CredentialID) == "credential"
+		})).Return(stored, nil)
+		m.users.EXPECT().Update(mock.Anything, mock.MatchedBy(func(u user.User) bool {
+			_, ok := u.Metadata["passkey_credentials"]
+			return !ok
+		})).Return(testUser, nil)
+		m.passkeys.EXPECT().ListByUser(mock.Anything, testUser.ID).Return([]passkey.Passkey{stored}, nil)
+		m.flows.EXPECT().Set(mock.Anything, mock.Anything).Return(nil)
+
+		_, err = s.StartFlow(context.Background(), request)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_StartPasskeyRegistration(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	testUser := user.User{ID: uuid.NewString(), Email: "john.doe@example.com", Metadata: pkgMetadata.Metadata{}}
+	principal := authenticate.Principal{
+		ID:              testUser.ID,
+		Type:            schema.UserPrincipal,
+		User:            &testUser,
+		AuthenticatedAt: now.Add(-time.Minute),
+	}
+
+	t.Run("should exclude the passkeys of the user", func(t *testing.T) {
+		s, m := newPasskeyService(t, now)
+		m.passkeys.EXPECT().ListByUser(mock.Anything, testUser.ID).Return([]passkey.Passkey{
+			{ID: uuid.NewString(), UserID: testUser.ID, CredentialID: []byte("credential")},
+		}, nil)
+		m.flows.EXPECT().Set(mock.Anything, mock.MatchedBy(func(f *authenticate.Flow) bool {
+			return f.Metadata["passkey_type"] == strategy.PasskeyAddType && f.Metadata["user_id"] == testUser.ID
+		})).Return(nil)
+
+		got, err := s.StartPasskeyRegistration(context.Background(), principal)
+		require.NoError(t, err)
+		require.Len(t, got.Options.Response.CredentialExcludeList, 1)
+		assert.Equal(t, []byte("credential"), []byte(got.Options.Response.CredentialExcludeList[0].CredentialID))
+	})
+
+	t.Run("should require a recent login", func(t *testing.T) {
+		s, _ := newPasskeyService(t, now)
+		stale := principal
+		stale.AuthenticatedAt = now.Add(-time.Hour)
+
+		_, err := s.StartPasskeyRegistration(context.Background(), stale)
+		assert.ErrorIs(t, err, authenticate.ErrReauthRequired)
+	})
+
+	t.Run("should not let impersonators add a passkey", func(t *testing.T) {
+		s, _ := newPasskeyService(t, now)
+		impersonated := principal
+		impersonated.ImpersonatedBy = &authenticate.Principal{ID: uuid.NewString(), Type: schema.UserPrincipal}
+
+		_, err := s.StartPasskeyRegistration(context.Background(), impersonated)
+		assert.ErrorIs(t, err, errors.ErrForbidden)
+	})
+}
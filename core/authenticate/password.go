@@ -0,0 +1,291 @@
+package authenticate
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/metadata"
+)
+
+const (
+	// credentialAddMaxAuthAge is how recent the login of a user must be to add
+	// a credential to the account without proving an existing one
+	credentialAddMaxAuthAge = 15 * time.Minute
+	passwordResetUserKey    = "user_id"
+	passwordResetTokenLen   = 32
+)
+
+// PasswordReset is a single use token to set a new password with
+type PasswordReset struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func (s Service) passwordEnabled() bool {
+	return s.config.Password.Enabled && s.passwordRepo != nil
+}
+
+// applyPassword logs in the user if the code of the flow is the password of
+// the user. Users aren't registered by this strategy, they set a password
+// once signed up with another strategy or invited by an admin.
+func (s Service) applyPassword(ctx context.Context, request RegistrationFinishRequest) (*RegistrationFinishResponse, error) {
+	if !s.passwordEnabled() {
+		return nil, ErrUnsupportedMethod
+	}
+	flowID, err := uuid.Parse(request.State)
+	if err != nil || len(request.Code) == 0 {
+		return nil, ErrFlowInvalid
+	}
+	if err = s.allow(ctx, "finish:ip:"+request.ClientIP, s.config.RateLimit.FinishIP); err != nil {
+		return nil, err
+	}
+	flow, err := s.flowRepo.Get(ctx, flowID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state for password: %w", err)
+	}
+	if !flow.IsValid(s.Now()) || flow.Method != PasswordAuthMethod.String() {
+		return nil, ErrFlowInvalid
+	}
+	if err = s.allow(ctx, "finish:email:"+flow.Email, s.config.RateLimit.FinishEmail); err != nil {
+		return nil, err
+	}
+
+	loggedInUser, err := s.verifyPassword(ctx, flow.Email, request.Code)
+	if err != nil {
+		if errors.Is(err, ErrInvalidPassword) {
+			if err := s.recordFailedAttempt(ctx, flow); err != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+
+	if err = s.consumeFlow(ctx, flow.ID); err != nil {
+		return nil, fmt.Errorf("failed to successfully login via password: %w", err)
+	}
+	return &RegistrationFinishResponse{
+		User: loggedInUser,
+		Flow: flow,
+	}, nil
+}
+
+// verifyPassword checks the password of the user, the hash is upgraded if it
+// was created with outdated parameters
+func (s Service) verifyPassword(ctx context.Context, email, password string) (user.User, error) {
+	loggedInUser, err := s.userService.GetByID(ctx, email)
+	if err != nil && !errors.Is(err, user.ErrNotExist) {
+		return user.User{}, err
+	}
+	var hash string
+	if err == nil && loggedInUser.State != user.Disabled {
+		if hash, err = s.passwordRepo.Get(ctx, loggedInUser.ID); err != nil && !errors.Is(err, ErrPasswordNotSet) {
+			return user.User{}, err
+		}
+	}
+	if hash == "" {
+		_, _ = s.password.Verify(password, s.dummyPasswordHash())
+		return user.User{}, ErrInvalidPassword
+	}
+
+	ok, err := s.password.Verify(password, hash)
+	if err != nil {
+		return user.User{}, err
+	}
+	if !ok {
+		return user.User{}, ErrInvalidPassword
+	}
+	if s.password.NeedsRehash(hash) {
+		if hash, err = s.password.Hash(password); err == nil {
+			err = s.passwordRepo.Set(ctx, loggedInUser.ID, hash)
+		}
+		if err != nil {
+			s.log.Warn("failed to upgrade password hash", "err", err)
+		}
+	}
+	return loggedInUser, nil
+}
+
+// ChangePassword sets a new password for the logged in user, the current one
+// is required if the user has a password already
+func (s Service) ChangePassword(ctx context.Context, principal Principal, currentPassword, newPassword string) error {
+	if !s.passwordEnabled() {
+		return ErrUnsupportedMethod
+	}
+	if principal.Type != schema.UserPrincipal || principal.User == nil {
+		return errors.ErrForbidden
+	}
+	if principal.ImpersonatedBy != nil {
+		return errors.ErrForbidden
+	}
+
+	hash, err := s.passwordRepo.Get(ctx, principal.ID)
+	switch {
+	case err == nil:
+		ok, err := s.password.Verify(currentPassword, hash)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidPassword
+		}
+	case errors.Is(err, ErrPasswordNotSet):
+		if principal.AuthenticatedAt.IsZero() || s.Now().Sub(principal.AuthenticatedAt) > credentialAddMaxAuthAge {
+			return ErrReauthRequired
+		}
+	default:
+		return err
+	}
+
+	if err = s.setPassword(ctx, *principal.User, newPassword); err != nil {
+		return err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.UserPasswordChangedEvent, audit.UserTarget(principal.ID))
+	return nil
+}
+
+// StartPasswordReset mails a reset token to the user, nothing is sent for
+// unknown emails but the response is the same to not reveal who has an account
+func (s Service) StartPasswordReset(ctx context.Context, email, clientIP string) error {
+	if !s.passwordEnabled() || s.mailDialer == nil {
+		return ErrUnsupportedMethod
+	}
+	email = strings.ToLower(email)
+	if err := s.limitStartFlow(ctx, RegistrationStartRequest{Email: email, ClientIP: clientIP}); err != nil {
+		return err
+	}
+
+	resetUser, err := s.userService.GetByID(ctx, email)
+	if err != nil {
+		if errors.Is(err, user.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if resetUser.State == user.Disabled {
+		return nil
+	}
+	reset, err := s.createPasswordReset(ctx, resetUser)
+	if err != nil {
+		return err
+	}
+	return strategy.NewPasswordResetMail(s.mailDialer, s.config.Password.ResetSubject, s.config.Password.ResetBody).
+		SendMail(resetUser.Email, reset.Token)
+}
+
+// IssuePasswordReset creates a reset token for an admin to hand over to the
+// user, for deployments which can't send mails
+func (s Service) IssuePasswordReset(ctx context.Context, userID string) (PasswordReset, error) {
+	if !s.passwordEnabled() {
+		return PasswordReset{}, ErrUnsupportedMethod
+	}
+	resetUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return PasswordReset{}, err
+	}
+	if resetUser.State == user.Disabled {
+		return PasswordReset{}, user.ErrDisabled
+	}
+	return s.createPasswordReset(ctx, resetUser)
+}
+
+// FinishPasswordReset sets the new password of the user the token was issued
+// for, all sessions of the user are logged out
+func (s Service) FinishPasswordReset(ctx context.Context, resetToken, newPassword string) error {
+	if !s.passwordEnabled() {
+		return ErrUnsupportedMethod
+	}
+	id, secret, ok := strings.Cut(resetToken, ".")
+	if !ok {
+		return ErrFlowInvalid
+	}
+	flowID, err := uuid.Parse(id)
+	if err != nil {
+		return ErrFlowInvalid
+	}
+	flow, err := s.flowRepo.Get(ctx, flowID)
+	if err != nil {
+		return ErrFlowInvalid
+	}
+	if !flow.IsValid(s.Now()) || flow.Method != PasswordResetAuthMethod.String() ||
+		subtle.ConstantTimeCompare([]byte(flow.Nonce), []byte(hashResetSecret(secret))) == 0 {
+		return ErrFlowInvalid
+	}
+	userID, _ := flow.Metadata[passwordResetUserKey].(string)
+	resetUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// the token stays valid if the password is rejected, so another can be tried
+	if err = s.setPassword(ctx, resetUser, newPassword); err != nil {
+		return err
+	}
+	if err = s.consumeFlow(ctx, flow.ID); err != nil {
+		return err
+	}
+	if err = s.sessionService.DeleteByUser(ctx, resetUser.ID); err != nil {
+		return err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.UserPasswordResetEvent, audit.UserTarget(resetUser.ID))
+	return nil
+}
+
+func (s Service) setPassword(ctx context.Context, u user.User, password string) error {
+	if err := s.password.Validate(password, u.Email, u.Name); err != nil {
+		return err
+	}
+	hash, err := s.password.Hash(password)
+	if err != nil {
+		return err
+	}
+	return s.passwordRepo.Set(ctx, u.ID, hash)
+}
+
+// createPasswordReset stores a flow holding the hash of the token secret,
+// the token is the flow id and the secret
+func (s Service) createPasswordReset(ctx context.Context, u user.User) (PasswordReset, error) {
+	buf := make([]byte, passwordResetTokenLen)
+	if _, err := rand.Read(buf); err != nil {
+		return PasswordReset{}, err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	flow := &Flow{
+		ID:        uuid.New(),
+		Method:    PasswordResetAuthMethod.String(),
+		Email:     u.Email,
+		Nonce:     hashResetSecret(secret),
+		CreatedAt: s.Now(),
+		ExpiresAt: s.Now().Add(s.config.Password.ResetValidity),
+		Metadata: metadata.Metadata{
+			passwordResetUserKey: u.ID,
+		},
+	}
+	if err := s.flowRepo.Set(ctx, flow); err != nil {
+		return PasswordReset{}, err
+	}
+	return PasswordReset{
+		Token:     flow.ID.String() + "." + secret,
+		ExpiresAt: flow.ExpiresAt,
+	}, nil
+}
+
+func hashResetSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,258 @@
+package authenticate_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/mocks"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	pkgMetadata "github.com/raystack/frontier/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testPasswordConfig = authenticate.PasswordConfig{
+	Enabled:       true,
+	MinLength:     12,
+	MaxLength:     128,
+	ResetValidity: time.Hour,
+	// cheap parameters keep the tests fast
+	Argon2: authenticate.Argon2Config{Memory: 64, Iterations: 1, Parallelism: 1},
+}
+
+type passwordMocks struct {
+	flows     *mocks.FlowRepository
+	users     *mocks.UserService
+	sessions  *mocks.SessionService
+	passwords *mocks.PasswordRepository
+}
+
+func newPasswordService(t *testing.T, now time.Time) (*authenticate.Service, passwordMocks) {
+	t.Helper()
+	flows, users, _, sessions, _ := createMocks(t)
+	m := passwordMocks{
+		flows:     flows,
+		users:     users,
+		sessions:  sessions,
+		passwords: mocks.NewPasswordRepository(t),
+	}
+	s := authenticate.NewService(nil, authenticate.Config{Password: testPasswordConfig},
+		m.flows, nil, nil, m.sessions, m.users, nil, nil, nil, m.passwords, nil, nil, nil)
+	s.Now = func() time.Time {
+		return now
+	}
+	return s, m
+}
+
+func testPasswordHash(t *testing.T, password string) string {
+	t.Helper()
+	hash, err := strategy.NewPassword(strategy.Argon2Params{
+		Memory:      testPasswordConfig.Argon2.Memory,
+		Iterations:  testPasswordConfig.Argon2.Iterations,
+		Parallelism: testPasswordConfig.Argon2.Parallelism,
+		SaltLength:  strategy.DefaultArgon2Params.SaltLength,
+		KeyLength:   strategy.DefaultArgon2Params.KeyLength,
+	}, strategy.PasswordPolicy{}, nil).Hash(password)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestService_FinishFlow_Password(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	testUser := user.User{ID: uuid.NewString(), Email: "john.doe@example.com", State: user.Enabled}
+	hash := testPasswordHash(t, "correct horse battery staple")
+	newFlow := func() *authenticate.Flow {
+		return &authenticate.Flow{
+			ID:        uuid.New(),
+			Method:    authenticate.PasswordAuthMethod.String(),
+			Email:     testUser.Email,
+			Metadata:  pkgMetadata.Metadata{},
+			ExpiresAt: now.Add(10 * time.Minute),
+		}
+	}
+
+	t.Run("should log in users with the right password", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		flow := newFlow()
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(testUser, nil)
+		m.passwords.EXPECT().Get(mock.Anything, testUser.ID).Return(hash, nil)
+		m.flows.EXPECT().Delete(mock.Anything, flow.ID).Return(nil)
+
+		got, err := s.FinishFlow(context.Background(), authenticate.RegistrationFinishRequest{
+			Method: authenticate.PasswordAuthMethod.String(),
+			Code:   "correct horse battery staple",
+			State:  flow.ID.String(),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testUser, got.User)
+	})
+
+	t.Run("should count wrong passwords as failed attempts", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		flow := newFlow()
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(testUser, nil)
+		m.passwords.EXPECT().Get(mock.Anything, testUser.ID).Return(hash, nil)
+		m.flows.EXPECT().Set(mock.Anything, mock.MatchedBy(func(f *authenticate.Flow) bool {
+			return f.Metadata["attempt"] == 1
+		})).Return(nil)
+
+		_, err := s.FinishFlow(context.Background(), authenticate.RegistrationFinishRequest{
+			Method: authenticate.PasswordAuthMethod.String(),
+			Code:   "Tr0ub4dor&3",
+			State:  flow.ID.String(),
+		})
+		assert.ErrorIs(t, err, authenticate.ErrInvalidPassword)
+	})
+
+	t.Run("should not reveal unknown users", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		flow := newFlow()
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(user.User{}, user.ErrNotExist)
+		m.flows.EXPECT().Set(mock.Anything, mock.Anything).Return(nil)
+
+		_, err := s.FinishFlow(context.Background(), authenticate.RegistrationFinishRequest{
+			Method: authenticate.PasswordAuthMethod.String(),
+			Code:   "correct horse battery staple",
+			State:  flow.ID.String(),
+		})
+		assert.ErrorIs(t, err, authenticate.ErrInvalidPassword)
+	})
+
+	t.Run("should not log in users without a password", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		flow := newFlow()
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.Email).Return(testUser, nil)
+		m.passwords.EXPECT().Get(mock.Anything, testUser.ID).Return("", authenticate.ErrPasswordNotSet)
+		m.flows.EXPECT().Set(mock.Anything, mock.Anything).Return(nil)
+
+		_, err := s.FinishFlow(context.Background(), authenticate.RegistrationFinishRequest{
+			Method: authenticate.PasswordAuthMethod.String(),
+			Code:   "correct horse battery staple",
+			State:  flow.ID.String(),
+		})
+		assert.ErrorIs(t, err, authenticate.ErrInvalidPassword)
+	})
+}
+
+func TestService_PasswordReset(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	testUser := user.User{ID: uuid.NewString(), Email: "john.doe@example.com", State: user.Enabled}
+
+	// issueReset returns a token and the flow it is stored in
+	issueReset := func(t *testing.T, s *authenticate.Service, m passwordMocks) (string, *authenticate.Flow) {
+		var flow *authenticate.Flow
+		m.users.EXPECT().GetByID(mock.Anything, testUser.ID).Return(testUser, nil).Once()
+		m.flows.EXPECT().Set(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, f *authenticate.Flow) error {
+			flow = f
+			return nil
+		}).Once()
+		reset, err := s.IssuePasswordReset(context.Background(), testUser.ID)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(time.Hour), reset.ExpiresAt)
+		assert.True(t, strings.HasPrefix(reset.Token, flow.ID.String()+"."))
+		assert.NotContains(t, flow.Nonce, strings.TrimPrefix(reset.Token, flow.ID.String()+"."),
+			"the secret should only be stored hashed")
+		return reset.Token, flow
+	}
+
+	t.Run("should set the password and log out the user", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		token, flow := issueReset(t, s, m)
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.ID).Return(testUser, nil)
+		m.passwords.EXPECT().Set(mock.Anything, testUser.ID, mock.MatchedBy(func(hash string) bool {
+			return strings.HasPrefix(hash, "$argon2id$")
+		})).Return(nil)
+		m.flows.EXPECT().Delete(mock.Anything, flow.ID).Return(nil)
+		m.sessions.EXPECT().DeleteByUser(mock.Anything, testUser.ID).Return(nil)
+
+		assert.NoError(t, s.FinishPasswordReset(context.Background(), token, "correct horse battery staple"))
+	})
+
+	t.Run("should keep the token if the password is rejected", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		token, flow := issueReset(t, s, m)
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+		m.users.EXPECT().GetByID(mock.Anything, testUser.ID).Return(testUser, nil)
+
+		err := s.FinishPasswordReset(context.Background(), token, "short")
+		assert.ErrorIs(t, err, strategy.ErrWeakPassword)
+	})
+
+	t.Run("should reject tokens with a wrong secret", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		_, flow := issueReset(t, s, m)
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+
+		err := s.FinishPasswordReset(context.Background(), flow.ID.String()+".guess", "correct horse battery staple")
+		assert.ErrorIs(t, err, authenticate.ErrFlowInvalid)
+	})
+
+	t.Run("should reject expired tokens", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		token, flow := issueReset(t, s, m)
+		s.Now = func() time.Time {
+			return now.Add(2 * time.Hour)
+		}
+		m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+
+		err := s.FinishPasswordReset(context.Background(), token, "correct horse battery staple")
+		assert.ErrorIs(t, err, authenticate.ErrFlowInvalid)
+	})
+}
+
+func TestService_ChangePassword(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	testUser := user.User{ID: uuid.NewString(), Email: "john.doe@example.com", State: user.Enabled}
+	principal := authenticate.Principal{
+		ID:              testUser.ID,
+		Type:            schema.UserPrincipal,
+		User:            &testUser,
+		AuthenticatedAt: now.Add(-time.Hour),
+	}
+
+	t.Run("should change the password given the current one", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		m.passwords.EXPECT().Get(mock.Anything, testUser.ID).Return(testPasswordHash(t, "correct horse battery staple"), nil)
+		m.passwords.EXPECT().Set(mock.Anything, testUser.ID, mock.Anything).Return(nil)
+
+		assert.NoError(t, s.ChangePassword(context.Background(), principal, "correct horse battery staple", "Tr0ub4dor&3-horse"))
+	})
+
+	t.Run("should reject a wrong current password", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		m.passwords.EXPECT().Get(mock.Anything, testUser.ID).Return(testPasswordHash(t, "correct horse battery staple"), nil)
+
+		err := s.ChangePassword(context.Background(), principal, "Correct horse battery staple", "Tr0ub4dor&3-horse")
+		assert.ErrorIs(t, err, authenticate.ErrInvalidPassword)
+	})
+
+	t.Run("should require a recent login to set the first password", func(t *testing.T) {
+		s, m := newPasswordService(t, now)
+		m.passwords.EXPECT().Get(mock.Anything, testUser.ID).Return("", authenticate.ErrPasswordNotSet)
+
+		err := s.ChangePassword(context.Background(), principal, "", "Tr0ub4dor&3-horse")
+		assert.ErrorIs(t, err, authenticate.ErrReauthRequired)
+	})
+
+	t.Run("should not let impersonators change the password", func(t *testing.T) {
+		s, _ := newPasswordService(t, now)
+		impersonated := principal
+		impersonated.ImpersonatedBy = &authenticate.Principal{ID: uuid.NewString(), Type: schema.UserPrincipal}
+
+		err := s.ChangePassword(context.Background(), impersonated, "correct horse battery staple", "Tr0ub4dor&3-horse")
+		assert.ErrorIs(t, err, errors.ErrForbidden)
+	})
+}
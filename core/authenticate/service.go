@@ -1,26 +1,27 @@
 package authenticate
 
 import (
-	"bytes"
 	"context"
 	"crypto/subtle"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/go-webauthn/webauthn/webauthn"
 	"github.com/raystack/frontier/pkg/metadata"
 
 	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/ratelimit"
 
 	"golang.org/x/exp/slices"
 
 	"github.com/lestrrat-go/jwx/v2/jwt"
 
 	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/passkey"
 	"github.com/raystack/frontier/core/serviceuser"
 	"github.com/raystack/frontier/internal/bootstrap/schema"
 	"github.com/raystack/frontier/internal/metrics"
@@ -54,9 +55,15 @@ var (
 	ErrStrategyNotApplicable = errors.New("strategy not applicable")
 	ErrUnsupportedMethod     = errors.New("unsupported authentication method")
 	ErrInvalidMailOTP        = errors.New("invalid mail otp")
+	ErrInvalidPassword       = errors.New("invalid email or password")
 	ErrMissingOIDCCode       = errors.New("OIDC code is missing")
 	ErrInvalidOIDCState      = errors.New("invalid auth state")
 	ErrFlowInvalid           = errors.New("invalid flow or expired")
+	ErrMFARequired           = errors.New("multi-factor authentication is required")
+	ErrReauthRequired        = errors.New("recent authentication is required, log in again")
+	ErrSSORequired           = errors.New("the organization of the email requires logging in with its identity provider")
+	ErrSSONotConfigured      = errors.New("no identity provider is registered for the email domain")
+	ErrSSODomainMismatch     = errors.New("user email domain is not verified for the organization")
 )
 
 type UserService interface {
@@ -80,6 +87,40 @@ type FlowRepository interface {
 
 type SessionService interface {
 	ExtractFromContext(ctx context.Context) (*frontiersession.Session, error)
+	Get(ctx context.Context, sessionID uuid.UUID) (*frontiersession.Session, error)
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+// PasswordRepository stores the argon2id hashes of user passwords
+type PasswordRepository interface {
+	Get(ctx context.Context, userID string) (string, error)
+	Set(ctx context.Context, userID string, hash string) error
+}
+
+// PasskeyRepository stores the webauthn credentials users registered
+type PasskeyRepository interface {
+	Create(ctx context.Context, passkey passkey.Passkey) (passkey.Passkey, error)
+	ListByUser(ctx context.Context, userID string) ([]passkey.Passkey, error)
+	UpdateUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error
+}
+
+// SSOService resolves the identity providers organizations registered for
+// the verified domains of their members
+type SSOService interface {
+	// Route returns the provider of the organization which verified the
+	// domain of the email
+	Route(ctx context.Context, email string) (SSOProvider, error)
+	Get(ctx context.Context, orgID string) (SSOProvider, error)
+	// Required tells if an organization which verified the domain of the
+	// email only allows its members to log in with its identity provider
+	Required(ctx context.Context, email string) (bool, error)
+	// Organizations returns the organizations which verified the domain of
+	// the email
+	Organizations(ctx context.Context, email string) ([]string, error)
+}
+
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, limit ratelimit.Limit) error
 }
 
 type TokenService interface {
@@ -100,11 +141,35 @@ type Service struct {
 	sessionService       SessionService
 	serviceUserService   ServiceUserService
 	webAuth              *webauthn.WebAuthn
+	rateLimiter          RateLimiter
+	passwordRepo         PasswordRepository
+	passkeyRepo          PasskeyRepository
+	ssoService           SSOService
+	password             *strategy.Password
+	// dummyPasswordHash is verified when there is no password to check
+	// against, so unknown users take as long to reject as wrong passwords
+	dummyPasswordHash func() string
 }
 
 func NewService(logger log.Logger, config Config, flowRepo FlowRepository,
 	mailDialer mailer.Dialer, tokenService TokenService, sessionService SessionService,
-	userService UserService, serviceUserService ServiceUserService, webAuthConfig *webauthn.WebAuthn) *Service {
+	userService UserService, serviceUserService ServiceUserService, webAuthConfig *webauthn.WebAuthn,
+	rateLimiter RateLimiter, passwordRepo PasswordRepository, breaches strategy.BreachChecker,
+	passkeyRepo PasskeyRepository, ssoService SSOService) *Service {
+	password := strategy.NewPassword(strategy.Argon2Params{
+		Memory:      config.Password.Argon2.Memory,
+		Iterations:  config.Password.Argon2.Iterations,
+		Parallelism: config.Password.Argon2.Parallelism,
+		SaltLength:  strategy.DefaultArgon2Params.SaltLength,
+		KeyLength:   strategy.DefaultArgon2Params.KeyLength,
+	}, strategy.PasswordPolicy{
+		MinLength:        config.Password.MinLength,
+		MaxLength:        config.Password.MaxLength,
+		RequireUppercase: config.Password.RequireUppercase,
+		RequireLowercase: config.Password.RequireLowercase,
+		RequireDigit:     config.Password.RequireDigit,
+		RequireSymbol:    config.Password.RequireSymbol,
+	}, breaches)
 	r := &Service{
 		log: logger,
 		cron: cron.New(cron.WithChain(
@@ -122,6 +187,15 @@ func NewService(logger log.Logger, config Config, flowRepo FlowRepository,
 		sessionService:       sessionService,
 		serviceUserService:   serviceUserService,
 		webAuth:              webAuthConfig,
+		rateLimiter:          rateLimiter,
+		passwordRepo:         passwordRepo,
+		passkeyRepo:          passkeyRepo,
+		ssoService:           ssoService,
+		password:             password,
+		dummyPasswordHash: sync.OnceValue(func() string {
+			hash, _ := password.Hash(uuid.NewString())
+			return hash
+		}),
 	}
 	return r
 }
@@ -135,9 +209,15 @@ func (s Service) SupportedStrategies() []string {
 	if s.mailDialer != nil {
 		strategies = append(strategies, MailOTPAuthMethod.String(), MailLinkAuthMethod.String())
 	}
-	if s.webAuth != nil {
+	if s.passkeyEnabled() {
 		strategies = append(strategies, PassKeyAuthMethod.String())
 	}
+	if s.passwordEnabled() {
+		strategies = append(strategies, PasswordAuthMethod.String())
+	}
+	if s.ssoService != nil {
+		strategies = append(strategies, SSOAuthMethod.String())
+	}
 	return strategies
 }
 
@@ -186,33 +266,20 @@ func (s Service) StartFlow(ctx context.Context, request RegistrationStartRequest
 		},
 	}
 
-	if request.Method == PassKeyAuthMethod.String() {
-		needRegistration := false
-		loggedInUser, err := s.userService.GetByID(ctx, request.Email)
-		if err != nil {
-			needRegistration = true
-		} else {
-			storedPasskey, passKeyExists := loggedInUser.Metadata["passkey_credentials"]
-			if !passKeyExists {
-				needRegistration = true
-			}
-			if _, ok := storedPasskey.(string); !ok {
-				needRegistration = true
-			}
-		}
+	if request.Method == SSOAuthMethod.String() {
+		return s.startSSO(ctx, request, flow)
+	}
+	if err := s.checkSSO(ctx, request.Email); err != nil {
+		return nil, err
+	}
 
-		if needRegistration {
-			response, err := s.startPassKeyRegisterMethod(ctx, flow)
-			if err != nil {
-				return nil, err
-			}
-			return response, nil
-		} else {
-			response, err := s.startPassKeyLoginMethod(ctx, loggedInUser, flow)
-			if err != nil {
-				return nil, err
-			}
-			return response, nil
+	if request.Method == PassKeyAuthMethod.String() {
+		return s.startPasskey(ctx, request, flow)
+	}
+	if request.Method == MailOTPAuthMethod.String() || request.Method == MailLinkAuthMethod.String() ||
+		request.Method == PasswordAuthMethod.String() {
+		if err := s.limitStartFlow(ctx, request); err != nil {
+			return nil, err
 		}
 	}
 
@@ -237,6 +304,18 @@ func (s Service) StartFlow(ctx context.Context, request RegistrationStartRequest
 		}, nil
 	}
 
+	if request.Method == PasswordAuthMethod.String() {
+		// the password is submitted as the code of the flow, nothing is sent
+		flow.Email = strings.ToLower(request.Email)
+		if err := s.flowRepo.Set(ctx, flow); err != nil {
+			return nil, err
+		}
+		return &RegistrationStartResponse{
+			Flow:  flow,
+			State: flow.ID.String(),
+		}, nil
+	}
+
 	if len(request.CallbackUrl) == 0 {
 		return nil, fmt.Errorf("callback url not configured")
 	}
@@ -305,6 +384,9 @@ func (s Service) FinishFlow(ctx context.Context, request RegistrationFinishReque
 		}
 		return response, nil
 	}
+	if request.Method == PasswordAuthMethod.String() {
+		return s.applyPassword(ctx, request)
+	}
 	if request.Method == PassKeyAuthMethod.String() {
 		response, err := s.applyPasskey(ctx, request)
 		if err != nil && !errors.Is(err, ErrStrategyNotApplicable) {
@@ -337,6 +419,9 @@ func (s Service) applyMailOTP(ctx context.Context, request RegistrationFinishReq
 	if err != nil {
 		return nil, ErrStrategyNotApplicable
 	}
+	if err = s.allow(ctx, "finish:ip:"+request.ClientIP, s.config.RateLimit.FinishIP); err != nil {
+		return nil, err
+	}
 	flow, err := s.flowRepo.Get(ctx, flowID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid state for mail otp: %w", err)
@@ -344,22 +429,14 @@ func (s Service) applyMailOTP(ctx context.Context, request RegistrationFinishReq
 	if !flow.IsValid(s.Now()) {
 		return nil, ErrFlowInvalid
 	}
+	if err = s.allow(ctx, "finish:email:"+flow.Email, s.config.RateLimit.FinishEmail); err != nil {
+		return nil, err
+	}
 
 	if subtle.ConstantTimeCompare([]byte(flow.Nonce), []byte(request.Code)) == 0 {
 		// avoid brute forcing otp
-		attemptInt := 0
-		if attempts, ok := flow.Metadata[otpAttemptKey]; ok {
-			attemptInt, _ = attempts.(int)
-		}
-		if attemptInt < maxOTPAttempt {
-			flow.Metadata[otpAttemptKey] = attemptInt + 1
-			if err = s.flowRepo.Set(ctx, flow); err != nil {
-				return nil, fmt.Errorf("failed to process flow code missmatch")
-			}
-		} else {
-			if err = s.consumeFlow(ctx, flowID); err != nil {
-				return nil, fmt.Errorf("failed to process flow code missmatch")
-			}
+		if err = s.recordFailedAttempt(ctx, flow); err != nil {
+			return nil, err
 		}
 		return nil, ErrInvalidMailOTP
 	}
@@ -379,237 +456,69 @@ func (s Service) applyMailOTP(ctx context.Context, request RegistrationFinishReq
 	}, nil
 }
 
-func (s Service) startPassKeyRegisterMethod(ctx context.Context, flow *Flow) (*RegistrationStartResponse, error) {
-	newPassKeyUser := strategy.NewPassKeyUser(flow.Email)
-	options, session, err := s.webAuth.BeginRegistration(newPassKeyUser)
-	if err != nil {
-		return nil, err
-	}
-
-	// webauthn library expects base64 encoded challenge when verifying the session
-	session.Challenge = base64.RawURLEncoding.EncodeToString([]byte(session.Challenge))
-	sessionInBytes, err := json.Marshal(session)
-	if err != nil {
-		return nil, err
-	}
-	flow.Metadata["passkey_session"] = sessionInBytes
-	flow.Metadata["passkey_type"] = strategy.PasskeyRegisterType
-	if err = s.flowRepo.Set(ctx, flow); err != nil {
-		return nil, err
-	}
-	optionsBytes, err := json.Marshal(options)
-	if err != nil {
-		return nil, err
-	}
-
-	return &RegistrationStartResponse{
-		Flow:  flow,
-		State: flow.ID.String(),
-		StateConfig: map[string]any{
-			"options": optionsBytes,
-		},
-	}, nil
-}
-
-func (s Service) startPassKeyLoginMethod(ctx context.Context, loggedInUser user.User, flow *Flow) (*RegistrationStartResponse, error) {
-	decodedCredBytes, err := base64.StdEncoding.DecodeString(loggedInUser.Metadata["passkey_credentials"].(string))
-	if err != nil {
-		return nil, err
-	}
-
-	var webAuthCredentialData []webauthn.Credential
-	err = json.Unmarshal(decodedCredBytes, &webAuthCredentialData)
-	if err != nil {
-		return nil, err
-	}
-	newPassKeyUser := strategy.NewPasskeyUserWithCredentials(flow.Email, webAuthCredentialData)
-	options, session, err := s.webAuth.BeginLogin(newPassKeyUser)
-	if err != nil {
-		return nil, err
-	}
-
-	// webauthn library expects base64 encoded challenge when verifying the session
-	session.Challenge = base64.RawURLEncoding.EncodeToString([]byte(session.Challenge))
-	sessionInBytes, err := json.Marshal(session)
-	if err != nil {
-		return nil, err
-	}
-	flow.Metadata["passkey_session"] = sessionInBytes
-	flow.Metadata["passkey_type"] = strategy.PasskeyLoginType
-	if err = s.flowRepo.Set(ctx, flow); err != nil {
-		return nil, err
-	}
-	optionsBytes, err := json.Marshal(options)
-	if err != nil {
-		return nil, err
-	}
-
-	return &RegistrationStartResponse{
-		Flow:  flow,
-		State: flow.ID.String(),
-		StateConfig: map[string]any{
-			"options": optionsBytes,
-		},
-	}, nil
-}
-
-func (s Service) finishPassKeyRegisterMethod(ctx context.Context, request RegistrationFinishRequest) (*RegistrationFinishResponse, error) {
-	passkeyOptions, ok := request.StateConfig["options"].(string)
-	if !ok {
-		return nil, errors.New("invalid auth state")
-	}
-	requestReader := bytes.NewReader([]byte(passkeyOptions))
-	credentialCreationResponse, err := protocol.ParseCredentialCreationResponseBody(requestReader)
-	if err != nil {
-		return nil, err
-	}
-
-	flowIdString := request.State
-	flowId, err := uuid.Parse(flowIdString)
-	if err != nil {
-		return nil, err
-	}
-	flow, err := s.flowRepo.Get(ctx, flowId)
-	if err != nil {
-		return nil, err
-	}
-
-	userFinishRegister := strategy.NewPassKeyUser(flow.Email)
-	encodedPasskeySession := flow.Metadata["passkey_session"]
-	var webAuthSessionData webauthn.SessionData
-	sessionBytes, err := base64.StdEncoding.DecodeString(encodedPasskeySession.(string))
-	if err != nil {
-		return nil, err
-	}
-	err = json.Unmarshal(sessionBytes, &webAuthSessionData)
-	if err != nil {
-		return nil, err
-	}
-	credential, err := s.webAuth.CreateCredential(userFinishRegister, webAuthSessionData, credentialCreationResponse)
-	if err != nil {
-		return nil, err
-	}
-	newUser, err := s.getOrCreateUser(ctx, flow.Email, "")
-	if err != nil {
-		return nil, err
-	}
-	if newUser.Metadata == nil {
-		newUser.Metadata = metadata.Metadata{}
-	}
-
-	webAuthCredentialData := []webauthn.Credential{*credential}
-	credBytes, err := json.Marshal(webAuthCredentialData)
-	if err != nil {
-		return nil, err
-	}
-	credBase64 := base64.StdEncoding.EncodeToString(credBytes)
-	newUser.Metadata["passkey_credentials"] = credBase64
-	newUpdatedUser, err := s.userService.Update(ctx, newUser)
-	if err != nil {
-		return nil, err
+// recordFailedAttempt counts a wrong code submitted for the flow, the flow
+// is consumed once it had too many
+func (s Service) recordFailedAttempt(ctx context.Context, flow *Flow) error {
+	attemptInt := 0
+	switch attempts := flow.Metadata[otpAttemptKey].(type) {
+	case int:
+		attemptInt = attempts
+	case float64:
+		// metadata read back from the database holds json numbers
+		attemptInt = int(attempts)
+	}
+	if attemptInt < maxOTPAttempt {
+		if flow.Metadata == nil {
+			flow.Metadata = metadata.Metadata{}
+		}
+		flow.Metadata[otpAttemptKey] = attemptInt + 1
+		if err := s.flowRepo.Set(ctx, flow); err != nil {
+			return fmt.Errorf("failed to process flow code missmatch")
+		}
+	} else {
+		if err := s.consumeFlow(ctx, flow.ID); err != nil {
+			return fmt.Errorf("failed to process flow code missmatch")
+		}
 	}
-
-	return &RegistrationFinishResponse{
-		User: newUpdatedUser,
-		Flow: flow,
-	}, nil
+	return nil
 }
 
-func (s Service) finishPassKeyLoginMethod(ctx context.Context, request RegistrationFinishRequest) (*RegistrationFinishResponse, error) {
-	passkeyOptions, ok := request.StateConfig["options"].(string)
-	if !ok {
-		return nil, errors.New("invalid auth state")
-	}
-	requestReader := bytes.NewReader([]byte(passkeyOptions))
-	response, err := protocol.ParseCredentialRequestResponseBody(requestReader)
-	if err != nil {
-		return nil, err
-	}
-
-	flowIdString := request.State
-	flowId, err := uuid.Parse(flowIdString)
-	if err != nil {
-		return nil, err
+// limitStartFlow avoids flooding inboxes with mails and guessing passwords by
+// limiting the flows started for an email, for the members of the
+// organizations which verified its domain and from an ip
+func (s Service) limitStartFlow(ctx context.Context, request RegistrationStartRequest) error {
+	email := strings.ToLower(request.Email)
+	if err := s.allow(ctx, "start:ip:"+request.ClientIP, s.config.RateLimit.StartIP); err != nil {
+		return err
 	}
-	flow, err := s.flowRepo.Get(ctx, flowId)
-	if err != nil {
-		return nil, err
+	if err := s.allow(ctx, "start:email:"+email, s.config.RateLimit.StartEmail); err != nil {
+		return err
 	}
-	userFinishRegister := strategy.NewPassKeyUser(flow.Email)
-	sessionInterface, ok := flow.Metadata["passkey_session"]
-	if !ok {
-		return nil, err
+	if s.ssoService == nil || s.config.RateLimit.StartOrg <= 0 {
+		return nil
 	}
-	var webAuthSessionData webauthn.SessionData
-	sessionBytes, err := base64.StdEncoding.DecodeString(sessionInterface.(string))
+	orgIDs, err := s.ssoService.Organizations(ctx, email)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	err = json.Unmarshal(sessionBytes, &webAuthSessionData)
-	if err != nil {
-		return nil, err
-	}
-
-	existingUser, err := s.getOrCreateUser(ctx, flow.Email, "")
-	if err != nil {
-		return nil, err
-	}
-	userCred, ok := existingUser.Metadata["passkey_credentials"]
-	if !ok {
-		return nil, err
-	}
-
-	decodedCredBytes, err := base64.StdEncoding.DecodeString(userCred.(string))
-	if err != nil {
-		return nil, err
-	}
-	var webAuthCredentialData []webauthn.Credential
-	err = json.Unmarshal(decodedCredBytes, &webAuthCredentialData)
-	if err != nil {
-		return nil, err
-	}
-	userFinishRegister.Credentials = webAuthCredentialData
-
-	_, err = s.webAuth.ValidateLogin(userFinishRegister, webAuthSessionData, response)
-	if err != nil {
-		return nil, err
+	for _, orgID := range orgIDs {
+		if err := s.allow(ctx, "start:org:"+orgID, s.config.RateLimit.StartOrg); err != nil {
+			return err
+		}
 	}
-
-	return &RegistrationFinishResponse{
-		User: existingUser,
-		Flow: flow,
-	}, nil
+	return nil
 }
 
-func (s Service) applyPasskey(ctx context.Context, request RegistrationFinishRequest) (*RegistrationFinishResponse, error) {
-	flowIdString := request.State
-	flowId, err := uuid.Parse(flowIdString)
-	if err != nil {
-		return nil, err
-	}
-	flow, err := s.flowRepo.Get(ctx, flowId)
-	if err != nil {
-		return nil, err
+// allow records a hit of the key, keys with an empty identifier like
+// requests without a client ip aren't limited
+func (s Service) allow(ctx context.Context, key string, requests int) error {
+	if s.rateLimiter == nil || strings.HasSuffix(key, ":") {
+		return nil
 	}
-	requestType := flow.Metadata["passkey_type"]
-	if request.Method == PassKeyAuthMethod.String() {
-		if requestType == strategy.PasskeyRegisterType {
-			response, err := s.finishPassKeyRegisterMethod(ctx, request)
-			if err != nil {
-				return nil, err
-			}
-			return response, nil
-		}
-
-		if requestType == strategy.PasskeyLoginType {
-			response, err := s.finishPassKeyLoginMethod(ctx, request)
-			if err != nil {
-				return nil, err
-			}
-			return response, nil
-		}
-	}
-	return nil, err
+	return s.rateLimiter.Allow(ctx, key, ratelimit.Limit{
+		Requests: requests,
+		Window:   s.config.RateLimit.Window,
+	})
 }
 
 func (s Service) applyOIDC(ctx context.Context, request RegistrationFinishRequest) (*RegistrationFinishResponse, error) {
@@ -638,10 +547,9 @@ func (s Service) applyOIDC(ctx context.Context, request RegistrationFinishReques
 		return nil, err
 	}
 
-	// can't find oidc config
-	oidcConfig, ok := s.config.OIDCConfig[flow.Method]
-	if !ok {
-		return nil, ErrStrategyNotApplicable
+	oidcConfig, ssoProvider, err := s.oidcConfig(ctx, flow)
+	if err != nil {
+		return nil, err
 	}
 
 	if _, ok := flow.Metadata["callback_url"]; !ok {
@@ -666,6 +574,15 @@ func (s Service) applyOIDC(ctx context.Context, request RegistrationFinishReques
 		return nil, err
 	}
 
+	if ssoProvider != nil {
+		err = checkSSODomain(*ssoProvider, oauthProfile.Email)
+	} else {
+		err = s.checkSSO(ctx, oauthProfile.Email)
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	// register a new user
 	newUser, err := s.getOrCreateUser(ctx, oauthProfile.Email, oauthProfile.Name)
 	if err != nil {
@@ -681,6 +598,13 @@ func (s Service) applyOIDC(ctx context.Context, request RegistrationFinishReques
 // BuildToken creates an access token for the given subjectID
 func (s Service) BuildToken(ctx context.Context, principal Principal, metadata map[string]string) ([]byte, error) {
 	metadata[token.SubTypeClaimsKey] = principal.Type
+	if principal.ImpersonatedBy != nil {
+		// tokens of impersonated users stay bound to the impersonation session
+		metadata[token.ImpersonationClaimsKey] = principal.ImpersonationSessionID
+	}
+	if !principal.AuthenticatedAt.IsZero() {
+		metadata[token.AuthTimeClaimsKey] = strconv.FormatInt(principal.AuthenticatedAt.Unix(), 10)
+	}
 	if principal.Type == schema.UserPrincipal && s.config.Token.Claims.AddUserEmailClaim {
 		metadata[token.SubEmailClaimsKey] = principal.User.Email
 	}
@@ -739,6 +663,40 @@ func (s Service) getOrCreateUser(ctx context.Context, email, title string) (user
 	return newUser, nil
 }
 
+// withImpersonator sets the superuser who opened the session on the principal
+// if the session is an impersonation session
+func (s Service) withImpersonator(ctx context.Context, principal Principal,
+	session *frontiersession.Session) (Principal, error) {
+	impersonatorID, impersonatorType, ok := session.ImpersonatedBy()
+	if !ok {
+		return principal, nil
+	}
+
+	impersonator := Principal{ID: impersonatorID, Type: impersonatorType}
+	switch impersonatorType {
+	case schema.UserPrincipal:
+		impersonatorUser, err := s.userService.GetByID(ctx, impersonatorID)
+		if err != nil {
+			return Principal{}, err
+		}
+		if impersonatorUser.State == user.Disabled {
+			return Principal{}, errors.ErrUnauthenticated
+		}
+		impersonator.User = &impersonatorUser
+	case schema.ServiceUserPrincipal:
+		impersonatorServiceUser, err := s.serviceUserService.Get(ctx, impersonatorID)
+		if err != nil {
+			return Principal{}, err
+		}
+		impersonator.ServiceUser = &impersonatorServiceUser
+	default:
+		return Principal{}, errors.ErrUnauthenticated
+	}
+	principal.ImpersonatedBy = &impersonator
+	principal.ImpersonationSessionID = session.ID.String()
+	return principal, nil
+}
+
 func (s Service) GetPrincipal(ctx context.Context, assertions ...ClientAssertion) (Principal, error) {
 	if metrics.ServiceOprLatency != nil {
 		promCollect := metrics.ServiceOprLatency("authenticate", "GetPrincipal")
@@ -760,17 +718,22 @@ func (s Service) GetPrincipal(ctx context.Context, assertions ...ClientAssertion
 	// extract user from session if present
 	if slices.Contains[[]ClientAssertion](assertions, SessionClientAssertion) {
 		session, err := s.sessionService.ExtractFromContext(ctx)
+		if err == nil && session.IsMFAPending(s.Now()) {
+			// the user still has to verify the second factor
+			return Principal{}, ErrMFARequired
+		}
 		if err == nil && session.IsValid(s.Now()) && utils.IsValidUUID(session.UserID) {
 			// userID is a valid uuid
 			currentUser, err := s.userService.GetByID(ctx, session.UserID)
 			if err != nil {
 				return Principal{}, err
 			}
-			return Principal{
-				ID:   currentUser.ID,
-				Type: schema.UserPrincipal,
-				User: &currentUser,
-			}, nil
+			return s.withImpersonator(ctx, Principal{
+				ID:              currentUser.ID,
+				Type:            schema.UserPrincipal,
+				User:            &currentUser,
+				AuthenticatedAt: session.AuthenticatedAt,
+			}, session)
 		}
 		if err != nil && !errors.Is(err, frontiersession.ErrNoSession) {
 			return Principal{}, err
@@ -817,11 +780,38 @@ func (s Service) GetPrincipal(ctx context.Context, assertions ...ClientAssertion
 				if err != nil {
 					return Principal{}, err
 				}
-				return Principal{
+				currentPrincipal = Principal{
 					ID:   currentUser.ID,
 					Type: schema.UserPrincipal,
 					User: &currentUser,
-				}, nil
+				}
+				if clientID, ok := claims[token.ClientIDClaimsKey].(string); ok {
+					// token was issued to an oauth2 client acting for the user
+					currentPrincipal.ClientID = clientID
+					scope, _ := claims[token.ScopeClaimsKey].(string)
+					currentPrincipal.Scopes = strings.Fields(scope)
+				}
+				if authTime, ok := claims[token.AuthTimeClaimsKey].(string); ok {
+					if unix, err := strconv.ParseInt(authTime, 10, 64); err == nil {
+						currentPrincipal.AuthenticatedAt = time.Unix(unix, 0).UTC()
+					}
+				}
+				if sessionID, ok := claims[token.ImpersonationClaimsKey].(string); ok {
+					// token was issued to a superuser impersonating the user
+					sessionUUID, err := uuid.Parse(sessionID)
+					if err != nil {
+						return Principal{}, errors.ErrUnauthenticated
+					}
+					session, err := s.sessionService.Get(ctx, sessionUUID)
+					if err != nil || !session.IsValid(s.Now()) || session.UserID != currentUser.ID {
+						return Principal{}, errors.ErrUnauthenticated
+					}
+					if _, _, ok := session.ImpersonatedBy(); !ok {
+						return Principal{}, errors.ErrUnauthenticated
+					}
+					return s.withImpersonator(ctx, currentPrincipal, session)
+				}
+				return currentPrincipal, nil
 			}
 		}
 
@@ -882,10 +872,12 @@ func (s Service) GetPrincipal(ctx context.Context, assertions ...ClientAssertion
 			if err != nil {
 				return Principal{}, err
 			}
+			// the identity proxy authenticates every request it passes on
 			return Principal{
-				ID:   currentUser.ID,
-				Type: schema.UserPrincipal,
-				User: &currentUser,
+				ID:              currentUser.ID,
+				Type:            schema.UserPrincipal,
+				User:            &currentUser,
+				AuthenticatedAt: s.Now(),
 			}, nil
 		}
 	}
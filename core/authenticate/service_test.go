@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"math/rand"
+	"strconv"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/raystack/frontier/core/authenticate/mocks"
 	frontiersession "github.com/raystack/frontier/core/authenticate/session"
 	"github.com/raystack/frontier/core/authenticate/token"
+	"github.com/raystack/frontier/core/ratelimit"
 	"github.com/raystack/frontier/core/serviceuser"
 	"github.com/raystack/frontier/core/user"
 	"github.com/raystack/frontier/internal/bootstrap/schema"
@@ -42,6 +44,9 @@ func createMocks(t *testing.T) (*mocks.FlowRepository, *mocks.UserService, *mock
 
 func TestService_GetPrincipal(t *testing.T) {
 	userID := uuid.New()
+	adminID := uuid.New()
+	impersonationSessionID := uuid.New()
+	authenticatedAt := time.Unix(time.Now().Add(-time.Hour).Unix(), 0).UTC()
 	testKey, err := utils.CreateJWKWithKID("test-id")
 	require.NoError(t, err)
 	tokenBytes, err := utils.BuildToken(testKey, "test", userID.String(), time.Hour, map[string]string{
@@ -76,7 +81,7 @@ func TestService_GetPrincipal(t *testing.T) {
 			},
 			wantErr: false,
 			setup: func() *authenticate.Service {
-				return authenticate.NewService(nil, authenticate.Config{}, nil, nil, nil, nil, nil, nil, nil)
+				return authenticate.NewService(nil, authenticate.Config{}, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -91,6 +96,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				User: &user.User{
 					ID: userID.String(),
 				},
+				AuthenticatedAt: authenticatedAt,
 			},
 			wantErr: false,
 			setup: func() *authenticate.Service {
@@ -99,7 +105,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				mockSess := &frontiersession.Session{
 					ID:              uuid.New(),
 					UserID:          userID.String(),
-					AuthenticatedAt: time.Now().Add(-time.Hour),
+					AuthenticatedAt: authenticatedAt,
 					ExpiresAt:       time.Now().Add(time.Hour),
 					CreatedAt:       time.Time{},
 					Metadata:        nil,
@@ -111,7 +117,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				}, nil)
 
 				return authenticate.NewService(nil, authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -135,7 +141,30 @@ func TestService_GetPrincipal(t *testing.T) {
 				mockSessionService.EXPECT().ExtractFromContext(mock.Anything).Return(mockSess, nil)
 
 				return authenticate.NewService(nil, authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
+			},
+		},
+		{
+			name: "reject principal from user session pending mfa",
+			args: args{
+				ctx:        context.Background(),
+				assertions: []authenticate.ClientAssertion{authenticate.SessionClientAssertion},
+			},
+			wantErr: true,
+			setup: func() *authenticate.Service {
+				mockFlow, mockUserService, mockTokenService, mockSessionService, mockServiceUserService := createMocks(t)
+
+				mockSess := &frontiersession.Session{
+					ID:              uuid.New(),
+					UserID:          userID.String(),
+					AuthenticatedAt: time.Now().Add(-time.Minute),
+					ExpiresAt:       time.Now().Add(time.Minute),
+					State:           frontiersession.StateMFAPending,
+				}
+				mockSessionService.EXPECT().ExtractFromContext(mock.Anything).Return(mockSess, nil)
+
+				return authenticate.NewService(nil, authenticate.Config{},
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -163,7 +192,71 @@ func TestService_GetPrincipal(t *testing.T) {
 				}, nil)
 
 				return authenticate.NewService(nil, authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
+			},
+		},
+		{
+			name: "fetch login time of principal from access token",
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), map[string][]string{
+					consts.UserTokenGatewayKey: {string(tokenBytes)},
+				}),
+				assertions: []authenticate.ClientAssertion{authenticate.AccessTokenClientAssertion},
+			},
+			want: authenticate.Principal{
+				ID:   userID.String(),
+				Type: schema.UserPrincipal,
+				User: &user.User{
+					ID: userID.String(),
+				},
+				AuthenticatedAt: authenticatedAt,
+			},
+			wantErr: false,
+			setup: func() *authenticate.Service {
+				mockFlow, mockUserService, mockTokenService, mockSessionService, mockServiceUserService := createMocks(t)
+
+				mockTokenService.EXPECT().Parse(mock.Anything, tokenBytes).Return(userID.String(), map[string]interface{}{
+					token.AuthTimeClaimsKey: strconv.FormatInt(authenticatedAt.Unix(), 10),
+				}, nil)
+				mockUserService.EXPECT().GetByID(mock.Anything, userID.String()).Return(user.User{
+					ID: userID.String(),
+				}, nil)
+
+				return authenticate.NewService(nil, authenticate.Config{},
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
+			},
+		},
+		{
+			name: "fetch client and granted scopes of principal from access token issued to oauth2 client",
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), map[string][]string{
+					consts.UserTokenGatewayKey: {string(tokenBytes)},
+				}),
+				assertions: []authenticate.ClientAssertion{authenticate.AccessTokenClientAssertion},
+			},
+			want: authenticate.Principal{
+				ID:   userID.String(),
+				Type: schema.UserPrincipal,
+				User: &user.User{
+					ID: userID.String(),
+				},
+				ClientID: "client-id",
+				Scopes:   []string{"openid", authenticate.ScopeRead},
+			},
+			wantErr: false,
+			setup: func() *authenticate.Service {
+				mockFlow, mockUserService, mockTokenService, mockSessionService, mockServiceUserService := createMocks(t)
+
+				mockTokenService.EXPECT().Parse(mock.Anything, tokenBytes).Return(userID.String(), map[string]interface{}{
+					token.ClientIDClaimsKey: "client-id",
+					token.ScopeClaimsKey:    "openid " + authenticate.ScopeRead,
+				}, nil)
+				mockUserService.EXPECT().GetByID(mock.Anything, userID.String()).Return(user.User{
+					ID: userID.String(),
+				}, nil)
+
+				return authenticate.NewService(nil, authenticate.Config{},
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -181,7 +274,82 @@ func TestService_GetPrincipal(t *testing.T) {
 				mockTokenService.EXPECT().Parse(mock.Anything, tokenBytes).Return("", map[string]interface{}{}, errors.New("invalid token"))
 
 				return authenticate.NewService(log.NewLogrus(), authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
+			},
+		},
+		{
+			name: "fetch impersonated principal from access token bound to impersonation session",
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), map[string][]string{
+					consts.UserTokenGatewayKey: {string(tokenBytes)},
+				}),
+				assertions: []authenticate.ClientAssertion{authenticate.AccessTokenClientAssertion},
+			},
+			want: authenticate.Principal{
+				ID:   userID.String(),
+				Type: schema.UserPrincipal,
+				User: &user.User{
+					ID: userID.String(),
+				},
+				ImpersonatedBy: &authenticate.Principal{
+					ID:   adminID.String(),
+					Type: schema.UserPrincipal,
+					User: &user.User{
+						ID: adminID.String(),
+					},
+				},
+				ImpersonationSessionID: impersonationSessionID.String(),
+			},
+			wantErr: false,
+			setup: func() *authenticate.Service {
+				mockFlow, mockUserService, mockTokenService, mockSessionService, mockServiceUserService := createMocks(t)
+
+				mockTokenService.EXPECT().Parse(mock.Anything, tokenBytes).Return(userID.String(), map[string]interface{}{
+					token.ImpersonationClaimsKey: impersonationSessionID.String(),
+				}, nil)
+				mockUserService.EXPECT().GetByID(mock.Anything, userID.String()).Return(user.User{
+					ID: userID.String(),
+				}, nil)
+				mockSessionService.EXPECT().Get(mock.Anything, impersonationSessionID).Return(&frontiersession.Session{
+					ID:              impersonationSessionID,
+					UserID:          userID.String(),
+					AuthenticatedAt: time.Now().Add(-time.Minute),
+					ExpiresAt:       time.Now().Add(time.Minute),
+					Metadata: map[string]any{
+						frontiersession.MetadataImpersonatedBy:     adminID.String(),
+						frontiersession.MetadataImpersonatedByType: schema.UserPrincipal,
+					},
+				}, nil)
+				mockUserService.EXPECT().GetByID(mock.Anything, adminID.String()).Return(user.User{
+					ID: adminID.String(),
+				}, nil)
+
+				return authenticate.NewService(nil, authenticate.Config{},
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
+			},
+		},
+		{
+			name: "reject principal from access token of ended impersonation session",
+			args: args{
+				ctx: metadata.NewIncomingContext(context.Background(), map[string][]string{
+					consts.UserTokenGatewayKey: {string(tokenBytes)},
+				}),
+				assertions: []authenticate.ClientAssertion{authenticate.AccessTokenClientAssertion},
+			},
+			wantErr: true,
+			setup: func() *authenticate.Service {
+				mockFlow, mockUserService, mockTokenService, mockSessionService, mockServiceUserService := createMocks(t)
+
+				mockTokenService.EXPECT().Parse(mock.Anything, tokenBytes).Return(userID.String(), map[string]interface{}{
+					token.ImpersonationClaimsKey: impersonationSessionID.String(),
+				}, nil)
+				mockUserService.EXPECT().GetByID(mock.Anything, userID.String()).Return(user.User{
+					ID: userID.String(),
+				}, nil)
+				mockSessionService.EXPECT().Get(mock.Anything, impersonationSessionID).Return(nil, frontiersession.ErrNoSession)
+
+				return authenticate.NewService(nil, authenticate.Config{},
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -208,7 +376,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				}, nil)
 
 				return authenticate.NewService(nil, authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -226,7 +394,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				mockServiceUserService.EXPECT().GetByJWT(mock.Anything, string(tokenBytes)).Return(serviceuser.ServiceUser{}, errors.New("invalid"))
 
 				return authenticate.NewService(log.NewLogrus(), authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -253,7 +421,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				}, nil)
 
 				return authenticate.NewService(nil, authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -280,7 +448,7 @@ func TestService_GetPrincipal(t *testing.T) {
 				}, nil)
 
 				return authenticate.NewService(nil, authenticate.Config{},
-					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil)
+					mockFlow, nil, mockTokenService, mockSessionService, mockUserService, mockServiceUserService, nil, nil, nil, nil, nil, nil)
 			},
 		},
 	}
@@ -339,7 +507,7 @@ func TestService_StartFlow(t *testing.T) {
 			wantErr: authenticate.ErrUnsupportedMethod,
 			setup: func() *authenticate.Service {
 				return authenticate.NewService(nil, authenticate.Config{}, nil, nil,
-					nil, nil, nil, nil, nil)
+					nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 			},
 		},
 		{
@@ -370,7 +538,7 @@ func TestService_StartFlow(t *testing.T) {
 						TestUsers: testusers.Config{Enabled: true, OTP: "111111", Domain: "example.com"},
 					},
 					mockFlowRepo, mockDialer, nil, nil,
-					nil, nil, nil)
+					nil, nil, nil, nil, nil, nil, nil, nil)
 				srv.Now = func() time.Time {
 					return timeNow
 				}
@@ -402,7 +570,7 @@ func TestService_StartFlow(t *testing.T) {
 						TestUsers: testusers.Config{Enabled: true, OTP: "111111", Domain: "example.com"},
 					},
 					mockFlowRepo, mockDialer, nil, nil,
-					nil, nil, nil)
+					nil, nil, nil, nil, nil, nil, nil, nil)
 				srv.Now = func() time.Time {
 					return timeNow
 				}
@@ -433,7 +601,78 @@ func TestService_StartFlow(t *testing.T) {
 						MailOTP: authenticate.MailOTPConfig{},
 					},
 					mockFlowRepo, mockDialer, nil, nil,
-					nil, nil, nil)
+					nil, nil, nil, nil, nil, nil, nil, nil)
+				srv.Now = func() time.Time {
+					return timeNow
+				}
+				return srv
+			},
+		},
+		{
+			name: "return ErrLimitExceeded without sending mail if email exceeded its limit",
+			args: args{
+				ctx: context.Background(),
+				request: authenticate.RegistrationStartRequest{
+					Method:   authenticate.MailOTPAuthMethod.String(),
+					Email:    "Test@example.com",
+					ClientIP: "203.0.113.7",
+				},
+			},
+			want:    nil,
+			wantErr: ratelimit.ErrLimitExceeded,
+			setup: func() *authenticate.Service {
+				mockFlowRepo, _, _, _, _ := createMocks(t)
+				mockRateLimiter := mocks.NewRateLimiter(t)
+				limit := ratelimit.Limit{Requests: 5, Window: 15 * time.Minute}
+				mockRateLimiter.EXPECT().Allow(mock.Anything, "start:ip:203.0.113.7", ratelimit.Limit{Requests: 30, Window: 15 * time.Minute}).Return(nil)
+				mockRateLimiter.EXPECT().Allow(mock.Anything, "start:email:test@example.com", limit).Return(ratelimit.LimitError{
+					Key:        "start:email:test@example.com",
+					RetryAfter: time.Minute,
+				})
+				srv := authenticate.NewService(
+					nil,
+					authenticate.Config{
+						RateLimit: authenticate.RateLimitConfig{Window: 15 * time.Minute, StartEmail: 5, StartIP: 30},
+					},
+					mockFlowRepo, &mailerMock.Dialer{}, nil, nil,
+					nil, nil, nil, mockRateLimiter, nil, nil, nil, nil)
+				srv.Now = func() time.Time {
+					return timeNow
+				}
+				return srv
+			},
+		},
+		{
+			name: "return ErrLimitExceeded without sending mail if the organization of the email exceeded its limit",
+			args: args{
+				ctx: context.Background(),
+				request: authenticate.RegistrationStartRequest{
+					Method:   authenticate.MailOTPAuthMethod.String(),
+					Email:    "test@example.com",
+					ClientIP: "203.0.113.7",
+				},
+			},
+			want:    nil,
+			wantErr: ratelimit.ErrLimitExceeded,
+			setup: func() *authenticate.Service {
+				mockFlowRepo, _, _, _, _ := createMocks(t)
+				mockRateLimiter := mocks.NewRateLimiter(t)
+				mockSSOService := mocks.NewSSOService(t)
+				mockSSOService.EXPECT().Required(mock.Anything, "test@example.com").Return(false, nil).Maybe()
+				mockSSOService.EXPECT().Organizations(mock.Anything, "test@example.com").Return([]string{"org-1"}, nil)
+				mockRateLimiter.EXPECT().Allow(mock.Anything, "start:ip:203.0.113.7", ratelimit.Limit{Requests: 30, Window: 15 * time.Minute}).Return(nil)
+				mockRateLimiter.EXPECT().Allow(mock.Anything, "start:email:test@example.com", ratelimit.Limit{Requests: 5, Window: 15 * time.Minute}).Return(nil)
+				mockRateLimiter.EXPECT().Allow(mock.Anything, "start:org:org-1", ratelimit.Limit{Requests: 200, Window: 15 * time.Minute}).Return(ratelimit.LimitError{
+					Key:        "start:org:org-1",
+					RetryAfter: time.Minute,
+				})
+				srv := authenticate.NewService(
+					nil,
+					authenticate.Config{
+						RateLimit: authenticate.RateLimitConfig{Window: 15 * time.Minute, StartEmail: 5, StartIP: 30, StartOrg: 200},
+					},
+					mockFlowRepo, &mailerMock.Dialer{}, nil, nil,
+					nil, nil, nil, mockRateLimiter, nil, nil, nil, mockSSOService)
 				srv.Now = func() time.Time {
 					return timeNow
 				}
@@ -73,6 +73,53 @@ func (_c *Repository_Delete_Call) RunAndReturn(run func(context.Context, uuid.UU
 	return _c
 }
 
+// DeleteByUser provides a mock function with given fields: ctx, userID
+func (_m *Repository) DeleteByUser(ctx context.Context, userID string) error {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteByUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_DeleteByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteByUser'
+type Repository_DeleteByUser_Call struct {
+	*mock.Call
+}
+
+// DeleteByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) DeleteByUser(ctx interface{}, userID interface{}) *Repository_DeleteByUser_Call {
+	return &Repository_DeleteByUser_Call{Call: _e.mock.On("DeleteByUser", ctx, userID)}
+}
+
+func (_c *Repository_DeleteByUser_Call) Run(run func(ctx context.Context, userID string)) *Repository_DeleteByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_DeleteByUser_Call) Return(_a0 error) *Repository_DeleteByUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_DeleteByUser_Call) RunAndReturn(run func(context.Context, string) error) *Repository_DeleteByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteExpiredSessions provides a mock function with given fields: ctx
 func (_m *Repository) DeleteExpiredSessions(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -178,6 +225,65 @@ func (_c *Repository_Get_Call) RunAndReturn(run func(context.Context, uuid.UUID)
 	return _c
 }
 
+// ListByUser provides a mock function with given fields: ctx, userID
+func (_m *Repository) ListByUser(ctx context.Context, userID string) ([]*session.Session, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []*session.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*session.Session, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*session.Session); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*session.Session)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Repository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type Repository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *Repository_Expecter) ListByUser(ctx interface{}, userID interface{}) *Repository_ListByUser_Call {
+	return &Repository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *Repository_ListByUser_Call) Run(run func(ctx context.Context, userID string)) *Repository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Repository_ListByUser_Call) Return(_a0 []*session.Session, _a1 error) *Repository_ListByUser_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Repository_ListByUser_Call) RunAndReturn(run func(context.Context, string) ([]*session.Session, error)) *Repository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function with given fields: ctx, _a1
 func (_m *Repository) Set(ctx context.Context, _a1 *session.Session) error {
 	ret := _m.Called(ctx, _a1)
@@ -225,6 +331,53 @@ func (_c *Repository_Set_Call) RunAndReturn(run func(context.Context, *session.S
 	return _c
 }
 
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *Repository) Update(ctx context.Context, _a1 *session.Session) error {
+	ret := _m.Called(ctx, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *session.Session) error); ok {
+		r0 = rf(ctx, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Repository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type Repository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - _a1 *session.Session
+func (_e *Repository_Expecter) Update(ctx interface{}, _a1 interface{}) *Repository_Update_Call {
+	return &Repository_Update_Call{Call: _e.mock.On("Update", ctx, _a1)}
+}
+
+func (_c *Repository_Update_Call) Run(run func(ctx context.Context, _a1 *session.Session)) *Repository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*session.Session))
+	})
+	return _c
+}
+
+func (_c *Repository_Update_Call) Return(_a0 error) *Repository_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Repository_Update_Call) RunAndReturn(run func(context.Context, *session.Session) error) *Repository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateValidity provides a mock function with given fields: ctx, id, validity
 func (_m *Repository) UpdateValidity(ctx context.Context, id uuid.UUID, validity time.Duration) error {
 	ret := _m.Called(ctx, id, validity)
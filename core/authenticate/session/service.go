@@ -4,7 +4,11 @@ import (
 	"context"
 	"errors"
 	"time"
+	"unicode/utf8"
 
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	pkgMetadata "github.com/raystack/frontier/pkg/metadata"
 	"github.com/raystack/frontier/pkg/server/consts"
 
 	"github.com/google/uuid"
@@ -16,15 +20,29 @@ import (
 var (
 	ErrNoSession       = errors.New("no session")
 	ErrDeletingSession = errors.New("error deleting session")
+	ErrInvalidLabel    = errors.New("session label must be at most 64 characters")
+	ErrNotActive       = errors.New("session is not fully authenticated")
 	refreshTime        = "0 0 * * *" // Once a day at midnight (UTC)
 )
 
+const maxLabelLength = 64
+
 type Repository interface {
 	Set(ctx context.Context, session *Session) error
 	Get(ctx context.Context, id uuid.UUID) (*Session, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DeleteExpiredSessions(ctx context.Context) error
 	UpdateValidity(ctx context.Context, id uuid.UUID, validity time.Duration) error
+	Update(ctx context.Context, session *Session) error
+	ListByUser(ctx context.Context, userID string) ([]*Session, error)
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
+// TokenRevoker revokes the refresh tokens bound to sessions along with the
+// access tokens issued with them
+type TokenRevoker interface {
+	RevokeSession(ctx context.Context, sessionID uuid.UUID) error
+	RevokeUserSessions(ctx context.Context, userID string) error
 }
 
 type Service struct {
@@ -33,6 +51,9 @@ type Service struct {
 	log      log.Logger
 	cron     *cron.Cron
 	Now      func() time.Time
+	// TokenRevoker is called once sessions are deleted, it is set once the
+	// service issuing the tokens is created as it depends on sessions
+	TokenRevoker TokenRevoker
 }
 
 func NewService(logger log.Logger, repo Repository, validity time.Duration) *Service {
@@ -47,24 +68,174 @@ func NewService(logger log.Logger, repo Repository, validity time.Duration) *Ser
 	}
 }
 
-func (s Service) Create(ctx context.Context, userID string) (*Session, error) {
+// Create creates the session of a logged in user, metadata describes the
+// client it is created from
+func (s Service) Create(ctx context.Context, userID string, metadata pkgMetadata.Metadata) (*Session, error) {
 	sess := &Session{
 		ID:              uuid.New(),
 		UserID:          userID,
 		AuthenticatedAt: s.Now(),
 		ExpiresAt:       s.Now().Add(s.validity),
 		CreatedAt:       s.Now(),
+		LastSeenAt:      s.Now(),
+		State:           StateActive,
+		Metadata:        metadata,
+	}
+	return sess, s.repo.Set(ctx, sess)
+}
+
+// CreateWithValidity creates an active session which expires after validity
+// instead of the configured session lifespan
+func (s Service) CreateWithValidity(ctx context.Context, userID string, validity time.Duration,
+	metadata pkgMetadata.Metadata) (*Session, error) {
+	sess := &Session{
+		ID:              uuid.New(),
+		UserID:          userID,
+		AuthenticatedAt: s.Now(),
+		ExpiresAt:       s.Now().Add(validity),
+		CreatedAt:       s.Now(),
+		LastSeenAt:      s.Now(),
+		State:           StateActive,
+		Metadata:        metadata,
+	}
+	return sess, s.repo.Set(ctx, sess)
+}
+
+// CreateMFAPending creates a session for a user who still has to verify a
+// second factor, it expires after validity unless activated
+func (s Service) CreateMFAPending(ctx context.Context, userID string, validity time.Duration,
+	metadata pkgMetadata.Metadata) (*Session, error) {
+	sess := &Session{
+		ID:              uuid.New(),
+		UserID:          userID,
+		AuthenticatedAt: s.Now(),
+		ExpiresAt:       s.Now().Add(validity),
+		CreatedAt:       s.Now(),
+		LastSeenAt:      s.Now(),
+		State:           StateMFAPending,
+		Metadata:        metadata,
 	}
 	return sess, s.repo.Set(ctx, sess)
 }
 
-// Refresh extends validity of session
+// Activate completes the authentication of a session pending a second
+// factor, it is valid for the whole session lifespan from now on
+func (s Service) Activate(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
+	sess, err := s.repo.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	sess.State = StateActive
+	sess.AuthenticatedAt = s.Now()
+	sess.ExpiresAt = s.Now().Add(s.validity)
+	if err = s.repo.Update(ctx, sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+func (s Service) Update(ctx context.Context, session *Session) error {
+	return s.repo.Update(ctx, session)
+}
+
+// Refresh extends validity of session and records the user was last seen now.
+// Sessions pending a second factor keep their short validity and can't be refreshed
 func (s Service) Refresh(ctx context.Context, sessionID uuid.UUID) error {
+	sess, err := s.repo.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if sess.State != StateActive {
+		return ErrNotActive
+	}
 	return s.repo.UpdateValidity(ctx, sessionID, s.validity)
 }
 
+func (s Service) Get(ctx context.Context, sessionID uuid.UUID) (*Session, error) {
+	return s.repo.Get(ctx, sessionID)
+}
+
+// Delete ends the session and revokes the tokens bound to it
 func (s Service) Delete(ctx context.Context, sessionID uuid.UUID) error {
-	return s.repo.Delete(ctx, sessionID)
+	if err := s.repo.Delete(ctx, sessionID); err != nil {
+		return err
+	}
+	return s.revokeSessionTokens(ctx, sessionID)
+}
+
+// ListByUser returns the sessions of a user which are not expired yet
+func (s Service) ListByUser(ctx context.Context, userID string) ([]*Session, error) {
+	return s.repo.ListByUser(ctx, userID)
+}
+
+// DeleteForUser revokes a session of the user, sessions of other users are
+// reported as not found
+func (s Service) DeleteForUser(ctx context.Context, userID string, sessionID uuid.UUID) error {
+	sess, err := s.repo.Get(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if sess.UserID != userID {
+		return ErrNoSession
+	}
+	return s.Delete(ctx, sessionID)
+}
+
+// SetLabel names a session of the user, an empty label removes the name.
+// Sessions of other users are reported as not found
+func (s Service) SetLabel(ctx context.Context, userID string, sessionID uuid.UUID, label string) (*Session, error) {
+	if utf8.RuneCountInString(label) > maxLabelLength {
+		return nil, ErrInvalidLabel
+	}
+	sess, err := s.repo.Get(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if sess.UserID != userID {
+		return nil, ErrNoSession
+	}
+	if sess.Metadata == nil {
+		sess.Metadata = pkgMetadata.Metadata{}
+	}
+	if label == "" {
+		delete(sess.Metadata, MetadataLabel)
+	} else {
+		sess.Metadata[MetadataLabel] = label
+	}
+	if err = s.repo.Update(ctx, sess); err != nil {
+		return nil, err
+	}
+	return sess, nil
+}
+
+// DeleteByUser revokes all the sessions of a user and the tokens bound to
+// them, logging them out everywhere
+func (s Service) DeleteByUser(ctx context.Context, userID string) error {
+	if err := s.repo.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	if s.TokenRevoker == nil {
+		return nil
+	}
+	return s.TokenRevoker.RevokeUserSessions(ctx, userID)
+}
+
+// RevokeAll is DeleteByUser on behalf of an admin, it is recorded in the
+// audit logs of the platform
+func (s Service) RevokeAll(ctx context.Context, userID string) error {
+	if err := s.DeleteByUser(ctx, userID); err != nil {
+		return err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.UserSessionsRevokedEvent, audit.UserTarget(userID))
+	return nil
+}
+
+func (s Service) revokeSessionTokens(ctx context.Context, sessionID uuid.UUID) error {
+	if s.TokenRevoker == nil {
+		return nil
+	}
+	return s.TokenRevoker.RevokeSession(ctx, sessionID)
 }
 
 func (s Service) ExtractFromContext(ctx context.Context) (*Session, error) {
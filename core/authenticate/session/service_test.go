@@ -3,6 +3,7 @@ package session_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,7 +29,7 @@ func TestService_Create(t *testing.T) {
 		}).Return(nil)
 
 		userID := "1"
-		sess, err := svc.Create(context.Background(), userID)
+		sess, err := svc.Create(context.Background(), userID, nil)
 
 		assert.Nil(t, err)
 		assert.Equal(t, sess.UserID, "1")
@@ -45,7 +46,7 @@ func TestService_Create(t *testing.T) {
 		}).Return(errors.New("internal-error"))
 
 		userID := "1"
-		_, err := svc.Create(context.Background(), userID)
+		_, err := svc.Create(context.Background(), userID, nil)
 
 		assert.NotNil(t, err)
 		assert.Equal(t, err.Error(), "internal-error")
@@ -58,6 +59,7 @@ func TestService_Refresh(t *testing.T) {
 		mockSessionID := uuid.New()
 		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
 
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, State: session.StateActive}, nil)
 		mockRepository.On("UpdateValidity", mock.Anything, mockSessionID, 24*time.Hour).Return(nil)
 
 		err := svc.Refresh(context.Background(), mockSessionID)
@@ -65,11 +67,24 @@ func TestService_Refresh(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("should not refresh a session pending a second factor", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockSessionID := uuid.New()
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, State: session.StateMFAPending}, nil)
+
+		err := svc.Refresh(context.Background(), mockSessionID)
+
+		assert.ErrorIs(t, err, session.ErrNotActive)
+	})
+
 	t.Run("should return an error if refresh fails", func(t *testing.T) {
 		mockRepository := mocks.NewRepository(t)
 		mockSessionID := uuid.New()
 		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
 
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, State: session.StateActive}, nil)
 		mockRepository.On("UpdateValidity", mock.Anything, mockSessionID, 24*time.Hour).Return(errors.New("internal-error"))
 
 		err := svc.Refresh(context.Background(), mockSessionID)
@@ -104,6 +119,141 @@ func TestService_Delete(t *testing.T) {
 		assert.NotNil(t, err)
 		assert.Equal(t, err.Error(), "internal-error")
 	})
+
+	t.Run("should revoke the tokens bound to the session", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockRevoker := mocks.NewTokenRevoker(t)
+		mockSessionID := uuid.New()
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+		svc.TokenRevoker = mockRevoker
+
+		mockRepository.On("Delete", mock.Anything, mockSessionID).Return(nil)
+		mockRevoker.EXPECT().RevokeSession(mock.Anything, mockSessionID).Return(nil)
+
+		err := svc.Delete(context.Background(), mockSessionID)
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestService_DeleteByUser(t *testing.T) {
+	t.Run("should revoke the tokens bound to the sessions of the user", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockRevoker := mocks.NewTokenRevoker(t)
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+		svc.TokenRevoker = mockRevoker
+
+		mockRepository.On("DeleteByUser", mock.Anything, "1").Return(nil)
+		mockRevoker.EXPECT().RevokeUserSessions(mock.Anything, "1").Return(nil)
+
+		err := svc.DeleteByUser(context.Background(), "1")
+
+		assert.Nil(t, err)
+	})
+}
+
+func TestService_Activate(t *testing.T) {
+	t.Run("should activate a session pending mfa", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockSessionID := uuid.New()
+		now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+		svc.Now = func() time.Time {
+			return now
+		}
+
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{
+			ID:        mockSessionID,
+			UserID:    "1",
+			State:     session.StateMFAPending,
+			ExpiresAt: now.Add(time.Minute),
+		}, nil)
+		mockRepository.On("Update", mock.Anything, mock.AnythingOfType("*session.Session")).Return(nil)
+
+		sess, err := svc.Activate(context.Background(), mockSessionID)
+
+		assert.Nil(t, err)
+		assert.Equal(t, session.StateActive, sess.State)
+		assert.Equal(t, now, sess.AuthenticatedAt)
+		assert.Equal(t, now.Add(24*time.Hour), sess.ExpiresAt)
+		assert.True(t, sess.IsValid(now))
+	})
+}
+
+func TestService_DeleteForUser(t *testing.T) {
+	t.Run("should revoke a session of the user", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockSessionID := uuid.New()
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, UserID: "1"}, nil)
+		mockRepository.On("Delete", mock.Anything, mockSessionID).Return(nil)
+
+		err := svc.DeleteForUser(context.Background(), "1", mockSessionID)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("should not revoke a session of another user", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockSessionID := uuid.New()
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, UserID: "2"}, nil)
+
+		err := svc.DeleteForUser(context.Background(), "1", mockSessionID)
+
+		assert.ErrorIs(t, err, session.ErrNoSession)
+	})
+}
+
+func TestService_SetLabel(t *testing.T) {
+	t.Run("should label a session of the user", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockSessionID := uuid.New()
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, UserID: "1"}, nil)
+		mockRepository.On("Update", mock.Anything, mock.AnythingOfType("*session.Session")).Return(nil)
+
+		sess, err := svc.SetLabel(context.Background(), "1", mockSessionID, "work laptop")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "work laptop", sess.Label())
+	})
+
+	t.Run("should not label a session of another user", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		mockSessionID := uuid.New()
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+
+		mockRepository.On("Get", mock.Anything, mockSessionID).Return(&session.Session{ID: mockSessionID, UserID: "2"}, nil)
+
+		_, err := svc.SetLabel(context.Background(), "1", mockSessionID, "work laptop")
+
+		assert.ErrorIs(t, err, session.ErrNoSession)
+	})
+
+	t.Run("should reject labels which are too long", func(t *testing.T) {
+		svc := session.NewService(log.NewLogrus(), mocks.NewRepository(t), 24*time.Hour)
+
+		_, err := svc.SetLabel(context.Background(), "1", uuid.New(), strings.Repeat("a", 65))
+
+		assert.ErrorIs(t, err, session.ErrInvalidLabel)
+	})
+}
+
+func TestService_RevokeAll(t *testing.T) {
+	t.Run("should revoke all the sessions of the user", func(t *testing.T) {
+		mockRepository := mocks.NewRepository(t)
+		svc := session.NewService(log.NewLogrus(), mockRepository, 24*time.Hour)
+
+		mockRepository.On("DeleteByUser", mock.Anything, "1").Return(nil)
+
+		err := svc.RevokeAll(context.Background(), "1")
+
+		assert.Nil(t, err)
+	})
 }
 
 func TestService_ExtractFromContext(t *testing.T) {
@@ -8,6 +8,34 @@ import (
 	"github.com/google/uuid"
 )
 
+type State string
+
+const (
+	StateActive State = "active"
+	// StateMFAPending is the state of sessions of users who passed a primary
+	// strategy but still have to verify their second factor
+	StateMFAPending State = "mfa_pending"
+)
+
+// metadata keys describing the client a session was created from
+const (
+	MetadataUserAgent  = "user_agent"
+	MetadataIPAddress  = "ip_address"
+	MetadataAuthMethod = "auth_method"
+	// MetadataLabel is the name the user gave the session to recognise it
+	MetadataLabel = "label"
+
+	// MetadataImpersonatedBy is the id of the superuser who opened an
+	// impersonation session, MetadataImpersonatedByType its principal type
+	MetadataImpersonatedBy     = "impersonated_by"
+	MetadataImpersonatedByType = "impersonated_by_type"
+	// MetadataImpersonationReason is why the superuser impersonates the user
+	MetadataImpersonationReason = "impersonation_reason"
+)
+
+// AuthMethodImpersonation is the auth method of impersonation sessions
+const AuthMethodImpersonation = "impersonation"
+
 // Session is created on successful authentication of users
 type Session struct {
 	ID uuid.UUID
@@ -22,12 +50,56 @@ type Session struct {
 	ExpiresAt time.Time
 	CreatedAt time.Time
 
+	// State of the session, only active sessions authenticate the user
+	State State
+
+	// LastSeenAt is the last time the session was refreshed
+	LastSeenAt time.Time
+
 	Metadata metadata.Metadata
 }
 
 func (s Session) IsValid(now time.Time) bool {
-	if s.ExpiresAt.After(now) && !s.AuthenticatedAt.IsZero() {
+	if s.ExpiresAt.After(now) && !s.AuthenticatedAt.IsZero() && s.State != StateMFAPending {
 		return true
 	}
 	return false
 }
+
+// UserAgent is the user agent of the client which created the session
+func (s Session) UserAgent() string {
+	return s.metadataString(MetadataUserAgent)
+}
+
+// IPAddress is the ip address of the client which created the session
+func (s Session) IPAddress() string {
+	return s.metadataString(MetadataIPAddress)
+}
+
+// AuthMethod is the strategy the user logged in with
+func (s Session) AuthMethod() string {
+	return s.metadataString(MetadataAuthMethod)
+}
+
+// Label is the name the user gave the session
+func (s Session) Label() string {
+	return s.metadataString(MetadataLabel)
+}
+
+// ImpersonatedBy returns the id and principal type of the superuser who
+// opened the session, ok is false for sessions the user logged in to
+func (s Session) ImpersonatedBy() (id string, principalType string, ok bool) {
+	id = s.metadataString(MetadataImpersonatedBy)
+	return id, s.metadataString(MetadataImpersonatedByType), id != ""
+}
+
+func (s Session) metadataString(key string) string {
+	val, _ := s.Metadata[key].(string)
+	return val
+}
+
+// IsMFAPending is true for sessions that can only be used to verify the
+// second factor of the user
+func (s Session) IsMFAPending(now time.Time) bool {
+	return s.ExpiresAt.After(now) && s.State == StateMFAPending
+}
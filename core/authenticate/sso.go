@@ -0,0 +1,117 @@
+package authenticate
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/pkg/utils"
+)
+
+const ssoOrgIDKey = "org_id"
+
+// SSOProvider is the OIDC identity provider of an organization
+type SSOProvider struct {
+	OrgID        string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// Domains are the verified domains of the organization, only their
+	// emails are logged in by the provider
+	Domains []string
+}
+
+// startSSO sends the user to the identity provider of the organization which
+// verified the domain of the email
+func (s Service) startSSO(ctx context.Context, request RegistrationStartRequest, flow *Flow) (*RegistrationStartResponse, error) {
+	if s.ssoService == nil {
+		return nil, ErrUnsupportedMethod
+	}
+	provider, err := s.ssoService.Route(ctx, request.Email)
+	if err != nil {
+		return nil, err
+	}
+	idp, err := strategy.NewRelyingPartyOIDC(
+		provider.ClientID,
+		provider.ClientSecret,
+		request.CallbackUrl).
+		Init(ctx, provider.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	oidcState, err := strategy.EmbedFlowInOIDCState(flow.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	endpoint, nonce, err := idp.AuthURL(oidcState)
+	if err != nil {
+		return nil, err
+	}
+
+	flow.StartURL = endpoint
+	flow.Nonce = nonce
+	flow.Email = strings.ToLower(request.Email)
+	flow.Metadata[ssoOrgIDKey] = provider.OrgID
+	if s.config.SSO.Validity != 0 {
+		flow.ExpiresAt = flow.CreatedAt.Add(s.config.SSO.Validity)
+	}
+	if err = s.flowRepo.Set(ctx, flow); err != nil {
+		return nil, err
+	}
+	return &RegistrationStartResponse{
+		Flow: flow,
+	}, nil
+}
+
+// oidcConfig returns the identity provider a flow was started with, either
+// one of the server config or the one of an organization
+func (s Service) oidcConfig(ctx context.Context, flow *Flow) (OIDCConfig, *SSOProvider, error) {
+	if flow.Method != SSOAuthMethod.String() {
+		oidcConfig, ok := s.config.OIDCConfig[flow.Method]
+		if !ok {
+			return OIDCConfig{}, nil, ErrStrategyNotApplicable
+		}
+		return oidcConfig, nil, nil
+	}
+	if s.ssoService == nil {
+		return OIDCConfig{}, nil, ErrUnsupportedMethod
+	}
+	orgID, _ := flow.Metadata[ssoOrgIDKey].(string)
+	provider, err := s.ssoService.Get(ctx, orgID)
+	if err != nil {
+		return OIDCConfig{}, nil, err
+	}
+	return OIDCConfig{
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		IssuerUrl:    provider.IssuerURL,
+	}, &provider, nil
+}
+
+// checkSSO rejects the methods other than the identity provider of the
+// organization for emails of a domain it enforces single sign-on for
+func (s Service) checkSSO(ctx context.Context, email string) error {
+	if s.ssoService == nil || email == "" {
+		return nil
+	}
+	required, err := s.ssoService.Required(ctx, email)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrSSORequired
+	}
+	return nil
+}
+
+// checkSSODomain makes sure the identity provider of an organization only
+// logs in emails of its verified domains, so it can't log in the users of
+// other organizations
+func checkSSODomain(provider SSOProvider, email string) error {
+	if !slices.Contains(provider.Domains, utils.ExtractDomainFromEmail(strings.ToLower(email))) {
+		return ErrSSODomainMismatch
+	}
+	return nil
+}
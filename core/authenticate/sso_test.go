@@ -0,0 +1,99 @@
+package authenticate_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newIssuer serves the discovery document of an identity provider
+func newIssuer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+			"jwks_uri":               srv.URL + "/jwks",
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestService_StartFlow_SSO(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	orgID := uuid.NewString()
+
+	newService := func(t *testing.T) (*authenticate.Service, *mocks.FlowRepository, *mocks.SSOService) {
+		flows, users, _, sessions, _ := createMocks(t)
+		sso := mocks.NewSSOService(t)
+		s := authenticate.NewService(nil, authenticate.Config{
+			Password: testPasswordConfig,
+			SSO:      authenticate.SSOConfig{Validity: 10 * time.Minute},
+		}, flows, nil, nil, sessions, users, nil, nil, nil, mocks.NewPasswordRepository(t), nil, nil, sso)
+		s.Now = func() time.Time {
+			return now
+		}
+		return s, flows, sso
+	}
+
+	t.Run("should send the user to the provider of the organization", func(t *testing.T) {
+		issuer := newIssuer(t)
+		s, flows, sso := newService(t)
+		sso.EXPECT().Route(mock.Anything, "John.Doe@acme.org").Return(authenticate.SSOProvider{
+			OrgID:        orgID,
+			IssuerURL:    issuer.URL,
+			ClientID:     "frontier",
+			ClientSecret: "secret",
+			Domains:      []string{"acme.org"},
+		}, nil)
+		flows.EXPECT().Set(mock.Anything, mock.MatchedBy(func(f *authenticate.Flow) bool {
+			return f.Metadata["org_id"] == orgID && f.Email == "john.doe@acme.org" &&
+				f.ExpiresAt.Equal(now.Add(10*time.Minute))
+		})).Return(nil)
+
+		got, err := s.StartFlow(context.Background(), authenticate.RegistrationStartRequest{
+			Method:      authenticate.SSOAuthMethod.String(),
+			Email:       "John.Doe@acme.org",
+			CallbackUrl: "http://localhost:3000/callback",
+		})
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(got.Flow.StartURL, issuer.URL+"/authorize"))
+	})
+
+	t.Run("should fail for domains without a provider", func(t *testing.T) {
+		s, _, sso := newService(t)
+		sso.EXPECT().Route(mock.Anything, "john.doe@example.com").Return(authenticate.SSOProvider{}, authenticate.ErrSSONotConfigured)
+
+		_, err := s.StartFlow(context.Background(), authenticate.RegistrationStartRequest{
+			Method: authenticate.SSOAuthMethod.String(),
+			Email:  "john.doe@example.com",
+		})
+		assert.ErrorIs(t, err, authenticate.ErrSSONotConfigured)
+	})
+
+	t.Run("should reject other strategies if the organization enforces sso", func(t *testing.T) {
+		s, _, sso := newService(t)
+		sso.EXPECT().Required(mock.Anything, "john.doe@acme.org").Return(true, nil)
+
+		_, err := s.StartFlow(context.Background(), authenticate.RegistrationStartRequest{
+			Method: authenticate.PasswordAuthMethod.String(),
+			Email:  "john.doe@acme.org",
+		})
+		assert.ErrorIs(t, err, authenticate.ErrSSORequired)
+	})
+}
@@ -10,6 +10,8 @@ const (
 	PasskeyAuthMethod   string = "passkey"
 	PasskeyRegisterType string = "register"
 	PasskeyLoginType    string = "login"
+	// PasskeyAddType adds a passkey to the account of a logged in user
+	PasskeyAddType string = "add"
 )
 
 type UserData struct {
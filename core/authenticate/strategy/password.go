@@ -0,0 +1,384 @@
+package strategy
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/raystack/frontier/pkg/mailer"
+	"golang.org/x/crypto/argon2"
+	"gopkg.in/mail.v2"
+)
+
+const (
+	PasswordAuthMethod string = "password"
+
+	// hashPrefixLength is the number of hex chars of the sha1 of a password
+	// breached passwords are looked up by, like the k-anonymity range api of
+	// haveibeenpwned
+	hashPrefixLength = 5
+	// hashLineChunk is the size read while looking for a line boundary, it
+	// fits the longest line of the breached passwords file
+	hashLineChunk = 128
+)
+
+var (
+	ErrWeakPassword        = errors.New("password doesn't satisfy the complexity rules")
+	ErrBreachedPassword    = errors.New("password has appeared in a data breach, choose another one")
+	ErrInvalidPasswordHash = errors.New("invalid password hash")
+
+	passwordSaltEncoding = base64.RawStdEncoding
+)
+
+// Argon2Params are the cost parameters of argon2id, the defaults follow the
+// second recommended option of RFC 9106
+type Argon2Params struct {
+	// Memory is in KiB
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+	SaltLength  uint32
+	KeyLength   uint32
+}
+
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// PasswordPolicy are the complexity rules a new password must satisfy
+type PasswordPolicy struct {
+	MinLength        int
+	MaxLength        int
+	RequireUppercase bool
+	RequireLowercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+}
+
+// BreachChecker tells if a password is known to have been leaked
+type BreachChecker interface {
+	IsBreached(password string) (bool, error)
+}
+
+// Password hashes passwords with argon2id and validates new ones against the
+// policy and the breached passwords
+type Password struct {
+	params   Argon2Params
+	policy   PasswordPolicy
+	breaches BreachChecker
+}
+
+// NewPassword creates the password strategy, breaches is optional
+func NewPassword(params Argon2Params, policy PasswordPolicy, breaches BreachChecker) *Password {
+	return &Password{
+		params:   params,
+		policy:   policy,
+		breaches: breaches,
+	}
+}
+
+// Hash encodes the password in the PHC string format:
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<hash>
+func (p Password) Hash(password string) (string, error) {
+	salt := make([]byte, p.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, p.params.Iterations, p.params.Memory, p.params.Parallelism, p.params.KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version,
+		p.params.Memory, p.params.Iterations, p.params.Parallelism,
+		passwordSaltEncoding.EncodeToString(salt), passwordSaltEncoding.EncodeToString(key)), nil
+}
+
+// Verify checks the password against a hash created by Hash, the parameters
+// of the hash are used so hashes outlive a change of the configured ones
+func (p Password) Verify(password, encoded string) (bool, error) {
+	params, salt, key, err := decodePasswordHash(encoded)
+	if err != nil {
+		return false, err
+	}
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash tells if the hash was created with other parameters than the
+// configured ones, it should be replaced once the password is verified
+func (p Password) NeedsRehash(encoded string) bool {
+	params, _, _, err := decodePasswordHash(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Memory != p.params.Memory || params.Iterations != p.params.Iterations ||
+		params.Parallelism != p.params.Parallelism || params.KeyLength != p.params.KeyLength
+}
+
+// Validate checks a new password against the policy and the breached
+// passwords, personal values like the email of the user are rejected too
+func (p Password) Validate(password string, personal ...string) error {
+	length := utf8.RuneCountInString(password)
+	if p.policy.MinLength > 0 && length < p.policy.MinLength {
+		return fmt.Errorf("%w: it must be at least %d characters long", ErrWeakPassword, p.policy.MinLength)
+	}
+	if p.policy.MaxLength > 0 && length > p.policy.MaxLength {
+		return fmt.Errorf("%w: it must be at most %d characters long", ErrWeakPassword, p.policy.MaxLength)
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	switch {
+	case p.policy.RequireUppercase && !upper:
+		return fmt.Errorf("%w: it must contain an uppercase letter", ErrWeakPassword)
+	case p.policy.RequireLowercase && !lower:
+		return fmt.Errorf("%w: it must contain a lowercase letter", ErrWeakPassword)
+	case p.policy.RequireDigit && !digit:
+		return fmt.Errorf("%w: it must contain a digit", ErrWeakPassword)
+	case p.policy.RequireSymbol && !symbol:
+		return fmt.Errorf("%w: it must contain a symbol", ErrWeakPassword)
+	}
+
+	for _, value := range personal {
+		if value != "" && strings.EqualFold(password, value) {
+			return fmt.Errorf("%w: it must not be your email or name", ErrWeakPassword)
+		}
+	}
+
+	if p.breaches != nil {
+		breached, err := p.breaches.IsBreached(password)
+		if err != nil {
+			return err
+		}
+		if breached {
+			return ErrBreachedPassword
+		}
+	}
+	return nil
+}
+
+// PasswordResetMail sends the token to reset a password with to the user
+type PasswordResetMail struct {
+	dialer  mailer.Dialer
+	subject string
+	body    string
+	Now     func() time.Time
+}
+
+func NewPasswordResetMail(d mailer.Dialer, subject, body string) *PasswordResetMail {
+	return &PasswordResetMail{
+		dialer:  d,
+		subject: subject,
+		body:    body,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+func (m PasswordResetMail) SendMail(to, token string) error {
+	values := map[string]string{
+		"Email": to,
+		"Token": token,
+	}
+	var body, subject bytes.Buffer
+	t, err := template.New("body").Parse(m.body)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+	if err = t.Execute(&body, values); err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+	t, err = template.New("sub").Parse(m.subject)
+	if err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+	if err = t.Execute(&subject, values); err != nil {
+		return fmt.Errorf("failed to parse email template: %w", err)
+	}
+
+	msg := mail.NewMessage()
+	msg.SetHeader("From", m.dialer.FromHeader())
+	msg.SetHeader("To", to)
+	msg.SetHeader("Subject", subject.String())
+	msg.SetBody("text/html", body.String())
+	msg.SetDateHeader("Date", m.Now())
+	return m.dialer.DialAndSend(msg)
+}
+
+func decodePasswordHash(encoded string) (Argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return Argon2Params{}, nil, nil, ErrInvalidPasswordHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return Argon2Params{}, nil, nil, ErrInvalidPasswordHash
+	}
+	var params Argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return Argon2Params{}, nil, nil, ErrInvalidPasswordHash
+	}
+	salt, err := passwordSaltEncoding.DecodeString(parts[4])
+	if err != nil {
+		return Argon2Params{}, nil, nil, ErrInvalidPasswordHash
+	}
+	key, err := passwordSaltEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return Argon2Params{}, nil, nil, ErrInvalidPasswordHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
+
+// HashPrefixFile looks up breached passwords in a local copy of the pwned
+// passwords, a file of upper case sha1 hashes sorted by hash with a line per
+// hash like "<SHA1>:<COUNT>" as produced by the haveibeenpwned downloader.
+// It is searched by the hash prefix like the range api so the lookup works
+// without network access and reads only a few blocks of the file.
+type HashPrefixFile struct {
+	file *os.File
+	size int64
+}
+
+// OpenHashPrefixFile opens the breached passwords file, it is kept open until
+// Close is called
+func OpenHashPrefixFile(path string) (*HashPrefixFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to open breached passwords file: %w", err)
+	}
+	return &HashPrefixFile{
+		file: file,
+		size: info.Size(),
+	}, nil
+}
+
+func (f *HashPrefixFile) IsBreached(password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	suffixes, err := f.Range(hash[:hashPrefixLength])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if subtle.ConstantTimeCompare([]byte(suffix), []byte(hash[hashPrefixLength:])) == 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Range returns the suffixes of the hashes starting with the prefix
+func (f *HashPrefixFile) Range(prefix string) ([]string, error) {
+	prefix = strings.ToUpper(prefix)
+
+	// binary search the first line which isn't sorted before the prefix
+	lo, hi := int64(0), f.size
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start, err := f.lineStart(mid)
+		if err != nil {
+			return nil, err
+		}
+		if start >= f.size {
+			hi = mid
+			continue
+		}
+		line, err := f.readLine(start)
+		if err != nil {
+			return nil, err
+		}
+		if hashOf(line) >= prefix {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	start, err := f.lineStart(lo)
+	if err != nil {
+		return nil, err
+	}
+
+	var suffixes []string
+	scanner := bufio.NewScanner(io.NewSectionReader(f.file, start, f.size-start))
+	for scanner.Scan() {
+		hash := hashOf(scanner.Text())
+		if !strings.HasPrefix(hash, prefix) {
+			break
+		}
+		suffixes = append(suffixes, hash[len(prefix):])
+	}
+	return suffixes, scanner.Err()
+}
+
+func (f *HashPrefixFile) Close() error {
+	return f.file.Close()
+}
+
+// lineStart returns the offset of the first line starting at or after offset
+func (f *HashPrefixFile) lineStart(offset int64) (int64, error) {
+	if offset == 0 {
+		return 0, nil
+	}
+	// the line starts at offset if the previous char ends a line
+	offset--
+	buf := make([]byte, hashLineChunk)
+	for offset < f.size {
+		n, err := f.file.ReadAt(buf, offset)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			return offset + int64(i) + 1, nil
+		}
+		offset += int64(n)
+	}
+	return f.size, nil
+}
+
+func (f *HashPrefixFile) readLine(offset int64) (string, error) {
+	line, err := bufio.NewReaderSize(io.NewSectionReader(f.file, offset, f.size-offset), hashLineChunk).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	return line, nil
+}
+
+// hashOf strips the count and the line ending of a line
+func hashOf(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
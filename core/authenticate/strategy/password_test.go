@@ -0,0 +1,182 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testArgon2Params keeps the tests fast, they aren't fit for production
+var testArgon2Params = Argon2Params{
+	Memory:      64,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// breachedHashes are sorted sha1 hashes, 5BAA6... is the hash of "password"
+// and 7C4A8... the hash of "123456"
+var breachedHashes = []string{
+	"000000005AD76BD555C1D6D771DE417A4B87E4B4:10",
+	"5BAA5FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:1",
+	"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:10434004",
+	"5BAA6FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF:2",
+	"7C4A8D09CA3762AF61E59520943DC26494F8941B:37359195",
+	"FFFFFFF8A0382AA9C8D9536EFBA43DDF5EB3A0A7:1",
+}
+
+func TestPassword_HashAndVerify(t *testing.T) {
+	p := NewPassword(testArgon2Params, PasswordPolicy{}, nil)
+	hash, err := p.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	ok, err := p.Verify("correct horse battery staple", hash)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = p.Verify("Correct horse battery staple", hash)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := p.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "hashes should be salted")
+
+	_, err = p.Verify("password", "$2a$10$notargon")
+	assert.ErrorIs(t, err, ErrInvalidPasswordHash)
+}
+
+func TestPassword_NeedsRehash(t *testing.T) {
+	p := NewPassword(testArgon2Params, PasswordPolicy{}, nil)
+	hash, err := p.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	assert.False(t, p.NeedsRehash(hash))
+
+	stronger := testArgon2Params
+	stronger.Iterations = 2
+	assert.True(t, NewPassword(stronger, PasswordPolicy{}, nil).NeedsRehash(hash))
+	assert.True(t, p.NeedsRehash("invalid"))
+}
+
+func TestPassword_Validate(t *testing.T) {
+	breaches := openTestHashPrefixFile(t)
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		personal []string
+		wantErr  error
+	}{
+		{
+			name:     "should accept passwords satisfying the policy",
+			policy:   PasswordPolicy{MinLength: 12, MaxLength: 64, RequireUppercase: true, RequireDigit: true, RequireSymbol: true},
+			password: "Tr0ub4dor&3-horse",
+		},
+		{
+			name:     "should count characters rather than bytes",
+			policy:   PasswordPolicy{MinLength: 6},
+			password: "pässwö",
+		},
+		{
+			name:     "should reject short passwords",
+			policy:   PasswordPolicy{MinLength: 12},
+			password: "horse",
+			wantErr:  ErrWeakPassword,
+		},
+		{
+			name:     "should reject long passwords",
+			policy:   PasswordPolicy{MaxLength: 8},
+			password: "correct horse battery staple",
+			wantErr:  ErrWeakPassword,
+		},
+		{
+			name:     "should require an uppercase letter",
+			policy:   PasswordPolicy{RequireUppercase: true},
+			password: "correct horse",
+			wantErr:  ErrWeakPassword,
+		},
+		{
+			name:     "should require a digit",
+			policy:   PasswordPolicy{RequireDigit: true},
+			password: "correct horse",
+			wantErr:  ErrWeakPassword,
+		},
+		{
+			name:     "should require a symbol",
+			policy:   PasswordPolicy{RequireSymbol: true},
+			password: "CorrectHorse1",
+			wantErr:  ErrWeakPassword,
+		},
+		{
+			name:     "should reject the email of the user",
+			password: "John.Doe@example.com",
+			personal: []string{"john.doe@example.com"},
+			wantErr:  ErrWeakPassword,
+		},
+		{
+			name:     "should reject breached passwords",
+			password: "password",
+			wantErr:  ErrBreachedPassword,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewPassword(testArgon2Params, tt.policy, breaches).Validate(tt.password, tt.personal...)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestHashPrefixFile_Range(t *testing.T) {
+	f := openTestHashPrefixFile(t)
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "00000", want: []string{"0005AD76BD555C1D6D771DE417A4B87E4B4"}},
+		{prefix: "5baa6", want: []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8", "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"}},
+		{prefix: "7C4A8", want: []string{"D09CA3762AF61E59520943DC26494F8941B"}},
+		{prefix: "FFFFF", want: []string{"FF8A0382AA9C8D9536EFBA43DDF5EB3A0A7"}},
+		{prefix: "12345", want: nil},
+	}
+	for _, tt := range tests {
+		got, err := f.Range(tt.prefix)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.prefix)
+	}
+}
+
+func TestHashPrefixFile_IsBreached(t *testing.T) {
+	f := openTestHashPrefixFile(t)
+	for password, want := range map[string]bool{
+		"password":                     true,
+		"123456":                       true,
+		"correct horse battery staple": false,
+	} {
+		got, err := f.IsBreached(password)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got, password)
+	}
+}
+
+func openTestHashPrefixFile(t *testing.T) *HashPrefixFile {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(breachedHashes, "\r\n")+"\r\n"), 0o600))
+	f, err := OpenHashPrefixFile(path)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = f.Close()
+	})
+	return f
+}
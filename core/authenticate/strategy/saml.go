@@ -0,0 +1,581 @@
+package strategy
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	SAMLAuthMethod string = "saml"
+
+	samlAssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	samlProtocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlMetadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	SAMLHTTPRedirectBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	SAMLHTTPPostBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	samlStatusSuccess       = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	samlBearerConfirmation  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	samlEmailNameIDFormat   = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	samlProtocolEnumeration = "urn:oasis:names:tc:SAML:2.0:protocol"
+
+	// digest methods accepted on signature references, sha1 is rejected
+	samlDigestSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	samlDigestSHA384 = "http://www.w3.org/2001/04/xmldsig-more#sha384"
+	samlDigestSHA512 = "http://www.w3.org/2001/04/xmlenc#sha512"
+
+	// samlClockSkew is the tolerance applied to validity windows of assertions
+	samlClockSkew = 3 * time.Minute
+)
+
+var (
+	ErrInvalidSAMLMetadata = errors.New("invalid saml metadata")
+	ErrInvalidSAMLResponse = errors.New("invalid saml response")
+
+	ErrMissingSAMLSignature = errors.New("saml response is not signed")
+	ErrInvalidSAMLSignature = errors.New("invalid saml signature")
+)
+
+// SAMLIdentityProvider is the subset of an IdP metadata needed for SP initiated logins
+type SAMLIdentityProvider struct {
+	EntityID string
+	// SSOURL is the single sign on endpoint with the HTTP-Redirect binding
+	SSOURL       string
+	Certificates []*x509.Certificate
+}
+
+// SAMLAssertion is the authenticated subject of a verified saml response
+type SAMLAssertion struct {
+	// ID identifies the assertion at its issuer, it must be recorded until the
+	// assertion expires so that a captured response can't be replayed
+	ID string
+	// ExpiresAt is the instant from which the assertion is rejected
+	ExpiresAt time.Time
+	NameID    string
+	// Attributes are keyed by both the name and the friendly name of the attribute
+	Attributes map[string][]string
+}
+
+// Attribute returns the first value of the first attribute present among the names
+func (a SAMLAssertion) Attribute(names ...string) string {
+	for _, name := range names {
+		if values := a.Attributes[name]; len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+// ParseSAMLMetadata reads the identity provider from its metadata document
+func ParseSAMLMetadata(data []byte) (SAMLIdentityProvider, error) {
+	root, err := parseSAMLDocument(data)
+	if err != nil {
+		return SAMLIdentityProvider{}, fmt.Errorf("%w: %w", ErrInvalidSAMLMetadata, err)
+	}
+
+	entities := []*etree.Element{root}
+	if isSAMLElement(root, samlMetadataNamespace, "EntitiesDescriptor") {
+		entities = samlChildElements(root, samlMetadataNamespace, "EntityDescriptor")
+	}
+	for _, entity := range entities {
+		if !isSAMLElement(entity, samlMetadataNamespace, "EntityDescriptor") {
+			continue
+		}
+		descriptor := samlChildElement(entity, samlMetadataNamespace, "IDPSSODescriptor")
+		if descriptor == nil {
+			continue
+		}
+
+		idp := SAMLIdentityProvider{
+			EntityID: samlAttr(entity, "entityID"),
+		}
+		for _, sso := range samlChildElements(descriptor, samlMetadataNamespace, "SingleSignOnService") {
+			if samlAttr(sso, "Binding") == SAMLHTTPRedirectBinding {
+				idp.SSOURL = samlAttr(sso, "Location")
+				break
+			}
+		}
+		for _, keyDescriptor := range samlChildElements(descriptor, samlMetadataNamespace, "KeyDescriptor") {
+			if use := samlAttr(keyDescriptor, "use"); use != "" && use != "signing" {
+				continue
+			}
+			keyInfo := samlChildElement(keyDescriptor, dsig.Namespace, dsig.KeyInfoTag)
+			if keyInfo == nil {
+				continue
+			}
+			for _, x509Data := range samlChildElements(keyInfo, dsig.Namespace, dsig.X509DataTag) {
+				for _, encoded := range samlChildElements(x509Data, dsig.Namespace, dsig.X509CertificateTag) {
+					cert, err := parseSAMLCertificate(samlText(encoded))
+					if err != nil {
+						return SAMLIdentityProvider{}, fmt.Errorf("%w: %w", ErrInvalidSAMLMetadata, err)
+					}
+					idp.Certificates = append(idp.Certificates, cert)
+				}
+			}
+		}
+
+		switch {
+		case idp.EntityID == "":
+			return SAMLIdentityProvider{}, fmt.Errorf("%w: missing entity id", ErrInvalidSAMLMetadata)
+		case idp.SSOURL == "":
+			return SAMLIdentityProvider{}, fmt.Errorf("%w: missing HTTP-Redirect single sign on service", ErrInvalidSAMLMetadata)
+		case len(idp.Certificates) == 0:
+			return SAMLIdentityProvider{}, fmt.Errorf("%w: missing signing certificate", ErrInvalidSAMLMetadata)
+		}
+		return idp, nil
+	}
+	return SAMLIdentityProvider{}, fmt.Errorf("%w: no identity provider descriptor found", ErrInvalidSAMLMetadata)
+}
+
+// SAML is a service provider for SP initiated logins against a single identity
+// provider. Requests are sent with the HTTP-Redirect binding and responses are
+// received on the assertion consumer service with the HTTP-POST binding
+type SAML struct {
+	entityID string
+	acsURL   string
+	idp      SAMLIdentityProvider
+	Now      func() time.Time
+}
+
+func NewSAML(entityID, acsURL string, idp SAMLIdentityProvider) *SAML {
+	return &SAML{
+		entityID: entityID,
+		acsURL:   acsURL,
+		idp:      idp,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// AuthURL builds the url redirecting the user to the identity provider, the
+// request id must be kept to validate the response
+func (s SAML) AuthURL(relayState string) (authURL string, requestID string, err error) {
+	requestID, err = generateSAMLID()
+	if err != nil {
+		return "", "", err
+	}
+
+	request := etree.NewElement("samlp:AuthnRequest")
+	request.CreateAttr("xmlns:samlp", samlProtocolNamespace)
+	request.CreateAttr("xmlns:saml", samlAssertionNamespace)
+	request.CreateAttr("ID", requestID)
+	request.CreateAttr("Version", "2.0")
+	request.CreateAttr("IssueInstant", s.Now().UTC().Format(time.RFC3339))
+	request.CreateAttr("Destination", s.idp.SSOURL)
+	request.CreateAttr("AssertionConsumerServiceURL", s.acsURL)
+	request.CreateAttr("ProtocolBinding", SAMLHTTPPostBinding)
+	request.CreateElement("saml:Issuer").SetText(s.entityID)
+	nameIDPolicy := request.CreateElement("samlp:NameIDPolicy")
+	nameIDPolicy.CreateAttr("Format", samlEmailNameIDFormat)
+	nameIDPolicy.CreateAttr("AllowCreate", "true")
+	encodedRequest, err := samlDocumentBytes(request)
+	if err != nil {
+		return "", "", err
+	}
+
+	var buf bytes.Buffer
+	writer, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err = writer.Write(encodedRequest); err != nil {
+		return "", "", err
+	}
+	if err = writer.Close(); err != nil {
+		return "", "", err
+	}
+
+	u, err := url.Parse(s.idp.SSOURL)
+	if err != nil {
+		return "", "", err
+	}
+	query := u.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	if relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), requestID, nil
+}
+
+// ParseResponse verifies the base64 encoded response posted to the assertion
+// consumer service for the request and returns its assertion. Either the
+// response or the assertion must be signed by a certificate of the identity
+// provider, encrypted assertions are not supported. The caller must reject an
+// assertion id it has already consumed before the assertion expires
+func (s SAML) ParseResponse(encoded string, requestID string) (*SAMLAssertion, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSAMLResponse, err)
+	}
+	response, err := parseSAMLDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSAMLResponse, err)
+	}
+	if !isSAMLElement(response, samlProtocolNamespace, "Response") {
+		return nil, fmt.Errorf("%w: expected a response", ErrInvalidSAMLResponse)
+	}
+
+	// values are only read from the copies returned by the signature
+	// verification, anything outside of the signed content is dropped
+	response, responseSigned, err := s.verifySignature(response)
+	if err != nil {
+		return nil, err
+	}
+
+	if destination := samlAttr(response, "Destination"); destination != "" && destination != s.acsURL {
+		return nil, fmt.Errorf("%w: unexpected destination", ErrInvalidSAMLResponse)
+	}
+	// unsolicited responses are rejected
+	if requestID == "" || samlAttr(response, "InResponseTo") != requestID {
+		return nil, fmt.Errorf("%w: response doesn't match the request", ErrInvalidSAMLResponse)
+	}
+	if issuer := samlChildElement(response, samlAssertionNamespace, "Issuer"); issuer != nil && samlText(issuer) != s.idp.EntityID {
+		return nil, fmt.Errorf("%w: unexpected issuer", ErrInvalidSAMLResponse)
+	}
+	var statusCode string
+	if status := samlChildElement(response, samlProtocolNamespace, "Status"); status != nil {
+		if code := samlChildElement(status, samlProtocolNamespace, "StatusCode"); code != nil {
+			statusCode = samlAttr(code, "Value")
+		}
+	}
+	if statusCode != samlStatusSuccess {
+		return nil, fmt.Errorf("%w: authentication failed with status %s", ErrInvalidSAMLResponse, statusCode)
+	}
+
+	if len(samlChildElements(response, samlAssertionNamespace, "EncryptedAssertion")) > 0 {
+		return nil, fmt.Errorf("%w: encrypted assertions are not supported", ErrInvalidSAMLResponse)
+	}
+	assertions := samlChildElements(response, samlAssertionNamespace, "Assertion")
+	if len(assertions) != 1 {
+		return nil, fmt.Errorf("%w: expected a single assertion", ErrInvalidSAMLResponse)
+	}
+	assertion, assertionSigned, err := s.verifySignature(assertions[0])
+	if err != nil {
+		return nil, err
+	}
+	if !responseSigned && !assertionSigned {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSAMLResponse, ErrMissingSAMLSignature)
+	}
+
+	return s.parseAssertion(assertion, requestID)
+}
+
+// verifySignature checks the enveloped signature of the element and returns
+// the signed content with the signature removed. Unsigned elements are
+// returned as is, a present signature must be valid
+func (s SAML) verifySignature(e *etree.Element) (*etree.Element, bool, error) {
+	signatures := samlChildElements(e, dsig.Namespace, dsig.SignatureTag)
+	if len(signatures) == 0 {
+		return e, false, nil
+	}
+	if len(signatures) > 1 {
+		return nil, false, samlSignatureError(errors.New("multiple signatures"))
+	}
+	if err := checkSAMLSignature(signatures[0], samlAttr(e, "ID")); err != nil {
+		return nil, false, samlSignatureError(err)
+	}
+
+	// the element is detached with the namespaces declared on its ancestors so
+	// that it can be canonicalized on its own
+	nsContext, err := etreeutils.NSBuildParentContext(e)
+	if err != nil {
+		return nil, false, samlSignatureError(err)
+	}
+	detached, err := etreeutils.NSDetatch(nsContext, e)
+	if err != nil {
+		return nil, false, samlSignatureError(err)
+	}
+
+	validation := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: s.idp.Certificates,
+	})
+	validation.IdAttribute = "ID"
+	validation.Clock = dsig.NewFakeClockAt(s.Now())
+	verified, err := validation.Validate(detached)
+	if err != nil {
+		return nil, false, samlSignatureError(err)
+	}
+	return verified, true, nil
+}
+
+// checkSAMLSignature restricts the signature to a single enveloped reference of
+// the element with exclusive canonicalization and without sha1
+func checkSAMLSignature(signature *etree.Element, id string) error {
+	signedInfo := samlChildElement(signature, dsig.Namespace, dsig.SignedInfoTag)
+	if signedInfo == nil {
+		return errors.New("missing SignedInfo")
+	}
+	c14n := samlChildElement(signedInfo, dsig.Namespace, dsig.CanonicalizationMethodTag)
+	if c14n == nil || samlAttr(c14n, dsig.AlgorithmAttr) != dsig.CanonicalXML10ExclusiveAlgorithmId.String() {
+		return errors.New("unsupported canonicalization method")
+	}
+	signatureMethod := samlChildElement(signedInfo, dsig.Namespace, dsig.SignatureMethodTag)
+	if signatureMethod == nil {
+		return errors.New("missing SignatureMethod")
+	}
+	switch algorithm := samlAttr(signatureMethod, dsig.AlgorithmAttr); algorithm {
+	case dsig.RSASHA256SignatureMethod, dsig.RSASHA384SignatureMethod, dsig.RSASHA512SignatureMethod:
+	default:
+		return fmt.Errorf("unsupported signature method %s", algorithm)
+	}
+
+	references := samlChildElements(signedInfo, dsig.Namespace, dsig.ReferenceTag)
+	if len(references) != 1 {
+		return errors.New("expected a single reference")
+	}
+	reference := references[0]
+	if id == "" || samlAttr(reference, dsig.URIAttr) != "#"+id {
+		return errors.New("reference doesn't point to the signed element")
+	}
+	enveloped := false
+	if transforms := samlChildElement(reference, dsig.Namespace, dsig.TransformsTag); transforms != nil {
+		for _, transform := range samlChildElements(transforms, dsig.Namespace, dsig.TransformTag) {
+			switch algorithm := samlAttr(transform, dsig.AlgorithmAttr); algorithm {
+			case dsig.EnvelopedSignatureAltorithmId.String():
+				enveloped = true
+			case dsig.CanonicalXML10ExclusiveAlgorithmId.String():
+			default:
+				return fmt.Errorf("unsupported transform %s", algorithm)
+			}
+		}
+	}
+	if !enveloped {
+		return errors.New("signature must be enveloped")
+	}
+	digestMethod := samlChildElement(reference, dsig.Namespace, dsig.DigestMethodTag)
+	if digestMethod == nil {
+		return errors.New("missing DigestMethod")
+	}
+	switch algorithm := samlAttr(digestMethod, dsig.AlgorithmAttr); algorithm {
+	case samlDigestSHA256, samlDigestSHA384, samlDigestSHA512:
+	default:
+		return fmt.Errorf("unsupported digest method %s", algorithm)
+	}
+	return nil
+}
+
+func samlSignatureError(err error) error {
+	return fmt.Errorf("%w: %w: %w", ErrInvalidSAMLResponse, ErrInvalidSAMLSignature, err)
+}
+
+// parseAssertion validates the assertion of a response to the request. The
+// request id is matched against the subject confirmation so that it is covered
+// by the signature of the assertion, the one of an unsigned response envelope
+// can't be trusted
+func (s SAML) parseAssertion(assertion *etree.Element, requestID string) (*SAMLAssertion, error) {
+	now := s.Now()
+	id := samlAttr(assertion, "ID")
+	if id == "" {
+		return nil, fmt.Errorf("%w: missing assertion id", ErrInvalidSAMLResponse)
+	}
+	issuer := samlChildElement(assertion, samlAssertionNamespace, "Issuer")
+	if issuer == nil || samlText(issuer) != s.idp.EntityID {
+		return nil, fmt.Errorf("%w: unexpected assertion issuer", ErrInvalidSAMLResponse)
+	}
+
+	subject := samlChildElement(assertion, samlAssertionNamespace, "Subject")
+	if subject == nil {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidSAMLResponse)
+	}
+	nameID := samlChildElement(subject, samlAssertionNamespace, "NameID")
+	if nameID == nil || samlText(nameID) == "" {
+		return nil, fmt.Errorf("%w: missing name id", ErrInvalidSAMLResponse)
+	}
+	var expiresAt time.Time
+	for _, confirmation := range samlChildElements(subject, samlAssertionNamespace, "SubjectConfirmation") {
+		if samlAttr(confirmation, "Method") != samlBearerConfirmation {
+			continue
+		}
+		data := samlChildElement(confirmation, samlAssertionNamespace, "SubjectConfirmationData")
+		if data == nil || samlAttr(data, "Recipient") != s.acsURL {
+			continue
+		}
+		if samlAttr(data, "InResponseTo") != requestID {
+			continue
+		}
+		notOnOrAfter, err := time.Parse(time.RFC3339Nano, samlAttr(data, "NotOnOrAfter"))
+		if err != nil || !now.Before(notOnOrAfter.Add(samlClockSkew)) {
+			continue
+		}
+		expiresAt = notOnOrAfter.Add(samlClockSkew)
+		break
+	}
+	if expiresAt.IsZero() {
+		return nil, fmt.Errorf("%w: no valid bearer subject confirmation", ErrInvalidSAMLResponse)
+	}
+
+	conditions := samlChildElement(assertion, samlAssertionNamespace, "Conditions")
+	if conditions == nil {
+		return nil, fmt.Errorf("%w: missing conditions", ErrInvalidSAMLResponse)
+	}
+	if value := samlAttr(conditions, "NotBefore"); value != "" {
+		notBefore, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil || now.Add(samlClockSkew).Before(notBefore) {
+			return nil, fmt.Errorf("%w: assertion is not yet valid", ErrInvalidSAMLResponse)
+		}
+	}
+	if value := samlAttr(conditions, "NotOnOrAfter"); value != "" {
+		notOnOrAfter, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil || !now.Before(notOnOrAfter.Add(samlClockSkew)) {
+			return nil, fmt.Errorf("%w: assertion has expired", ErrInvalidSAMLResponse)
+		}
+		if notOnOrAfter.Add(samlClockSkew).Before(expiresAt) {
+			expiresAt = notOnOrAfter.Add(samlClockSkew)
+		}
+	}
+	restrictions := samlChildElements(conditions, samlAssertionNamespace, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return nil, fmt.Errorf("%w: missing audience restriction", ErrInvalidSAMLResponse)
+	}
+	for _, restriction := range restrictions {
+		allowed := false
+		for _, audience := range samlChildElements(restriction, samlAssertionNamespace, "Audience") {
+			if samlText(audience) == s.entityID {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return nil, fmt.Errorf("%w: assertion is not intended for this service provider", ErrInvalidSAMLResponse)
+		}
+	}
+
+	result := &SAMLAssertion{
+		ID:         id,
+		ExpiresAt:  expiresAt,
+		NameID:     samlText(nameID),
+		Attributes: map[string][]string{},
+	}
+	for _, statement := range samlChildElements(assertion, samlAssertionNamespace, "AttributeStatement") {
+		for _, attribute := range samlChildElements(statement, samlAssertionNamespace, "Attribute") {
+			var values []string
+			for _, value := range samlChildElements(attribute, samlAssertionNamespace, "AttributeValue") {
+				values = append(values, samlText(value))
+			}
+			for _, name := range []string{samlAttr(attribute, "Name"), samlAttr(attribute, "FriendlyName")} {
+				if name != "" {
+					result.Attributes[name] = append(result.Attributes[name], values...)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// Metadata returns the service provider metadata to be registered at the identity provider
+func (s SAML) Metadata() []byte {
+	entity := etree.NewElement("md:EntityDescriptor")
+	entity.CreateAttr("xmlns:md", samlMetadataNamespace)
+	entity.CreateAttr("entityID", s.entityID)
+	descriptor := entity.CreateElement("md:SPSSODescriptor")
+	descriptor.CreateAttr("AuthnRequestsSigned", "false")
+	descriptor.CreateAttr("WantAssertionsSigned", "true")
+	descriptor.CreateAttr("protocolSupportEnumeration", samlProtocolEnumeration)
+	descriptor.CreateElement("md:NameIDFormat").SetText(samlEmailNameIDFormat)
+	acs := descriptor.CreateElement("md:AssertionConsumerService")
+	acs.CreateAttr("Binding", SAMLHTTPPostBinding)
+	acs.CreateAttr("Location", s.acsURL)
+	acs.CreateAttr("index", "1")
+	// serializing an in-memory tree of elements and attributes doesn't fail
+	data, _ := samlDocumentBytes(entity)
+	return data
+}
+
+// generateSAMLID creates a random xml id, it must not start with a digit
+func generateSAMLID() (string, error) {
+	idBytes := make([]byte, 16)
+	if _, err := io.ReadFull(rand.Reader, idBytes); err != nil {
+		return "", err
+	}
+	return "id" + hex.EncodeToString(idBytes), nil
+}
+
+// parseSAMLDocument returns the root element of a single xml document
+func parseSAMLDocument(data []byte) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	root := doc.Root()
+	if root == nil {
+		return nil, errors.New("missing root element")
+	}
+	return root, nil
+}
+
+func samlDocumentBytes(root *etree.Element) ([]byte, error) {
+	doc := etree.NewDocument()
+	doc.SetRoot(root)
+	return doc.WriteToBytes()
+}
+
+func isSAMLElement(e *etree.Element, namespace, local string) bool {
+	return e.Tag == local && e.NamespaceURI() == namespace
+}
+
+// samlChildElements returns the direct children of the element with the
+// namespace and local name, nested elements are never looked up
+func samlChildElements(e *etree.Element, namespace, local string) []*etree.Element {
+	var children []*etree.Element
+	for _, child := range e.ChildElements() {
+		if isSAMLElement(child, namespace, local) {
+			children = append(children, child)
+		}
+	}
+	return children
+}
+
+func samlChildElement(e *etree.Element, namespace, local string) *etree.Element {
+	if children := samlChildElements(e, namespace, local); len(children) > 0 {
+		return children[0]
+	}
+	return nil
+}
+
+// samlAttr returns the value of an unqualified attribute, unlike
+// etree.SelectAttr attributes of another namespace never match
+func samlAttr(e *etree.Element, name string) string {
+	for _, a := range e.Attr {
+		if a.Space == "" && a.Key == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// samlText concatenates the character data of the element, comments are
+// skipped so that a comment can't truncate a value
+func samlText(e *etree.Element) string {
+	var text strings.Builder
+	for _, token := range e.Child {
+		if data, ok := token.(*etree.CharData); ok {
+			text.WriteString(data.Data)
+		}
+	}
+	return strings.TrimSpace(text.String())
+}
+
+func parseSAMLCertificate(encoded string) (*x509.Certificate, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,380 @@
+package strategy
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/raystack/frontier/core/authenticate/strategy/samltest"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testSPEntityID = "https://frontier.example.com/saml/acme/metadata"
+	testACSURL     = "https://frontier.example.com/saml/acme/acs"
+)
+
+func newTestIdP(t *testing.T) *samltest.IdentityProvider {
+	t.Helper()
+	idp, err := samltest.New("https://idp.example.com", "https://idp.example.com/sso?tenant=acme")
+	assert.NoError(t, err)
+	return idp
+}
+
+func TestParseSAMLMetadata(t *testing.T) {
+	idp := newTestIdP(t)
+
+	got, err := ParseSAMLMetadata(idp.Metadata())
+	assert.NoError(t, err)
+	assert.Equal(t, idp.EntityID, got.EntityID)
+	assert.Equal(t, idp.SSOURL, got.SSOURL)
+	assert.Len(t, got.Certificates, 1)
+	assert.True(t, idp.Certificate.Equal(got.Certificates[0]))
+
+	wrapped := `<md:EntitiesDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata">` + string(idp.Metadata()) + `</md:EntitiesDescriptor>`
+	got, err = ParseSAMLMetadata([]byte(wrapped))
+	assert.NoError(t, err)
+	assert.Equal(t, idp.EntityID, got.EntityID)
+
+	_, err = ParseSAMLMetadata([]byte(`<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID="sp"><md:SPSSODescriptor/></md:EntityDescriptor>`))
+	assert.ErrorIs(t, err, ErrInvalidSAMLMetadata)
+
+	_, err = ParseSAMLMetadata([]byte(`not xml`))
+	assert.ErrorIs(t, err, ErrInvalidSAMLMetadata)
+}
+
+func TestSAML_AuthURL(t *testing.T) {
+	idp := newTestIdP(t)
+	sp := NewSAML(testSPEntityID, testACSURL, SAMLIdentityProvider{EntityID: idp.EntityID, SSOURL: idp.SSOURL})
+
+	authURL, requestID, err := sp.AuthURL("flow-state")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(requestID, "id"))
+
+	u, err := url.Parse(authURL)
+	assert.NoError(t, err)
+	assert.Equal(t, "idp.example.com", u.Host)
+	assert.Equal(t, "acme", u.Query().Get("tenant"))
+	assert.Equal(t, "flow-state", u.Query().Get("RelayState"))
+
+	deflated, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+	assert.NoError(t, err)
+	inflated, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	assert.NoError(t, err)
+	request, err := parseSAMLDocument(inflated)
+	assert.NoError(t, err)
+	assert.True(t, isSAMLElement(request, samlProtocolNamespace, "AuthnRequest"))
+	assert.Equal(t, requestID, samlAttr(request, "ID"))
+	assert.Equal(t, testACSURL, samlAttr(request, "AssertionConsumerServiceURL"))
+	assert.Equal(t, testSPEntityID, samlText(samlChildElement(request, samlAssertionNamespace, "Issuer")))
+}
+
+func TestSAML_ParseResponse(t *testing.T) {
+	idp := newTestIdP(t)
+	otherIdP := newTestIdP(t)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	requestID := "id0123456789"
+	expiresAt := now.Add(5*time.Minute + samlClockSkew)
+	valid := samltest.Response{
+		InResponseTo: requestID,
+		Destination:  testACSURL,
+		Audience:     testSPEntityID,
+		NameID:       "john@acme.org",
+		Attributes: map[string]string{
+			"email":       "john@acme.org",
+			"displayName": "John Doe",
+		},
+		IssuedAt: now,
+	}
+
+	tests := []struct {
+		name      string
+		response  func(r samltest.Response) samltest.Response
+		signer    *samltest.IdentityProvider
+		alter     func(t *testing.T, e *etree.Element)
+		requestID string
+		now       time.Time
+		want      *SAMLAssertion
+		wantErr   error
+	}{
+		{
+			name: "should accept a response with a signed assertion",
+			want: &SAMLAssertion{
+				ID:        "_assertion" + requestID,
+				ExpiresAt: expiresAt,
+				NameID:    "john@acme.org",
+				Attributes: map[string][]string{
+					"email":       {"john@acme.org"},
+					"displayName": {"John Doe"},
+				},
+			},
+		},
+		{
+			name: "should accept a signed response",
+			response: func(r samltest.Response) samltest.Response {
+				r.SignResponse = true
+				r.Attributes = nil
+				return r
+			},
+			want: &SAMLAssertion{
+				ID:         "_assertion" + requestID,
+				ExpiresAt:  expiresAt,
+				NameID:     "john@acme.org",
+				Attributes: map[string][]string{},
+			},
+		},
+		{
+			name: "should accept a response within the clock skew",
+			now:  now.Add(-time.Minute),
+			response: func(r samltest.Response) samltest.Response {
+				r.Attributes = nil
+				return r
+			},
+			want: &SAMLAssertion{
+				ID:         "_assertion" + requestID,
+				ExpiresAt:  expiresAt,
+				NameID:     "john@acme.org",
+				Attributes: map[string][]string{},
+			},
+		},
+		{
+			name: "should reject an unsigned response",
+			response: func(r samltest.Response) samltest.Response {
+				r.Unsigned = true
+				return r
+			},
+			wantErr: ErrMissingSAMLSignature,
+		},
+		{
+			name:    "should reject a response signed by another identity provider",
+			signer:  otherIdP,
+			wantErr: ErrInvalidSAMLSignature,
+		},
+		{
+			name: "should reject a tampered assertion",
+			alter: func(t *testing.T, e *etree.Element) {
+				testNameID(t, samlChildElement(e, samlAssertionNamespace, "Assertion")).SetText("admin@acme.org")
+			},
+			wantErr: ErrInvalidSAMLSignature,
+		},
+		{
+			name: "should reject an injected unsigned assertion",
+			alter: func(t *testing.T, e *etree.Element) {
+				e.CreateElement("saml:Assertion")
+			},
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name: "should reject a forged assertion wrapping the signed one",
+			alter: func(t *testing.T, e *etree.Element) {
+				// the signed assertion is hidden inside a forged copy keeping its id
+				original := samlChildElement(e, samlAssertionNamespace, "Assertion")
+				forged := original.Copy()
+				testNameID(t, forged).SetText("admin@acme.org")
+				e.RemoveChild(original)
+				forged.AddChild(original)
+				e.AddChild(forged)
+			},
+			wantErr: ErrInvalidSAMLSignature,
+		},
+		{
+			name: "should reject a forged assertion carrying the signature",
+			alter: func(t *testing.T, e *etree.Element) {
+				// the signature moves into a forged assertion next to the original one
+				original := samlChildElement(e, samlAssertionNamespace, "Assertion")
+				forged := original.Copy()
+				testNameID(t, forged).SetText("admin@acme.org")
+				signature := samlChildElement(original, dsig.Namespace, dsig.SignatureTag)
+				original.RemoveChild(signature)
+				e.RemoveChild(original)
+				e.AddChild(forged)
+				testExtensions(e).AddChild(original)
+			},
+			wantErr: ErrInvalidSAMLSignature,
+		},
+		{
+			name: "should reject a signed response wrapping a forged assertion",
+			response: func(r samltest.Response) samltest.Response {
+				r.SignResponse = true
+				return r
+			},
+			alter: func(t *testing.T, e *etree.Element) {
+				// the signed response is moved aside and a forged one takes its place
+				original := e.Copy()
+				testNameID(t, samlChildElement(e, samlAssertionNamespace, "Assertion")).SetText("admin@acme.org")
+				e.RemoveChild(samlChildElement(e, dsig.Namespace, dsig.SignatureTag))
+				testExtensions(e).AddChild(original)
+			},
+			wantErr: ErrMissingSAMLSignature,
+		},
+		{
+			name: "should reject a signature referencing another element",
+			alter: func(t *testing.T, e *etree.Element) {
+				assertion := samlChildElement(e, samlAssertionNamespace, "Assertion")
+				for i, a := range assertion.Attr {
+					if a.Key == "ID" {
+						assertion.Attr[i].Value = "_forged"
+					}
+				}
+			},
+			wantErr: ErrInvalidSAMLSignature,
+		},
+		{
+			name: "should not truncate a signed value at an injected comment",
+			response: func(r samltest.Response) samltest.Response {
+				r.NameID = "john@acme.org.evil.com"
+				r.Attributes = nil
+				return r
+			},
+			alter: func(t *testing.T, e *etree.Element) {
+				// comments are not covered by the signature
+				nameID := testNameID(t, samlChildElement(e, samlAssertionNamespace, "Assertion"))
+				nameID.SetText("john@acme.org")
+				nameID.CreateComment("injected")
+				nameID.CreateText(".evil.com")
+			},
+			want: &SAMLAssertion{
+				ID:         "_assertion" + requestID,
+				ExpiresAt:  expiresAt,
+				NameID:     "john@acme.org.evil.com",
+				Attributes: map[string][]string{},
+			},
+		},
+		{
+			name: "should reject a namespaced attribute shadowing the assertion id",
+			alter: func(t *testing.T, e *etree.Element) {
+				assertion := samlChildElement(e, samlAssertionNamespace, "Assertion")
+				assertion.CreateAttr("saml:ID", "_forged")
+				// the forged attribute comes first to be picked by lookups ignoring namespaces
+				last := len(assertion.Attr) - 1
+				assertion.Attr = append(assertion.Attr[last:], assertion.Attr[:last]...)
+			},
+			wantErr: ErrInvalidSAMLSignature,
+		},
+		{
+			name:      "should reject a response for another request",
+			requestID: "id-other",
+			wantErr:   ErrInvalidSAMLResponse,
+		},
+		{
+			name: "should reject an unsolicited response",
+			response: func(r samltest.Response) samltest.Response {
+				r.InResponseTo = ""
+				return r
+			},
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name: "should reject a signed assertion not confirming the request",
+			response: func(r samltest.Response) samltest.Response {
+				// only the unsigned response envelope refers to the request
+				r.UnconfirmedRequest = true
+				return r
+			},
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name: "should reject a response for another destination",
+			response: func(r samltest.Response) samltest.Response {
+				r.Destination = "https://evil.example.com/acs"
+				return r
+			},
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name: "should reject an assertion for another audience",
+			response: func(r samltest.Response) samltest.Response {
+				r.Audience = "https://other.example.com"
+				return r
+			},
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name:    "should reject an expired assertion",
+			now:     now.Add(time.Hour),
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name:    "should reject an assertion not yet valid",
+			now:     now.Add(-time.Hour),
+			wantErr: ErrInvalidSAMLResponse,
+		},
+		{
+			name: "should reject a failed authentication",
+			response: func(r samltest.Response) samltest.Response {
+				r.Status = samltest.StatusRequester
+				return r
+			},
+			wantErr: ErrInvalidSAMLResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid
+			if tt.response != nil {
+				r = tt.response(r)
+			}
+			signer := idp
+			if tt.signer != nil {
+				signer = tt.signer
+			}
+			response, err := signer.Build(r)
+			assert.NoError(t, err)
+			if tt.alter != nil {
+				tt.alter(t, response.Root())
+			}
+			data, err := response.WriteToBytes()
+			assert.NoError(t, err)
+			encoded := base64.StdEncoding.EncodeToString(data)
+
+			sp := NewSAML(testSPEntityID, testACSURL, SAMLIdentityProvider{
+				EntityID:     idp.EntityID,
+				SSOURL:       idp.SSOURL,
+				Certificates: []*x509.Certificate{idp.Certificate},
+			})
+			sp.Now = func() time.Time {
+				if !tt.now.IsZero() {
+					return tt.now
+				}
+				return now
+			}
+			expectedRequestID := requestID
+			if tt.requestID != "" {
+				expectedRequestID = tt.requestID
+			}
+
+			got, err := sp.ParseResponse(encoded, expectedRequestID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func testNameID(t *testing.T, assertion *etree.Element) *etree.Element {
+	t.Helper()
+	subject := samlChildElement(assertion, samlAssertionNamespace, "Subject")
+	if !assert.NotNil(t, subject) {
+		t.FailNow()
+	}
+	return samlChildElement(subject, samlAssertionNamespace, "NameID")
+}
+
+// testExtensions returns the protocol extensions of the response, a place
+// where arbitrary elements are allowed by the schema
+func testExtensions(response *etree.Element) *etree.Element {
+	extensions := etree.NewElement("samlp:Extensions")
+	response.InsertChildAt(1, extensions)
+	return extensions
+}
@@ -0,0 +1,222 @@
+// Package samltest provides an in-process saml identity provider to test
+// service provider logins without an external IdP
+package samltest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const (
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	metadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	StatusSuccess   = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	StatusRequester = "urn:oasis:names:tc:SAML:2.0:status:Requester"
+)
+
+type IdentityProvider struct {
+	EntityID    string
+	SSOURL      string
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+}
+
+// New creates an identity provider with a freshly generated self signed certificate
+func New(entityID, ssoURL string) (*IdentityProvider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: entityID},
+		// the validity is wide enough for tests running at a fixed time
+		NotBefore: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:  time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	return &IdentityProvider{
+		EntityID:    entityID,
+		SSOURL:      ssoURL,
+		Key:         key,
+		Certificate: cert,
+	}, nil
+}
+
+// Metadata returns the identity provider metadata document
+func (idp IdentityProvider) Metadata() []byte {
+	entity := etree.NewElement("md:EntityDescriptor")
+	entity.CreateAttr("xmlns:md", metadataNamespace)
+	entity.CreateAttr("xmlns:ds", dsig.Namespace)
+	entity.CreateAttr("entityID", idp.EntityID)
+	descriptor := entity.CreateElement("md:IDPSSODescriptor")
+	descriptor.CreateAttr("protocolSupportEnumeration", protocolNamespace)
+
+	keyDescriptor := descriptor.CreateElement("md:KeyDescriptor")
+	keyDescriptor.CreateAttr("use", "signing")
+	keyDescriptor.CreateElement("ds:KeyInfo").
+		CreateElement("ds:X509Data").
+		CreateElement("ds:X509Certificate").
+		SetText(base64.StdEncoding.EncodeToString(idp.Certificate.Raw))
+
+	sso := descriptor.CreateElement("md:SingleSignOnService")
+	sso.CreateAttr("Binding", "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect")
+	sso.CreateAttr("Location", idp.SSOURL)
+
+	doc := etree.NewDocument()
+	doc.SetRoot(entity)
+	data, _ := doc.WriteToBytes()
+	return data
+}
+
+// Response describes the response the identity provider issues
+type Response struct {
+	InResponseTo string
+	// Destination is the assertion consumer service url of the service provider
+	Destination string
+	Audience    string
+	NameID      string
+	Attributes  map[string]string
+	// Status defaults to success
+	Status   string
+	IssuedAt time.Time
+	// SignResponse signs the response instead of the assertion
+	SignResponse bool
+	// Unsigned skips signing the response
+	Unsigned bool
+	// UnconfirmedRequest omits the request id from the subject confirmation
+	// of the assertion, it is only kept on the response
+	UnconfirmedRequest bool
+}
+
+// Encode builds the response and returns it base64 encoded as posted to the
+// assertion consumer service
+func (idp IdentityProvider) Encode(r Response) (string, error) {
+	doc, err := idp.Build(r)
+	if err != nil {
+		return "", err
+	}
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// Build returns the response document, it can be altered before being encoded
+func (idp IdentityProvider) Build(r Response) (*etree.Document, error) {
+	issuedAt := r.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = time.Now().UTC()
+	}
+	status := r.Status
+	if status == "" {
+		status = StatusSuccess
+	}
+	instant := issuedAt.Format(time.RFC3339)
+	expiry := issuedAt.Add(5 * time.Minute).Format(time.RFC3339)
+
+	response := etree.NewElement("samlp:Response")
+	response.CreateAttr("xmlns:samlp", protocolNamespace)
+	response.CreateAttr("xmlns:saml", assertionNamespace)
+	response.CreateAttr("ID", "_response"+r.InResponseTo)
+	response.CreateAttr("Version", "2.0")
+	response.CreateAttr("IssueInstant", instant)
+	response.CreateAttr("Destination", r.Destination)
+	response.CreateAttr("InResponseTo", r.InResponseTo)
+	response.AddChild(idp.issuer())
+	response.CreateElement("samlp:Status").
+		CreateElement("samlp:StatusCode").
+		CreateAttr("Value", status)
+
+	assertion := response.CreateElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", assertionNamespace)
+	assertion.CreateAttr("ID", "_assertion"+r.InResponseTo)
+	assertion.CreateAttr("Version", "2.0")
+	assertion.CreateAttr("IssueInstant", instant)
+	assertion.AddChild(idp.issuer())
+
+	subject := assertion.CreateElement("saml:Subject")
+	nameID := subject.CreateElement("saml:NameID")
+	nameID.CreateAttr("Format", "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress")
+	nameID.SetText(r.NameID)
+	confirmation := subject.CreateElement("saml:SubjectConfirmation")
+	confirmation.CreateAttr("Method", "urn:oasis:names:tc:SAML:2.0:cm:bearer")
+	confirmationData := confirmation.CreateElement("saml:SubjectConfirmationData")
+	if !r.UnconfirmedRequest {
+		confirmationData.CreateAttr("InResponseTo", r.InResponseTo)
+	}
+	confirmationData.CreateAttr("NotOnOrAfter", expiry)
+	confirmationData.CreateAttr("Recipient", r.Destination)
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", instant)
+	conditions.CreateAttr("NotOnOrAfter", expiry)
+	conditions.CreateElement("saml:AudienceRestriction").
+		CreateElement("saml:Audience").
+		SetText(r.Audience)
+
+	if len(r.Attributes) > 0 {
+		statement := assertion.CreateElement("saml:AttributeStatement")
+		for name, value := range r.Attributes {
+			attribute := statement.CreateElement("saml:Attribute")
+			attribute.CreateAttr("Name", name)
+			attribute.CreateElement("saml:AttributeValue").SetText(value)
+		}
+	}
+
+	switch {
+	case r.Unsigned:
+	case r.SignResponse:
+		if err := idp.Sign(response); err != nil {
+			return nil, err
+		}
+	default:
+		if err := idp.Sign(assertion); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := etree.NewDocument()
+	doc.SetRoot(response)
+	return doc, nil
+}
+
+// Sign adds an enveloped signature of the element after its issuer
+func (idp IdentityProvider) Sign(e *etree.Element) error {
+	ctx := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(tls.Certificate{
+		Certificate: [][]byte{idp.Certificate.Raw},
+		PrivateKey:  idp.Key,
+	}))
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signature, err := ctx.ConstructSignature(e, true)
+	if err != nil {
+		return err
+	}
+	e.InsertChildAt(1, signature)
+	return nil
+}
+
+func (idp IdentityProvider) issuer() *etree.Element {
+	issuer := etree.NewElement("saml:Issuer")
+	issuer.SetText(idp.EntityID)
+	return issuer
+}
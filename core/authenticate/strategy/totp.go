@@ -0,0 +1,117 @@
+package strategy
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TOTPAuthMethod string = "totp"
+
+	totpDigits     = 6
+	totpPeriod     = 30
+	totpSecretSize = 20
+	// totpSkew is the number of periods before and after the current one
+	// codes are accepted for, to allow for clock drift of devices
+	totpSkew = 1
+)
+
+var (
+	ErrInvalidTOTP = errors.New("invalid totp code")
+
+	totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// TOTP generates and verifies time based one time passwords (RFC 6238) using
+// the parameters all authenticator apps support: HMAC-SHA1, 6 digits and a
+// 30 seconds period
+type TOTP struct {
+	secret []byte
+	Now    func() time.Time
+}
+
+func NewTOTP(secret []byte) *TOTP {
+	return &TOTP{
+		secret: secret,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// GenerateTOTPSecret creates a random secret to share with the authenticator app
+func GenerateTOTPSecret() ([]byte, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// EncodedSecret is the secret in base32 as typed in authenticator apps
+func (t TOTP) EncodedSecret() string {
+	return totpSecretEncoding.EncodeToString(t.secret)
+}
+
+// ProvisioningURI is the otpauth uri authenticator apps scan as a qr code
+func (t TOTP) ProvisioningURI(issuer, account string) string {
+	params := url.Values{}
+	params.Set("secret", t.EncodedSecret())
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", strconv.Itoa(totpDigits))
+	params.Set("period", strconv.Itoa(totpPeriod))
+	return fmt.Sprintf("otpauth://totp/%s:%s?%s", url.PathEscape(issuer), url.PathEscape(account), params.Encode())
+}
+
+// Step is the time step codes are currently generated for
+func (t TOTP) Step() int64 {
+	return t.Now().Unix() / totpPeriod
+}
+
+// Code generates the code of a time step
+func (t TOTP) Code(step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, t.secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// Validate checks the code against the current time step and the steps next
+// to it, returning the step it matched. Codes of steps up to lastUsedStep are
+// rejected so a code can't be replayed once used.
+func (t TOTP) Validate(code string, lastUsedStep int64) (int64, error) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return 0, ErrInvalidTOTP
+	}
+	current := t.Step()
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(t.Code(step)), []byte(code)) == 1 {
+			return step, nil
+		}
+	}
+	return 0, ErrInvalidTOTP
+}
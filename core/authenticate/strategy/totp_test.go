@@ -0,0 +1,105 @@
+package strategy
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the sha1 secret of the RFC 6238 test vectors
+var rfc6238Secret = []byte("12345678901234567890")
+
+func TestTOTP_Code(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		totp := NewTOTP(rfc6238Secret)
+		totp.Now = func() time.Time {
+			return time.Unix(tt.unix, 0)
+		}
+		assert.Equal(t, tt.want, totp.Code(totp.Step()))
+	}
+}
+
+func TestTOTP_Validate(t *testing.T) {
+	totp := NewTOTP(rfc6238Secret)
+	totp.Now = func() time.Time {
+		return time.Unix(1111111109, 0)
+	}
+	current := totp.Step()
+
+	tests := []struct {
+		name         string
+		code         string
+		lastUsedStep int64
+		want         int64
+		err          error
+	}{
+		{
+			name: "should accept the current code",
+			code: "081804",
+			want: current,
+		},
+		{
+			name: "should accept codes with spaces",
+			code: "081 804",
+			want: current,
+		},
+		{
+			name: "should accept the code of the previous step",
+			code: totp.Code(current - 1),
+			want: current - 1,
+		},
+		{
+			name: "should reject codes outside the skew",
+			code: totp.Code(current - 2),
+			err:  ErrInvalidTOTP,
+		},
+		{
+			name:         "should reject a code already used",
+			code:         "081804",
+			lastUsedStep: current,
+			err:          ErrInvalidTOTP,
+		},
+		{
+			name: "should reject malformed codes",
+			code: "8180",
+			err:  ErrInvalidTOTP,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := totp.Validate(tt.code, tt.lastUsedStep)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTOTP_ProvisioningURI(t *testing.T) {
+	totp := NewTOTP(rfc6238Secret)
+	uri, err := url.Parse(totp.ProvisioningURI("Frontier", "alice@acme.org"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Frontier:alice@acme.org", uri.Path)
+	assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", uri.Query().Get("secret"))
+	assert.Equal(t, "Frontier", uri.Query().Get("issuer"))
+	assert.Equal(t, "6", uri.Query().Get("digits"))
+}
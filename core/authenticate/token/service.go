@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/raystack/frontier/pkg/utils"
 
+	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 )
@@ -15,6 +17,7 @@ import (
 var (
 	ErrMissingRSADisableToken = errors.New("rsa key missing in config, generate and pass file path")
 	ErrInvalidToken           = errors.New("failed to verify a valid token")
+	ErrRevokedToken           = errors.New("token has been revoked")
 )
 
 const (
@@ -23,18 +26,90 @@ const (
 	OrgIDsClaimKey      = "org_ids"
 	SubTypeClaimsKey    = "sub_type"
 	SubEmailClaimsKey   = "email"
+	// ImpersonationClaimsKey holds the impersonation session a token is
+	// bound to, the token is only valid as long as the session is
+	ImpersonationClaimsKey = "impersonation_session"
+	// AuthTimeClaimsKey holds the unix time the user logged in at
+	AuthTimeClaimsKey = "auth_time"
+	// ClientIDClaimsKey and ScopeClaimsKey are added to access tokens issued
+	// to oauth2 clients as per RFC 9068, the scope is space delimited
+	ClientIDClaimsKey = "client_id"
+	ScopeClaimsKey    = "scope"
 )
 
+// DenylistRepository keeps the ids of revoked tokens until they expire
+type DenylistRepository interface {
+	Add(ctx context.Context, jti string, expiresAt time.Time) error
+	// List returns the ids of revoked tokens that haven't expired yet along
+	// with their expiry
+	List(ctx context.Context) (map[string]time.Time, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+// revokedTokens keeps the denylist in memory so parsing a token doesn't query
+// the database. Revocations are permanent, entries are only dropped once the
+// token has expired anyway.
+type revokedTokens struct {
+	mu   sync.RWMutex
+	jtis map[string]time.Time
+}
+
+func (r *revokedTokens) contains(jti string, now time.Time) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	expiresAt, ok := r.jtis[jti]
+	return ok && expiresAt.After(now)
+}
+
+func (r *revokedTokens) add(jti string, expiresAt time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.jtis[jti] = expiresAt
+}
+
+// merge adds the tokens revoked by any instance and forgets the expired ones,
+// local entries are kept as the listing may predate their revocation
+func (r *revokedTokens) merge(jtis map[string]time.Time, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for jti, expiresAt := range r.jtis {
+		if expiresAt.After(now) {
+			if _, ok := jtis[jti]; !ok {
+				jtis[jti] = expiresAt
+			}
+		}
+	}
+	for jti, expiresAt := range jtis {
+		if !expiresAt.After(now) {
+			delete(jtis, jti)
+		}
+	}
+	r.jtis = jtis
+}
+
 type Service struct {
 	keySet       jwk.Set
 	publicKeySet jwk.Set
 	issuer       string
 	validity     time.Duration
+	denylist     DenylistRepository
+	revoked      *revokedTokens
+}
+
+type Option func(*Service)
+
+// WithDenylist rejects tokens revoked before they expire while parsing, the
+// denylist is cached in memory and kept in sync with RefreshRevoked
+func WithDenylist(denylist DenylistRepository) Option {
+	return func(s *Service) {
+		s.denylist = denylist
+		s.revoked = &revokedTokens{jtis: map[string]time.Time{}}
+	}
 }
 
 // NewService creates a new token service
 // generate keys used for rsa via frontier cli "frontier server keygen"
-func NewService(keySet jwk.Set, issuer string, validity time.Duration) Service {
+func NewService(keySet jwk.Set, issuer string, validity time.Duration, opts ...Option) Service {
 	publicKeySet := jwk.NewSet()
 	if keySet != nil {
 		pub, err := utils.GetPublicKeySet(context.Background(), keySet)
@@ -44,12 +119,16 @@ func NewService(keySet jwk.Set, issuer string, validity time.Duration) Service {
 		publicKeySet = pub
 	}
 
-	return Service{
+	s := Service{
 		keySet:       keySet,
 		issuer:       issuer,
 		publicKeySet: publicKeySet,
 		validity:     validity,
 	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
 }
 
 // GetPublicKeySet returns the public keys to verify the access token
@@ -74,6 +153,34 @@ func (s Service) Build(subjectID string, metadata map[string]string) ([]byte, er
 	return utils.BuildToken(rsaKey, s.issuer, subjectID, s.validity, metadata)
 }
 
+// BuildIDToken creates an openid connect id token for the audience. It isn't
+// marked as generated by frontier so it can't be used as an access token
+func (s Service) BuildIDToken(subjectID, audience string, claims map[string]any) ([]byte, error) {
+	if s.keySet == nil {
+		return nil, ErrMissingRSADisableToken
+	}
+	rsaKey, ok := s.keySet.Key(0)
+	if !ok {
+		return nil, errors.New("missing rsa key to generate token")
+	}
+
+	now := time.Now().UTC()
+	body := jwt.NewBuilder().
+		Issuer(s.issuer).
+		Subject(subjectID).
+		Audience([]string{audience}).
+		IssuedAt(now).
+		Expiration(now.Add(s.validity))
+	for claimKey, claimVal := range claims {
+		body = body.Claim(claimKey, claimVal)
+	}
+	tok, err := body.Build()
+	if err != nil {
+		return nil, err
+	}
+	return jwt.Sign(tok, jwt.WithKey(jwa.RS256, rsaKey))
+}
+
 func (s Service) Parse(ctx context.Context, userToken []byte) (string, map[string]any, error) {
 	if s.keySet == nil {
 		return "", nil, ErrMissingRSADisableToken
@@ -83,9 +190,53 @@ func (s Service) Parse(ctx context.Context, userToken []byte) (string, map[strin
 	if err != nil {
 		return "", nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidToken)
 	}
+	// every token issued by frontier has an id, tokens without one couldn't
+	// be revoked
+	if verifiedToken.JwtID() == "" {
+		return "", nil, fmt.Errorf("missing token id: %w", ErrInvalidToken)
+	}
+	if s.revoked != nil && s.revoked.contains(verifiedToken.JwtID(), time.Now()) {
+		return "", nil, fmt.Errorf("%w: %w", ErrRevokedToken, ErrInvalidToken)
+	}
 	tokenClaims, err := verifiedToken.AsMap(ctx)
 	if err != nil {
 		return "", nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidToken)
 	}
 	return verifiedToken.Subject(), tokenClaims, nil
 }
+
+// Revoke denies the token with the given id until it expires. The token is
+// rejected right away by this instance, other instances reject it once they
+// refresh their denylist, see TokenConfig.RevocationSyncInterval.
+func (s Service) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	if s.denylist == nil {
+		return errors.New("token denylist is not configured")
+	}
+	if err := s.denylist.Add(ctx, jti, expiresAt); err != nil {
+		return err
+	}
+	s.revoked.add(jti, expiresAt)
+	return nil
+}
+
+// RefreshRevoked loads the tokens revoked by every instance into the in
+// memory denylist
+func (s Service) RefreshRevoked(ctx context.Context) error {
+	if s.denylist == nil {
+		return nil
+	}
+	jtis, err := s.denylist.List(ctx)
+	if err != nil {
+		return err
+	}
+	s.revoked.merge(jtis, time.Now())
+	return nil
+}
+
+// PurgeRevoked forgets revoked tokens that have expired anyway
+func (s Service) PurgeRevoked(ctx context.Context) error {
+	if s.denylist == nil {
+		return nil
+	}
+	return s.denylist.DeleteExpired(ctx)
+}
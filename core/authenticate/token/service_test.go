@@ -0,0 +1,74 @@
+package token
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/frontier/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokedTokens(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should reject revoked tokens until they expire", func(t *testing.T) {
+		r := &revokedTokens{jtis: map[string]time.Time{}}
+		r.add("jti", now.Add(time.Minute))
+
+		assert.True(t, r.contains("jti", now))
+		assert.False(t, r.contains("jti", now.Add(time.Minute)))
+		assert.False(t, r.contains("other", now))
+	})
+
+	t.Run("should keep local revocations missing from the listing", func(t *testing.T) {
+		r := &revokedTokens{jtis: map[string]time.Time{}}
+		r.add("local", now.Add(time.Minute))
+		r.add("expired", now.Add(-time.Minute))
+
+		r.merge(map[string]time.Time{
+			"remote":         now.Add(time.Minute),
+			"remote-expired": now,
+		}, now)
+		assert.Equal(t, map[string]time.Time{
+			"local":  now.Add(time.Minute),
+			"remote": now.Add(time.Minute),
+		}, r.jtis)
+	})
+}
+
+func TestService_Parse(t *testing.T) {
+	keySet, err := utils.CreateJWKs(1)
+	assert.NoError(t, err)
+	s := NewService(keySet, "frontier", time.Minute)
+
+	t.Run("should parse tokens issued by the service", func(t *testing.T) {
+		accessToken, err := s.Build("user-id", map[string]string{})
+		assert.NoError(t, err)
+
+		sub, _, err := s.Parse(context.Background(), accessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, "user-id", sub)
+	})
+
+	t.Run("should reject tokens without an id", func(t *testing.T) {
+		idToken, err := s.BuildIDToken("user-id", "client-id", nil)
+		assert.NoError(t, err)
+
+		_, _, err = s.Parse(context.Background(), idToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("should reject revoked tokens", func(t *testing.T) {
+		accessToken, err := s.Build("user-id", map[string]string{})
+		assert.NoError(t, err)
+		parsed, err := jwt.ParseInsecure(accessToken)
+		assert.NoError(t, err)
+		revokingService := s
+		revokingService.revoked = &revokedTokens{jtis: map[string]time.Time{parsed.JwtID(): time.Now().Add(time.Minute)}}
+
+		_, _, err = revokingService.Parse(context.Background(), accessToken)
+		assert.ErrorIs(t, err, ErrRevokedToken)
+	})
+}
@@ -0,0 +1,7 @@
+package explain
+
+import "errors"
+
+var (
+	ErrInvalidDetail = errors.New("subject, object and permission are required")
+)
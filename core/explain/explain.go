@@ -0,0 +1,43 @@
+package explain
+
+import "github.com/raystack/frontier/core/relation"
+
+// Explanation tells why a subject has or lacks a permission on an object
+type Explanation struct {
+	Allowed bool
+	// Grants are the policies granting the permission
+	Grants []Grant
+	// Tree is how the authz engine resolved the check, it only keeps the
+	// steps granting the permission if it is allowed
+	Tree Step
+}
+
+// Grant is a policy granting the permission, the subject is a member of the
+// groups of Via which hold Role on Resource through the policy. Via is empty
+// if the role is bound to the subject itself.
+type Grant struct {
+	PolicyID string
+	RoleID   string
+	RoleName string
+	Resource relation.Object
+	Via      []relation.Object
+}
+
+// Step checked if the subject has the relation or permission named Name on
+// the object
+type Step struct {
+	Object       relation.Object
+	Name         string
+	IsPermission bool
+	Subject      relation.Subject
+	Result       relation.TraceResult
+	// Cached steps were resolved from the cache of the authz engine, the
+	// steps they depend on are unknown
+	Cached bool
+	// PolicyID is set on the steps of role bindings, it is the policy which
+	// created the binding
+	PolicyID string
+	// RoleName is set on the steps of role bindings and roles
+	RoleName string
+	Children []Step
+}
@@ -0,0 +1,203 @@
+package explain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+type RelationService interface {
+	CheckPermissionWithTrace(ctx context.Context, rel relation.Relation) (bool, relation.CheckTrace, error)
+}
+
+type PolicyService interface {
+	Get(ctx context.Context, id string) (policy.Policy, error)
+}
+
+type RoleService interface {
+	Get(ctx context.Context, id string) (role.Role, error)
+}
+
+type Service struct {
+	relationService RelationService
+	policyService   PolicyService
+	roleService     RoleService
+}
+
+func NewService(relationService RelationService, policyService PolicyService, roleService RoleService) *Service {
+	return &Service{
+		relationService: relationService,
+		policyService:   policyService,
+		roleService:     roleService,
+	}
+}
+
+// Explain checks the permission of the subject on the object with tracing
+// and names the policies and roles the check went through
+func (s Service) Explain(ctx context.Context, subject relation.Subject, object relation.Object, permission string) (Explanation, error) {
+	if subject.ID == "" || subject.Namespace == "" || object.ID == "" || object.Namespace == "" || permission == "" {
+		return Explanation{}, ErrInvalidDetail
+	}
+	allowed, trace, err := s.relationService.CheckPermissionWithTrace(ctx, relation.Relation{
+		Subject:      subject,
+		Object:       object,
+		RelationName: permission,
+	})
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	r := resolver{
+		service:  s,
+		policies: map[string]policy.Policy{},
+		roles:    map[string]role.Role{},
+	}
+	tree, err := r.step(ctx, trace, allowed)
+	if err != nil {
+		return Explanation{}, err
+	}
+	explanation := Explanation{
+		Allowed: allowed,
+		Tree:    tree,
+	}
+	if allowed {
+		explanation.Grants = r.grants(tree)
+	}
+	return explanation, nil
+}
+
+// resolver builds the steps of a trace, it caches the policies and roles as
+// the same ones are often visited through several paths
+type resolver struct {
+	service  Service
+	policies map[string]policy.Policy
+	roles    map[string]role.Role
+}
+
+// step converts the trace, when onlyGranting is set the steps which didn't
+// grant the permission are dropped
+func (r resolver) step(ctx context.Context, trace relation.CheckTrace, onlyGranting bool) (Step, error) {
+	step := Step{
+		Object:       trace.Object,
+		Name:         trace.RelationName,
+		IsPermission: trace.IsPermission,
+		Subject:      trace.Subject,
+		Result:       trace.Result,
+		Cached:       trace.Cached,
+	}
+
+	switch trace.Object.Namespace {
+	case schema.RoleBindingNamespace:
+		step.PolicyID = trace.Object.ID
+		pol, err := r.policy(ctx, trace.Object.ID)
+		if err != nil {
+			return Step{}, err
+		}
+		if pol.RoleID != "" {
+			if step.RoleName, err = r.roleName(ctx, pol.RoleID); err != nil {
+				return Step{}, err
+			}
+		}
+	case schema.RoleNamespace:
+		var err error
+		if step.RoleName, err = r.roleName(ctx, trace.Object.ID); err != nil {
+			return Step{}, err
+		}
+	}
+
+	for _, child := range trace.Children {
+		if onlyGranting && !granting(child.Result) {
+			continue
+		}
+		childStep, err := r.step(ctx, child, onlyGranting)
+		if err != nil {
+			return Step{}, err
+		}
+		step.Children = append(step.Children, childStep)
+	}
+	return step, nil
+}
+
+// grants lists the policies of the granting role bindings of the tree
+func (r resolver) grants(tree Step) []Grant {
+	var grants []Grant
+	var walk func(step Step)
+	walk = func(step Step) {
+		if step.Object.Namespace == schema.RoleBindingNamespace && step.IsPermission && granting(step.Result) {
+			pol := r.policies[step.PolicyID]
+			grant := Grant{
+				PolicyID: step.PolicyID,
+				RoleID:   pol.RoleID,
+				RoleName: r.roles[pol.RoleID].Name,
+				Resource: relation.Object{
+					ID:        pol.ResourceID,
+					Namespace: pol.ResourceType,
+				},
+			}
+			for _, child := range step.Children {
+				if child.Name == schema.RoleBearerRelationName {
+					grant.Via = groups(child)
+				}
+			}
+			grants = append(grants, grant)
+			return
+		}
+		for _, child := range step.Children {
+			walk(child)
+		}
+	}
+	walk(tree)
+	return grants
+}
+
+func (r resolver) policy(ctx context.Context, id string) (policy.Policy, error) {
+	if pol, ok := r.policies[id]; ok {
+		return pol, nil
+	}
+	pol, err := r.service.policyService.Get(ctx, id)
+	if err != nil {
+		// relations of deleted policies may linger in the authz engine
+		if !errors.Is(err, policy.ErrNotExist) {
+			return policy.Policy{}, err
+		}
+		pol = policy.Policy{ID: id}
+	}
+	r.policies[id] = pol
+	return pol, nil
+}
+
+func (r resolver) roleName(ctx context.Context, id string) (string, error) {
+	if rl, ok := r.roles[id]; ok {
+		return rl.Name, nil
+	}
+	rl, err := r.service.roleService.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, role.ErrNotExist) {
+			return "", err
+		}
+		rl = role.Role{ID: id}
+	}
+	r.roles[id] = rl
+	return rl.Name, nil
+}
+
+// groups returns the groups the subject is a granting member of below the
+// step
+func groups(step Step) []relation.Object {
+	var found []relation.Object
+	for _, child := range step.Children {
+		if child.Object.Namespace == schema.GroupNamespace && child.Name == schema.MemberRelationName {
+			found = append(found, child.Object)
+		}
+		found = append(found, groups(child)...)
+	}
+	return found
+}
+
+func granting(result relation.TraceResult) bool {
+	return result == relation.TraceResultAllowed || result == relation.TraceResultConditional
+}
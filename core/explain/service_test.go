@@ -0,0 +1,120 @@
+package explain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raystack/frontier/core/explain"
+	"github.com/raystack/frontier/core/explain/mocks"
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	user    = relation.Subject{ID: "user-id", Namespace: schema.UserPrincipal}
+	project = relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}
+)
+
+// groupGrantTrace is the trace of a user deleting a project as a member of
+// a group bound to the project owner role, the role binding of another
+// policy of the user didn't grant the permission
+func groupGrantTrace() relation.CheckTrace {
+	return relation.CheckTrace{
+		Object: project, Subject: user, RelationName: "delete", IsPermission: true, Result: relation.TraceResultAllowed,
+		Children: []relation.CheckTrace{
+			{
+				Object: relation.Object{ID: "policy-id", Namespace: schema.RoleBindingNamespace}, Subject: user,
+				RelationName: "app_project_delete", IsPermission: true, Result: relation.TraceResultAllowed,
+				Children: []relation.CheckTrace{
+					{
+						Object: relation.Object{ID: "policy-id", Namespace: schema.RoleBindingNamespace}, Subject: user,
+						RelationName: schema.RoleBearerRelationName, Result: relation.TraceResultAllowed,
+						Children: []relation.CheckTrace{
+							{
+								Object: relation.Object{ID: "group-id", Namespace: schema.GroupNamespace}, Subject: user,
+								RelationName: schema.MemberRelationName, Result: relation.TraceResultAllowed,
+							},
+						},
+					},
+					{
+						Object: relation.Object{ID: "role-id", Namespace: schema.RoleNamespace}, Subject: user,
+						RelationName: "app_project_delete", Result: relation.TraceResultAllowed,
+					},
+				},
+			},
+			{
+				Object: relation.Object{ID: "viewer-policy-id", Namespace: schema.RoleBindingNamespace}, Subject: user,
+				RelationName: "app_project_delete", IsPermission: true, Result: relation.TraceResultDenied,
+			},
+		},
+	}
+}
+
+func TestService_Explain(t *testing.T) {
+	t.Run("should name the policy, role and groups granting the permission", func(t *testing.T) {
+		relations := mocks.NewRelationService(t)
+		policies := mocks.NewPolicyService(t)
+		roles := mocks.NewRoleService(t)
+		relations.EXPECT().CheckPermissionWithTrace(mock.Anything, relation.Relation{
+			Subject:      user,
+			Object:       project,
+			RelationName: "delete",
+		}).Return(true, groupGrantTrace(), nil)
+		policies.EXPECT().Get(mock.Anything, "policy-id").Return(policy.Policy{
+			ID:           "policy-id",
+			RoleID:       "role-id",
+			ResourceID:   "project-id",
+			ResourceType: schema.ProjectNamespace,
+		}, nil).Once()
+		roles.EXPECT().Get(mock.Anything, "role-id").Return(role.Role{ID: "role-id", Name: "app_project_owner"}, nil).Once()
+
+		got, err := explain.NewService(relations, policies, roles).Explain(context.Background(), user, project, "delete")
+		assert.NoError(t, err)
+		assert.True(t, got.Allowed)
+		assert.Equal(t, []explain.Grant{{
+			PolicyID: "policy-id",
+			RoleID:   "role-id",
+			RoleName: "app_project_owner",
+			Resource: project,
+			Via:      []relation.Object{{ID: "group-id", Namespace: schema.GroupNamespace}},
+		}}, got.Grants)
+		// the role binding which didn't grant the permission is dropped
+		assert.Len(t, got.Tree.Children, 1)
+		assert.Equal(t, "app_project_owner", got.Tree.Children[0].RoleName)
+		assert.Equal(t, "policy-id", got.Tree.Children[0].PolicyID)
+	})
+
+	t.Run("should keep the whole tree of denied checks", func(t *testing.T) {
+		relations := mocks.NewRelationService(t)
+		policies := mocks.NewPolicyService(t)
+		roles := mocks.NewRoleService(t)
+		trace := relation.CheckTrace{
+			Object: project, Subject: user, RelationName: "delete", IsPermission: true, Result: relation.TraceResultDenied,
+			Children: []relation.CheckTrace{
+				{
+					Object: relation.Object{ID: "viewer-policy-id", Namespace: schema.RoleBindingNamespace}, Subject: user,
+					RelationName: "app_project_delete", IsPermission: true, Result: relation.TraceResultDenied,
+				},
+			},
+		}
+		relations.EXPECT().CheckPermissionWithTrace(mock.Anything, mock.Anything).Return(false, trace, nil)
+		policies.EXPECT().Get(mock.Anything, "viewer-policy-id").Return(policy.Policy{}, policy.ErrNotExist)
+
+		got, err := explain.NewService(relations, policies, roles).Explain(context.Background(), user, project, "delete")
+		assert.NoError(t, err)
+		assert.False(t, got.Allowed)
+		assert.Empty(t, got.Grants)
+		assert.Len(t, got.Tree.Children, 1)
+		assert.Equal(t, relation.TraceResultDenied, got.Tree.Children[0].Result)
+	})
+
+	t.Run("should require the permission", func(t *testing.T) {
+		_, err := explain.NewService(mocks.NewRelationService(t), mocks.NewPolicyService(t), mocks.NewRoleService(t)).
+			Explain(context.Background(), user, project, "")
+		assert.ErrorIs(t, err, explain.ErrInvalidDetail)
+	})
+}
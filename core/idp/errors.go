@@ -0,0 +1,12 @@
+package idp
+
+import "errors"
+
+var (
+	ErrNotExist      = errors.New("identity provider doesn't exist")
+	ErrConflict      = errors.New("organization already has an identity provider")
+	ErrInvalidDetail = errors.New("invalid identity provider details")
+	// ErrAmbiguousDomain is returned when organizations verified the same
+	// domain and registered an identity provider, logins can't be routed
+	ErrAmbiguousDomain = errors.New("email domain is verified by more than one organization with an identity provider")
+)
@@ -0,0 +1,29 @@
+package idp
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, provider Provider) (Provider, error)
+	GetByOrgID(ctx context.Context, orgID string) (Provider, error)
+	List(ctx context.Context) ([]Provider, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// Provider is the OIDC identity provider registered by an organization, like
+// its Okta or Azure AD tenant. Emails of the verified domains of the
+// organization log in through it.
+type Provider struct {
+	ID    string
+	OrgID string
+	// IssuerURL is where the provider configuration is discovered from
+	IssuerURL string
+	ClientID  string
+	// ClientSecret is encrypted at rest
+	ClientSecret string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
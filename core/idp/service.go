@@ -0,0 +1,176 @@
+package idp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/domain"
+	"github.com/raystack/frontier/core/preference"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/utils"
+)
+
+// DomainRepository lists the domains organizations verified, the repository
+// is used over the domain service as the authenticate service depends on
+// this one
+type DomainRepository interface {
+	List(ctx context.Context, flt domain.Filter) ([]domain.Domain, error)
+}
+
+type PreferenceService interface {
+	List(ctx context.Context, filter preference.Filter) ([]preference.Preference, error)
+}
+
+type Service struct {
+	repository       Repository
+	domainRepository DomainRepository
+	prefService      PreferenceService
+}
+
+func NewService(repository Repository, domainRepository DomainRepository, prefService PreferenceService) *Service {
+	return &Service{
+		repository:       repository,
+		domainRepository: domainRepository,
+		prefService:      prefService,
+	}
+}
+
+// Create registers the identity provider of an organization
+func (s Service) Create(ctx context.Context, provider Provider) (Provider, error) {
+	if provider.OrgID == "" || provider.ClientID == "" || provider.ClientSecret == "" {
+		return Provider{}, fmt.Errorf("%w: organization, client id and client secret are required", ErrInvalidDetail)
+	}
+	issuer, err := url.Parse(provider.IssuerURL)
+	if err != nil || (issuer.Scheme != "https" && issuer.Scheme != "http") || issuer.Host == "" {
+		return Provider{}, fmt.Errorf("%w: issuer url must be an http(s) url", ErrInvalidDetail)
+	}
+	return s.repository.Create(ctx, provider)
+}
+
+// GetByOrgID returns the provider registered by an organization
+func (s Service) GetByOrgID(ctx context.Context, orgID string) (Provider, error) {
+	return s.repository.GetByOrgID(ctx, orgID)
+}
+
+func (s Service) List(ctx context.Context) ([]Provider, error) {
+	return s.repository.List(ctx)
+}
+
+func (s Service) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+// Route returns the identity provider of the organization which verified the
+// domain of the email
+func (s Service) Route(ctx context.Context, email string) (authenticate.SSOProvider, error) {
+	domains, err := s.verifiedDomains(ctx, email)
+	if err != nil {
+		return authenticate.SSOProvider{}, err
+	}
+
+	var routed []Provider
+	for _, d := range domains {
+		provider, err := s.repository.GetByOrgID(ctx, d.OrgID)
+		if err != nil {
+			if errors.Is(err, ErrNotExist) {
+				continue
+			}
+			return authenticate.SSOProvider{}, err
+		}
+		routed = append(routed, provider)
+	}
+	switch len(routed) {
+	case 0:
+		return authenticate.SSOProvider{}, authenticate.ErrSSONotConfigured
+	case 1:
+		return s.ssoProvider(ctx, routed[0])
+	default:
+		return authenticate.SSOProvider{}, ErrAmbiguousDomain
+	}
+}
+
+// Get returns the identity provider of an organization
+func (s Service) Get(ctx context.Context, orgID string) (authenticate.SSOProvider, error) {
+	provider, err := s.repository.GetByOrgID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, ErrNotExist) {
+			return authenticate.SSOProvider{}, authenticate.ErrSSONotConfigured
+		}
+		return authenticate.SSOProvider{}, err
+	}
+	return s.ssoProvider(ctx, provider)
+}
+
+// Required tells if an organization which verified the domain of the email
+// only lets its members log in with its identity provider
+func (s Service) Required(ctx context.Context, email string) (bool, error) {
+	domains, err := s.verifiedDomains(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	for _, d := range domains {
+		prefs, err := s.prefService.List(ctx, preference.Filter{
+			ResourceID:   d.OrgID,
+			ResourceType: schema.OrganizationNamespace,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, pref := range prefs {
+			if pref.Name == preference.OrganizationSSOOnly && pref.Value == "true" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Organizations returns the organizations which verified the domain of the
+// email
+func (s Service) Organizations(ctx context.Context, email string) ([]string, error) {
+	domains, err := s.verifiedDomains(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	var orgIDs []string
+	for _, d := range domains {
+		orgIDs = append(orgIDs, d.OrgID)
+	}
+	return orgIDs, nil
+}
+
+func (s Service) verifiedDomains(ctx context.Context, email string) ([]domain.Domain, error) {
+	emailDomain := utils.ExtractDomainFromEmail(strings.ToLower(strings.TrimSpace(email)))
+	if emailDomain == "" {
+		return nil, nil
+	}
+	return s.domainRepository.List(ctx, domain.Filter{
+		Name:  emailDomain,
+		State: domain.Verified,
+	})
+}
+
+func (s Service) ssoProvider(ctx context.Context, provider Provider) (authenticate.SSOProvider, error) {
+	domains, err := s.domainRepository.List(ctx, domain.Filter{
+		OrgID: provider.OrgID,
+		State: domain.Verified,
+	})
+	if err != nil {
+		return authenticate.SSOProvider{}, err
+	}
+	names := make([]string, 0, len(domains))
+	for _, d := range domains {
+		names = append(names, d.Name)
+	}
+	return authenticate.SSOProvider{
+		OrgID:        provider.OrgID,
+		IssuerURL:    provider.IssuerURL,
+		ClientID:     provider.ClientID,
+		ClientSecret: provider.ClientSecret,
+		Domains:      names,
+	}, nil
+}
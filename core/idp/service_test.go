@@ -0,0 +1,202 @@
+package idp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/domain"
+	"github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/core/idp/mocks"
+	"github.com/raystack/frontier/core/preference"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type serviceMocks struct {
+	repository *mocks.Repository
+	domains    *mocks.DomainRepository
+	prefs      *mocks.PreferenceService
+}
+
+func newService(t *testing.T) (*idp.Service, serviceMocks) {
+	m := serviceMocks{
+		repository: mocks.NewRepository(t),
+		domains:    mocks.NewDomainRepository(t),
+		prefs:      mocks.NewPreferenceService(t),
+	}
+	return idp.NewService(m.repository, m.domains, m.prefs), m
+}
+
+func TestService_Create(t *testing.T) {
+	provider := idp.Provider{
+		OrgID:        uuid.NewString(),
+		IssuerURL:    "https://accounts.acme.org",
+		ClientID:     "frontier",
+		ClientSecret: "secret",
+	}
+
+	t.Run("should register the provider", func(t *testing.T) {
+		s, m := newService(t)
+		m.repository.EXPECT().Create(mock.Anything, provider).Return(provider, nil)
+
+		got, err := s.Create(context.Background(), provider)
+		assert.NoError(t, err)
+		assert.Equal(t, provider, got)
+	})
+
+	t.Run("should reject issuers which are not urls", func(t *testing.T) {
+		s, _ := newService(t)
+		invalid := provider
+		invalid.IssuerURL = "accounts.acme.org"
+
+		_, err := s.Create(context.Background(), invalid)
+		assert.ErrorIs(t, err, idp.ErrInvalidDetail)
+	})
+}
+
+func TestService_Route(t *testing.T) {
+	orgID := uuid.NewString()
+	otherOrgID := uuid.NewString()
+	provider := idp.Provider{
+		ID:           uuid.NewString(),
+		OrgID:        orgID,
+		IssuerURL:    "https://accounts.acme.org",
+		ClientID:     "frontier",
+		ClientSecret: "secret",
+	}
+	verified := domain.Filter{Name: "acme.org", State: domain.Verified}
+
+	tests := []struct {
+		name    string
+		email   string
+		setup   func(m serviceMocks)
+		want    authenticate.SSOProvider
+		wantErr error
+	}{
+		{
+			name:  "should route to the provider of the organization which verified the domain",
+			email: "John.Doe@Acme.org",
+			setup: func(m serviceMocks) {
+				m.domains.EXPECT().List(mock.Anything, verified).Return([]domain.Domain{{OrgID: orgID, Name: "acme.org"}}, nil)
+				m.repository.EXPECT().GetByOrgID(mock.Anything, orgID).Return(provider, nil)
+				m.domains.EXPECT().List(mock.Anything, domain.Filter{OrgID: orgID, State: domain.Verified}).
+					Return([]domain.Domain{{OrgID: orgID, Name: "acme.org"}, {OrgID: orgID, Name: "acme.io"}}, nil)
+			},
+			want: authenticate.SSOProvider{
+				OrgID:        orgID,
+				IssuerURL:    provider.IssuerURL,
+				ClientID:     provider.ClientID,
+				ClientSecret: provider.ClientSecret,
+				Domains:      []string{"acme.org", "acme.io"},
+			},
+		},
+		{
+			name:  "should fail if the organization has no provider",
+			email: "john.doe@acme.org",
+			setup: func(m serviceMocks) {
+				m.domains.EXPECT().List(mock.Anything, verified).Return([]domain.Domain{{OrgID: orgID, Name: "acme.org"}}, nil)
+				m.repository.EXPECT().GetByOrgID(mock.Anything, orgID).Return(idp.Provider{}, idp.ErrNotExist)
+			},
+			wantErr: authenticate.ErrSSONotConfigured,
+		},
+		{
+			name:  "should fail if no organization verified the domain",
+			email: "john.doe@acme.org",
+			setup: func(m serviceMocks) {
+				m.domains.EXPECT().List(mock.Anything, verified).Return([]domain.Domain{}, nil)
+			},
+			wantErr: authenticate.ErrSSONotConfigured,
+		},
+		{
+			name:  "should not guess between organizations which verified the same domain",
+			email: "john.doe@acme.org",
+			setup: func(m serviceMocks) {
+				m.domains.EXPECT().List(mock.Anything, verified).Return([]domain.Domain{
+					{OrgID: orgID, Name: "acme.org"},
+					{OrgID: otherOrgID, Name: "acme.org"},
+				}, nil)
+				m.repository.EXPECT().GetByOrgID(mock.Anything, orgID).Return(provider, nil)
+				other := provider
+				other.OrgID = otherOrgID
+				m.repository.EXPECT().GetByOrgID(mock.Anything, otherOrgID).Return(other, nil)
+			},
+			wantErr: idp.ErrAmbiguousDomain,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			got, err := s.Route(context.Background(), tt.email)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Required(t *testing.T) {
+	orgID := uuid.NewString()
+	verified := domain.Filter{Name: "acme.org", State: domain.Verified}
+	orgPrefs := preference.Filter{ResourceID: orgID, ResourceType: schema.OrganizationNamespace}
+
+	t.Run("should require sso if the organization enforces it", func(t *testing.T) {
+		s, m := newService(t)
+		m.domains.EXPECT().List(mock.Anything, verified).Return([]domain.Domain{{OrgID: orgID, Name: "acme.org"}}, nil)
+		m.prefs.EXPECT().List(mock.Anything, orgPrefs).Return([]preference.Preference{
+			{Name: preference.OrganizationSSOOnly, Value: "true"},
+		}, nil)
+
+		got, err := s.Required(context.Background(), "john.doe@acme.org")
+		assert.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("should not require sso by default", func(t *testing.T) {
+		s, m := newService(t)
+		m.domains.EXPECT().List(mock.Anything, verified).Return([]domain.Domain{{OrgID: orgID, Name: "acme.org"}}, nil)
+		m.prefs.EXPECT().List(mock.Anything, orgPrefs).Return([]preference.Preference{}, nil)
+
+		got, err := s.Required(context.Background(), "john.doe@acme.org")
+		assert.NoError(t, err)
+		assert.False(t, got)
+	})
+
+	t.Run("should not require sso for emails without a domain", func(t *testing.T) {
+		s, _ := newService(t)
+
+		got, err := s.Required(context.Background(), "john.doe")
+		assert.NoError(t, err)
+		assert.False(t, got)
+	})
+}
+
+func TestService_Organizations(t *testing.T) {
+	orgID := uuid.NewString()
+
+	t.Run("should return the organizations which verified the domain", func(t *testing.T) {
+		s, m := newService(t)
+		m.domains.EXPECT().List(mock.Anything, domain.Filter{Name: "acme.org", State: domain.Verified}).
+			Return([]domain.Domain{{OrgID: orgID, Name: "acme.org"}}, nil)
+
+		got, err := s.Organizations(context.Background(), "John.Doe@Acme.org")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{orgID}, got)
+	})
+
+	t.Run("should return no organization for emails without a domain", func(t *testing.T) {
+		s, _ := newService(t)
+
+		got, err := s.Organizations(context.Background(), "john.doe")
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
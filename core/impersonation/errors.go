@@ -0,0 +1,13 @@
+package impersonation
+
+import "errors"
+
+var (
+	ErrUnauthenticated = errors.New("impersonation requires an authenticated superuser")
+	ErrForbidden       = errors.New("only superusers can impersonate users")
+	ErrChained         = errors.New("impersonated users can't impersonate other users")
+	ErrReasonRequired  = errors.New("reason of the impersonation is required")
+	ErrInvalidTarget   = errors.New("user can't be impersonated")
+	ErrDisabledByOrg   = errors.New("impersonation is disabled by an organization of the user")
+	ErrNotExist        = errors.New("impersonation session doesn't exist")
+)
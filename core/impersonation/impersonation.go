@@ -0,0 +1,19 @@
+package impersonation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Impersonation is a short-lived session of a superuser acting as another
+// user, e.g. to reproduce what a customer sees
+type Impersonation struct {
+	SessionID uuid.UUID
+	// UserID is the impersonated user
+	UserID string
+	// AccessToken authenticates requests as the user, it is only valid as
+	// long as the session is
+	AccessToken string
+	ExpiresAt   time.Time
+}
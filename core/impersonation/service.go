@@ -0,0 +1,226 @@
+package impersonation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/preference"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/metadata"
+)
+
+type UserService interface {
+	GetByID(ctx context.Context, id string) (user.User, error)
+	IsSudo(ctx context.Context, id string, permissionName string) (bool, error)
+}
+
+type ServiceUserService interface {
+	IsSudo(ctx context.Context, id string, permissionName string) (bool, error)
+}
+
+type OrgService interface {
+	ListByUser(ctx context.Context, principal authenticate.Principal, flt organization.Filter) ([]organization.Organization, error)
+}
+
+type PreferenceService interface {
+	List(ctx context.Context, flt preference.Filter) ([]preference.Preference, error)
+}
+
+type SessionService interface {
+	CreateWithValidity(ctx context.Context, userID string, validity time.Duration, metadata metadata.Metadata) (*frontiersession.Session, error)
+	Get(ctx context.Context, sessionID uuid.UUID) (*frontiersession.Session, error)
+	Delete(ctx context.Context, sessionID uuid.UUID) error
+}
+
+type TokenBuilder interface {
+	BuildToken(ctx context.Context, principal authenticate.Principal, metadata map[string]string) ([]byte, error)
+}
+
+type Service struct {
+	userService        UserService
+	serviceUserService ServiceUserService
+	orgService         OrgService
+	prefService        PreferenceService
+	sessionService     SessionService
+	tokenBuilder       TokenBuilder
+	config             authenticate.ImpersonationConfig
+}
+
+func NewService(userService UserService, serviceUserService ServiceUserService, orgService OrgService,
+	prefService PreferenceService, sessionService SessionService, tokenBuilder TokenBuilder,
+	config authenticate.ImpersonationConfig) *Service {
+	return &Service{
+		userService:        userService,
+		serviceUserService: serviceUserService,
+		orgService:         orgService,
+		prefService:        prefService,
+		sessionService:     sessionService,
+		tokenBuilder:       tokenBuilder,
+		config:             config,
+	}
+}
+
+// Start opens an impersonation session of the superuser as the user, the
+// returned access token authenticates requests as the user until the session
+// expires or is ended. It is logged in the audit trail of the platform and
+// of every organization of the user.
+func (s Service) Start(ctx context.Context, impersonator authenticate.Principal, userID, reason string) (Impersonation, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return Impersonation{}, ErrReasonRequired
+	}
+	if err := s.checkImpersonator(ctx, impersonator); err != nil {
+		return Impersonation{}, err
+	}
+
+	target, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return Impersonation{}, err
+	}
+	if target.ID == impersonator.ID || target.State == user.Disabled {
+		return Impersonation{}, ErrInvalidTarget
+	}
+	// superusers can't be impersonated to borrow their permissions
+	if isSudo, err := s.userService.IsSudo(ctx, target.ID, schema.PlatformSudoPermission); err != nil {
+		return Impersonation{}, err
+	} else if isSudo {
+		return Impersonation{}, ErrInvalidTarget
+	}
+
+	orgs, err := s.orgService.ListByUser(ctx, authenticate.Principal{
+		ID:   target.ID,
+		Type: schema.UserPrincipal,
+	}, organization.Filter{})
+	if err != nil {
+		return Impersonation{}, err
+	}
+	for _, org := range orgs {
+		disabled, err := s.disabledByOrg(ctx, org.ID)
+		if err != nil {
+			return Impersonation{}, err
+		}
+		if disabled {
+			return Impersonation{}, ErrDisabledByOrg
+		}
+	}
+
+	session, err := s.sessionService.CreateWithValidity(ctx, target.ID, s.config.Validity, metadata.Metadata{
+		frontiersession.MetadataAuthMethod:          frontiersession.AuthMethodImpersonation,
+		frontiersession.MetadataImpersonatedBy:      impersonator.ID,
+		frontiersession.MetadataImpersonatedByType:  impersonator.Type,
+		frontiersession.MetadataImpersonationReason: reason,
+	})
+	if err != nil {
+		return Impersonation{}, err
+	}
+	accessToken, err := s.tokenBuilder.BuildToken(ctx, authenticate.Principal{
+		ID:                     target.ID,
+		Type:                   schema.UserPrincipal,
+		User:                   &target,
+		ImpersonatedBy:         &impersonator,
+		ImpersonationSessionID: session.ID.String(),
+	}, map[string]string{})
+	if err != nil {
+		_ = s.sessionService.Delete(ctx, session.ID)
+		return Impersonation{}, err
+	}
+
+	attrs := map[string]string{
+		"reason":     reason,
+		"session_id": session.ID.String(),
+		"expires_at": session.ExpiresAt.Format(time.RFC3339),
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		LogWithAttrs(audit.UserImpersonatedEvent, audit.UserTarget(target.ID), attrs)
+	for _, org := range orgs {
+		_ = audit.GetAuditor(ctx, org.ID).
+			LogWithAttrs(audit.UserImpersonatedEvent, audit.UserTarget(target.ID), attrs)
+	}
+
+	return Impersonation{
+		SessionID:   session.ID,
+		UserID:      target.ID,
+		AccessToken: string(accessToken),
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+// End revokes an impersonation session before it expires, along with the
+// access tokens bound to it. Superusers can end any impersonation, the
+// impersonated principal can end its own.
+func (s Service) End(ctx context.Context, principal authenticate.Principal, sessionID uuid.UUID) error {
+	if principal.ImpersonatedBy == nil || principal.ImpersonationSessionID != sessionID.String() {
+		if err := s.checkImpersonator(ctx, principal); err != nil {
+			return err
+		}
+	}
+
+	session, err := s.sessionService.Get(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, frontiersession.ErrNoSession) {
+			return ErrNotExist
+		}
+		return err
+	}
+	if _, _, ok := session.ImpersonatedBy(); !ok {
+		return ErrNotExist
+	}
+	if err = s.sessionService.Delete(ctx, session.ID); err != nil {
+		return err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		LogWithAttrs(audit.UserImpersonationEndedEvent, audit.UserTarget(session.UserID), map[string]string{
+			"session_id": session.ID.String(),
+		})
+	return nil
+}
+
+// checkImpersonator verifies the principal is a superuser acting as itself
+func (s Service) checkImpersonator(ctx context.Context, principal authenticate.Principal) error {
+	if principal.ID == "" {
+		return ErrUnauthenticated
+	}
+	if principal.ImpersonatedBy != nil {
+		return ErrChained
+	}
+
+	var isSudo bool
+	var err error
+	switch principal.Type {
+	case schema.UserPrincipal:
+		isSudo, err = s.userService.IsSudo(ctx, principal.ID, schema.PlatformSudoPermission)
+	case schema.ServiceUserPrincipal:
+		isSudo, err = s.serviceUserService.IsSudo(ctx, principal.ID, schema.PlatformSudoPermission)
+	}
+	if err != nil {
+		return err
+	}
+	if !isSudo {
+		return ErrForbidden
+	}
+	return nil
+}
+
+func (s Service) disabledByOrg(ctx context.Context, orgID string) (bool, error) {
+	prefs, err := s.prefService.List(ctx, preference.Filter{
+		ResourceID:   orgID,
+		ResourceType: schema.OrganizationNamespace,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, pref := range prefs {
+		if pref.Name == preference.OrganizationDisableImpersonation && pref.Value == "true" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,228 @@
+package impersonation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/impersonation"
+	"github.com/raystack/frontier/core/impersonation/mocks"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/preference"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testAdminID = "5a4b4c2e-31a3-11ec-8d3d-0242ac130003"
+	testUserID  = "9f256f86-31a3-11ec-8d3d-0242ac130003"
+	testOrgID   = "2e73f4a2-3763-4fc7-a8ad-d5e1d0b1a7e0"
+)
+
+var (
+	testSessionID = uuid.MustParse("0f8a4b1e-6b5c-4d3a-9e2f-1a2b3c4d5e6f")
+	testExpiresAt = time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	admin         = authenticate.Principal{ID: testAdminID, Type: schema.UserPrincipal}
+)
+
+type impersonationMocks struct {
+	users        *mocks.UserService
+	serviceUsers *mocks.ServiceUserService
+	orgs         *mocks.OrgService
+	prefs        *mocks.PreferenceService
+	sessions     *mocks.SessionService
+	tokens       *mocks.TokenBuilder
+}
+
+func newImpersonationService(t *testing.T) (*impersonation.Service, impersonationMocks) {
+	m := impersonationMocks{
+		users:        mocks.NewUserService(t),
+		serviceUsers: mocks.NewServiceUserService(t),
+		orgs:         mocks.NewOrgService(t),
+		prefs:        mocks.NewPreferenceService(t),
+		sessions:     mocks.NewSessionService(t),
+		tokens:       mocks.NewTokenBuilder(t),
+	}
+	return impersonation.NewService(m.users, m.serviceUsers, m.orgs, m.prefs, m.sessions, m.tokens,
+		authenticate.ImpersonationConfig{Validity: 30 * time.Minute}), m
+}
+
+func expectTarget(m impersonationMocks, prefs []preference.Preference) {
+	m.users.EXPECT().IsSudo(mock.Anything, testAdminID, schema.PlatformSudoPermission).Return(true, nil)
+	m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(user.User{ID: testUserID, State: user.Enabled}, nil)
+	m.users.EXPECT().IsSudo(mock.Anything, testUserID, schema.PlatformSudoPermission).Return(false, nil)
+	m.orgs.EXPECT().ListByUser(mock.Anything, authenticate.Principal{ID: testUserID, Type: schema.UserPrincipal}, organization.Filter{}).
+		Return([]organization.Organization{{ID: testOrgID}}, nil)
+	m.prefs.EXPECT().List(mock.Anything, preference.Filter{ResourceID: testOrgID, ResourceType: schema.OrganizationNamespace}).
+		Return(prefs, nil)
+}
+
+func TestService_Start(t *testing.T) {
+	t.Run("should issue a token bound to a short-lived session of the user", func(t *testing.T) {
+		s, m := newImpersonationService(t)
+		expectTarget(m, []preference.Preference{{Name: preference.OrganizationDisableImpersonation, Value: "false"}})
+		m.sessions.EXPECT().CreateWithValidity(mock.Anything, testUserID, 30*time.Minute, metadata.Metadata{
+			frontiersession.MetadataAuthMethod:          frontiersession.AuthMethodImpersonation,
+			frontiersession.MetadataImpersonatedBy:      testAdminID,
+			frontiersession.MetadataImpersonatedByType:  schema.UserPrincipal,
+			frontiersession.MetadataImpersonationReason: "ticket 42",
+		}).Return(&frontiersession.Session{ID: testSessionID, UserID: testUserID, ExpiresAt: testExpiresAt}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.MatchedBy(func(p authenticate.Principal) bool {
+			return p.ID == testUserID && p.ImpersonatedBy != nil && p.ImpersonatedBy.ID == testAdminID &&
+				p.ImpersonationSessionID == testSessionID.String()
+		}), map[string]string{}).Return([]byte("token"), nil)
+
+		got, err := s.Start(context.Background(), admin, testUserID, " ticket 42 ")
+		assert.NoError(t, err)
+		assert.Equal(t, impersonation.Impersonation{
+			SessionID:   testSessionID,
+			UserID:      testUserID,
+			AccessToken: "token",
+			ExpiresAt:   testExpiresAt,
+		}, got)
+	})
+
+	t.Run("should remove the session if the token can't be built", func(t *testing.T) {
+		s, m := newImpersonationService(t)
+		expectTarget(m, nil)
+		m.sessions.EXPECT().CreateWithValidity(mock.Anything, testUserID, 30*time.Minute, mock.Anything).
+			Return(&frontiersession.Session{ID: testSessionID, UserID: testUserID}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("no keys"))
+		m.sessions.EXPECT().Delete(mock.Anything, testSessionID).Return(nil)
+
+		_, err := s.Start(context.Background(), admin, testUserID, "ticket 42")
+		assert.Error(t, err)
+	})
+
+	tests := []struct {
+		name         string
+		impersonator authenticate.Principal
+		reason       string
+		setup        func(m impersonationMocks)
+		wantErr      error
+	}{
+		{
+			name:         "should require a reason",
+			impersonator: admin,
+			reason:       " ",
+			wantErr:      impersonation.ErrReasonRequired,
+		},
+		{
+			name:         "should reject principals without sudo permission",
+			impersonator: admin,
+			reason:       "ticket 42",
+			setup: func(m impersonationMocks) {
+				m.users.EXPECT().IsSudo(mock.Anything, testAdminID, schema.PlatformSudoPermission).Return(false, nil)
+			},
+			wantErr: impersonation.ErrForbidden,
+		},
+		{
+			name: "should reject impersonated principals",
+			impersonator: authenticate.Principal{
+				ID:             "2b3c4d5e-31a3-11ec-8d3d-0242ac130003",
+				Type:           schema.UserPrincipal,
+				ImpersonatedBy: &admin,
+			},
+			reason:  "ticket 42",
+			wantErr: impersonation.ErrChained,
+		},
+		{
+			name:         "should reject disabled users",
+			impersonator: admin,
+			reason:       "ticket 42",
+			setup: func(m impersonationMocks) {
+				m.users.EXPECT().IsSudo(mock.Anything, testAdminID, schema.PlatformSudoPermission).Return(true, nil)
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(user.User{ID: testUserID, State: user.Disabled}, nil)
+			},
+			wantErr: impersonation.ErrInvalidTarget,
+		},
+		{
+			name:         "should reject superusers",
+			impersonator: admin,
+			reason:       "ticket 42",
+			setup: func(m impersonationMocks) {
+				m.users.EXPECT().IsSudo(mock.Anything, testAdminID, schema.PlatformSudoPermission).Return(true, nil)
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(user.User{ID: testUserID, State: user.Enabled}, nil)
+				m.users.EXPECT().IsSudo(mock.Anything, testUserID, schema.PlatformSudoPermission).Return(true, nil)
+			},
+			wantErr: impersonation.ErrInvalidTarget,
+		},
+		{
+			name:         "should reject users of organizations which opted out",
+			impersonator: admin,
+			reason:       "ticket 42",
+			setup: func(m impersonationMocks) {
+				expectTarget(m, []preference.Preference{{Name: preference.OrganizationDisableImpersonation, Value: "true"}})
+			},
+			wantErr: impersonation.ErrDisabledByOrg,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newImpersonationService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			_, err := s.Start(context.Background(), tt.impersonator, testUserID, tt.reason)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestService_End(t *testing.T) {
+	impersonationSession := &frontiersession.Session{
+		ID:     testSessionID,
+		UserID: testUserID,
+		Metadata: metadata.Metadata{
+			frontiersession.MetadataImpersonatedBy:     testAdminID,
+			frontiersession.MetadataImpersonatedByType: schema.UserPrincipal,
+		},
+	}
+
+	t.Run("should let superusers end the impersonation", func(t *testing.T) {
+		s, m := newImpersonationService(t)
+		m.users.EXPECT().IsSudo(mock.Anything, testAdminID, schema.PlatformSudoPermission).Return(true, nil)
+		m.sessions.EXPECT().Get(mock.Anything, testSessionID).Return(impersonationSession, nil)
+		m.sessions.EXPECT().Delete(mock.Anything, testSessionID).Return(nil)
+
+		assert.NoError(t, s.End(context.Background(), admin, testSessionID))
+	})
+
+	t.Run("should let the impersonated principal end its own impersonation", func(t *testing.T) {
+		s, m := newImpersonationService(t)
+		m.sessions.EXPECT().Get(mock.Anything, testSessionID).Return(impersonationSession, nil)
+		m.sessions.EXPECT().Delete(mock.Anything, testSessionID).Return(nil)
+
+		assert.NoError(t, s.End(context.Background(), authenticate.Principal{
+			ID:                     testUserID,
+			Type:                   schema.UserPrincipal,
+			ImpersonatedBy:         &admin,
+			ImpersonationSessionID: testSessionID.String(),
+		}, testSessionID))
+	})
+
+	t.Run("should not end sessions users logged in to", func(t *testing.T) {
+		s, m := newImpersonationService(t)
+		m.users.EXPECT().IsSudo(mock.Anything, testAdminID, schema.PlatformSudoPermission).Return(true, nil)
+		m.sessions.EXPECT().Get(mock.Anything, testSessionID).Return(&frontiersession.Session{ID: testSessionID, UserID: testUserID}, nil)
+
+		assert.ErrorIs(t, s.End(context.Background(), admin, testSessionID), impersonation.ErrNotExist)
+	})
+
+	t.Run("should reject users without sudo permission", func(t *testing.T) {
+		s, m := newImpersonationService(t)
+		m.users.EXPECT().IsSudo(mock.Anything, testUserID, schema.PlatformSudoPermission).Return(false, nil)
+
+		assert.ErrorIs(t, s.End(context.Background(), authenticate.Principal{
+			ID:   testUserID,
+			Type: schema.UserPrincipal,
+		}, testSessionID), impersonation.ErrForbidden)
+	})
+}
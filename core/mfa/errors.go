@@ -0,0 +1,13 @@
+package mfa
+
+import "errors"
+
+var (
+	ErrNotExist        = errors.New("totp is not enrolled")
+	ErrAlreadyEnrolled = errors.New("totp is already enrolled")
+	ErrInvalidCode     = errors.New("invalid totp or recovery code")
+	ErrNotPending      = errors.New("session is not pending multi-factor authentication")
+	ErrPending         = errors.New("session is pending multi-factor authentication")
+	ErrRequiredByOrg   = errors.New("multi-factor authentication is required by an organization of the user")
+	ErrTooManyAttempts = errors.New("too many invalid codes, login again")
+)
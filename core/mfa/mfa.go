@@ -0,0 +1,55 @@
+package mfa
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	Get(ctx context.Context, userID string) (TOTP, error)
+	Upsert(ctx context.Context, totp TOTP) (TOTP, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+// TOTP is the authenticator app a user enrolled as second factor
+type TOTP struct {
+	UserID string
+	// Secret is shared with the authenticator app
+	Secret []byte
+	// RecoveryCodes are the hashes of the recovery codes not used yet
+	RecoveryCodes []string
+	// LastUsedStep is the time step of the last accepted code, codes of
+	// earlier steps are rejected
+	LastUsedStep int64
+	// ConfirmedAt is set once the user proved the app generates valid codes,
+	// the app is not asked for on login until then
+	ConfirmedAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (t TOTP) IsConfirmed() bool {
+	return !t.ConfirmedAt.IsZero()
+}
+
+// Enrollment is what the user needs to add the account in an authenticator app
+type Enrollment struct {
+	// Secret is the base32 secret to type in the app
+	Secret string
+	// ProvisioningURI is the otpauth uri encoded in the qr code
+	ProvisioningURI string
+	// QRCode is the png image of the provisioning uri
+	QRCode []byte
+}
+
+type Status struct {
+	// Enrolled is true once the user confirmed an authenticator app
+	Enrolled bool
+	// Required is true if an organization of the user enforces mfa
+	Required bool
+	// Pending is true if the session still has to verify the second factor
+	Pending bool
+	// RecoveryCodes is the number of unused recovery codes
+	RecoveryCodes int
+}
@@ -0,0 +1,344 @@
+package mfa
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/preference"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/crypt"
+	"github.com/raystack/frontier/pkg/metadata"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeLen   = 10
+	// maxAttempts is the number of invalid codes a pending session accepts
+	// before it is deleted
+	maxAttempts = 5
+	attemptKey  = "mfa_attempt"
+	// qrCodeScale is the number of pixels of each module of the qr code
+	qrCodeScale = 6
+)
+
+var recoveryCodeRunes = []rune("abcdefghjkmnpqrstuvwxyz23456789")
+
+type UserService interface {
+	GetByID(ctx context.Context, id string) (user.User, error)
+}
+
+type OrgService interface {
+	ListByUser(ctx context.Context, principal authenticate.Principal, flt organization.Filter) ([]organization.Organization, error)
+}
+
+type PreferenceService interface {
+	List(ctx context.Context, flt preference.Filter) ([]preference.Preference, error)
+}
+
+type SessionService interface {
+	Create(ctx context.Context, userID string, metadata metadata.Metadata) (*frontiersession.Session, error)
+	CreateMFAPending(ctx context.Context, userID string, validity time.Duration, metadata metadata.Metadata) (*frontiersession.Session, error)
+	Activate(ctx context.Context, sessionID uuid.UUID) (*frontiersession.Session, error)
+	Update(ctx context.Context, session *frontiersession.Session) error
+	Delete(ctx context.Context, sessionID uuid.UUID) error
+}
+
+type Service struct {
+	repository     Repository
+	userService    UserService
+	orgService     OrgService
+	prefService    PreferenceService
+	sessionService SessionService
+	config         authenticate.MFAConfig
+	Now            func() time.Time
+}
+
+func NewService(repository Repository, userService UserService, orgService OrgService,
+	prefService PreferenceService, sessionService SessionService, config authenticate.MFAConfig) *Service {
+	return &Service{
+		repository:     repository,
+		userService:    userService,
+		orgService:     orgService,
+		prefService:    prefService,
+		sessionService: sessionService,
+		config:         config,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// CreateSession creates the session of a user who passed a primary strategy.
+// If the user has to verify a second factor, the session stays pending until
+// the code is verified. metadata describes the client the user logged in from.
+func (s Service) CreateSession(ctx context.Context, userID string, metadata metadata.Metadata) (*frontiersession.Session, error) {
+	required, err := s.Required(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !required {
+		return s.sessionService.Create(ctx, userID, metadata)
+	}
+	return s.sessionService.CreateMFAPending(ctx, userID, s.config.Validity, metadata)
+}
+
+// Required is true if the user enrolled an authenticator app or belongs to
+// an organization enforcing mfa
+func (s Service) Required(ctx context.Context, userID string) (bool, error) {
+	totp, err := s.repository.Get(ctx, userID)
+	if err != nil && !errors.Is(err, ErrNotExist) {
+		return false, err
+	}
+	if err == nil && totp.IsConfirmed() {
+		return true, nil
+	}
+	return s.requiredByOrg(ctx, userID)
+}
+
+func (s Service) requiredByOrg(ctx context.Context, userID string) (bool, error) {
+	orgs, err := s.orgService.ListByUser(ctx, authenticate.Principal{
+		ID:   userID,
+		Type: schema.UserPrincipal,
+	}, organization.Filter{})
+	if err != nil {
+		return false, err
+	}
+	for _, org := range orgs {
+		prefs, err := s.prefService.List(ctx, preference.Filter{
+			ResourceID:   org.ID,
+			ResourceType: schema.OrganizationNamespace,
+		})
+		if err != nil {
+			return false, err
+		}
+		for _, pref := range prefs {
+			if pref.Name == preference.OrganizationMFA && pref.Value == "true" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Status describes the second factor of the session user
+func (s Service) Status(ctx context.Context, session *frontiersession.Session) (Status, error) {
+	status := Status{
+		Pending: session.IsMFAPending(s.Now()),
+	}
+	totp, err := s.repository.Get(ctx, session.UserID)
+	if err != nil && !errors.Is(err, ErrNotExist) {
+		return Status{}, err
+	}
+	if err == nil && totp.IsConfirmed() {
+		status.Enrolled = true
+		status.RecoveryCodes = len(totp.RecoveryCodes)
+	}
+	if status.Required, err = s.requiredByOrg(ctx, session.UserID); err != nil {
+		return Status{}, err
+	}
+	return status, nil
+}
+
+// Enroll generates a new secret for the authenticator app of the user, it is
+// only used for logins once confirmed. Pending sessions can enroll users
+// who don't have a confirmed app yet, as organizations enforcing mfa
+// require their members to enroll on login.
+func (s Service) Enroll(ctx context.Context, session *frontiersession.Session) (Enrollment, error) {
+	existing, err := s.repository.Get(ctx, session.UserID)
+	if err != nil && !errors.Is(err, ErrNotExist) {
+		return Enrollment{}, err
+	}
+	if err == nil && existing.IsConfirmed() {
+		return Enrollment{}, ErrAlreadyEnrolled
+	}
+
+	currentUser, err := s.userService.GetByID(ctx, session.UserID)
+	if err != nil {
+		return Enrollment{}, err
+	}
+	secret, err := strategy.GenerateTOTPSecret()
+	if err != nil {
+		return Enrollment{}, err
+	}
+	totp := strategy.NewTOTP(secret)
+	uri := totp.ProvisioningURI(s.config.Issuer, currentUser.Email)
+	// a negative size scales each module instead of fixing the image size
+	image, err := qrcode.Encode(uri, qrcode.Medium, -qrCodeScale)
+	if err != nil {
+		return Enrollment{}, err
+	}
+
+	if _, err = s.repository.Upsert(ctx, TOTP{
+		UserID: session.UserID,
+		Secret: secret,
+	}); err != nil {
+		return Enrollment{}, err
+	}
+	return Enrollment{
+		Secret:          totp.EncodedSecret(),
+		ProvisioningURI: uri,
+		QRCode:          image,
+	}, nil
+}
+
+// Confirm checks the first code of the enrolled app and returns the recovery
+// codes of the user, they are only shown once. A pending session is activated
+// as the user just verified the app.
+func (s Service) Confirm(ctx context.Context, session *frontiersession.Session, code string) ([]string, error) {
+	totp, err := s.repository.Get(ctx, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if totp.IsConfirmed() {
+		return nil, ErrAlreadyEnrolled
+	}
+
+	totpStrategy := strategy.NewTOTP(totp.Secret)
+	totpStrategy.Now = s.Now
+	step, err := totpStrategy.Validate(code, totp.LastUsedStep)
+	if err != nil {
+		return nil, ErrInvalidCode
+	}
+
+	recoveryCodes := make([]string, 0, recoveryCodeCount)
+	totp.RecoveryCodes = make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		recoveryCode := crypt.GenerateRandomStringFromLetters(recoveryCodeLen, recoveryCodeRunes)
+		recoveryCodes = append(recoveryCodes, recoveryCode[:recoveryCodeLen/2]+"-"+recoveryCode[recoveryCodeLen/2:])
+		totp.RecoveryCodes = append(totp.RecoveryCodes, hashRecoveryCode(recoveryCode))
+	}
+	totp.LastUsedStep = step
+	totp.ConfirmedAt = s.Now()
+	if _, err = s.repository.Upsert(ctx, totp); err != nil {
+		return nil, err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.UserMFAEnabledEvent, audit.UserTarget(session.UserID))
+
+	if session.IsMFAPending(s.Now()) {
+		if _, err = s.sessionService.Activate(ctx, session.ID); err != nil {
+			return nil, err
+		}
+	}
+	return recoveryCodes, nil
+}
+
+// Verify completes the login of a pending session with a code of the
+// authenticator app or an unused recovery code
+func (s Service) Verify(ctx context.Context, session *frontiersession.Session, code string) (*frontiersession.Session, error) {
+	if !session.IsMFAPending(s.Now()) {
+		return nil, ErrNotPending
+	}
+	if err := s.verifyCode(ctx, session.UserID, code); err != nil {
+		if !errors.Is(err, ErrInvalidCode) {
+			return nil, err
+		}
+		return nil, s.recordFailedAttempt(ctx, session)
+	}
+	return s.sessionService.Activate(ctx, session.ID)
+}
+
+// Disable removes the authenticator app of the user, it requires a valid code
+// and isn't allowed if an organization of the user enforces mfa
+func (s Service) Disable(ctx context.Context, session *frontiersession.Session, code string) error {
+	if session.IsMFAPending(s.Now()) {
+		return ErrPending
+	}
+	required, err := s.requiredByOrg(ctx, session.UserID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrRequiredByOrg
+	}
+	if err = s.verifyCode(ctx, session.UserID, code); err != nil {
+		return err
+	}
+	if err = s.repository.Delete(ctx, session.UserID); err != nil {
+		return err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.UserMFADisabledEvent, audit.UserTarget(session.UserID))
+	return nil
+}
+
+// verifyCode checks a code of the confirmed app of the user, recovery codes
+// are accepted as well and can only be used once
+func (s Service) verifyCode(ctx context.Context, userID, code string) error {
+	totp, err := s.repository.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !totp.IsConfirmed() {
+		return ErrNotExist
+	}
+
+	totpStrategy := strategy.NewTOTP(totp.Secret)
+	totpStrategy.Now = s.Now
+	if step, err := totpStrategy.Validate(code, totp.LastUsedStep); err == nil {
+		totp.LastUsedStep = step
+		_, err = s.repository.Upsert(ctx, totp)
+		return err
+	}
+
+	hashed := hashRecoveryCode(normalizeRecoveryCode(code))
+	for i, recoveryCode := range totp.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(recoveryCode), []byte(hashed)) == 1 {
+			totp.RecoveryCodes = append(totp.RecoveryCodes[:i], totp.RecoveryCodes[i+1:]...)
+			_, err = s.repository.Upsert(ctx, totp)
+			return err
+		}
+	}
+	return ErrInvalidCode
+}
+
+// recordFailedAttempt counts invalid codes in the pending session to avoid
+// brute forcing them, the session is deleted once there are too many
+func (s Service) recordFailedAttempt(ctx context.Context, session *frontiersession.Session) error {
+	attempts := 0
+	if session.Metadata == nil {
+		session.Metadata = map[string]any{}
+	}
+	switch val := session.Metadata[attemptKey].(type) {
+	case int:
+		attempts = val
+	case float64:
+		// metadata read back from the database holds json numbers
+		attempts = int(val)
+	}
+	attempts++
+	if attempts >= maxAttempts {
+		if err := s.sessionService.Delete(ctx, session.ID); err != nil {
+			return err
+		}
+		return ErrTooManyAttempts
+	}
+	session.Metadata[attemptKey] = attempts
+	if err := s.sessionService.Update(ctx, session); err != nil {
+		return err
+	}
+	return ErrInvalidCode
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
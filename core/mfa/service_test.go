@@ -0,0 +1,372 @@
+package mfa_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/mfa"
+	"github.com/raystack/frontier/core/mfa/mocks"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/preference"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUserID = "9f256f86-31a3-11ec-8d3d-0242ac130003"
+	testOrgID  = "2e73f4a2-3763-4fc7-a8ad-d5e1d0b1a7e0"
+)
+
+var (
+	mfaNow     = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	testSecret = []byte("12345678901234567890")
+)
+
+type mfaMocks struct {
+	repository *mocks.Repository
+	users      *mocks.UserService
+	orgs       *mocks.OrgService
+	prefs      *mocks.PreferenceService
+	sessions   *mocks.SessionService
+}
+
+func newMFAService(t *testing.T) (*mfa.Service, mfaMocks) {
+	m := mfaMocks{
+		repository: mocks.NewRepository(t),
+		users:      mocks.NewUserService(t),
+		orgs:       mocks.NewOrgService(t),
+		prefs:      mocks.NewPreferenceService(t),
+		sessions:   mocks.NewSessionService(t),
+	}
+	s := mfa.NewService(m.repository, m.users, m.orgs, m.prefs, m.sessions, authenticate.MFAConfig{
+		Issuer:   "Frontier",
+		Validity: 10 * time.Minute,
+	})
+	s.Now = func() time.Time {
+		return mfaNow
+	}
+	return s, m
+}
+
+func currentCode(step int64) string {
+	totp := strategy.NewTOTP(testSecret)
+	totp.Now = func() time.Time {
+		return mfaNow
+	}
+	return totp.Code(totp.Step() + step)
+}
+
+func currentStep() int64 {
+	totp := strategy.NewTOTP(testSecret)
+	totp.Now = func() time.Time {
+		return mfaNow
+	}
+	return totp.Step()
+}
+
+func hashed(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func confirmedTOTP() mfa.TOTP {
+	return mfa.TOTP{
+		UserID:        testUserID,
+		Secret:        testSecret,
+		RecoveryCodes: []string{hashed("abcdefghjk"), hashed("mnpqrstuvw")},
+		ConfirmedAt:   mfaNow.Add(-time.Hour),
+	}
+}
+
+func pendingSession() *frontiersession.Session {
+	return &frontiersession.Session{
+		ID:        uuid.New(),
+		UserID:    testUserID,
+		State:     frontiersession.StateMFAPending,
+		ExpiresAt: mfaNow.Add(5 * time.Minute),
+		Metadata:  metadata.Metadata{},
+	}
+}
+
+func activeSession() *frontiersession.Session {
+	return &frontiersession.Session{
+		ID:              uuid.New(),
+		UserID:          testUserID,
+		State:           frontiersession.StateActive,
+		AuthenticatedAt: mfaNow.Add(-time.Hour),
+		ExpiresAt:       mfaNow.Add(time.Hour),
+	}
+}
+
+func expectOrgPreference(m mfaMocks, value string) {
+	m.orgs.EXPECT().ListByUser(mock.Anything, mock.Anything, organization.Filter{}).
+		Return([]organization.Organization{{ID: testOrgID}}, nil)
+	m.prefs.EXPECT().List(mock.Anything, mock.MatchedBy(func(flt preference.Filter) bool {
+		return flt.ResourceID == testOrgID
+	})).Return([]preference.Preference{{Name: preference.OrganizationMFA, Value: value}}, nil)
+}
+
+func TestService_CreateSession(t *testing.T) {
+	clientMetadata := metadata.Metadata{frontiersession.MetadataAuthMethod: "mailotp"}
+	tests := []struct {
+		name    string
+		setup   func(m mfaMocks)
+		pending bool
+	}{
+		{
+			name: "should create an active session if the user has no second factor",
+			setup: func(m mfaMocks) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(mfa.TOTP{}, mfa.ErrNotExist)
+				expectOrgPreference(m, "false")
+			},
+		},
+		{
+			name: "should ignore an enrollment which isn't confirmed",
+			setup: func(m mfaMocks) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(mfa.TOTP{UserID: testUserID}, nil)
+				expectOrgPreference(m, "false")
+			},
+		},
+		{
+			name: "should create a pending session if the user enrolled an app",
+			setup: func(m mfaMocks) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+			},
+			pending: true,
+		},
+		{
+			name: "should create a pending session if an organization enforces mfa",
+			setup: func(m mfaMocks) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(mfa.TOTP{}, mfa.ErrNotExist)
+				expectOrgPreference(m, "true")
+			},
+			pending: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newMFAService(t)
+			tt.setup(m)
+			if tt.pending {
+				m.sessions.EXPECT().CreateMFAPending(mock.Anything, testUserID, 10*time.Minute, clientMetadata).
+					Return(pendingSession(), nil)
+			} else {
+				m.sessions.EXPECT().Create(mock.Anything, testUserID, clientMetadata).Return(activeSession(), nil)
+			}
+
+			got, err := s.CreateSession(context.Background(), testUserID, clientMetadata)
+			require.NoError(t, err)
+			assert.Equal(t, tt.pending, got.IsMFAPending(mfaNow))
+		})
+	}
+}
+
+func TestService_Enroll(t *testing.T) {
+	t.Run("should generate a secret and its qr code", func(t *testing.T) {
+		s, m := newMFAService(t)
+		m.repository.EXPECT().Get(mock.Anything, testUserID).Return(mfa.TOTP{}, mfa.ErrNotExist)
+		m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(user.User{ID: testUserID, Email: "alice@acme.org"}, nil)
+		m.repository.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(totp mfa.TOTP) bool {
+			return totp.UserID == testUserID && len(totp.Secret) > 0 && !totp.IsConfirmed()
+		})).Return(mfa.TOTP{}, nil)
+
+		got, err := s.Enroll(context.Background(), activeSession())
+		require.NoError(t, err)
+		assert.NotEmpty(t, got.Secret)
+		assert.Contains(t, got.ProvisioningURI, "otpauth://totp/Frontier:alice@acme.org?")
+		assert.Equal(t, []byte("\x89PNG"), got.QRCode[:4])
+	})
+
+	t.Run("should reject users who already enrolled", func(t *testing.T) {
+		s, m := newMFAService(t)
+		m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+
+		_, err := s.Enroll(context.Background(), activeSession())
+		assert.ErrorIs(t, err, mfa.ErrAlreadyEnrolled)
+	})
+}
+
+func TestService_Confirm(t *testing.T) {
+	t.Run("should return recovery codes and activate the pending session", func(t *testing.T) {
+		s, m := newMFAService(t)
+		session := pendingSession()
+		m.repository.EXPECT().Get(mock.Anything, testUserID).Return(mfa.TOTP{UserID: testUserID, Secret: testSecret}, nil)
+		var stored mfa.TOTP
+		m.repository.EXPECT().Upsert(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, totp mfa.TOTP) (mfa.TOTP, error) {
+			stored = totp
+			return totp, nil
+		})
+		m.sessions.EXPECT().Activate(mock.Anything, session.ID).Return(activeSession(), nil)
+
+		got, err := s.Confirm(context.Background(), session, currentCode(0))
+		require.NoError(t, err)
+		assert.Len(t, got, 10)
+		assert.True(t, stored.IsConfirmed())
+		assert.Equal(t, currentStep(), stored.LastUsedStep)
+		require.Len(t, stored.RecoveryCodes, 10)
+		for i, code := range got {
+			assert.Regexp(t, "^[a-z2-9]{5}-[a-z2-9]{5}$", code)
+			assert.Equal(t, hashed(code[:5]+code[6:]), stored.RecoveryCodes[i])
+		}
+	})
+
+	t.Run("should reject an invalid code", func(t *testing.T) {
+		s, m := newMFAService(t)
+		m.repository.EXPECT().Get(mock.Anything, testUserID).Return(mfa.TOTP{UserID: testUserID, Secret: testSecret}, nil)
+
+		_, err := s.Confirm(context.Background(), activeSession(), currentCode(-3))
+		assert.ErrorIs(t, err, mfa.ErrInvalidCode)
+	})
+}
+
+func TestService_Verify(t *testing.T) {
+	tests := []struct {
+		name    string
+		session func() *frontiersession.Session
+		code    string
+		setup   func(m mfaMocks, session *frontiersession.Session)
+		wantErr error
+	}{
+		{
+			name:    "should activate the session with a code of the app",
+			session: pendingSession,
+			code:    currentCode(0),
+			setup: func(m mfaMocks, session *frontiersession.Session) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+				m.repository.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(totp mfa.TOTP) bool {
+					return totp.LastUsedStep == currentStep()
+				})).Return(mfa.TOTP{}, nil)
+				m.sessions.EXPECT().Activate(mock.Anything, session.ID).Return(activeSession(), nil)
+			},
+		},
+		{
+			name:    "should activate the session with a recovery code and consume it",
+			session: pendingSession,
+			code:    "ABCDE-FGHJK",
+			setup: func(m mfaMocks, session *frontiersession.Session) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+				m.repository.EXPECT().Upsert(mock.Anything, mock.MatchedBy(func(totp mfa.TOTP) bool {
+					return len(totp.RecoveryCodes) == 1 && totp.RecoveryCodes[0] == hashed("mnpqrstuvw")
+				})).Return(mfa.TOTP{}, nil)
+				m.sessions.EXPECT().Activate(mock.Anything, session.ID).Return(activeSession(), nil)
+			},
+		},
+		{
+			name:    "should reject a code already used",
+			session: pendingSession,
+			code:    currentCode(0),
+			setup: func(m mfaMocks, session *frontiersession.Session) {
+				totp := confirmedTOTP()
+				totp.LastUsedStep = currentStep()
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(totp, nil)
+				m.sessions.EXPECT().Update(mock.Anything, session).Return(nil)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name:    "should count invalid codes in the session",
+			session: pendingSession,
+			code:    "000000",
+			setup: func(m mfaMocks, session *frontiersession.Session) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+				m.sessions.EXPECT().Update(mock.Anything, mock.MatchedBy(func(s *frontiersession.Session) bool {
+					return s.Metadata["mfa_attempt"] == 1
+				})).Return(nil)
+			},
+			wantErr: mfa.ErrInvalidCode,
+		},
+		{
+			name: "should delete the session after too many invalid codes",
+			session: func() *frontiersession.Session {
+				session := pendingSession()
+				session.Metadata["mfa_attempt"] = float64(4)
+				return session
+			},
+			code: "000000",
+			setup: func(m mfaMocks, session *frontiersession.Session) {
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+				m.sessions.EXPECT().Delete(mock.Anything, session.ID).Return(nil)
+			},
+			wantErr: mfa.ErrTooManyAttempts,
+		},
+		{
+			name:    "should reject sessions which aren't pending",
+			session: activeSession,
+			code:    currentCode(0),
+			setup:   func(m mfaMocks, session *frontiersession.Session) {},
+			wantErr: mfa.ErrNotPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newMFAService(t)
+			session := tt.session()
+			tt.setup(m, session)
+
+			got, err := s.Verify(context.Background(), session, tt.code)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.IsValid(mfaNow))
+		})
+	}
+}
+
+func TestService_Disable(t *testing.T) {
+	tests := []struct {
+		name    string
+		session func() *frontiersession.Session
+		setup   func(m mfaMocks)
+		wantErr error
+	}{
+		{
+			name:    "should remove the app with a valid code",
+			session: activeSession,
+			setup: func(m mfaMocks) {
+				expectOrgPreference(m, "false")
+				m.repository.EXPECT().Get(mock.Anything, testUserID).Return(confirmedTOTP(), nil)
+				m.repository.EXPECT().Upsert(mock.Anything, mock.Anything).Return(mfa.TOTP{}, nil)
+				m.repository.EXPECT().Delete(mock.Anything, testUserID).Return(nil)
+			},
+		},
+		{
+			name:    "should not remove the app if an organization enforces mfa",
+			session: activeSession,
+			setup: func(m mfaMocks) {
+				expectOrgPreference(m, "true")
+			},
+			wantErr: mfa.ErrRequiredByOrg,
+		},
+		{
+			name:    "should not remove the app from a pending session",
+			session: pendingSession,
+			setup:   func(m mfaMocks) {},
+			wantErr: mfa.ErrPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newMFAService(t)
+			tt.setup(m)
+
+			err := s.Disable(context.Background(), tt.session(), currentCode(0))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// AuthorizeDevice starts the device authorization of the client as per RFC
+// 8628 section 3.1, the returned authorization holds the plain device code
+func (s *Service) AuthorizeDevice(ctx context.Context, clientID, clientSecret, scope string) (DeviceAuthorization, error) {
+	client, err := s.AuthenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+	scopes := ParseScope(scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return DeviceAuthorization{}, NewError(ErrorCodeInvalidScope, "scope "+scope+" is not allowed for the client")
+		}
+	}
+
+	deviceCode, err := randomToken()
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+	userCode, err := randomUserCode()
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+	created, err := s.deviceRepo.Create(ctx, DeviceAuthorization{
+		ClientID:       client.ID,
+		Scopes:         scopes,
+		DeviceCodeHash: HashCode(deviceCode),
+		UserCode:       userCode,
+		Status:         DeviceStatusPending,
+		Interval:       s.config.OAuth2.DevicePollInterval,
+		ExpiresAt:      s.Now().Add(s.config.OAuth2.DeviceCodeValidity),
+	})
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+	created.DeviceCode = deviceCode
+	return created, nil
+}
+
+// GetPendingDevice returns the device authorization of the user code while
+// it waits for a user to approve it
+func (s *Service) GetPendingDevice(ctx context.Context, userCode string) (DeviceAuthorization, Client, error) {
+	device, err := s.deviceRepo.GetByUserCode(ctx, NormalizeUserCode(userCode))
+	if err != nil {
+		return DeviceAuthorization{}, Client{}, err
+	}
+	if device.Status != DeviceStatusPending || !s.Now().Before(device.ExpiresAt) {
+		return DeviceAuthorization{}, Client{}, ErrDeviceNotFound
+	}
+	client, err := s.clientRepo.GetByID(ctx, device.ClientID)
+	if err != nil {
+		return DeviceAuthorization{}, Client{}, err
+	}
+	return device, client, nil
+}
+
+// ApproveDevice records the decision of the user logged in with the session
+// on a pending device authorization, the device gets its tokens on the next poll
+func (s *Service) ApproveDevice(ctx context.Context, userCode, userID, sessionID string, approved bool) (DeviceAuthorization, error) {
+	device, _, err := s.GetPendingDevice(ctx, userCode)
+	if err != nil {
+		return DeviceAuthorization{}, err
+	}
+	device.Status = DeviceStatusDenied
+	if approved {
+		device.Status = DeviceStatusApproved
+		device.UserID = userID
+		device.SessionID = sessionID
+	}
+	if err := s.deviceRepo.Decide(ctx, device.ID, device.Status, device.UserID, device.SessionID); err != nil {
+		return DeviceAuthorization{}, err
+	}
+	return device, nil
+}
+
+// exchangeDeviceCode answers the polls of the device as per RFC 8628 section
+// 3.5 until the user decided, devices polling faster than the interval are
+// asked to slow down
+func (s *Service) exchangeDeviceCode(ctx context.Context, client Client, req TokenRequest) (Token, error) {
+	if req.DeviceCode == "" {
+		return Token{}, NewError(ErrorCodeInvalidRequest, "device_code is required")
+	}
+	device, err := s.deviceRepo.GetByDeviceCode(ctx, HashCode(req.DeviceCode))
+	if err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			return Token{}, NewError(ErrorCodeInvalidGrant, "device_code is invalid or already used")
+		}
+		return Token{}, err
+	}
+	switch {
+	case device.ClientID != client.ID:
+		return Token{}, NewError(ErrorCodeInvalidGrant, "device_code was issued to another client")
+	case !s.Now().Before(device.ExpiresAt):
+		return Token{}, NewError(ErrorCodeExpiredToken, "device_code has expired")
+	}
+
+	switch device.Status {
+	case DeviceStatusDenied:
+		if err := s.deviceRepo.Delete(ctx, device.ID); err != nil {
+			return Token{}, err
+		}
+		return Token{}, NewError(ErrorCodeAccessDenied, "user denied the request")
+	case DeviceStatusPending:
+		now := s.Now()
+		tooFast := !device.PolledAt.IsZero() && now.Sub(device.PolledAt) < device.Interval
+		if err := s.deviceRepo.SetPolledAt(ctx, device.ID, now); err != nil {
+			return Token{}, err
+		}
+		if tooFast {
+			return Token{}, NewError(ErrorCodeSlowDown, "")
+		}
+		return Token{}, NewError(ErrorCodeAuthorizationPending, "")
+	}
+
+	device, err = s.deviceRepo.Consume(ctx, device.ID)
+	if err != nil {
+		if errors.Is(err, ErrDeviceNotFound) {
+			// exchanged by a concurrent poll
+			return Token{}, NewError(ErrorCodeInvalidGrant, "device_code is invalid or already used")
+		}
+		return Token{}, err
+	}
+	var family RefreshToken
+	if device.SessionID != "" {
+		family = RefreshToken{
+			FamilyID:  uuid.NewString(),
+			SessionID: device.SessionID,
+		}
+	}
+	return s.issueTokens(ctx, client, device.UserID, device.Scopes, family, "")
+}
+
+// randomUserCode generates 8 characters of the user code alphabet, about 34
+// bits of entropy as recommended by RFC 8628 section 6.1
+func randomUserCode() (string, error) {
+	code := make([]byte, 8)
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := range code {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = userCodeAlphabet[n.Int64()]
+	}
+	return NormalizeUserCode(string(code)), nil
+}
@@ -0,0 +1,247 @@
+package oauth2_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNormalizeUserCode(t *testing.T) {
+	assert.Equal(t, "BCDF-GHJK", oauth2.NormalizeUserCode("bcdf-ghjk"))
+	assert.Equal(t, "BCDF-GHJK", oauth2.NormalizeUserCode(" bcdf ghjk "))
+	assert.Equal(t, "BCDF", oauth2.NormalizeUserCode("bcdf"))
+}
+
+func TestService_AuthorizeDevice(t *testing.T) {
+	t.Run("should store the hash of the device code", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		var stored oauth2.DeviceAuthorization
+		m.devices.EXPECT().Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, d oauth2.DeviceAuthorization) (oauth2.DeviceAuthorization, error) {
+				stored = d
+				d.ID = "device-id"
+				return d, nil
+			})
+
+		got, err := s.AuthorizeDevice(context.Background(), "client-id", "", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "device-id", got.ID)
+		assert.NotEmpty(t, got.DeviceCode)
+		assert.Empty(t, stored.DeviceCode)
+		assert.Equal(t, oauth2.HashCode(got.DeviceCode), stored.DeviceCodeHash)
+		assert.Equal(t, got.UserCode, oauth2.NormalizeUserCode(got.UserCode))
+		assert.Len(t, got.UserCode, 9)
+		assert.Equal(t, oauth2.DeviceStatusPending, stored.Status)
+		assert.Equal(t, 5*time.Second, stored.Interval)
+		assert.Equal(t, oauth2Now.Add(10*time.Minute), stored.ExpiresAt)
+		// scopes default to all the scopes of the client
+		assert.Equal(t, []string{"profile", "email"}, stored.Scopes)
+	})
+
+	t.Run("should reject scopes not allowed for the client", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+
+		_, err := s.AuthorizeDevice(context.Background(), "client-id", "", "profile admin")
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidScope, oauthErr.Code)
+	})
+}
+
+func TestService_ApproveDevice(t *testing.T) {
+	pending := oauth2.DeviceAuthorization{
+		ID:        "device-id",
+		ClientID:  "client-id",
+		UserCode:  "BCDF-GHJK",
+		Status:    oauth2.DeviceStatusPending,
+		ExpiresAt: oauth2Now.Add(time.Minute),
+	}
+
+	t.Run("should bind the approved device to the user and session", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.devices.EXPECT().GetByUserCode(mock.Anything, "BCDF-GHJK").Return(pending, nil)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.devices.EXPECT().Decide(mock.Anything, "device-id", oauth2.DeviceStatusApproved, "user-id", "session-id").Return(nil)
+
+		got, err := s.ApproveDevice(context.Background(), "bcdfghjk", "user-id", "session-id", true)
+		assert.NoError(t, err)
+		assert.Equal(t, oauth2.DeviceStatusApproved, got.Status)
+	})
+
+	t.Run("should record a denial without the user", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.devices.EXPECT().GetByUserCode(mock.Anything, "BCDF-GHJK").Return(pending, nil)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.devices.EXPECT().Decide(mock.Anything, "device-id", oauth2.DeviceStatusDenied, "", "").Return(nil)
+
+		got, err := s.ApproveDevice(context.Background(), "BCDF-GHJK", "user-id", "session-id", false)
+		assert.NoError(t, err)
+		assert.Equal(t, oauth2.DeviceStatusDenied, got.Status)
+	})
+
+	t.Run("should not approve an expired or decided device", func(t *testing.T) {
+		for _, modify := range []func(d *oauth2.DeviceAuthorization){
+			func(d *oauth2.DeviceAuthorization) { d.ExpiresAt = oauth2Now },
+			func(d *oauth2.DeviceAuthorization) { d.Status = oauth2.DeviceStatusApproved },
+		} {
+			s, m := newOAuth2Service(t)
+			device := pending
+			modify(&device)
+			m.devices.EXPECT().GetByUserCode(mock.Anything, "BCDF-GHJK").Return(device, nil)
+
+			_, err := s.ApproveDevice(context.Background(), "BCDF-GHJK", "user-id", "session-id", true)
+			assert.ErrorIs(t, err, oauth2.ErrDeviceNotFound)
+		}
+	})
+}
+
+func TestService_ExchangeDeviceCode(t *testing.T) {
+	sessionID := uuid.New()
+	device := oauth2.DeviceAuthorization{
+		ID:             "device-id",
+		ClientID:       "client-id",
+		Scopes:         []string{"profile"},
+		DeviceCodeHash: oauth2.HashCode("the-device-code"),
+		UserCode:       "BCDF-GHJK",
+		Status:         oauth2.DeviceStatusPending,
+		Interval:       5 * time.Second,
+		ExpiresAt:      oauth2Now.Add(time.Minute),
+	}
+	tokenRequest := oauth2.TokenRequest{
+		GrantType:  oauth2.GrantTypeDeviceCode,
+		DeviceCode: "the-device-code",
+		ClientID:   "client-id",
+	}
+
+	t.Run("should issue tokens bound to the session once approved", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		approved := device
+		approved.Status = oauth2.DeviceStatusApproved
+		approved.UserID = "user-id"
+		approved.SessionID = sessionID.String()
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.devices.EXPECT().GetByDeviceCode(mock.Anything, oauth2.HashCode("the-device-code")).Return(approved, nil)
+		m.devices.EXPECT().Consume(mock.Anything, "device-id").Return(approved, nil)
+		m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(user.User{ID: "user-id", State: user.Enabled}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.Anything, map[string]string{
+			oauth2.ClientIDClaimKey: "client-id",
+			oauth2.ScopeClaimKey:    "profile",
+		}).Return([]byte("jwt"), nil)
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(&frontiersession.Session{
+			ID:              sessionID,
+			UserID:          "user-id",
+			AuthenticatedAt: oauth2Now.Add(-time.Hour),
+			ExpiresAt:       oauth2Now.Add(12 * time.Hour),
+		}, nil)
+		var family oauth2.RefreshToken
+		m.refreshTokens.EXPECT().Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, t oauth2.RefreshToken) (oauth2.RefreshToken, error) {
+				family = t
+				return t, nil
+			})
+
+		got, err := s.Exchange(context.Background(), tokenRequest)
+		assert.NoError(t, err)
+		assert.Equal(t, "jwt", got.AccessToken)
+		assert.NotEmpty(t, got.RefreshToken)
+		assert.Equal(t, sessionID.String(), family.SessionID)
+		assert.Equal(t, oauth2.HashCode(got.RefreshToken), family.TokenHash)
+	})
+
+	tests := []struct {
+		name   string
+		device func() oauth2.DeviceAuthorization
+		getErr error
+		setup  func(m oauth2Mocks)
+		code   string
+	}{
+		{
+			name:   "should reject an unknown device code",
+			getErr: oauth2.ErrDeviceNotFound,
+			code:   oauth2.ErrorCodeInvalidGrant,
+		},
+		{
+			name: "should reject a device code of another client",
+			device: func() oauth2.DeviceAuthorization {
+				d := device
+				d.ClientID = "another-client"
+				return d
+			},
+			code: oauth2.ErrorCodeInvalidGrant,
+		},
+		{
+			name: "should reject an expired device code",
+			device: func() oauth2.DeviceAuthorization {
+				d := device
+				d.ExpiresAt = oauth2Now
+				return d
+			},
+			code: oauth2.ErrorCodeExpiredToken,
+		},
+		{
+			name: "should keep the device waiting while pending",
+			device: func() oauth2.DeviceAuthorization {
+				d := device
+				d.PolledAt = oauth2Now.Add(-5 * time.Second)
+				return d
+			},
+			setup: func(m oauth2Mocks) {
+				m.devices.EXPECT().SetPolledAt(mock.Anything, "device-id", oauth2Now).Return(nil)
+			},
+			code: oauth2.ErrorCodeAuthorizationPending,
+		},
+		{
+			name: "should slow down a device polling faster than the interval",
+			device: func() oauth2.DeviceAuthorization {
+				d := device
+				d.PolledAt = oauth2Now.Add(-time.Second)
+				return d
+			},
+			setup: func(m oauth2Mocks) {
+				m.devices.EXPECT().SetPolledAt(mock.Anything, "device-id", oauth2Now).Return(nil)
+			},
+			code: oauth2.ErrorCodeSlowDown,
+		},
+		{
+			name: "should deny the device once the user denied it",
+			device: func() oauth2.DeviceAuthorization {
+				d := device
+				d.Status = oauth2.DeviceStatusDenied
+				return d
+			},
+			setup: func(m oauth2Mocks) {
+				m.devices.EXPECT().Delete(mock.Anything, "device-id").Return(nil)
+			},
+			code: oauth2.ErrorCodeAccessDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newOAuth2Service(t)
+			m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+			got := device
+			if tt.device != nil {
+				got = tt.device()
+			}
+			m.devices.EXPECT().GetByDeviceCode(mock.Anything, oauth2.HashCode("the-device-code")).Return(got, tt.getErr)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			_, err := s.Exchange(context.Background(), tokenRequest)
+			var oauthErr *oauth2.Error
+			assert.True(t, errors.As(err, &oauthErr))
+			assert.Equal(t, tt.code, oauthErr.Code)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package oauth2
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrClientNotFound        = errors.New("oauth2 client doesn't exist")
+	ErrInvalidClientDetail   = errors.New("invalid oauth2 client details")
+	ErrAuthorizationNotFound = errors.New("oauth2 authorization doesn't exist")
+	ErrInvalidRedirectURI    = errors.New("redirect uri is not registered for the client")
+	ErrRefreshTokenNotFound  = errors.New("oauth2 refresh token doesn't exist")
+	ErrConsentNotFound       = errors.New("oauth2 consent doesn't exist")
+	ErrDeviceNotFound        = errors.New("oauth2 device authorization doesn't exist")
+)
+
+// error codes defined in RFC 6749 section 4.1.2.1 and 5.2
+const (
+	ErrorCodeInvalidRequest          = "invalid_request"
+	ErrorCodeInvalidClient           = "invalid_client"
+	ErrorCodeInvalidGrant            = "invalid_grant"
+	ErrorCodeUnauthorizedClient      = "unauthorized_client"
+	ErrorCodeUnsupportedGrantType    = "unsupported_grant_type"
+	ErrorCodeUnsupportedResponseType = "unsupported_response_type"
+	ErrorCodeInvalidScope            = "invalid_scope"
+	ErrorCodeAccessDenied            = "access_denied"
+	ErrorCodeLoginRequired           = "login_required"
+	ErrorCodeServerError             = "server_error"
+
+	// device authorization errors of RFC 8628 section 3.5
+	ErrorCodeAuthorizationPending = "authorization_pending"
+	ErrorCodeSlowDown             = "slow_down"
+	ErrorCodeExpiredToken         = "expired_token"
+
+	// bearer token errors of RFC 6750 section 3.1
+	ErrorCodeInvalidToken      = "invalid_token"
+	ErrorCodeInsufficientScope = "insufficient_scope"
+)
+
+// Error is an oauth2 protocol error returned to the client as is
+type Error struct {
+	Code        string
+	Description string
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+func NewError(code, description string) *Error {
+	return &Error{
+		Code:        code,
+		Description: description,
+	}
+}
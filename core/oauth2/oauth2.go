@@ -0,0 +1,283 @@
+package oauth2
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate/token"
+	"github.com/raystack/frontier/pkg/metadata"
+)
+
+const (
+	ResponseTypeCode = "code"
+
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// token type hints as per RFC 7009 section 2.1
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+
+	// CodeChallengeMethodS256 is the only PKCE method supported, plain is
+	// rejected as it doesn't protect against an intercepted code
+	CodeChallengeMethodS256 = "S256"
+
+	TokenTypeBearer = "Bearer"
+
+	ClientIDClaimKey = token.ClientIDClaimsKey
+	ScopeClaimKey    = token.ScopeClaimsKey
+)
+
+// Client is a third party application allowed to request access tokens on
+// behalf of frontier users
+type Client struct {
+	ID   string
+	Name string
+	// SecretHash is the bcrypt hash of the client secret, empty for public clients
+	SecretHash string
+	// RedirectURIs are the only urls the authorization response is sent to
+	RedirectURIs []string
+	// Scopes the client is allowed to request
+	Scopes []string
+	// Public clients like SPAs and native apps can't keep a secret and
+	// authenticate with PKCE alone
+	Public bool
+
+	Metadata  metadata.Metadata
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c Client) HasRedirectURI(uri string) bool {
+	// redirect uris are compared as exact strings as per RFC 6749 section 3.1.2.3
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// AuthorizeRequest is the authorization request sent by the client through
+// the user agent to the authorization endpoint
+type AuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+}
+
+// Authorization is an authorization request of a user waiting for consent,
+// once consented it holds the hash of the code issued to the client
+type Authorization struct {
+	ID                  string
+	ClientID            string
+	UserID              string
+	RedirectURI         string
+	Scopes              []string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Nonce               string
+	// SessionID is the session of the user that authorized the client,
+	// refresh tokens issued for the code are bound to it
+	SessionID string
+
+	// Code is the plain authorization code, only set when it is issued
+	Code string
+	// CodeHash is the sha256 of the code, the code itself is never stored
+	CodeHash string
+
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// Consent records the scopes a user has granted to a client, later
+// authorization requests within these scopes skip the consent step
+type Consent struct {
+	ClientID  string
+	UserID    string
+	Scopes    []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c Consent) Covers(scopes []string) bool {
+	for _, s := range scopes {
+		if !slices.Contains(c.Scopes, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// TokenRequest is the request sent by the client to the token endpoint
+type TokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	ClientID     string
+	ClientSecret string
+	CodeVerifier string
+	RefreshToken string
+	DeviceCode   string
+	// Scope can narrow down the scopes of a refreshed access token
+	Scope string
+}
+
+// Token is the successful response of the token endpoint
+type Token struct {
+	AccessToken string
+	TokenType   string
+	ExpiresIn   time.Duration
+	Scopes      []string
+	// RefreshToken is opaque and rotated on every use
+	RefreshToken string
+	// IDToken is issued when the openid scope was granted
+	IDToken string
+}
+
+// RefreshToken is bound to the session of the user who authorized the client.
+// Every refresh rotates the token, all tokens rotated from the same code
+// belong to one family which is revoked when a used token is presented again
+type RefreshToken struct {
+	ID        string
+	FamilyID  string
+	ClientID  string
+	UserID    string
+	SessionID string
+	Scopes    []string
+	// TokenHash is the sha256 of the token, the token itself is never stored
+	TokenHash string
+	// AccessTokenID and AccessTokenExpiresAt identify the access token issued
+	// along, it is denied as well when the family is revoked
+	AccessTokenID        string
+	AccessTokenExpiresAt time.Time
+
+	ExpiresAt time.Time
+	// UsedAt is set once the token is rotated
+	UsedAt    time.Time
+	RevokedAt time.Time
+	CreatedAt time.Time
+}
+
+func (t RefreshToken) IsActive(at time.Time) bool {
+	return t.UsedAt.IsZero() && t.RevokedAt.IsZero() && at.Before(t.ExpiresAt)
+}
+
+// RevokeRequest is sent by the client to the revocation endpoint as per RFC 7009
+type RevokeRequest struct {
+	Token         string
+	TokenTypeHint string
+	ClientID      string
+	ClientSecret  string
+}
+
+// Introspection describes a token as per RFC 7662 section 2.2, inactive
+// tokens carry no other detail
+type Introspection struct {
+	Active    bool
+	TokenType string
+	// Claims of the token, timestamps are in seconds since epoch
+	Claims map[string]any
+}
+
+type DeviceStatus string
+
+const (
+	DeviceStatusPending  DeviceStatus = "pending"
+	DeviceStatusApproved DeviceStatus = "approved"
+	DeviceStatusDenied   DeviceStatus = "denied"
+)
+
+// DeviceAuthorization is a device authorization request of RFC 8628. The
+// device polls the token endpoint with the device code while the user
+// approves the request in a browser by entering the user code
+type DeviceAuthorization struct {
+	ID       string
+	ClientID string
+	Scopes   []string
+	// DeviceCode is the plain device code, only set when it is issued
+	DeviceCode string
+	// DeviceCodeHash is the sha256 of the device code, the code itself is never stored
+	DeviceCodeHash string
+	// UserCode is short and case insensitive to be typed by the user
+	UserCode string
+	Status   DeviceStatus
+	// UserID and SessionID are set once a user approved the request, refresh
+	// tokens issued to the device are bound to the session
+	UserID    string
+	SessionID string
+	// Interval is the minimum time the device waits between two polls
+	Interval time.Duration
+	PolledAt time.Time
+
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// ParseScope splits a space delimited scope parameter
+func ParseScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// FormatScope joins scopes to be sent as a scope parameter
+func FormatScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// HashCode returns the hash of an authorization code as stored in the database
+func HashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// userCodeAlphabet has no vowels to avoid forming words and no characters
+// easily confused with each other as per RFC 8628 section 6.1
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// NormalizeUserCode uppercases the user code and drops the characters a user
+// could have typed along like dashes and spaces, the stored codes are
+// formatted as XXXX-XXXX
+func NormalizeUserCode(userCode string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(userCode) {
+		if strings.ContainsRune(userCodeAlphabet, r) {
+			b.WriteRune(r)
+		}
+	}
+	code := b.String()
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+// VerifyCodeChallenge checks the PKCE verifier against the challenge sent
+// with the authorization request as per RFC 7636 section 4.6
+func VerifyCodeChallenge(challenge, method, verifier string) bool {
+	if method != CodeChallengeMethodS256 || !validCodeVerifier(verifier) {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:]) == challenge
+}
+
+// validCodeVerifier checks the verifier is 43 to 128 unreserved characters
+func validCodeVerifier(verifier string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, r := range verifier {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '.', r == '_', r == '~':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+package oauth2_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCodeChallenge(t *testing.T) {
+	// example from RFC 7636 appendix B
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	tests := []struct {
+		name      string
+		challenge string
+		method    string
+		verifier  string
+		want      bool
+	}{
+		{
+			name:      "should accept the verifier of the challenge",
+			challenge: challenge,
+			method:    oauth2.CodeChallengeMethodS256,
+			verifier:  verifier,
+			want:      true,
+		},
+		{
+			name:      "should reject another verifier",
+			challenge: challenge,
+			method:    oauth2.CodeChallengeMethodS256,
+			verifier:  strings.Repeat("a", 43),
+			want:      false,
+		},
+		{
+			name:      "should reject plain method",
+			challenge: verifier,
+			method:    "plain",
+			verifier:  verifier,
+			want:      false,
+		},
+		{
+			name:      "should reject a short verifier",
+			challenge: challenge,
+			method:    oauth2.CodeChallengeMethodS256,
+			verifier:  "short",
+			want:      false,
+		},
+		{
+			name:      "should reject a verifier with reserved characters",
+			challenge: challenge,
+			method:    oauth2.CodeChallengeMethodS256,
+			verifier:  strings.Repeat("a", 42) + "/",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, oauth2.VerifyCodeChallenge(tt.challenge, tt.method, tt.verifier))
+		})
+	}
+}
+
+func TestConsent_Covers(t *testing.T) {
+	consent := oauth2.Consent{Scopes: []string{"profile", "email"}}
+	assert.True(t, consent.Covers([]string{"email"}))
+	assert.True(t, consent.Covers(nil))
+	assert.False(t, consent.Covers([]string{"email", "orgs"}))
+}
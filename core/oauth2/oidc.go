@@ -0,0 +1,139 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/token"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+// scopes defined by OpenID Connect Core section 5.4, requesting openid
+// makes the token response carry an id token
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// standard claims of OpenID Connect Core section 5.1
+const (
+	NameClaimKey              = "name"
+	PreferredUsernameClaimKey = "preferred_username"
+	PictureClaimKey           = "picture"
+	UpdatedAtClaimKey         = "updated_at"
+	EmailClaimKey             = "email"
+	NonceClaimKey             = "nonce"
+	AuthTimeClaimKey          = "auth_time"
+)
+
+// SupportedScopes are advertised in the discovery document
+var SupportedScopes = []string{ScopeOpenID, ScopeProfile, ScopeEmail,
+	authenticate.ScopeRead, authenticate.ScopeWrite, authenticate.ScopeAdmin}
+
+// SupportedClaims are advertised in the discovery document
+var SupportedClaims = []string{
+	jwt.IssuerKey, jwt.SubjectKey, jwt.AudienceKey, jwt.ExpirationKey, jwt.IssuedAtKey,
+	AuthTimeClaimKey, NonceClaimKey, NameClaimKey, PreferredUsernameClaimKey, PictureClaimKey,
+	UpdatedAtClaimKey, EmailClaimKey, token.OrgIDsClaimKey,
+}
+
+type OrgService interface {
+	ListByUser(ctx context.Context, principal authenticate.Principal, filter organization.Filter) ([]organization.Organization, error)
+}
+
+// UserInfo returns the claims of the user the access token was issued for,
+// limited to the scopes granted to the client
+func (s *Service) UserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	userID, claims, err := s.tokenService.Parse(ctx, []byte(accessToken))
+	if err != nil {
+		return nil, NewError(ErrorCodeInvalidToken, "access token is invalid")
+	}
+	scope, _ := claims[ScopeClaimKey].(string)
+	scopes := ParseScope(scope)
+	if _, ok := claims[ClientIDClaimKey]; !ok || !slices.Contains(scopes, ScopeOpenID) {
+		return nil, NewError(ErrorCodeInsufficientScope, "access token wasn't granted the openid scope")
+	}
+
+	currentUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, user.ErrNotExist) {
+			return nil, NewError(ErrorCodeInvalidToken, "user doesn't exist")
+		}
+		return nil, err
+	}
+	if currentUser.State == user.Disabled {
+		return nil, NewError(ErrorCodeInvalidToken, "user is disabled")
+	}
+	userClaims, err := s.userClaims(ctx, currentUser, scopes)
+	if err != nil {
+		return nil, err
+	}
+	userClaims[jwt.SubjectKey] = currentUser.ID
+	return userClaims, nil
+}
+
+// userClaims maps the user to the standard claims released for the scopes
+func (s *Service) userClaims(ctx context.Context, u user.User, scopes []string) (map[string]any, error) {
+	claims := map[string]any{}
+	if slices.Contains(scopes, ScopeProfile) {
+		if u.Title != "" {
+			claims[NameClaimKey] = u.Title
+		}
+		claims[PreferredUsernameClaimKey] = u.Name
+		if u.Avatar != "" {
+			claims[PictureClaimKey] = u.Avatar
+		}
+		if !u.UpdatedAt.IsZero() {
+			claims[UpdatedAtClaimKey] = u.UpdatedAt.Unix()
+		}
+	}
+	if slices.Contains(scopes, ScopeEmail) {
+		claims[EmailClaimKey] = u.Email
+	}
+
+	if s.config.Token.Claims.AddOrgIDsClaim && s.orgService != nil {
+		// same as the org_ids claim of access tokens issued by frontier
+		orgs, err := s.orgService.ListByUser(ctx, authenticate.Principal{
+			ID:   u.ID,
+			Type: schema.UserPrincipal,
+			User: &u,
+		}, organization.Filter{})
+		if err != nil {
+			return nil, err
+		}
+		orgIDs := make([]string, 0, len(orgs))
+		for _, o := range orgs {
+			orgIDs = append(orgIDs, o.ID)
+		}
+		claims[token.OrgIDsClaimKey] = strings.Join(orgIDs, ",")
+	}
+	return claims, nil
+}
+
+// buildIDToken issues the id token of OpenID Connect Core section 2 for the client
+func (s *Service) buildIDToken(ctx context.Context, client Client, u user.User, scopes []string,
+	nonce string, authTime time.Time) (string, error) {
+	claims, err := s.userClaims(ctx, u, scopes)
+	if err != nil {
+		return "", err
+	}
+	if nonce != "" {
+		claims[NonceClaimKey] = nonce
+	}
+	if !authTime.IsZero() {
+		claims[AuthTimeClaimKey] = authTime.Unix()
+	}
+	idToken, err := s.tokenService.BuildIDToken(u.ID, client.ID, claims)
+	if err != nil {
+		return "", err
+	}
+	return string(idToken), nil
+}
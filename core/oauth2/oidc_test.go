@@ -0,0 +1,126 @@
+package oauth2_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_ExchangeOpenID(t *testing.T) {
+	s, m := newOAuth2Service(t)
+	client := publicClient()
+	client.Scopes = []string{oauth2.ScopeOpenID, oauth2.ScopeProfile, oauth2.ScopeEmail}
+	m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(client, nil)
+	m.authorizations.EXPECT().Consume(mock.Anything, oauth2.HashCode("the-code")).Return(oauth2.Authorization{
+		ClientID:            "client-id",
+		UserID:              "user-id",
+		RedirectURI:         testRedirect,
+		Scopes:              []string{oauth2.ScopeOpenID, oauth2.ScopeEmail},
+		CodeChallenge:       testChallenge,
+		CodeChallengeMethod: oauth2.CodeChallengeMethodS256,
+		Nonce:               "n-0S6_WzA2Mj",
+		ExpiresAt:           oauth2Now.Add(time.Minute),
+	}, nil)
+	m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(user.User{
+		ID:     "user-id",
+		Name:   "john",
+		Title:  "John Doe",
+		Email:  "john@example.com",
+		Avatar: "https://example.com/john.png",
+		State:  user.Enabled,
+	}, nil)
+	m.tokens.EXPECT().BuildToken(mock.Anything, mock.Anything, mock.Anything).Return([]byte("jwt"), nil)
+	// profile claims are not released without the profile scope
+	m.tokenService.EXPECT().BuildIDToken("user-id", "client-id", map[string]any{
+		oauth2.EmailClaimKey: "john@example.com",
+		oauth2.NonceClaimKey: "n-0S6_WzA2Mj",
+	}).Return([]byte("id-token"), nil)
+
+	got, err := s.Exchange(context.Background(), oauth2.TokenRequest{
+		GrantType:    oauth2.GrantTypeAuthorizationCode,
+		Code:         "the-code",
+		ClientID:     "client-id",
+		RedirectURI:  testRedirect,
+		CodeVerifier: testVerifier,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "id-token", got.IDToken)
+}
+
+func TestService_UserInfo(t *testing.T) {
+	profile := user.User{
+		ID:        "user-id",
+		Name:      "john",
+		Title:     "John Doe",
+		Email:     "john@example.com",
+		Avatar:    "https://example.com/john.png",
+		State:     user.Enabled,
+		UpdatedAt: oauth2Now,
+	}
+
+	t.Run("should return the claims of the granted scopes", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("user-id", map[string]any{
+			oauth2.ClientIDClaimKey: "client-id",
+			oauth2.ScopeClaimKey:    "openid profile",
+		}, nil)
+		m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(profile, nil)
+
+		got, err := s.UserInfo(context.Background(), "jwt")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			jwt.SubjectKey:                   "user-id",
+			oauth2.NameClaimKey:              "John Doe",
+			oauth2.PreferredUsernameClaimKey: "john",
+			oauth2.PictureClaimKey:           "https://example.com/john.png",
+			oauth2.UpdatedAtClaimKey:         oauth2Now.Unix(),
+		}, got)
+	})
+
+	t.Run("should reject tokens without the openid scope", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			claims map[string]any
+		}{
+			{
+				name:   "frontier session token",
+				claims: map[string]any{},
+			},
+			{
+				name: "oauth2 token without openid",
+				claims: map[string]any{
+					oauth2.ClientIDClaimKey: "client-id",
+					oauth2.ScopeClaimKey:    "profile email",
+				},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				s, m := newOAuth2Service(t)
+				m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("user-id", tt.claims, nil)
+
+				_, err := s.UserInfo(context.Background(), "jwt")
+				var oauthErr *oauth2.Error
+				assert.True(t, errors.As(err, &oauthErr))
+				assert.Equal(t, oauth2.ErrorCodeInsufficientScope, oauthErr.Code)
+			})
+		}
+	})
+
+	t.Run("should reject invalid tokens", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("", nil, errors.New("token is revoked"))
+
+		_, err := s.UserInfo(context.Background(), "jwt")
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidToken, oauthErr.Code)
+	})
+}
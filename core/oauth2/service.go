@@ -0,0 +1,784 @@
+package oauth2
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/robfig/cron/v3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	cleanupSchedule = "@every 30m"
+)
+
+type ClientRepository interface {
+	Create(ctx context.Context, client Client) (Client, error)
+	GetByID(ctx context.Context, id string) (Client, error)
+	List(ctx context.Context) ([]Client, error)
+	// UpdateSecret replaces the secret hash of a confidential client
+	UpdateSecret(ctx context.Context, id, secretHash string) error
+	Delete(ctx context.Context, id string) error
+}
+
+type AuthorizationRepository interface {
+	Create(ctx context.Context, authorization Authorization) (Authorization, error)
+	GetByID(ctx context.Context, id string) (Authorization, error)
+	// SetCode attaches the hash of the issued code to a pending authorization
+	SetCode(ctx context.Context, id, codeHash string, expiresAt time.Time) error
+	// Consume deletes the authorization holding the code and returns it, a
+	// code can only be consumed once
+	Consume(ctx context.Context, codeHash string) (Authorization, error)
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token RefreshToken) (RefreshToken, error)
+	GetByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	// Use marks an active token as rotated, a token can only be used once
+	Use(ctx context.Context, id string) error
+	// RevokeFamily revokes every token of the family and returns them
+	RevokeFamily(ctx context.Context, familyID string) ([]RefreshToken, error)
+	// RevokeSession revokes every token bound to the session and returns them
+	RevokeSession(ctx context.Context, sessionID string) ([]RefreshToken, error)
+	// RevokeUser revokes every token of the user and returns them
+	RevokeUser(ctx context.Context, userID string) ([]RefreshToken, error)
+	DeleteExpired(ctx context.Context) error
+}
+
+type DeviceRepository interface {
+	Create(ctx context.Context, device DeviceAuthorization) (DeviceAuthorization, error)
+	GetByUserCode(ctx context.Context, userCode string) (DeviceAuthorization, error)
+	GetByDeviceCode(ctx context.Context, deviceCodeHash string) (DeviceAuthorization, error)
+	// SetPolledAt records the last time the device polled the token endpoint
+	SetPolledAt(ctx context.Context, id string, at time.Time) error
+	// Decide records the decision of the user on a pending authorization
+	Decide(ctx context.Context, id string, status DeviceStatus, userID, sessionID string) error
+	// Consume deletes the approved authorization and returns it, an approval
+	// can only be exchanged once
+	Consume(ctx context.Context, id string) (DeviceAuthorization, error)
+	Delete(ctx context.Context, id string) error
+	DeleteExpired(ctx context.Context) error
+}
+
+type ConsentRepository interface {
+	Get(ctx context.Context, clientID, userID string) (Consent, error)
+	Upsert(ctx context.Context, consent Consent) (Consent, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id string) (user.User, error)
+}
+
+type SessionService interface {
+	Get(ctx context.Context, sessionID uuid.UUID) (*frontiersession.Session, error)
+}
+
+type TokenBuilder interface {
+	BuildToken(ctx context.Context, principal authenticate.Principal, metadata map[string]string) ([]byte, error)
+}
+
+type TokenService interface {
+	Parse(ctx context.Context, userToken []byte) (string, map[string]any, error)
+	BuildIDToken(subjectID, audience string, claims map[string]any) ([]byte, error)
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	RefreshRevoked(ctx context.Context) error
+	PurgeRevoked(ctx context.Context) error
+}
+
+type Service struct {
+	log            log.Logger
+	clientRepo     ClientRepository
+	authRepo       AuthorizationRepository
+	consentRepo    ConsentRepository
+	refreshRepo    RefreshTokenRepository
+	deviceRepo     DeviceRepository
+	userService    UserService
+	orgService     OrgService
+	sessionService SessionService
+	tokenBuilder   TokenBuilder
+	tokenService   TokenService
+	config         authenticate.Config
+	Now            func() time.Time
+
+	mu  sync.Mutex
+	job *cron.Cron
+}
+
+func NewService(logger log.Logger, clientRepo ClientRepository, authRepo AuthorizationRepository,
+	consentRepo ConsentRepository, refreshRepo RefreshTokenRepository, deviceRepo DeviceRepository,
+	userService UserService, orgService OrgService, sessionService SessionService, tokenBuilder TokenBuilder,
+	tokenService TokenService, config authenticate.Config) *Service {
+	return &Service{
+		log:            logger,
+		clientRepo:     clientRepo,
+		authRepo:       authRepo,
+		consentRepo:    consentRepo,
+		refreshRepo:    refreshRepo,
+		deviceRepo:     deviceRepo,
+		userService:    userService,
+		orgService:     orgService,
+		sessionService: sessionService,
+		tokenBuilder:   tokenBuilder,
+		tokenService:   tokenService,
+		config:         config,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// CreateClient registers a client and returns the plain secret of confidential
+// clients, the secret is not stored and can't be retrieved later
+func (s *Service) CreateClient(ctx context.Context, client Client) (Client, string, error) {
+	// clients without redirect uris can only use the device authorization grant
+	if client.Name == "" {
+		return Client{}, "", ErrInvalidClientDetail
+	}
+	for _, uri := range client.RedirectURIs {
+		parsed, err := url.Parse(uri)
+		if err != nil || !parsed.IsAbs() || parsed.Fragment != "" {
+			return Client{}, "", ErrInvalidClientDetail
+		}
+	}
+
+	var secret string
+	if !client.Public {
+		var err error
+		if secret, client.SecretHash, err = newClientSecret(); err != nil {
+			return Client{}, "", err
+		}
+	}
+
+	created, err := s.clientRepo.Create(ctx, client)
+	if err != nil {
+		return Client{}, "", err
+	}
+	return created, secret, nil
+}
+
+func (s *Service) GetClient(ctx context.Context, id string) (Client, error) {
+	return s.clientRepo.GetByID(ctx, id)
+}
+
+func (s *Service) ListClients(ctx context.Context) ([]Client, error) {
+	return s.clientRepo.List(ctx)
+}
+
+// RotateClientSecret replaces the secret of a confidential client and returns
+// the new plain secret, the old secret stops working right away
+func (s *Service) RotateClientSecret(ctx context.Context, id string) (string, error) {
+	client, err := s.clientRepo.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if client.Public {
+		return "", ErrInvalidClientDetail
+	}
+	secret, secretHash, err := newClientSecret()
+	if err != nil {
+		return "", err
+	}
+	if err := s.clientRepo.UpdateSecret(ctx, client.ID, secretHash); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// newClientSecret generates a client secret along with the bcrypt hash it's
+// stored as
+func newClientSecret() (string, string, error) {
+	secret, err := randomToken()
+	if err != nil {
+		return "", "", err
+	}
+	sHash, err := bcrypt.GenerateFromPassword([]byte(secret), 14)
+	if err != nil {
+		return "", "", err
+	}
+	return secret, string(sHash), nil
+}
+
+func (s *Service) DeleteClient(ctx context.Context, id string) error {
+	return s.clientRepo.Delete(ctx, id)
+}
+
+// ResolveClient validates the client and the redirect uri of an authorization
+// request. Failures must not be redirected back to the client as the redirect
+// uri can't be trusted, the returned redirect uri is the one responses are sent to
+func (s *Service) ResolveClient(ctx context.Context, clientID, redirectURI string) (Client, string, error) {
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return Client{}, "", err
+	}
+	if redirectURI == "" {
+		// redirect uri can be omitted only if a single one is registered
+		if len(client.RedirectURIs) != 1 {
+			return Client{}, "", ErrInvalidRedirectURI
+		}
+		return client, client.RedirectURIs[0], nil
+	}
+	if !client.HasRedirectURI(redirectURI) {
+		return Client{}, "", ErrInvalidRedirectURI
+	}
+	return client, redirectURI, nil
+}
+
+// Authorize validates the authorization request of the user logged in with
+// the given session. If the user has already consented to the requested scopes
+// the code is issued right away, otherwise the returned authorization waits for consent.
+// Protocol failures are returned as *Error and should be redirected to the client.
+func (s *Service) Authorize(ctx context.Context, req AuthorizeRequest, userID, sessionID string) (Authorization, error) {
+	client, redirectURI, err := s.ResolveClient(ctx, req.ClientID, req.RedirectURI)
+	if err != nil {
+		return Authorization{}, err
+	}
+	if req.ResponseType != ResponseTypeCode {
+		return Authorization{}, NewError(ErrorCodeUnsupportedResponseType, "only code response type is supported")
+	}
+	if req.CodeChallenge == "" {
+		return Authorization{}, NewError(ErrorCodeInvalidRequest, "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != CodeChallengeMethodS256 {
+		return Authorization{}, NewError(ErrorCodeInvalidRequest, "code_challenge_method must be S256")
+	}
+
+	scopes := ParseScope(req.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(client.Scopes, scope) {
+			return Authorization{}, NewError(ErrorCodeInvalidScope, "scope "+scope+" is not allowed for the client")
+		}
+	}
+
+	authorization := Authorization{
+		ClientID:            client.ID,
+		UserID:              userID,
+		RedirectURI:         redirectURI,
+		Scopes:              scopes,
+		State:               req.State,
+		CodeChallenge:       req.CodeChallenge,
+		CodeChallengeMethod: req.CodeChallengeMethod,
+		Nonce:               req.Nonce,
+		SessionID:           sessionID,
+		ExpiresAt:           s.Now().Add(s.config.OAuth2.CodeValidity),
+	}
+
+	consent, err := s.consentRepo.Get(ctx, client.ID, userID)
+	if err != nil && !errors.Is(err, ErrConsentNotFound) {
+		return Authorization{}, err
+	}
+	if err == nil && consent.Covers(scopes) {
+		code, err := randomToken()
+		if err != nil {
+			return Authorization{}, err
+		}
+		authorization.CodeHash = HashCode(code)
+		created, err := s.authRepo.Create(ctx, authorization)
+		if err != nil {
+			return Authorization{}, err
+		}
+		created.Code = code
+		return created, nil
+	}
+	return s.authRepo.Create(ctx, authorization)
+}
+
+// GetPendingAuthorization returns an authorization of the user waiting for consent
+func (s *Service) GetPendingAuthorization(ctx context.Context, id, userID string) (Authorization, Client, error) {
+	authorization, err := s.authRepo.GetByID(ctx, id)
+	if err != nil {
+		return Authorization{}, Client{}, err
+	}
+	if authorization.UserID != userID || authorization.CodeHash != "" || !s.Now().Before(authorization.ExpiresAt) {
+		return Authorization{}, Client{}, ErrAuthorizationNotFound
+	}
+	client, err := s.clientRepo.GetByID(ctx, authorization.ClientID)
+	if err != nil {
+		return Authorization{}, Client{}, err
+	}
+	return authorization, client, nil
+}
+
+// Consent records the decision of the user on a pending authorization. On
+// approval a code is issued, on denial an access_denied *Error is returned
+// along with the authorization so the client can still be redirected
+func (s *Service) Consent(ctx context.Context, id, userID string, approved bool) (Authorization, error) {
+	authorization, _, err := s.GetPendingAuthorization(ctx, id, userID)
+	if err != nil {
+		return Authorization{}, err
+	}
+	if !approved {
+		if err := s.authRepo.Delete(ctx, authorization.ID); err != nil {
+			return Authorization{}, err
+		}
+		return authorization, NewError(ErrorCodeAccessDenied, "user denied the request")
+	}
+
+	consent, err := s.consentRepo.Get(ctx, authorization.ClientID, userID)
+	if err != nil && !errors.Is(err, ErrConsentNotFound) {
+		return Authorization{}, err
+	}
+	consent.ClientID = authorization.ClientID
+	consent.UserID = userID
+	for _, scope := range authorization.Scopes {
+		if !slices.Contains(consent.Scopes, scope) {
+			consent.Scopes = append(consent.Scopes, scope)
+		}
+	}
+	if _, err := s.consentRepo.Upsert(ctx, consent); err != nil {
+		return Authorization{}, err
+	}
+
+	code, err := randomToken()
+	if err != nil {
+		return Authorization{}, err
+	}
+	authorization.CodeHash = HashCode(code)
+	authorization.ExpiresAt = s.Now().Add(s.config.OAuth2.CodeValidity)
+	if err := s.authRepo.SetCode(ctx, authorization.ID, authorization.CodeHash, authorization.ExpiresAt); err != nil {
+		return Authorization{}, err
+	}
+	authorization.Code = code
+	return authorization, nil
+}
+
+// Exchange issues tokens for an authorization code, a refresh token or an
+// approved device code. The access token is a regular frontier token of the
+// user carrying the client id and scopes
+func (s *Service) Exchange(ctx context.Context, req TokenRequest) (Token, error) {
+	if req.GrantType != GrantTypeAuthorizationCode && req.GrantType != GrantTypeRefreshToken &&
+		req.GrantType != GrantTypeDeviceCode {
+		return Token{}, NewError(ErrorCodeUnsupportedGrantType, "")
+	}
+	client, err := s.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return Token{}, err
+	}
+	switch req.GrantType {
+	case GrantTypeRefreshToken:
+		return s.refresh(ctx, client, req)
+	case GrantTypeDeviceCode:
+		return s.exchangeDeviceCode(ctx, client, req)
+	}
+
+	if req.Code == "" || req.CodeVerifier == "" {
+		return Token{}, NewError(ErrorCodeInvalidRequest, "code and code_verifier are required")
+	}
+	authorization, err := s.authRepo.Consume(ctx, HashCode(req.Code))
+	if err != nil {
+		if errors.Is(err, ErrAuthorizationNotFound) {
+			return Token{}, NewError(ErrorCodeInvalidGrant, "code is invalid or already used")
+		}
+		return Token{}, err
+	}
+	switch {
+	case !s.Now().Before(authorization.ExpiresAt):
+		return Token{}, NewError(ErrorCodeInvalidGrant, "code has expired")
+	case authorization.ClientID != client.ID:
+		return Token{}, NewError(ErrorCodeInvalidGrant, "code was issued to another client")
+	case req.RedirectURI != authorization.RedirectURI &&
+		!(req.RedirectURI == "" && len(client.RedirectURIs) == 1):
+		return Token{}, NewError(ErrorCodeInvalidGrant, "redirect_uri doesn't match the authorization request")
+	case !VerifyCodeChallenge(authorization.CodeChallenge, authorization.CodeChallengeMethod, req.CodeVerifier):
+		return Token{}, NewError(ErrorCodeInvalidGrant, "code_verifier doesn't match the code_challenge")
+	}
+
+	var family RefreshToken
+	if authorization.SessionID != "" {
+		family = RefreshToken{
+			FamilyID:  uuid.NewString(),
+			SessionID: authorization.SessionID,
+		}
+	}
+	return s.issueTokens(ctx, client, authorization.UserID, authorization.Scopes, family, authorization.Nonce)
+}
+
+// refresh rotates the refresh token. Presenting a token that was already
+// rotated means it leaked, the whole family is revoked in that case
+func (s *Service) refresh(ctx context.Context, client Client, req TokenRequest) (Token, error) {
+	if req.RefreshToken == "" {
+		return Token{}, NewError(ErrorCodeInvalidRequest, "refresh_token is required")
+	}
+	current, err := s.refreshRepo.GetByHash(ctx, HashCode(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			return Token{}, NewError(ErrorCodeInvalidGrant, "refresh token is invalid")
+		}
+		return Token{}, err
+	}
+	if current.ClientID != client.ID {
+		return Token{}, NewError(ErrorCodeInvalidGrant, "refresh token was issued to another client")
+	}
+	if _, err := s.checkRefreshToken(ctx, current); err != nil {
+		return Token{}, err
+	}
+
+	scopes := current.Scopes
+	if requested := ParseScope(req.Scope); len(requested) > 0 {
+		for _, scope := range requested {
+			if !slices.Contains(current.Scopes, scope) {
+				return Token{}, NewError(ErrorCodeInvalidScope, "scope "+scope+" was not granted")
+			}
+		}
+		scopes = requested
+	}
+
+	if err := s.useRefreshToken(ctx, current); err != nil {
+		return Token{}, err
+	}
+	return s.issueTokens(ctx, client, current.UserID, scopes, current, "")
+}
+
+// checkRefreshToken returns the session of a refresh token that can be
+// rotated. A token that was already rotated leaked, its family is revoked
+func (s *Service) checkRefreshToken(ctx context.Context, current RefreshToken) (*frontiersession.Session, error) {
+	if !current.UsedAt.IsZero() && current.RevokedAt.IsZero() {
+		s.log.Warn("refresh token reused, revoking the family", "client_id", current.ClientID, "family_id", current.FamilyID)
+		if err := s.revokeFamily(ctx, current.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, NewError(ErrorCodeInvalidGrant, "refresh token is invalid")
+	}
+	if !current.IsActive(s.Now()) {
+		return nil, NewError(ErrorCodeInvalidGrant, "refresh token is invalid")
+	}
+	return s.activeSession(ctx, current.SessionID)
+}
+
+// useRefreshToken marks the token as rotated, the family is revoked if a
+// concurrent request rotated it first
+func (s *Service) useRefreshToken(ctx context.Context, current RefreshToken) error {
+	if err := s.refreshRepo.Use(ctx, current.ID); err != nil {
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			if err := s.revokeFamily(ctx, current.FamilyID); err != nil {
+				return err
+			}
+			return NewError(ErrorCodeInvalidGrant, "refresh token is invalid")
+		}
+		return err
+	}
+	return nil
+}
+
+// issueTokens builds the access token, the id token when openid was granted
+// and, when a family is given, the next refresh token of the family
+func (s *Service) issueTokens(ctx context.Context, client Client, userID string, scopes []string,
+	family RefreshToken, nonce string) (Token, error) {
+	currentUser, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return Token{}, err
+	}
+	if currentUser.State == user.Disabled {
+		return Token{}, NewError(ErrorCodeInvalidGrant, "user is disabled")
+	}
+	accessToken, err := s.tokenBuilder.BuildToken(ctx, authenticate.Principal{
+		ID:   currentUser.ID,
+		Type: schema.UserPrincipal,
+		User: &currentUser,
+	}, map[string]string{
+		ClientIDClaimKey: client.ID,
+		ScopeClaimKey:    FormatScope(scopes),
+	})
+	if err != nil {
+		return Token{}, err
+	}
+	token := Token{
+		AccessToken: string(accessToken),
+		TokenType:   TokenTypeBearer,
+		ExpiresIn:   s.config.Token.Validity,
+		Scopes:      scopes,
+	}
+
+	var session *frontiersession.Session
+	if family.FamilyID != "" {
+		if session, err = s.activeSession(ctx, family.SessionID); err != nil {
+			return Token{}, err
+		}
+	}
+	if slices.Contains(scopes, ScopeOpenID) {
+		var authTime time.Time
+		if session != nil {
+			authTime = session.AuthenticatedAt
+		}
+		if token.IDToken, err = s.buildIDToken(ctx, client, currentUser, scopes, nonce, authTime); err != nil {
+			return Token{}, err
+		}
+	}
+	if family.FamilyID == "" {
+		return token, nil
+	}
+
+	next := RefreshToken{
+		FamilyID:  family.FamilyID,
+		ClientID:  client.ID,
+		UserID:    currentUser.ID,
+		SessionID: family.SessionID,
+		Scopes:    scopes,
+	}
+	if len(family.Scopes) > 0 {
+		// the family keeps the scopes granted with the code even if a refresh narrowed them
+		next.Scopes = family.Scopes
+	}
+	if token.RefreshToken, err = s.createRefreshToken(ctx, next, session, accessToken); err != nil {
+		return Token{}, err
+	}
+	return token, nil
+}
+
+// createRefreshToken stores the next token of a family and returns it, it
+// expires with the session at the latest
+func (s *Service) createRefreshToken(ctx context.Context, next RefreshToken, session *frontiersession.Session,
+	accessToken []byte) (string, error) {
+	next.ExpiresAt = s.Now().Add(s.config.OAuth2.RefreshTokenValidity)
+	if session.ExpiresAt.Before(next.ExpiresAt) {
+		next.ExpiresAt = session.ExpiresAt
+	}
+	plain, err := randomToken()
+	if err != nil {
+		return "", err
+	}
+	next.TokenHash = HashCode(plain)
+	// the access token is denied along with the family if it gets revoked
+	if parsed, err := jwt.ParseInsecure(accessToken); err == nil {
+		next.AccessTokenID = parsed.JwtID()
+		next.AccessTokenExpiresAt = parsed.Expiration()
+	}
+	if _, err := s.refreshRepo.Create(ctx, next); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+// activeSession returns the session refresh tokens are bound to, tokens of
+// a session that ended can't be refreshed
+func (s *Service) activeSession(ctx context.Context, sessionID string) (*frontiersession.Session, error) {
+	id, err := uuid.Parse(sessionID)
+	if err != nil {
+		return nil, NewError(ErrorCodeInvalidGrant, "session has ended")
+	}
+	session, err := s.sessionService.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, frontiersession.ErrNoSession) {
+			return nil, NewError(ErrorCodeInvalidGrant, "session has ended")
+		}
+		return nil, err
+	}
+	if !session.IsValid(s.Now()) {
+		return nil, NewError(ErrorCodeInvalidGrant, "session has ended")
+	}
+	return session, nil
+}
+
+func (s *Service) revokeFamily(ctx context.Context, familyID string) error {
+	revoked, err := s.refreshRepo.RevokeFamily(ctx, familyID)
+	if err != nil {
+		return err
+	}
+	return s.denyAccessTokens(ctx, revoked)
+}
+
+// denyAccessTokens revokes the access tokens issued along with the refresh
+// tokens which haven't expired yet
+func (s *Service) denyAccessTokens(ctx context.Context, revoked []RefreshToken) error {
+	var errs []error
+	for _, t := range revoked {
+		if t.AccessTokenID != "" && s.Now().Before(t.AccessTokenExpiresAt) {
+			errs = append(errs, s.tokenService.Revoke(ctx, t.AccessTokenID, t.AccessTokenExpiresAt))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Revoke invalidates a token issued to the client as per RFC 7009. Revoking
+// a refresh token revokes its family and the access tokens issued with it.
+// Unknown tokens are ignored as the client can't do anything about them
+func (s *Service) Revoke(ctx context.Context, req RevokeRequest) error {
+	client, err := s.AuthenticateClient(ctx, req.ClientID, req.ClientSecret)
+	if err != nil {
+		return err
+	}
+	if req.Token == "" {
+		return NewError(ErrorCodeInvalidRequest, "token is required")
+	}
+
+	if req.TokenTypeHint != TokenTypeHintAccessToken {
+		refreshToken, err := s.refreshRepo.GetByHash(ctx, HashCode(req.Token))
+		switch {
+		case err == nil:
+			if refreshToken.ClientID != client.ID {
+				return nil
+			}
+			return s.revokeFamily(ctx, refreshToken.FamilyID)
+		case !errors.Is(err, ErrRefreshTokenNotFound):
+			return err
+		}
+	}
+
+	_, claims, err := s.tokenService.Parse(ctx, []byte(req.Token))
+	if err != nil {
+		// invalid, expired or already revoked
+		return nil
+	}
+	if claims[ClientIDClaimKey] != client.ID {
+		return nil
+	}
+	jti, _ := claims[jwt.JwtIDKey].(string)
+	expiresAt, _ := claims[jwt.ExpirationKey].(time.Time)
+	if jti == "" {
+		return nil
+	}
+	return s.tokenService.Revoke(ctx, jti, expiresAt)
+}
+
+// Introspect describes the token as per RFC 7662. Both frontier access
+// tokens and refresh tokens are supported
+func (s *Service) Introspect(ctx context.Context, token, tokenTypeHint string) (Introspection, error) {
+	if token == "" {
+		return Introspection{}, NewError(ErrorCodeInvalidRequest, "token is required")
+	}
+	if tokenTypeHint != TokenTypeHintAccessToken {
+		refreshToken, err := s.refreshRepo.GetByHash(ctx, HashCode(token))
+		switch {
+		case err == nil:
+			if !refreshToken.IsActive(s.Now()) {
+				return Introspection{}, nil
+			}
+			if _, err := s.activeSession(ctx, refreshToken.SessionID); err != nil {
+				var oauthErr *Error
+				if errors.As(err, &oauthErr) {
+					return Introspection{}, nil
+				}
+				return Introspection{}, err
+			}
+			return Introspection{
+				Active:    true,
+				TokenType: TokenTypeHintRefreshToken,
+				Claims: map[string]any{
+					jwt.SubjectKey:    refreshToken.UserID,
+					ClientIDClaimKey:  refreshToken.ClientID,
+					ScopeClaimKey:     FormatScope(refreshToken.Scopes),
+					jwt.ExpirationKey: refreshToken.ExpiresAt.Unix(),
+					jwt.IssuedAtKey:   refreshToken.CreatedAt.Unix(),
+				},
+			}, nil
+		case !errors.Is(err, ErrRefreshTokenNotFound):
+			return Introspection{}, err
+		}
+	}
+
+	_, claims, err := s.tokenService.Parse(ctx, []byte(token))
+	if err != nil {
+		return Introspection{}, nil
+	}
+	for k, v := range claims {
+		if t, ok := v.(time.Time); ok {
+			claims[k] = t.Unix()
+		}
+	}
+	return Introspection{
+		Active:    true,
+		TokenType: TokenTypeBearer,
+		Claims:    claims,
+	}, nil
+}
+
+// AuthenticateClient checks the secret of confidential clients, public
+// clients are identified by their id alone
+func (s *Service) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (Client, error) {
+	if clientID == "" {
+		return Client{}, NewError(ErrorCodeInvalidClient, "client_id is required")
+	}
+	client, err := s.clientRepo.GetByID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, ErrClientNotFound) {
+			return Client{}, NewError(ErrorCodeInvalidClient, "client doesn't exist")
+		}
+		return Client{}, err
+	}
+	if client.Public {
+		return client, nil
+	}
+	if clientSecret == "" ||
+		bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(clientSecret)) != nil {
+		return Client{}, NewError(ErrorCodeInvalidClient, "client authentication failed")
+	}
+	return client, nil
+}
+
+// Init loads the revoked access tokens, keeps them in sync with the other
+// instances and schedules the cleanup of expired authorizations and tokens
+func (s *Service) Init(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.job != nil {
+		s.job.Stop()
+	}
+	if err := s.tokenService.RefreshRevoked(ctx); err != nil {
+		return err
+	}
+	s.job = cron.New(cron.WithChain(
+		cron.SkipIfStillRunning(cron.DefaultLogger),
+		cron.Recover(cron.DefaultLogger),
+	))
+	if _, err := s.job.AddFunc(cleanupSchedule, func() {
+		if err := s.authRepo.DeleteExpired(ctx); err != nil {
+			s.log.Warn("failed to delete expired oauth2 authorizations", "err", err)
+		}
+		if err := s.refreshRepo.DeleteExpired(ctx); err != nil {
+			s.log.Warn("failed to delete expired oauth2 refresh tokens", "err", err)
+		}
+		if err := s.deviceRepo.DeleteExpired(ctx); err != nil {
+			s.log.Warn("failed to delete expired oauth2 device authorizations", "err", err)
+		}
+		if err := s.tokenService.PurgeRevoked(ctx); err != nil {
+			s.log.Warn("failed to purge expired revoked tokens", "err", err)
+		}
+	}); err != nil {
+		return err
+	}
+	// bounds how long an access token revoked by another instance is still
+	// accepted by this one
+	s.job.Schedule(cron.Every(s.config.Token.RevocationSyncInterval), cron.FuncJob(func() {
+		if err := s.tokenService.RefreshRevoked(ctx); err != nil {
+			s.log.Warn("failed to refresh revoked tokens", "err", err)
+		}
+	}))
+	s.job.Start()
+	return nil
+}
+
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.job != nil {
+		return s.job.Stop().Err()
+	}
+	return nil
+}
+
+// randomToken generates 256 bits of randomness encoded to be url safe
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,613 @@
+package oauth2_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/oauth2/mocks"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	oauth2Now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	testRedirect  = "https://app.example.com/callback"
+)
+
+type oauth2Mocks struct {
+	clients        *mocks.ClientRepository
+	authorizations *mocks.AuthorizationRepository
+	consents       *mocks.ConsentRepository
+	refreshTokens  *mocks.RefreshTokenRepository
+	devices        *mocks.DeviceRepository
+	users          *mocks.UserService
+	orgs           *mocks.OrgService
+	sessions       *mocks.SessionService
+	tokens         *mocks.TokenBuilder
+	tokenService   *mocks.TokenService
+}
+
+func newOAuth2Service(t *testing.T) (*oauth2.Service, oauth2Mocks) {
+	m := oauth2Mocks{
+		clients:        mocks.NewClientRepository(t),
+		authorizations: mocks.NewAuthorizationRepository(t),
+		consents:       mocks.NewConsentRepository(t),
+		refreshTokens:  mocks.NewRefreshTokenRepository(t),
+		devices:        mocks.NewDeviceRepository(t),
+		users:          mocks.NewUserService(t),
+		orgs:           mocks.NewOrgService(t),
+		sessions:       mocks.NewSessionService(t),
+		tokens:         mocks.NewTokenBuilder(t),
+		tokenService:   mocks.NewTokenService(t),
+	}
+	s := oauth2.NewService(log.NewNoop(), m.clients, m.authorizations, m.consents, m.refreshTokens, m.devices,
+		m.users, m.orgs, m.sessions, m.tokens, m.tokenService,
+		authenticate.Config{
+			Token: authenticate.TokenConfig{Validity: time.Hour},
+			OAuth2: authenticate.OAuth2Config{
+				CodeValidity:         5 * time.Minute,
+				RefreshTokenValidity: 24 * time.Hour,
+				DeviceCodeValidity:   10 * time.Minute,
+				DevicePollInterval:   5 * time.Second,
+			},
+		})
+	s.Now = func() time.Time {
+		return oauth2Now
+	}
+	return s, m
+}
+
+func publicClient() oauth2.Client {
+	return oauth2.Client{
+		ID:           "client-id",
+		Name:         "app",
+		RedirectURIs: []string{testRedirect, "https://app.example.com/other"},
+		Scopes:       []string{"profile", "email"},
+		Public:       true,
+	}
+}
+
+func authorizeRequest() oauth2.AuthorizeRequest {
+	return oauth2.AuthorizeRequest{
+		ResponseType:        oauth2.ResponseTypeCode,
+		ClientID:            "client-id",
+		RedirectURI:         testRedirect,
+		Scope:               "profile",
+		State:               "xyz",
+		CodeChallenge:       testChallenge,
+		CodeChallengeMethod: oauth2.CodeChallengeMethodS256,
+	}
+}
+
+func TestService_Authorize(t *testing.T) {
+	t.Run("should not redirect when client or redirect uri is invalid", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "unknown").Return(oauth2.Client{}, oauth2.ErrClientNotFound)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+
+		req := authorizeRequest()
+		req.ClientID = "unknown"
+		_, err := s.Authorize(context.Background(), req, "user-id", "")
+		assert.ErrorIs(t, err, oauth2.ErrClientNotFound)
+
+		req = authorizeRequest()
+		req.RedirectURI = "https://evil.example.com/callback"
+		_, err = s.Authorize(context.Background(), req, "user-id", "")
+		assert.ErrorIs(t, err, oauth2.ErrInvalidRedirectURI)
+
+		// redirect uri can't be omitted when more than one is registered
+		req.RedirectURI = ""
+		_, err = s.Authorize(context.Background(), req, "user-id", "")
+		assert.ErrorIs(t, err, oauth2.ErrInvalidRedirectURI)
+	})
+
+	t.Run("should return protocol errors for invalid requests", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(r *oauth2.AuthorizeRequest)
+			code   string
+		}{
+			{
+				name:   "unsupported response type",
+				modify: func(r *oauth2.AuthorizeRequest) { r.ResponseType = "token" },
+				code:   oauth2.ErrorCodeUnsupportedResponseType,
+			},
+			{
+				name:   "missing code challenge",
+				modify: func(r *oauth2.AuthorizeRequest) { r.CodeChallenge = "" },
+				code:   oauth2.ErrorCodeInvalidRequest,
+			},
+			{
+				name:   "plain code challenge method",
+				modify: func(r *oauth2.AuthorizeRequest) { r.CodeChallengeMethod = "plain" },
+				code:   oauth2.ErrorCodeInvalidRequest,
+			},
+			{
+				name:   "scope not allowed for the client",
+				modify: func(r *oauth2.AuthorizeRequest) { r.Scope = "profile admin" },
+				code:   oauth2.ErrorCodeInvalidScope,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				s, m := newOAuth2Service(t)
+				m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+				req := authorizeRequest()
+				tt.modify(&req)
+
+				_, err := s.Authorize(context.Background(), req, "user-id", "")
+				var oauthErr *oauth2.Error
+				assert.True(t, errors.As(err, &oauthErr))
+				assert.Equal(t, tt.code, oauthErr.Code)
+			})
+		}
+	})
+
+	t.Run("should wait for consent of a new client", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.consents.EXPECT().Get(mock.Anything, "client-id", "user-id").Return(oauth2.Consent{}, oauth2.ErrConsentNotFound)
+		m.authorizations.EXPECT().Create(mock.Anything, mock.MatchedBy(func(a oauth2.Authorization) bool {
+			return a.CodeHash == "" && a.UserID == "user-id" && a.RedirectURI == testRedirect &&
+				a.ExpiresAt.Equal(oauth2Now.Add(5*time.Minute))
+		})).RunAndReturn(func(ctx context.Context, a oauth2.Authorization) (oauth2.Authorization, error) {
+			a.ID = "auth-id"
+			return a, nil
+		})
+
+		got, err := s.Authorize(context.Background(), authorizeRequest(), "user-id", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "auth-id", got.ID)
+		assert.Empty(t, got.Code)
+		assert.Equal(t, []string{"profile"}, got.Scopes)
+	})
+
+	t.Run("should issue the code right away when scopes were consented", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.consents.EXPECT().Get(mock.Anything, "client-id", "user-id").Return(oauth2.Consent{
+			Scopes: []string{"profile", "email"},
+		}, nil)
+		var stored oauth2.Authorization
+		m.authorizations.EXPECT().Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, a oauth2.Authorization) (oauth2.Authorization, error) {
+				stored = a
+				return a, nil
+			})
+
+		req := authorizeRequest()
+		req.Scope = ""
+		got, err := s.Authorize(context.Background(), req, "user-id", "")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got.Code)
+		assert.Equal(t, oauth2.HashCode(got.Code), stored.CodeHash)
+		// scopes default to all the scopes of the client
+		assert.Equal(t, []string{"profile", "email"}, stored.Scopes)
+	})
+}
+
+func TestService_Consent(t *testing.T) {
+	pending := oauth2.Authorization{
+		ID:          "auth-id",
+		ClientID:    "client-id",
+		UserID:      "user-id",
+		RedirectURI: testRedirect,
+		Scopes:      []string{"email"},
+		State:       "xyz",
+		ExpiresAt:   oauth2Now.Add(time.Minute),
+	}
+
+	t.Run("should not expose the authorization of another user", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.authorizations.EXPECT().GetByID(mock.Anything, "auth-id").Return(pending, nil)
+
+		_, err := s.Consent(context.Background(), "auth-id", "another-user", true)
+		assert.ErrorIs(t, err, oauth2.ErrAuthorizationNotFound)
+	})
+
+	t.Run("should drop the authorization when denied", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.authorizations.EXPECT().GetByID(mock.Anything, "auth-id").Return(pending, nil)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.authorizations.EXPECT().Delete(mock.Anything, "auth-id").Return(nil)
+
+		got, err := s.Consent(context.Background(), "auth-id", "user-id", false)
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeAccessDenied, oauthErr.Code)
+		assert.Equal(t, "xyz", got.State)
+	})
+
+	t.Run("should remember the consent and issue a code when approved", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.authorizations.EXPECT().GetByID(mock.Anything, "auth-id").Return(pending, nil)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.consents.EXPECT().Get(mock.Anything, "client-id", "user-id").Return(oauth2.Consent{
+			ClientID: "client-id",
+			UserID:   "user-id",
+			Scopes:   []string{"profile"},
+		}, nil)
+		m.consents.EXPECT().Upsert(mock.Anything, oauth2.Consent{
+			ClientID: "client-id",
+			UserID:   "user-id",
+			Scopes:   []string{"profile", "email"},
+		}).Return(oauth2.Consent{}, nil)
+		var codeHash string
+		m.authorizations.EXPECT().SetCode(mock.Anything, "auth-id", mock.Anything, oauth2Now.Add(5*time.Minute)).
+			RunAndReturn(func(ctx context.Context, id, hash string, expiresAt time.Time) error {
+				codeHash = hash
+				return nil
+			})
+
+		got, err := s.Consent(context.Background(), "auth-id", "user-id", true)
+		assert.NoError(t, err)
+		assert.Equal(t, oauth2.HashCode(got.Code), codeHash)
+	})
+}
+
+func TestService_Exchange(t *testing.T) {
+	secret := "client-secret"
+	secretHash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	assert.NoError(t, err)
+	confidential := publicClient()
+	confidential.Public = false
+	confidential.SecretHash = string(secretHash)
+
+	issued := oauth2.Authorization{
+		ID:                  "auth-id",
+		ClientID:            "client-id",
+		UserID:              "user-id",
+		RedirectURI:         testRedirect,
+		Scopes:              []string{"profile", "email"},
+		CodeChallenge:       testChallenge,
+		CodeChallengeMethod: oauth2.CodeChallengeMethodS256,
+		CodeHash:            oauth2.HashCode("the-code"),
+		ExpiresAt:           oauth2Now.Add(time.Minute),
+	}
+	tokenRequest := func() oauth2.TokenRequest {
+		return oauth2.TokenRequest{
+			GrantType:    oauth2.GrantTypeAuthorizationCode,
+			Code:         "the-code",
+			RedirectURI:  testRedirect,
+			ClientID:     "client-id",
+			ClientSecret: secret,
+			CodeVerifier: testVerifier,
+		}
+	}
+
+	t.Run("should issue a user token carrying client id and scopes", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(confidential, nil)
+		m.authorizations.EXPECT().Consume(mock.Anything, oauth2.HashCode("the-code")).Return(issued, nil)
+		m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(user.User{
+			ID:    "user-id",
+			Email: "user@example.com",
+			State: user.Enabled,
+		}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.MatchedBy(func(p authenticate.Principal) bool {
+			return p.ID == "user-id" && p.User != nil
+		}), map[string]string{
+			oauth2.ClientIDClaimKey: "client-id",
+			oauth2.ScopeClaimKey:    "profile email",
+		}).Return([]byte("jwt"), nil)
+
+		got, err := s.Exchange(context.Background(), tokenRequest())
+		assert.NoError(t, err)
+		assert.Equal(t, oauth2.Token{
+			AccessToken: "jwt",
+			TokenType:   oauth2.TokenTypeBearer,
+			ExpiresIn:   time.Hour,
+			Scopes:      []string{"profile", "email"},
+		}, got)
+	})
+
+	tests := []struct {
+		name          string
+		modify        func(r *oauth2.TokenRequest)
+		authorization func() oauth2.Authorization
+		consumeErr    error
+		code          string
+	}{
+		{
+			name:   "unsupported grant type",
+			modify: func(r *oauth2.TokenRequest) { r.GrantType = "password" },
+			code:   oauth2.ErrorCodeUnsupportedGrantType,
+		},
+		{
+			name:   "wrong client secret",
+			modify: func(r *oauth2.TokenRequest) { r.ClientSecret = "wrong" },
+			code:   oauth2.ErrorCodeInvalidClient,
+		},
+		{
+			name:       "code already used",
+			consumeErr: oauth2.ErrAuthorizationNotFound,
+			code:       oauth2.ErrorCodeInvalidGrant,
+		},
+		{
+			name: "expired code",
+			authorization: func() oauth2.Authorization {
+				a := issued
+				a.ExpiresAt = oauth2Now
+				return a
+			},
+			code: oauth2.ErrorCodeInvalidGrant,
+		},
+		{
+			name: "code issued to another client",
+			authorization: func() oauth2.Authorization {
+				a := issued
+				a.ClientID = "another-client"
+				return a
+			},
+			code: oauth2.ErrorCodeInvalidGrant,
+		},
+		{
+			name:   "different redirect uri",
+			modify: func(r *oauth2.TokenRequest) { r.RedirectURI = "https://app.example.com/other" },
+			code:   oauth2.ErrorCodeInvalidGrant,
+		},
+		{
+			name:   "wrong code verifier",
+			modify: func(r *oauth2.TokenRequest) { r.CodeVerifier = testChallenge },
+			code:   oauth2.ErrorCodeInvalidGrant,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("should reject "+tt.name, func(t *testing.T) {
+			s, m := newOAuth2Service(t)
+			req := tokenRequest()
+			if tt.modify != nil {
+				tt.modify(&req)
+			}
+			m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(confidential, nil).Maybe()
+			authorization := issued
+			if tt.authorization != nil {
+				authorization = tt.authorization()
+			}
+			m.authorizations.EXPECT().Consume(mock.Anything, mock.Anything).Return(authorization, tt.consumeErr).Maybe()
+
+			_, err := s.Exchange(context.Background(), req)
+			var oauthErr *oauth2.Error
+			assert.True(t, errors.As(err, &oauthErr))
+			assert.Equal(t, tt.code, oauthErr.Code)
+		})
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	sessionID := uuid.New()
+	activeSession := &frontiersession.Session{
+		ID:              sessionID,
+		UserID:          "user-id",
+		AuthenticatedAt: oauth2Now.Add(-time.Hour),
+		ExpiresAt:       oauth2Now.Add(12 * time.Hour),
+	}
+	current := oauth2.RefreshToken{
+		ID:        "refresh-id",
+		FamilyID:  "family-id",
+		ClientID:  "client-id",
+		UserID:    "user-id",
+		SessionID: sessionID.String(),
+		Scopes:    []string{"profile", "email"},
+		TokenHash: oauth2.HashCode("the-refresh-token"),
+		ExpiresAt: oauth2Now.Add(time.Hour),
+	}
+	refreshRequest := oauth2.TokenRequest{
+		GrantType:    oauth2.GrantTypeRefreshToken,
+		ClientID:     "client-id",
+		RefreshToken: "the-refresh-token",
+	}
+
+	t.Run("should rotate the refresh token within the family", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, oauth2.HashCode("the-refresh-token")).Return(current, nil)
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(activeSession, nil)
+		m.refreshTokens.EXPECT().Use(mock.Anything, "refresh-id").Return(nil)
+		m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(user.User{ID: "user-id", State: user.Enabled}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.Anything, map[string]string{
+			oauth2.ClientIDClaimKey: "client-id",
+			oauth2.ScopeClaimKey:    "profile",
+		}).Return([]byte("jwt"), nil)
+		var next oauth2.RefreshToken
+		m.refreshTokens.EXPECT().Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, t oauth2.RefreshToken) (oauth2.RefreshToken, error) {
+				next = t
+				return t, nil
+			})
+
+		req := refreshRequest
+		req.Scope = "profile"
+		got, err := s.Exchange(context.Background(), req)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, got.RefreshToken)
+		assert.NotEqual(t, "the-refresh-token", got.RefreshToken)
+		assert.Equal(t, []string{"profile"}, got.Scopes)
+		assert.Equal(t, oauth2.HashCode(got.RefreshToken), next.TokenHash)
+		assert.Equal(t, "family-id", next.FamilyID)
+		// narrowing the scope of a refresh doesn't shrink the grant of the family
+		assert.Equal(t, []string{"profile", "email"}, next.Scopes)
+		// refresh tokens don't outlive the session
+		assert.Equal(t, activeSession.ExpiresAt, next.ExpiresAt)
+	})
+
+	t.Run("should revoke the family when a rotated token is reused", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		used := current
+		used.UsedAt = oauth2Now.Add(-time.Minute)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(used, nil)
+		m.refreshTokens.EXPECT().RevokeFamily(mock.Anything, "family-id").Return([]oauth2.RefreshToken{
+			{ID: "next-id", AccessTokenID: "jti-live", AccessTokenExpiresAt: oauth2Now.Add(time.Minute)},
+			{ID: "old-id", AccessTokenID: "jti-expired", AccessTokenExpiresAt: oauth2Now.Add(-time.Minute)},
+		}, nil)
+		m.tokenService.EXPECT().Revoke(mock.Anything, "jti-live", oauth2Now.Add(time.Minute)).Return(nil)
+
+		_, err := s.Exchange(context.Background(), refreshRequest)
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidGrant, oauthErr.Code)
+	})
+
+	t.Run("should reject the refresh once the session has ended", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(current, nil)
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(nil, frontiersession.ErrNoSession)
+
+		_, err := s.Exchange(context.Background(), refreshRequest)
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidGrant, oauthErr.Code)
+	})
+
+	t.Run("should reject a scope that wasn't granted", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(current, nil)
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(activeSession, nil)
+
+		req := refreshRequest
+		req.Scope = "profile admin"
+		_, err := s.Exchange(context.Background(), req)
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidScope, oauthErr.Code)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	t.Run("should revoke the family of a refresh token", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, oauth2.HashCode("the-refresh-token")).Return(oauth2.RefreshToken{
+			ClientID: "client-id",
+			FamilyID: "family-id",
+		}, nil)
+		m.refreshTokens.EXPECT().RevokeFamily(mock.Anything, "family-id").Return(nil, nil)
+
+		err := s.Revoke(context.Background(), oauth2.RevokeRequest{
+			Token:    "the-refresh-token",
+			ClientID: "client-id",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should deny an access token issued to the client", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		expiresAt := oauth2Now.Add(time.Hour)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("user-id", map[string]any{
+			jwt.JwtIDKey:            "jti",
+			jwt.ExpirationKey:       expiresAt,
+			oauth2.ClientIDClaimKey: "client-id",
+		}, nil)
+		m.tokenService.EXPECT().Revoke(mock.Anything, "jti", expiresAt).Return(nil)
+
+		err := s.Revoke(context.Background(), oauth2.RevokeRequest{
+			Token:         "jwt",
+			TokenTypeHint: oauth2.TokenTypeHintAccessToken,
+			ClientID:      "client-id",
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should ignore tokens of other clients", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(oauth2.RefreshToken{}, oauth2.ErrRefreshTokenNotFound)
+		m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("user-id", map[string]any{
+			jwt.JwtIDKey:            "jti",
+			oauth2.ClientIDClaimKey: "another-client",
+		}, nil)
+
+		err := s.Revoke(context.Background(), oauth2.RevokeRequest{
+			Token:    "jwt",
+			ClientID: "client-id",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_Introspect(t *testing.T) {
+	t.Run("should describe an active access token", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		expiresAt := oauth2Now.Add(time.Hour)
+		m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("user-id", map[string]any{
+			jwt.SubjectKey:          "user-id",
+			jwt.ExpirationKey:       expiresAt,
+			oauth2.ClientIDClaimKey: "client-id",
+		}, nil)
+
+		got, err := s.Introspect(context.Background(), "jwt", oauth2.TokenTypeHintAccessToken)
+		assert.NoError(t, err)
+		assert.True(t, got.Active)
+		assert.Equal(t, oauth2.TokenTypeBearer, got.TokenType)
+		assert.Equal(t, expiresAt.Unix(), got.Claims[jwt.ExpirationKey])
+		assert.Equal(t, "user-id", got.Claims[jwt.SubjectKey])
+	})
+
+	t.Run("should report revoked tokens as inactive", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(oauth2.RefreshToken{}, oauth2.ErrRefreshTokenNotFound)
+		m.tokenService.EXPECT().Parse(mock.Anything, []byte("jwt")).Return("", nil, errors.New("token is revoked"))
+
+		got, err := s.Introspect(context.Background(), "jwt", "")
+		assert.NoError(t, err)
+		assert.False(t, got.Active)
+	})
+
+	t.Run("should report used refresh tokens as inactive", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, oauth2.HashCode("the-refresh-token")).Return(oauth2.RefreshToken{
+			UsedAt:    oauth2Now.Add(-time.Minute),
+			ExpiresAt: oauth2Now.Add(time.Hour),
+		}, nil)
+
+		got, err := s.Introspect(context.Background(), "the-refresh-token", oauth2.TokenTypeHintRefreshToken)
+		assert.NoError(t, err)
+		assert.False(t, got.Active)
+	})
+}
+
+func TestService_RotateClientSecret(t *testing.T) {
+	t.Run("should store the hash of a new secret", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		client := publicClient()
+		client.Public = false
+		client.SecretHash = "old-hash"
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(client, nil)
+		var storedHash string
+		m.clients.EXPECT().UpdateSecret(mock.Anything, "client-id", mock.Anything).
+			RunAndReturn(func(_ context.Context, _ string, secretHash string) error {
+				storedHash = secretHash
+				return nil
+			})
+
+		secret, err := s.RotateClientSecret(context.Background(), "client-id")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, secret)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(storedHash), []byte(secret)))
+	})
+
+	t.Run("should reject public clients", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.clients.EXPECT().GetByID(mock.Anything, "client-id").Return(publicClient(), nil)
+
+		_, err := s.RotateClientSecret(context.Background(), "client-id")
+		assert.ErrorIs(t, err, oauth2.ErrInvalidClientDetail)
+	})
+}
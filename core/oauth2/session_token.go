@@ -0,0 +1,161 @@
+package oauth2
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/authenticate/token"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+// IssueSessionToken issues an access token to the user logged in with the
+// session along with the first refresh token of a new family bound to it.
+// Refresh tokens of sessions aren't issued to a client, they are rotated on
+// every use and revoked once the session ends
+func (s *Service) IssueSessionToken(ctx context.Context, sessionID uuid.UUID) (Token, error) {
+	session, err := s.activeSession(ctx, sessionID.String())
+	if err != nil {
+		return Token{}, err
+	}
+	if _, _, ok := session.ImpersonatedBy(); ok {
+		// impersonation tokens are bound to the impersonation itself
+		return Token{}, NewError(ErrorCodeInvalidGrant, "impersonation sessions can't issue refresh tokens")
+	}
+	return s.issueSessionTokens(ctx, session, uuid.NewString())
+}
+
+// RefreshSessionToken rotates a refresh token of a session. Presenting a
+// token that was already rotated revokes the whole family
+func (s *Service) RefreshSessionToken(ctx context.Context, refreshToken string) (Token, error) {
+	if refreshToken == "" {
+		return Token{}, NewError(ErrorCodeInvalidRequest, "refresh_token is required")
+	}
+	current, err := s.refreshRepo.GetByHash(ctx, HashCode(refreshToken))
+	if err != nil {
+		if errors.Is(err, ErrRefreshTokenNotFound) {
+			return Token{}, NewError(ErrorCodeInvalidGrant, "refresh token is invalid")
+		}
+		return Token{}, err
+	}
+	if current.ClientID != "" {
+		// tokens of clients are refreshed with the client credentials
+		return Token{}, NewError(ErrorCodeInvalidGrant, "refresh token was issued to a client")
+	}
+	session, err := s.checkRefreshToken(ctx, current)
+	if err != nil {
+		return Token{}, err
+	}
+	if err := s.useRefreshToken(ctx, current); err != nil {
+		return Token{}, err
+	}
+	return s.issueSessionTokens(ctx, session, current.FamilyID)
+}
+
+// RevokeSessionToken revokes a refresh token of a session along with its
+// family, or an access token issued to a session. Unknown tokens and the
+// tokens of clients are ignored as per RFC 7009
+func (s *Service) RevokeSessionToken(ctx context.Context, tokenValue string) error {
+	if tokenValue == "" {
+		return NewError(ErrorCodeInvalidRequest, "token is required")
+	}
+	refreshToken, err := s.refreshRepo.GetByHash(ctx, HashCode(tokenValue))
+	switch {
+	case err == nil:
+		if refreshToken.ClientID != "" {
+			return nil
+		}
+		return s.revokeFamily(ctx, refreshToken.FamilyID)
+	case !errors.Is(err, ErrRefreshTokenNotFound):
+		return err
+	}
+
+	_, claims, err := s.tokenService.Parse(ctx, []byte(tokenValue))
+	if err != nil {
+		// invalid, expired or already revoked
+		return nil
+	}
+	if _, ok := claims[ClientIDClaimKey]; ok {
+		return nil
+	}
+	jti, _ := claims[jwt.JwtIDKey].(string)
+	expiresAt, _ := claims[jwt.ExpirationKey].(time.Time)
+	return s.tokenService.Revoke(ctx, jti, expiresAt)
+}
+
+// RevokeSession revokes the refresh tokens bound to a session that ended,
+// issued to the session or to the clients authorized in it, along with the
+// access tokens issued with them
+func (s *Service) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	revoked, err := s.refreshRepo.RevokeSession(ctx, sessionID.String())
+	if err != nil {
+		return err
+	}
+	return s.denyAccessTokens(ctx, revoked)
+}
+
+// RevokeUserSessions is RevokeSession for every session of the user
+func (s *Service) RevokeUserSessions(ctx context.Context, userID string) error {
+	revoked, err := s.refreshRepo.RevokeUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.denyAccessTokens(ctx, revoked)
+}
+
+// issueSessionTokens builds the access token of the user of the session,
+// with the same claims as the tokens of /v1beta1/auth/token, and the next
+// refresh token of the family
+func (s *Service) issueSessionTokens(ctx context.Context, session *frontiersession.Session, familyID string) (Token, error) {
+	currentUser, err := s.userService.GetByID(ctx, session.UserID)
+	if err != nil {
+		return Token{}, err
+	}
+	if currentUser.State == user.Disabled {
+		return Token{}, NewError(ErrorCodeInvalidGrant, "user is disabled")
+	}
+	principal := authenticate.Principal{
+		ID:              currentUser.ID,
+		Type:            schema.UserPrincipal,
+		User:            &currentUser,
+		AuthenticatedAt: session.AuthenticatedAt,
+	}
+	claims := map[string]string{}
+	if s.config.Token.Claims.AddOrgIDsClaim {
+		orgs, err := s.orgService.ListByUser(ctx, principal, organization.Filter{})
+		if err != nil {
+			return Token{}, err
+		}
+		orgIDs := make([]string, 0, len(orgs))
+		for _, o := range orgs {
+			orgIDs = append(orgIDs, o.ID)
+		}
+		claims[token.OrgIDsClaimKey] = strings.Join(orgIDs, ",")
+	}
+	accessToken, err := s.tokenBuilder.BuildToken(ctx, principal, claims)
+	if err != nil {
+		return Token{}, err
+	}
+
+	refreshToken, err := s.createRefreshToken(ctx, RefreshToken{
+		FamilyID:  familyID,
+		UserID:    currentUser.ID,
+		SessionID: session.ID.String(),
+	}, session, accessToken)
+	if err != nil {
+		return Token{}, err
+	}
+	return Token{
+		AccessToken:  string(accessToken),
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    s.config.Token.Validity,
+		RefreshToken: refreshToken,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package oauth2_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_IssueSessionToken(t *testing.T) {
+	sessionID := uuid.New()
+	activeSession := &frontiersession.Session{
+		ID:              sessionID,
+		UserID:          "user-id",
+		AuthenticatedAt: oauth2Now.Add(-time.Hour),
+		ExpiresAt:       oauth2Now.Add(12 * time.Hour),
+	}
+
+	t.Run("should start a refresh token family bound to the session", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(activeSession, nil)
+		m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(user.User{ID: "user-id", State: user.Enabled}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.MatchedBy(func(p authenticate.Principal) bool {
+			return p.ID == "user-id" && p.AuthenticatedAt.Equal(activeSession.AuthenticatedAt)
+		}), map[string]string{}).Return([]byte("jwt"), nil)
+		var created oauth2.RefreshToken
+		m.refreshTokens.EXPECT().Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, t oauth2.RefreshToken) (oauth2.RefreshToken, error) {
+				created = t
+				return t, nil
+			})
+
+		got, err := s.IssueSessionToken(context.Background(), sessionID)
+		assert.NoError(t, err)
+		assert.Equal(t, "jwt", got.AccessToken)
+		assert.Equal(t, time.Hour, got.ExpiresIn)
+		assert.Equal(t, oauth2.HashCode(got.RefreshToken), created.TokenHash)
+		assert.NotEmpty(t, created.FamilyID)
+		assert.Empty(t, created.ClientID)
+		assert.Equal(t, sessionID.String(), created.SessionID)
+		// refresh tokens don't outlive the session
+		assert.Equal(t, activeSession.ExpiresAt, created.ExpiresAt)
+	})
+
+	t.Run("should reject impersonation sessions", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		impersonation := *activeSession
+		impersonation.Metadata = metadata.Metadata{frontiersession.MetadataImpersonatedBy: "admin-id"}
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(&impersonation, nil)
+
+		_, err := s.IssueSessionToken(context.Background(), sessionID)
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidGrant, oauthErr.Code)
+	})
+}
+
+func TestService_RefreshSessionToken(t *testing.T) {
+	sessionID := uuid.New()
+	activeSession := &frontiersession.Session{
+		ID:              sessionID,
+		UserID:          "user-id",
+		AuthenticatedAt: oauth2Now.Add(-time.Hour),
+		ExpiresAt:       oauth2Now.Add(12 * time.Hour),
+	}
+	current := oauth2.RefreshToken{
+		ID:        "refresh-id",
+		FamilyID:  "family-id",
+		UserID:    "user-id",
+		SessionID: sessionID.String(),
+		TokenHash: oauth2.HashCode("the-refresh-token"),
+		ExpiresAt: oauth2Now.Add(time.Hour),
+	}
+
+	t.Run("should rotate the refresh token within the family", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, oauth2.HashCode("the-refresh-token")).Return(current, nil)
+		m.sessions.EXPECT().Get(mock.Anything, sessionID).Return(activeSession, nil)
+		m.refreshTokens.EXPECT().Use(mock.Anything, "refresh-id").Return(nil)
+		m.users.EXPECT().GetByID(mock.Anything, "user-id").Return(user.User{ID: "user-id", State: user.Enabled}, nil)
+		m.tokens.EXPECT().BuildToken(mock.Anything, mock.Anything, map[string]string{}).Return([]byte("jwt"), nil)
+		var next oauth2.RefreshToken
+		m.refreshTokens.EXPECT().Create(mock.Anything, mock.Anything).
+			RunAndReturn(func(ctx context.Context, t oauth2.RefreshToken) (oauth2.RefreshToken, error) {
+				next = t
+				return t, nil
+			})
+
+		got, err := s.RefreshSessionToken(context.Background(), "the-refresh-token")
+		assert.NoError(t, err)
+		assert.NotEqual(t, "the-refresh-token", got.RefreshToken)
+		assert.Equal(t, oauth2.HashCode(got.RefreshToken), next.TokenHash)
+		assert.Equal(t, "family-id", next.FamilyID)
+	})
+
+	t.Run("should revoke the family when a rotated token is reused", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		used := current
+		used.UsedAt = oauth2Now.Add(-time.Minute)
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(used, nil)
+		m.refreshTokens.EXPECT().RevokeFamily(mock.Anything, "family-id").Return([]oauth2.RefreshToken{
+			{ID: "next-id", AccessTokenID: "jti-live", AccessTokenExpiresAt: oauth2Now.Add(time.Minute)},
+		}, nil)
+		m.tokenService.EXPECT().Revoke(mock.Anything, "jti-live", oauth2Now.Add(time.Minute)).Return(nil)
+
+		_, err := s.RefreshSessionToken(context.Background(), "the-refresh-token")
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidGrant, oauthErr.Code)
+	})
+
+	t.Run("should reject the refresh tokens of clients", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		clientToken := current
+		clientToken.ClientID = "client-id"
+		m.refreshTokens.EXPECT().GetByHash(mock.Anything, mock.Anything).Return(clientToken, nil)
+
+		_, err := s.RefreshSessionToken(context.Background(), "the-refresh-token")
+		var oauthErr *oauth2.Error
+		assert.True(t, errors.As(err, &oauthErr))
+		assert.Equal(t, oauth2.ErrorCodeInvalidGrant, oauthErr.Code)
+	})
+}
+
+func TestService_RevokeSession(t *testing.T) {
+	t.Run("should deny the access tokens issued in the session", func(t *testing.T) {
+		s, m := newOAuth2Service(t)
+		sessionID := uuid.New()
+		m.refreshTokens.EXPECT().RevokeSession(mock.Anything, sessionID.String()).Return([]oauth2.RefreshToken{
+			{ID: "live-id", AccessTokenID: "jti-live", AccessTokenExpiresAt: oauth2Now.Add(time.Minute)},
+			{ID: "old-id", AccessTokenID: "jti-expired", AccessTokenExpiresAt: oauth2Now.Add(-time.Minute)},
+		}, nil)
+		m.tokenService.EXPECT().Revoke(mock.Anything, "jti-live", oauth2Now.Add(time.Minute)).Return(nil)
+
+		assert.NoError(t, s.RevokeSession(context.Background(), sessionID))
+	})
+}
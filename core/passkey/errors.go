@@ -0,0 +1,11 @@
+package passkey
+
+import "errors"
+
+var (
+	ErrNotExist        = errors.New("passkey doesn't exist")
+	ErrConflict        = errors.New("passkey is already registered")
+	ErrInvalidNickname = errors.New("nickname must be between 1 and 64 characters")
+	ErrNotRegistered   = errors.New("no passkey is registered for the user, log in with another method to add one")
+	ErrCloned          = errors.New("passkey sign count went backwards, the authenticator may be cloned")
+)
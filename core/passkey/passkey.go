@@ -0,0 +1,97 @@
+package passkey
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, passkey Passkey) (Passkey, error)
+	Get(ctx context.Context, id string) (Passkey, error)
+	ListByUser(ctx context.Context, userID string) ([]Passkey, error)
+	UpdateNickname(ctx context.Context, id, nickname string) (Passkey, error)
+	UpdateUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error
+	Delete(ctx context.Context, id string) error
+}
+
+// Passkey is a webauthn credential registered by a user
+type Passkey struct {
+	ID     string
+	UserID string
+
+	CredentialID    []byte
+	PublicKey       []byte
+	AttestationType string
+	Transports      []string
+	// AAGUID identifies the model of the authenticator, it is zero for
+	// authenticators which don't disclose it
+	AAGUID uuid.UUID
+	// SignCount is the signature counter of the last login, a counter which
+	// doesn't increase signals a cloned authenticator
+	SignCount      uint32
+	BackupEligible bool
+	BackupState    bool
+
+	Nickname   string
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// FromCredential builds the passkey of a credential created by a registration
+func FromCredential(userID string, credential webauthn.Credential) Passkey {
+	aaguid, err := uuid.FromBytes(credential.Authenticator.AAGUID)
+	if err != nil {
+		aaguid = uuid.Nil
+	}
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+	return Passkey{
+		UserID:          userID,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      transports,
+		AAGUID:          aaguid,
+		SignCount:       credential.Authenticator.SignCount,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}
+}
+
+// Credential returns the webauthn credential to verify logins with
+func (p Passkey) Credential() webauthn.Credential {
+	transports := make([]protocol.AuthenticatorTransport, 0, len(p.Transports))
+	for _, transport := range p.Transports {
+		transports = append(transports, protocol.AuthenticatorTransport(transport))
+	}
+	return webauthn.Credential{
+		ID:              p.CredentialID,
+		PublicKey:       p.PublicKey,
+		AttestationType: p.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			BackupEligible: p.BackupEligible,
+			BackupState:    p.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    p.AAGUID[:],
+			SignCount: p.SignCount,
+		},
+	}
+}
+
+// Credentials returns the webauthn credentials of the passkeys
+func Credentials(passkeys []Passkey) []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(passkeys))
+	for _, p := range passkeys {
+		credentials = append(credentials, p.Credential())
+	}
+	return credentials
+}
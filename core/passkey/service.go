@@ -0,0 +1,79 @@
+package passkey
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+const maxNicknameLength = 64
+
+// Service lets users manage the passkeys they registered, the webauthn
+// ceremonies creating and using them are handled by the authenticate service
+type Service struct {
+	repository Repository
+}
+
+func NewService(repository Repository) *Service {
+	return &Service{
+		repository: repository,
+	}
+}
+
+func (s Service) List(ctx context.Context, userID string) ([]Passkey, error) {
+	return s.repository.ListByUser(ctx, userID)
+}
+
+// Rename sets the nickname users tell their passkeys apart with
+func (s Service) Rename(ctx context.Context, userID, id, nickname string) (Passkey, error) {
+	nickname, err := CleanNickname(nickname)
+	if err != nil {
+		return Passkey{}, err
+	}
+	if _, err := s.get(ctx, userID, id); err != nil {
+		return Passkey{}, err
+	}
+	return s.repository.UpdateNickname(ctx, id, nickname)
+}
+
+// Delete removes a passkey of the user, like the one of a lost device
+func (s Service) Delete(ctx context.Context, userID, id string) error {
+	passkey, err := s.get(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if err = s.repository.Delete(ctx, id); err != nil {
+		return err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		LogWithAttrs(audit.UserPasskeyDeletedEvent, audit.UserTarget(userID), map[string]string{
+			"passkey_id": passkey.ID,
+			"nickname":   passkey.Nickname,
+		})
+	return nil
+}
+
+// get returns the passkey if it belongs to the user, passkeys of other
+// users don't exist for the caller
+func (s Service) get(ctx context.Context, userID, id string) (Passkey, error) {
+	passkey, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return Passkey{}, err
+	}
+	if passkey.UserID != userID {
+		return Passkey{}, ErrNotExist
+	}
+	return passkey, nil
+}
+
+// CleanNickname trims the nickname and checks its length
+func CleanNickname(nickname string) (string, error) {
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" || utf8.RuneCountInString(nickname) > maxNicknameLength {
+		return "", ErrInvalidNickname
+	}
+	return nickname, nil
+}
@@ -0,0 +1,96 @@
+package passkey_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/passkey"
+	"github.com/raystack/frontier/core/passkey/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestService_Rename(t *testing.T) {
+	userID := uuid.NewString()
+	owned := passkey.Passkey{ID: uuid.NewString(), UserID: userID, Nickname: "laptop"}
+
+	tests := []struct {
+		name     string
+		userID   string
+		nickname string
+		setup    func(repo *mocks.Repository)
+		want     passkey.Passkey
+		wantErr  error
+	}{
+		{
+			name:     "should rename a passkey of the user",
+			userID:   userID,
+			nickname: "  work laptop ",
+			setup: func(repo *mocks.Repository) {
+				repo.EXPECT().Get(mock.Anything, owned.ID).Return(owned, nil)
+				repo.EXPECT().UpdateNickname(mock.Anything, owned.ID, "work laptop").
+					Return(passkey.Passkey{ID: owned.ID, UserID: userID, Nickname: "work laptop"}, nil)
+			},
+			want: passkey.Passkey{ID: owned.ID, UserID: userID, Nickname: "work laptop"},
+		},
+		{
+			name:     "should reject blank nicknames",
+			userID:   userID,
+			nickname: "   ",
+			wantErr:  passkey.ErrInvalidNickname,
+		},
+		{
+			name:     "should reject long nicknames",
+			userID:   userID,
+			nickname: strings.Repeat("a", 65),
+			wantErr:  passkey.ErrInvalidNickname,
+		},
+		{
+			name:     "should not rename passkeys of other users",
+			userID:   uuid.NewString(),
+			nickname: "mine now",
+			setup: func(repo *mocks.Repository) {
+				repo.EXPECT().Get(mock.Anything, owned.ID).Return(owned, nil)
+			},
+			wantErr: passkey.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewRepository(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			got, err := passkey.NewService(repo).Rename(context.Background(), tt.userID, owned.ID, tt.nickname)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	userID := uuid.NewString()
+	owned := passkey.Passkey{ID: uuid.NewString(), UserID: userID}
+
+	t.Run("should delete a passkey of the user", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		repo.EXPECT().Get(mock.Anything, owned.ID).Return(owned, nil)
+		repo.EXPECT().Delete(mock.Anything, owned.ID).Return(nil)
+
+		assert.NoError(t, passkey.NewService(repo).Delete(context.Background(), userID, owned.ID))
+	})
+
+	t.Run("should not delete passkeys of other users", func(t *testing.T) {
+		repo := mocks.NewRepository(t)
+		repo.EXPECT().Get(mock.Anything, owned.ID).Return(owned, nil)
+
+		err := passkey.NewService(repo).Delete(context.Background(), uuid.NewString(), owned.ID)
+		assert.ErrorIs(t, err, passkey.ErrNotExist)
+	})
+}
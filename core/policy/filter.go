@@ -1,5 +1,7 @@
 package policy
 
+import "time"
+
 type Filter struct {
 	OrgID     string
 	ProjectID string
@@ -11,4 +13,11 @@ type Filter struct {
 	PrincipalID   string
 	PrincipalIDs  []string
 	ResourceType  string
+
+	// ExpiresBefore keeps policies expiring before the time, set to now it
+	// lists the expired ones
+	ExpiresBefore time.Time
+	// ExpiresAfter keeps policies expiring after the time, combined with
+	// ExpiresBefore it lists the ones expiring soon
+	ExpiresAfter time.Time
 }
@@ -26,11 +26,25 @@ type Policy struct {
 	PrincipalID   string `json:"principal_id"`
 	PrincipalType string `json:"principal_type"`
 	Metadata      metadata.Metadata
+	// StartsAt and ExpiresAt limit the role binding to a time window, the
+	// zero value leaves that side open
+	StartsAt  time.Time `json:"starts_at"`
+	ExpiresAt time.Time `json:"expires_at"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// IsTimeBound tells if the policy only grants its role within a time window
+func (p Policy) IsTimeBound() bool {
+	return !p.StartsAt.IsZero() || !p.ExpiresAt.IsZero()
+}
+
+// IsExpired tells if the policy stopped granting its role
+func (p Policy) IsExpired(now time.Time) bool {
+	return !p.ExpiresAt.IsZero() && !now.Before(p.ExpiresAt)
+}
+
 type Filters struct {
 	UserID  string
 	GroupID string
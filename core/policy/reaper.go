@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"context"
+	"sync"
+
+	"github.com/raystack/salt/log"
+	"github.com/robfig/cron/v3"
+)
+
+// reapSchedule is how often expired policies are deleted, checks already
+// deny them once expired so this only keeps listings and the authz engine clean
+const reapSchedule = "@every 1m"
+
+// Reaper deletes expired policies in the background
+type Reaper struct {
+	log     log.Logger
+	service *Service
+
+	mu  sync.Mutex
+	job *cron.Cron
+}
+
+func NewReaper(logger log.Logger, service *Service) *Reaper {
+	return &Reaper{
+		log:     logger,
+		service: service,
+	}
+}
+
+// Init schedules the job deleting expired policies
+func (r *Reaper) Init(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.job != nil {
+		r.job.Stop()
+	}
+	r.job = cron.New(cron.WithChain(
+		cron.SkipIfStillRunning(cron.DefaultLogger),
+		cron.Recover(cron.DefaultLogger),
+	))
+	if _, err := r.job.AddFunc(reapSchedule, func() {
+		count, err := r.service.DeleteExpired(ctx)
+		if err != nil {
+			r.log.Warn("deleting expired policies failed", "err", err)
+		}
+		if count > 0 {
+			r.log.Info("deleted expired policies", "count", count)
+		}
+	}); err != nil {
+		return err
+	}
+	r.job.Start()
+	return nil
+}
+
+// Close stops the job
+func (r *Reaper) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.job == nil {
+		return nil
+	}
+	return r.job.Stop().Err()
+}
@@ -2,7 +2,10 @@ package policy
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/raystack/frontier/core/audit"
 	"github.com/raystack/frontier/pkg/utils"
 
 	"github.com/raystack/frontier/core/role"
@@ -14,6 +17,7 @@ import (
 type RelationService interface {
 	Create(ctx context.Context, rel relation.Relation) (relation.Relation, error)
 	Delete(ctx context.Context, rel relation.Relation) error
+	ListRelations(ctx context.Context, rel relation.Relation) ([]relation.Relation, error)
 }
 
 type RoleService interface {
@@ -25,6 +29,7 @@ type Service struct {
 	repository      Repository
 	relationService RelationService
 	roleService     RoleService
+	Now             func() time.Time
 }
 
 func NewService(repository Repository, relationService RelationService, roleService RoleService) *Service {
@@ -32,6 +37,9 @@ func NewService(repository Repository, relationService RelationService, roleServ
 		repository:      repository,
 		relationService: relationService,
 		roleService:     roleService,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
 	}
 }
 
@@ -48,6 +56,15 @@ func (s Service) Count(ctx context.Context, f Filter) (int64, error) {
 }
 
 func (s Service) Create(ctx context.Context, policy Policy) (Policy, error) {
+	if !policy.ExpiresAt.IsZero() {
+		if !policy.ExpiresAt.After(policy.StartsAt) {
+			return Policy{}, fmt.Errorf("%w: policy must expire after it starts", ErrInvalidDetail)
+		}
+		if policy.IsExpired(s.Now()) {
+			return Policy{}, fmt.Errorf("%w: policy is already expired", ErrInvalidDetail)
+		}
+	}
+
 	// check if role exists and get its ID if it was passed by name
 	policyRole, err := s.roleService.Get(ctx, policy.RoleID)
 	if err != nil {
@@ -81,57 +98,155 @@ func (s Service) Delete(ctx context.Context, id string) error {
 // AssignRole Note: ideally this should be in a single transaction
 // read more about how user defined roles work in spicedb https://authzed.com/blog/user-defined-roles
 func (s Service) AssignRole(ctx context.Context, pol Policy) error {
+	for _, rel := range pol.Relations() {
+		if _, err := s.relationService.Create(ctx, rel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Relations are the relations binding the role of the policy to its
+// principal on its resource, the role binding is named by the policy id
+func (pol Policy) Relations() []relation.Relation {
 	// bind role with user
 	subjectSubRelation := ""
 	if pol.PrincipalType == schema.GroupPrincipal {
 		subjectSubRelation = schema.MemberRelationName
 	}
-	_, err := s.relationService.Create(ctx, relation.Relation{
-		Object: relation.Object{
-			ID:        pol.ID,
-			Namespace: schema.RoleBindingNamespace,
+	return []relation.Relation{
+		{
+			Object: relation.Object{
+				ID:        pol.ID,
+				Namespace: schema.RoleBindingNamespace,
+			},
+			Subject: relation.Subject{
+				ID:              pol.PrincipalID,
+				Namespace:       pol.PrincipalType,
+				SubRelationName: subjectSubRelation,
+			},
+			RelationName: schema.RoleBearerRelationName,
+			Caveat:       validWindow(pol),
 		},
-		Subject: relation.Subject{
-			ID:              pol.PrincipalID,
-			Namespace:       pol.PrincipalType,
-			SubRelationName: subjectSubRelation,
+		{
+			Object: relation.Object{
+				ID:        pol.ID,
+				Namespace: schema.RoleBindingNamespace,
+			},
+			Subject: relation.Subject{
+				ID:        pol.RoleID,
+				Namespace: schema.RoleNamespace,
+			},
+			RelationName: schema.RoleRelationName,
 		},
-		RelationName: schema.RoleBearerRelationName,
-	})
-	if err != nil {
-		return err
-	}
-	_, err = s.relationService.Create(ctx, relation.Relation{
-		Object: relation.Object{
-			ID:        pol.ID,
-			Namespace: schema.RoleBindingNamespace,
-		},
-		Subject: relation.Subject{
-			ID:        pol.RoleID,
-			Namespace: schema.RoleNamespace,
+		// bind policy to resource
+		{
+			Object: relation.Object{
+				ID:        pol.ResourceID,
+				Namespace: pol.ResourceType,
+			},
+			Subject: relation.Subject{
+				ID:        pol.ID,
+				Namespace: schema.RoleBindingNamespace,
+			},
+			RelationName: schema.RoleGrantRelationName,
 		},
-		RelationName: schema.RoleRelationName,
-	})
-	if err != nil {
-		return err
 	}
+}
 
-	// bind policy to resource
-	_, err = s.relationService.Create(ctx, relation.Relation{
-		Object: relation.Object{
-			ID:        pol.ResourceID,
-			Namespace: pol.ResourceType,
-		},
-		Subject: relation.Subject{
-			ID:        pol.ID,
-			Namespace: schema.RoleBindingNamespace,
+// validWindow conditions the role binding of a time bound policy so checks
+// fail outside of its window, even before expired policies are deleted
+func validWindow(pol Policy) *relation.Caveat {
+	if !pol.IsTimeBound() {
+		return nil
+	}
+	startsAt, expiresAt := pol.StartsAt, pol.ExpiresAt
+	if startsAt.IsZero() {
+		startsAt = time.Unix(0, 0)
+	}
+	if expiresAt.IsZero() {
+		expiresAt = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	}
+	return &relation.Caveat{
+		Name: schema.ValidWindowCaveatName,
+		Context: map[string]any{
+			"starts_at":  startsAt.UTC().Format(time.RFC3339),
+			"expires_at": expiresAt.UTC().Format(time.RFC3339),
 		},
-		RelationName: schema.RoleGrantRelationName,
+	}
+}
+
+// DeleteExpired deletes the policies past their expiry along with their
+// role bindings and returns how many were deleted
+func (s Service) DeleteExpired(ctx context.Context) (int, error) {
+	expired, err := s.repository.List(ctx, Filter{
+		ExpiresBefore: s.Now(),
 	})
 	if err != nil {
-		return err
+		return 0, err
 	}
-	return nil
+	for idx, pol := range expired {
+		// policies of resources no org owns anymore are logged on the platform org
+		orgID, err := s.OrgID(ctx, pol)
+		if err != nil {
+			orgID = schema.PlatformOrgID.String()
+		}
+		if err := s.Delete(ctx, pol.ID); err != nil {
+			return idx, fmt.Errorf("policy %s: %w", pol.ID, err)
+		}
+		_ = audit.GetAuditor(ctx, orgID).
+			LogWithAttrs(audit.PolicyExpiredEvent, audit.Target{
+				ID:   pol.ResourceID,
+				Type: pol.ResourceType,
+			}, map[string]string{
+				"policy_id":      pol.ID,
+				"role_id":        pol.RoleID,
+				"principal_id":   pol.PrincipalID,
+				"principal_type": pol.PrincipalType,
+				"expires_at":     pol.ExpiresAt.Format(time.RFC3339),
+			})
+	}
+	return len(expired), nil
+}
+
+// OrgID returns the organization owning the resource of the policy by
+// following the project and org relations of the resource
+func (s Service) OrgID(ctx context.Context, pol Policy) (string, error) {
+	namespace, id := pol.ResourceType, pol.ResourceID
+	// a resource belongs to a project which belongs to an org
+	for hops := 0; hops < 3; hops++ {
+		switch namespace {
+		case schema.OrganizationNamespace:
+			return id, nil
+		case schema.PlatformNamespace:
+			return schema.PlatformOrgID.String(), nil
+		}
+		parent := relation.Relation{
+			Object: relation.Object{
+				ID:        id,
+				Namespace: namespace,
+			},
+			Subject: relation.Subject{
+				Namespace: schema.ProjectNamespace,
+			},
+			RelationName: schema.ProjectRelationName,
+		}
+		if namespace == schema.ProjectNamespace || namespace == schema.GroupNamespace {
+			parent.Subject.Namespace = schema.OrganizationNamespace
+			parent.RelationName = schema.OrganizationRelationName
+		}
+		rels, err := s.relationService.ListRelations(ctx, parent)
+		if err != nil {
+			return "", err
+		}
+		if len(rels) == 0 {
+			return "", fmt.Errorf("%w: no organization owns %s", ErrNotExist,
+				schema.JoinNamespaceAndResourceID(pol.ResourceType, pol.ResourceID))
+		}
+		namespace, id = rels[0].Subject.Namespace, rels[0].Subject.ID
+	}
+	return "", fmt.Errorf("%w: no organization owns %s", ErrNotExist,
+		schema.JoinNamespaceAndResourceID(pol.ResourceType, pol.ResourceID))
 }
 
 // ListRoles lists roles assigned via policies to a user
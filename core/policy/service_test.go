@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
 	"github.com/raystack/frontier/core/policy"
 	"github.com/raystack/frontier/core/policy/mocks"
 	"github.com/raystack/frontier/core/relation"
 	"github.com/raystack/frontier/core/role"
 	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/mock"
 )
 
 func mockService(t *testing.T) (*mocks.Repository, *mocks.RoleService, *mocks.RelationService) {
@@ -330,3 +334,229 @@ func TestService_ListRoles(t *testing.T) {
 		})
 	}
 }
+
+func TestService_CreateTimeBound(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("reject a policy expiring before it starts", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		s := policy.NewService(repo, relationService, roleService)
+		s.Now = func() time.Time { return now }
+		_, err := s.Create(ctx, policy.Policy{
+			RoleID:    "role-id",
+			StartsAt:  now.Add(2 * time.Hour),
+			ExpiresAt: now.Add(time.Hour),
+		})
+		if !errors.Is(err, policy.ErrInvalidDetail) {
+			t.Errorf("Create() error = %v, want %v", err, policy.ErrInvalidDetail)
+		}
+	})
+
+	t.Run("reject an already expired policy", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		s := policy.NewService(repo, relationService, roleService)
+		s.Now = func() time.Time { return now }
+		_, err := s.Create(ctx, policy.Policy{
+			RoleID:    "role-id",
+			ExpiresAt: now.Add(-time.Hour),
+		})
+		if !errors.Is(err, policy.ErrInvalidDetail) {
+			t.Errorf("Create() error = %v, want %v", err, policy.ErrInvalidDetail)
+		}
+	})
+
+	t.Run("condition the role binding with the validity window", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		pol := policy.Policy{
+			ID:            "policy-id",
+			RoleID:        "role-id",
+			ResourceID:    "resource-id",
+			ResourceType:  schema.ProjectNamespace,
+			PrincipalID:   "user-id",
+			PrincipalType: schema.UserPrincipal,
+			ExpiresAt:     now.Add(time.Hour),
+		}
+		roleService.On("Get", ctx, "role-id").Return(role.Role{ID: "role-id"}, nil)
+		repo.On("Upsert", ctx, pol).Return(pol, nil)
+		relationService.On("Create", ctx, relation.Relation{
+			Object: relation.Object{
+				ID:        "policy-id",
+				Namespace: schema.RoleBindingNamespace,
+			},
+			Subject: relation.Subject{
+				ID:        "user-id",
+				Namespace: schema.UserPrincipal,
+			},
+			RelationName: schema.RoleBearerRelationName,
+			Caveat: &relation.Caveat{
+				Name: schema.ValidWindowCaveatName,
+				Context: map[string]any{
+					"starts_at":  "1970-01-01T00:00:00Z",
+					"expires_at": "2026-10-18T13:00:00Z",
+				},
+			},
+		}).Return(relation.Relation{}, nil)
+		relationService.On("Create", ctx, relation.Relation{
+			Object: relation.Object{
+				ID:        "policy-id",
+				Namespace: schema.RoleBindingNamespace,
+			},
+			Subject: relation.Subject{
+				ID:        "role-id",
+				Namespace: schema.RoleNamespace,
+			},
+			RelationName: schema.RoleRelationName,
+		}).Return(relation.Relation{}, nil)
+		relationService.On("Create", ctx, relation.Relation{
+			Object: relation.Object{
+				ID:        "resource-id",
+				Namespace: schema.ProjectNamespace,
+			},
+			Subject: relation.Subject{
+				ID:        "policy-id",
+				Namespace: schema.RoleBindingNamespace,
+			},
+			RelationName: schema.RoleGrantRelationName,
+		}).Return(relation.Relation{}, nil)
+
+		s := policy.NewService(repo, relationService, roleService)
+		s.Now = func() time.Time { return now }
+		got, err := s.Create(ctx, pol)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if diff := cmp.Diff(pol, got); diff != "" {
+			t.Errorf("Create() mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestService_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("delete the expired policies with their role bindings", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		auditRepo := auditmocks.NewRepository(t)
+		ctx := audit.SetContextWithService(ctx, audit.NewService("frontier", auditRepo, audit.NewNoopWebhookService()))
+		repo.On("List", ctx, policy.Filter{ExpiresBefore: now}).Return([]policy.Policy{
+			{ID: "policy-1", ResourceID: "org-1", ResourceType: schema.OrganizationNamespace, ExpiresAt: now.Add(-time.Minute)},
+			{ID: "policy-2", ResourceID: "project-1", ResourceType: schema.ProjectNamespace, ExpiresAt: now.Add(-time.Hour)},
+		}, nil)
+		relationService.On("ListRelations", ctx, relation.Relation{
+			Object:       relation.Object{ID: "project-1", Namespace: schema.ProjectNamespace},
+			Subject:      relation.Subject{Namespace: schema.OrganizationNamespace},
+			RelationName: schema.OrganizationRelationName,
+		}).Return([]relation.Relation{{
+			Object:  relation.Object{ID: "project-1", Namespace: schema.ProjectNamespace},
+			Subject: relation.Subject{ID: "org-2", Namespace: schema.OrganizationNamespace},
+		}}, nil)
+		for _, id := range []string{"policy-1", "policy-2"} {
+			relationService.On("Delete", ctx, relation.Relation{
+				Object: relation.Object{
+					ID:        id,
+					Namespace: schema.RoleBindingNamespace,
+				},
+			}).Return(nil)
+			repo.On("Delete", ctx, id).Return(nil)
+		}
+		// the expiry is logged on the org owning the resource of each policy
+		for _, orgID := range []string{"org-1", "org-2"} {
+			auditRepo.EXPECT().Create(ctx, mock.MatchedBy(func(l *audit.Log) bool {
+				return l.OrgID == orgID && l.Action == audit.PolicyExpiredEvent.String()
+			})).Return(nil).Once()
+		}
+
+		s := policy.NewService(repo, relationService, roleService)
+		s.Now = func() time.Time { return now }
+		got, err := s.DeleteExpired(ctx)
+		if err != nil {
+			t.Fatalf("DeleteExpired() error = %v", err)
+		}
+		if got != 2 {
+			t.Errorf("DeleteExpired() = %d, want 2", got)
+		}
+	})
+
+	t.Run("stop at the first policy failing to be deleted", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		repo.On("List", ctx, policy.Filter{ExpiresBefore: now}).Return([]policy.Policy{
+			{ID: "policy-1", ResourceID: "org-1", ResourceType: schema.OrganizationNamespace, ExpiresAt: now.Add(-time.Minute)},
+			{ID: "policy-2", ResourceID: "org-1", ResourceType: schema.OrganizationNamespace, ExpiresAt: now.Add(-time.Hour)},
+		}, nil)
+		relationService.On("Delete", ctx, relation.Relation{
+			Object: relation.Object{
+				ID:        "policy-1",
+				Namespace: schema.RoleBindingNamespace,
+			},
+		}).Return(errors.New("relation delete failed"))
+
+		s := policy.NewService(repo, relationService, roleService)
+		s.Now = func() time.Time { return now }
+		got, err := s.DeleteExpired(ctx)
+		if err == nil {
+			t.Fatal("DeleteExpired() expected an error")
+		}
+		if got != 0 {
+			t.Errorf("DeleteExpired() = %d, want 0", got)
+		}
+	})
+}
+
+func TestService_OrgID(t *testing.T) {
+	ctx := context.Background()
+	resourceToProject := relation.Relation{
+		Object:       relation.Object{ID: "instance-1", Namespace: "compute/instance"},
+		Subject:      relation.Subject{Namespace: schema.ProjectNamespace},
+		RelationName: schema.ProjectRelationName,
+	}
+	projectToOrg := relation.Relation{
+		Object:       relation.Object{ID: "project-1", Namespace: schema.ProjectNamespace},
+		Subject:      relation.Subject{Namespace: schema.OrganizationNamespace},
+		RelationName: schema.OrganizationRelationName,
+	}
+
+	t.Run("resolve the org of a resource through its project", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		relationService.On("ListRelations", ctx, resourceToProject).Return([]relation.Relation{{
+			Subject: relation.Subject{ID: "project-1", Namespace: schema.ProjectNamespace},
+		}}, nil)
+		relationService.On("ListRelations", ctx, projectToOrg).Return([]relation.Relation{{
+			Subject: relation.Subject{ID: "org-1", Namespace: schema.OrganizationNamespace},
+		}}, nil)
+
+		s := policy.NewService(repo, relationService, roleService)
+		got, err := s.OrgID(ctx, policy.Policy{ResourceID: "instance-1", ResourceType: "compute/instance"})
+		if err != nil {
+			t.Fatalf("OrgID() error = %v", err)
+		}
+		if got != "org-1" {
+			t.Errorf("OrgID() = %s, want org-1", got)
+		}
+	})
+
+	t.Run("return the platform org for platform policies", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+
+		s := policy.NewService(repo, relationService, roleService)
+		got, err := s.OrgID(ctx, policy.Policy{ResourceID: schema.PlatformID, ResourceType: schema.PlatformNamespace})
+		if err != nil {
+			t.Fatalf("OrgID() error = %v", err)
+		}
+		if got != schema.PlatformOrgID.String() {
+			t.Errorf("OrgID() = %s, want the platform org", got)
+		}
+	})
+
+	t.Run("fail when no org owns the resource", func(t *testing.T) {
+		repo, roleService, relationService := mockService(t)
+		relationService.On("ListRelations", ctx, projectToOrg).Return(nil, nil)
+
+		s := policy.NewService(repo, relationService, roleService)
+		_, err := s.OrgID(ctx, policy.Policy{ResourceID: "project-1", ResourceType: schema.ProjectNamespace})
+		if !errors.Is(err, policy.ErrNotExist) {
+			t.Errorf("OrgID() error = %v, want %v", err, policy.ErrNotExist)
+		}
+	})
+}
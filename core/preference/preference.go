@@ -39,6 +39,13 @@ const (
 	OrganizationMailLink    = "mail_link"
 	OrganizationMailOTP     = "mail_otp"
 	OrganizationSocialLogin = "social_login"
+	OrganizationMFA         = "mfa"
+	// OrganizationDisableImpersonation stops superusers from impersonating
+	// the members of the organization
+	OrganizationDisableImpersonation = "disable_impersonation"
+	// OrganizationSSOOnly makes the emails of the verified domains of the
+	// organization log in with its identity provider only
+	OrganizationSSOOnly = "sso_only"
 
 	// user default traits
 	UserFirstName = "first_name"
@@ -176,4 +183,37 @@ var DefaultTraits = []Trait{
 		SubHeading:   "Manage organization security and how it's members authenticate.",
 		Input:        TraitInputCheckbox,
 	},
+	{
+		ResourceType: schema.OrganizationNamespace,
+		Name:         OrganizationMFA,
+		Title:        "Multi-factor authentication",
+		Description:  "Require members to verify a code of an authenticator app after logging in.",
+		Heading:      "Security",
+		SubHeading:   "Manage organization security and how it's members authenticate.",
+		Input:        TraitInputCheckbox,
+		InputHints:   "true,false",
+		Default:      "false",
+	},
+	{
+		ResourceType: schema.OrganizationNamespace,
+		Name:         OrganizationDisableImpersonation,
+		Title:        "Disable impersonation",
+		Description:  "Don't allow platform support staff to log in as members of the organization.",
+		Heading:      "Security",
+		SubHeading:   "Manage organization security and how it's members authenticate.",
+		Input:        TraitInputCheckbox,
+		InputHints:   "true,false",
+		Default:      "false",
+	},
+	{
+		ResourceType: schema.OrganizationNamespace,
+		Name:         OrganizationSSOOnly,
+		Title:        "Single sign-on only",
+		Description:  "Require emails of the verified domains to log in with the identity provider of the organization.",
+		Heading:      "Security",
+		SubHeading:   "Manage organization security and how it's members authenticate.",
+		Input:        TraitInputCheckbox,
+		InputHints:   "true,false",
+		Default:      "false",
+	},
 }
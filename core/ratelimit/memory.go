@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryWindow struct {
+	start     time.Time
+	window    time.Duration
+	current   int64
+	previous  int64
+	expiresAt time.Time
+}
+
+// MemoryStore keeps the windows in the memory of the process, limits aren't
+// shared between replicas of the server
+type MemoryStore struct {
+	mu      sync.Mutex
+	windows map[string]*memoryWindow
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		windows: map[string]*memoryWindow{},
+	}
+}
+
+func (m *MemoryStore) Incr(ctx context.Context, key string, windowStart time.Time, window time.Duration) (int64, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w, ok := m.windows[key]
+	switch {
+	case !ok || w.window != window:
+		w = &memoryWindow{start: windowStart, window: window}
+		m.windows[key] = w
+	case w.start.Equal(windowStart):
+	case w.start.Add(window).Equal(windowStart):
+		w.start, w.previous, w.current = windowStart, w.current, 0
+	default:
+		w.start, w.previous, w.current = windowStart, 0, 0
+	}
+	w.current++
+	w.expiresAt = windowStart.Add(2 * window)
+	return w.current, w.previous, nil
+}
+
+func (m *MemoryStore) DeleteExpired(ctx context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, w := range m.windows {
+		if !w.expiresAt.After(now) {
+			delete(m.windows, key)
+		}
+	}
+	return nil
+}
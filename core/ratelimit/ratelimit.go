@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/raystack/salt/log"
+	"github.com/robfig/cron/v3"
+)
+
+var ErrLimitExceeded = errors.New("rate limit exceeded")
+
+const cleanupTime = "*/10 * * * *" // every 10 minutes
+
+// Store counts the hits of keys in fixed windows
+type Store interface {
+	// Incr adds a hit to the window of the key starting at windowStart and
+	// returns the hits of this window and of the one before it. Windows are
+	// kept until the end of the window after them.
+	Incr(ctx context.Context, key string, windowStart time.Time, window time.Duration) (current int64, previous int64, err error)
+	// DeleteExpired removes windows which aren't used by limits anymore
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+// Limit allows Requests hits of a key in any Window
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Window <= 0
+}
+
+// LimitError is returned once a key exceeded its limit
+type LimitError struct {
+	Key string
+	// RetryAfter is the time until the key is allowed again
+	RetryAfter time.Duration
+}
+
+func (e LimitError) Error() string {
+	return fmt.Sprintf("%s, retry after %s", ErrLimitExceeded, e.RetryAfter)
+}
+
+func (e LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Limiter enforces limits over a sliding window. The hits of a key in the
+// last window are estimated from the fixed window in progress and the one
+// before it, weighted by how much of it still overlaps the sliding window.
+// Denied hits are counted as well, clients retrying early stay locked out.
+type Limiter struct {
+	log   log.Logger
+	store Store
+	cron  *cron.Cron
+	Now   func() time.Time
+}
+
+func NewLimiter(logger log.Logger, store Store) *Limiter {
+	return &Limiter{
+		log:   logger,
+		store: store,
+		cron:  cron.New(),
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Allow records a hit of the key and returns a LimitError if the key is over
+// its limit
+func (l *Limiter) Allow(ctx context.Context, key string, limit Limit) error {
+	if limit.IsZero() {
+		return nil
+	}
+	now := l.Now()
+	windowStart := now.Truncate(limit.Window)
+	current, previous, err := l.store.Incr(ctx, key, windowStart, limit.Window)
+	if err != nil {
+		return err
+	}
+
+	elapsed := now.Sub(windowStart)
+	overlap := 1 - float64(elapsed)/float64(limit.Window)
+	if float64(previous)*overlap+float64(current) <= float64(limit.Requests) {
+		return nil
+	}
+	return LimitError{
+		Key:        key,
+		RetryAfter: retryAfter(current, previous, limit, elapsed),
+	}
+}
+
+// retryAfter is the time until the next hit of the key is allowed, if no
+// other hits are made meanwhile
+func retryAfter(current, previous int64, limit Limit, elapsed time.Duration) time.Duration {
+	window := float64(limit.Window)
+	// room left for hits made before the next one
+	room := float64(limit.Requests - 1)
+	var wait float64
+	if float64(current) > room {
+		// the window in progress becomes the previous one, its weight has to
+		// decrease enough for the next hit to fit
+		wait = window - float64(elapsed) + window*(1-room/float64(current))
+	} else {
+		// the previous window has to slide out far enough for the next hit
+		wait = window*(1-(room-float64(current))/float64(previous)) - float64(elapsed)
+	}
+	// rounded to milliseconds first to not turn float errors into a second
+	retry := (time.Duration(wait).Round(time.Millisecond) + time.Second - 1).Truncate(time.Second)
+	if retry < time.Second {
+		retry = time.Second
+	}
+	return retry
+}
+
+// Init starts removing expired windows from the store periodically
+func (l *Limiter) Init(ctx context.Context) error {
+	_, err := l.cron.AddFunc(cleanupTime, func() {
+		if err := l.store.DeleteExpired(ctx, l.Now()); err != nil {
+			l.log.Warn("failed to delete expired rate limit windows", "err", err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	l.cron.Start()
+	return nil
+}
+
+func (l *Limiter) Close() error {
+	return l.cron.Stop().Err()
+}
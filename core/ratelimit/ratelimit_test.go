@@ -0,0 +1,110 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/ratelimit"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(now *time.Time) *ratelimit.Limiter {
+	l := ratelimit.NewLimiter(log.NewNoop(), ratelimit.NewMemoryStore())
+	l.Now = func() time.Time {
+		return *now
+	}
+	return l
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limit := ratelimit.Limit{Requests: 3, Window: time.Minute}
+	windowStart := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("should allow hits within the limit and deny the rest", func(t *testing.T) {
+		now := windowStart.Add(10 * time.Second)
+		l := newTestLimiter(&now)
+		for i := 0; i < limit.Requests; i++ {
+			require.NoError(t, l.Allow(ctx, "key", limit))
+		}
+
+		err := l.Allow(ctx, "key", limit)
+		assert.ErrorIs(t, err, ratelimit.ErrLimitExceeded)
+		var limitErr ratelimit.LimitError
+		require.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, "key", limitErr.Key)
+		// 4 hits in the window in progress, the next one fits once they weigh
+		// less than 2 in the previous window: 50s + 30s
+		assert.Equal(t, 80*time.Second, limitErr.RetryAfter)
+
+		// other keys aren't affected
+		assert.NoError(t, l.Allow(ctx, "other", limit))
+	})
+
+	t.Run("should weigh hits of the previous window by its overlap", func(t *testing.T) {
+		now := windowStart.Add(50 * time.Second)
+		l := newTestLimiter(&now)
+		for i := 0; i < limit.Requests; i++ {
+			require.NoError(t, l.Allow(ctx, "key", limit))
+		}
+
+		// 3 hits weigh 2.25 a quarter into the next window
+		now = windowStart.Add(75 * time.Second)
+		err := l.Allow(ctx, "key", limit)
+		var limitErr ratelimit.LimitError
+		require.True(t, errors.As(err, &limitErr))
+		// the previous window has to weigh at most 1: 40s - 15s
+		assert.Equal(t, 25*time.Second, limitErr.RetryAfter)
+
+		// 3 hits weigh 1 two thirds into the next window, leaving room for
+		// the denied hit and this one
+		now = windowStart.Add(100 * time.Second)
+		assert.NoError(t, l.Allow(ctx, "key", limit))
+	})
+
+	t.Run("should forget hits older than the window", func(t *testing.T) {
+		now := windowStart
+		l := newTestLimiter(&now)
+		for i := 0; i < limit.Requests; i++ {
+			require.NoError(t, l.Allow(ctx, "key", limit))
+		}
+		require.Error(t, l.Allow(ctx, "key", limit))
+
+		now = windowStart.Add(2 * time.Minute)
+		assert.NoError(t, l.Allow(ctx, "key", limit))
+	})
+
+	t.Run("should not limit zero limits", func(t *testing.T) {
+		now := windowStart
+		l := newTestLimiter(&now)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, l.Allow(ctx, "key", ratelimit.Limit{Window: time.Minute}))
+		}
+	})
+}
+
+func TestMemoryStore_DeleteExpired(t *testing.T) {
+	ctx := context.Background()
+	store := ratelimit.NewMemoryStore()
+	windowStart := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	_, _, err := store.Incr(ctx, "key", windowStart, time.Minute)
+	require.NoError(t, err)
+
+	// the window is still used as the previous one by the next window
+	require.NoError(t, store.DeleteExpired(ctx, windowStart.Add(90*time.Second)))
+	current, previous, err := store.Incr(ctx, "key", windowStart.Add(time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), current)
+	assert.Equal(t, int64(1), previous)
+
+	require.NoError(t, store.DeleteExpired(ctx, windowStart.Add(3*time.Minute)))
+	current, previous, err = store.Incr(ctx, "key", windowStart.Add(3*time.Minute), time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), current)
+	assert.Equal(t, int64(0), previous)
+}
@@ -15,6 +15,7 @@ type Repository interface {
 
 type AuthzRepository interface {
 	Check(ctx context.Context, rel Relation) (bool, error)
+	CheckWithTrace(ctx context.Context, rel Relation) (bool, CheckTrace, error)
 	BatchCheck(ctx context.Context, relations []Relation) ([]CheckPair, error)
 	Delete(ctx context.Context, rel Relation) error
 	Add(ctx context.Context, rel Relation) error
@@ -28,6 +29,30 @@ type CheckPair struct {
 	Status   bool
 }
 
+// TraceResult is the outcome of a step of a traced check
+type TraceResult string
+
+const (
+	TraceResultUnspecified TraceResult = "unspecified"
+	TraceResultAllowed     TraceResult = "allowed"
+	TraceResultDenied      TraceResult = "denied"
+	TraceResultConditional TraceResult = "conditional"
+)
+
+// CheckTrace is a step the authz engine resolved a check through, it checked
+// if the subject has the relation or permission named RelationName on the
+// object. Children are the steps it depended on, they are missing if its
+// result was cached.
+type CheckTrace struct {
+	Object       Object
+	Subject      Subject
+	RelationName string
+	IsPermission bool
+	Result       TraceResult
+	Cached       bool
+	Children     []CheckTrace
+}
+
 type Object struct {
 	ID        string
 	Namespace string
@@ -39,11 +64,20 @@ type Subject struct {
 	SubRelationName string `json:"subject_sub_relation"`
 }
 
+// Caveat conditions a relation, it only holds while the caveat expression
+// evaluates to true for its context
+type Caveat struct {
+	Name    string
+	Context map[string]any
+}
+
 type Relation struct {
 	ID           string
 	Object       Object
 	Subject      Subject
 	RelationName string `json:"relation_name"`
+	// Caveat is only kept in the authz engine
+	Caveat *Caveat `json:"-"`
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
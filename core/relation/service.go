@@ -33,6 +33,7 @@ func (s Service) Create(ctx context.Context, rel Relation) (Relation, error) {
 		return Relation{}, fmt.Errorf("%w: %s", ErrCreatingRelationInStore, err.Error())
 	}
 
+	createdRelation.Caveat = rel.Caveat
 	err = s.authzRepository.Add(ctx, createdRelation)
 	if err != nil {
 		return Relation{}, fmt.Errorf("%w: %s", ErrCreatingRelationInAuthzEngine, err.Error())
@@ -70,6 +71,12 @@ func (s Service) CheckPermission(ctx context.Context, rel Relation) (bool, error
 	return s.authzRepository.Check(ctx, rel)
 }
 
+// CheckPermissionWithTrace checks the permission and returns how the authz
+// engine resolved it, it is slower than CheckPermission
+func (s Service) CheckPermissionWithTrace(ctx context.Context, rel Relation) (bool, CheckTrace, error) {
+	return s.authzRepository.CheckWithTrace(ctx, rel)
+}
+
 func (s Service) BatchCheckPermission(ctx context.Context, relations []Relation) ([]CheckPair, error) {
 	return s.authzRepository.BatchCheck(ctx, relations)
 }
@@ -58,45 +58,39 @@ func (s Service) Upsert(ctx context.Context, toCreate Role) (Role, error) {
 }
 
 func (s Service) createRolePermissionRelation(ctx context.Context, roleID string, permissions []string) error {
-	// create relation between role and permissions
-	// for example for each permission:
-	// app/role:org_owner#organization_delete@app/user:*
-	// app/role:org_owner#organization_update@app/user:*
-	// this needs to be created for each type of principles
-	for _, perm := range permissions {
-		_, err := s.relationService.Create(ctx, relation.Relation{
-			Object: relation.Object{
-				ID:        roleID,
-				Namespace: schema.RoleNamespace,
-			},
-			Subject: relation.Subject{
-				ID:        "*", // all principles who have role will have access
-				Namespace: schema.UserPrincipal,
-			},
-			RelationName: perm,
-		})
-		if err != nil {
-			return err
-		}
-		// do the same with service user
-		_, err = s.relationService.Create(ctx, relation.Relation{
-			Object: relation.Object{
-				ID:        roleID,
-				Namespace: schema.RoleNamespace,
-			},
-			Subject: relation.Subject{
-				ID:        "*", // all principles who have role will have access
-				Namespace: schema.ServiceUserPrincipal,
-			},
-			RelationName: perm,
-		})
-		if err != nil {
+	for _, rel := range PermissionRelations(roleID, permissions) {
+		if _, err := s.relationService.Create(ctx, rel); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// PermissionRelations are the relations between a role and its permissions
+// for example for each permission:
+// app/role:org_owner#organization_delete@app/user:*
+// app/role:org_owner#organization_update@app/user:*
+// they are created for each type of principal
+func PermissionRelations(roleID string, permissions []string) []relation.Relation {
+	relations := make([]relation.Relation, 0, 2*len(permissions))
+	for _, perm := range permissions {
+		for _, principalType := range []string{schema.UserPrincipal, schema.ServiceUserPrincipal} {
+			relations = append(relations, relation.Relation{
+				Object: relation.Object{
+					ID:        roleID,
+					Namespace: schema.RoleNamespace,
+				},
+				Subject: relation.Subject{
+					ID:        "*", // all principles who have role will have access
+					Namespace: principalType,
+				},
+				RelationName: perm,
+			})
+		}
+	}
+	return relations
+}
+
 func (s Service) deleteRolePermissionRelations(ctx context.Context, roleID string) error {
 	// delete relation between role and permissions
 	// for example for each permission:
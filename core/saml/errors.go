@@ -0,0 +1,15 @@
+package saml
+
+import "errors"
+
+var (
+	ErrNotExist         = errors.New("saml connection doesn't exist")
+	ErrConflict         = errors.New("organization already has a saml connection")
+	ErrInvalidDetail    = errors.New("invalid saml connection details")
+	ErrNotConfigured    = errors.New("saml url is not configured")
+	ErrInvalidFlow      = errors.New("invalid saml login or expired")
+	ErrMissingEmail     = errors.New("saml assertion doesn't carry the user email")
+	ErrDomainNotAllowed = errors.New("user email domain is not verified for the organization")
+	ErrUserDisabled     = errors.New("user is disabled")
+	ErrAssertionReplay  = errors.New("saml assertion has already been used")
+)
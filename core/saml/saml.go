@@ -0,0 +1,66 @@
+package saml
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// paths of the service provider endpoints, relative to the configured url
+	MetadataPath = "/saml/%s/metadata"
+	LoginPath    = "/saml/%s/login"
+	ACSPath      = "/saml/%s/acs"
+)
+
+// attributes looked up when a connection doesn't map them, as released by
+// common identity providers
+var (
+	defaultEmailAttributes = []string{"email", "mail", "emailaddress",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+		"urn:oid:0.9.2342.19200300.100.1.3"}
+	defaultNameAttributes = []string{"displayName", "name",
+		"http://schemas.xmlsoap.org/ws/2005/05/identity/claims/name",
+		"http://schemas.microsoft.com/identity/claims/displayname",
+		"urn:oid:2.16.840.1.113730.3.1.241"}
+	defaultAvatarAttributes = []string{"picture", "avatar"}
+)
+
+type Repository interface {
+	Create(ctx context.Context, connection Connection) (Connection, error)
+	GetByOrgID(ctx context.Context, orgID string) (Connection, error)
+	List(ctx context.Context) ([]Connection, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// AssertionRepository remembers the assertions consumed by logins until they
+// expire, so that a captured response can't be posted again
+type AssertionRepository interface {
+	// Consume records the assertion of the issuer, ErrAssertionReplay is
+	// returned if it was consumed before
+	Consume(ctx context.Context, issuer, id string, expiresAt time.Time) error
+}
+
+// Connection is the saml identity provider registered by an organization,
+// its members log in through it with SP initiated logins
+type Connection struct {
+	ID    string
+	OrgID string
+	// EntityID of the identity provider, read from its metadata
+	EntityID string
+	// MetadataURL is where the metadata was fetched from, if provided
+	MetadataURL string
+	// Metadata is the identity provider metadata document
+	Metadata         string
+	AttributeMapping AttributeMapping
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// AttributeMapping names the assertion attributes holding the user fields,
+// empty fields fall back to the attributes commonly used by identity providers
+type AttributeMapping struct {
+	Email  string `json:"email,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Avatar string `json:"avatar,omitempty"`
+}
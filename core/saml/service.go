@@ -0,0 +1,324 @@
+package saml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/domain"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/str"
+	"github.com/raystack/frontier/pkg/utils"
+)
+
+const (
+	orgIDFlowKey = "org_id"
+	// maxMetadataSize bounds the metadata document fetched from an url
+	maxMetadataSize = 1 << 20
+	// metadataFetchTimeout bounds the request fetching the metadata document
+	metadataFetchTimeout = 10 * time.Second
+)
+
+type OrgService interface {
+	Get(ctx context.Context, idOrName string) (organization.Organization, error)
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id string) (user.User, error)
+	Create(ctx context.Context, user user.User) (user.User, error)
+}
+
+type DomainService interface {
+	List(ctx context.Context, flt domain.Filter) ([]domain.Domain, error)
+	Join(ctx context.Context, orgID string, userID string) error
+}
+
+type Service struct {
+	repository    Repository
+	assertionRepo AssertionRepository
+	flowRepo      authenticate.FlowRepository
+	orgService    OrgService
+	userService   UserService
+	domainService DomainService
+	config        authenticate.SAMLConfig
+	Client        *http.Client
+	Now           func() time.Time
+}
+
+func NewService(repository Repository, assertionRepo AssertionRepository, flowRepo authenticate.FlowRepository,
+	orgService OrgService, userService UserService, domainService DomainService, config authenticate.SAMLConfig) *Service {
+	return &Service{
+		repository:    repository,
+		assertionRepo: assertionRepo,
+		flowRepo:      flowRepo,
+		orgService:    orgService,
+		userService:   userService,
+		domainService: domainService,
+		config:        config,
+		Client:        &http.Client{Timeout: metadataFetchTimeout},
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Create registers the identity provider of an organization, its metadata is
+// fetched from the metadata url if the document isn't provided
+func (s Service) Create(ctx context.Context, connection Connection) (Connection, error) {
+	org, err := s.orgService.Get(ctx, connection.OrgID)
+	if err != nil {
+		return Connection{}, err
+	}
+	connection.OrgID = org.ID
+
+	if connection.Metadata == "" && connection.MetadataURL != "" {
+		if connection.Metadata, err = s.fetchMetadata(ctx, connection.MetadataURL); err != nil {
+			return Connection{}, fmt.Errorf("%w: %w", ErrInvalidDetail, err)
+		}
+	}
+	if connection.Metadata == "" {
+		return Connection{}, fmt.Errorf("%w: metadata is required", ErrInvalidDetail)
+	}
+	idp, err := strategy.ParseSAMLMetadata([]byte(connection.Metadata))
+	if err != nil {
+		return Connection{}, fmt.Errorf("%w: %w", ErrInvalidDetail, err)
+	}
+	connection.EntityID = idp.EntityID
+	return s.repository.Create(ctx, connection)
+}
+
+// Get returns the connection of an organization
+func (s Service) Get(ctx context.Context, orgIDOrName string) (Connection, error) {
+	org, err := s.orgService.Get(ctx, orgIDOrName)
+	if err != nil {
+		return Connection{}, err
+	}
+	return s.repository.GetByOrgID(ctx, org.ID)
+}
+
+func (s Service) List(ctx context.Context) ([]Connection, error) {
+	return s.repository.List(ctx)
+}
+
+func (s Service) Delete(ctx context.Context, id string) error {
+	return s.repository.Delete(ctx, id)
+}
+
+// Metadata returns the service provider metadata the organization registers
+// at its identity provider
+func (s Service) Metadata(ctx context.Context, orgIDOrName string) ([]byte, error) {
+	_, sp, err := s.serviceProvider(ctx, orgIDOrName)
+	if err != nil {
+		return nil, err
+	}
+	return sp.Metadata(), nil
+}
+
+// StartLogin returns the url sending the user to the identity provider of the
+// organization, the user is sent to returnTo once the login is finished
+func (s Service) StartLogin(ctx context.Context, orgIDOrName, returnTo string) (string, error) {
+	connection, sp, err := s.serviceProvider(ctx, orgIDOrName)
+	if err != nil {
+		return "", err
+	}
+
+	flow := &authenticate.Flow{
+		ID:        uuid.New(),
+		Method:    strategy.SAMLAuthMethod,
+		FinishURL: returnTo,
+		Metadata: map[string]any{
+			orgIDFlowKey: connection.OrgID,
+		},
+		CreatedAt: s.Now(),
+		ExpiresAt: s.Now().Add(s.config.Validity),
+	}
+	// the flow travels as the relay state and the request id is kept to
+	// match the response with this request
+	authURL, requestID, err := sp.AuthURL(flow.ID.String())
+	if err != nil {
+		return "", err
+	}
+	flow.Nonce = requestID
+	if err = s.flowRepo.Set(ctx, flow); err != nil {
+		return "", err
+	}
+	return authURL, nil
+}
+
+// FinishLogin verifies the response posted by the identity provider and returns
+// the user it authenticates. Users are created and added to the organization on
+// their first login, as long as their email belongs to a verified domain of the
+// organization so an identity provider can't log in users of other organizations
+func (s Service) FinishLogin(ctx context.Context, orgIDOrName, samlResponse, relayState string) (user.User, *authenticate.Flow, error) {
+	connection, sp, err := s.serviceProvider(ctx, orgIDOrName)
+	if err != nil {
+		return user.User{}, nil, err
+	}
+
+	flowID, err := uuid.Parse(relayState)
+	if err != nil {
+		return user.User{}, nil, ErrInvalidFlow
+	}
+	flow, err := s.flowRepo.Get(ctx, flowID)
+	if err != nil {
+		return user.User{}, nil, ErrInvalidFlow
+	}
+	// a response can only be used once, whatever its outcome
+	if err = s.flowRepo.Delete(ctx, flow.ID); err != nil {
+		return user.User{}, nil, err
+	}
+	if flow.Method != strategy.SAMLAuthMethod || flow.Metadata[orgIDFlowKey] != connection.OrgID || !flow.IsValid(s.Now()) {
+		return user.User{}, nil, ErrInvalidFlow
+	}
+
+	assertion, err := sp.ParseResponse(samlResponse, flow.Nonce)
+	if err != nil {
+		return user.User{}, nil, err
+	}
+	if err = s.assertionRepo.Consume(ctx, connection.EntityID, assertion.ID, assertion.ExpiresAt); err != nil {
+		return user.User{}, nil, err
+	}
+	profile := connection.AttributeMapping.apply(*assertion)
+	if profile.Email == "" {
+		return user.User{}, nil, ErrMissingEmail
+	}
+
+	domains, err := s.domainService.List(ctx, domain.Filter{
+		OrgID: connection.OrgID,
+		State: domain.Verified,
+	})
+	if err != nil {
+		return user.User{}, nil, err
+	}
+	emailDomain := utils.ExtractDomainFromEmail(profile.Email)
+	if !slices.ContainsFunc(domains, func(d domain.Domain) bool {
+		return d.Name == emailDomain
+	}) {
+		return user.User{}, nil, ErrDomainNotAllowed
+	}
+
+	loggedInUser, err := s.getOrCreateUser(ctx, profile)
+	if err != nil {
+		return user.User{}, nil, err
+	}
+	if err = s.domainService.Join(ctx, connection.OrgID, loggedInUser.ID); err != nil {
+		return user.User{}, nil, err
+	}
+	return loggedInUser, flow, nil
+}
+
+func (s Service) serviceProvider(ctx context.Context, orgIDOrName string) (Connection, *strategy.SAML, error) {
+	if s.config.URL == "" {
+		return Connection{}, nil, ErrNotConfigured
+	}
+	connection, err := s.Get(ctx, orgIDOrName)
+	if err != nil {
+		return Connection{}, nil, err
+	}
+	idp, err := strategy.ParseSAMLMetadata([]byte(connection.Metadata))
+	if err != nil {
+		return Connection{}, nil, err
+	}
+
+	baseURL := strings.TrimSuffix(s.config.URL, "/")
+	sp := strategy.NewSAML(
+		baseURL+fmt.Sprintf(MetadataPath, connection.OrgID),
+		baseURL+fmt.Sprintf(ACSPath, connection.OrgID),
+		idp,
+	)
+	sp.Now = s.Now
+	return connection, sp, nil
+}
+
+// fetchMetadata downloads the metadata document of the identity provider, it
+// holds the certificate signing the assertions so it's only fetched over https
+func (s Service) fetchMetadata(ctx context.Context, metadataURL string) (string, error) {
+	u, err := url.Parse(metadataURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", errors.New("metadata url must be an https url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch metadata: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxMetadataSize {
+		return "", fmt.Errorf("metadata is larger than %d bytes", maxMetadataSize)
+	}
+	return string(data), nil
+}
+
+func (s Service) getOrCreateUser(ctx context.Context, profile user.User) (user.User, error) {
+	existingUser, err := s.userService.GetByID(ctx, profile.Email)
+	if err == nil {
+		if existingUser.State == user.Disabled {
+			return user.User{}, ErrUserDisabled
+		}
+		return existingUser, nil
+	}
+	if !errors.Is(err, user.ErrNotExist) {
+		return user.User{}, err
+	}
+
+	newUser, err := s.userService.Create(ctx, user.User{
+		Title:  profile.Title,
+		Email:  profile.Email,
+		Avatar: profile.Avatar,
+		Name:   str.GenerateUserSlug(profile.Email),
+	})
+	if err != nil {
+		return user.User{}, err
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		LogWithAttrs(audit.UserCreatedEvent, audit.UserTarget(newUser.ID), map[string]string{
+			"email":  newUser.Email,
+			"name":   newUser.Name,
+			"title":  newUser.Title,
+			"avatar": newUser.Avatar,
+		})
+	return newUser, nil
+}
+
+// apply reads the user fields from the assertion, the email falls back to the
+// name id when it is an email address
+func (m AttributeMapping) apply(assertion strategy.SAMLAssertion) user.User {
+	lookup := func(mapped string, defaults []string) string {
+		if mapped != "" {
+			return assertion.Attribute(mapped)
+		}
+		return assertion.Attribute(defaults...)
+	}
+
+	email := lookup(m.Email, defaultEmailAttributes)
+	if email == "" && m.Email == "" && strings.Contains(assertion.NameID, "@") {
+		email = assertion.NameID
+	}
+	return user.User{
+		Email:  strings.ToLower(strings.TrimSpace(email)),
+		Title:  lookup(m.Name, defaultNameAttributes),
+		Avatar: lookup(m.Avatar, defaultAvatarAttributes),
+	}
+}
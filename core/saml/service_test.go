@@ -0,0 +1,394 @@
+package saml_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	authnmocks "github.com/raystack/frontier/core/authenticate/mocks"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/authenticate/strategy/samltest"
+	"github.com/raystack/frontier/core/domain"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/core/saml/mocks"
+	"github.com/raystack/frontier/core/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testOrgID  = "9f256f86-31a3-11ec-8d3d-0242ac130003"
+	testACSURL = "https://frontier.example.com/saml/" + testOrgID + "/acs"
+	testSPID   = "https://frontier.example.com/saml/" + testOrgID + "/metadata"
+)
+
+var samlNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type samlMocks struct {
+	repository *mocks.Repository
+	assertions *mocks.AssertionRepository
+	flows      *authnmocks.FlowRepository
+	orgs       *mocks.OrgService
+	users      *mocks.UserService
+	domains    *mocks.DomainService
+}
+
+func newSAMLService(t *testing.T) (*saml.Service, samlMocks) {
+	m := samlMocks{
+		repository: mocks.NewRepository(t),
+		assertions: mocks.NewAssertionRepository(t),
+		flows:      authnmocks.NewFlowRepository(t),
+		orgs:       mocks.NewOrgService(t),
+		users:      mocks.NewUserService(t),
+		domains:    mocks.NewDomainService(t),
+	}
+	s := saml.NewService(m.repository, m.assertions, m.flows, m.orgs, m.users, m.domains, authenticate.SAMLConfig{
+		URL:      "https://frontier.example.com/",
+		Validity: 10 * time.Minute,
+	})
+	s.Now = func() time.Time {
+		return samlNow
+	}
+	return s, m
+}
+
+func newIdP(t *testing.T) *samltest.IdentityProvider {
+	t.Helper()
+	idp, err := samltest.New("https://idp.acme.org", "https://idp.acme.org/sso")
+	assert.NoError(t, err)
+	return idp
+}
+
+// consume expects the assertion of a response issued at samlNow to be recorded
+func consume(m samlMocks, issuer string) {
+	m.assertions.EXPECT().Consume(mock.Anything, issuer, mock.AnythingOfType("string"), mock.MatchedBy(func(expiresAt time.Time) bool {
+		return expiresAt.After(samlNow)
+	})).Return(nil)
+}
+
+func TestService_Create(t *testing.T) {
+	idp := newIdP(t)
+	metadataServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/large" {
+			_, _ = w.Write(make([]byte, 1<<20+1))
+			return
+		}
+		_, _ = w.Write(idp.Metadata())
+	}))
+	defer metadataServer.Close()
+
+	tests := []struct {
+		name       string
+		connection saml.Connection
+		setup      func(m samlMocks)
+		want       saml.Connection
+		wantErr    error
+	}{
+		{
+			name: "should register a connection from the metadata document",
+			connection: saml.Connection{
+				OrgID:    "acme",
+				Metadata: string(idp.Metadata()),
+			},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, "acme").Return(organization.Organization{ID: testOrgID}, nil)
+				m.repository.EXPECT().Create(mock.Anything, saml.Connection{
+					OrgID:    testOrgID,
+					EntityID: idp.EntityID,
+					Metadata: string(idp.Metadata()),
+				}).Return(saml.Connection{ID: "connection-id", OrgID: testOrgID, EntityID: idp.EntityID}, nil)
+			},
+			want: saml.Connection{ID: "connection-id", OrgID: testOrgID, EntityID: idp.EntityID},
+		},
+		{
+			name: "should fetch the metadata from the url",
+			connection: saml.Connection{
+				OrgID:       testOrgID,
+				MetadataURL: metadataServer.URL,
+			},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+				m.repository.EXPECT().Create(mock.Anything, saml.Connection{
+					OrgID:       testOrgID,
+					EntityID:    idp.EntityID,
+					MetadataURL: metadataServer.URL,
+					Metadata:    string(idp.Metadata()),
+				}).Return(saml.Connection{ID: "connection-id"}, nil)
+			},
+			want: saml.Connection{ID: "connection-id"},
+		},
+		{
+			name: "should return error if the metadata url isn't https",
+			connection: saml.Connection{
+				OrgID:       testOrgID,
+				MetadataURL: "http://idp.acme.org/metadata",
+			},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+			},
+			wantErr: saml.ErrInvalidDetail,
+		},
+		{
+			name: "should return error if the fetched metadata is too large",
+			connection: saml.Connection{
+				OrgID:       testOrgID,
+				MetadataURL: metadataServer.URL + "/large",
+			},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+			},
+			wantErr: saml.ErrInvalidDetail,
+		},
+		{
+			name:       "should return error if metadata is missing",
+			connection: saml.Connection{OrgID: testOrgID},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+			},
+			wantErr: saml.ErrInvalidDetail,
+		},
+		{
+			name:       "should return error if metadata is invalid",
+			connection: saml.Connection{OrgID: testOrgID, Metadata: "<EntityDescriptor/>"},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+			},
+			wantErr: saml.ErrInvalidDetail,
+		},
+		{
+			name:       "should return error if organization doesn't exist",
+			connection: saml.Connection{OrgID: "unknown", Metadata: string(idp.Metadata())},
+			setup: func(m samlMocks) {
+				m.orgs.EXPECT().Get(mock.Anything, "unknown").Return(organization.Organization{}, organization.ErrNotExist)
+			},
+			wantErr: organization.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSAMLService(t)
+			s.Client = metadataServer.Client()
+			tt.setup(m)
+			got, err := s.Create(context.Background(), tt.connection)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_Login(t *testing.T) {
+	idp := newIdP(t)
+	connection := saml.Connection{
+		ID:       "connection-id",
+		OrgID:    testOrgID,
+		EntityID: idp.EntityID,
+		Metadata: string(idp.Metadata()),
+	}
+	verifiedDomains := []domain.Domain{{Name: "acme.org", OrgID: testOrgID, State: domain.Verified}}
+
+	tests := []struct {
+		name       string
+		connection saml.Connection
+		response   func(r samltest.Response) samltest.Response
+		flow       func(f *authenticate.Flow)
+		setup      func(m samlMocks)
+		want       user.User
+		wantErr    error
+	}{
+		{
+			name:       "should create the user on first login and add it to the organization",
+			connection: connection,
+			setup: func(m samlMocks) {
+				consume(m, idp.EntityID)
+				m.domains.EXPECT().List(mock.Anything, domain.Filter{OrgID: testOrgID, State: domain.Verified}).Return(verifiedDomains, nil)
+				m.users.EXPECT().GetByID(mock.Anything, "john@acme.org").Return(user.User{}, user.ErrNotExist)
+				m.users.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u user.User) bool {
+					return u.Email == "john@acme.org" && u.Title == "John Doe" && u.Avatar == "https://acme.org/john.png"
+				})).Return(user.User{ID: "user-id", Email: "john@acme.org"}, nil)
+				m.domains.EXPECT().Join(mock.Anything, testOrgID, "user-id").Return(nil)
+			},
+			want: user.User{ID: "user-id", Email: "john@acme.org"},
+		},
+		{
+			name:       "should log in an existing user",
+			connection: connection,
+			setup: func(m samlMocks) {
+				consume(m, idp.EntityID)
+				m.domains.EXPECT().List(mock.Anything, mock.Anything).Return(verifiedDomains, nil)
+				m.users.EXPECT().GetByID(mock.Anything, "john@acme.org").Return(user.User{ID: "user-id", Email: "john@acme.org", State: user.Enabled}, nil)
+				m.domains.EXPECT().Join(mock.Anything, testOrgID, "user-id").Return(nil)
+			},
+			want: user.User{ID: "user-id", Email: "john@acme.org", State: user.Enabled},
+		},
+		{
+			name: "should read the user fields from the mapped attributes",
+			connection: func() saml.Connection {
+				c := connection
+				c.AttributeMapping = saml.AttributeMapping{Email: "upn", Name: "cn"}
+				return c
+			}(),
+			response: func(r samltest.Response) samltest.Response {
+				r.Attributes = map[string]string{"upn": "John@Acme.org", "cn": "Johnny", "email": "other@acme.org"}
+				return r
+			},
+			setup: func(m samlMocks) {
+				consume(m, idp.EntityID)
+				m.domains.EXPECT().List(mock.Anything, mock.Anything).Return(verifiedDomains, nil)
+				m.users.EXPECT().GetByID(mock.Anything, "john@acme.org").Return(user.User{}, user.ErrNotExist)
+				m.users.EXPECT().Create(mock.Anything, mock.MatchedBy(func(u user.User) bool {
+					return u.Email == "john@acme.org" && u.Title == "Johnny"
+				})).Return(user.User{ID: "user-id"}, nil)
+				m.domains.EXPECT().Join(mock.Anything, testOrgID, "user-id").Return(nil)
+			},
+			want: user.User{ID: "user-id"},
+		},
+		{
+			name:       "should fall back to the name id for the email",
+			connection: connection,
+			response: func(r samltest.Response) samltest.Response {
+				r.Attributes = nil
+				return r
+			},
+			setup: func(m samlMocks) {
+				consume(m, idp.EntityID)
+				m.domains.EXPECT().List(mock.Anything, mock.Anything).Return(verifiedDomains, nil)
+				m.users.EXPECT().GetByID(mock.Anything, "john@acme.org").Return(user.User{ID: "user-id"}, nil)
+				m.domains.EXPECT().Join(mock.Anything, testOrgID, "user-id").Return(nil)
+			},
+			want: user.User{ID: "user-id"},
+		},
+		{
+			name:       "should reject users outside the verified domains of the organization",
+			connection: connection,
+			response: func(r samltest.Response) samltest.Response {
+				r.NameID = "ceo@other.org"
+				r.Attributes = map[string]string{"email": "ceo@other.org"}
+				return r
+			},
+			setup: func(m samlMocks) {
+				consume(m, idp.EntityID)
+				m.domains.EXPECT().List(mock.Anything, mock.Anything).Return(verifiedDomains, nil)
+			},
+			wantErr: saml.ErrDomainNotAllowed,
+		},
+		{
+			name:       "should reject disabled users",
+			connection: connection,
+			setup: func(m samlMocks) {
+				consume(m, idp.EntityID)
+				m.domains.EXPECT().List(mock.Anything, mock.Anything).Return(verifiedDomains, nil)
+				m.users.EXPECT().GetByID(mock.Anything, "john@acme.org").Return(user.User{ID: "user-id", State: user.Disabled}, nil)
+			},
+			wantErr: saml.ErrUserDisabled,
+		},
+		{
+			name:       "should reject a replayed assertion",
+			connection: connection,
+			setup: func(m samlMocks) {
+				m.assertions.EXPECT().Consume(mock.Anything, idp.EntityID, mock.Anything, mock.Anything).Return(saml.ErrAssertionReplay)
+			},
+			wantErr: saml.ErrAssertionReplay,
+		},
+		{
+			name:       "should reject an expired login",
+			connection: connection,
+			flow: func(f *authenticate.Flow) {
+				f.ExpiresAt = samlNow.Add(-time.Second)
+			},
+			wantErr: saml.ErrInvalidFlow,
+		},
+		{
+			name:       "should reject a login started for another organization",
+			connection: connection,
+			flow: func(f *authenticate.Flow) {
+				f.Metadata["org_id"] = "other-org"
+			},
+			wantErr: saml.ErrInvalidFlow,
+		},
+		{
+			name:       "should reject a response that doesn't match the request",
+			connection: connection,
+			response: func(r samltest.Response) samltest.Response {
+				r.InResponseTo = "id-other"
+				return r
+			},
+			wantErr: strategy.ErrInvalidSAMLResponse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSAMLService(t)
+			ctx := context.Background()
+			m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+			m.repository.EXPECT().GetByOrgID(mock.Anything, testOrgID).Return(tt.connection, nil)
+
+			var flow *authenticate.Flow
+			m.flows.EXPECT().Set(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, f *authenticate.Flow) error {
+				flow = f
+				return nil
+			})
+			authURL, err := s.StartLogin(ctx, testOrgID, "https://app.acme.org/home")
+			assert.NoError(t, err)
+			assert.Contains(t, authURL, idp.SSOURL+"?")
+			assert.Equal(t, strategy.SAMLAuthMethod, flow.Method)
+			assert.Equal(t, "https://app.acme.org/home", flow.FinishURL)
+			assert.Equal(t, samlNow.Add(10*time.Minute), flow.ExpiresAt)
+			if tt.flow != nil {
+				tt.flow(flow)
+			}
+			m.flows.EXPECT().Get(mock.Anything, flow.ID).Return(flow, nil)
+			m.flows.EXPECT().Delete(mock.Anything, flow.ID).Return(nil)
+
+			r := samltest.Response{
+				InResponseTo: flow.Nonce,
+				Destination:  testACSURL,
+				Audience:     testSPID,
+				NameID:       "john@acme.org",
+				Attributes: map[string]string{
+					"email":       "john@acme.org",
+					"displayName": "John Doe",
+					"picture":     "https://acme.org/john.png",
+				},
+				IssuedAt: samlNow,
+			}
+			if tt.response != nil {
+				r = tt.response(r)
+			}
+			encoded, err := idp.Encode(r)
+			assert.NoError(t, err)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			got, gotFlow, err := s.FinishLogin(ctx, testOrgID, encoded, flow.ID.String())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, flow, gotFlow)
+		})
+	}
+}
+
+func TestService_FinishLogin_InvalidRelayState(t *testing.T) {
+	idp := newIdP(t)
+	s, m := newSAMLService(t)
+	m.orgs.EXPECT().Get(mock.Anything, testOrgID).Return(organization.Organization{ID: testOrgID}, nil)
+	m.repository.EXPECT().GetByOrgID(mock.Anything, testOrgID).Return(saml.Connection{
+		OrgID:    testOrgID,
+		Metadata: string(idp.Metadata()),
+	}, nil)
+
+	_, _, err := s.FinishLogin(context.Background(), testOrgID, "response", "not-a-flow")
+	assert.ErrorIs(t, err, saml.ErrInvalidFlow)
+}
@@ -0,0 +1,15 @@
+package scim
+
+import "errors"
+
+var (
+	ErrUnauthenticated = errors.New("invalid or missing scim bearer token")
+	ErrForbidden       = errors.New("scim token is not allowed to manage the organization")
+	ErrNotExist        = errors.New("scim resource doesn't exist")
+	ErrConflict        = errors.New("scim resource already exists")
+	ErrInvalidFilter   = errors.New("invalid scim filter")
+	ErrInvalidPath     = errors.New("invalid scim patch path")
+	ErrInvalidValue    = errors.New("invalid scim attribute value")
+	ErrMutability      = errors.New("scim attribute is immutable")
+	ErrLastAdmin       = errors.New("last admin of the organization can't be deprovisioned")
+)
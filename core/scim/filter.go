@@ -0,0 +1,272 @@
+package scim
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Resource exposes the attribute values of a resource to filters
+type Resource interface {
+	Attribute(path string) []string
+}
+
+// Filter is a parsed filter expression of RFC 7644 section 3.4.2.2
+type Filter interface {
+	Match(r Resource) bool
+}
+
+// ParseFilter parses a filter like `userName eq "john@example.com"`, it
+// supports the comparison operators, `pr`, `and`, `or`, `not` and grouping.
+// String comparisons are case-insensitive as no attribute is case exact
+func ParseFilter(filter string) (Filter, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, p.tokens[p.pos].text)
+	}
+	return expr, nil
+}
+
+type logicalFilter struct {
+	and         bool
+	left, right Filter
+}
+
+func (f logicalFilter) Match(r Resource) bool {
+	if f.and {
+		return f.left.Match(r) && f.right.Match(r)
+	}
+	return f.left.Match(r) || f.right.Match(r)
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f notFilter) Match(r Resource) bool {
+	return !f.filter.Match(r)
+}
+
+type attributeFilter struct {
+	path  string
+	op    string
+	value string
+}
+
+func (f attributeFilter) Match(r Resource) bool {
+	for _, actual := range r.Attribute(f.path) {
+		if f.compare(strings.ToLower(actual)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f attributeFilter) compare(actual string) bool {
+	switch f.op {
+	case "pr":
+		return actual != ""
+	case "eq":
+		return actual == f.value
+	case "ne":
+		return actual != f.value
+	case "co":
+		return strings.Contains(actual, f.value)
+	case "sw":
+		return strings.HasPrefix(actual, f.value)
+	case "ew":
+		return strings.HasSuffix(actual, f.value)
+	case "gt":
+		return actual > f.value
+	case "ge":
+		return actual >= f.value
+	case "lt":
+		return actual < f.value
+	case "le":
+		return actual <= f.value
+	}
+	return false
+}
+
+type tokenKind int
+
+const (
+	wordToken tokenKind = iota
+	stringToken
+	openToken
+	closeToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: openToken, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: closeToken, text: ")"})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(filter) && filter[end] != '"'; end++ {
+				if filter[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(filter) {
+				return nil, fmt.Errorf("%w: unterminated string", ErrInvalidFilter)
+			}
+			value, err := strconv.Unquote(filter[i : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid string %s", ErrInvalidFilter, filter[i:end+1])
+			}
+			tokens = append(tokens, token{kind: stringToken, text: value})
+			i = end + 1
+		default:
+			end := i
+			for end < len(filter) && !unicode.IsSpace(rune(filter[end])) && !strings.ContainsRune(`()"`, rune(filter[end])) {
+				end++
+			}
+			tokens = append(tokens, token{kind: wordToken, text: filter[i:end]})
+			i = end
+		}
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("%w: empty filter", ErrInvalidFilter)
+	}
+	return tokens, nil
+}
+
+// filterParser is a recursive descent parser where `and` binds tighter than `or`
+type filterParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *filterParser) next() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t, true
+}
+
+func (p *filterParser) acceptWord(word string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == wordToken && strings.EqualFold(p.tokens[p.pos].text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalFilter{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptWord("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalFilter{and: true, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	negate := p.acceptWord("not")
+	t, ok := p.next()
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected end of filter", ErrInvalidFilter)
+	}
+
+	var expr Filter
+	switch {
+	case t.kind == openToken:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing, ok := p.next(); !ok || closing.kind != closeToken {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", ErrInvalidFilter)
+		}
+		expr = inner
+	case negate:
+		return nil, fmt.Errorf("%w: not must be followed by a parenthesis", ErrInvalidFilter)
+	case t.kind == wordToken:
+		attr, err := p.parseAttribute(t.text)
+		if err != nil {
+			return nil, err
+		}
+		expr = attr
+	default:
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidFilter, t.text)
+	}
+
+	if negate {
+		return notFilter{filter: expr}, nil
+	}
+	return expr, nil
+}
+
+func (p *filterParser) parseAttribute(path string) (Filter, error) {
+	op, ok := p.next()
+	if !ok || op.kind != wordToken {
+		return nil, fmt.Errorf("%w: missing operator after %s", ErrInvalidFilter, path)
+	}
+	operator := strings.ToLower(op.text)
+	switch operator {
+	case "pr":
+		return attributeFilter{path: path, op: operator}, nil
+	case "eq", "ne", "co", "sw", "ew", "gt", "ge", "lt", "le":
+	default:
+		return nil, fmt.Errorf("%w: unsupported operator %s", ErrInvalidFilter, op.text)
+	}
+
+	value, ok := p.next()
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("%w: missing value after %s %s", ErrInvalidFilter, path, op.text)
+	case value.kind == stringToken:
+	case value.kind == wordToken && value.text != "null":
+		// booleans and numbers are compared by their literal
+	default:
+		return nil, fmt.Errorf("%w: unsupported value %q", ErrInvalidFilter, value.text)
+	}
+	return attributeFilter{path: path, op: operator, value: strings.ToLower(value.text)}, nil
+}
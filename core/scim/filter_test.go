@@ -0,0 +1,52 @@
+package scim_test
+
+import (
+	"testing"
+
+	"github.com/raystack/frontier/core/scim"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	john := scim.User{
+		ID:          "user-id",
+		UserName:    "John.Doe@acme.org",
+		DisplayName: "John Doe",
+		Active:      true,
+	}
+
+	tests := []struct {
+		filter  string
+		want    bool
+		wantErr error
+	}{
+		{filter: `userName eq "john.doe@acme.org"`, want: true},
+		{filter: `urn:ietf:params:scim:schemas:core:2.0:User:userName eq "john.doe@acme.org"`, want: true},
+		{filter: `emails.value sw "john"`, want: true},
+		{filter: `displayName co "doe" and active eq true`, want: true},
+		{filter: `displayName ew "smith" or id eq "user-id"`, want: true},
+		{filter: `displayName ew "smith" or id eq "user-id" and active eq false`, want: false},
+		{filter: `(displayName ew "smith" or id eq "user-id") and active eq true`, want: true},
+		{filter: `not (userName eq "john.doe@acme.org")`, want: false},
+		{filter: `name.givenName pr`, want: false},
+		{filter: `userName ne "jane@acme.org"`, want: true},
+		{filter: `title eq "engineer"`, want: false},
+		{filter: `userName eq "john.doe@acme.org" and`, wantErr: scim.ErrInvalidFilter},
+		{filter: `userName regex "john"`, wantErr: scim.ErrInvalidFilter},
+		{filter: `userName eq "john`, wantErr: scim.ErrInvalidFilter},
+		{filter: `(userName pr`, wantErr: scim.ErrInvalidFilter},
+		{filter: `not userName pr`, wantErr: scim.ErrInvalidFilter},
+		{filter: ``, wantErr: scim.ErrInvalidFilter},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			got, err := scim.ParseFilter(tt.filter)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got.Match(john))
+		})
+	}
+}
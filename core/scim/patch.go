@@ -0,0 +1,245 @@
+package scim
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	patchAdd     = "add"
+	patchRemove  = "remove"
+	patchReplace = "replace"
+)
+
+// applyUserPatch applies the operations of a PatchOp request to the user,
+// attributes frontier doesn't store are ignored
+func applyUserPatch(u User, ops []PatchOperation) (User, error) {
+	for _, op := range ops {
+		path := strings.ToLower(trimSchema(op.Path, UserSchema))
+		switch strings.ToLower(op.Op) {
+		case patchAdd, patchReplace:
+			if path == "" {
+				values, ok := op.Value.(map[string]any)
+				if !ok {
+					return User{}, fmt.Errorf("%w: patch without a path requires an object", ErrInvalidValue)
+				}
+				for attr, value := range values {
+					if err := setUserAttribute(&u, strings.ToLower(trimSchema(attr, UserSchema)), value); err != nil {
+						return User{}, err
+					}
+				}
+				continue
+			}
+			if err := setUserAttribute(&u, path, op.Value); err != nil {
+				return User{}, err
+			}
+		case patchRemove:
+			switch path {
+			case "":
+				return User{}, fmt.Errorf("%w: remove requires a path", ErrInvalidPath)
+			case "active", "username", "emails", "id":
+				return User{}, fmt.Errorf("%w: %s can't be removed", ErrMutability, op.Path)
+			case "name":
+				u.GivenName, u.FamilyName = "", ""
+				continue
+			}
+			if err := setUserAttribute(&u, path, ""); err != nil {
+				return User{}, err
+			}
+		default:
+			return User{}, fmt.Errorf("%w: unsupported op %s", ErrInvalidValue, op.Op)
+		}
+	}
+	return u, nil
+}
+
+func setUserAttribute(u *User, path string, value any) error {
+	switch path {
+	case "active":
+		active, err := boolValue(value)
+		if err != nil {
+			return err
+		}
+		u.Active = active
+	case "displayname", "name.formatted":
+		return stringValue(value, &u.DisplayName)
+	case "name.givenname":
+		return stringValue(value, &u.GivenName)
+	case "name.familyname":
+		return stringValue(value, &u.FamilyName)
+	case "name":
+		name, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: name must be an object", ErrInvalidValue)
+		}
+		for attr, v := range name {
+			if err := setUserAttribute(u, "name."+strings.ToLower(attr), v); err != nil {
+				return err
+			}
+		}
+	case "username":
+		// the email is the identity of the user and can't be changed, identity
+		// providers resend it unchanged though
+		if v, ok := value.(string); !ok || !strings.EqualFold(v, u.UserName) {
+			return fmt.Errorf("%w: %s", ErrMutability, path)
+		}
+	case "id":
+		if v, ok := value.(string); !ok || v != u.ID {
+			return fmt.Errorf("%w: %s", ErrMutability, path)
+		}
+	}
+	return nil
+}
+
+// applyGroupPatch applies the operations of a PatchOp request to the group,
+// members are matched by their user id
+func applyGroupPatch(g Group, ops []PatchOperation) (Group, error) {
+	for _, op := range ops {
+		path := trimSchema(op.Path, GroupSchema)
+		attr, valueFilter, err := splitValuePath(path)
+		if err != nil {
+			return Group{}, err
+		}
+		switch strings.ToLower(op.Op) {
+		case patchAdd, patchReplace:
+			replace := strings.EqualFold(op.Op, patchReplace)
+			if attr == "" {
+				values, ok := op.Value.(map[string]any)
+				if !ok {
+					return Group{}, fmt.Errorf("%w: patch without a path requires an object", ErrInvalidValue)
+				}
+				for name, value := range values {
+					if err := setGroupAttribute(&g, strings.ToLower(trimSchema(name, GroupSchema)), value, replace); err != nil {
+						return Group{}, err
+					}
+				}
+				continue
+			}
+			if valueFilter != nil {
+				return Group{}, fmt.Errorf("%w: value filters are only supported to remove members", ErrInvalidPath)
+			}
+			if err := setGroupAttribute(&g, attr, op.Value, replace); err != nil {
+				return Group{}, err
+			}
+		case patchRemove:
+			if attr != "members" {
+				return Group{}, fmt.Errorf("%w: only members can be removed", ErrInvalidPath)
+			}
+			switch {
+			case valueFilter != nil:
+				g.Members = slices.DeleteFunc(g.Members, func(m Member) bool {
+					return valueFilter.Match(m)
+				})
+			case op.Value != nil:
+				// azure ad sends the removed members as the value
+				removed, err := memberValues(op.Value)
+				if err != nil {
+					return Group{}, err
+				}
+				g.Members = slices.DeleteFunc(g.Members, func(m Member) bool {
+					return slices.Contains(removed, m.Value)
+				})
+			default:
+				g.Members = nil
+			}
+		default:
+			return Group{}, fmt.Errorf("%w: unsupported op %s", ErrInvalidValue, op.Op)
+		}
+	}
+	return g, nil
+}
+
+func setGroupAttribute(g *Group, attr string, value any, replace bool) error {
+	switch attr {
+	case "displayname":
+		return stringValue(value, &g.DisplayName)
+	case "members":
+		added, err := memberValues(value)
+		if err != nil {
+			return err
+		}
+		if replace {
+			g.Members = nil
+		}
+		for _, id := range added {
+			if !slices.ContainsFunc(g.Members, func(m Member) bool { return m.Value == id }) {
+				g.Members = append(g.Members, Member{Value: id})
+			}
+		}
+	case "id":
+		if v, ok := value.(string); !ok || v != g.ID {
+			return fmt.Errorf("%w: %s", ErrMutability, attr)
+		}
+	}
+	return nil
+}
+
+// splitValuePath splits a path like members[value eq "id"] in the attribute
+// and the filter of its values
+func splitValuePath(path string) (string, Filter, error) {
+	attr, rest, found := strings.Cut(path, "[")
+	attr = strings.ToLower(strings.TrimSpace(attr))
+	if !found {
+		return attr, nil, nil
+	}
+	if !strings.HasSuffix(rest, "]") {
+		return "", nil, fmt.Errorf("%w: %s", ErrInvalidPath, path)
+	}
+	filter, err := ParseFilter(strings.TrimSuffix(rest, "]"))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidPath, err)
+	}
+	return attr, filter, nil
+}
+
+// memberValues returns the user ids of a list of members like [{"value": "id"}]
+func memberValues(value any) ([]string, error) {
+	var members []any
+	switch v := value.(type) {
+	case []any:
+		members = v
+	case map[string]any:
+		members = []any{v}
+	default:
+		return nil, fmt.Errorf("%w: members must be a list", ErrInvalidValue)
+	}
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		member, ok := m.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: member must be an object", ErrInvalidValue)
+		}
+		id, ok := member["value"].(string)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%w: member value is required", ErrInvalidValue)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func stringValue(value any, dst *string) error {
+	s, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%w: expected a string", ErrInvalidValue)
+	}
+	*dst = s
+	return nil
+}
+
+// boolValue accepts strings as well since azure ad sends "True" and "False"
+func boolValue(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.ToLower(v))
+		if err != nil {
+			return false, fmt.Errorf("%w: expected a boolean", ErrInvalidValue)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("%w: expected a boolean", ErrInvalidValue)
+}
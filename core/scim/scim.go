@@ -0,0 +1,179 @@
+package scim
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	UserSchema         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	GroupSchema        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ListResponseSchema = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	PatchOpSchema      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ErrorSchema        = "urn:ietf:params:scim:api:messages:2.0:Error"
+
+	// MaxPageSize bounds the resources returned by a single list request
+	MaxPageSize = 100
+)
+
+// User is the scim view of a frontier user provisioned into an organization.
+// The user name is the email of the user, the display name its title
+type User struct {
+	ID          string
+	UserName    string
+	DisplayName string
+	GivenName   string
+	FamilyName  string
+	Active      bool
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Title is the frontier title of the user, the display name takes precedence
+// over the name parts
+func (u User) Title() string {
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return strings.TrimSpace(u.GivenName + " " + u.FamilyName)
+}
+
+// Attribute returns the values of a user attribute matched by filters
+func (u User) Attribute(path string) []string {
+	switch strings.ToLower(trimSchema(path, UserSchema)) {
+	case "id":
+		return []string{u.ID}
+	case "username", "emails", "emails.value":
+		return []string{u.UserName}
+	case "displayname", "name.formatted":
+		return []string{u.Title()}
+	case "name.givenname":
+		return []string{u.GivenName}
+	case "name.familyname":
+		return []string{u.FamilyName}
+	case "active":
+		if u.Active {
+			return []string{"true"}
+		}
+		return []string{"false"}
+	}
+	return nil
+}
+
+// Profile is the display name the identity provider of an organization set
+// for a member the organization doesn't own. Users are global in frontier, the
+// title of a user is only updated by the organization which verified the
+// domain of its email
+type Profile struct {
+	OrgID       string
+	UserID      string
+	DisplayName string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type Repository interface {
+	Get(ctx context.Context, orgID, userID string) (Profile, error)
+	List(ctx context.Context, orgID string) ([]Profile, error)
+	Upsert(ctx context.Context, profile Profile) (Profile, error)
+	Delete(ctx context.Context, orgID, userID string) error
+}
+
+// Group is the scim view of a frontier group of an organization, the display
+// name is the group title
+type Group struct {
+	ID          string
+	DisplayName string
+	Members     []Member
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Attribute returns the values of a group attribute matched by filters
+func (g Group) Attribute(path string) []string {
+	switch strings.ToLower(trimSchema(path, GroupSchema)) {
+	case "id":
+		return []string{g.ID}
+	case "displayname":
+		return []string{g.DisplayName}
+	case "members", "members.value":
+		values := make([]string, 0, len(g.Members))
+		for _, m := range g.Members {
+			values = append(values, m.Value)
+		}
+		return values
+	}
+	return nil
+}
+
+// Member is a user of a group, the value is the user id
+type Member struct {
+	Value   string
+	Display string
+}
+
+// Attribute returns the values of a member attribute matched by value filters
+// of patch paths like members[value eq "id"]
+func (m Member) Attribute(path string) []string {
+	switch strings.ToLower(path) {
+	case "value":
+		return []string{m.Value}
+	case "display":
+		return []string{m.Display}
+	}
+	return nil
+}
+
+// PatchOperation is a single operation of a PatchOp request as per RFC 7644
+// section 3.5.2, the value is the decoded json value
+type PatchOperation struct {
+	Op    string
+	Path  string
+	Value any
+}
+
+// Page selects the resources of a list request, the start index is 1-based
+// and the count is bounded by MaxPageSize
+type Page struct {
+	StartIndex int
+	Count      int
+}
+
+// ListResult is a page of the resources matching a list request
+type ListResult[T any] struct {
+	Resources    []T
+	TotalResults int
+	StartIndex   int
+}
+
+// trimSchema strips the schema urn of fully qualified attribute paths
+func trimSchema(path, schema string) string {
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)], schema) && path[len(schema)] == ':' {
+		return path[len(schema)+1:]
+	}
+	return path
+}
+
+func paginate[T any](resources []T, page Page) ListResult[T] {
+	start := page.StartIndex
+	if start < 1 {
+		start = 1
+	}
+	// a count of zero only returns the total results as per RFC 7644 section 3.4.2.4
+	count := min(max(page.Count, 0), MaxPageSize)
+	result := ListResult[T]{
+		Resources:    []T{},
+		TotalResults: len(resources),
+		StartIndex:   start,
+	}
+	if start > len(resources) {
+		return result
+	}
+	end := start - 1 + count
+	if end > len(resources) {
+		end = len(resources)
+	}
+	result.Resources = resources[start-1 : end]
+	return result
+}
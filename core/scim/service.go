@@ -0,0 +1,674 @@
+package scim
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/domain"
+	"github.com/raystack/frontier/core/group"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/serviceuser"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/str"
+	"github.com/raystack/frontier/pkg/utils"
+)
+
+type OrgService interface {
+	Get(ctx context.Context, idOrName string) (organization.Organization, error)
+	AddUsers(ctx context.Context, orgID string, userIDs []string) error
+}
+
+type UserService interface {
+	GetByID(ctx context.Context, id string) (user.User, error)
+	GetByEmail(ctx context.Context, email string) (user.User, error)
+	Create(ctx context.Context, user user.User) (user.User, error)
+	Update(ctx context.Context, toUpdate user.User) (user.User, error)
+	ListByOrg(ctx context.Context, orgID string, roleFilter string) ([]user.User, error)
+	ListByGroup(ctx context.Context, groupID string, roleFilter string) ([]user.User, error)
+}
+
+type GroupService interface {
+	Create(ctx context.Context, grp group.Group) (group.Group, error)
+	Get(ctx context.Context, id string) (group.Group, error)
+	Update(ctx context.Context, grp group.Group) (group.Group, error)
+	ListByOrganization(ctx context.Context, id string) ([]group.Group, error)
+	AddUsers(ctx context.Context, groupID string, userIDs []string) error
+	RemoveUsers(ctx context.Context, groupID string, userIDs []string) error
+	Delete(ctx context.Context, id string) error
+}
+
+type DeleterService interface {
+	RemoveUsersFromOrg(ctx context.Context, orgID string, userIDs []string) error
+}
+
+type ServiceUserService interface {
+	GetBySecret(ctx context.Context, credID string, reqSecret string) (serviceuser.ServiceUser, error)
+	ListToken(ctx context.Context, serviceUserID string) ([]serviceuser.Credential, error)
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+type DomainService interface {
+	List(ctx context.Context, flt domain.Filter) ([]domain.Domain, error)
+}
+
+// Service provisions the users and groups of an organization from an identity
+// provider over scim. Users are global in frontier, provisioning a user makes
+// it a member of the organization and deprovisioning removes the membership.
+// The identity provider only manages the members of its organization and only
+// changes users the organization owns through a verified domain
+type Service struct {
+	repository         Repository
+	orgService         OrgService
+	userService        UserService
+	groupService       GroupService
+	deleterService     DeleterService
+	serviceUserService ServiceUserService
+	resourceService    ResourceService
+	domainService      DomainService
+}
+
+func NewService(repository Repository, orgService OrgService, userService UserService, groupService GroupService,
+	deleterService DeleterService, serviceUserService ServiceUserService, resourceService ResourceService,
+	domainService DomainService) *Service {
+	return &Service{
+		repository:         repository,
+		orgService:         orgService,
+		userService:        userService,
+		groupService:       groupService,
+		deleterService:     deleterService,
+		serviceUserService: serviceUserService,
+		resourceService:    resourceService,
+		domainService:      domainService,
+	}
+}
+
+// Authenticate verifies the opaque token of a service user of the organization,
+// the service user must be allowed to update the organization. The returned
+// principal owns the groups created by the identity provider
+func (s Service) Authenticate(ctx context.Context, orgIDOrName, tokenID, token string) (organization.Organization, authenticate.Principal, error) {
+	if tokenID == "" || token == "" {
+		return organization.Organization{}, authenticate.Principal{}, ErrUnauthenticated
+	}
+	serviceUser, err := s.serviceUserService.GetBySecret(ctx, tokenID, token)
+	if err != nil {
+		return organization.Organization{}, authenticate.Principal{}, ErrUnauthenticated
+	}
+	// client secrets are accepted by GetBySecret as well
+	tokens, err := s.serviceUserService.ListToken(ctx, serviceUser.ID)
+	if err != nil {
+		return organization.Organization{}, authenticate.Principal{}, err
+	}
+	if !slices.ContainsFunc(tokens, func(c serviceuser.Credential) bool {
+		return c.ID == tokenID && c.Type == serviceuser.OpaqueTokenCredentialType
+	}) || serviceUser.State == serviceuser.Disabled.String() {
+		return organization.Organization{}, authenticate.Principal{}, ErrUnauthenticated
+	}
+
+	org, err := s.orgService.Get(ctx, orgIDOrName)
+	if err != nil {
+		if errors.Is(err, organization.ErrNotExist) || errors.Is(err, organization.ErrInvalidUUID) ||
+			errors.Is(err, organization.ErrDisabled) {
+			return organization.Organization{}, authenticate.Principal{}, ErrForbidden
+		}
+		return organization.Organization{}, authenticate.Principal{}, err
+	}
+	if serviceUser.OrgID != org.ID {
+		return organization.Organization{}, authenticate.Principal{}, ErrForbidden
+	}
+	allowed, err := s.resourceService.CheckAuthz(ctx, resource.Check{
+		Object: relation.Object{
+			ID:        org.ID,
+			Namespace: schema.OrganizationNamespace,
+		},
+		Subject: relation.Subject{
+			ID:        serviceUser.ID,
+			Namespace: schema.ServiceUserPrincipal,
+		},
+		Permission: schema.UpdatePermission,
+	})
+	if err != nil {
+		return organization.Organization{}, authenticate.Principal{}, err
+	}
+	if !allowed {
+		return organization.Organization{}, authenticate.Principal{}, ErrForbidden
+	}
+	return org, authenticate.Principal{
+		ID:          serviceUser.ID,
+		Type:        schema.ServiceUserPrincipal,
+		ServiceUser: &serviceUser,
+	}, nil
+}
+
+// ListUsers lists the members of the organization matching the filter
+func (s Service) ListUsers(ctx context.Context, orgID, filter string, page Page) (ListResult[User], error) {
+	flt, err := parseOptionalFilter(filter)
+	if err != nil {
+		return ListResult[User]{}, err
+	}
+	members, err := s.userService.ListByOrg(ctx, orgID, "")
+	if err != nil {
+		return ListResult[User]{}, err
+	}
+	sortUsers(members)
+	displayNames, err := s.displayNames(ctx, orgID)
+	if err != nil {
+		return ListResult[User]{}, err
+	}
+
+	users := make([]User, 0, len(members))
+	for _, member := range members {
+		u := toUser(member, displayNames, true)
+		if flt == nil || flt.Match(u) {
+			users = append(users, u)
+		}
+	}
+	return paginate(users, page), nil
+}
+
+// GetUser returns a member of the organization
+func (s Service) GetUser(ctx context.Context, orgID, id string) (User, error) {
+	u, err := s.getMember(ctx, orgID, id)
+	if err != nil {
+		return User{}, err
+	}
+	return s.toUser(ctx, orgID, u, true)
+}
+
+// CreateUser adds the user with the email of the user name to the organization,
+// the user is created if it doesn't exist in frontier yet. It is the only
+// operation adding users to the organization
+func (s Service) CreateUser(ctx context.Context, orgID string, u User) (User, error) {
+	email := strings.ToLower(strings.TrimSpace(u.UserName))
+	if !utils.IsValidEmail(email) {
+		return User{}, fmt.Errorf("%w: userName must be an email", ErrInvalidValue)
+	}
+	slug := str.GenerateUserSlug(email)
+
+	existing, err := s.userService.GetByEmail(ctx, email)
+	switch {
+	case errors.Is(err, user.ErrNotExist):
+		created, err := s.userService.Create(ctx, user.User{
+			Name:  slug,
+			Email: email,
+			Title: u.Title(),
+		})
+		if err != nil {
+			return User{}, err
+		}
+		if u.Active {
+			if err := s.orgService.AddUsers(ctx, orgID, []string{created.ID}); err != nil {
+				return User{}, err
+			}
+		}
+		return toUser(created, nil, u.Active), nil
+	case err != nil:
+		return User{}, err
+	}
+
+	member, err := s.isMember(ctx, orgID, existing.ID)
+	if err != nil {
+		return User{}, err
+	}
+	if member {
+		return User{}, ErrConflict
+	}
+	if !u.Active {
+		return toUser(existing, nil, false), nil
+	}
+	if err := s.orgService.AddUsers(ctx, orgID, []string{existing.ID}); err != nil {
+		return User{}, err
+	}
+	return s.saveProfile(ctx, orgID, existing, u.Title())
+}
+
+// ReplaceUser updates the member with a full representation, an inactive
+// user is removed from the organization
+func (s Service) ReplaceUser(ctx context.Context, orgID, id string, u User) (User, error) {
+	existing, err := s.getMember(ctx, orgID, id)
+	if err != nil {
+		return User{}, err
+	}
+	if u.UserName != "" && !strings.EqualFold(u.UserName, existing.Email) {
+		return User{}, fmt.Errorf("%w: userName", ErrMutability)
+	}
+	return s.saveUser(ctx, orgID, existing, u)
+}
+
+// PatchUser applies the operations of a PatchOp request to the member
+func (s Service) PatchUser(ctx context.Context, orgID, id string, ops []PatchOperation) (User, error) {
+	existing, err := s.getMember(ctx, orgID, id)
+	if err != nil {
+		return User{}, err
+	}
+	current, err := s.toUser(ctx, orgID, existing, true)
+	if err != nil {
+		return User{}, err
+	}
+	patched, err := applyUserPatch(current, ops)
+	if err != nil {
+		return User{}, err
+	}
+	return s.saveUser(ctx, orgID, existing, patched)
+}
+
+// DeleteUser removes the user from the organization, the user itself is kept
+// as it may belong to other organizations
+func (s Service) DeleteUser(ctx context.Context, orgID, id string) error {
+	if _, err := s.getMember(ctx, orgID, id); err != nil {
+		return err
+	}
+	return s.removeMember(ctx, orgID, id)
+}
+
+// getMember returns the user if it is a member of the organization, users of
+// other organizations don't exist for the identity provider
+func (s Service) getMember(ctx context.Context, orgID, id string) (user.User, error) {
+	// users are only addressed by id, GetByID accepts emails and slugs as well
+	if !utils.IsValidUUID(id) {
+		return user.User{}, ErrNotExist
+	}
+	u, err := s.userService.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, user.ErrNotExist) {
+			return user.User{}, ErrNotExist
+		}
+		return user.User{}, err
+	}
+	member, err := s.isMember(ctx, orgID, u.ID)
+	if err != nil {
+		return user.User{}, err
+	}
+	if !member {
+		return user.User{}, ErrNotExist
+	}
+	return u, nil
+}
+
+// saveUser saves the display name of a member or removes it from the
+// organization when it is no longer active
+func (s Service) saveUser(ctx context.Context, orgID string, existing user.User, u User) (User, error) {
+	if !u.Active {
+		if err := s.removeMember(ctx, orgID, existing.ID); err != nil {
+			return User{}, err
+		}
+		return toUser(existing, nil, false), nil
+	}
+	return s.saveProfile(ctx, orgID, existing, u.Title())
+}
+
+// saveProfile updates the title of a user owned by the organization, the
+// display name of any other member is kept on its profile in the organization
+func (s Service) saveProfile(ctx context.Context, orgID string, existing user.User, title string) (User, error) {
+	owned, err := s.ownsUser(ctx, orgID, existing)
+	if err != nil {
+		return User{}, err
+	}
+	if owned {
+		if title != existing.Title {
+			existing.Title = title
+			updated, err := s.userService.Update(ctx, existing)
+			if err != nil {
+				return User{}, err
+			}
+			existing = updated
+		}
+		return toUser(existing, nil, true), nil
+	}
+
+	current, err := s.toUser(ctx, orgID, existing, true)
+	if err != nil {
+		return User{}, err
+	}
+	if title == current.DisplayName {
+		return current, nil
+	}
+	profile, err := s.repository.Upsert(ctx, Profile{
+		OrgID:       orgID,
+		UserID:      existing.ID,
+		DisplayName: title,
+	})
+	if err != nil {
+		return User{}, err
+	}
+	return toUser(existing, map[string]string{existing.ID: profile.DisplayName}, true), nil
+}
+
+// ownsUser reports whether the organization verified the domain of the email
+// of the user, only then the identity provider may change the user itself
+func (s Service) ownsUser(ctx context.Context, orgID string, u user.User) (bool, error) {
+	domains, err := s.domainService.List(ctx, domain.Filter{
+		OrgID: orgID,
+		State: domain.Verified,
+	})
+	if err != nil {
+		return false, err
+	}
+	emailDomain := utils.ExtractDomainFromEmail(u.Email)
+	return slices.ContainsFunc(domains, func(d domain.Domain) bool {
+		return strings.EqualFold(d.Name, emailDomain)
+	}), nil
+}
+
+// removeMember removes the user and its profile from the organization, the
+// last admin is kept so the organization can still be managed
+func (s Service) removeMember(ctx context.Context, orgID, userID string) error {
+	admins, err := s.userService.ListByOrg(ctx, orgID, organization.AdminRole)
+	if err != nil {
+		return err
+	}
+	if len(admins) == 1 && admins[0].ID == userID {
+		return ErrLastAdmin
+	}
+	if err := s.deleterService.RemoveUsersFromOrg(ctx, orgID, []string{userID}); err != nil {
+		return err
+	}
+	if err := s.repository.Delete(ctx, orgID, userID); err != nil && !errors.Is(err, ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s Service) isMember(ctx context.Context, orgID, userID string) (bool, error) {
+	members, err := s.userService.ListByOrg(ctx, orgID, "")
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(members, func(u user.User) bool {
+		return u.ID == userID
+	}), nil
+}
+
+// toUser maps a member with the display name of its profile in the organization
+func (s Service) toUser(ctx context.Context, orgID string, u user.User, active bool) (User, error) {
+	profile, err := s.repository.Get(ctx, orgID, u.ID)
+	switch {
+	case errors.Is(err, ErrNotExist):
+		return toUser(u, nil, active), nil
+	case err != nil:
+		return User{}, err
+	}
+	return toUser(u, map[string]string{u.ID: profile.DisplayName}, active), nil
+}
+
+// displayNames returns the display names of the profiles in the organization
+// by user id
+func (s Service) displayNames(ctx context.Context, orgID string) (map[string]string, error) {
+	profiles, err := s.repository.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(profiles))
+	for _, p := range profiles {
+		names[p.UserID] = p.DisplayName
+	}
+	return names, nil
+}
+
+// ListGroups lists the groups of the organization matching the filter
+func (s Service) ListGroups(ctx context.Context, orgID, filter string, page Page) (ListResult[Group], error) {
+	flt, err := parseOptionalFilter(filter)
+	if err != nil {
+		return ListResult[Group]{}, err
+	}
+	groups, err := s.groupService.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return ListResult[Group]{}, err
+	}
+	slices.SortFunc(groups, func(a, b group.Group) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	displayNames, err := s.displayNames(ctx, orgID)
+	if err != nil {
+		return ListResult[Group]{}, err
+	}
+
+	result := make([]Group, 0, len(groups))
+	for _, grp := range groups {
+		g, err := s.toGroup(ctx, grp, displayNames)
+		if err != nil {
+			return ListResult[Group]{}, err
+		}
+		if flt == nil || flt.Match(g) {
+			result = append(result, g)
+		}
+	}
+	return paginate(result, page), nil
+}
+
+// GetGroup returns a group of the organization with its members
+func (s Service) GetGroup(ctx context.Context, orgID, id string) (Group, error) {
+	grp, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return Group{}, err
+	}
+	return s.groupOf(ctx, grp)
+}
+
+// CreateGroup creates a group in the organization owned by the service user
+// of the identity provider, the members must belong to the organization
+func (s Service) CreateGroup(ctx context.Context, orgID string, g Group) (Group, error) {
+	name := str.GenerateSlug(g.DisplayName)
+	if name == "" {
+		return Group{}, fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+	memberIDs := memberIDs(g.Members)
+	if err := s.checkMembers(ctx, orgID, memberIDs); err != nil {
+		return Group{}, err
+	}
+
+	created, err := s.groupService.Create(ctx, group.Group{
+		Name:           name,
+		Title:          g.DisplayName,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, group.ErrConflict) {
+			return Group{}, ErrConflict
+		}
+		return Group{}, err
+	}
+	if len(memberIDs) > 0 {
+		if err := s.groupService.AddUsers(ctx, created.ID, memberIDs); err != nil {
+			return Group{}, err
+		}
+	}
+	return s.groupOf(ctx, created)
+}
+
+// ReplaceGroup updates the title and the members of the group with a full representation
+func (s Service) ReplaceGroup(ctx context.Context, orgID, id string, g Group) (Group, error) {
+	grp, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return Group{}, err
+	}
+	current, err := s.groupOf(ctx, grp)
+	if err != nil {
+		return Group{}, err
+	}
+	return s.saveGroup(ctx, orgID, grp, current, g)
+}
+
+// PatchGroup applies the operations of a PatchOp request to the group
+func (s Service) PatchGroup(ctx context.Context, orgID, id string, ops []PatchOperation) (Group, error) {
+	grp, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return Group{}, err
+	}
+	current, err := s.groupOf(ctx, grp)
+	if err != nil {
+		return Group{}, err
+	}
+	patched, err := applyGroupPatch(current, ops)
+	if err != nil {
+		return Group{}, err
+	}
+	return s.saveGroup(ctx, orgID, grp, current, patched)
+}
+
+// DeleteGroup deletes the group of the organization
+func (s Service) DeleteGroup(ctx context.Context, orgID, id string) error {
+	grp, err := s.getGroup(ctx, orgID, id)
+	if err != nil {
+		return err
+	}
+	return s.groupService.Delete(ctx, grp.ID)
+}
+
+func (s Service) getGroup(ctx context.Context, orgID, id string) (group.Group, error) {
+	if !utils.IsValidUUID(id) {
+		return group.Group{}, ErrNotExist
+	}
+	grp, err := s.groupService.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, group.ErrNotExist) {
+			return group.Group{}, ErrNotExist
+		}
+		return group.Group{}, err
+	}
+	if grp.OrganizationID != orgID {
+		return group.Group{}, ErrNotExist
+	}
+	return grp, nil
+}
+
+// saveGroup updates the title of the group and adds or removes the users
+// which differ between the current and the desired members
+func (s Service) saveGroup(ctx context.Context, orgID string, grp group.Group, current, desired Group) (Group, error) {
+	if strings.TrimSpace(desired.DisplayName) == "" {
+		return Group{}, fmt.Errorf("%w: displayName is required", ErrInvalidValue)
+	}
+	if desired.DisplayName != grp.Title {
+		grp.Title = desired.DisplayName
+		updated, err := s.groupService.Update(ctx, grp)
+		if err != nil {
+			return Group{}, err
+		}
+		grp = updated
+	}
+
+	currentIDs, desiredIDs := memberIDs(current.Members), memberIDs(desired.Members)
+	var added, removed []string
+	for _, id := range desiredIDs {
+		if !slices.Contains(currentIDs, id) {
+			added = append(added, id)
+		}
+	}
+	for _, id := range currentIDs {
+		if !slices.Contains(desiredIDs, id) {
+			removed = append(removed, id)
+		}
+	}
+	if len(added) > 0 {
+		if err := s.checkMembers(ctx, orgID, added); err != nil {
+			return Group{}, err
+		}
+		if err := s.groupService.AddUsers(ctx, grp.ID, added); err != nil {
+			return Group{}, err
+		}
+	}
+	if len(removed) > 0 {
+		if err := s.groupService.RemoveUsers(ctx, grp.ID, removed); err != nil {
+			return Group{}, err
+		}
+	}
+	return s.groupOf(ctx, grp)
+}
+
+// checkMembers ensures the users belong to the organization
+func (s Service) checkMembers(ctx context.Context, orgID string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	members, err := s.userService.ListByOrg(ctx, orgID, "")
+	if err != nil {
+		return err
+	}
+	for _, id := range userIDs {
+		if !slices.ContainsFunc(members, func(u user.User) bool { return u.ID == id }) {
+			return fmt.Errorf("%w: user %s is not a member of the organization", ErrInvalidValue, id)
+		}
+	}
+	return nil
+}
+
+// groupOf maps a group of the organization with the display names of its members
+func (s Service) groupOf(ctx context.Context, grp group.Group) (Group, error) {
+	displayNames, err := s.displayNames(ctx, grp.OrganizationID)
+	if err != nil {
+		return Group{}, err
+	}
+	return s.toGroup(ctx, grp, displayNames)
+}
+
+func (s Service) toGroup(ctx context.Context, grp group.Group, displayNames map[string]string) (Group, error) {
+	users, err := s.userService.ListByGroup(ctx, grp.ID, "")
+	if err != nil {
+		return Group{}, err
+	}
+	sortUsers(users)
+	members := make([]Member, 0, len(users))
+	for _, u := range users {
+		members = append(members, Member{Value: u.ID, Display: toUser(u, displayNames, true).DisplayName})
+	}
+	return Group{
+		ID:          grp.ID,
+		DisplayName: grp.Title,
+		Members:     members,
+		CreatedAt:   grp.CreatedAt,
+		UpdatedAt:   grp.UpdatedAt,
+	}, nil
+}
+
+// toUser maps a frontier user, it is active as long as it is a member of the
+// organization. The display name of its profile in the organization takes
+// precedence over the title of the user
+func toUser(u user.User, displayNames map[string]string, member bool) User {
+	displayName, ok := displayNames[u.ID]
+	if !ok {
+		displayName = u.Title
+	}
+	return User{
+		ID:          u.ID,
+		UserName:    u.Email,
+		DisplayName: displayName,
+		Active:      member,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
+
+func memberIDs(members []Member) []string {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		if !slices.Contains(ids, m.Value) {
+			ids = append(ids, m.Value)
+		}
+	}
+	return ids
+}
+
+// sortUsers orders users by creation for stable pagination
+func sortUsers(users []user.User) {
+	slices.SortFunc(users, func(a, b user.User) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+}
+
+func parseOptionalFilter(filter string) (Filter, error) {
+	if strings.TrimSpace(filter) == "" {
+		return nil, nil
+	}
+	return ParseFilter(filter)
+}
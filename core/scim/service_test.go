@@ -0,0 +1,493 @@
+package scim_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/domain"
+	"github.com/raystack/frontier/core/group"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/scim"
+	"github.com/raystack/frontier/core/scim/mocks"
+	"github.com/raystack/frontier/core/serviceuser"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testOrgID   = "9f256f86-31a3-11ec-8d3d-0242ac130003"
+	testUserID  = "2e73f4a2-3763-4fc7-a0d2-5ac0b7a3bde1"
+	testAdminID = "8b8b0d43-0a79-4c2b-8e0a-2cbd0f0c9b5c"
+	testGroupID = "c2d85306-96f4-4895-98b4-c3e5c2f3084d"
+)
+
+var scimNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type scimMocks struct {
+	repository   *mocks.Repository
+	orgs         *mocks.OrgService
+	users        *mocks.UserService
+	groups       *mocks.GroupService
+	deleter      *mocks.DeleterService
+	serviceUsers *mocks.ServiceUserService
+	resources    *mocks.ResourceService
+	domains      *mocks.DomainService
+}
+
+func newSCIMService(t *testing.T) (*scim.Service, scimMocks) {
+	m := scimMocks{
+		repository:   mocks.NewRepository(t),
+		orgs:         mocks.NewOrgService(t),
+		users:        mocks.NewUserService(t),
+		groups:       mocks.NewGroupService(t),
+		deleter:      mocks.NewDeleterService(t),
+		serviceUsers: mocks.NewServiceUserService(t),
+		resources:    mocks.NewResourceService(t),
+		domains:      mocks.NewDomainService(t),
+	}
+	return scim.NewService(m.repository, m.orgs, m.users, m.groups, m.deleter, m.serviceUsers, m.resources, m.domains), m
+}
+
+// verifiedDomains expects the organization to own the users of acme.org
+func verifiedDomains(m scimMocks) {
+	m.domains.EXPECT().List(mock.Anything, domain.Filter{OrgID: testOrgID, State: domain.Verified}).
+		Return([]domain.Domain{{Name: "acme.org", OrgID: testOrgID, State: domain.Verified}}, nil)
+}
+
+func TestService_Authenticate(t *testing.T) {
+	serviceUser := serviceuser.ServiceUser{ID: "su-id", OrgID: testOrgID, State: serviceuser.Enabled.String()}
+	opaqueToken := serviceuser.Credential{ID: "token-id", Type: serviceuser.OpaqueTokenCredentialType}
+
+	tests := []struct {
+		name    string
+		tokenID string
+		setup   func(m scimMocks)
+		wantErr error
+	}{
+		{
+			name:    "should authenticate a token of a service user of the organization",
+			tokenID: "token-id",
+			setup: func(m scimMocks) {
+				m.serviceUsers.EXPECT().GetBySecret(mock.Anything, "token-id", "secret").Return(serviceUser, nil)
+				m.serviceUsers.EXPECT().ListToken(mock.Anything, "su-id").Return([]serviceuser.Credential{opaqueToken}, nil)
+				m.orgs.EXPECT().Get(mock.Anything, "acme").Return(organization.Organization{ID: testOrgID}, nil)
+				m.resources.EXPECT().CheckAuthz(mock.Anything, resource.Check{
+					Object:     relation.Object{ID: testOrgID, Namespace: schema.OrganizationNamespace},
+					Subject:    relation.Subject{ID: "su-id", Namespace: schema.ServiceUserPrincipal},
+					Permission: schema.UpdatePermission,
+				}).Return(true, nil)
+			},
+		},
+		{
+			name:    "should reject a missing token",
+			wantErr: scim.ErrUnauthenticated,
+		},
+		{
+			name:    "should reject client secrets",
+			tokenID: "secret-id",
+			setup: func(m scimMocks) {
+				m.serviceUsers.EXPECT().GetBySecret(mock.Anything, "secret-id", "secret").Return(serviceUser, nil)
+				m.serviceUsers.EXPECT().ListToken(mock.Anything, "su-id").Return([]serviceuser.Credential{
+					opaqueToken, {ID: "secret-id", Type: serviceuser.ClientSecretCredentialType},
+				}, nil)
+			},
+			wantErr: scim.ErrUnauthenticated,
+		},
+		{
+			name:    "should reject an invalid token",
+			tokenID: "token-id",
+			setup: func(m scimMocks) {
+				m.serviceUsers.EXPECT().GetBySecret(mock.Anything, "token-id", "secret").
+					Return(serviceuser.ServiceUser{}, serviceuser.ErrInvalidCred)
+			},
+			wantErr: scim.ErrUnauthenticated,
+		},
+		{
+			name:    "should reject a service user of another organization",
+			tokenID: "token-id",
+			setup: func(m scimMocks) {
+				m.serviceUsers.EXPECT().GetBySecret(mock.Anything, "token-id", "secret").Return(serviceUser, nil)
+				m.serviceUsers.EXPECT().ListToken(mock.Anything, "su-id").Return([]serviceuser.Credential{opaqueToken}, nil)
+				m.orgs.EXPECT().Get(mock.Anything, "acme").Return(organization.Organization{ID: "another-org"}, nil)
+			},
+			wantErr: scim.ErrForbidden,
+		},
+		{
+			name:    "should reject a service user not allowed to update the organization",
+			tokenID: "token-id",
+			setup: func(m scimMocks) {
+				m.serviceUsers.EXPECT().GetBySecret(mock.Anything, "token-id", "secret").Return(serviceUser, nil)
+				m.serviceUsers.EXPECT().ListToken(mock.Anything, "su-id").Return([]serviceuser.Credential{opaqueToken}, nil)
+				m.orgs.EXPECT().Get(mock.Anything, "acme").Return(organization.Organization{ID: testOrgID}, nil)
+				m.resources.EXPECT().CheckAuthz(mock.Anything, mock.Anything).Return(false, nil)
+			},
+			wantErr: scim.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSCIMService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			org, principal, err := s.Authenticate(context.Background(), "acme", tt.tokenID, "secret")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testOrgID, org.ID)
+			assert.Equal(t, authenticate.Principal{
+				ID:          "su-id",
+				Type:        schema.ServiceUserPrincipal,
+				ServiceUser: &serviceUser,
+			}, principal)
+		})
+	}
+}
+
+func TestService_ListUsers(t *testing.T) {
+	s, m := newSCIMService(t)
+	m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{
+		{ID: "user-3", Email: "jane@acme.org", CreatedAt: scimNow.Add(time.Minute)},
+		{ID: "user-1", Email: "john@acme.org", CreatedAt: scimNow},
+		{ID: "user-2", Email: "jack@example.com", CreatedAt: scimNow},
+	}, nil)
+	m.repository.EXPECT().List(mock.Anything, testOrgID).Return([]scim.Profile{
+		{OrgID: testOrgID, UserID: "user-3", DisplayName: "Jane"},
+	}, nil)
+
+	got, err := s.ListUsers(context.Background(), testOrgID, `userName ew "@acme.org"`, scim.Page{StartIndex: 2, Count: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got.TotalResults)
+	assert.Equal(t, 2, got.StartIndex)
+	assert.Equal(t, []scim.User{{
+		ID:          "user-3",
+		UserName:    "jane@acme.org",
+		DisplayName: "Jane",
+		Active:      true,
+		CreatedAt:   scimNow.Add(time.Minute),
+	}}, got.Resources)
+}
+
+func TestService_CreateUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    scim.User
+		setup   func(m scimMocks)
+		want    scim.User
+		wantErr error
+	}{
+		{
+			name: "should create a new user and add it to the organization",
+			user: scim.User{UserName: "John.Doe@acme.org", GivenName: "John", FamilyName: "Doe", Active: true},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByEmail(mock.Anything, "john.doe@acme.org").Return(user.User{}, user.ErrNotExist)
+				m.users.EXPECT().Create(mock.Anything, user.User{
+					Name:  "johndoe_acme_org",
+					Email: "john.doe@acme.org",
+					Title: "John Doe",
+				}).Return(user.User{ID: testUserID, Email: "john.doe@acme.org", Title: "John Doe"}, nil)
+				m.orgs.EXPECT().AddUsers(mock.Anything, testOrgID, []string{testUserID}).Return(nil)
+			},
+			want: scim.User{ID: testUserID, UserName: "john.doe@acme.org", DisplayName: "John Doe", Active: true},
+		},
+		{
+			name: "should add an existing user to the organization",
+			user: scim.User{UserName: "john.doe@acme.org", DisplayName: "John Doe", Active: true},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByEmail(mock.Anything, "john.doe@acme.org").
+					Return(user.User{ID: testUserID, Email: "john.doe@acme.org", Title: "John Doe"}, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{{ID: testAdminID}}, nil)
+				m.orgs.EXPECT().AddUsers(mock.Anything, testOrgID, []string{testUserID}).Return(nil)
+				verifiedDomains(m)
+			},
+			want: scim.User{ID: testUserID, UserName: "john.doe@acme.org", DisplayName: "John Doe", Active: true},
+		},
+		{
+			name: "should keep the display name of a user of another domain on its profile",
+			user: scim.User{UserName: "john.doe@example.com", DisplayName: "Johnny", Active: true},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByEmail(mock.Anything, "john.doe@example.com").
+					Return(user.User{ID: testUserID, Email: "john.doe@example.com", Title: "John Doe"}, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{{ID: testAdminID}}, nil)
+				m.orgs.EXPECT().AddUsers(mock.Anything, testOrgID, []string{testUserID}).Return(nil)
+				verifiedDomains(m)
+				m.repository.EXPECT().Get(mock.Anything, testOrgID, testUserID).Return(scim.Profile{}, scim.ErrNotExist)
+				m.repository.EXPECT().Upsert(mock.Anything, scim.Profile{
+					OrgID:       testOrgID,
+					UserID:      testUserID,
+					DisplayName: "Johnny",
+				}).Return(scim.Profile{OrgID: testOrgID, UserID: testUserID, DisplayName: "Johnny"}, nil)
+			},
+			want: scim.User{ID: testUserID, UserName: "john.doe@example.com", DisplayName: "Johnny", Active: true},
+		},
+		{
+			name: "should reject a user already provisioned",
+			user: scim.User{UserName: "john.doe@acme.org", Active: true},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByEmail(mock.Anything, "john.doe@acme.org").Return(user.User{ID: testUserID}, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{{ID: testUserID}}, nil)
+			},
+			wantErr: scim.ErrConflict,
+		},
+		{
+			name:    "should reject a user name which isn't an email",
+			user:    scim.User{UserName: "john", Active: true},
+			wantErr: scim.ErrInvalidValue,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSCIMService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			got, err := s.CreateUser(context.Background(), testOrgID, tt.user)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_PatchUser(t *testing.T) {
+	john := user.User{ID: testUserID, Email: "john@acme.org", Title: "John"}
+	jack := user.User{ID: testUserID, Email: "jack@example.com", Title: "Jack"}
+
+	tests := []struct {
+		name    string
+		ops     []scim.PatchOperation
+		setup   func(m scimMocks)
+		want    scim.User
+		wantErr error
+	}{
+		{
+			name: "should update the title of a user of a verified domain",
+			ops:  []scim.PatchOperation{{Op: "Replace", Path: "displayName", Value: "John Doe"}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(john, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{john}, nil)
+				m.repository.EXPECT().Get(mock.Anything, testOrgID, testUserID).Return(scim.Profile{}, scim.ErrNotExist)
+				verifiedDomains(m)
+				updated := john
+				updated.Title = "John Doe"
+				m.users.EXPECT().Update(mock.Anything, updated).Return(updated, nil)
+			},
+			want: scim.User{ID: testUserID, UserName: "john@acme.org", DisplayName: "John Doe", Active: true},
+		},
+		{
+			name: "should not update the title of a user the organization doesn't own",
+			ops:  []scim.PatchOperation{{Op: "Replace", Path: "displayName", Value: "Jack Doe"}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(jack, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{jack}, nil)
+				m.repository.EXPECT().Get(mock.Anything, testOrgID, testUserID).Return(scim.Profile{}, scim.ErrNotExist)
+				verifiedDomains(m)
+				m.repository.EXPECT().Upsert(mock.Anything, scim.Profile{
+					OrgID:       testOrgID,
+					UserID:      testUserID,
+					DisplayName: "Jack Doe",
+				}).Return(scim.Profile{OrgID: testOrgID, UserID: testUserID, DisplayName: "Jack Doe"}, nil)
+			},
+			want: scim.User{ID: testUserID, UserName: "jack@example.com", DisplayName: "Jack Doe", Active: true},
+		},
+		{
+			name: "should remove a deactivated user and its profile from the organization",
+			ops:  []scim.PatchOperation{{Op: "replace", Value: map[string]any{"active": "False"}}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(jack, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{jack}, nil)
+				m.repository.EXPECT().Get(mock.Anything, testOrgID, testUserID).
+					Return(scim.Profile{OrgID: testOrgID, UserID: testUserID, DisplayName: "Jack Doe"}, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, organization.AdminRole).Return([]user.User{{ID: testAdminID}}, nil)
+				m.deleter.EXPECT().RemoveUsersFromOrg(mock.Anything, testOrgID, []string{testUserID}).Return(nil)
+				m.repository.EXPECT().Delete(mock.Anything, testOrgID, testUserID).Return(nil)
+			},
+			want: scim.User{ID: testUserID, UserName: "jack@example.com", DisplayName: "Jack", Active: false},
+		},
+		{
+			name: "should keep the last admin of the organization",
+			ops:  []scim.PatchOperation{{Op: "replace", Path: "active", Value: false}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(john, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{john}, nil)
+				m.repository.EXPECT().Get(mock.Anything, testOrgID, testUserID).Return(scim.Profile{}, scim.ErrNotExist)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, organization.AdminRole).Return([]user.User{john}, nil)
+			},
+			wantErr: scim.ErrLastAdmin,
+		},
+		{
+			name: "should not add users of other organizations",
+			ops:  []scim.PatchOperation{{Op: "replace", Path: "active", Value: true}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(john, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{}, nil)
+			},
+			wantErr: scim.ErrNotExist,
+		},
+		{
+			name: "should not expose users outside of the organization",
+			ops:  []scim.PatchOperation{{Op: "replace", Path: "displayName", Value: "John Doe"}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(john, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{}, nil)
+			},
+			wantErr: scim.ErrNotExist,
+		},
+		{
+			name: "should not change the email",
+			ops:  []scim.PatchOperation{{Op: "replace", Path: "userName", Value: "jane@acme.org"}},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(john, nil)
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{john}, nil)
+				m.repository.EXPECT().Get(mock.Anything, testOrgID, testUserID).Return(scim.Profile{}, scim.ErrNotExist)
+			},
+			wantErr: scim.ErrMutability,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSCIMService(t)
+			tt.setup(m)
+			got, err := s.PatchUser(context.Background(), testOrgID, testUserID, tt.ops)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestService_ReplaceUser(t *testing.T) {
+	t.Run("should not add users of other organizations", func(t *testing.T) {
+		s, m := newSCIMService(t)
+		m.users.EXPECT().GetByID(mock.Anything, testUserID).Return(user.User{ID: testUserID, Email: "john@example.com"}, nil)
+		m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{{ID: testAdminID}}, nil)
+
+		_, err := s.ReplaceUser(context.Background(), testOrgID, testUserID, scim.User{
+			UserName:    "john@example.com",
+			DisplayName: "Mallory",
+			Active:      true,
+		})
+		assert.ErrorIs(t, err, scim.ErrNotExist)
+	})
+}
+
+func TestService_CreateGroup(t *testing.T) {
+	t.Run("should create the group with the members", func(t *testing.T) {
+		s, m := newSCIMService(t)
+		m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{{ID: testUserID}}, nil)
+		m.groups.EXPECT().Create(mock.Anything, group.Group{
+			Name:           "Platform-Engineers",
+			Title:          "Platform Engineers",
+			OrganizationID: testOrgID,
+		}).Return(group.Group{ID: testGroupID, Title: "Platform Engineers", OrganizationID: testOrgID}, nil)
+		m.groups.EXPECT().AddUsers(mock.Anything, testGroupID, []string{testUserID}).Return(nil)
+		m.repository.EXPECT().List(mock.Anything, testOrgID).Return([]scim.Profile{}, nil)
+		m.users.EXPECT().ListByGroup(mock.Anything, testGroupID, "").Return([]user.User{{ID: testUserID, Title: "John"}}, nil)
+
+		got, err := s.CreateGroup(context.Background(), testOrgID, scim.Group{
+			DisplayName: "Platform Engineers",
+			Members:     []scim.Member{{Value: testUserID}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, scim.Group{
+			ID:          testGroupID,
+			DisplayName: "Platform Engineers",
+			Members:     []scim.Member{{Value: testUserID, Display: "John"}},
+		}, got)
+	})
+
+	t.Run("should reject members outside of the organization", func(t *testing.T) {
+		s, m := newSCIMService(t)
+		m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{}, nil)
+
+		_, err := s.CreateGroup(context.Background(), testOrgID, scim.Group{
+			DisplayName: "Platform Engineers",
+			Members:     []scim.Member{{Value: testUserID}},
+		})
+		assert.ErrorIs(t, err, scim.ErrInvalidValue)
+	})
+}
+
+func TestService_PatchGroup(t *testing.T) {
+	engineers := group.Group{ID: testGroupID, Title: "Engineers", OrganizationID: testOrgID}
+
+	tests := []struct {
+		name    string
+		ops     []scim.PatchOperation
+		setup   func(m scimMocks)
+		wantErr error
+	}{
+		{
+			name: "should add and remove members",
+			ops: []scim.PatchOperation{
+				{Op: "add", Path: "members", Value: []any{map[string]any{"value": testUserID}}},
+				{Op: "remove", Path: `members[value eq "` + testAdminID + `"]`},
+			},
+			setup: func(m scimMocks) {
+				m.users.EXPECT().ListByOrg(mock.Anything, testOrgID, "").Return([]user.User{{ID: testUserID}, {ID: testAdminID}}, nil)
+				m.groups.EXPECT().AddUsers(mock.Anything, testGroupID, []string{testUserID}).Return(nil)
+				m.groups.EXPECT().RemoveUsers(mock.Anything, testGroupID, []string{testAdminID}).Return(nil)
+			},
+		},
+		{
+			name: "should rename the group",
+			ops:  []scim.PatchOperation{{Op: "replace", Value: map[string]any{"displayName": "Platform Engineers"}}},
+			setup: func(m scimMocks) {
+				renamed := engineers
+				renamed.Title = "Platform Engineers"
+				m.groups.EXPECT().Update(mock.Anything, renamed).Return(renamed, nil)
+			},
+		},
+		{
+			name:    "should reject unsupported operations",
+			ops:     []scim.PatchOperation{{Op: "move", Path: "members"}},
+			wantErr: scim.ErrInvalidValue,
+		},
+		{
+			name:    "should reject removing the display name",
+			ops:     []scim.PatchOperation{{Op: "remove", Path: "displayName"}},
+			wantErr: scim.ErrInvalidPath,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newSCIMService(t)
+			m.groups.EXPECT().Get(mock.Anything, testGroupID).Return(engineers, nil)
+			m.repository.EXPECT().List(mock.Anything, testOrgID).Return([]scim.Profile{}, nil)
+			m.users.EXPECT().ListByGroup(mock.Anything, testGroupID, "").Return([]user.User{{ID: testAdminID}}, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+			_, err := s.PatchGroup(context.Background(), testOrgID, testGroupID, tt.ops)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_GetGroup(t *testing.T) {
+	s, m := newSCIMService(t)
+	m.groups.EXPECT().Get(mock.Anything, testGroupID).
+		Return(group.Group{ID: testGroupID, OrganizationID: "another-org"}, nil)
+
+	_, err := s.GetGroup(context.Background(), testOrgID, testGroupID)
+	assert.True(t, errors.Is(err, scim.ErrNotExist))
+}
@@ -0,0 +1,8 @@
+package simulation
+
+import "errors"
+
+var (
+	ErrInvalidDetail = errors.New("invalid simulation detail")
+	ErrTooLarge      = errors.New("simulation has too many checks")
+)
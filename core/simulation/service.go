@@ -0,0 +1,318 @@
+package simulation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/permission"
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+const (
+	// MaxResources bounds the resources of a simulation
+	MaxResources = 20
+	// maxChecks bounds the permissions checked on both sides of a
+	// simulation, there is a check per resource, permission and principal
+	maxChecks = 20000
+)
+
+// SandboxRepository evaluates checks on copies of the relations of the
+// authz engine, the copies are never written to it
+type SandboxRepository interface {
+	Relations(ctx context.Context, objects []relation.Object) ([]relation.Relation, error)
+	Permissions(ctx context.Context, namespace string) ([]string, error)
+	BatchCheck(ctx context.Context, relations []relation.Relation, checks []relation.Relation) ([]relation.CheckPair, error)
+}
+
+type PolicyService interface {
+	Get(ctx context.Context, id string) (policy.Policy, error)
+}
+
+type RoleService interface {
+	Get(ctx context.Context, id string) (role.Role, error)
+}
+
+type PermissionService interface {
+	Get(ctx context.Context, id string) (permission.Permission, error)
+}
+
+type Service struct {
+	sandboxRepository SandboxRepository
+	policyService     PolicyService
+	roleService       RoleService
+	permissionService PermissionService
+}
+
+func NewService(sandboxRepository SandboxRepository, policyService PolicyService,
+	roleService RoleService, permissionService PermissionService) *Service {
+	return &Service{
+		sandboxRepository: sandboxRepository,
+		policyService:     policyService,
+		roleService:       roleService,
+		permissionService: permissionService,
+	}
+}
+
+// Simulate computes how the change would alter the permissions of principals
+// on the resources of the scope. The relations the permissions depend on are
+// copied from the authz engine to a sandbox where they are checked before and
+// after the change, the change itself is never applied. Only the principals
+// whose permissions change are returned.
+func (s Service) Simulate(ctx context.Context, scope Scope, change Change) ([]AccessChange, error) {
+	if len(scope.Resources) == 0 || len(scope.Resources) > MaxResources {
+		return nil, fmt.Errorf("%w: between 1 and %d resources are required", ErrInvalidDetail, MaxResources)
+	}
+	for _, resource := range scope.Resources {
+		if resource.ID == "" || resource.Namespace == "" {
+			return nil, fmt.Errorf("%w: resources must have a namespace and an id", ErrInvalidDetail)
+		}
+	}
+
+	added, removed, objects, err := s.changeRelations(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+	before, err := s.sandboxRepository.Relations(ctx, append(slices.Clone(scope.Resources), objects...))
+	if err != nil {
+		return nil, err
+	}
+	after := apply(before, added, removed)
+
+	checks, err := s.checks(ctx, scope, principals(before, after))
+	if err != nil {
+		return nil, err
+	}
+	if len(checks) > maxChecks {
+		return nil, fmt.Errorf("%w: narrow down the resources or permissions", ErrTooLarge)
+	}
+	if len(checks) == 0 {
+		return nil, nil
+	}
+	beforeResults, err := s.sandboxRepository.BatchCheck(ctx, before, checks)
+	if err != nil {
+		return nil, err
+	}
+	afterResults, err := s.sandboxRepository.BatchCheck(ctx, after, checks)
+	if err != nil {
+		return nil, err
+	}
+	return diff(checks, beforeResults, afterResults), nil
+}
+
+// changeRelations resolves the change to the relations it adds and removes,
+// along with the objects they depend on which may not be related to the
+// resources yet
+func (s Service) changeRelations(ctx context.Context, change Change) (added, removed []relation.Relation, objects []relation.Object, err error) {
+	for _, pol := range change.AddPolicies {
+		if pol.ResourceID == "" || pol.ResourceType == "" || pol.PrincipalID == "" || pol.PrincipalType == "" {
+			return nil, nil, nil, fmt.Errorf("%w: policies require a role, resource and principal", ErrInvalidDetail)
+		}
+		if !pol.ExpiresAt.IsZero() && !pol.ExpiresAt.After(pol.StartsAt) {
+			return nil, nil, nil, fmt.Errorf("%w: policy must expire after it starts", ErrInvalidDetail)
+		}
+		policyRole, err := s.roleService.Get(ctx, pol.RoleID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		pol.RoleID = policyRole.ID
+		pol.ID = uuid.NewString()
+		added = append(added, pol.Relations()...)
+		objects = append(objects, relation.Object{ID: pol.RoleID, Namespace: schema.RoleNamespace})
+		if pol.PrincipalType == schema.GroupPrincipal {
+			objects = append(objects, relation.Object{ID: pol.PrincipalID, Namespace: schema.GroupNamespace})
+		}
+	}
+
+	for _, id := range change.RemovePolicies {
+		pol, err := s.policyService.Get(ctx, id)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		removed = append(removed, pol.Relations()...)
+	}
+
+	for _, roleChange := range change.Roles {
+		changedRole, err := s.roleService.Get(ctx, roleChange.RoleID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		addSlugs, err := s.slugs(ctx, roleChange.AddPermissions)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		removeSlugs, err := s.slugs(ctx, roleChange.RemovePermissions)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		added = append(added, role.PermissionRelations(changedRole.ID, addSlugs)...)
+		removed = append(removed, role.PermissionRelations(changedRole.ID, removeSlugs)...)
+		objects = append(objects, relation.Object{ID: changedRole.ID, Namespace: schema.RoleNamespace})
+	}
+	return added, removed, objects, nil
+}
+
+func (s Service) slugs(ctx context.Context, permissions []string) ([]string, error) {
+	slugs := make([]string, 0, len(permissions))
+	for _, name := range permissions {
+		perm, err := s.permissionService.Get(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		slugs = append(slugs, perm.Slug)
+	}
+	return slugs, nil
+}
+
+// checks lists the check of every permission of the scope for every
+// principal on every resource
+func (s Service) checks(ctx context.Context, scope Scope, principals []relation.Subject) ([]relation.Relation, error) {
+	namespacePermissions := map[string][]string{}
+	var checks []relation.Relation
+	for _, resource := range scope.Resources {
+		permissions, ok := namespacePermissions[resource.Namespace]
+		if !ok {
+			var err error
+			if permissions, err = s.sandboxRepository.Permissions(ctx, resource.Namespace); err != nil {
+				return nil, err
+			}
+			namespacePermissions[resource.Namespace] = permissions
+		}
+		if len(permissions) == 0 {
+			return nil, fmt.Errorf("%w: %s has no permissions", ErrInvalidDetail, resource.Namespace)
+		}
+		if len(scope.Permissions) > 0 {
+			for _, name := range scope.Permissions {
+				if !slices.Contains(permissions, name) {
+					return nil, fmt.Errorf("%w: %s isn't a permission of %s", ErrInvalidDetail, name, resource.Namespace)
+				}
+			}
+			permissions = scope.Permissions
+		}
+		for _, name := range permissions {
+			for _, principal := range principals {
+				checks = append(checks, relation.Relation{
+					Object:       resource,
+					Subject:      principal,
+					RelationName: name,
+				})
+			}
+		}
+	}
+	return checks, nil
+}
+
+// relationKey identifies a relation regardless of its caveat, like the authz
+// engine does
+type relationKey struct {
+	object       relation.Object
+	relationName string
+	subject      relation.Subject
+}
+
+func keyOf(rel relation.Relation) relationKey {
+	return relationKey{
+		object:       rel.Object,
+		relationName: rel.RelationName,
+		subject:      rel.Subject,
+	}
+}
+
+// apply returns a copy of the relations without the removed ones and with
+// the added ones, added relations replace the existing ones
+func apply(relations, added, removed []relation.Relation) []relation.Relation {
+	dropped := map[relationKey]bool{}
+	for _, rel := range removed {
+		dropped[keyOf(rel)] = true
+	}
+	for _, rel := range added {
+		dropped[keyOf(rel)] = true
+	}
+	result := make([]relation.Relation, 0, len(relations)+len(added))
+	for _, rel := range relations {
+		if !dropped[keyOf(rel)] {
+			result = append(result, rel)
+		}
+	}
+	seen := map[relationKey]bool{}
+	for _, rel := range added {
+		if !seen[keyOf(rel)] {
+			seen[keyOf(rel)] = true
+			result = append(result, rel)
+		}
+	}
+	return result
+}
+
+// principals lists the users and service users related to anything in the
+// relations, nobody else can hold a permission the change grants or revokes
+func principals(relationSets ...[]relation.Relation) []relation.Subject {
+	seen := map[relation.Subject]bool{}
+	var found []relation.Subject
+	for _, relations := range relationSets {
+		for _, rel := range relations {
+			subject := relation.Subject{ID: rel.Subject.ID, Namespace: rel.Subject.Namespace}
+			if subject.ID == "*" || seen[subject] ||
+				(subject.Namespace != schema.UserPrincipal && subject.Namespace != schema.ServiceUserPrincipal) {
+				continue
+			}
+			seen[subject] = true
+			found = append(found, subject)
+		}
+	}
+	slices.SortFunc(found, func(a, b relation.Subject) int {
+		return strings.Compare(a.Namespace+":"+a.ID, b.Namespace+":"+b.ID)
+	})
+	return found
+}
+
+// diff lists the principals whose permissions differ between the results of
+// the checks before and after the change
+func diff(checks []relation.Relation, before, after []relation.CheckPair) []AccessChange {
+	type access struct {
+		principal relation.Subject
+		resource  relation.Object
+	}
+	var order []access
+	changes := map[access]*AccessChange{}
+	for i, check := range checks {
+		key := access{principal: check.Subject, resource: check.Object}
+		change, ok := changes[key]
+		if !ok {
+			change = &AccessChange{Principal: check.Subject, Resource: check.Object}
+			changes[key] = change
+			order = append(order, key)
+		}
+		hadBefore, hasAfter := before[i].Status, after[i].Status
+		if hadBefore {
+			change.Before = append(change.Before, check.RelationName)
+		}
+		if hasAfter {
+			change.After = append(change.After, check.RelationName)
+		}
+		switch {
+		case hasAfter && !hadBefore:
+			change.Gained = append(change.Gained, check.RelationName)
+		case hadBefore && !hasAfter:
+			change.Lost = append(change.Lost, check.RelationName)
+		}
+	}
+
+	var result []AccessChange
+	for _, key := range order {
+		change := changes[key]
+		if len(change.Gained) == 0 && len(change.Lost) == 0 {
+			continue
+		}
+		for _, permissions := range [][]string{change.Before, change.After, change.Gained, change.Lost} {
+			slices.Sort(permissions)
+		}
+		result = append(result, *change)
+	}
+	return result
+}
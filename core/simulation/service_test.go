@@ -0,0 +1,209 @@
+package simulation_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/raystack/frontier/core/permission"
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/core/simulation"
+	"github.com/raystack/frontier/core/simulation/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	project = relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}
+	alice   = relation.Subject{ID: "alice-id", Namespace: schema.UserPrincipal}
+	bob     = relation.Subject{ID: "bob-id", Namespace: schema.UserPrincipal}
+
+	viewerPolicy = policy.Policy{
+		ID:            "policy-id",
+		RoleID:        "viewer-role-id",
+		ResourceID:    "project-id",
+		ResourceType:  schema.ProjectNamespace,
+		PrincipalID:   "alice-id",
+		PrincipalType: schema.UserPrincipal,
+	}
+)
+
+// projectRelations are the relations of a project alice views through a
+// policy and bob is a member of a group of
+func projectRelations() []relation.Relation {
+	relations := append(viewerPolicy.Relations(), role.PermissionRelations("viewer-role-id", []string{"app_project_get"})...)
+	return append(relations, relation.Relation{
+		Object:       relation.Object{ID: "group-id", Namespace: schema.GroupNamespace},
+		Subject:      bob,
+		RelationName: schema.MemberRelationName,
+	})
+}
+
+type serviceMocks struct {
+	sandbox     *mocks.SandboxRepository
+	policies    *mocks.PolicyService
+	roles       *mocks.RoleService
+	permissions *mocks.PermissionService
+}
+
+func newTestService(t *testing.T) (*simulation.Service, serviceMocks) {
+	m := serviceMocks{
+		sandbox:     mocks.NewSandboxRepository(t),
+		policies:    mocks.NewPolicyService(t),
+		roles:       mocks.NewRoleService(t),
+		permissions: mocks.NewPermissionService(t),
+	}
+	return simulation.NewService(m.sandbox, m.policies, m.roles, m.permissions), m
+}
+
+func hasRelation(relations []relation.Relation, object relation.Object, name string, subject relation.Subject) bool {
+	return slices.ContainsFunc(relations, func(rel relation.Relation) bool {
+		return rel.Object == object && rel.RelationName == name && rel.Subject == subject
+	})
+}
+
+// checkResults answers the checks with status
+func checkResults(status func(check relation.Relation) bool) func(context.Context, []relation.Relation, []relation.Relation) ([]relation.CheckPair, error) {
+	return func(_ context.Context, _ []relation.Relation, checks []relation.Relation) ([]relation.CheckPair, error) {
+		result := make([]relation.CheckPair, 0, len(checks))
+		for _, check := range checks {
+			result = append(result, relation.CheckPair{Relation: check, Status: status(check)})
+		}
+		return result, nil
+	}
+}
+
+func TestService_Simulate(t *testing.T) {
+	scope := simulation.Scope{Resources: []relation.Object{project}}
+
+	t.Run("should report the permissions lost by deleting a policy", func(t *testing.T) {
+		svc, m := newTestService(t)
+		m.policies.EXPECT().Get(mock.Anything, "policy-id").Return(viewerPolicy, nil)
+		m.sandbox.EXPECT().Relations(mock.Anything, []relation.Object{project}).Return(projectRelations(), nil)
+		m.sandbox.EXPECT().Permissions(mock.Anything, schema.ProjectNamespace).Return([]string{"get", "delete"}, nil)
+		m.sandbox.EXPECT().BatchCheck(mock.Anything, projectRelations(), mock.Anything).
+			RunAndReturn(checkResults(func(check relation.Relation) bool {
+				return check.Subject == alice && check.RelationName == "get"
+			}))
+		m.sandbox.EXPECT().BatchCheck(mock.Anything, mock.MatchedBy(func(relations []relation.Relation) bool {
+			return !hasRelation(relations, project, schema.RoleGrantRelationName,
+				relation.Subject{ID: "policy-id", Namespace: schema.RoleBindingNamespace}) &&
+				len(relations) == len(projectRelations())-3
+		}), mock.Anything).RunAndReturn(checkResults(func(relation.Relation) bool {
+			return false
+		}))
+
+		got, err := svc.Simulate(context.Background(), scope, simulation.Change{RemovePolicies: []string{"policy-id"}})
+		assert.NoError(t, err)
+		assert.Equal(t, []simulation.AccessChange{{
+			Principal: alice,
+			Resource:  project,
+			Before:    []string{"get"},
+			Lost:      []string{"get"},
+		}}, got)
+	})
+
+	t.Run("should copy the relations of the role and group of added policies", func(t *testing.T) {
+		svc, m := newTestService(t)
+		m.roles.EXPECT().Get(mock.Anything, schema.RoleProjectOwner).Return(role.Role{ID: "owner-role-id"}, nil)
+		m.sandbox.EXPECT().Relations(mock.Anything, []relation.Object{
+			project,
+			{ID: "owner-role-id", Namespace: schema.RoleNamespace},
+			{ID: "group-id", Namespace: schema.GroupNamespace},
+		}).Return(projectRelations(), nil)
+		m.sandbox.EXPECT().Permissions(mock.Anything, schema.ProjectNamespace).Return([]string{"get", "delete"}, nil)
+		m.sandbox.EXPECT().BatchCheck(mock.Anything, projectRelations(), mock.Anything).
+			RunAndReturn(checkResults(func(check relation.Relation) bool {
+				return check.Subject == alice && check.RelationName == "get"
+			}))
+		m.sandbox.EXPECT().BatchCheck(mock.Anything, mock.MatchedBy(func(relations []relation.Relation) bool {
+			return slices.ContainsFunc(relations, func(rel relation.Relation) bool {
+				return rel.RelationName == schema.RoleBearerRelationName &&
+					rel.Subject == relation.Subject{ID: "group-id", Namespace: schema.GroupNamespace, SubRelationName: schema.MemberRelationName}
+			})
+		}), mock.Anything).RunAndReturn(checkResults(func(check relation.Relation) bool {
+			return check.Subject == bob || check.RelationName == "get"
+		}))
+
+		got, err := svc.Simulate(context.Background(), scope, simulation.Change{AddPolicies: []policy.Policy{{
+			RoleID:        schema.RoleProjectOwner,
+			ResourceID:    "project-id",
+			ResourceType:  schema.ProjectNamespace,
+			PrincipalID:   "group-id",
+			PrincipalType: schema.GroupPrincipal,
+		}}})
+		assert.NoError(t, err)
+		assert.Equal(t, []simulation.AccessChange{{
+			Principal: bob,
+			Resource:  project,
+			After:     []string{"delete", "get"},
+			Gained:    []string{"delete", "get"},
+		}}, got)
+	})
+
+	t.Run("should add and remove the permissions of changed roles", func(t *testing.T) {
+		svc, m := newTestService(t)
+		m.roles.EXPECT().Get(mock.Anything, "viewer-role-id").Return(role.Role{ID: "viewer-role-id"}, nil)
+		m.permissions.EXPECT().Get(mock.Anything, "app.project.delete").Return(permission.Permission{Slug: "app_project_delete"}, nil)
+		m.permissions.EXPECT().Get(mock.Anything, "app.project.get").Return(permission.Permission{Slug: "app_project_get"}, nil)
+		m.sandbox.EXPECT().Relations(mock.Anything, []relation.Object{
+			project,
+			{ID: "viewer-role-id", Namespace: schema.RoleNamespace},
+		}).Return(projectRelations(), nil)
+		m.sandbox.EXPECT().Permissions(mock.Anything, schema.ProjectNamespace).Return([]string{"get", "delete"}, nil)
+		m.sandbox.EXPECT().BatchCheck(mock.Anything, projectRelations(), mock.Anything).
+			RunAndReturn(checkResults(func(check relation.Relation) bool {
+				return check.Subject == alice && check.RelationName == "get"
+			}))
+		viewerRole := relation.Object{ID: "viewer-role-id", Namespace: schema.RoleNamespace}
+		m.sandbox.EXPECT().BatchCheck(mock.Anything, mock.MatchedBy(func(relations []relation.Relation) bool {
+			return hasRelation(relations, viewerRole, "app_project_delete", relation.Subject{ID: "*", Namespace: schema.ServiceUserPrincipal}) &&
+				!hasRelation(relations, viewerRole, "app_project_get", relation.Subject{ID: "*", Namespace: schema.UserPrincipal})
+		}), mock.Anything).RunAndReturn(checkResults(func(check relation.Relation) bool {
+			return check.Subject == alice && check.RelationName == "delete"
+		}))
+
+		got, err := svc.Simulate(context.Background(), simulation.Scope{
+			Resources:   []relation.Object{project},
+			Permissions: []string{"get", "delete"},
+		}, simulation.Change{Roles: []simulation.RoleChange{{
+			RoleID:            "viewer-role-id",
+			AddPermissions:    []string{"app.project.delete"},
+			RemovePermissions: []string{"app.project.get"},
+		}}})
+		assert.NoError(t, err)
+		assert.Equal(t, []simulation.AccessChange{{
+			Principal: alice,
+			Resource:  project,
+			Before:    []string{"get"},
+			After:     []string{"delete"},
+			Gained:    []string{"delete"},
+			Lost:      []string{"get"},
+		}}, got)
+	})
+
+	t.Run("should reject permissions the resource doesn't have", func(t *testing.T) {
+		svc, m := newTestService(t)
+		m.sandbox.EXPECT().Relations(mock.Anything, []relation.Object{project}).Return(projectRelations(), nil)
+		m.sandbox.EXPECT().Permissions(mock.Anything, schema.ProjectNamespace).Return([]string{"get", "delete"}, nil)
+
+		_, err := svc.Simulate(context.Background(), simulation.Scope{
+			Resources:   []relation.Object{project},
+			Permissions: []string{"billingmanage"},
+		}, simulation.Change{})
+		assert.ErrorIs(t, err, simulation.ErrInvalidDetail)
+	})
+
+	t.Run("should bound the resources", func(t *testing.T) {
+		svc, _ := newTestService(t)
+		resources := make([]relation.Object, simulation.MaxResources+1)
+		for i := range resources {
+			resources[i] = project
+		}
+		_, err := svc.Simulate(context.Background(), simulation.Scope{Resources: resources}, simulation.Change{})
+		assert.ErrorIs(t, err, simulation.ErrInvalidDetail)
+	})
+}
@@ -0,0 +1,41 @@
+package simulation
+
+import (
+	"github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+)
+
+// Change is a change to the policies and roles which is only simulated
+type Change struct {
+	// AddPolicies are created, their role may be given by id or name
+	AddPolicies []policy.Policy
+	// RemovePolicies are the ids of the policies deleted
+	RemovePolicies []string
+	Roles          []RoleChange
+}
+
+// RoleChange adds and removes permissions of a role, permissions may be
+// given by id, slug or name like in the role apis
+type RoleChange struct {
+	RoleID            string
+	AddPermissions    []string
+	RemovePermissions []string
+}
+
+// Scope bounds the simulation to the permissions on a few resources, all
+// the permissions of their namespaces are simulated if Permissions is empty
+type Scope struct {
+	Resources   []relation.Object
+	Permissions []string
+}
+
+// AccessChange is the change of the permissions of a principal on a
+// resource, the permissions are sorted
+type AccessChange struct {
+	Principal relation.Subject
+	Resource  relation.Object
+	Before    []string
+	After     []string
+	Gained    []string
+	Lost      []string
+}
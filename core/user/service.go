@@ -35,21 +35,28 @@ type RoleService interface {
 	List(ctx context.Context, f role.Filter) ([]role.Role, error)
 }
 
+// SessionService revokes the sessions of users who are disabled
+type SessionService interface {
+	DeleteByUser(ctx context.Context, userID string) error
+}
+
 type Service struct {
 	repository      Repository
 	relationService RelationService
 	policyService   PolicyService
 	roleService     RoleService
+	sessionService  SessionService
 	Now             func() time.Time
 }
 
 func NewService(repository Repository, relationRepo RelationService,
-	policyService PolicyService, roleService RoleService) *Service {
+	policyService PolicyService, roleService RoleService, sessionService SessionService) *Service {
 	return &Service{
 		repository:      repository,
 		relationService: relationRepo,
 		policyService:   policyService,
 		roleService:     roleService,
+		sessionService:  sessionService,
 		Now: func() time.Time {
 			return time.Now().UTC()
 		},
@@ -126,8 +133,12 @@ func (s Service) Enable(ctx context.Context, id string) error {
 	return s.repository.SetState(ctx, id, Enabled)
 }
 
+// Disable blocks the user from logging in, all of its sessions are revoked
 func (s Service) Disable(ctx context.Context, id string) error {
-	return s.repository.SetState(ctx, id, Disabled)
+	if err := s.repository.SetState(ctx, id, Disabled); err != nil {
+		return err
+	}
+	return s.sessionService.DeleteByUser(ctx, id)
 }
 
 // Delete by user uuid
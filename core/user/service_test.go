@@ -14,6 +14,7 @@ import (
 	"github.com/raystack/frontier/core/user/mocks"
 	"github.com/raystack/frontier/internal/bootstrap/schema"
 	"github.com/raystack/frontier/pkg/str"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -50,7 +51,7 @@ func TestService_GetByID(t *testing.T) {
 					ID:   testID.String(),
 					Name: "test",
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -69,7 +70,7 @@ func TestService_GetByID(t *testing.T) {
 					Name:  "test",
 					Email: "test@test.com",
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -86,7 +87,7 @@ func TestService_GetByID(t *testing.T) {
 					ID:   testID.String(),
 					Name: "test",
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -97,7 +98,7 @@ func TestService_GetByID(t *testing.T) {
 			setup: func() *user.Service {
 				repo, relationService, policyService, roleService := mockService(t)
 				repo.EXPECT().GetByName(mock.Anything, "invalid").Return(user.User{}, errors.New("not found"))
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -175,7 +176,7 @@ func TestService_Create(t *testing.T) {
 					CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 					UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -193,7 +194,7 @@ func TestService_Create(t *testing.T) {
 					Email: "test",
 					State: user.Enabled,
 				}).Return(user.User{}, errors.New("failed to create"))
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -263,7 +264,7 @@ func TestService_List(t *testing.T) {
 						Name: "test-2",
 					},
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -309,7 +310,7 @@ func TestService_List(t *testing.T) {
 						Name: "test-2",
 					},
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -342,7 +343,7 @@ func TestService_List(t *testing.T) {
 						Name: "test-2",
 					},
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -418,7 +419,7 @@ func TestService_Update(t *testing.T) {
 					CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 					UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -469,7 +470,7 @@ func TestService_Update(t *testing.T) {
 					CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 					UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -520,7 +521,7 @@ func TestService_Update(t *testing.T) {
 					CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 					UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -537,7 +538,7 @@ func TestService_Update(t *testing.T) {
 					Name:  "test ",
 					Email: "test",
 				}).Return(user.User{}, errors.New("failed to update"))
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -574,7 +575,7 @@ func TestService_Delete(t *testing.T) {
 					Namespace: schema.UserPrincipal,
 				}}).Return(nil)
 				repo.EXPECT().Delete(mock.Anything, "test-id").Return(nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -587,7 +588,7 @@ func TestService_Delete(t *testing.T) {
 					ID:        "test-id",
 					Namespace: schema.UserPrincipal,
 				}}).Return(errors.New("failed to delete relation"))
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -666,7 +667,7 @@ func TestService_Sudo(t *testing.T) {
 					},
 					RelationName: schema.AdminRelationName,
 				}).Return(relation.Relation{}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -711,7 +712,7 @@ func TestService_Sudo(t *testing.T) {
 						Status: true,
 					},
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -776,7 +777,7 @@ func TestService_Sudo(t *testing.T) {
 					},
 					RelationName: schema.MemberRelationName,
 				}).Return(relation.Relation{}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -853,7 +854,7 @@ func TestService_UnSudo(t *testing.T) {
 					},
 					RelationName: schema.MemberRelationName,
 				}).Return(nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 		{
@@ -897,7 +898,7 @@ func TestService_UnSudo(t *testing.T) {
 						Status: false,
 					},
 				}, nil)
-				return user.NewService(repo, relationService, policyService, roleService)
+				return user.NewService(repo, relationService, policyService, roleService, nil)
 			},
 		},
 	}
@@ -910,3 +911,24 @@ func TestService_UnSudo(t *testing.T) {
 		})
 	}
 }
+
+func TestService_Disable(t *testing.T) {
+	t.Run("should revoke the sessions of a disabled user", func(t *testing.T) {
+		repo, relationService, policyService, roleService := mockService(t)
+		sessionService := mocks.NewSessionService(t)
+		repo.EXPECT().SetState(mock.Anything, "user-id", user.Disabled).Return(nil)
+		sessionService.EXPECT().DeleteByUser(mock.Anything, "user-id").Return(nil)
+
+		s := user.NewService(repo, relationService, policyService, roleService, sessionService)
+		assert.NoError(t, s.Disable(context.Background(), "user-id"))
+	})
+
+	t.Run("should keep the sessions if the user isn't disabled", func(t *testing.T) {
+		repo, relationService, policyService, roleService := mockService(t)
+		sessionService := mocks.NewSessionService(t)
+		repo.EXPECT().SetState(mock.Anything, "user-id", user.Disabled).Return(user.ErrNotExist)
+
+		s := user.NewService(repo, relationService, policyService, roleService, sessionService)
+		assert.ErrorIs(t, s.Disable(context.Background(), "user-id"), user.ErrNotExist)
+	})
+}
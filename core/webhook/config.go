@@ -1,5 +1,32 @@
 package webhook
 
+import "time"
+
 type Config struct {
 	EncryptionKey string `yaml:"encryption_key" mapstructure:"encryption_key" default:"hash-secret-should-be-32-chars--"`
+
+	// SecretOverlap is the default duration for which the old secrets keep
+	// signing the payload after a secret is rotated
+	SecretOverlap time.Duration `yaml:"secret_overlap" mapstructure:"secret_overlap" default:"24h"`
+
+	// LegacySignature keeps sending the deprecated X-Signature header, signed
+	// without a timestamp, along with X-Webhook-Signature until receivers migrate
+	LegacySignature bool `yaml:"legacy_signature" mapstructure:"legacy_signature" default:"true"`
+
+	Delivery DeliveryConfig `yaml:"delivery" mapstructure:"delivery"`
+}
+
+type DeliveryConfig struct {
+	// Interval is how often the dispatcher polls the outbox for due deliveries
+	Interval time.Duration `yaml:"interval" mapstructure:"interval" default:"10s"`
+	// BatchSize is the max number of deliveries claimed in a single dispatch
+	BatchSize int `yaml:"batch_size" mapstructure:"batch_size" default:"50"`
+	// Timeout is the http timeout for a single delivery attempt
+	Timeout time.Duration `yaml:"timeout" mapstructure:"timeout" default:"5s"`
+	// MaxAttempts is the number of failed attempts after which a delivery is dead-lettered
+	MaxAttempts int `yaml:"max_attempts" mapstructure:"max_attempts" default:"10"`
+	// BackoffBase is the wait before the first retry, doubled on every subsequent failure
+	BackoffBase time.Duration `yaml:"backoff_base" mapstructure:"backoff_base" default:"30s"`
+	// BackoffMax caps the wait between two retries
+	BackoffMax time.Duration `yaml:"backoff_max" mapstructure:"backoff_max" default:"6h"`
 }
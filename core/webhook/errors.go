@@ -3,9 +3,11 @@ package webhook
 import "errors"
 
 var (
-	ErrNotFound      = errors.New("webhook doesn't exist")
-	ErrInvalidDetail = errors.New("invalid webhook details")
-	ErrConflict      = errors.New("webhook already exist")
-	ErrInvalidUUID   = errors.New("invalid syntax of uuid")
-	ErrDisabled      = errors.New("webhook is disabled")
+	ErrNotFound         = errors.New("webhook doesn't exist")
+	ErrInvalidDetail    = errors.New("invalid webhook details")
+	ErrConflict         = errors.New("webhook already exist")
+	ErrInvalidUUID      = errors.New("invalid syntax of uuid")
+	ErrDisabled         = errors.New("webhook is disabled")
+	ErrEventNotFound    = errors.New("webhook event doesn't exist")
+	ErrDeliveryNotFound = errors.New("webhook delivery doesn't exist")
 )
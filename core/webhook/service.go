@@ -3,9 +3,13 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/raystack/frontier/pkg/server/consts"
+	"github.com/robfig/cron/v3"
 
 	"golang.org/x/exp/slices"
 
@@ -19,30 +23,76 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/google/uuid"
 	"github.com/raystack/frontier/pkg/crypt"
+	pkgwebhook "github.com/raystack/frontier/pkg/webhook"
 )
 
 const (
-	DefaultSecretID    = "1"
-	SignatureHeader    = "X-Signature"
-	EndpointRetryCount = 3
+	DefaultSecretID = "1"
+	// SignatureHeader carries the legacy signature of the payload without a
+	// timestamp, it's sent until the deprecation window ends
+	// Deprecated: receivers should verify TimestampedSignatureHeader
+	SignatureHeader = "X-Signature"
+	// TimestampedSignatureHeader carries the signatures of the payload along
+	// with the signing timestamp, one per active secret
+	TimestampedSignatureHeader = "X-Webhook-Signature"
+	EventIDHeader              = "X-Webhook-Event-ID"
+	DeliveryHeader             = "X-Webhook-Delivery-ID"
+
+	// maxResponseSnippet is the max number of bytes of the endpoint
+	// response body stored with each attempt
+	maxResponseSnippet = 1024
 )
 
 type EndpointRepository interface {
 	Create(ctx context.Context, endpoint Endpoint) (Endpoint, error)
+	GetByID(ctx context.Context, id string) (Endpoint, error)
 	UpdateByID(ctx context.Context, endpoint Endpoint) (Endpoint, error)
+	UpdateSecrets(ctx context.Context, id string, secrets []Secret) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, filter EndpointFilter) ([]Endpoint, error)
 }
 
+type DeliveryRepository interface {
+	// Enqueue persists the event and a pending delivery for each endpoint atomically
+	Enqueue(ctx context.Context, deliveries []Delivery) ([]Delivery, error)
+	// Claim leases due deliveries for the given duration so that concurrent
+	// dispatchers don't pick them again
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Delivery, error)
+	// RecordAttempt stores the attempt and updates the delivery state
+	RecordAttempt(ctx context.Context, delivery Delivery, attempt DeliveryAttempt) error
+	List(ctx context.Context, filter DeliveryFilter) ([]Delivery, error)
+	// Requeue marks all deliveries of an event as pending again
+	Requeue(ctx context.Context, eventID string) ([]Delivery, error)
+}
+
 type Service struct {
-	eRepo EndpointRepository
+	eRepo           EndpointRepository
+	dRepo           DeliveryRepository
+	cfg             DeliveryConfig
+	secretOverlap   time.Duration
+	legacySignature bool
+
+	dispatchJob *cron.Cron
+	notify      chan struct{}
+	mu          sync.Mutex
+	Now         func() time.Time
 }
 
-func NewService(eRepo EndpointRepository) *Service {
-	return &Service{eRepo: eRepo}
+func NewService(eRepo EndpointRepository, dRepo DeliveryRepository, cfg Config) *Service {
+	return &Service{
+		eRepo:           eRepo,
+		dRepo:           dRepo,
+		cfg:             cfg.Delivery,
+		secretOverlap:   cfg.SecretOverlap,
+		legacySignature: cfg.LegacySignature,
+		notify:          make(chan struct{}, 1),
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
 }
 
-func (s Service) CreateEndpoint(ctx context.Context, endpoint Endpoint) (Endpoint, error) {
+func (s *Service) CreateEndpoint(ctx context.Context, endpoint Endpoint) (Endpoint, error) {
 	if endpoint.ID == "" {
 		endpoint.ID = uuid.NewString()
 	}
@@ -56,13 +106,62 @@ func (s Service) CreateEndpoint(ctx context.Context, endpoint Endpoint) (Endpoin
 		return Endpoint{}, err
 	}
 	endpoint.Secrets = append(endpoint.Secrets, Secret{
-		ID:    DefaultSecretID,
-		Value: secretHex,
+		ID:        DefaultSecretID,
+		Value:     secretHex,
+		CreatedAt: s.Now(),
 	})
 	return s.eRepo.Create(ctx, endpoint)
 }
 
-func (s Service) UpdateEndpoint(ctx context.Context, endpoint Endpoint) (Endpoint, error) {
+// RotateSecret generates a new secret for the endpoint. Payloads are signed with
+// both the old and the new secrets until overlap elapses, after which the old
+// secrets are retired. If overlap is zero, configured default is used.
+func (s *Service) RotateSecret(ctx context.Context, id string, overlap time.Duration) (Secret, error) {
+	if id == "" {
+		return Secret{}, ErrInvalidUUID
+	}
+	if overlap == 0 {
+		overlap = s.secretOverlap
+	}
+	endpoint, err := s.eRepo.GetByID(ctx, id)
+	if err != nil {
+		return Secret{}, err
+	}
+
+	now := s.Now()
+	lastID := 0
+	var secrets []Secret
+	for _, secret := range endpoint.Secrets {
+		if n, err := strconv.Atoi(secret.ID); err == nil && n > lastID {
+			lastID = n
+		}
+		// drop the secrets which already completed their overlap
+		if !secret.IsActive(now) {
+			continue
+		}
+		if secret.ExpiresAt.IsZero() || secret.ExpiresAt.After(now.Add(overlap)) {
+			secret.ExpiresAt = now.Add(overlap)
+		}
+		secrets = append(secrets, secret)
+	}
+
+	secretHex, err := crypt.NewEncryptionKeyInHex()
+	if err != nil {
+		return Secret{}, err
+	}
+	newSecret := Secret{
+		ID:        strconv.Itoa(lastID + 1),
+		Value:     secretHex,
+		CreatedAt: now,
+	}
+	secrets = append(secrets, newSecret)
+	if err := s.eRepo.UpdateSecrets(ctx, id, secrets); err != nil {
+		return Secret{}, err
+	}
+	return newSecret, nil
+}
+
+func (s *Service) UpdateEndpoint(ctx context.Context, endpoint Endpoint) (Endpoint, error) {
 	if endpoint.ID == "" {
 		return Endpoint{}, ErrInvalidUUID
 	}
@@ -74,11 +173,11 @@ func (s Service) UpdateEndpoint(ctx context.Context, endpoint Endpoint) (Endpoin
 	return updated, nil
 }
 
-func (s Service) DeleteEndpoint(ctx context.Context, id string) error {
+func (s *Service) DeleteEndpoint(ctx context.Context, id string) error {
 	return s.eRepo.Delete(ctx, id)
 }
 
-func (s Service) ListEndpoints(ctx context.Context, filter EndpointFilter) ([]Endpoint, error) {
+func (s *Service) ListEndpoints(ctx context.Context, filter EndpointFilter) ([]Endpoint, error) {
 	endpoints, err := s.eRepo.List(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -89,7 +188,9 @@ func (s Service) ListEndpoints(ctx context.Context, filter EndpointFilter) ([]En
 	return endpoints, nil
 }
 
-func (s Service) Publish(ctx context.Context, evt Event) error {
+// Publish writes the event to the outbox for every subscribed endpoint,
+// actual delivery happens asynchronously by the dispatcher
+func (s *Service) Publish(ctx context.Context, evt Event) error {
 	logger := grpczap.Extract(ctx)
 	data, err := structpb.NewStruct(evt.Data)
 	if err != nil {
@@ -109,67 +210,316 @@ func (s Service) Publish(ctx context.Context, evt Event) error {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	// send event to endpoints
-	go func() {
-		detachedRepoContext, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		endpoints, err := s.eRepo.List(detachedRepoContext, EndpointFilter{
-			State: Enabled,
+	endpoints, err := s.eRepo.List(ctx, EndpointFilter{
+		State: Enabled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list endpoints: %w", err)
+	}
+	requestID, _ := consts.GetRequestIDFromCtx(ctx)
+	var deliveries []Delivery
+	for _, endpoint := range endpoints {
+		if len(endpoint.SubscribedEvents) > 0 && !slices.Contains(endpoint.SubscribedEvents, evt.Action) {
+			continue
+		}
+		deliveries = append(deliveries, Delivery{
+			ID:            uuid.NewString(),
+			EventID:       evt.ID,
+			EndpointID:    endpoint.ID,
+			Action:        evt.Action,
+			Payload:       payload,
+			State:         DeliveryPending,
+			NextAttemptAt: s.Now(),
+			RequestID:     requestID,
 		})
+	}
+	if len(deliveries) == 0 {
+		return nil
+	}
+	if _, err := s.dRepo.Enqueue(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to enqueue event: %w", err)
+	}
+	s.wakeDispatcher()
+	return nil
+}
+
+// ListDeliveries returns deliveries along with their attempt history
+func (s *Service) ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, error) {
+	return s.dRepo.List(ctx, filter)
+}
+
+// Redeliver schedules all deliveries of an event for an immediate retry,
+// including the ones which were dead-lettered or already succeeded
+func (s *Service) Redeliver(ctx context.Context, eventID string) ([]Delivery, error) {
+	if eventID == "" {
+		return nil, ErrInvalidUUID
+	}
+	deliveries, err := s.dRepo.Requeue(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, ErrEventNotFound
+	}
+	s.wakeDispatcher()
+	return deliveries, nil
+}
+
+// Init starts the background dispatcher which drains the outbox
+func (s *Service) Init(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg.Interval == time.Duration(0) {
+		return nil
+	}
+	if s.dispatchJob != nil {
+		s.dispatchJob.Stop()
+	}
+	s.dispatchJob = cron.New(cron.WithChain(
+		cron.SkipIfStillRunning(cron.DefaultLogger),
+		cron.Recover(cron.DefaultLogger),
+	))
+	jobID, err := s.dispatchJob.AddFunc(fmt.Sprintf("@every %s", s.cfg.Interval.String()), func() {
+		s.Dispatch(ctx)
+	})
+	if err != nil {
+		return err
+	}
+	s.dispatchJob.Start()
+	job := s.dispatchJob.Entry(jobID).WrappedJob
+
+	// run the same job on demand as soon as new events are enqueued
+	// instead of waiting for the next tick
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-s.notify:
+				if !ok {
+					return
+				}
+				job.Run()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *Service) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dispatchJob != nil {
+		return s.dispatchJob.Stop().Err()
+	}
+	return nil
+}
+
+func (s *Service) wakeDispatcher() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+		// a dispatch is already pending
+	}
+}
+
+// Dispatch claims due deliveries and sends them to their endpoints
+func (s *Service) Dispatch(ctx context.Context) {
+	logger := grpczap.Extract(ctx)
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		// lease the deliveries long enough for all of them to be attempted,
+		// even if they all belong to the same endpoint
+		lease := s.cfg.Timeout*time.Duration(s.cfg.BatchSize) + time.Minute
+		deliveries, err := s.dRepo.Claim(ctx, s.cfg.BatchSize, lease)
+		if err != nil {
+			logger.Error("failed to claim webhook deliveries", zap.Error(err))
+			return
+		}
+		if len(deliveries) == 0 {
+			return
+		}
+
+		endpoints, err := s.eRepo.List(ctx, EndpointFilter{})
 		if err != nil {
 			logger.Error("failed to list endpoints", zap.Error(err))
 			return
 		}
-		var errs []error
+		endpointsByID := make(map[string]Endpoint, len(endpoints))
 		for _, endpoint := range endpoints {
-			if len(endpoint.SubscribedEvents) > 0 && !slices.Contains(endpoint.SubscribedEvents, event.GetAction()) {
-				continue
-			}
-			if len(endpoint.Secrets) == 0 {
-				errs = append(errs, fmt.Errorf("no secret found for endpoint: %s", endpoint.ID))
-				continue
-			}
-
-			// just use the first secret to sign the payload for now
-			secret := endpoint.Secrets[0]
-			signature, err := crypt.GenerateHMACFromHex(payload, secret.Value)
-			if err != nil {
-				errs = append(errs, fmt.Errorf("failed to generate HMAC: %w", err))
-				continue
-			}
+			endpointsByID[endpoint.ID] = endpoint
+		}
 
-			requestHeaders := make(map[string]string)
-			for k, v := range endpoint.Headers {
-				requestHeaders[k] = v
-			}
-			if id, ok := consts.GetRequestIDFromCtx(ctx); ok {
-				requestHeaders[consts.RequestIDHeader] = id
-			}
-			requestHeaders[SignatureHeader] = signatureHeader(signature, secret.ID)
-			if err := post(endpoint.URL, requestHeaders, payload); err != nil {
-				errs = append(errs, err)
+		// deliveries of an endpoint are sent in order, endpoints are sent to
+		// concurrently so that a slow endpoint doesn't hold the others back
+		var endpointIDs []string
+		deliveriesByEndpoint := make(map[string][]Delivery)
+		for _, delivery := range deliveries {
+			if _, ok := deliveriesByEndpoint[delivery.EndpointID]; !ok {
+				endpointIDs = append(endpointIDs, delivery.EndpointID)
 			}
+			deliveriesByEndpoint[delivery.EndpointID] = append(deliveriesByEndpoint[delivery.EndpointID], delivery)
 		}
-		if len(errs) > 0 {
-			logger.Error("failed to send events", zap.Errors("errs", errs))
+		var wg sync.WaitGroup
+		for _, endpointID := range endpointIDs {
+			wg.Add(1)
+			go func(endpoint Endpoint, deliveries []Delivery) {
+				defer wg.Done()
+				for _, delivery := range deliveries {
+					s.deliver(ctx, delivery, endpoint)
+				}
+			}(endpointsByID[endpointID], deliveriesByEndpoint[endpointID])
 		}
-	}()
-	return nil
+		wg.Wait()
+		if len(deliveries) < s.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+// deliver attempts the delivery and records the outcome
+func (s *Service) deliver(ctx context.Context, delivery Delivery, endpoint Endpoint) {
+	logger := grpczap.Extract(ctx)
+	attempt := s.attempt(delivery, endpoint)
+	delivery = s.nextState(delivery, attempt)
+	if err := s.dRepo.RecordAttempt(ctx, delivery, attempt); err != nil {
+		logger.Error("failed to record webhook delivery attempt", zap.Error(err),
+			zap.String("delivery_id", delivery.ID))
+	}
+	if delivery.State == DeliveryDead {
+		logger.Warn("webhook delivery dead-lettered", zap.String("delivery_id", delivery.ID),
+			zap.String("endpoint_id", delivery.EndpointID), zap.String("event_id", delivery.EventID),
+			zap.String("error", attempt.Error))
+	}
+}
+
+// attempt sends the delivery once and reports the outcome
+func (s *Service) attempt(delivery Delivery, endpoint Endpoint) DeliveryAttempt {
+	attempt := DeliveryAttempt{
+		ID:         uuid.NewString(),
+		DeliveryID: delivery.ID,
+		CreatedAt:  s.Now(),
+	}
+	if endpoint.ID == "" {
+		attempt.Error = ErrNotFound.Error()
+		return attempt
+	}
+	if endpoint.State == Disabled {
+		attempt.Error = ErrDisabled.Error()
+		return attempt
+	}
+	signatureHeaders, err := s.sign(endpoint, delivery.Payload)
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt
+	}
+
+	requestHeaders := make(map[string]string)
+	for k, v := range endpoint.Headers {
+		requestHeaders[k] = v
+	}
+	if delivery.RequestID != "" {
+		requestHeaders[consts.RequestIDHeader] = delivery.RequestID
+	}
+	requestHeaders[EventIDHeader] = delivery.EventID
+	requestHeaders[DeliveryHeader] = delivery.ID
+	for k, v := range signatureHeaders {
+		requestHeaders[k] = v
+	}
+
+	start := time.Now()
+	resp, err := post(endpoint.URL, requestHeaders, delivery.Payload, s.cfg.Timeout)
+	attempt.Latency = time.Since(start)
+	if resp != nil {
+		attempt.StatusCode = resp.StatusCode()
+		attempt.Response = truncate(resp.String(), maxResponseSnippet)
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	} else if !attempt.Succeeded() {
+		attempt.Error = fmt.Sprintf("unexpected status code: %d", attempt.StatusCode)
+	}
+	return attempt
 }
 
-func signatureHeader(val string, id string) string {
-	return fmt.Sprintf("%s=%s", id, val)
+// nextState moves the delivery forward based on the outcome of the attempt
+func (s *Service) nextState(delivery Delivery, attempt DeliveryAttempt) Delivery {
+	delivery.Attempts++
+	delivery.LastError = attempt.Error
+	if attempt.Succeeded() {
+		delivery.State = DeliverySucceeded
+		return delivery
+	}
+	if delivery.Attempts >= s.cfg.MaxAttempts {
+		delivery.State = DeliveryDead
+		return delivery
+	}
+	delivery.State = DeliveryPending
+	delivery.NextAttemptAt = s.Now().Add(backoff(delivery.Attempts, s.cfg.BackoffBase, s.cfg.BackoffMax))
+	return delivery
 }
 
-func post(url string, headers map[string]string, payload []byte) error {
-	// post event
+// backoff returns an exponentially growing wait for the given attempt count
+func backoff(attempts int, base, max time.Duration) time.Duration {
+	if attempts < 1 {
+		return base
+	}
+	wait := float64(base) * math.Pow(2, float64(attempts-1))
+	if wait > float64(max) {
+		return max
+	}
+	return time.Duration(wait)
+}
+
+// sign generates the signature headers of the payload. The timestamped header
+// is signed with every active secret of the endpoint, newest secret first. The
+// legacy header only fits a single signature, it's signed with the oldest active
+// secret which receivers of the legacy header already hold
+func (s *Service) sign(endpoint Endpoint, payload []byte) (map[string]string, error) {
+	now := s.Now()
+	var ids []string
+	hexKeys := make(map[string]string)
+	for i := len(endpoint.Secrets) - 1; i >= 0; i-- {
+		secret := endpoint.Secrets[i]
+		if !secret.IsActive(now) {
+			continue
+		}
+		ids = append(ids, secret.ID)
+		hexKeys[secret.ID] = secret.Value
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no active secret found for endpoint: %s", endpoint.ID)
+	}
+	signature, err := pkgwebhook.Sign(now, payload, ids, hexKeys)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]string{
+		TimestampedSignatureHeader: signature.String(),
+	}
+	if s.legacySignature {
+		oldestID := ids[len(ids)-1]
+		legacy, err := pkgwebhook.SignLegacy(payload, oldestID, hexKeys[oldestID])
+		if err != nil {
+			return nil, err
+		}
+		headers[SignatureHeader] = legacy
+	}
+	return headers, nil
+}
+
+func post(url string, headers map[string]string, payload []byte, timeout time.Duration) (*resty.Response, error) {
 	client := resty.New().
-		SetRetryCount(EndpointRetryCount).
-		SetRetryWaitTime(3 * time.Second).
-		SetRetryMaxWaitTime(20 * time.Second).
-		SetTimeout(3 * time.Second).
+		SetTimeout(timeout).
 		SetHeaders(headers)
+	return client.R().SetBody(payload).Post(url)
+}
 
-	_, err := client.R().SetBody(payload).Post(url)
-	return err
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
 }
@@ -0,0 +1,255 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/webhook"
+	"github.com/raystack/frontier/core/webhook/mocks"
+	"github.com/raystack/frontier/pkg/crypt"
+	pkgwebhook "github.com/raystack/frontier/pkg/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testConfig = webhook.Config{
+	SecretOverlap:   time.Hour,
+	LegacySignature: true,
+	Delivery: webhook.DeliveryConfig{
+		BatchSize:   10,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+		BackoffBase: time.Minute,
+		BackoffMax:  time.Hour,
+	},
+}
+
+func TestService_Publish(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []webhook.Endpoint
+		wantIDs   []string
+		listErr   error
+		wantErr   bool
+	}{
+		{
+			name: "should enqueue a delivery only for subscribed endpoints",
+			endpoints: []webhook.Endpoint{
+				{ID: "e1", SubscribedEvents: []string{"app.user.created"}},
+				{ID: "e2", SubscribedEvents: []string{"app.user.deleted"}},
+				{ID: "e3"},
+			},
+			wantIDs: []string{"e1", "e3"},
+		},
+		{
+			name: "should not enqueue anything if no endpoint is subscribed",
+			endpoints: []webhook.Endpoint{
+				{ID: "e2", SubscribedEvents: []string{"app.user.deleted"}},
+			},
+		},
+		{
+			name:    "should fail the caller if endpoints can't be listed",
+			listErr: errors.New("connection refused"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eRepo := mocks.NewEndpointRepository(t)
+			dRepo := mocks.NewDeliveryRepository(t)
+			eRepo.EXPECT().List(mock.Anything, webhook.EndpointFilter{State: webhook.Enabled}).Return(tt.endpoints, tt.listErr)
+			if len(tt.wantIDs) > 0 {
+				dRepo.EXPECT().Enqueue(mock.Anything, mock.MatchedBy(func(deliveries []webhook.Delivery) bool {
+					var got []string
+					for _, d := range deliveries {
+						if d.EventID != "evt-1" || d.State != webhook.DeliveryPending || len(d.Payload) == 0 {
+							return false
+						}
+						got = append(got, d.EndpointID)
+					}
+					return assert.ObjectsAreEqual(tt.wantIDs, got)
+				})).Return(nil, nil)
+			}
+
+			s := webhook.NewService(eRepo, dRepo, testConfig)
+			err := s.Publish(context.Background(), webhook.Event{
+				ID:        "evt-1",
+				Action:    "app.user.created",
+				Data:      map[string]any{"id": "u1"},
+				CreatedAt: time.Now(),
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Dispatch(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		statusCode    int
+		attempts      int
+		wantState     webhook.DeliveryState
+		wantNextRetry time.Time
+	}{
+		{
+			name:       "should mark delivery as succeeded on 2xx",
+			statusCode: http.StatusNoContent,
+			wantState:  webhook.DeliverySucceeded,
+		},
+		{
+			name:          "should schedule a retry with backoff on failure",
+			statusCode:    http.StatusBadGateway,
+			attempts:      1,
+			wantState:     webhook.DeliveryPending,
+			wantNextRetry: now.Add(2 * time.Minute),
+		},
+		{
+			name:       "should dead-letter delivery once attempts are exhausted",
+			statusCode: http.StatusInternalServerError,
+			attempts:   2,
+			wantState:  webhook.DeliveryDead,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				sig, err := pkgwebhook.ParseSignature(r.Header.Get(webhook.TimestampedSignatureHeader))
+				assert.NoError(t, err)
+				assert.Equal(t, now, sig.Timestamp)
+				assert.Equal(t, "1", sig.Values[0].SecretID)
+				legacyID, legacyMAC, ok := strings.Cut(r.Header.Get(webhook.SignatureHeader), "=")
+				assert.True(t, ok)
+				assert.Equal(t, "1", legacyID)
+				valid, err := crypt.VerifyHMACFromHex([]byte(`{"id":"evt-1"}`), "0a0b0c0d", legacyMAC)
+				assert.NoError(t, err)
+				assert.True(t, valid)
+				assert.Equal(t, "evt-1", r.Header.Get(webhook.EventIDHeader))
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer srv.Close()
+
+			eRepo := mocks.NewEndpointRepository(t)
+			dRepo := mocks.NewDeliveryRepository(t)
+			delivery := webhook.Delivery{
+				ID:         "d1",
+				EventID:    "evt-1",
+				EndpointID: "e1",
+				Payload:    []byte(`{"id":"evt-1"}`),
+				State:      webhook.DeliveryPending,
+				Attempts:   tt.attempts,
+			}
+			dRepo.EXPECT().Claim(mock.Anything, testConfig.Delivery.BatchSize, mock.Anything).Return([]webhook.Delivery{delivery}, nil)
+			eRepo.EXPECT().List(mock.Anything, webhook.EndpointFilter{}).Return([]webhook.Endpoint{
+				{
+					ID:      "e1",
+					URL:     srv.URL,
+					State:   webhook.Enabled,
+					Secrets: []webhook.Secret{{ID: "1", Value: "0a0b0c0d"}},
+				},
+			}, nil)
+			dRepo.EXPECT().RecordAttempt(mock.Anything, mock.Anything, mock.Anything).
+				Run(func(ctx context.Context, d webhook.Delivery, a webhook.DeliveryAttempt) {
+					assert.Equal(t, tt.wantState, d.State)
+					assert.Equal(t, tt.attempts+1, d.Attempts)
+					assert.Equal(t, tt.statusCode, a.StatusCode)
+					if !tt.wantNextRetry.IsZero() {
+						assert.Equal(t, tt.wantNextRetry, d.NextAttemptAt)
+					}
+				}).Return(nil)
+
+			s := webhook.NewService(eRepo, dRepo, testConfig)
+			s.Now = func() time.Time { return now }
+			s.Dispatch(context.Background())
+		})
+	}
+}
+
+func TestService_Dispatch_Concurrent(t *testing.T) {
+	// the slow endpoint only responds once the delivery to the fast endpoint
+	// is recorded, which times it out if endpoints are attempted one by one
+	fastRecorded := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-fastRecorded:
+		case <-r.Context().Done():
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer fast.Close()
+
+	eRepo := mocks.NewEndpointRepository(t)
+	dRepo := mocks.NewDeliveryRepository(t)
+	dRepo.EXPECT().Claim(mock.Anything, testConfig.Delivery.BatchSize, mock.Anything).Return([]webhook.Delivery{
+		{ID: "d1", EventID: "evt-1", EndpointID: "slow", Payload: []byte(`{}`), State: webhook.DeliveryPending},
+		{ID: "d2", EventID: "evt-1", EndpointID: "fast", Payload: []byte(`{}`), State: webhook.DeliveryPending},
+	}, nil)
+	secrets := []webhook.Secret{{ID: "1", Value: "0a0b0c0d"}}
+	eRepo.EXPECT().List(mock.Anything, webhook.EndpointFilter{}).Return([]webhook.Endpoint{
+		{ID: "slow", URL: slow.URL, State: webhook.Enabled, Secrets: secrets},
+		{ID: "fast", URL: fast.URL, State: webhook.Enabled, Secrets: secrets},
+	}, nil)
+	dRepo.EXPECT().RecordAttempt(mock.Anything, mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, d webhook.Delivery, a webhook.DeliveryAttempt) {
+			assert.Equal(t, webhook.DeliverySucceeded, d.State, d.ID)
+			if d.ID == "d2" {
+				close(fastRecorded)
+			}
+		}).Return(nil).Times(2)
+
+	s := webhook.NewService(eRepo, dRepo, testConfig)
+	s.Dispatch(context.Background())
+}
+
+func TestService_Redeliver(t *testing.T) {
+	eRepo := mocks.NewEndpointRepository(t)
+	dRepo := mocks.NewDeliveryRepository(t)
+	dRepo.EXPECT().Requeue(mock.Anything, "evt-1").Return(nil, nil)
+
+	s := webhook.NewService(eRepo, dRepo, testConfig)
+	_, err := s.Redeliver(context.Background(), "evt-1")
+	assert.ErrorIs(t, err, webhook.ErrEventNotFound)
+}
+
+func TestService_RotateSecret(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	eRepo := mocks.NewEndpointRepository(t)
+	dRepo := mocks.NewDeliveryRepository(t)
+	eRepo.EXPECT().GetByID(mock.Anything, "e1").Return(webhook.Endpoint{
+		ID: "e1",
+		Secrets: []webhook.Secret{
+			{ID: "1", Value: "0a", ExpiresAt: now.Add(-time.Minute)},
+			{ID: "2", Value: "0b"},
+		},
+	}, nil)
+	eRepo.EXPECT().UpdateSecrets(mock.Anything, "e1", mock.Anything).
+		Run(func(ctx context.Context, id string, secrets []webhook.Secret) {
+			// expired secret is retired, active one overlaps with the new secret
+			assert.Len(t, secrets, 2)
+			assert.Equal(t, "2", secrets[0].ID)
+			assert.Equal(t, now.Add(testConfig.SecretOverlap), secrets[0].ExpiresAt)
+			assert.Equal(t, "3", secrets[1].ID)
+			assert.True(t, secrets[1].ExpiresAt.IsZero())
+		}).Return(nil)
+
+	s := webhook.NewService(eRepo, dRepo, testConfig)
+	s.Now = func() time.Time { return now }
+	secret, err := s.RotateSecret(context.Background(), "e1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", secret.ID)
+	assert.NotEmpty(t, secret.Value)
+}
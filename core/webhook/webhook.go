@@ -17,6 +17,16 @@ type Secret struct {
 	ID string
 	// Value store the secret value in hex
 	Value string
+	// CreatedAt is when the secret was generated
+	CreatedAt time.Time
+	// ExpiresAt is set once the secret is rotated, payloads are signed with it
+	// only until it expires. Zero value means it never expires
+	ExpiresAt time.Time
+}
+
+// IsActive reports whether payloads should still be signed with the secret
+func (s Secret) IsActive(at time.Time) bool {
+	return s.ExpiresAt.IsZero() || at.Before(s.ExpiresAt)
 }
 
 type Endpoint struct {
@@ -52,3 +62,67 @@ type Event struct {
 type EndpointFilter struct {
 	State State
 }
+
+type DeliveryState string
+
+const (
+	// DeliveryPending deliveries are waiting to be dispatched or retried
+	DeliveryPending DeliveryState = "pending"
+	// DeliverySucceeded deliveries were acknowledged by the endpoint with a 2xx response
+	DeliverySucceeded DeliveryState = "succeeded"
+	// DeliveryDead deliveries exhausted all attempts and will not be retried
+	// unless redelivered manually
+	DeliveryDead DeliveryState = "dead"
+)
+
+// Delivery tracks an event that needs to be sent to a single endpoint
+type Delivery struct {
+	ID         string
+	EventID    string
+	EndpointID string
+	// Action of the event, denormalized for filtering
+	Action string
+	// Payload is the serialized event body sent to the endpoint
+	Payload []byte
+	State   DeliveryState
+	// Attempts is the number of attempts made so far
+	Attempts int
+	// NextAttemptAt is when the dispatcher should pick the delivery next
+	NextAttemptAt time.Time
+	// LastError is the error of the most recent failed attempt
+	LastError string
+	// RequestID of the api call which generated the event, if any
+	RequestID string
+
+	// History of attempts, populated only when listing deliveries
+	History []DeliveryAttempt
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DeliveryAttempt is a record of a single http call made for a delivery
+type DeliveryAttempt struct {
+	ID         string
+	DeliveryID string
+	// StatusCode is the http status returned by the endpoint, 0 if no response was received
+	StatusCode int
+	// Latency of the http call
+	Latency time.Duration
+	// Response is a truncated snippet of the response body
+	Response string
+	// Error is set when the attempt failed
+	Error     string
+	CreatedAt time.Time
+}
+
+func (a DeliveryAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+type DeliveryFilter struct {
+	EventID    string
+	EndpointID string
+	State      DeliveryState
+	Limit      int
+}
@@ -54,6 +54,15 @@ module.exports = {
         "authn/introduction",
         "authn/user",
         "authn/serviceuser",
+        "authn/oauth2",
+        "authn/password",
+        "authn/passkey",
+        "authn/saml",
+        "authn/sso",
+        "authn/scim",
+        "authn/mfa",
+        "authn/reauthentication",
+        "authn/impersonation",
         "authn/org-domain",
       ],
     },
@@ -70,6 +79,7 @@ module.exports = {
         "authz/permission",
         "authz/role",
         "authz/policy",
+        "authz/access-request",
         "authz/example",
       ],
     },
@@ -136,11 +146,13 @@ module.exports = {
         "reference/configurations",
         "reference/smtp",
         "reference/webhook",
+        "reference/audit-logs",
         "reference/api-auth",
         "reference/billing-configurations",
         "reference/cli",
         "reference/metaschemas",
         "reference/api-definitions",
+        "reference/http-endpoints",
         "reference/shell-autocomplete",
       ],
     },
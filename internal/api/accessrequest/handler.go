@@ -0,0 +1,311 @@
+package accessrequest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	frontieraccessrequest "github.com/raystack/frontier/core/accessrequest"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	CreatePath  = "POST /access-requests"
+	ListPath    = "GET /access-requests"
+	GetPath     = "GET /access-requests/{id}"
+	ApprovePath = "POST /access-requests/{id}/approve"
+	DenyPath    = "POST /access-requests/{id}/deny"
+	CancelPath  = "POST /access-requests/{id}/cancel"
+
+	maxBodySize = 16 << 10
+)
+
+type AccessRequestService interface {
+	Create(ctx context.Context, request frontieraccessrequest.Request) (frontieraccessrequest.Request, error)
+	Get(ctx context.Context, id string) (frontieraccessrequest.Request, error)
+	List(ctx context.Context, flt frontieraccessrequest.Filter) ([]frontieraccessrequest.Request, error)
+	Approvers(ctx context.Context, request frontieraccessrequest.Request) ([]string, error)
+	CanReview(ctx context.Context, object relation.Object, userID string) (bool, error)
+	Approve(ctx context.Context, id, reviewerID, note string) (frontieraccessrequest.Request, error)
+	Deny(ctx context.Context, id, reviewerID, note string) (frontieraccessrequest.Request, error)
+	Cancel(ctx context.Context, id, principalID string) (frontieraccessrequest.Request, error)
+}
+
+// Handler serves the endpoints principals request temporary roles with and
+// approvers review the requests with
+type Handler struct {
+	log            log.Logger
+	authenticator  *httputil.Authenticator
+	requestService AccessRequestService
+	sessionDecoder httputil.SessionDecoder
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, requestService AccessRequestService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:            logger,
+		authenticator:  httputil.NewAuthenticator(authnService, sessionDecoder),
+		requestService: requestService,
+	}
+}
+
+// Register mounts the endpoints access is requested and reviewed with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(CreatePath, h.Create, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(ListPath, h.List, httputil.WithScope(authenticate.ScopeRead))
+	router.Handle(GetPath, h.Get, httputil.WithScope(authenticate.ScopeRead))
+	router.Handle(ApprovePath, h.Approve, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(DenyPath, h.Deny, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(CancelPath, h.Cancel, httputil.WithScope(authenticate.ScopeWrite))
+}
+
+type requestResponse struct {
+	ID            string     `json:"id"`
+	RoleID        string     `json:"role_id"`
+	Resource      string     `json:"resource"`
+	Principal     string     `json:"principal"`
+	Justification string     `json:"justification"`
+	Duration      string     `json:"duration"`
+	State         string     `json:"state"`
+	ReviewerID    string     `json:"reviewer_id,omitempty"`
+	ReviewNote    string     `json:"review_note,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	PolicyID      string     `json:"policy_id,omitempty"`
+	Approvers     []string   `json:"approvers,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func toResponse(r frontieraccessrequest.Request) requestResponse {
+	response := requestResponse{
+		ID:            r.ID,
+		RoleID:        r.RoleID,
+		Resource:      schema.JoinNamespaceAndResourceID(r.ResourceType, r.ResourceID),
+		Principal:     schema.JoinNamespaceAndResourceID(r.PrincipalType, r.PrincipalID),
+		Justification: r.Justification,
+		Duration:      r.Duration.String(),
+		State:         r.State.String(),
+		ReviewerID:    r.ReviewerID,
+		ReviewNote:    r.ReviewNote,
+		PolicyID:      r.PolicyID,
+		CreatedAt:     r.CreatedAt,
+	}
+	if !r.ReviewedAt.IsZero() {
+		response.ReviewedAt = &r.ReviewedAt
+	}
+	return response
+}
+
+type createRequest struct {
+	RoleID string `json:"role_id"`
+	// Resource is a namespaced id, e.g. app/project:<id>
+	Resource      string `json:"resource"`
+	Justification string `json:"justification"`
+	// Duration is how long the role is needed for, e.g. 4h
+	Duration string `json:"duration"`
+}
+
+// Create files a request of the caller for a role on a resource
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req createRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.RoleID == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "role_id, resource, justification and duration are required")
+		return
+	}
+	resourceType, resourceID, err := schema.SplitNamespaceAndResourceID(req.Resource)
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "resource must be a namespaced id")
+		return
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "duration must be a duration, e.g. 4h")
+		return
+	}
+
+	created, err := h.requestService.Create(ctx, frontieraccessrequest.Request{
+		RoleID:        req.RoleID,
+		ResourceID:    resourceID,
+		ResourceType:  resourceType,
+		PrincipalID:   principal.ID,
+		PrincipalType: principal.Type,
+		Justification: req.Justification,
+		Duration:      duration,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, toResponse(created))
+}
+
+type listResponse struct {
+	AccessRequests []requestResponse `json:"access_requests"`
+}
+
+// List returns the requests of the caller, or the requests on a resource if
+// the caller can review them. The state query parameter narrows them to a
+// state, e.g. pending.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	flt := frontieraccessrequest.Filter{
+		State: frontieraccessrequest.State(query.Get("state")),
+	}
+	if raw := query.Get("resource"); raw != "" {
+		resourceType, resourceID, err := schema.SplitNamespaceAndResourceID(raw)
+		if err != nil {
+			httputil.WriteMessage(w, http.StatusBadRequest, "resource must be a namespaced id")
+			return
+		}
+		canReview, err := h.requestService.CanReview(ctx, relation.Object{ID: resourceID, Namespace: resourceType}, principal.ID)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		if !canReview {
+			httputil.WriteMessage(w, http.StatusForbidden, frontiererrors.ErrForbidden.Error())
+			return
+		}
+		flt.ResourceID = resourceID
+		flt.ResourceType = resourceType
+	} else {
+		flt.PrincipalID = principal.ID
+		flt.PrincipalType = principal.Type
+	}
+
+	requests, err := h.requestService.List(ctx, flt)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := listResponse{AccessRequests: make([]requestResponse, 0, len(requests))}
+	for _, req := range requests {
+		response.AccessRequests = append(response.AccessRequests, toResponse(req))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+// Get returns a request with the users who can review it, only its requester
+// and approvers can see it
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	req, err := h.requestService.Get(ctx, r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	approvers, err := h.requestService.Approvers(ctx, req)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if req.PrincipalID != principal.ID && !contains(approvers, principal.ID) {
+		// don't disclose requests to principals who can't see them
+		h.writeError(w, frontieraccessrequest.ErrNotExist)
+		return
+	}
+	response := toResponse(req)
+	response.Approvers = approvers
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+type reviewRequest struct {
+	Note string `json:"note"`
+}
+
+// Approve grants the requested role until the requested duration elapsed
+func (h *Handler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.requestService.Approve)
+}
+
+// Deny closes the request without granting the role
+func (h *Handler) Deny(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.requestService.Deny)
+}
+
+func (h *Handler) review(w http.ResponseWriter, r *http.Request,
+	fn func(ctx context.Context, id, reviewerID, note string) (frontieraccessrequest.Request, error)) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req reviewRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.WriteMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	reviewed, err := fn(ctx, r.PathValue("id"), principal.ID, req.Note)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toResponse(reviewed))
+}
+
+// Cancel withdraws a pending request of the caller
+func (h *Handler) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	cancelled, err := h.requestService.Cancel(ctx, r.PathValue("id"), principal.ID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toResponse(cancelled))
+}
+
+func contains(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontieraccessrequest.ErrInvalidDetail),
+		errors.Is(err, frontieraccessrequest.ErrJustificationRequired),
+		errors.Is(err, frontieraccessrequest.ErrInvalidDuration),
+		errors.Is(err, role.ErrInvalidID):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontieraccessrequest.ErrNotApprover),
+		errors.Is(err, frontieraccessrequest.ErrNotRequester):
+		status = http.StatusForbidden
+	case errors.Is(err, frontieraccessrequest.ErrNotExist), errors.Is(err, role.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontieraccessrequest.ErrNotPending),
+		errors.Is(err, frontieraccessrequest.ErrAlreadyGranted):
+		status = http.StatusConflict
+	case errors.Is(err, frontieraccessrequest.ErrNoApprover):
+		status = http.StatusUnprocessableEntity
+	default:
+		h.log.Error("access request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
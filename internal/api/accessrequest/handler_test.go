@@ -0,0 +1,217 @@
+package accessrequest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	frontieraccessrequest "github.com/raystack/frontier/core/accessrequest"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/accessrequest/mocks"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	principal = authenticate.Principal{ID: "requester-id", Type: schema.UserPrincipal, User: &user.User{ID: "requester-id"}}
+	approver  = authenticate.Principal{ID: "approver-id", Type: schema.UserPrincipal, User: &user.User{ID: "approver-id"}}
+	pending   = frontieraccessrequest.Request{
+		ID:            "request-id",
+		RoleID:        "role-id",
+		ResourceID:    "project-id",
+		ResourceType:  schema.ProjectNamespace,
+		PrincipalID:   "requester-id",
+		PrincipalType: schema.UserPrincipal,
+		Justification: "investigate the incident",
+		Duration:      4 * time.Hour,
+		State:         frontieraccessrequest.StatePending,
+		CreatedAt:     time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+	}
+)
+
+type handlerMocks struct {
+	authn    *httpmocks.AuthnService
+	requests *mocks.AccessRequestService
+	decoder  *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:    httpmocks.NewAuthnService(t),
+		requests: mocks.NewAccessRequestService(t),
+		decoder:  httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.requests, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Run("should file a request of the caller", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.requests.EXPECT().Create(mock.Anything, frontieraccessrequest.Request{
+			RoleID:        "role-id",
+			ResourceID:    "project-id",
+			ResourceType:  schema.ProjectNamespace,
+			PrincipalID:   "requester-id",
+			PrincipalType: schema.UserPrincipal,
+			Justification: "investigate the incident",
+			Duration:      4 * time.Hour,
+		}).Return(pending, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/access-requests", strings.NewReader(`{
+			"role_id": "role-id",
+			"resource": "app/project:project-id",
+			"justification": "investigate the incident",
+			"duration": "4h"
+		}`)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id": "request-id",
+			"role_id": "role-id",
+			"resource": "app/project:project-id",
+			"principal": "app/user:requester-id",
+			"justification": "investigate the incident",
+			"duration": "4h0m0s",
+			"state": "pending",
+			"created_at": "2026-10-18T12:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject invalid durations", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/access-requests", strings.NewReader(`{
+			"role_id": "role-id",
+			"resource": "app/project:project-id",
+			"justification": "investigate the incident",
+			"duration": "a while"
+		}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should report resources nobody can approve requests on", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.requests.EXPECT().Create(mock.Anything, mock.Anything).Return(frontieraccessrequest.Request{}, frontieraccessrequest.ErrNoApprover)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/access-requests", strings.NewReader(`{
+			"role_id": "role-id",
+			"resource": "app/project:project-id",
+			"justification": "investigate the incident",
+			"duration": "4h"
+		}`)))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Run("should list the pending requests on a resource to its approvers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, approver, nil)
+		m.requests.EXPECT().CanReview(mock.Anything, relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}, "approver-id").Return(true, nil)
+		m.requests.EXPECT().List(mock.Anything, frontieraccessrequest.Filter{
+			ResourceID:   "project-id",
+			ResourceType: schema.ProjectNamespace,
+			State:        frontieraccessrequest.StatePending,
+		}).Return([]frontieraccessrequest.Request{pending}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/access-requests?resource=app/project:project-id&state=pending", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":"request-id"`)
+	})
+
+	t.Run("should not list the requests on a resource to others", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.requests.EXPECT().CanReview(mock.Anything, relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}, "requester-id").Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/access-requests?resource=app/project:project-id", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should list the requests of the caller", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.requests.EXPECT().List(mock.Anything, frontieraccessrequest.Filter{
+			PrincipalID:   "requester-id",
+			PrincipalType: schema.UserPrincipal,
+		}).Return(nil, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/access-requests", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"access_requests": []}`, rec.Body.String())
+	})
+}
+
+func TestHandler_Get(t *testing.T) {
+	t.Run("should hide requests from principals who can't see them", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, authenticate.Principal{ID: "stranger-id", Type: schema.UserPrincipal}, nil)
+		m.requests.EXPECT().Get(mock.Anything, "request-id").Return(pending, nil)
+		m.requests.EXPECT().Approvers(mock.Anything, pending).Return([]string{"approver-id"}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/access-requests/request-id", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestHandler_Approve(t *testing.T) {
+	t.Run("should approve the request as the caller", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, approver, nil)
+		approved := pending
+		approved.State = frontieraccessrequest.StateApproved
+		approved.ReviewerID = "approver-id"
+		approved.PolicyID = "policy-id"
+		m.requests.EXPECT().Approve(mock.Anything, "request-id", "approver-id", "go ahead").Return(approved, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/access-requests/request-id/approve", strings.NewReader(`{"note": "go ahead"}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"policy_id":"policy-id"`)
+	})
+
+	t.Run("should reject reviews of principals who aren't approvers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.requests.EXPECT().Approve(mock.Anything, "request-id", "requester-id", "").Return(frontieraccessrequest.Request{}, frontieraccessrequest.ErrNotApprover)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/access-requests/request-id/approve", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject reviews of reviewed requests", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, approver, nil)
+		m.requests.EXPECT().Deny(mock.Anything, "request-id", "approver-id", "").Return(frontieraccessrequest.Request{}, frontieraccessrequest.ErrNotPending)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/access-requests/request-id/deny", nil))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
@@ -10,53 +10,79 @@ import (
 	"github.com/raystack/frontier/billing/product"
 	"github.com/raystack/frontier/billing/subscription"
 	"github.com/raystack/frontier/billing/usage"
+	"github.com/raystack/frontier/core/accessrequest"
 	"github.com/raystack/frontier/core/audit"
 	"github.com/raystack/frontier/core/authenticate"
 	"github.com/raystack/frontier/core/authenticate/session"
 	"github.com/raystack/frontier/core/deleter"
 	"github.com/raystack/frontier/core/domain"
 	"github.com/raystack/frontier/core/event"
+	"github.com/raystack/frontier/core/explain"
 	"github.com/raystack/frontier/core/group"
+	"github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/core/impersonation"
 	"github.com/raystack/frontier/core/invitation"
 	"github.com/raystack/frontier/core/kyc"
 	"github.com/raystack/frontier/core/metaschema"
+	"github.com/raystack/frontier/core/mfa"
 	"github.com/raystack/frontier/core/namespace"
+	"github.com/raystack/frontier/core/oauth2"
 	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/passkey"
 	"github.com/raystack/frontier/core/permission"
 	"github.com/raystack/frontier/core/policy"
 	"github.com/raystack/frontier/core/preference"
 	"github.com/raystack/frontier/core/project"
+	"github.com/raystack/frontier/core/ratelimit"
 	"github.com/raystack/frontier/core/relation"
 	"github.com/raystack/frontier/core/resource"
 	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/core/scim"
 	"github.com/raystack/frontier/core/serviceuser"
+	"github.com/raystack/frontier/core/simulation"
 	"github.com/raystack/frontier/core/user"
 	"github.com/raystack/frontier/core/webhook"
 	"github.com/raystack/frontier/internal/bootstrap"
+	"github.com/raystack/frontier/internal/store/spicedb"
 )
 
 type Deps struct {
-	OrgService         *organization.Service
-	OrgKycService      *kyc.Service
-	ProjectService     *project.Service
-	GroupService       *group.Service
-	RoleService        *role.Service
-	PolicyService      *policy.Service
-	UserService        *user.Service
-	NamespaceService   *namespace.Service
-	PermissionService  *permission.Service
-	RelationService    *relation.Service
-	ResourceService    *resource.Service
-	SessionService     *session.Service
-	AuthnService       *authenticate.Service
-	DeleterService     *deleter.Service
-	MetaSchemaService  *metaschema.Service
-	BootstrapService   *bootstrap.Service
-	InvitationService  *invitation.Service
-	ServiceUserService *serviceuser.Service
-	AuditService       *audit.Service
-	DomainService      *domain.Service
-	PreferenceService  *preference.Service
+	OrgService           *organization.Service
+	OrgKycService        *kyc.Service
+	ProjectService       *project.Service
+	GroupService         *group.Service
+	RoleService          *role.Service
+	PolicyService        *policy.Service
+	UserService          *user.Service
+	NamespaceService     *namespace.Service
+	PermissionService    *permission.Service
+	RelationService      *relation.Service
+	ResourceService      *resource.Service
+	SessionService       *session.Service
+	AuthnService         *authenticate.Service
+	RateLimiter          *ratelimit.Limiter
+	OAuth2Service        *oauth2.Service
+	SAMLService          *saml.Service
+	IDPService           *idp.Service
+	SCIMService          *scim.Service
+	MFAService           *mfa.Service
+	PasskeyService       *passkey.Service
+	ImpersonationService *impersonation.Service
+	DeleterService       *deleter.Service
+	MetaSchemaService    *metaschema.Service
+	BootstrapService     *bootstrap.Service
+	InvitationService    *invitation.Service
+	ServiceUserService   *serviceuser.Service
+	AuditService         *audit.Service
+	AuditRetention       *audit.RetentionService
+	PolicyReaper         *policy.Reaper
+	CheckCache           *spicedb.CheckCache
+	AccessRequestService *accessrequest.Service
+	ExplainService       *explain.Service
+	SimulationService    *simulation.Service
+	DomainService        *domain.Service
+	PreferenceService    *preference.Service
 
 	CustomerService     *customer.Service
 	PlanService         *plan.Service
@@ -0,0 +1,549 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	frontieraudit "github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/utils"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	VerifyChainPath     = "GET /admin/organizations/{id}/audit-logs/verify"
+	ListRetentionPath   = "GET /admin/audit-logs/retention"
+	GetRetentionPath    = "GET /admin/organizations/{id}/audit-logs/retention"
+	SetRetentionPath    = "PUT /admin/organizations/{id}/audit-logs/retention"
+	DeleteRetentionPath = "DELETE /admin/organizations/{id}/audit-logs/retention"
+	ListArchivesPath    = "GET /admin/organizations/{id}/audit-logs/archives"
+	RestorePath         = "POST /admin/organizations/{id}/audit-logs/restore"
+	SearchPath          = "GET /organizations/{id}/audit-logs/search"
+
+	maxBodySize = 16 << 10
+)
+
+type AuditService interface {
+	VerifyChain(ctx context.Context, orgID string) (frontieraudit.ChainVerification, error)
+	Search(ctx context.Context, flt frontieraudit.Filter) (frontieraudit.SearchResult, error)
+}
+
+type RetentionService interface {
+	SetPolicy(ctx context.Context, policy frontieraudit.RetentionPolicy) (frontieraudit.RetentionPolicy, error)
+	GetPolicy(ctx context.Context, orgID string) (frontieraudit.RetentionPolicy, error)
+	ListPolicies(ctx context.Context) ([]frontieraudit.RetentionPolicy, error)
+	DeletePolicy(ctx context.Context, orgID string) error
+	ListArchives(ctx context.Context, orgID string, start, end time.Time) ([]frontieraudit.Archive, error)
+	Restore(ctx context.Context, orgID string, start, end time.Time) (int, error)
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+// Handler serves the search of the audit logs of organizations and the admin
+// endpoints verifying, retaining and restoring them, the admin endpoints are
+// only available to superusers
+type Handler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	auditService       AuditService
+	retentionService   RetentionService
+	resourceService    ResourceService
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, auditService AuditService,
+	retentionService RetentionService, resourceService ResourceService, userService httputil.SudoService,
+	serviceUserService httputil.SudoService, sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		auditService:       auditService,
+		retentionService:   retentionService,
+		resourceService:    resourceService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+	}
+}
+
+// Register mounts the audit log endpoints
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(VerifyChainPath, h.VerifyChain, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(ListRetentionPath, h.ListRetentionPolicies, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(GetRetentionPath, h.GetRetentionPolicy, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(SetRetentionPath, h.SetRetentionPolicy, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(DeleteRetentionPath, h.DeleteRetentionPolicy, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(ListArchivesPath, h.ListArchives, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(RestorePath, h.Restore, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(SearchPath, h.Search, httputil.WithScope(authenticate.ScopeRead))
+}
+
+type chainBreakResponse struct {
+	LogID    string `json:"log_id"`
+	Sequence int64  `json:"sequence"`
+	Reason   string `json:"reason"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// chainTruncationNote is returned with every verification as a valid chain
+// doesn't prove that none of the latest logs were deleted
+const chainTruncationNote = "deleting the latest logs leaves a valid chain, truncation is only detected by " +
+	"comparing head_sequence and head_hash with a chain anchor recorded outside of frontier"
+
+type chainVerificationResponse struct {
+	OrgID        string              `json:"org_id"`
+	Checked      int                 `json:"checked"`
+	Valid        bool                `json:"valid"`
+	Break        *chainBreakResponse `json:"break,omitempty"`
+	HeadSequence int64               `json:"head_sequence"`
+	HeadHash     string              `json:"head_hash"`
+	Note         string              `json:"note"`
+}
+
+// VerifyChain recomputes the hash chain of the audit logs of an organization
+// and reports the first broken link
+func (h *Handler) VerifyChain(w http.ResponseWriter, r *http.Request) {
+	ctx, orgID, ok := h.sudoOnOrg(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := h.auditService.VerifyChain(ctx, orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := chainVerificationResponse{
+		OrgID:        result.OrgID,
+		Checked:      result.Checked,
+		Valid:        result.Valid,
+		HeadSequence: result.HeadSequence,
+		HeadHash:     result.HeadHash,
+		Note:         chainTruncationNote,
+	}
+	if result.Break != nil {
+		response.Break = &chainBreakResponse{
+			LogID:    result.Break.LogID,
+			Sequence: result.Break.Sequence,
+			Reason:   result.Break.Reason,
+			Expected: result.Break.Expected,
+			Actual:   result.Break.Actual,
+		}
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+type retentionPolicyRequest struct {
+	// HotPeriod is a duration like "720h", 0 keeps the logs forever
+	HotPeriod string `json:"hot_period"`
+}
+
+type retentionPolicyResponse struct {
+	OrgID     string    `json:"org_id"`
+	HotPeriod string    `json:"hot_period"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type retentionPoliciesResponse struct {
+	Policies []retentionPolicyResponse `json:"policies"`
+}
+
+func toRetentionPolicyResponse(policy frontieraudit.RetentionPolicy) retentionPolicyResponse {
+	return retentionPolicyResponse{
+		OrgID:     policy.OrgID,
+		HotPeriod: policy.HotPeriod.String(),
+		CreatedAt: policy.CreatedAt,
+		UpdatedAt: policy.UpdatedAt,
+	}
+}
+
+// ListRetentionPolicies returns the organizations overriding the default
+// retention of their audit logs
+func (h *Handler) ListRetentionPolicies(w http.ResponseWriter, r *http.Request) {
+	ctx, ok := h.sudo(w, r)
+	if !ok {
+		return
+	}
+
+	policies, err := h.retentionService.ListPolicies(ctx)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := retentionPoliciesResponse{Policies: make([]retentionPolicyResponse, 0, len(policies))}
+	for _, policy := range policies {
+		response.Policies = append(response.Policies, toRetentionPolicyResponse(policy))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+// GetRetentionPolicy returns the retention policy of an organization
+func (h *Handler) GetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, orgID, ok := h.sudoOnOrg(w, r)
+	if !ok {
+		return
+	}
+
+	policy, err := h.retentionService.GetPolicy(ctx, orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toRetentionPolicyResponse(policy))
+}
+
+// SetRetentionPolicy sets how long the audit logs of an organization are
+// kept in the database before they are archived
+func (h *Handler) SetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, orgID, ok := h.sudoOnOrg(w, r)
+	if !ok {
+		return
+	}
+
+	var req retentionPolicyRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.HotPeriod == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "hot_period is required")
+		return
+	}
+	hotPeriod, err := time.ParseDuration(req.HotPeriod)
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "hot_period must be a duration, e.g. 720h")
+		return
+	}
+
+	policy, err := h.retentionService.SetPolicy(ctx, frontieraudit.RetentionPolicy{
+		OrgID:     orgID,
+		HotPeriod: hotPeriod,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toRetentionPolicyResponse(policy))
+}
+
+// DeleteRetentionPolicy reverts an organization to the default retention
+func (h *Handler) DeleteRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	ctx, orgID, ok := h.sudoOnOrg(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.retentionService.DeletePolicy(ctx, orgID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type archiveResponse struct {
+	ID           string    `json:"id"`
+	FromSequence int64     `json:"from_sequence"`
+	ToSequence   int64     `json:"to_sequence"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	Count        int       `json:"count"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type archivesResponse struct {
+	Archives []archiveResponse `json:"archives"`
+}
+
+// ListArchives returns the archives of the audit logs of an organization
+// overlapping the start_time and end_time query parameters
+func (h *Handler) ListArchives(w http.ResponseWriter, r *http.Request) {
+	ctx, orgID, ok := h.sudoOnOrg(w, r)
+	if !ok {
+		return
+	}
+	start, end, err := parseTimeRange(r.URL.Query().Get("start_time"), r.URL.Query().Get("end_time"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	archives, err := h.retentionService.ListArchives(ctx, orgID, start, end)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := archivesResponse{Archives: make([]archiveResponse, 0, len(archives))}
+	for _, archive := range archives {
+		response.Archives = append(response.Archives, archiveResponse{
+			ID:           archive.ID,
+			FromSequence: archive.FromSequence,
+			ToSequence:   archive.ToSequence,
+			StartTime:    archive.StartTime,
+			EndTime:      archive.EndTime,
+			Count:        archive.Count,
+			CreatedAt:    archive.CreatedAt,
+		})
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+type restoreRequest struct {
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type restoreResponse struct {
+	Restored int `json:"restored"`
+}
+
+// Restore brings the archived audit logs of an organization created within
+// the time range back to the database until they expire again
+func (h *Handler) Restore(w http.ResponseWriter, r *http.Request) {
+	ctx, orgID, ok := h.sudoOnOrg(w, r)
+	if !ok {
+		return
+	}
+
+	var req restoreRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	start, end, err := parseTimeRange(req.StartTime, req.EndTime)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	restored, err := h.retentionService.Restore(ctx, orgID, start, end)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, restoreResponse{Restored: restored})
+}
+
+type actorResponse struct {
+	ID             string         `json:"id"`
+	Type           string         `json:"type"`
+	Name           string         `json:"name,omitempty"`
+	ImpersonatedBy *actorResponse `json:"impersonated_by,omitempty"`
+}
+
+type targetResponse struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type logResponse struct {
+	ID        string            `json:"id"`
+	OrgID     string            `json:"org_id"`
+	Source    string            `json:"source"`
+	Action    string            `json:"action"`
+	Actor     actorResponse     `json:"actor"`
+	Target    targetResponse    `json:"target"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+type searchResponse struct {
+	Logs         []logResponse  `json:"logs"`
+	NextCursor   string         `json:"next_cursor,omitempty"`
+	ActionFacets map[string]int `json:"action_facets"`
+}
+
+func toActorResponse(actor frontieraudit.Actor) actorResponse {
+	response := actorResponse{
+		ID:   actor.ID,
+		Type: actor.Type,
+		Name: actor.Name,
+	}
+	if actor.ImpersonatedBy != nil {
+		impersonatedBy := toActorResponse(*actor.ImpersonatedBy)
+		response.ImpersonatedBy = &impersonatedBy
+	}
+	return response
+}
+
+// Search returns a page of the audit logs of an organization matching the
+// query parameters, newest first, along with the count of matching logs per
+// action. The caller needs the update permission on the organization
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	orgID := r.PathValue("id")
+	if !utils.IsValidUUID(orgID) {
+		httputil.WriteMessage(w, http.StatusBadRequest, "organization id must be a uuid")
+		return
+	}
+	if err := httputil.Authorize(ctx, h.resourceService, principal,
+		relation.Object{Namespace: schema.OrganizationNamespace, ID: orgID}, schema.UpdatePermission); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	flt, err := parseFilter(r.URL.Query())
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	flt.OrgID = orgID
+
+	result, err := h.auditService.Search(ctx, flt)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := searchResponse{
+		Logs:         make([]logResponse, 0, len(result.Logs)),
+		NextCursor:   result.NextCursor,
+		ActionFacets: result.ActionFacets,
+	}
+	if response.ActionFacets == nil {
+		response.ActionFacets = map[string]int{}
+	}
+	for _, l := range result.Logs {
+		response.Logs = append(response.Logs, logResponse{
+			ID:     l.ID,
+			OrgID:  l.OrgID,
+			Source: l.Source,
+			Action: l.Action,
+			Actor:  toActorResponse(l.Actor),
+			Target: targetResponse{
+				ID:   l.Target.ID,
+				Type: l.Target.Type,
+				Name: l.Target.Name,
+			},
+			Metadata:  l.Metadata,
+			CreatedAt: l.CreatedAt,
+		})
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+// parseFilter reads the search filter from the query parameters, metadata
+// is repeated as key:value pairs and the time bounds are rfc3339 times
+func parseFilter(query url.Values) (frontieraudit.Filter, error) {
+	flt := frontieraudit.Filter{
+		Source:     query.Get("source"),
+		Action:     query.Get("action"),
+		ActorID:    query.Get("actor_id"),
+		ActorType:  query.Get("actor_type"),
+		TargetID:   query.Get("target_id"),
+		TargetType: query.Get("target_type"),
+		Query:      query.Get("q"),
+		Cursor:     query.Get("cursor"),
+	}
+	for _, pair := range query["metadata"] {
+		key, value, found := strings.Cut(pair, ":")
+		if !found || key == "" {
+			return flt, errors.New("metadata must be a key:value pair")
+		}
+		if flt.Metadata == nil {
+			flt.Metadata = map[string]string{}
+		}
+		flt.Metadata[key] = value
+	}
+	for name, bound := range map[string]*time.Time{"start_time": &flt.StartTime, "end_time": &flt.EndTime} {
+		if value := query.Get(name); value != "" {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return flt, fmt.Errorf("%s must be an rfc3339 time", name)
+			}
+			*bound = t
+		}
+	}
+	if value := query.Get("ignore_system"); value != "" {
+		ignoreSystem, err := strconv.ParseBool(value)
+		if err != nil {
+			return flt, errors.New("ignore_system must be a boolean")
+		}
+		flt.IgnoreSystem = ignoreSystem
+	}
+	if value := query.Get("page_size"); value != "" {
+		pageSize, err := strconv.Atoi(value)
+		if err != nil || pageSize < 0 {
+			return flt, errors.New("page_size must be a positive number")
+		}
+		flt.PageSize = pageSize
+	}
+	return flt, nil
+}
+
+// parseTimeRange parses the rfc3339 bounds of a time range, both are required
+func parseTimeRange(startTime, endTime string) (time.Time, time.Time, error) {
+	start, err := time.Parse(time.RFC3339, startTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: start_time must be an rfc3339 time", frontieraudit.ErrInvalidRestoreRange)
+	}
+	end, err := time.Parse(time.RFC3339, endTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: end_time must be an rfc3339 time", frontieraudit.ErrInvalidRestoreRange)
+	}
+	return start, end, nil
+}
+
+// sudoOnOrg is sudo for the endpoints of an organization, it also returns
+// the id of the organization of the path
+func (h *Handler) sudoOnOrg(w http.ResponseWriter, r *http.Request) (context.Context, string, bool) {
+	ctx, ok := h.sudo(w, r)
+	if !ok {
+		return nil, "", false
+	}
+	orgID := r.PathValue("id")
+	if !utils.IsValidUUID(orgID) {
+		httputil.WriteMessage(w, http.StatusBadRequest, "organization id must be a uuid")
+		return nil, "", false
+	}
+	return ctx, orgID, true
+}
+
+// sudo authenticates the caller and verifies it is a superuser, it writes
+// the error response otherwise
+func (h *Handler) sudo(w http.ResponseWriter, r *http.Request) (context.Context, bool) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return nil, false
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return nil, false
+	}
+	return ctx, true
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontieraudit.ErrInvalidRetentionPolicy), errors.Is(err, frontieraudit.ErrInvalidRestoreRange),
+		errors.Is(err, frontieraudit.ErrInvalidCursor):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontieraudit.ErrRetentionPolicyNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, frontieraudit.ErrChainNotSupported):
+		status = http.StatusNotImplemented
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	default:
+		h.log.Error("audit log request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,365 @@
+package audit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	frontieraudit "github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/internal/api/audit/mocks"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const orgID = "9b2c4d6e-8f0a-4b1c-9d3e-5f7a9b1c3d5e"
+
+var admin = authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal}
+
+type handlerMocks struct {
+	authn        *httpmocks.AuthnService
+	audit        *mocks.AuditService
+	retention    *mocks.RetentionService
+	resources    *mocks.ResourceService
+	users        *httpmocks.SudoService
+	serviceUsers *httpmocks.SudoService
+	decoder      *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:        httpmocks.NewAuthnService(t),
+		audit:        mocks.NewAuditService(t),
+		retention:    mocks.NewRetentionService(t),
+		resources:    mocks.NewResourceService(t),
+		users:        httpmocks.NewSudoService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.audit, m.retention, m.resources, m.users, m.serviceUsers, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectAdmin(m handlerMocks, sudo bool) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(admin, nil)
+	m.users.EXPECT().IsSudo(mock.Anything, admin.ID, schema.PlatformSudoPermission).Return(sudo, nil)
+}
+
+func TestHandler_VerifyChain(t *testing.T) {
+	t.Run("should report the first broken link of the chain", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.audit.EXPECT().VerifyChain(mock.Anything, orgID).Return(frontieraudit.ChainVerification{
+			OrgID:        orgID,
+			Checked:      3,
+			HeadSequence: 2,
+			HeadHash:     "abc",
+			Break: &frontieraudit.ChainBreak{
+				LogID:    "log-id",
+				Sequence: 3,
+				Reason:   "hash mismatch",
+				Expected: "abc",
+				Actual:   "def",
+			},
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/organizations/"+orgID+"/audit-logs/verify", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"org_id": "`+orgID+`",
+			"checked": 3,
+			"valid": false,
+			"break": {"log_id": "log-id", "sequence": 3, "reason": "hash mismatch", "expected": "abc", "actual": "def"},
+			"head_sequence": 2,
+			"head_hash": "abc",
+			"note": "`+chainTruncationNote+`"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should report intact chains", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.audit.EXPECT().VerifyChain(mock.Anything, orgID).Return(frontieraudit.ChainVerification{
+			OrgID:        orgID,
+			Checked:      10,
+			Valid:        true,
+			HeadSequence: 10,
+			HeadHash:     "abc",
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/organizations/"+orgID+"/audit-logs/verify", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"org_id": "`+orgID+`",
+			"checked": 10,
+			"valid": true,
+			"head_sequence": 10,
+			"head_hash": "abc",
+			"note": "`+chainTruncationNote+`"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should fail if the repository doesn't keep the chain", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.audit.EXPECT().VerifyChain(mock.Anything, orgID).Return(frontieraudit.ChainVerification{}, frontieraudit.ErrChainNotSupported)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/organizations/"+orgID+"/audit-logs/verify", nil))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+	})
+
+	t.Run("should forbid callers which are not superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/organizations/"+orgID+"/audit-logs/verify", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestHandler_RetentionPolicy(t *testing.T) {
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should set the hot period of the organization", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.retention.EXPECT().SetPolicy(mock.Anything, frontieraudit.RetentionPolicy{
+			OrgID:     orgID,
+			HotPeriod: 720 * time.Hour,
+		}).Return(frontieraudit.RetentionPolicy{
+			OrgID:     orgID,
+			HotPeriod: 720 * time.Hour,
+			CreatedAt: updatedAt,
+			UpdatedAt: updatedAt,
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/organizations/"+orgID+"/audit-logs/retention",
+			strings.NewReader(`{"hot_period": "720h"}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"org_id": "`+orgID+`",
+			"hot_period": "720h0m0s",
+			"created_at": "2024-01-01T00:00:00Z",
+			"updated_at": "2024-01-01T00:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject invalid hot periods", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/organizations/"+orgID+"/audit-logs/retention",
+			strings.NewReader(`{"hot_period": "a month"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return not found for organizations without a policy", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.retention.EXPECT().GetPolicy(mock.Anything, orgID).Return(frontieraudit.RetentionPolicy{}, frontieraudit.ErrRetentionPolicyNotFound)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/organizations/"+orgID+"/audit-logs/retention", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should delete the policy of the organization", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.retention.EXPECT().DeletePolicy(mock.Anything, orgID).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/organizations/"+orgID+"/audit-logs/retention", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should forbid callers which are not superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/audit-logs/retention", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestHandler_Restore(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should restore the archived logs of the range", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.retention.EXPECT().Restore(mock.Anything, orgID, start, end).Return(42, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/organizations/"+orgID+"/audit-logs/restore",
+			strings.NewReader(`{"start_time": "2024-01-01T00:00:00Z", "end_time": "2024-02-01T00:00:00Z"}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"restored": 42}`, rec.Body.String())
+	})
+
+	t.Run("should require both ends of the range", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/organizations/"+orgID+"/audit-logs/restore",
+			strings.NewReader(`{"start_time": "2024-01-01T00:00:00Z"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should list the archives of the range", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.retention.EXPECT().ListArchives(mock.Anything, orgID, start, end).Return([]frontieraudit.Archive{{
+			ID:           "archive-id",
+			OrgID:        orgID,
+			FromSequence: 1,
+			ToSequence:   42,
+			StartTime:    start,
+			EndTime:      end,
+			Count:        42,
+			CreatedAt:    end,
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/organizations/"+orgID+
+			"/audit-logs/archives?start_time=2024-01-01T00:00:00Z&end_time=2024-02-01T00:00:00Z", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"archives": [{
+			"id": "archive-id",
+			"from_sequence": 1,
+			"to_sequence": 42,
+			"start_time": "2024-01-01T00:00:00Z",
+			"end_time": "2024-02-01T00:00:00Z",
+			"count": 42,
+			"created_at": "2024-02-01T00:00:00Z"
+		}]}`, rec.Body.String())
+	})
+}
+
+func TestHandler_Search(t *testing.T) {
+	member := authenticate.Principal{ID: "member-id", Type: schema.UserPrincipal}
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	expectMember := func(m handlerMocks, allowed bool) {
+		m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		m.authn.EXPECT().GetPrincipal(mock.Anything).Return(member, nil)
+		m.resources.EXPECT().CheckAuthz(mock.Anything, resource.Check{
+			Object:     relation.Object{Namespace: schema.OrganizationNamespace, ID: orgID},
+			Subject:    relation.Subject{ID: member.ID, Namespace: member.Type},
+			Permission: schema.UpdatePermission,
+		}).Return(allowed, nil)
+	}
+
+	t.Run("should search the logs of the organization with the filters", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectMember(m, true)
+		m.audit.EXPECT().Search(mock.Anything, frontieraudit.Filter{
+			OrgID:        orgID,
+			Action:       "app.project.updated",
+			ActorID:      "actor-id",
+			TargetType:   "app/project",
+			Metadata:     map[string]string{"region": "eu"},
+			Query:        `"billing" -test`,
+			StartTime:    createdAt,
+			IgnoreSystem: true,
+			PageSize:     10,
+			Cursor:       "cursor",
+		}).Return(frontieraudit.SearchResult{
+			Logs: []frontieraudit.Log{{
+				ID:     "log-id",
+				OrgID:  orgID,
+				Source: "frontier",
+				Action: "app.project.updated",
+				Actor: frontieraudit.Actor{
+					ID:             "actor-id",
+					Type:           schema.UserPrincipal,
+					ImpersonatedBy: &frontieraudit.Actor{ID: "admin-id", Type: schema.UserPrincipal},
+				},
+				Target:    frontieraudit.Target{ID: "project-id", Type: "app/project", Name: "billing"},
+				Metadata:  map[string]string{"region": "eu"},
+				CreatedAt: createdAt,
+			}},
+			NextCursor:   "next",
+			ActionFacets: map[string]int{"app.project.updated": 12},
+		}, nil)
+
+		query := url.Values{
+			"action":        {"app.project.updated"},
+			"actor_id":      {"actor-id"},
+			"target_type":   {"app/project"},
+			"metadata":      {"region:eu"},
+			"q":             {`"billing" -test`},
+			"start_time":    {"2024-01-01T00:00:00Z"},
+			"ignore_system": {"true"},
+			"page_size":     {"10"},
+			"cursor":        {"cursor"},
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/organizations/"+orgID+"/audit-logs/search?"+query.Encode(), nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"logs": [{
+				"id": "log-id",
+				"org_id": "`+orgID+`",
+				"source": "frontier",
+				"action": "app.project.updated",
+				"actor": {"id": "actor-id", "type": "app/user", "impersonated_by": {"id": "admin-id", "type": "app/user"}},
+				"target": {"id": "project-id", "type": "app/project", "name": "billing"},
+				"metadata": {"region": "eu"},
+				"created_at": "2024-01-01T00:00:00Z"
+			}],
+			"next_cursor": "next",
+			"action_facets": {"app.project.updated": 12}
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject invalid cursors", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectMember(m, true)
+		m.audit.EXPECT().Search(mock.Anything, frontieraudit.Filter{OrgID: orgID, Cursor: "oops"}).
+			Return(frontieraudit.SearchResult{}, frontieraudit.ErrInvalidCursor)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/organizations/"+orgID+"/audit-logs/search?cursor=oops", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should reject malformed metadata filters", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectMember(m, true)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/organizations/"+orgID+"/audit-logs/search?metadata=region", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should forbid callers which can't update the organization", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectMember(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/organizations/"+orgID+"/audit-logs/search", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -0,0 +1,173 @@
+package explain
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	frontierexplain "github.com/raystack/frontier/core/explain"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	ExplainPath = "GET /admin/permissions/explain"
+)
+
+type ExplainService interface {
+	Explain(ctx context.Context, subject relation.Subject, object relation.Object, permission string) (frontierexplain.Explanation, error)
+}
+
+// Handler serves the admin endpoint explaining why a principal has or lacks
+// a permission, it is only available to superusers
+type Handler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	explainService     ExplainService
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, explainService ExplainService,
+	userService httputil.SudoService, serviceUserService httputil.SudoService, sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		explainService:     explainService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+	}
+}
+
+// Register mounts the permission explanation endpoint
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(ExplainPath, h.Explain)
+}
+
+type grantResponse struct {
+	PolicyID string   `json:"policy_id"`
+	RoleID   string   `json:"role_id"`
+	RoleName string   `json:"role_name"`
+	Resource string   `json:"resource"`
+	Via      []string `json:"via"`
+}
+
+type stepResponse struct {
+	Object string `json:"object"`
+	Name   string `json:"name"`
+	// Type is permission or relation
+	Type     string         `json:"type"`
+	Subject  string         `json:"subject"`
+	Result   string         `json:"result"`
+	Cached   bool           `json:"cached"`
+	PolicyID string         `json:"policy_id,omitempty"`
+	RoleName string         `json:"role_name,omitempty"`
+	Children []stepResponse `json:"children"`
+}
+
+type explainResponse struct {
+	Allowed bool            `json:"allowed"`
+	Grants  []grantResponse `json:"grants"`
+	Tree    stepResponse    `json:"tree"`
+}
+
+func toStepResponse(step frontierexplain.Step) stepResponse {
+	response := stepResponse{
+		Object:   schema.JoinNamespaceAndResourceID(step.Object.Namespace, step.Object.ID),
+		Name:     step.Name,
+		Type:     "relation",
+		Subject:  subjectString(step.Subject),
+		Result:   string(step.Result),
+		Cached:   step.Cached,
+		PolicyID: step.PolicyID,
+		RoleName: step.RoleName,
+		Children: make([]stepResponse, 0, len(step.Children)),
+	}
+	if step.IsPermission {
+		response.Type = "permission"
+	}
+	for _, child := range step.Children {
+		response.Children = append(response.Children, toStepResponse(child))
+	}
+	return response
+}
+
+func subjectString(subject relation.Subject) string {
+	str := schema.JoinNamespaceAndResourceID(subject.Namespace, subject.ID)
+	if subject.SubRelationName != "" {
+		str += "#" + subject.SubRelationName
+	}
+	return str
+}
+
+// Explain checks the permission of the subject on the resource and returns
+// the policies granting it along with the resolution tree of the check
+func (h *Handler) Explain(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	subjectNamespace, subjectID, err := schema.SplitNamespaceAndResourceID(query.Get("subject"))
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "subject must be a namespaced id, e.g. app/user:<id>")
+		return
+	}
+	objectNamespace, objectID, err := schema.SplitNamespaceAndResourceID(query.Get("resource"))
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "resource must be a namespaced id, e.g. app/project:<id>")
+		return
+	}
+
+	explanation, err := h.explainService.Explain(ctx,
+		relation.Subject{ID: subjectID, Namespace: subjectNamespace},
+		relation.Object{ID: objectID, Namespace: objectNamespace},
+		query.Get("permission"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	response := explainResponse{
+		Allowed: explanation.Allowed,
+		Grants:  make([]grantResponse, 0, len(explanation.Grants)),
+		Tree:    toStepResponse(explanation.Tree),
+	}
+	for _, grant := range explanation.Grants {
+		via := make([]string, 0, len(grant.Via))
+		for _, group := range grant.Via {
+			via = append(via, schema.JoinNamespaceAndResourceID(group.Namespace, group.ID))
+		}
+		response.Grants = append(response.Grants, grantResponse{
+			PolicyID: grant.PolicyID,
+			RoleID:   grant.RoleID,
+			RoleName: grant.RoleName,
+			Resource: schema.JoinNamespaceAndResourceID(grant.Resource.Namespace, grant.Resource.ID),
+			Via:      via,
+		})
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontierexplain.ErrInvalidDetail):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	default:
+		h.log.Error("permission explanation failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
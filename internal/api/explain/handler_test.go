@@ -0,0 +1,133 @@
+package explain
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontierexplain "github.com/raystack/frontier/core/explain"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/api/explain/mocks"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var admin = authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal}
+
+type handlerMocks struct {
+	authn        *httpmocks.AuthnService
+	explain      *mocks.ExplainService
+	users        *httpmocks.SudoService
+	serviceUsers *httpmocks.SudoService
+	decoder      *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:        httpmocks.NewAuthnService(t),
+		explain:      mocks.NewExplainService(t),
+		users:        httpmocks.NewSudoService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.explain, m.users, m.serviceUsers, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func TestHandler_Explain(t *testing.T) {
+	t.Run("should explain the permission to superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		m.users.EXPECT().IsSudo(mock.Anything, admin.ID, schema.PlatformSudoPermission).Return(true, nil)
+		m.explain.EXPECT().Explain(mock.Anything,
+			relation.Subject{ID: "user-id", Namespace: schema.UserPrincipal},
+			relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace},
+			"delete").Return(frontierexplain.Explanation{
+			Allowed: true,
+			Grants: []frontierexplain.Grant{{
+				PolicyID: "policy-id",
+				RoleID:   "role-id",
+				RoleName: "app_project_owner",
+				Resource: relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace},
+				Via:      []relation.Object{{ID: "group-id", Namespace: schema.GroupNamespace}},
+			}},
+			Tree: frontierexplain.Step{
+				Object:       relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace},
+				Name:         "delete",
+				IsPermission: true,
+				Subject:      relation.Subject{ID: "user-id", Namespace: schema.UserPrincipal},
+				Result:       relation.TraceResultAllowed,
+			},
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/admin/permissions/explain?subject=app/user:user-id&resource=app/project:project-id&permission=delete", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"allowed": true,
+			"grants": [{
+				"policy_id": "policy-id",
+				"role_id": "role-id",
+				"role_name": "app_project_owner",
+				"resource": "app/project:project-id",
+				"via": ["app/group:group-id"]
+			}],
+			"tree": {
+				"object": "app/project:project-id",
+				"name": "delete",
+				"type": "permission",
+				"subject": "app/user:user-id",
+				"result": "allowed",
+				"cached": false,
+				"children": []
+			}
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject other principals", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		m.users.EXPECT().IsSudo(mock.Anything, admin.ID, schema.PlatformSudoPermission).Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/admin/permissions/explain?subject=app/user:user-id&resource=app/project:project-id&permission=delete", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject impersonated superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		impersonated := admin
+		impersonated.ImpersonatedBy = &authenticate.Principal{ID: "other-admin-id", Type: schema.UserPrincipal}
+		expectPrincipal(m, impersonated, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/admin/permissions/explain?subject=app/user:user-id&resource=app/project:project-id&permission=delete", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject malformed subjects", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		m.users.EXPECT().IsSudo(mock.Anything, admin.ID, schema.PlatformSudoPermission).Return(true, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/admin/permissions/explain?subject=user-id&resource=app/project:project-id&permission=delete", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
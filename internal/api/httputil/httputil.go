@@ -0,0 +1,148 @@
+// Package httputil holds what the plain http handlers of the api share:
+// authenticating the caller, authorizing it and writing json responses.
+package httputil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+)
+
+type AuthnService interface {
+	GetPrincipal(ctx context.Context, via ...authenticate.ClientAssertion) (authenticate.Principal, error)
+}
+
+// SessionDecoder builds the request context holding the session and the
+// credentials of the caller
+type SessionDecoder interface {
+	RequestContext(r *http.Request) context.Context
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+type SudoService interface {
+	IsSudo(ctx context.Context, id string, permissionName string) (bool, error)
+}
+
+// ErrResourceRequired is returned when authorizing a check without an object
+var ErrResourceRequired = errors.New("resource id is required")
+
+// ErrorResponse is the body of the error responses
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+func WriteJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// WriteMessage writes an error response with the message
+func WriteMessage(w http.ResponseWriter, status int, message string) {
+	WriteJSON(w, status, ErrorResponse{Message: message})
+}
+
+// Authenticator resolves the principal calling a handler
+type Authenticator struct {
+	authnService   AuthnService
+	sessionDecoder SessionDecoder
+}
+
+func NewAuthenticator(authnService AuthnService, sessionDecoder SessionDecoder) *Authenticator {
+	return &Authenticator{
+		authnService:   authnService,
+		sessionDecoder: sessionDecoder,
+	}
+}
+
+// Principal authenticates the caller and sets it as the actor of the audit
+// logs written while serving the request, it writes the error response if
+// the caller isn't authenticated. The principal resolved by ResolvePrincipal
+// is used if the request went through it.
+func (a *Authenticator) Principal(w http.ResponseWriter, r *http.Request) (context.Context, authenticate.Principal, bool) {
+	ctx := a.sessionDecoder.RequestContext(r)
+	var principal authenticate.Principal
+	var err error
+	if resolved, ok := resolvedFromContext(ctx); ok {
+		principal, err = resolved.principal, resolved.err
+	} else {
+		principal, err = a.authnService.GetPrincipal(ctx)
+	}
+	if err != nil {
+		WriteMessage(w, http.StatusUnauthorized, "not authenticated")
+		return nil, authenticate.Principal{}, false
+	}
+	return ContextWithActor(ctx, principal), principal, true
+}
+
+// ContextWithActor sets the principal as the actor of the audit logs
+func ContextWithActor(ctx context.Context, principal authenticate.Principal) context.Context {
+	actor := audit.Actor{
+		ID:   principal.ID,
+		Type: principal.Type,
+	}
+	if principal.ImpersonatedBy != nil {
+		actor.ImpersonatedBy = &audit.Actor{
+			ID:   principal.ImpersonatedBy.ID,
+			Type: principal.ImpersonatedBy.Type,
+		}
+	}
+	return audit.SetContextWithActor(ctx, actor)
+}
+
+// Authorize checks the principal has the permission on the object, it
+// returns frontiererrors.ErrForbidden if it doesn't
+func Authorize(ctx context.Context, resourceService ResourceService, principal authenticate.Principal,
+	object relation.Object, permission string) error {
+	if object.ID == "" {
+		return ErrResourceRequired
+	}
+	allowed, err := resourceService.CheckAuthz(ctx, resource.Check{
+		Object: object,
+		Subject: relation.Subject{
+			ID:        principal.ID,
+			Namespace: principal.Type,
+		},
+		Permission: permission,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return frontiererrors.ErrForbidden
+	}
+	return nil
+}
+
+// CheckSudo verifies the principal is a superuser acting as itself
+func CheckSudo(ctx context.Context, users SudoService, serviceUsers SudoService, principal authenticate.Principal) error {
+	if principal.ImpersonatedBy != nil {
+		return frontiererrors.ErrForbidden
+	}
+	var isSudo bool
+	var err error
+	switch principal.Type {
+	case schema.UserPrincipal:
+		isSudo, err = users.IsSudo(ctx, principal.ID, schema.PlatformSudoPermission)
+	case schema.ServiceUserPrincipal:
+		isSudo, err = serviceUsers.IsSudo(ctx, principal.ID, schema.PlatformSudoPermission)
+	}
+	if err != nil {
+		return err
+	}
+	if !isSudo {
+		return frontiererrors.ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package httputil_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/server/consts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticator_Principal(t *testing.T) {
+	t.Run("should set the impersonator along with the principal as the actor", func(t *testing.T) {
+		authn, decoder := mocks.NewAuthnService(t), mocks.NewSessionDecoder(t)
+		principal := authenticate.Principal{
+			ID:             "user-id",
+			Type:           schema.UserPrincipal,
+			ImpersonatedBy: &authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal},
+		}
+		decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, nil)
+
+		ctx, got, ok := httputil.NewAuthenticator(authn, decoder).Principal(httptest.NewRecorder(),
+			httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.True(t, ok)
+		assert.Equal(t, principal, got)
+		actor, _ := ctx.Value(consts.AuditActorContextKey).(audit.Actor)
+		assert.Equal(t, audit.Actor{
+			ID:             "user-id",
+			Type:           schema.UserPrincipal,
+			ImpersonatedBy: &audit.Actor{ID: "admin-id", Type: schema.UserPrincipal},
+		}, actor)
+	})
+
+	t.Run("should reject unauthenticated callers", func(t *testing.T) {
+		authn, decoder := mocks.NewAuthnService(t), mocks.NewSessionDecoder(t)
+		decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		authn.EXPECT().GetPrincipal(mock.Anything).Return(authenticate.Principal{}, errors.New("no credentials"))
+
+		rec := httptest.NewRecorder()
+		_, _, ok := httputil.NewAuthenticator(authn, decoder).Principal(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.JSONEq(t, `{"message": "not authenticated"}`, rec.Body.String())
+	})
+}
+
+func TestResolvePrincipal(t *testing.T) {
+	t.Run("should resolve the principal once for the middlewares and the handler", func(t *testing.T) {
+		authn, decoder := mocks.NewAuthnService(t), mocks.NewSessionDecoder(t)
+		principal := authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, AuthenticatedAt: time.Now()}
+		decoder.EXPECT().RequestContext(mock.Anything).RunAndReturn(func(r *http.Request) context.Context {
+			return r.Context()
+		})
+		authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, nil).Once()
+
+		authenticator := httputil.NewAuthenticator(authn, decoder)
+		mux := http.NewServeMux()
+		httputil.NewRouter(mux, httputil.ResolvePrincipal(authn, decoder), httputil.ClientScopes(),
+			httputil.RecentAuthentication(mocks.NewRecentAuthnService(t),
+				map[string]time.Duration{"POST /password/change": time.Minute})).Handle("POST /password/change",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, got, ok := authenticator.Principal(w, r)
+				assert.True(t, ok)
+				assert.Equal(t, principal, got)
+			})
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/change", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should reject unauthenticated callers in the handler without resolving again", func(t *testing.T) {
+		authn, decoder := mocks.NewAuthnService(t), mocks.NewSessionDecoder(t)
+		decoder.EXPECT().RequestContext(mock.Anything).RunAndReturn(func(r *http.Request) context.Context {
+			return r.Context()
+		})
+		authn.EXPECT().GetPrincipal(mock.Anything).Return(authenticate.Principal{}, errors.New("no credentials")).Once()
+
+		authenticator := httputil.NewAuthenticator(authn, decoder)
+		mux := http.NewServeMux()
+		httputil.NewRouter(mux, httputil.ResolvePrincipal(authn, decoder), httputil.ClientScopes()).Handle("GET /items",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _, ok := authenticator.Principal(w, r)
+				assert.False(t, ok)
+			})
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	principal := authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal}
+	project := relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}
+
+	t.Run("should return forbidden when the check fails", func(t *testing.T) {
+		resources := mocks.NewResourceService(t)
+		resources.EXPECT().CheckAuthz(mock.Anything, mock.Anything).Return(false, nil)
+		err := httputil.Authorize(context.Background(), resources, principal, project, schema.UpdatePermission)
+		assert.ErrorIs(t, err, frontiererrors.ErrForbidden)
+	})
+
+	t.Run("should require the id of the object", func(t *testing.T) {
+		err := httputil.Authorize(context.Background(), mocks.NewResourceService(t), principal,
+			relation.Object{Namespace: schema.ProjectNamespace}, schema.UpdatePermission)
+		assert.ErrorIs(t, err, httputil.ErrResourceRequired)
+	})
+}
+
+func TestRouter_Handle(t *testing.T) {
+	t.Run("should apply the middlewares outermost first with the route", func(t *testing.T) {
+		var calls []string
+		middleware := func(name string) httputil.Middleware {
+			return func(route httputil.Route, next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					calls = append(calls, name+" "+route.Pattern+" "+route.Scope)
+					next.ServeHTTP(w, r)
+				})
+			}
+		}
+		mux := http.NewServeMux()
+		httputil.NewRouter(mux, middleware("outer"), middleware("inner")).Handle("GET /items/{id}",
+			func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, "handler")
+			}, httputil.WithScope(authenticate.ScopeRead))
+
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/1", nil))
+		assert.Equal(t, []string{"outer GET /items/{id} frontier:read", "inner GET /items/{id} frontier:read", "handler"}, calls)
+	})
+}
+
+func TestRecentAuthentication(t *testing.T) {
+	rules := map[string]time.Duration{"POST /password/change": 15 * time.Minute}
+	serve := func(t *testing.T, principal authenticate.Principal, pattern string) (*httptest.ResponseRecorder, bool) {
+		authn, decoder, strategies := mocks.NewAuthnService(t), mocks.NewSessionDecoder(t), mocks.NewRecentAuthnService(t)
+		decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, nil).Once()
+		strategies.EXPECT().SupportedStrategies().Return([]string{"mailotp"}).Maybe()
+
+		called := false
+		mux := http.NewServeMux()
+		httputil.NewRouter(mux, httputil.ResolvePrincipal(authn, decoder),
+			httputil.RecentAuthentication(strategies, rules)).Handle(pattern,
+			func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				got, ok := authenticate.GetPrincipalFromContext(r.Context())
+				assert.True(t, ok)
+				assert.Equal(t, principal.ID, got.ID)
+			})
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/change", nil))
+		return rec, called
+	}
+
+	t.Run("should let users who logged in recently through", func(t *testing.T) {
+		_, called := serve(t, authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal,
+			AuthenticatedAt: time.Now().Add(-time.Minute)}, "POST /password/change")
+		assert.True(t, called)
+	})
+
+	t.Run("should ask users who logged in long ago to log in again", func(t *testing.T) {
+		rec, called := serve(t, authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal,
+			AuthenticatedAt: time.Now().Add(-time.Hour)}, "POST /password/change")
+		assert.False(t, called)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.JSONEq(t, `{
+			"message": "recent authentication is required, log in again",
+			"reason": "REAUTHENTICATION_REQUIRED",
+			"max_auth_age": 900,
+			"strategies": ["mailotp"]
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject impersonated users", func(t *testing.T) {
+		rec, called := serve(t, authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal,
+			AuthenticatedAt: time.Now(), ImpersonatedBy: &authenticate.Principal{ID: "admin-id"}}, "POST /password/change")
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should not check endpoints without a rule", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		called := false
+		mux := http.NewServeMux()
+		httputil.NewRouter(mux, httputil.RecentAuthentication(mocks.NewRecentAuthnService(t), rules)).
+			Handle("POST /password/reset", func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/reset", nil))
+		assert.True(t, called)
+	})
+}
+
+func TestClientScopes(t *testing.T) {
+	client := authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal,
+		ClientID: "client-id", Scopes: []string{authenticate.ScopeRead}}
+	serve := func(t *testing.T, principal authenticate.Principal, err error, opts ...httputil.RouteOption) (*httptest.ResponseRecorder, bool) {
+		authn, decoder := mocks.NewAuthnService(t), mocks.NewSessionDecoder(t)
+		decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err).Once()
+
+		called := false
+		mux := http.NewServeMux()
+		httputil.NewRouter(mux, httputil.ResolvePrincipal(authn, decoder), httputil.ClientScopes()).Handle("GET /items",
+			func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}, opts...)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+		return rec, called
+	}
+
+	t.Run("should let clients granted the scope of the route through", func(t *testing.T) {
+		_, called := serve(t, client, nil, httputil.WithScope(authenticate.ScopeRead))
+		assert.True(t, called)
+	})
+
+	t.Run("should reject clients missing the scope of the route", func(t *testing.T) {
+		rec, called := serve(t, client, nil, httputil.WithScope(authenticate.ScopeWrite))
+		assert.False(t, called)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject clients on routes without a scope", func(t *testing.T) {
+		_, called := serve(t, client, nil)
+		assert.False(t, called)
+	})
+
+	t.Run("should let users through routes without a scope", func(t *testing.T) {
+		_, called := serve(t, authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal}, nil)
+		assert.True(t, called)
+	})
+
+	t.Run("should leave unauthenticated requests to the handler", func(t *testing.T) {
+		_, called := serve(t, authenticate.Principal{}, errors.New("no credentials"))
+		assert.True(t, called)
+	})
+}
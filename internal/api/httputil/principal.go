@@ -0,0 +1,44 @@
+package httputil
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/raystack/frontier/core/authenticate"
+)
+
+type resolvedPrincipalKey struct{}
+
+// resolvedPrincipal is the outcome of authenticating the caller of a request
+type resolvedPrincipal struct {
+	principal authenticate.Principal
+	err       error
+}
+
+// ResolvePrincipal authenticates the caller once per request and keeps the
+// outcome in the request context for the middlewares and handlers after it,
+// it must come before the middlewares reading the principal. Requests failing
+// to authenticate are passed along for the handlers to reject.
+func ResolvePrincipal(authnService AuthnService, sessionDecoder SessionDecoder) Middleware {
+	return func(_ Route, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, err := authnService.GetPrincipal(sessionDecoder.RequestContext(r))
+			ctx := context.WithValue(r.Context(), resolvedPrincipalKey{}, resolvedPrincipal{
+				principal: principal,
+				err:       err,
+			})
+			if err == nil {
+				// services resolving the principal again find it in the context
+				ctx = authenticate.SetContextWithPrincipal(ctx, &principal)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// resolvedFromContext returns the outcome of ResolvePrincipal, it's false if
+// the request didn't go through it
+func resolvedFromContext(ctx context.Context) (resolvedPrincipal, bool) {
+	resolved, ok := ctx.Value(resolvedPrincipalKey{}).(resolvedPrincipal)
+	return resolved, ok
+}
@@ -0,0 +1,59 @@
+package httputil
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+)
+
+// ReauthRequiredReason tells clients to run a login flow again with any of
+// the strategies of the response, same as the grpc error details
+const ReauthRequiredReason = "REAUTHENTICATION_REQUIRED"
+
+type RecentAuthnService interface {
+	SupportedStrategies() []string
+}
+
+type reauthRequiredResponse struct {
+	Message string `json:"message"`
+	Reason  string `json:"reason"`
+	// MaxAuthAge is in seconds
+	MaxAuthAge int      `json:"max_auth_age"`
+	Strategies []string `json:"strategies"`
+}
+
+// RecentAuthentication asks the callers of the endpoints with a rule to have
+// logged in within its max age, rules are keyed by route pattern. It reads
+// the principal resolved by ResolvePrincipal.
+func RecentAuthentication(authnService RecentAuthnService, rules map[string]time.Duration) Middleware {
+	return func(route Route, next http.Handler) http.Handler {
+		maxAge, ok := rules[route.Pattern]
+		if !ok {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolved, ok := resolvedFromContext(r.Context())
+			if !ok || resolved.err != nil {
+				WriteMessage(w, http.StatusUnauthorized, "not authenticated")
+				return
+			}
+			if err := resolved.principal.CheckRecentAuthentication(maxAge, time.Now()); err != nil {
+				if errors.Is(err, authenticate.ErrReauthRequired) {
+					WriteJSON(w, http.StatusUnauthorized, reauthRequiredResponse{
+						Message:    err.Error(),
+						Reason:     ReauthRequiredReason,
+						MaxAuthAge: int(maxAge.Seconds()),
+						Strategies: authnService.SupportedStrategies(),
+					})
+					return
+				}
+				WriteMessage(w, http.StatusForbidden, frontiererrors.ErrForbidden.Error())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
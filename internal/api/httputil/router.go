@@ -0,0 +1,58 @@
+package httputil
+
+import (
+	"net/http"
+)
+
+// Route is an endpoint registered on the router
+type Route struct {
+	Pattern string
+	// Scope is what oauth2 clients must have been granted to call the
+	// endpoint, endpoints without one are never allowed to them
+	Scope string
+}
+
+type RouteOption func(*Route)
+
+// WithScope allows oauth2 clients granted the scope to call the endpoint
+func WithScope(scope string) RouteOption {
+	return func(r *Route) {
+		r.Scope = scope
+	}
+}
+
+// Middleware decorates the handler of the route
+type Middleware func(route Route, next http.Handler) http.Handler
+
+// Router mounts the endpoints of the handlers on a mux, each decorated by the
+// middlewares with the first one being the outermost
+type Router struct {
+	mux         *http.ServeMux
+	middlewares []Middleware
+}
+
+func NewRouter(mux *http.ServeMux, middlewares ...Middleware) *Router {
+	return &Router{
+		mux:         mux,
+		middlewares: middlewares,
+	}
+}
+
+func (r *Router) Handle(pattern string, handler http.HandlerFunc, opts ...RouteOption) {
+	route := Route{Pattern: pattern}
+	for _, opt := range opts {
+		opt(&route)
+	}
+	var h http.Handler = handler
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		h = r.middlewares[i](route, h)
+	}
+	r.mux.Handle(pattern, h)
+}
+
+// Wrap adapts a wrapper decorating every endpoint alike
+func Wrap(wrapper func(http.Handler) http.Handler) Middleware {
+	return func(_ Route, next http.Handler) http.Handler {
+		return wrapper(next)
+	}
+}
@@ -0,0 +1,25 @@
+package httputil
+
+import (
+	"net/http"
+)
+
+// ClientScopes rejects the oauth2 clients which weren't granted the scope of
+// the route. It reads the principal resolved by ResolvePrincipal, requests
+// failing to authenticate are left for the handlers to reject.
+func ClientScopes() Middleware {
+	return func(route Route, next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resolved, ok := resolvedFromContext(r.Context())
+			if !ok || resolved.err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if !resolved.principal.HasScope(route.Scope) {
+				WriteMessage(w, http.StatusForbidden, "access token wasn't granted the scope of the operation")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
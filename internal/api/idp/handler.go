@@ -0,0 +1,250 @@
+package idp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	frontieridp "github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	CreatePath = "POST /v1beta1/organizations/{org}/idp"
+	GetPath    = "GET /v1beta1/organizations/{org}/idp"
+	DeletePath = "DELETE /v1beta1/organizations/{org}/idp"
+	ListPath   = "GET /admin/idps"
+
+	maxBodySize = 16 << 10
+)
+
+type IDPService interface {
+	Create(ctx context.Context, provider frontieridp.Provider) (frontieridp.Provider, error)
+	GetByOrgID(ctx context.Context, orgID string) (frontieridp.Provider, error)
+	List(ctx context.Context) ([]frontieridp.Provider, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type OrgService interface {
+	Get(ctx context.Context, idOrName string) (organization.Organization, error)
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+// Handler serves the endpoints organization admins register the oidc
+// identity provider of their organization with, they require the update
+// permission on the organization. Superusers list the providers of every
+// organization. Client secrets are never returned.
+type Handler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	idpService         IDPService
+	orgService         OrgService
+	resourceService    ResourceService
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, idpService IDPService, orgService OrgService,
+	resourceService ResourceService, userService httputil.SudoService, serviceUserService httputil.SudoService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		idpService:         idpService,
+		orgService:         orgService,
+		resourceService:    resourceService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+	}
+}
+
+// Register mounts the endpoints identity providers are managed with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(CreatePath, h.Create, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(GetPath, h.Get, httputil.WithScope(authenticate.ScopeRead))
+	router.Handle(DeletePath, h.Delete, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(ListPath, h.List, httputil.WithScope(authenticate.ScopeAdmin))
+}
+
+type providerResponse struct {
+	ID        string    `json:"id"`
+	OrgID     string    `json:"org_id"`
+	IssuerURL string    `json:"issuer_url"`
+	ClientID  string    `json:"client_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toResponse(p frontieridp.Provider) providerResponse {
+	return providerResponse{
+		ID:        p.ID,
+		OrgID:     p.OrgID,
+		IssuerURL: p.IssuerURL,
+		ClientID:  p.ClientID,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+type createRequest struct {
+	IssuerURL    string `json:"issuer_url"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// Create registers the identity provider of the organization
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req createRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "issuer_url, client_id and client_secret are required")
+		return
+	}
+	orgID, err := h.authorize(ctx, principal, r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	provider, err := h.idpService.Create(ctx, frontieridp.Provider{
+		OrgID:        orgID,
+		IssuerURL:    req.IssuerURL,
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, provider.OrgID).
+		LogWithAttrs(audit.OrgIDPCreatedEvent, audit.OrgTarget(provider.OrgID), map[string]string{
+			"provider_id": provider.ID,
+			"issuer_url":  provider.IssuerURL,
+		})
+	httputil.WriteJSON(w, http.StatusCreated, toResponse(provider))
+}
+
+// Get returns the identity provider of the organization
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	orgID, err := h.authorize(ctx, principal, r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	provider, err := h.idpService.GetByOrgID(ctx, orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toResponse(provider))
+}
+
+// Delete removes the identity provider of the organization, its members
+// can't log in with it anymore. Existing sessions stay valid until they expire.
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	orgID, err := h.authorize(ctx, principal, r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	provider, err := h.idpService.GetByOrgID(ctx, orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if err := h.idpService.Delete(ctx, provider.ID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, provider.OrgID).
+		LogWithAttrs(audit.OrgIDPDeletedEvent, audit.OrgTarget(provider.OrgID), map[string]string{
+			"provider_id": provider.ID,
+			"issuer_url":  provider.IssuerURL,
+		})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type listResponse struct {
+	Providers []providerResponse `json:"providers"`
+}
+
+// List returns the identity providers of every organization
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	providers, err := h.idpService.List(ctx)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := listResponse{Providers: make([]providerResponse, 0, len(providers))}
+	for _, p := range providers {
+		response.Providers = append(response.Providers, toResponse(p))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+// authorize resolves the organization addressed by its id or name and checks
+// the principal can update it
+func (h *Handler) authorize(ctx context.Context, principal authenticate.Principal, orgIDOrName string) (string, error) {
+	org, err := h.orgService.Get(ctx, orgIDOrName)
+	if err != nil {
+		return "", err
+	}
+	if err := httputil.Authorize(ctx, h.resourceService, principal, relation.Object{
+		Namespace: schema.OrganizationNamespace,
+		ID:        org.ID,
+	}, schema.UpdatePermission); err != nil {
+		return "", err
+	}
+	return org.ID, nil
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontieridp.ErrInvalidDetail), errors.Is(err, organization.ErrInvalidUUID):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, frontieridp.ErrNotExist), errors.Is(err, organization.ErrNotExist),
+		errors.Is(err, organization.ErrDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, frontieridp.ErrConflict):
+		status = http.StatusConflict
+	default:
+		h.log.Error("identity provider request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,162 @@
+package idp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/authenticate"
+	frontieridp "github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/idp/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	principal = authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, User: &user.User{ID: "user-id"}}
+	testOrg   = organization.Organization{ID: "org-id", Name: "acme"}
+)
+
+type handlerMocks struct {
+	authn        *httpmocks.AuthnService
+	decoder      *httpmocks.SessionDecoder
+	idps         *mocks.IDPService
+	orgs         *mocks.OrgService
+	resources    *mocks.ResourceService
+	users        *httpmocks.SudoService
+	serviceUsers *httpmocks.SudoService
+	auditRepo    *auditmocks.Repository
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:        httpmocks.NewAuthnService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+		idps:         mocks.NewIDPService(t),
+		orgs:         mocks.NewOrgService(t),
+		resources:    mocks.NewResourceService(t),
+		users:        httpmocks.NewSudoService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		auditRepo:    auditmocks.NewRepository(t),
+	}
+	handler := NewHandler(log.NewNoop(), m.authn, m.idps, m.orgs, m.resources, m.users, m.serviceUsers, m.decoder)
+	mux := http.NewServeMux()
+	handler.Register(httputil.NewRouter(mux))
+
+	ctx := audit.SetContextWithService(context.Background(), audit.NewService("frontier", m.auditRepo, audit.NewNoopWebhookService()))
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(ctx)
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, nil)
+	return mux, m
+}
+
+func expectOrgUpdate(m handlerMocks, allowed bool) {
+	m.orgs.EXPECT().Get(mock.Anything, testOrg.Name).Return(testOrg, nil)
+	m.resources.EXPECT().CheckAuthz(mock.Anything, resource.Check{
+		Object:     relation.Object{Namespace: schema.OrganizationNamespace, ID: testOrg.ID},
+		Subject:    relation.Subject{ID: principal.ID, Namespace: principal.Type},
+		Permission: schema.UpdatePermission,
+	}).Return(allowed, nil)
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Run("should register the identity provider without returning the secret", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectOrgUpdate(m, true)
+		m.idps.EXPECT().Create(mock.Anything, frontieridp.Provider{
+			OrgID:        testOrg.ID,
+			IssuerURL:    "https://accounts.acme.org",
+			ClientID:     "frontier",
+			ClientSecret: "secret",
+		}).Return(frontieridp.Provider{
+			ID:           "provider-id",
+			OrgID:        testOrg.ID,
+			IssuerURL:    "https://accounts.acme.org",
+			ClientID:     "frontier",
+			ClientSecret: "secret",
+			CreatedAt:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		}, nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == testOrg.ID && l.Action == audit.OrgIDPCreatedEvent.String() &&
+				l.Actor.ID == principal.ID && l.Metadata["provider_id"] == "provider-id"
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/organizations/acme/idp", strings.NewReader(`{
+			"issuer_url": "https://accounts.acme.org",
+			"client_id": "frontier",
+			"client_secret": "secret"
+		}`)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id": "provider-id",
+			"org_id": "org-id",
+			"issuer_url": "https://accounts.acme.org",
+			"client_id": "frontier",
+			"created_at": "2026-10-18T12:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject callers who can't update the organization", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectOrgUpdate(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/organizations/acme/idp", strings.NewReader(`{
+			"issuer_url": "https://accounts.acme.org",
+			"client_id": "frontier",
+			"client_secret": "secret"
+		}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject invalid provider details", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectOrgUpdate(m, true)
+		m.idps.EXPECT().Create(mock.Anything, mock.Anything).Return(frontieridp.Provider{}, frontieridp.ErrInvalidDetail)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/organizations/acme/idp",
+			strings.NewReader(`{"issuer_url": "ftp://acme.org"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestHandler_Delete(t *testing.T) {
+	t.Run("should delete the identity provider of the organization", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectOrgUpdate(m, true)
+		m.idps.EXPECT().GetByOrgID(mock.Anything, testOrg.ID).Return(frontieridp.Provider{ID: "provider-id", OrgID: testOrg.ID}, nil)
+		m.idps.EXPECT().Delete(mock.Anything, "provider-id").Return(nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == testOrg.ID && l.Action == audit.OrgIDPDeletedEvent.String()
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1beta1/organizations/acme/idp", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Run("should only list the providers to superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		m.users.EXPECT().IsSudo(mock.Anything, principal.ID, schema.PlatformSudoPermission).Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/idps", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
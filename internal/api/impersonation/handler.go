@@ -0,0 +1,131 @@
+package impersonation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontierimpersonation "github.com/raystack/frontier/core/impersonation"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	StartPath = "POST /impersonations"
+	EndPath   = "DELETE /impersonations/{id}"
+
+	maxBodySize = 4 << 10
+)
+
+type ImpersonationService interface {
+	Start(ctx context.Context, impersonator authenticate.Principal, userID, reason string) (frontierimpersonation.Impersonation, error)
+	End(ctx context.Context, principal authenticate.Principal, sessionID uuid.UUID) error
+}
+
+// Handler serves the endpoints superusers impersonate users with. They are
+// plain http handlers as the api has no messages for them yet.
+type Handler struct {
+	log                  log.Logger
+	impersonationService ImpersonationService
+	authenticator        *httputil.Authenticator
+}
+
+func NewHandler(logger log.Logger, impersonationService ImpersonationService, authnService httputil.AuthnService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:                  logger,
+		impersonationService: impersonationService,
+		authenticator:        httputil.NewAuthenticator(authnService, sessionDecoder),
+	}
+}
+
+// Register mounts the endpoints impersonations are started and ended with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(StartPath, h.Start)
+	router.Handle(EndPath, h.End)
+}
+
+type startRequest struct {
+	UserID string `json:"user_id"`
+	Reason string `json:"reason"`
+}
+
+type startResponse struct {
+	SessionID   string    `json:"session_id"`
+	UserID      string    `json:"user_id"`
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// Start opens an impersonation session as the user, the access token in the
+// response is used as bearer token to act as the user
+func (h *Handler) Start(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req startRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.UserID == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	impersonation, err := h.impersonationService.Start(ctx, principal, req.UserID, req.Reason)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, startResponse{
+		SessionID:   impersonation.SessionID.String(),
+		UserID:      impersonation.UserID,
+		AccessToken: impersonation.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresAt:   impersonation.ExpiresAt,
+	})
+}
+
+// End revokes an impersonation session along with its access tokens
+func (h *Handler) End(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusNotFound, frontierimpersonation.ErrNotExist.Error())
+		return
+	}
+	if err := h.impersonationService.End(ctx, principal, sessionID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontierimpersonation.ErrUnauthenticated):
+		status = http.StatusUnauthorized
+	case errors.Is(err, frontierimpersonation.ErrForbidden), errors.Is(err, frontierimpersonation.ErrChained),
+		errors.Is(err, frontierimpersonation.ErrInvalidTarget), errors.Is(err, frontierimpersonation.ErrDisabledByOrg):
+		status = http.StatusForbidden
+	case errors.Is(err, frontierimpersonation.ErrReasonRequired):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontierimpersonation.ErrNotExist), errors.Is(err, user.ErrNotExist),
+		errors.Is(err, user.ErrInvalidUUID), errors.Is(err, user.ErrInvalidID):
+		status = http.StatusNotFound
+	default:
+		h.log.Error("impersonation failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,150 @@
+package impersonation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontierimpersonation "github.com/raystack/frontier/core/impersonation"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/impersonation/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	admin         = authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal}
+	testSessionID = uuid.MustParse("0f8a4b1e-6b5c-4d3a-9e2f-1a2b3c4d5e6f")
+)
+
+type handlerMocks struct {
+	impersonation *mocks.ImpersonationService
+	authn         *httpmocks.AuthnService
+	decoder       *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		impersonation: mocks.NewImpersonationService(t),
+		authn:         httpmocks.NewAuthnService(t),
+		decoder:       httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.impersonation, m.authn, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func TestHandler_Start(t *testing.T) {
+	t.Run("should return the access token of the impersonation", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		m.impersonation.EXPECT().Start(mock.Anything, admin, "user-id", "ticket 42").Return(frontierimpersonation.Impersonation{
+			SessionID:   testSessionID,
+			UserID:      "user-id",
+			AccessToken: "token",
+			ExpiresAt:   time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC),
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/impersonations",
+			strings.NewReader(`{"user_id": "user-id", "reason": "ticket 42"}`)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"session_id": "0f8a4b1e-6b5c-4d3a-9e2f-1a2b3c4d5e6f",
+			"user_id": "user-id",
+			"access_token": "token",
+			"token_type": "Bearer",
+			"expires_at": "2026-10-18T12:30:00Z"
+		}`, rec.Body.String())
+	})
+
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(m handlerMocks)
+		wantStatus int
+	}{
+		{
+			name: "should reject unauthenticated requests",
+			body: `{"user_id": "user-id", "reason": "ticket 42"}`,
+			setup: func(m handlerMocks) {
+				expectPrincipal(m, authenticate.Principal{}, errors.ErrUnauthenticated)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "should require the user",
+			body: `{"reason": "ticket 42"}`,
+			setup: func(m handlerMocks) {
+				expectPrincipal(m, admin, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should reject principals without sudo permission",
+			body: `{"user_id": "user-id", "reason": "ticket 42"}`,
+			setup: func(m handlerMocks) {
+				expectPrincipal(m, admin, nil)
+				m.impersonation.EXPECT().Start(mock.Anything, admin, "user-id", "ticket 42").
+					Return(frontierimpersonation.Impersonation{}, frontierimpersonation.ErrForbidden)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "should reject users of organizations which opted out",
+			body: `{"user_id": "user-id", "reason": "ticket 42"}`,
+			setup: func(m handlerMocks) {
+				expectPrincipal(m, admin, nil)
+				m.impersonation.EXPECT().Start(mock.Anything, admin, "user-id", "ticket 42").
+					Return(frontierimpersonation.Impersonation{}, frontierimpersonation.ErrDisabledByOrg)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			tt.setup(m)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/impersonations", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestHandler_End(t *testing.T) {
+	t.Run("should end the impersonation", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		m.impersonation.EXPECT().End(mock.Anything, admin, testSessionID).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/impersonations/"+testSessionID.String(), nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return not found for invalid session ids", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/impersonations/session-id", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,229 @@
+package mfa
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	frontiermfa "github.com/raystack/frontier/core/mfa"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	StatusPath  = "GET /mfa/totp"
+	EnrollPath  = "POST /mfa/totp/enroll"
+	ConfirmPath = "POST /mfa/totp/confirm"
+	VerifyPath  = "POST /mfa/totp/verify"
+	DisablePath = "POST /mfa/totp/disable"
+
+	maxBodySize = 1 << 10
+)
+
+type MFAService interface {
+	Status(ctx context.Context, session *frontiersession.Session) (frontiermfa.Status, error)
+	Enroll(ctx context.Context, session *frontiersession.Session) (frontiermfa.Enrollment, error)
+	Confirm(ctx context.Context, session *frontiersession.Session, code string) ([]string, error)
+	Verify(ctx context.Context, session *frontiersession.Session, code string) (*frontiersession.Session, error)
+	Disable(ctx context.Context, session *frontiersession.Session, code string) error
+}
+
+type SessionService interface {
+	ExtractFromContext(ctx context.Context) (*frontiersession.Session, error)
+}
+
+// Handler serves the endpoints to enroll an authenticator app and verify its
+// codes. They are plain http handlers as sessions pending the second factor
+// are rejected by the api.
+type Handler struct {
+	log            log.Logger
+	mfaService     MFAService
+	sessionService SessionService
+	sessionDecoder httputil.SessionDecoder
+	Now            func() time.Time
+}
+
+func NewHandler(logger log.Logger, mfaService MFAService, sessionService SessionService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:            logger,
+		mfaService:     mfaService,
+		sessionService: sessionService,
+		sessionDecoder: sessionDecoder,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Register mounts the endpoints of the authenticator app factor
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(StatusPath, h.Status)
+	router.Handle(EnrollPath, h.Enroll)
+	router.Handle(ConfirmPath, h.Confirm)
+	router.Handle(VerifyPath, h.Verify)
+	router.Handle(DisablePath, h.Disable)
+}
+
+type statusResponse struct {
+	Enrolled          bool `json:"enrolled"`
+	Required          bool `json:"required"`
+	Pending           bool `json:"pending"`
+	RecoveryCodesLeft int  `json:"recovery_codes_left"`
+}
+
+type enrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	// QRCode is a data uri of the png image, usable as image source
+	QRCode string `json:"qr_code"`
+}
+
+type codeRequest struct {
+	Code string `json:"code"`
+}
+
+type confirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// Status tells if the user enrolled an app and if the session still has to
+// verify it
+func (h *Handler) Status(w http.ResponseWriter, r *http.Request) {
+	ctx, session, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	status, err := h.mfaService.Status(ctx, session)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, statusResponse{
+		Enrolled:          status.Enrolled,
+		Required:          status.Required,
+		Pending:           status.Pending,
+		RecoveryCodesLeft: status.RecoveryCodes,
+	})
+}
+
+// Enroll generates the secret of a new authenticator app
+func (h *Handler) Enroll(w http.ResponseWriter, r *http.Request) {
+	ctx, session, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	enrollment, err := h.mfaService.Enroll(ctx, session)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, enrollResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+		QRCode:          "data:image/png;base64," + base64.StdEncoding.EncodeToString(enrollment.QRCode),
+	})
+}
+
+// Confirm enables the enrolled app with its first code and returns the
+// recovery codes of the user
+func (h *Handler) Confirm(w http.ResponseWriter, r *http.Request) {
+	ctx, session, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	code, ok := readCode(w, r)
+	if !ok {
+		return
+	}
+	recoveryCodes, err := h.mfaService.Confirm(ctx, session, code)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, confirmResponse{RecoveryCodes: recoveryCodes})
+}
+
+// Verify completes the login of a session pending the second factor
+func (h *Handler) Verify(w http.ResponseWriter, r *http.Request) {
+	ctx, session, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	code, ok := readCode(w, r)
+	if !ok {
+		return
+	}
+	if _, err := h.mfaService.Verify(ctx, session, code); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Disable removes the authenticator app of the user
+func (h *Handler) Disable(w http.ResponseWriter, r *http.Request) {
+	ctx, session, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	code, ok := readCode(w, r)
+	if !ok {
+		return
+	}
+	if err := h.mfaService.Disable(ctx, session, code); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// session returns the session of the request, either active or pending the
+// second factor
+func (h *Handler) session(w http.ResponseWriter, r *http.Request) (context.Context, *frontiersession.Session, bool) {
+	ctx := h.sessionDecoder.RequestContext(r)
+	session, err := h.sessionService.ExtractFromContext(ctx)
+	if err != nil || (!session.IsValid(h.Now()) && !session.IsMFAPending(h.Now())) {
+		if err != nil && !errors.Is(err, frontiersession.ErrNoSession) {
+			h.log.Error("failed to get session", "err", err)
+		}
+		httputil.WriteMessage(w, http.StatusUnauthorized, "not logged in")
+		return nil, nil, false
+	}
+	return ctx, session, true
+}
+
+func readCode(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req codeRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.Code == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "code is required")
+		return "", false
+	}
+	return req.Code, true
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontiermfa.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontiermfa.ErrInvalidCode):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiermfa.ErrTooManyAttempts):
+		status = http.StatusUnauthorized
+	case errors.Is(err, frontiermfa.ErrAlreadyEnrolled), errors.Is(err, frontiermfa.ErrNotPending):
+		status = http.StatusConflict
+	case errors.Is(err, frontiermfa.ErrPending), errors.Is(err, frontiermfa.ErrRequiredByOrg):
+		status = http.StatusForbidden
+	default:
+		h.log.Error("multi-factor authentication failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
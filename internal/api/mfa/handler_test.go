@@ -0,0 +1,163 @@
+package mfa
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	frontiermfa "github.com/raystack/frontier/core/mfa"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/mfa/mocks"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var handlerNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type handlerMocks struct {
+	mfa      *mocks.MFAService
+	sessions *mocks.SessionService
+	decoder  *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		mfa:      mocks.NewMFAService(t),
+		sessions: mocks.NewSessionService(t),
+		decoder:  httpmocks.NewSessionDecoder(t),
+	}
+	h := NewHandler(log.NewNoop(), m.mfa, m.sessions, m.decoder)
+	h.Now = func() time.Time {
+		return handlerNow
+	}
+	mux := http.NewServeMux()
+	h.Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectSession(m handlerMocks, state frontiersession.State) *frontiersession.Session {
+	session := &frontiersession.Session{
+		ID:              uuid.New(),
+		UserID:          "user-id",
+		State:           state,
+		AuthenticatedAt: handlerNow.Add(-time.Minute),
+		ExpiresAt:       handlerNow.Add(time.Hour),
+	}
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.sessions.EXPECT().ExtractFromContext(mock.Anything).Return(session, nil)
+	return session
+}
+
+func TestHandler_Status(t *testing.T) {
+	t.Run("should describe the second factor of the user", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		session := expectSession(m, frontiersession.StateActive)
+		m.mfa.EXPECT().Status(mock.Anything, session).Return(frontiermfa.Status{Enrolled: true, RecoveryCodes: 8}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mfa/totp", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"enrolled":true,"required":false,"pending":false,"recovery_codes_left":8}`, rec.Body.String())
+	})
+
+	t.Run("should reject requests without a session", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		m.sessions.EXPECT().ExtractFromContext(mock.Anything).Return(nil, frontiersession.ErrNoSession)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mfa/totp", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_Enroll(t *testing.T) {
+	mux, m := newTestHandler(t)
+	session := expectSession(m, frontiersession.StateMFAPending)
+	m.mfa.EXPECT().Enroll(mock.Anything, session).Return(frontiermfa.Enrollment{
+		Secret:          "GEZDGNBV",
+		ProvisioningURI: "otpauth://totp/Frontier:alice@acme.org?secret=GEZDGNBV",
+		QRCode:          []byte("png"),
+	}, nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mfa/totp/enroll", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"secret": "GEZDGNBV",
+		"provisioning_uri": "otpauth://totp/Frontier:alice@acme.org?secret=GEZDGNBV",
+		"qr_code": "data:image/png;base64,cG5n"
+	}`, rec.Body.String())
+}
+
+func TestHandler_Verify(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		setup  func(m handlerMocks)
+		status int
+	}{
+		{
+			name: "should activate the session",
+			body: `{"code":"123456"}`,
+			setup: func(m handlerMocks) {
+				session := expectSession(m, frontiersession.StateMFAPending)
+				m.mfa.EXPECT().Verify(mock.Anything, session, "123456").Return(session, nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name: "should reject an invalid code",
+			body: `{"code":"000000"}`,
+			setup: func(m handlerMocks) {
+				session := expectSession(m, frontiersession.StateMFAPending)
+				m.mfa.EXPECT().Verify(mock.Anything, session, "000000").Return(nil, frontiermfa.ErrInvalidCode)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "should log out after too many invalid codes",
+			body: `{"code":"000000"}`,
+			setup: func(m handlerMocks) {
+				session := expectSession(m, frontiersession.StateMFAPending)
+				m.mfa.EXPECT().Verify(mock.Anything, session, "000000").Return(nil, frontiermfa.ErrTooManyAttempts)
+			},
+			status: http.StatusUnauthorized,
+		},
+		{
+			name: "should require a code",
+			body: `{}`,
+			setup: func(m handlerMocks) {
+				expectSession(m, frontiersession.StateMFAPending)
+			},
+			status: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			tt.setup(m)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mfa/totp/verify", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestHandler_Disable(t *testing.T) {
+	mux, m := newTestHandler(t)
+	session := expectSession(m, frontiersession.StateActive)
+	m.mfa.EXPECT().Disable(mock.Anything, session, "123456").Return(frontiermfa.ErrRequiredByOrg)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mfa/totp/disable", strings.NewReader(`{"code":"123456"}`)))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
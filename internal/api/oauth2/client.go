@@ -0,0 +1,219 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	CreateClientPath       = "POST /admin/oauth2/clients"
+	ListClientsPath        = "GET /admin/oauth2/clients"
+	RotateClientSecretPath = "POST /admin/oauth2/clients/{id}/secret/rotate"
+	DeleteClientPath       = "DELETE /admin/oauth2/clients/{id}"
+
+	maxClientBodySize = 16 << 10
+)
+
+type ClientService interface {
+	CreateClient(ctx context.Context, client frontieroauth2.Client) (frontieroauth2.Client, string, error)
+	ListClients(ctx context.Context) ([]frontieroauth2.Client, error)
+	RotateClientSecret(ctx context.Context, id string) (string, error)
+	DeleteClient(ctx context.Context, id string) error
+}
+
+// ClientHandler serves the admin endpoints the clients of the authorization
+// server are registered with. Clients aren't owned by an organization, so
+// they are only available to superusers. Secrets are returned once, when the
+// client is created or its secret rotated, and never stored in plain.
+type ClientHandler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	clientService      ClientService
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+}
+
+func NewClientHandler(logger log.Logger, authnService httputil.AuthnService, clientService ClientService,
+	userService httputil.SudoService, serviceUserService httputil.SudoService, sessionDecoder httputil.SessionDecoder) *ClientHandler {
+	return &ClientHandler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		clientService:      clientService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+	}
+}
+
+// Register mounts the endpoints oauth2 clients are managed with
+func (h *ClientHandler) Register(router *httputil.Router) {
+	router.Handle(CreateClientPath, h.Create, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(ListClientsPath, h.List, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(RotateClientSecretPath, h.RotateSecret, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(DeleteClientPath, h.Delete, httputil.WithScope(authenticate.ScopeAdmin))
+}
+
+type clientResponse struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Secret       string    `json:"secret,omitempty"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	Scopes       []string  `json:"scopes"`
+	Public       bool      `json:"public"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func toClientResponse(c frontieroauth2.Client) clientResponse {
+	return clientResponse{
+		ID:           c.ID,
+		Name:         c.Name,
+		RedirectURIs: c.RedirectURIs,
+		Scopes:       c.Scopes,
+		Public:       c.Public,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+type createClientRequest struct {
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	Public       bool     `json:"public"`
+}
+
+// Create registers a client, confidential clients get a secret returned
+// only in this response
+func (h *ClientHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	var req createClientRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxClientBodySize)).Decode(&req); err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "name and redirect_uris are required")
+		return
+	}
+
+	client, secret, err := h.clientService.CreateClient(ctx, frontieroauth2.Client{
+		Name:         req.Name,
+		RedirectURIs: req.RedirectURIs,
+		Scopes:       req.Scopes,
+		Public:       req.Public,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		LogWithAttrs(audit.OAuth2ClientCreatedEvent, audit.OAuth2ClientTarget(client.ID), map[string]string{
+			"name": client.Name,
+		})
+	response := toClientResponse(client)
+	response.Secret = secret
+	httputil.WriteJSON(w, http.StatusCreated, response)
+}
+
+type listClientsResponse struct {
+	Clients []clientResponse `json:"clients"`
+}
+
+// List returns the registered clients without their secrets
+func (h *ClientHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	clients, err := h.clientService.ListClients(ctx)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := listClientsResponse{Clients: make([]clientResponse, 0, len(clients))}
+	for _, c := range clients {
+		response.Clients = append(response.Clients, toClientResponse(c))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+type rotateSecretResponse struct {
+	Secret string `json:"secret"`
+}
+
+// RotateSecret replaces the secret of a confidential client, the previous
+// secret stops working right away
+func (h *ClientHandler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	clientID := r.PathValue("id")
+	secret, err := h.clientService.RotateClientSecret(ctx, clientID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.OAuth2ClientSecretRotatedEvent, audit.OAuth2ClientTarget(clientID))
+	httputil.WriteJSON(w, http.StatusOK, rotateSecretResponse{Secret: secret})
+}
+
+// Delete removes a client along with its refresh tokens, access tokens
+// already issued stay valid until they expire
+func (h *ClientHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	clientID := r.PathValue("id")
+	if err := h.clientService.DeleteClient(ctx, clientID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, schema.PlatformOrgID.String()).
+		Log(audit.OAuth2ClientDeletedEvent, audit.OAuth2ClientTarget(clientID))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ClientHandler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontieroauth2.ErrInvalidClientDetail):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, frontieroauth2.ErrClientNotFound):
+		status = http.StatusNotFound
+	default:
+		h.log.Error("oauth2 client request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
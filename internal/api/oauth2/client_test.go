@@ -0,0 +1,173 @@
+package oauth2
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/authenticate"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/oauth2/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var adminPrincipal = authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal, User: &user.User{ID: "admin-id"}}
+
+type clientHandlerMocks struct {
+	clients   *mocks.ClientService
+	users     *httpmocks.SudoService
+	auditRepo *auditmocks.Repository
+}
+
+func newClientTestHandler(t *testing.T, sudo bool) (*http.ServeMux, clientHandlerMocks) {
+	authn := httpmocks.NewAuthnService(t)
+	decoder := httpmocks.NewSessionDecoder(t)
+	m := clientHandlerMocks{
+		clients:   mocks.NewClientService(t),
+		users:     httpmocks.NewSudoService(t),
+		auditRepo: auditmocks.NewRepository(t),
+	}
+	handler := NewClientHandler(log.NewNoop(), authn, m.clients, m.users, httpmocks.NewSudoService(t), decoder)
+	mux := http.NewServeMux()
+	handler.Register(httputil.NewRouter(mux))
+
+	ctx := audit.SetContextWithService(context.Background(), audit.NewService("frontier", m.auditRepo, audit.NewNoopWebhookService()))
+	decoder.EXPECT().RequestContext(mock.Anything).Return(ctx)
+	authn.EXPECT().GetPrincipal(mock.Anything).Return(adminPrincipal, nil)
+	m.users.EXPECT().IsSudo(mock.Anything, adminPrincipal.ID, schema.PlatformSudoPermission).Return(sudo, nil)
+	return mux, m
+}
+
+func TestClientHandler_Create(t *testing.T) {
+	t.Run("should return the secret of a new confidential client once", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().CreateClient(mock.Anything, frontieroauth2.Client{
+			Name:         "app",
+			RedirectURIs: []string{testRedirectURI},
+			Scopes:       []string{"profile"},
+		}).Return(frontieroauth2.Client{
+			ID:           "client-id",
+			Name:         "app",
+			SecretHash:   "hash",
+			RedirectURIs: []string{testRedirectURI},
+			Scopes:       []string{"profile"},
+			CreatedAt:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		}, "secret", nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == schema.PlatformOrgID.String() && l.Action == audit.OAuth2ClientCreatedEvent.String() &&
+				l.Target.ID == "client-id" && l.Actor.ID == adminPrincipal.ID
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/oauth2/clients", strings.NewReader(`{
+			"name": "app",
+			"redirect_uris": ["https://app.example.com/callback"],
+			"scopes": ["profile"]
+		}`)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id": "client-id",
+			"name": "app",
+			"secret": "secret",
+			"redirect_uris": ["https://app.example.com/callback"],
+			"scopes": ["profile"],
+			"public": false,
+			"created_at": "2026-10-18T12:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject callers who aren't superusers", func(t *testing.T) {
+		mux, _ := newClientTestHandler(t, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/oauth2/clients",
+			strings.NewReader(`{"name": "app"}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject invalid client details", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().CreateClient(mock.Anything, mock.Anything).Return(frontieroauth2.Client{}, "", frontieroauth2.ErrInvalidClientDetail)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/oauth2/clients",
+			strings.NewReader(`{"name": "app"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestClientHandler_List(t *testing.T) {
+	t.Run("should list the clients without their secrets", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().ListClients(mock.Anything).Return([]frontieroauth2.Client{{
+			ID:           "client-id",
+			Name:         "app",
+			SecretHash:   "hash",
+			RedirectURIs: []string{testRedirectURI},
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/oauth2/clients", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "hash")
+		assert.Contains(t, rec.Body.String(), `"id":"client-id"`)
+	})
+}
+
+func TestClientHandler_RotateSecret(t *testing.T) {
+	t.Run("should return the new secret", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().RotateClientSecret(mock.Anything, "client-id").Return("new-secret", nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.Action == audit.OAuth2ClientSecretRotatedEvent.String() && l.Target.ID == "client-id"
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/oauth2/clients/client-id/secret/rotate", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"secret": "new-secret"}`, rec.Body.String())
+	})
+
+	t.Run("should reject public clients", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().RotateClientSecret(mock.Anything, "client-id").Return("", frontieroauth2.ErrInvalidClientDetail)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/oauth2/clients/client-id/secret/rotate", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestClientHandler_Delete(t *testing.T) {
+	t.Run("should delete the client", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().DeleteClient(mock.Anything, "client-id").Return(nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.Action == audit.OAuth2ClientDeletedEvent.String() && l.Target.ID == "client-id"
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/oauth2/clients/client-id", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return not found for unknown clients", func(t *testing.T) {
+		mux, m := newClientTestHandler(t, true)
+		m.clients.EXPECT().DeleteClient(mock.Anything, "unknown").Return(frontieroauth2.ErrClientNotFound)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/oauth2/clients/unknown", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,45 @@
+package oauth2
+
+import (
+	"html/template"
+	"net/http"
+)
+
+type consentPage struct {
+	Challenge  string
+	ClientName string
+	Scopes     []string
+	User       string
+}
+
+// consentTemplate is the built-in consent page served when no external
+// consent url is configured
+var consentTemplate = template.Must(template.New("consent").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Authorize {{.ClientName}}</title>
+</head>
+<body>
+<h2>{{.ClientName}} wants to access your account</h2>
+<p>Signed in as {{.User}}</p>
+{{if .Scopes}}<p>The application is requesting the following permissions:</p>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<form method="post" action="` + ConsentPath + `">
+<input type="hidden" name="` + consentChallengeParam + `" value="{{.Challenge}}">
+<button type="submit" name="decision" value="deny">Deny</button>
+<button type="submit" name="decision" value="approve">Allow</button>
+</form>
+</body>
+</html>
+`))
+
+func renderConsentPage(w http.ResponseWriter, page consentPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// the page must not be framed by another site to trick users into approving
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = consentTemplate.Execute(w, page)
+}
@@ -0,0 +1,236 @@
+package oauth2
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+// DeviceAuthorization handles the device authorization request as per RFC 8628 section 3.1
+func (h *Handler) DeviceAuthorization(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidRequest, "malformed device authorization request"))
+		return
+	}
+	clientID, clientSecret := clientCredentials(r, r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"))
+
+	device, err := h.oauth2Service.AuthorizeDevice(r.Context(), clientID, clientSecret, r.PostForm.Get("scope"))
+	if err != nil {
+		var oauthErr *frontieroauth2.Error
+		if !errors.As(err, &oauthErr) {
+			h.log.Error("failed to authorize oauth2 device", "err", err)
+			oauthErr = frontieroauth2.NewError(frontieroauth2.ErrorCodeServerError, "")
+		}
+		writeTokenError(w, oauthErr)
+		return
+	}
+
+	verificationURI := h.config.OAuth2.DeviceVerificationURL
+	if verificationURI == "" {
+		verificationURI = strings.TrimSuffix(h.config.Token.Issuer, "/") + DevicePath
+	}
+	verificationURIComplete := verificationURI
+	if parsed, err := url.Parse(verificationURI); err == nil {
+		query := parsed.Query()
+		query.Set(userCodeParam, device.UserCode)
+		parsed.RawQuery = query.Encode()
+		verificationURIComplete = parsed.String()
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"device_code":               device.DeviceCode,
+		"user_code":                 device.UserCode,
+		"verification_uri":          verificationURI,
+		"verification_uri_complete": verificationURIComplete,
+		"expires_in":                int64(h.config.OAuth2.DeviceCodeValidity.Seconds()),
+		"interval":                  int64(device.Interval.Seconds()),
+	})
+}
+
+// Device is the verification page of RFC 8628 section 3.3 where a logged in
+// user enters the user code shown by the device and approves it. It shows the
+// pending request on GET and records the decision on POST, JSON is used when
+// requested by an external verification page
+func (h *Handler) Device(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	asJSON := strings.Contains(r.Header.Get("Accept"), "application/json") ||
+		strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+
+	ctx := h.sessionDecoder.RequestContext(r)
+	principal, err := h.authnService.GetPrincipal(ctx, authenticate.SessionClientAssertion)
+	if err != nil || principal.Type != schema.UserPrincipal || principal.User == nil {
+		if asJSON || r.Method != http.MethodGet || h.config.OAuth2.LoginURL == "" {
+			h.writeErrorPage(w, http.StatusUnauthorized, "user is not logged in")
+			return
+		}
+		loginURL, err := url.Parse(h.config.OAuth2.LoginURL)
+		if err != nil {
+			h.log.Error("invalid oauth2 login url", "err", err)
+			h.writeErrorPage(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		query := loginURL.Query()
+		query.Set("return_to", requestURL(r))
+		loginURL.RawQuery = query.Encode()
+		http.Redirect(w, r, loginURL.String(), http.StatusFound)
+		return
+	}
+
+	var userCode, decision string
+	if r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var body struct {
+			UserCode string `json:"user_code"`
+			Decision string `json:"decision"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeErrorPage(w, http.StatusBadRequest, "malformed device request")
+			return
+		}
+		userCode, decision = body.UserCode, body.Decision
+	} else {
+		if err := r.ParseForm(); err != nil {
+			h.writeErrorPage(w, http.StatusBadRequest, "malformed device request")
+			return
+		}
+		userCode, decision = r.Form.Get(userCodeParam), r.Form.Get("decision")
+	}
+
+	if r.Method == http.MethodGet {
+		if userCode == "" {
+			if asJSON {
+				h.writeErrorPage(w, http.StatusBadRequest, "user_code is required")
+				return
+			}
+			renderDevicePage(w, http.StatusOK, devicePage{User: principal.User.Email})
+			return
+		}
+		device, client, err := h.oauth2Service.GetPendingDevice(ctx, userCode)
+		if err != nil {
+			h.writeDeviceError(w, err, asJSON, principal.User.Email)
+			return
+		}
+		if asJSON {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"user_code": device.UserCode,
+				"client": map[string]any{
+					"id":   client.ID,
+					"name": client.Name,
+				},
+				"scopes": device.Scopes,
+			})
+			return
+		}
+		renderDevicePage(w, http.StatusOK, devicePage{
+			UserCode:   device.UserCode,
+			ClientName: client.Name,
+			Scopes:     device.Scopes,
+			User:       principal.User.Email,
+		})
+		return
+	}
+
+	// refresh tokens issued to the device live as long as the approving session
+	var sessionID string
+	if sess, err := h.sessionService.ExtractFromContext(ctx); err == nil {
+		sessionID = sess.ID.String()
+	}
+	device, err := h.oauth2Service.ApproveDevice(ctx, userCode, principal.ID, sessionID, decision == "approve")
+	if err != nil {
+		h.writeDeviceError(w, err, asJSON, principal.User.Email)
+		return
+	}
+	if asJSON {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"status": string(device.Status),
+		})
+		return
+	}
+	renderDevicePage(w, http.StatusOK, devicePage{
+		User:   principal.User.Email,
+		Status: string(device.Status),
+	})
+}
+
+func (h *Handler) writeDeviceError(w http.ResponseWriter, err error, asJSON bool, user string) {
+	switch {
+	case errors.Is(err, frontieroauth2.ErrDeviceNotFound) && !asJSON:
+		// let the user retry with the right code
+		renderDevicePage(w, http.StatusNotFound, devicePage{
+			User:  user,
+			Error: "The code is invalid or has expired",
+		})
+	case errors.Is(err, frontieroauth2.ErrDeviceNotFound):
+		h.writeErrorPage(w, http.StatusNotFound, "device request doesn't exist or has expired")
+	default:
+		h.log.Error("failed to process oauth2 device", "err", err)
+		h.writeErrorPage(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+type devicePage struct {
+	UserCode   string
+	ClientName string
+	Scopes     []string
+	User       string
+	Status     string
+	Error      string
+}
+
+// deviceTemplate is the built-in verification page served when no external
+// verification url is configured
+var deviceTemplate = template.Must(template.New("device").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Connect a device</title>
+</head>
+<body>
+{{if eq .Status "approved"}}<h2>Device connected</h2>
+<p>You can return to your device.</p>
+{{else if eq .Status "denied"}}<h2>Request denied</h2>
+<p>The device was not connected to your account.</p>
+{{else if .UserCode}}<h2>{{.ClientName}} wants to access your account</h2>
+<p>Signed in as {{.User}}</p>
+<p>Make sure the code shown on your device is <strong>{{.UserCode}}</strong></p>
+{{if .Scopes}}<p>The application is requesting the following permissions:</p>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>{{end}}
+<form method="post" action="` + DevicePath + `">
+<input type="hidden" name="` + userCodeParam + `" value="{{.UserCode}}">
+<button type="submit" name="decision" value="deny">Deny</button>
+<button type="submit" name="decision" value="approve">Allow</button>
+</form>
+{{else}}<h2>Connect a device</h2>
+<p>Signed in as {{.User}}</p>
+{{if .Error}}<p>{{.Error}}</p>{{end}}
+<form method="get" action="` + DevicePath + `">
+<label>Enter the code shown on your device <input type="text" name="` + userCodeParam + `" autocomplete="off" autofocus></label>
+<button type="submit">Continue</button>
+</form>
+{{end}}</body>
+</html>
+`))
+
+func renderDevicePage(w http.ResponseWriter, status int, page devicePage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	// the page must not be framed by another site to trick users into approving
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "frame-ancestors 'none'")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = deviceTemplate.Execute(w, page)
+}
@@ -0,0 +1,141 @@
+package oauth2
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_DeviceAuthorization(t *testing.T) {
+	t.Run("should return the codes and the verification uri", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{DeviceCodeValidity: 10 * time.Minute})
+		o.EXPECT().AuthorizeDevice(mock.Anything, "client-id", "", "profile").Return(frontieroauth2.DeviceAuthorization{
+			DeviceCode: "the-device-code",
+			UserCode:   "BCDF-GHJK",
+			Interval:   5 * time.Second,
+		}, nil)
+
+		form := url.Values{"client_id": {"client-id"}, "scope": {"profile"}}
+		r := httptest.NewRequest(http.MethodPost, DeviceAuthorizationPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.DeviceAuthorization(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"device_code": "the-device-code",
+			"user_code": "BCDF-GHJK",
+			"verification_uri": "https://frontier.example.com/oauth2/device",
+			"verification_uri_complete": "https://frontier.example.com/oauth2/device?user_code=BCDF-GHJK",
+			"expires_in": 600,
+			"interval": 5
+		}`, w.Body.String())
+	})
+
+	t.Run("should return protocol errors as json", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().AuthorizeDevice(mock.Anything, "", "", "").
+			Return(frontieroauth2.DeviceAuthorization{}, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidClient, "client_id is required"))
+
+		r := httptest.NewRequest(http.MethodPost, DeviceAuthorizationPath, nil)
+		w := httptest.NewRecorder()
+		h.DeviceAuthorization(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"invalid_client","error_description":"client_id is required"}`, w.Body.String())
+	})
+}
+
+func TestHandler_Device(t *testing.T) {
+	loggedIn := authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, User: &user.User{ID: "user-id", Email: "john@example.com"}}
+
+	t.Run("should send users without a session to the login page", func(t *testing.T) {
+		h, _, a := newTestHandler(t, authenticate.OAuth2Config{LoginURL: "https://frontier.example.com/login"})
+		a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).
+			Return(authenticate.Principal{}, errors.New("unauthenticated"))
+
+		r := httptest.NewRequest(http.MethodGet, "http://frontier.example.com"+DevicePath+"?user_code=BCDF-GHJK", nil)
+		w := httptest.NewRecorder()
+		h.Device(w, r)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://frontier.example.com/login?return_to="+
+			url.QueryEscape("http://frontier.example.com"+DevicePath+"?user_code=BCDF-GHJK"), w.Header().Get("Location"))
+	})
+
+	t.Run("should show the pending request of the user code", func(t *testing.T) {
+		h, o, a := newTestHandler(t, authenticate.OAuth2Config{})
+		a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).Return(loggedIn, nil)
+		o.EXPECT().GetPendingDevice(mock.Anything, "bcdf-ghjk").Return(frontieroauth2.DeviceAuthorization{
+			UserCode: "BCDF-GHJK",
+			Scopes:   []string{"profile"},
+		}, frontieroauth2.Client{ID: "client-id", Name: "cli"}, nil)
+
+		r := httptest.NewRequest(http.MethodGet, DevicePath+"?user_code=bcdf-ghjk", nil)
+		r.Header.Set("Accept", "application/json")
+		w := httptest.NewRecorder()
+		h.Device(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_code":"BCDF-GHJK","client":{"id":"client-id","name":"cli"},"scopes":["profile"]}`, w.Body.String())
+	})
+
+	t.Run("should let the user retry an invalid code", func(t *testing.T) {
+		h, o, a := newTestHandler(t, authenticate.OAuth2Config{})
+		a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).Return(loggedIn, nil)
+		o.EXPECT().GetPendingDevice(mock.Anything, "wrong").
+			Return(frontieroauth2.DeviceAuthorization{}, frontieroauth2.Client{}, frontieroauth2.ErrDeviceNotFound)
+
+		r := httptest.NewRequest(http.MethodGet, DevicePath+"?user_code=wrong", nil)
+		w := httptest.NewRecorder()
+		h.Device(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, "DENY", w.Header().Get("X-Frame-Options"))
+		assert.Contains(t, w.Body.String(), `name="user_code"`)
+	})
+
+	t.Run("should approve the device with the session of the user", func(t *testing.T) {
+		h, o, a := newTestHandler(t, authenticate.OAuth2Config{})
+		a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).Return(loggedIn, nil)
+		o.EXPECT().ApproveDevice(mock.Anything, "BCDF-GHJK", "user-id", testSessionID.String(), true).
+			Return(frontieroauth2.DeviceAuthorization{Status: frontieroauth2.DeviceStatusApproved}, nil)
+
+		r := httptest.NewRequest(http.MethodPost, DevicePath, strings.NewReader(`{"user_code":"BCDF-GHJK","decision":"approve"}`))
+		r.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		h.Device(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body map[string]string
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "approved", body["status"])
+	})
+
+	t.Run("should not record decisions without a session", func(t *testing.T) {
+		h, _, a := newTestHandler(t, authenticate.OAuth2Config{LoginURL: "https://frontier.example.com/login"})
+		a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).
+			Return(authenticate.Principal{}, errors.New("unauthenticated"))
+
+		form := url.Values{"user_code": {"BCDF-GHJK"}, "decision": {"approve"}}
+		r := httptest.NewRequest(http.MethodPost, DevicePath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.Device(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
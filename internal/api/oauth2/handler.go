@@ -0,0 +1,505 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	AuthorizePath  = "/oauth2/authorize"
+	ConsentPath    = "/oauth2/consent"
+	TokenPath      = "/oauth2/token"
+	RevokePath     = "/oauth2/revoke"
+	IntrospectPath = "/oauth2/introspect"
+	UserInfoPath   = "/oauth2/userinfo"
+	DiscoveryPath  = "/.well-known/openid-configuration"
+
+	DeviceAuthorizationPath = "/oauth2/device_authorization"
+	DevicePath              = "/oauth2/device"
+
+	consentChallengeParam = "consent_challenge"
+	userCodeParam         = "user_code"
+)
+
+type OAuth2Service interface {
+	ResolveClient(ctx context.Context, clientID, redirectURI string) (frontieroauth2.Client, string, error)
+	Authorize(ctx context.Context, req frontieroauth2.AuthorizeRequest, userID, sessionID string) (frontieroauth2.Authorization, error)
+	GetPendingAuthorization(ctx context.Context, id, userID string) (frontieroauth2.Authorization, frontieroauth2.Client, error)
+	Consent(ctx context.Context, id, userID string, approved bool) (frontieroauth2.Authorization, error)
+	Exchange(ctx context.Context, req frontieroauth2.TokenRequest) (frontieroauth2.Token, error)
+	Revoke(ctx context.Context, req frontieroauth2.RevokeRequest) error
+	Introspect(ctx context.Context, token, tokenTypeHint string) (frontieroauth2.Introspection, error)
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (frontieroauth2.Client, error)
+	UserInfo(ctx context.Context, accessToken string) (map[string]any, error)
+	AuthorizeDevice(ctx context.Context, clientID, clientSecret, scope string) (frontieroauth2.DeviceAuthorization, error)
+	GetPendingDevice(ctx context.Context, userCode string) (frontieroauth2.DeviceAuthorization, frontieroauth2.Client, error)
+	ApproveDevice(ctx context.Context, userCode, userID, sessionID string, approved bool) (frontieroauth2.DeviceAuthorization, error)
+}
+
+type AuthnService interface {
+	GetPrincipal(ctx context.Context, via ...authenticate.ClientAssertion) (authenticate.Principal, error)
+}
+
+type SessionService interface {
+	ExtractFromContext(ctx context.Context) (*frontiersession.Session, error)
+}
+
+// SessionDecoder builds the request context holding the session of the user
+type SessionDecoder interface {
+	RequestContext(r *http.Request) context.Context
+}
+
+// Handler serves the oauth2 authorization server endpoints. They are plain
+// http handlers as the protocol mandates form encoded requests and redirects
+type Handler struct {
+	log            log.Logger
+	oauth2Service  OAuth2Service
+	authnService   AuthnService
+	sessionService SessionService
+	sessionDecoder SessionDecoder
+	config         authenticate.Config
+}
+
+func NewHandler(logger log.Logger, oauth2Service OAuth2Service, authnService AuthnService,
+	sessionService SessionService, sessionDecoder SessionDecoder, config authenticate.Config) *Handler {
+	return &Handler{
+		log:            logger,
+		oauth2Service:  oauth2Service,
+		authnService:   authnService,
+		sessionService: sessionService,
+		sessionDecoder: sessionDecoder,
+		config:         config,
+	}
+}
+
+// Register mounts the endpoints on the mux, tokenWrapper decorates the
+// endpoints which are called cross origin by browser based clients
+func (h *Handler) Register(mux *http.ServeMux, tokenWrapper func(http.Handler) http.Handler) {
+	mux.HandleFunc(AuthorizePath, h.Authorize)
+	mux.HandleFunc(ConsentPath, h.Consent)
+	mux.Handle(TokenPath, tokenWrapper(http.HandlerFunc(h.Token)))
+	mux.Handle(RevokePath, tokenWrapper(http.HandlerFunc(h.Revoke)))
+	mux.Handle(IntrospectPath, tokenWrapper(http.HandlerFunc(h.Introspect)))
+	mux.Handle(UserInfoPath, tokenWrapper(http.HandlerFunc(h.UserInfo)))
+	mux.Handle(DiscoveryPath, tokenWrapper(http.HandlerFunc(h.Discovery)))
+	mux.Handle(DeviceAuthorizationPath, tokenWrapper(http.HandlerFunc(h.DeviceAuthorization)))
+	mux.HandleFunc(DevicePath, h.Device)
+}
+
+// Authorize handles the authorization request as per RFC 6749 section 4.1.1
+func (h *Handler) Authorize(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	params := r.URL.Query()
+	req := frontieroauth2.AuthorizeRequest{
+		ResponseType:        params.Get("response_type"),
+		ClientID:            params.Get("client_id"),
+		RedirectURI:         params.Get("redirect_uri"),
+		Scope:               params.Get("scope"),
+		State:               params.Get("state"),
+		CodeChallenge:       params.Get("code_challenge"),
+		CodeChallengeMethod: params.Get("code_challenge_method"),
+		Nonce:               params.Get("nonce"),
+	}
+
+	ctx := h.sessionDecoder.RequestContext(r)
+	// the client and redirect uri are verified before anything is sent back to it
+	_, redirectURI, err := h.oauth2Service.ResolveClient(ctx, req.ClientID, req.RedirectURI)
+	if err != nil {
+		switch {
+		case errors.Is(err, frontieroauth2.ErrClientNotFound):
+			h.writeErrorPage(w, http.StatusBadRequest, "client doesn't exist")
+		case errors.Is(err, frontieroauth2.ErrInvalidRedirectURI):
+			h.writeErrorPage(w, http.StatusBadRequest, "redirect_uri is not registered for the client")
+		default:
+			h.log.Error("failed to resolve oauth2 client", "err", err)
+			h.writeErrorPage(w, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+
+	principal, err := h.authnService.GetPrincipal(ctx, authenticate.SessionClientAssertion)
+	if err != nil || principal.Type != schema.UserPrincipal {
+		if h.config.OAuth2.LoginURL == "" || params.Get("prompt") == "none" {
+			redirectWithError(w, r, redirectURI, req.State,
+				frontieroauth2.NewError(frontieroauth2.ErrorCodeLoginRequired, "user is not logged in"))
+			return
+		}
+		loginURL, err := url.Parse(h.config.OAuth2.LoginURL)
+		if err != nil {
+			h.log.Error("invalid oauth2 login url", "err", err)
+			h.writeErrorPage(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		query := loginURL.Query()
+		query.Set("return_to", requestURL(r))
+		loginURL.RawQuery = query.Encode()
+		http.Redirect(w, r, loginURL.String(), http.StatusFound)
+		return
+	}
+
+	// refresh tokens issued for the authorization live as long as the session
+	var sessionID string
+	if sess, err := h.sessionService.ExtractFromContext(ctx); err == nil {
+		sessionID = sess.ID.String()
+	}
+	authorization, err := h.oauth2Service.Authorize(ctx, req, principal.ID, sessionID)
+	if err != nil {
+		var oauthErr *frontieroauth2.Error
+		if errors.As(err, &oauthErr) {
+			redirectWithError(w, r, redirectURI, req.State, oauthErr)
+			return
+		}
+		h.log.Error("failed to authorize oauth2 request", "err", err)
+		redirectWithError(w, r, redirectURI, req.State,
+			frontieroauth2.NewError(frontieroauth2.ErrorCodeServerError, ""))
+		return
+	}
+	if authorization.Code != "" {
+		redirectWithCode(w, r, authorization)
+		return
+	}
+
+	consentURL := ConsentPath
+	if h.config.OAuth2.ConsentURL != "" {
+		consentURL = h.config.OAuth2.ConsentURL
+	}
+	parsed, err := url.Parse(consentURL)
+	if err != nil {
+		h.log.Error("invalid oauth2 consent url", "err", err)
+		h.writeErrorPage(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	query := parsed.Query()
+	query.Set(consentChallengeParam, authorization.ID)
+	parsed.RawQuery = query.Encode()
+	http.Redirect(w, r, parsed.String(), http.StatusFound)
+}
+
+// Consent shows the pending authorization of the user on GET and records
+// the decision on POST. JSON is used when requested by an external consent page
+func (h *Handler) Consent(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := h.sessionDecoder.RequestContext(r)
+	principal, err := h.authnService.GetPrincipal(ctx, authenticate.SessionClientAssertion)
+	if err != nil || principal.Type != schema.UserPrincipal || principal.User == nil {
+		h.writeErrorPage(w, http.StatusUnauthorized, "user is not logged in")
+		return
+	}
+
+	asJSON := strings.Contains(r.Header.Get("Accept"), "application/json") ||
+		strings.HasPrefix(r.Header.Get("Content-Type"), "application/json")
+	var challenge, decision string
+	if r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var body struct {
+			ConsentChallenge string `json:"consent_challenge"`
+			Decision         string `json:"decision"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			h.writeErrorPage(w, http.StatusBadRequest, "malformed consent request")
+			return
+		}
+		challenge, decision = body.ConsentChallenge, body.Decision
+	} else {
+		if err := r.ParseForm(); err != nil {
+			h.writeErrorPage(w, http.StatusBadRequest, "malformed consent request")
+			return
+		}
+		challenge, decision = r.Form.Get(consentChallengeParam), r.Form.Get("decision")
+	}
+
+	if r.Method == http.MethodGet {
+		authorization, client, err := h.oauth2Service.GetPendingAuthorization(ctx, challenge, principal.ID)
+		if err != nil {
+			h.writeConsentError(w, err)
+			return
+		}
+		if asJSON {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"consent_challenge": authorization.ID,
+				"client": map[string]any{
+					"id":   client.ID,
+					"name": client.Name,
+				},
+				"scopes":       authorization.Scopes,
+				"redirect_uri": authorization.RedirectURI,
+			})
+			return
+		}
+		renderConsentPage(w, consentPage{
+			Challenge:  authorization.ID,
+			ClientName: client.Name,
+			Scopes:     authorization.Scopes,
+			User:       principal.User.Email,
+		})
+		return
+	}
+
+	authorization, err := h.oauth2Service.Consent(ctx, challenge, principal.ID, decision == "approve")
+	var oauthErr *frontieroauth2.Error
+	if err != nil && !errors.As(err, &oauthErr) {
+		h.writeConsentError(w, err)
+		return
+	}
+	redirectTo := authorizationResponseURL(authorization, oauthErr)
+	if asJSON {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"redirect_to": redirectTo,
+		})
+		return
+	}
+	http.Redirect(w, r, redirectTo, http.StatusFound)
+}
+
+// Token handles the access token request as per RFC 6749 section 4.1.3,
+// the refresh request as per section 6 and the device access token request
+// as per RFC 8628 section 3.4
+func (h *Handler) Token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidRequest, "malformed token request"))
+		return
+	}
+	req := frontieroauth2.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		DeviceCode:   r.PostForm.Get("device_code"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+	req.ClientID, req.ClientSecret = clientCredentials(r, req.ClientID, req.ClientSecret)
+
+	token, err := h.oauth2Service.Exchange(r.Context(), req)
+	if err != nil {
+		var oauthErr *frontieroauth2.Error
+		if !errors.As(err, &oauthErr) {
+			h.log.Error("failed to exchange oauth2 code", "err", err)
+			oauthErr = frontieroauth2.NewError(frontieroauth2.ErrorCodeServerError, "")
+		}
+		writeTokenError(w, oauthErr)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	body := map[string]any{
+		"access_token": token.AccessToken,
+		"token_type":   token.TokenType,
+		"expires_in":   int64(token.ExpiresIn.Seconds()),
+		"scope":        frontieroauth2.FormatScope(token.Scopes),
+	}
+	if token.RefreshToken != "" {
+		body["refresh_token"] = token.RefreshToken
+	}
+	if token.IDToken != "" {
+		body["id_token"] = token.IDToken
+	}
+	writeJSON(w, http.StatusOK, body)
+}
+
+// Revoke handles the token revocation request as per RFC 7009. The response
+// is always successful for unknown tokens so clients can't probe them
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidRequest, "malformed revocation request"))
+		return
+	}
+	req := frontieroauth2.RevokeRequest{
+		Token:         r.PostForm.Get("token"),
+		TokenTypeHint: r.PostForm.Get("token_type_hint"),
+	}
+	req.ClientID, req.ClientSecret = clientCredentials(r, r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"))
+
+	if err := h.oauth2Service.Revoke(r.Context(), req); err != nil {
+		var oauthErr *frontieroauth2.Error
+		if !errors.As(err, &oauthErr) {
+			h.log.Error("failed to revoke oauth2 token", "err", err)
+			oauthErr = frontieroauth2.NewError(frontieroauth2.ErrorCodeServerError, "")
+		}
+		writeTokenError(w, oauthErr)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+}
+
+// Introspect handles the token introspection request as per RFC 7662. Only
+// confidential clients are allowed to introspect tokens
+func (h *Handler) Introspect(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeTokenError(w, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidRequest, "malformed introspection request"))
+		return
+	}
+	clientID, clientSecret := clientCredentials(r, r.PostForm.Get("client_id"), r.PostForm.Get("client_secret"))
+	client, err := h.oauth2Service.AuthenticateClient(r.Context(), clientID, clientSecret)
+	if err == nil && client.Public {
+		err = frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidClient, "public clients can't introspect tokens")
+	}
+	if err != nil {
+		var oauthErr *frontieroauth2.Error
+		if !errors.As(err, &oauthErr) {
+			h.log.Error("failed to authenticate oauth2 client", "err", err)
+			oauthErr = frontieroauth2.NewError(frontieroauth2.ErrorCodeServerError, "")
+		}
+		writeTokenError(w, oauthErr)
+		return
+	}
+
+	introspection, err := h.oauth2Service.Introspect(r.Context(), r.PostForm.Get("token"), r.PostForm.Get("token_type_hint"))
+	if err != nil {
+		var oauthErr *frontieroauth2.Error
+		if !errors.As(err, &oauthErr) {
+			h.log.Error("failed to introspect oauth2 token", "err", err)
+			oauthErr = frontieroauth2.NewError(frontieroauth2.ErrorCodeServerError, "")
+		}
+		writeTokenError(w, oauthErr)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	if !introspection.Active {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"active": false,
+		})
+		return
+	}
+	body := map[string]any{}
+	for k, v := range introspection.Claims {
+		body[k] = v
+	}
+	body["active"] = true
+	body["token_type"] = introspection.TokenType
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (h *Handler) writeConsentError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, frontieroauth2.ErrAuthorizationNotFound):
+		h.writeErrorPage(w, http.StatusNotFound, "authorization request doesn't exist or has expired")
+	default:
+		h.log.Error("failed to process oauth2 consent", "err", err)
+		h.writeErrorPage(w, http.StatusInternalServerError, "internal error")
+	}
+}
+
+func (h *Handler) writeErrorPage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(message))
+}
+
+func redirectWithCode(w http.ResponseWriter, r *http.Request, authorization frontieroauth2.Authorization) {
+	http.Redirect(w, r, authorizationResponseURL(authorization, nil), http.StatusFound)
+}
+
+func redirectWithError(w http.ResponseWriter, r *http.Request, redirectURI, state string, oauthErr *frontieroauth2.Error) {
+	http.Redirect(w, r, authorizationResponseURL(frontieroauth2.Authorization{
+		RedirectURI: redirectURI,
+		State:       state,
+	}, oauthErr), http.StatusFound)
+}
+
+// authorizationResponseURL adds the code or the error to the redirect uri
+// of the client as per RFC 6749 section 4.1.2
+func authorizationResponseURL(authorization frontieroauth2.Authorization, oauthErr *frontieroauth2.Error) string {
+	// redirect uris are validated while registering the client
+	redirectURL, _ := url.Parse(authorization.RedirectURI)
+	query := redirectURL.Query()
+	if oauthErr != nil {
+		query.Set("error", oauthErr.Code)
+		if oauthErr.Description != "" {
+			query.Set("error_description", oauthErr.Description)
+		}
+	} else {
+		query.Set("code", authorization.Code)
+	}
+	if authorization.State != "" {
+		query.Set("state", authorization.State)
+	}
+	redirectURL.RawQuery = query.Encode()
+	return redirectURL.String()
+}
+
+// clientCredentials prefers the credentials in basic auth over the ones in the form
+func clientCredentials(r *http.Request, clientID, clientSecret string) (string, string) {
+	if basicID, basicSecret, ok := r.BasicAuth(); ok {
+		// credentials in basic auth are form encoded as per RFC 6749 section 2.3.1
+		clientID, _ = url.QueryUnescape(basicID)
+		clientSecret, _ = url.QueryUnescape(basicSecret)
+	}
+	return clientID, clientSecret
+}
+
+func writeTokenError(w http.ResponseWriter, oauthErr *frontieroauth2.Error) {
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case frontieroauth2.ErrorCodeInvalidClient:
+		status = http.StatusUnauthorized
+		w.Header().Set("WWW-Authenticate", `Basic realm="frontier"`)
+	case frontieroauth2.ErrorCodeServerError:
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	body := map[string]string{
+		"error": oauthErr.Code,
+	}
+	if oauthErr.Description != "" {
+		body["error_description"] = oauthErr.Description
+	}
+	writeJSON(w, status, body)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// requestURL rebuilds the absolute url of the request as seen by the user agent
+func requestURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	host := r.Host
+	if forwardedHost := r.Header.Get("X-Forwarded-Host"); forwardedHost != "" {
+		host = forwardedHost
+	}
+	return (&url.URL{
+		Scheme:   scheme,
+		Host:     host,
+		Path:     r.URL.Path,
+		RawQuery: r.URL.RawQuery,
+	}).String()
+}
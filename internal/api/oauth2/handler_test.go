@@ -0,0 +1,302 @@
+package oauth2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/oauth2/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testRedirectURI = "https://app.example.com/callback"
+
+var testSessionID = uuid.MustParse("6b8b4567-327b-4f6e-9a3c-2f1e5d4c3b2a")
+
+func newTestHandler(t *testing.T, cfg authenticate.OAuth2Config) (*Handler, *mocks.OAuth2Service, *mocks.AuthnService) {
+	oauth2Service := mocks.NewOAuth2Service(t)
+	authnService := mocks.NewAuthnService(t)
+	sessionService := mocks.NewSessionService(t)
+	sessionService.EXPECT().ExtractFromContext(mock.Anything).Return(&frontiersession.Session{ID: testSessionID}, nil).Maybe()
+	sessionDecoder := mocks.NewSessionDecoder(t)
+	sessionDecoder.EXPECT().RequestContext(mock.Anything).RunAndReturn(func(r *http.Request) context.Context {
+		return r.Context()
+	}).Maybe()
+	return NewHandler(log.NewNoop(), oauth2Service, authnService, sessionService, sessionDecoder, authenticate.Config{
+		Token:  authenticate.TokenConfig{Issuer: "https://frontier.example.com"},
+		OAuth2: cfg,
+	}), oauth2Service, authnService
+}
+
+func TestHandler_Authorize(t *testing.T) {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {"client-id"},
+		"redirect_uri":          {testRedirectURI},
+		"state":                 {"xyz"},
+		"code_challenge":        {"challenge"},
+		"code_challenge_method": {"S256"},
+	}
+	loggedIn := authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, User: &user.User{ID: "user-id"}}
+
+	tests := []struct {
+		name     string
+		cfg      authenticate.OAuth2Config
+		setup    func(o *mocks.OAuth2Service, a *mocks.AuthnService)
+		status   int
+		location string
+	}{
+		{
+			name: "should not redirect to an unregistered redirect uri",
+			setup: func(o *mocks.OAuth2Service, a *mocks.AuthnService) {
+				o.EXPECT().ResolveClient(mock.Anything, "client-id", testRedirectURI).
+					Return(frontieroauth2.Client{}, "", frontieroauth2.ErrInvalidRedirectURI)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "should send users without a session to the login page",
+			cfg:  authenticate.OAuth2Config{LoginURL: "https://frontier.example.com/login"},
+			setup: func(o *mocks.OAuth2Service, a *mocks.AuthnService) {
+				o.EXPECT().ResolveClient(mock.Anything, "client-id", testRedirectURI).
+					Return(frontieroauth2.Client{}, testRedirectURI, nil)
+				a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).
+					Return(authenticate.Principal{}, errors.New("unauthenticated"))
+			},
+			status:   http.StatusFound,
+			location: "https://frontier.example.com/login?return_to=" + url.QueryEscape("http://frontier.example.com"+AuthorizePath+"?"+query.Encode()),
+		},
+		{
+			name: "should redirect protocol errors to the client",
+			setup: func(o *mocks.OAuth2Service, a *mocks.AuthnService) {
+				o.EXPECT().ResolveClient(mock.Anything, "client-id", testRedirectURI).
+					Return(frontieroauth2.Client{}, testRedirectURI, nil)
+				a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).Return(loggedIn, nil)
+				o.EXPECT().Authorize(mock.Anything, mock.Anything, "user-id", testSessionID.String()).
+					Return(frontieroauth2.Authorization{}, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidScope, ""))
+			},
+			status:   http.StatusFound,
+			location: testRedirectURI + "?error=invalid_scope&state=xyz",
+		},
+		{
+			name: "should ask for consent",
+			setup: func(o *mocks.OAuth2Service, a *mocks.AuthnService) {
+				o.EXPECT().ResolveClient(mock.Anything, "client-id", testRedirectURI).
+					Return(frontieroauth2.Client{}, testRedirectURI, nil)
+				a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).Return(loggedIn, nil)
+				o.EXPECT().Authorize(mock.Anything, mock.Anything, "user-id", testSessionID.String()).
+					Return(frontieroauth2.Authorization{ID: "auth-id"}, nil)
+			},
+			status:   http.StatusFound,
+			location: ConsentPath + "?consent_challenge=auth-id",
+		},
+		{
+			name: "should redirect with the code when already consented",
+			setup: func(o *mocks.OAuth2Service, a *mocks.AuthnService) {
+				o.EXPECT().ResolveClient(mock.Anything, "client-id", testRedirectURI).
+					Return(frontieroauth2.Client{}, testRedirectURI, nil)
+				a.EXPECT().GetPrincipal(mock.Anything, authenticate.SessionClientAssertion).Return(loggedIn, nil)
+				o.EXPECT().Authorize(mock.Anything, mock.Anything, "user-id", testSessionID.String()).
+					Return(frontieroauth2.Authorization{ID: "auth-id", Code: "the-code", RedirectURI: testRedirectURI, State: "xyz"}, nil)
+			},
+			status:   http.StatusFound,
+			location: testRedirectURI + "?code=the-code&state=xyz",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, o, a := newTestHandler(t, tt.cfg)
+			tt.setup(o, a)
+
+			r := httptest.NewRequest(http.MethodGet, "http://frontier.example.com"+AuthorizePath+"?"+query.Encode(), nil)
+			w := httptest.NewRecorder()
+			h.Authorize(w, r)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.location != "" {
+				assert.Equal(t, tt.location, w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestHandler_Token(t *testing.T) {
+	t.Run("should return the token with client credentials from basic auth", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().Exchange(mock.Anything, frontieroauth2.TokenRequest{
+			GrantType:    frontieroauth2.GrantTypeAuthorizationCode,
+			Code:         "the-code",
+			ClientID:     "client-id",
+			ClientSecret: "secret",
+			CodeVerifier: "verifier",
+		}).Return(frontieroauth2.Token{
+			AccessToken:  "jwt",
+			TokenType:    frontieroauth2.TokenTypeBearer,
+			ExpiresIn:    time.Hour,
+			Scopes:       []string{"profile", "email"},
+			RefreshToken: "refresh",
+		}, nil)
+
+		form := url.Values{"grant_type": {"authorization_code"}, "code": {"the-code"}, "code_verifier": {"verifier"}}
+		r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.SetBasicAuth("client-id", "secret")
+		w := httptest.NewRecorder()
+		h.Token(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+		var body map[string]any
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, map[string]any{
+			"access_token":  "jwt",
+			"token_type":    "Bearer",
+			"expires_in":    float64(3600),
+			"scope":         "profile email",
+			"refresh_token": "refresh",
+		}, body)
+	})
+
+	t.Run("should return protocol errors as json", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().Exchange(mock.Anything, mock.Anything).
+			Return(frontieroauth2.Token{}, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidClient, "client authentication failed"))
+
+		r := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader("grant_type=authorization_code"))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.Token(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"invalid_client","error_description":"client authentication failed"}`, w.Body.String())
+	})
+}
+
+func TestHandler_Revoke(t *testing.T) {
+	t.Run("should succeed for tokens the service ignores", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().Revoke(mock.Anything, frontieroauth2.RevokeRequest{
+			Token:         "unknown",
+			TokenTypeHint: "refresh_token",
+			ClientID:      "client-id",
+		}).Return(nil)
+
+		form := url.Values{"token": {"unknown"}, "token_type_hint": {"refresh_token"}, "client_id": {"client-id"}}
+		r := httptest.NewRequest(http.MethodPost, RevokePath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.Revoke(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+	})
+}
+
+func TestHandler_Introspect(t *testing.T) {
+	introspect := func(h *Handler) *httptest.ResponseRecorder {
+		form := url.Values{"token": {"jwt"}}
+		r := httptest.NewRequest(http.MethodPost, IntrospectPath, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.SetBasicAuth("client-id", "secret")
+		w := httptest.NewRecorder()
+		h.Introspect(w, r)
+		return w
+	}
+
+	t.Run("should not let public clients introspect", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().AuthenticateClient(mock.Anything, "client-id", "secret").
+			Return(frontieroauth2.Client{ID: "client-id", Public: true}, nil)
+
+		w := introspect(h)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("should return the claims of an active token", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().AuthenticateClient(mock.Anything, "client-id", "secret").
+			Return(frontieroauth2.Client{ID: "client-id"}, nil)
+		o.EXPECT().Introspect(mock.Anything, "jwt", "").Return(frontieroauth2.Introspection{
+			Active:    true,
+			TokenType: frontieroauth2.TokenTypeBearer,
+			Claims:    map[string]any{"sub": "user-id", "exp": int64(1700000000)},
+		}, nil)
+
+		w := introspect(h)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"active":true,"token_type":"Bearer","sub":"user-id","exp":1700000000}`, w.Body.String())
+	})
+
+	t.Run("should only report inactive tokens as such", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().AuthenticateClient(mock.Anything, "client-id", "secret").
+			Return(frontieroauth2.Client{ID: "client-id"}, nil)
+		o.EXPECT().Introspect(mock.Anything, "jwt", "").Return(frontieroauth2.Introspection{}, nil)
+
+		w := introspect(h)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"active":false}`, w.Body.String())
+	})
+}
+
+func TestHandler_Discovery(t *testing.T) {
+	h, _, _ := newTestHandler(t, authenticate.OAuth2Config{})
+	r := httptest.NewRequest(http.MethodGet, DiscoveryPath, nil)
+	w := httptest.NewRecorder()
+	h.Discovery(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "https://frontier.example.com", body["issuer"])
+	assert.Equal(t, "https://frontier.example.com/oauth2/authorize", body["authorization_endpoint"])
+	assert.Equal(t, "https://frontier.example.com/oauth2/userinfo", body["userinfo_endpoint"])
+	assert.Equal(t, "https://frontier.example.com/.well-known/jwks.json", body["jwks_uri"])
+	assert.Equal(t, "https://frontier.example.com/oauth2/device_authorization", body["device_authorization_endpoint"])
+}
+
+func TestHandler_UserInfo(t *testing.T) {
+	t.Run("should return the claims of the user", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().UserInfo(mock.Anything, "jwt").Return(map[string]any{
+			"sub":   "user-id",
+			"email": "john@example.com",
+		}, nil)
+
+		r := httptest.NewRequest(http.MethodGet, UserInfoPath, nil)
+		r.Header.Set("Authorization", "Bearer jwt")
+		w := httptest.NewRecorder()
+		h.UserInfo(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"sub":"user-id","email":"john@example.com"}`, w.Body.String())
+	})
+
+	t.Run("should challenge requests with an invalid token", func(t *testing.T) {
+		h, o, _ := newTestHandler(t, authenticate.OAuth2Config{})
+		o.EXPECT().UserInfo(mock.Anything, "jwt").
+			Return(nil, frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidToken, "access token is invalid"))
+
+		r := httptest.NewRequest(http.MethodGet, UserInfoPath, nil)
+		r.Header.Set("Authorization", "Bearer jwt")
+		w := httptest.NewRecorder()
+		h.UserInfo(w, r)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Header().Get("WWW-Authenticate"), `error="invalid_token"`)
+	})
+}
@@ -0,0 +1,81 @@
+package oauth2
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+)
+
+// jwksPath is served by the grpc gateway
+const jwksPath = "/.well-known/jwks.json"
+
+// Discovery serves the provider metadata of OpenID Connect Discovery section 3.
+// Endpoints are relative to the issuer, which must be the public url of frontier
+func (h *Handler) Discovery(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	issuer := strings.TrimSuffix(h.config.Token.Issuer, "/")
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                issuer,
+		"authorization_endpoint":                issuer + AuthorizePath,
+		"token_endpoint":                        issuer + TokenPath,
+		"userinfo_endpoint":                     issuer + UserInfoPath,
+		"revocation_endpoint":                   issuer + RevokePath,
+		"introspection_endpoint":                issuer + IntrospectPath,
+		"device_authorization_endpoint":         issuer + DeviceAuthorizationPath,
+		"jwks_uri":                              issuer + jwksPath,
+		"response_types_supported":              []string{frontieroauth2.ResponseTypeCode},
+		"grant_types_supported":                 []string{frontieroauth2.GrantTypeAuthorizationCode, frontieroauth2.GrantTypeRefreshToken, frontieroauth2.GrantTypeDeviceCode},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":      []string{frontieroauth2.CodeChallengeMethodS256},
+		"scopes_supported":                      frontieroauth2.SupportedScopes,
+		"claims_supported":                      frontieroauth2.SupportedClaims,
+	})
+}
+
+// UserInfo returns the claims of the user as per OpenID Connect Core section 5.3,
+// the access token is accepted as per RFC 6750
+func (h *Handler) UserInfo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	accessToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found && r.Method == http.MethodPost {
+		if err := r.ParseForm(); err == nil {
+			accessToken = r.PostForm.Get("access_token")
+		}
+	}
+	if strings.TrimSpace(accessToken) == "" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="frontier"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.oauth2Service.UserInfo(r.Context(), strings.TrimSpace(accessToken))
+	if err != nil {
+		var oauthErr *frontieroauth2.Error
+		if !errors.As(err, &oauthErr) {
+			h.log.Error("failed to get oauth2 user info", "err", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		status := http.StatusUnauthorized
+		if oauthErr.Code == frontieroauth2.ErrorCodeInsufficientScope {
+			status = http.StatusForbidden
+		}
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="frontier", error=%q, error_description=%q`,
+			oauthErr.Code, oauthErr.Description))
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, claims)
+}
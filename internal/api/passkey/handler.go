@@ -0,0 +1,247 @@
+package passkey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/raystack/frontier/core/authenticate"
+	frontierpasskey "github.com/raystack/frontier/core/passkey"
+	"github.com/raystack/frontier/internal/api/httputil"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	ListPath           = "GET /passkeys"
+	RegisterPath       = "POST /passkeys/register"
+	RegisterFinishPath = "POST /passkeys/register/finish"
+	RenamePath         = "PATCH /passkeys/{id}"
+	DeletePath         = "DELETE /passkeys/{id}"
+
+	maxBodySize = 16 << 10
+)
+
+type AuthnService interface {
+	GetPrincipal(ctx context.Context, via ...authenticate.ClientAssertion) (authenticate.Principal, error)
+	StartPasskeyRegistration(ctx context.Context, principal authenticate.Principal) (authenticate.PasskeyRegistration, error)
+	FinishPasskeyRegistration(ctx context.Context, principal authenticate.Principal, state string,
+		credentialResponse []byte, nickname string) (frontierpasskey.Passkey, error)
+}
+
+type PasskeyService interface {
+	List(ctx context.Context, userID string) ([]frontierpasskey.Passkey, error)
+	Rename(ctx context.Context, userID, id, nickname string) (frontierpasskey.Passkey, error)
+	Delete(ctx context.Context, userID, id string) error
+}
+
+// Handler serves the endpoints users manage their passkeys with, logging in
+// with a passkey goes through the authenticate rpcs like the other
+// strategies. They are plain http handlers until the api has messages for
+// passkeys.
+type Handler struct {
+	log            log.Logger
+	authnService   AuthnService
+	authenticator  *httputil.Authenticator
+	passkeyService PasskeyService
+}
+
+func NewHandler(logger log.Logger, authnService AuthnService, passkeyService PasskeyService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:            logger,
+		authnService:   authnService,
+		authenticator:  httputil.NewAuthenticator(authnService, sessionDecoder),
+		passkeyService: passkeyService,
+	}
+}
+
+// Register mounts the endpoints users manage their passkeys with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(ListPath, h.List)
+	router.Handle(RegisterPath, h.StartRegistration)
+	router.Handle(RegisterFinishPath, h.FinishRegistration)
+	router.Handle(RenamePath, h.Rename)
+	router.Handle(DeletePath, h.Delete)
+}
+
+type passkeyResponse struct {
+	ID             string     `json:"id"`
+	Nickname       string     `json:"nickname"`
+	AAGUID         string     `json:"aaguid"`
+	Transports     []string   `json:"transports"`
+	BackupEligible bool       `json:"backup_eligible"`
+	BackupState    bool       `json:"backup_state"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func toResponse(p frontierpasskey.Passkey) passkeyResponse {
+	response := passkeyResponse{
+		ID:             p.ID,
+		Nickname:       p.Nickname,
+		AAGUID:         p.AAGUID.String(),
+		Transports:     p.Transports,
+		BackupEligible: p.BackupEligible,
+		BackupState:    p.BackupState,
+		CreatedAt:      p.CreatedAt,
+	}
+	if response.Transports == nil {
+		response.Transports = []string{}
+	}
+	if !p.LastUsedAt.IsZero() {
+		response.LastUsedAt = &p.LastUsedAt
+	}
+	return response
+}
+
+type listResponse struct {
+	Passkeys []passkeyResponse `json:"passkeys"`
+}
+
+// List returns the passkeys of the logged in user
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.principal(w, r)
+	if !ok {
+		return
+	}
+	passkeys, err := h.passkeyService.List(ctx, principal.ID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := listResponse{Passkeys: make([]passkeyResponse, 0, len(passkeys))}
+	for _, p := range passkeys {
+		response.Passkeys = append(response.Passkeys, toResponse(p))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+type startRegistrationResponse struct {
+	State   string                       `json:"state"`
+	Options *protocol.CredentialCreation `json:"options"`
+}
+
+// StartRegistration returns the options to create a new passkey with in the
+// browser, they are passed as is to navigator.credentials.create
+func (h *Handler) StartRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.principal(w, r)
+	if !ok {
+		return
+	}
+	registration, err := h.authnService.StartPasskeyRegistration(ctx, principal)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, startRegistrationResponse{
+		State:   registration.State,
+		Options: registration.Options,
+	})
+}
+
+type finishRegistrationRequest struct {
+	State    string `json:"state"`
+	Nickname string `json:"nickname"`
+	// Credential is the public key credential returned by the browser
+	Credential json.RawMessage `json:"credential"`
+}
+
+// FinishRegistration adds the passkey created in the browser to the account
+func (h *Handler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.principal(w, r)
+	if !ok {
+		return
+	}
+	var req finishRegistrationRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil ||
+		req.State == "" || len(req.Credential) == 0 {
+		httputil.WriteMessage(w, http.StatusBadRequest, "state and credential are required")
+		return
+	}
+	created, err := h.authnService.FinishPasskeyRegistration(ctx, principal, req.State, req.Credential, req.Nickname)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, toResponse(created))
+}
+
+type renameRequest struct {
+	Nickname string `json:"nickname"`
+}
+
+// Rename sets the nickname of a passkey of the logged in user
+func (h *Handler) Rename(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.principal(w, r)
+	if !ok {
+		return
+	}
+	var req renameRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "nickname is required")
+		return
+	}
+	updated, err := h.passkeyService.Rename(ctx, principal.ID, r.PathValue("id"), req.Nickname)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toResponse(updated))
+}
+
+// Delete removes a passkey of the logged in user
+func (h *Handler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.principal(w, r)
+	if !ok {
+		return
+	}
+	if err := h.passkeyService.Delete(ctx, principal.ID, r.PathValue("id")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// principal authenticates the caller, passkeys belong to users so service
+// users are rejected
+func (h *Handler) principal(w http.ResponseWriter, r *http.Request) (context.Context, authenticate.Principal, bool) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if ok && principal.User == nil {
+		httputil.WriteMessage(w, http.StatusForbidden, frontiererrors.ErrForbidden.Error())
+		return nil, authenticate.Principal{}, false
+	}
+	return ctx, principal, ok
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, authenticate.ErrReauthRequired):
+		status = http.StatusUnauthorized
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, frontierpasskey.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontierpasskey.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, frontierpasskey.ErrInvalidNickname), errors.Is(err, authenticate.ErrFlowInvalid):
+		status = http.StatusBadRequest
+	case errors.Is(err, authenticate.ErrUnsupportedMethod):
+		status = http.StatusNotImplemented
+	default:
+		var protocolErr *protocol.Error
+		if errors.As(err, &protocolErr) {
+			httputil.WriteMessage(w, http.StatusBadRequest, protocolErr.Details)
+			return
+		}
+		h.log.Error("passkey request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,171 @@
+package passkey
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontierpasskey "github.com/raystack/frontier/core/passkey"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/passkey/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var principal = authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, User: &user.User{ID: "user-id"}}
+
+type handlerMocks struct {
+	authn    *mocks.AuthnService
+	passkeys *mocks.PasskeyService
+	decoder  *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:    mocks.NewAuthnService(t),
+		passkeys: mocks.NewPasskeyService(t),
+		decoder:  httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.passkeys, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Run("should list the passkeys of the caller", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.passkeys.EXPECT().List(mock.Anything, principal.ID).Return([]frontierpasskey.Passkey{{
+			ID:         "passkey-id",
+			UserID:     principal.ID,
+			Nickname:   "laptop",
+			Transports: []string{"internal"},
+			LastUsedAt: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+			CreatedAt:  time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/passkeys", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"passkeys": [{
+			"id": "passkey-id",
+			"nickname": "laptop",
+			"aaguid": "00000000-0000-0000-0000-000000000000",
+			"transports": ["internal"],
+			"backup_eligible": false,
+			"backup_state": false,
+			"last_used_at": "2026-10-18T12:00:00Z",
+			"created_at": "2026-10-01T12:00:00Z"
+		}]}`, rec.Body.String())
+	})
+
+	t.Run("should reject service users", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, authenticate.Principal{ID: "service-user-id", Type: schema.ServiceUserPrincipal}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/passkeys", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject unauthenticated requests", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, authenticate.Principal{}, errors.ErrUnauthenticated)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/passkeys", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_FinishRegistration(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(m handlerMocks)
+		wantStatus int
+	}{
+		{
+			name: "should add the passkey",
+			body: `{"state": "flow-id", "nickname": "laptop", "credential": {"id": "credential"}}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().FinishPasskeyRegistration(mock.Anything, principal, "flow-id",
+					[]byte(`{"id": "credential"}`), "laptop").
+					Return(frontierpasskey.Passkey{ID: "passkey-id", UserID: principal.ID, Nickname: "laptop"}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "should require the credential",
+			body:       `{"state": "flow-id"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should ask for a recent login",
+			body: `{"state": "flow-id", "credential": {"id": "credential"}}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().FinishPasskeyRegistration(mock.Anything, principal, "flow-id",
+					[]byte(`{"id": "credential"}`), "").Return(frontierpasskey.Passkey{}, authenticate.ErrReauthRequired)
+			},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name: "should return conflict for registered passkeys",
+			body: `{"state": "flow-id", "credential": {"id": "credential"}}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().FinishPasskeyRegistration(mock.Anything, principal, "flow-id",
+					[]byte(`{"id": "credential"}`), "").Return(frontierpasskey.Passkey{}, frontierpasskey.ErrConflict)
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			expectPrincipal(m, principal, nil)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/passkeys/register/finish", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	t.Run("should delete the passkey", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.passkeys.EXPECT().Delete(mock.Anything, principal.ID, "passkey-id").Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/passkeys/passkey-id", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return not found for passkeys of other users", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.passkeys.EXPECT().Delete(mock.Anything, principal.ID, "passkey-id").Return(frontierpasskey.ErrNotExist)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/passkeys/passkey-id", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
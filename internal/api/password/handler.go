@@ -0,0 +1,189 @@
+package password
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/ratelimit"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/utils"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	ResetPath        = "POST /password/reset"
+	ResetConfirmPath = "POST /password/reset/confirm"
+	ChangePath       = "POST /password/change"
+	IssueResetPath   = "POST /users/{id}/password/reset"
+
+	maxBodySize = 4 << 10
+)
+
+type AuthnService interface {
+	GetPrincipal(ctx context.Context, via ...authenticate.ClientAssertion) (authenticate.Principal, error)
+	ChangePassword(ctx context.Context, principal authenticate.Principal, currentPassword, newPassword string) error
+	StartPasswordReset(ctx context.Context, email, clientIP string) error
+	IssuePasswordReset(ctx context.Context, userID string) (authenticate.PasswordReset, error)
+	FinishPasswordReset(ctx context.Context, resetToken, newPassword string) error
+}
+
+// Handler serves the endpoints users change and reset their password with,
+// logging in with a password goes through the authenticate rpcs like the
+// other strategies
+type Handler struct {
+	log                log.Logger
+	authnService       AuthnService
+	authenticator      *httputil.Authenticator
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+	// proxies are trusted to forward the address of the client
+	proxies utils.Proxies
+}
+
+func NewHandler(logger log.Logger, authnService AuthnService, userService httputil.SudoService,
+	serviceUserService httputil.SudoService, sessionDecoder httputil.SessionDecoder, proxies utils.Proxies) *Handler {
+	return &Handler{
+		log:                logger,
+		authnService:       authnService,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		userService:        userService,
+		serviceUserService: serviceUserService,
+		proxies:            proxies,
+	}
+}
+
+// Register mounts the endpoints passwords are changed and reset with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(ResetPath, h.Reset)
+	router.Handle(ResetConfirmPath, h.ResetConfirm)
+	router.Handle(ChangePath, h.Change)
+	router.Handle(IssueResetPath, h.IssueReset)
+}
+
+type resetRequest struct {
+	Email string `json:"email"`
+}
+
+// Reset mails a reset token to the user, it's accepted for unknown emails
+// too to not reveal who has an account
+func (h *Handler) Reset(w http.ResponseWriter, r *http.Request) {
+	var req resetRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.Email == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "email is required")
+		return
+	}
+	if err := h.authnService.StartPasswordReset(r.Context(), req.Email,
+		h.proxies.ClientIP(strings.Join(r.Header.Values("X-Forwarded-For"), ","), r.RemoteAddr)); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type resetConfirmRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetConfirm sets the new password of the user the token was issued for
+func (h *Handler) ResetConfirm(w http.ResponseWriter, r *http.Request) {
+	var req resetConfirmRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil ||
+		req.Token == "" || req.Password == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "token and password are required")
+		return
+	}
+	if err := h.authnService.FinishPasswordReset(r.Context(), req.Token, req.Password); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type changeRequest struct {
+	CurrentPassword string `json:"current_password"`
+	Password        string `json:"password"`
+}
+
+// Change sets a new password for the logged in user
+func (h *Handler) Change(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req changeRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.Password == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "password is required")
+		return
+	}
+	if err := h.authnService.ChangePassword(ctx, principal, req.CurrentPassword, req.Password); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type issueResetResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// IssueReset returns a reset token for superusers to hand over to the user
+// when mails can't be sent
+func (h *Handler) IssueReset(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	reset, err := h.authnService.IssuePasswordReset(ctx, r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, issueResetResponse{
+		Token:     reset.Token,
+		ExpiresAt: reset.ExpiresAt,
+	})
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var limitErr ratelimit.LimitError
+	switch {
+	case errors.As(err, &limitErr):
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limitErr.RetryAfter.Seconds()))))
+		status = http.StatusTooManyRequests
+	case errors.Is(err, authenticate.ErrReauthRequired):
+		status = http.StatusUnauthorized
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, authenticate.ErrInvalidPassword), errors.Is(err, authenticate.ErrFlowInvalid),
+		errors.Is(err, strategy.ErrWeakPassword), errors.Is(err, strategy.ErrBreachedPassword):
+		status = http.StatusBadRequest
+	case errors.Is(err, user.ErrNotExist), errors.Is(err, user.ErrInvalidUUID), errors.Is(err, user.ErrInvalidID),
+		errors.Is(err, user.ErrDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, authenticate.ErrUnsupportedMethod):
+		status = http.StatusNotImplemented
+	default:
+		h.log.Error("password request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
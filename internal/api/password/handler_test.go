@@ -0,0 +1,196 @@
+package password
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/ratelimit"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/password/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var principal = authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal}
+
+type handlerMocks struct {
+	authn        *mocks.AuthnService
+	users        *httpmocks.SudoService
+	serviceUsers *httpmocks.SudoService
+	decoder      *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:        mocks.NewAuthnService(t),
+		users:        httpmocks.NewSudoService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.users, m.serviceUsers, m.decoder, nil).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func TestHandler_Reset(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(m handlerMocks)
+		wantStatus int
+	}{
+		{
+			name: "should accept the reset",
+			body: `{"email": "john.doe@example.com"}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().StartPasswordReset(mock.Anything, "john.doe@example.com", "192.0.2.1").Return(nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "should require the email",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return too many requests once limited",
+			body: `{"email": "john.doe@example.com"}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().StartPasswordReset(mock.Anything, "john.doe@example.com", "192.0.2.1").
+					Return(ratelimit.LimitError{Key: "start:email:john.doe@example.com", RetryAfter: time.Minute})
+			},
+			wantStatus: http.StatusTooManyRequests,
+		},
+		{
+			name: "should return not implemented without a mailer",
+			body: `{"email": "john.doe@example.com"}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().StartPasswordReset(mock.Anything, "john.doe@example.com", "192.0.2.1").
+					Return(authenticate.ErrUnsupportedMethod)
+			},
+			wantStatus: http.StatusNotImplemented,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/reset", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestHandler_ResetConfirm(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(m handlerMocks)
+		wantStatus int
+	}{
+		{
+			name: "should set the password",
+			body: `{"token": "flow.secret", "password": "correct horse battery staple"}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().FinishPasswordReset(mock.Anything, "flow.secret", "correct horse battery staple").Return(nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "should reject breached passwords",
+			body: `{"token": "flow.secret", "password": "password"}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().FinishPasswordReset(mock.Anything, "flow.secret", "password").Return(strategy.ErrBreachedPassword)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should reject invalid tokens",
+			body: `{"token": "flow.guess", "password": "correct horse battery staple"}`,
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().FinishPasswordReset(mock.Anything, "flow.guess", "correct horse battery staple").
+					Return(authenticate.ErrFlowInvalid)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			tt.setup(m)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/reset/confirm", strings.NewReader(tt.body)))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+		})
+	}
+}
+
+func TestHandler_Change(t *testing.T) {
+	t.Run("should change the password of the caller", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.authn.EXPECT().ChangePassword(mock.Anything, principal, "current", "correct horse battery staple").Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/change",
+			strings.NewReader(`{"current_password": "current", "password": "correct horse battery staple"}`)))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should reject unauthenticated requests", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, authenticate.Principal{}, errors.ErrUnauthenticated)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/password/change",
+			strings.NewReader(`{"password": "correct horse battery staple"}`)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_IssueReset(t *testing.T) {
+	t.Run("should return the token to superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.users.EXPECT().IsSudo(mock.Anything, principal.ID, schema.PlatformSudoPermission).Return(true, nil)
+		m.authn.EXPECT().IssuePasswordReset(mock.Anything, "target-id").Return(authenticate.PasswordReset{
+			Token:     "flow.secret",
+			ExpiresAt: time.Date(2026, 10, 18, 13, 0, 0, 0, time.UTC),
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/target-id/password/reset", nil))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{"token": "flow.secret", "expires_at": "2026-10-18T13:00:00Z"}`, rec.Body.String())
+	})
+
+	t.Run("should reject principals without sudo permission", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		m.users.EXPECT().IsSudo(mock.Anything, principal.ID, schema.PlatformSudoPermission).Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/users/target-id/password/reset", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
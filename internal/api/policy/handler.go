@@ -0,0 +1,279 @@
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/group"
+	frontierpolicy "github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	CreatePath = "POST /v1beta1/policies/timebound"
+	ListPath   = "GET /v1beta1/policies/timebound"
+
+	// ExpiryExpired lists the policies past their expiry the reaper didn't
+	// delete yet
+	ExpiryExpired = "expired"
+	// ExpiryExpiring lists the policies expiring within the given duration
+	ExpiryExpiring = "expiring"
+
+	defaultExpiringWithin = 24 * time.Hour
+	maxBodySize           = 16 << 10
+)
+
+type PolicyService interface {
+	Create(ctx context.Context, policy frontierpolicy.Policy) (frontierpolicy.Policy, error)
+	List(ctx context.Context, flt frontierpolicy.Filter) ([]frontierpolicy.Policy, error)
+	OrgID(ctx context.Context, pol frontierpolicy.Policy) (string, error)
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+// Handler serves the endpoints time bound policies are created and listed
+// with, they are authorized like the CreatePolicy and ListPolicies rpcs. They
+// are plain http handlers until the policy messages have a validity window.
+type Handler struct {
+	log             log.Logger
+	authenticator   *httputil.Authenticator
+	policyService   PolicyService
+	resourceService ResourceService
+	Now             func() time.Time
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, policyService PolicyService,
+	resourceService ResourceService, sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:             logger,
+		authenticator:   httputil.NewAuthenticator(authnService, sessionDecoder),
+		policyService:   policyService,
+		resourceService: resourceService,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Register mounts the endpoints time bound policies are managed with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(CreatePath, h.Create, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(ListPath, h.List, httputil.WithScope(authenticate.ScopeRead))
+}
+
+type policyResponse struct {
+	ID        string            `json:"id"`
+	RoleID    string            `json:"role_id"`
+	Resource  string            `json:"resource"`
+	Principal string            `json:"principal"`
+	Metadata  metadata.Metadata `json:"metadata,omitempty"`
+	StartsAt  *time.Time        `json:"starts_at,omitempty"`
+	ExpiresAt *time.Time        `json:"expires_at,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+func toResponse(p frontierpolicy.Policy) policyResponse {
+	response := policyResponse{
+		ID:        p.ID,
+		RoleID:    p.RoleID,
+		Resource:  schema.JoinNamespaceAndResourceID(p.ResourceType, p.ResourceID),
+		Principal: schema.JoinNamespaceAndResourceID(p.PrincipalType, p.PrincipalID),
+		Metadata:  p.Metadata,
+		CreatedAt: p.CreatedAt,
+	}
+	if !p.StartsAt.IsZero() {
+		response.StartsAt = &p.StartsAt
+	}
+	if !p.ExpiresAt.IsZero() {
+		response.ExpiresAt = &p.ExpiresAt
+	}
+	return response
+}
+
+type createRequest struct {
+	RoleID string `json:"role_id"`
+	// Resource and Principal are namespaced ids, e.g. app/project:<id>
+	Resource  string            `json:"resource"`
+	Principal string            `json:"principal"`
+	Metadata  metadata.Metadata `json:"metadata"`
+	StartsAt  time.Time         `json:"starts_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// Create binds a role to a principal on a resource, optionally within a
+// time window
+func (h *Handler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req createRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.RoleID == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "role_id, resource and principal are required")
+		return
+	}
+	resourceType, resourceID, err := schema.SplitNamespaceAndResourceID(req.Resource)
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "resource must be a namespaced id")
+		return
+	}
+	principalType, principalID, err := schema.SplitNamespaceAndResourceID(req.Principal)
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "principal must be a namespaced id")
+		return
+	}
+
+	permission := schema.DeletePermission
+	switch resourceType {
+	case schema.OrganizationNamespace, schema.ProjectNamespace:
+		permission = schema.PolicyManagePermission
+	case schema.GroupNamespace:
+		permission = group.AdminPermission
+	}
+	if err := httputil.Authorize(ctx, h.resourceService, principal, relation.Object{Namespace: resourceType, ID: resourceID}, permission); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	created, err := h.policyService.Create(ctx, frontierpolicy.Policy{
+		RoleID:        req.RoleID,
+		ResourceID:    resourceID,
+		ResourceType:  resourceType,
+		PrincipalID:   principalID,
+		PrincipalType: principalType,
+		Metadata:      req.Metadata,
+		StartsAt:      req.StartsAt,
+		ExpiresAt:     req.ExpiresAt,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	attrs := map[string]string{
+		"role_id":        created.RoleID,
+		"principal_id":   created.PrincipalID,
+		"principal_type": created.PrincipalType,
+	}
+	if !created.ExpiresAt.IsZero() {
+		attrs["expires_at"] = created.ExpiresAt.Format(time.RFC3339)
+	}
+	// the grant is logged on the org owning the resource for its admins to see
+	orgID, err := h.policyService.OrgID(ctx, created)
+	if err != nil {
+		h.log.Warn("resolving the organization of a policy failed", "policy_id", created.ID, "err", err)
+		orgID = schema.PlatformOrgID.String()
+	}
+	_ = audit.GetAuditor(ctx, orgID).
+		LogWithAttrs(audit.PolicyCreatedEvent, audit.Target{
+			ID:   created.ResourceID,
+			Type: created.ResourceType,
+		}, attrs)
+	httputil.WriteJSON(w, http.StatusCreated, toResponse(created))
+}
+
+type listResponse struct {
+	Policies []policyResponse `json:"policies"`
+}
+
+// List returns the policies of an organization, project or group. The expiry
+// query parameter narrows them to the expired ones or to the ones expiring
+// within the duration given by within, 24h by default.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	flt := frontierpolicy.Filter{
+		OrgID:     query.Get("org_id"),
+		ProjectID: query.Get("project_id"),
+		GroupID:   query.Get("group_id"),
+	}
+	var object relation.Object
+	permission := schema.PolicyManagePermission
+	switch {
+	case flt.OrgID != "":
+		object = relation.Object{Namespace: schema.OrganizationNamespace, ID: flt.OrgID}
+	case flt.ProjectID != "":
+		object = relation.Object{Namespace: schema.ProjectNamespace, ID: flt.ProjectID}
+	case flt.GroupID != "":
+		object = relation.Object{Namespace: schema.GroupNamespace, ID: flt.GroupID}
+		permission = group.AdminPermission
+	default:
+		httputil.WriteMessage(w, http.StatusBadRequest, "one of org_id, project_id or group_id is required")
+		return
+	}
+
+	now := h.Now()
+	switch query.Get("expiry") {
+	case "":
+	case ExpiryExpired:
+		flt.ExpiresBefore = now
+	case ExpiryExpiring:
+		within := defaultExpiringWithin
+		if raw := query.Get("within"); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil || parsed <= 0 {
+				httputil.WriteMessage(w, http.StatusBadRequest, "within must be a positive duration")
+				return
+			}
+			within = parsed
+		}
+		flt.ExpiresAfter = now
+		flt.ExpiresBefore = now.Add(within)
+	default:
+		httputil.WriteMessage(w, http.StatusBadRequest, "expiry must be expired or expiring")
+		return
+	}
+
+	if err := httputil.Authorize(ctx, h.resourceService, principal, object, permission); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	policies, err := h.policyService.List(ctx, flt)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := listResponse{Policies: make([]policyResponse, 0, len(policies))}
+	for _, p := range policies {
+		response.Policies = append(response.Policies, toResponse(p))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontierpolicy.ErrInvalidDetail), errors.Is(err, role.ErrInvalidID),
+		errors.Is(err, httputil.ErrResourceRequired):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, role.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontierpolicy.ErrConflict):
+		status = http.StatusConflict
+	default:
+		h.log.Error("policy request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,206 @@
+package policy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/authenticate"
+	frontierpolicy "github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/policy/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	principal = authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, User: &user.User{ID: "user-id"}}
+	now       = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+)
+
+type handlerMocks struct {
+	authn     *httpmocks.AuthnService
+	policies  *mocks.PolicyService
+	resources *mocks.ResourceService
+	decoder   *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:     httpmocks.NewAuthnService(t),
+		policies:  mocks.NewPolicyService(t),
+		resources: mocks.NewResourceService(t),
+		decoder:   httpmocks.NewSessionDecoder(t),
+	}
+	handler := NewHandler(log.NewNoop(), m.authn, m.policies, m.resources, m.decoder)
+	handler.Now = func() time.Time { return now }
+	mux := http.NewServeMux()
+	handler.Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func expectAuthz(m handlerMocks, object relation.Object, permission string, allowed bool) {
+	m.resources.EXPECT().CheckAuthz(mock.Anything, resource.Check{
+		Object:     object,
+		Subject:    relation.Subject{ID: principal.ID, Namespace: principal.Type},
+		Permission: permission,
+	}).Return(allowed, nil)
+}
+
+func TestHandler_Create(t *testing.T) {
+	t.Run("should create a time bound policy", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		auditRepo := auditmocks.NewRepository(t)
+		ctx := audit.SetContextWithService(context.Background(), audit.NewService("frontier", auditRepo, audit.NewNoopWebhookService()))
+		m.decoder.EXPECT().RequestContext(mock.Anything).Return(ctx)
+		m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, nil)
+		expectAuthz(m, relation.Object{Namespace: schema.ProjectNamespace, ID: "project-id"}, schema.PolicyManagePermission, true)
+		pol := frontierpolicy.Policy{
+			RoleID:        "role-id",
+			ResourceID:    "project-id",
+			ResourceType:  schema.ProjectNamespace,
+			PrincipalID:   "member-id",
+			PrincipalType: schema.UserPrincipal,
+			ExpiresAt:     now.Add(time.Hour),
+		}
+		created := pol
+		created.ID = "policy-id"
+		created.CreatedAt = now
+		m.policies.EXPECT().Create(mock.Anything, pol).Return(created, nil)
+		m.policies.EXPECT().OrgID(mock.Anything, created).Return("org-id", nil)
+		// the grant is logged on the org owning the project
+		auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == "org-id" && l.Action == audit.PolicyCreatedEvent.String()
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/policies/timebound", strings.NewReader(`{
+			"role_id": "role-id",
+			"resource": "app/project:project-id",
+			"principal": "app/user:member-id",
+			"expires_at": "2026-10-18T13:00:00Z"
+		}`)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id": "policy-id",
+			"role_id": "role-id",
+			"resource": "app/project:project-id",
+			"principal": "app/user:member-id",
+			"expires_at": "2026-10-18T13:00:00Z",
+			"created_at": "2026-10-18T12:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject callers who can't manage the policies of the resource", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		expectAuthz(m, relation.Object{Namespace: schema.GroupNamespace, ID: "group-id"}, schema.DeletePermission, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/policies/timebound", strings.NewReader(`{
+			"role_id": "role-id",
+			"resource": "app/group:group-id",
+			"principal": "app/user:member-id"
+		}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject an invalid validity window", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		expectAuthz(m, relation.Object{Namespace: schema.OrganizationNamespace, ID: "org-id"}, schema.PolicyManagePermission, true)
+		m.policies.EXPECT().Create(mock.Anything, mock.Anything).Return(frontierpolicy.Policy{}, frontierpolicy.ErrInvalidDetail)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/policies/timebound", strings.NewReader(`{
+			"role_id": "role-id",
+			"resource": "app/organization:org-id",
+			"principal": "app/user:member-id",
+			"expires_at": "2020-01-01T00:00:00Z"
+		}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should reject unauthenticated callers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, authenticate.Principal{}, assert.AnError)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/policies/timebound", strings.NewReader(`{}`)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Run("should list the policies expiring within the duration", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		expectAuthz(m, relation.Object{Namespace: schema.OrganizationNamespace, ID: "org-id"}, schema.PolicyManagePermission, true)
+		m.policies.EXPECT().List(mock.Anything, frontierpolicy.Filter{
+			OrgID:         "org-id",
+			ExpiresAfter:  now,
+			ExpiresBefore: now.Add(2 * time.Hour),
+		}).Return([]frontierpolicy.Policy{{
+			ID:            "policy-id",
+			RoleID:        "role-id",
+			ResourceID:    "org-id",
+			ResourceType:  schema.OrganizationNamespace,
+			PrincipalID:   "member-id",
+			PrincipalType: schema.UserPrincipal,
+			ExpiresAt:     now.Add(time.Hour),
+			CreatedAt:     now,
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1beta1/policies/timebound?org_id=org-id&expiry=expiring&within=2h", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"policies": [{
+			"id": "policy-id",
+			"role_id": "role-id",
+			"resource": "app/organization:org-id",
+			"principal": "app/user:member-id",
+			"expires_at": "2026-10-18T13:00:00Z",
+			"created_at": "2026-10-18T12:00:00Z"
+		}]}`, rec.Body.String())
+	})
+
+	t.Run("should list the expired policies of a group", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+		expectAuthz(m, relation.Object{Namespace: schema.GroupNamespace, ID: "group-id"}, schema.DeletePermission, true)
+		m.policies.EXPECT().List(mock.Anything, frontierpolicy.Filter{
+			GroupID:       "group-id",
+			ExpiresBefore: now,
+		}).Return(nil, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1beta1/policies/timebound?group_id=group-id&expiry=expired", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"policies": []}`, rec.Body.String())
+	})
+
+	t.Run("should reject an unknown expiry filter", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, principal, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1beta1/policies/timebound?org_id=org-id&expiry=soon", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,257 @@
+package saml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	frontiersaml "github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	CreateConnectionPath = "POST /v1beta1/organizations/{org}/saml"
+	GetConnectionPath    = "GET /v1beta1/organizations/{org}/saml"
+	DeleteConnectionPath = "DELETE /v1beta1/organizations/{org}/saml"
+	ListConnectionsPath  = "GET /admin/saml/connections"
+
+	// maxConnectionBodySize leaves room for an inline metadata document
+	maxConnectionBodySize = 1 << 20
+)
+
+type ConnectionService interface {
+	Create(ctx context.Context, connection frontiersaml.Connection) (frontiersaml.Connection, error)
+	Get(ctx context.Context, orgIDOrName string) (frontiersaml.Connection, error)
+	List(ctx context.Context) ([]frontiersaml.Connection, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type OrgService interface {
+	Get(ctx context.Context, idOrName string) (organization.Organization, error)
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+// ConnectionHandler serves the endpoints organization admins register the
+// saml identity provider of their organization with, they require the
+// update permission on the organization. Superusers list the connections of
+// every organization.
+type ConnectionHandler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	connectionService  ConnectionService
+	orgService         OrgService
+	resourceService    ResourceService
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+}
+
+func NewConnectionHandler(logger log.Logger, authnService httputil.AuthnService, connectionService ConnectionService,
+	orgService OrgService, resourceService ResourceService, userService httputil.SudoService,
+	serviceUserService httputil.SudoService, sessionDecoder httputil.SessionDecoder) *ConnectionHandler {
+	return &ConnectionHandler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		connectionService:  connectionService,
+		orgService:         orgService,
+		resourceService:    resourceService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+	}
+}
+
+// Register mounts the endpoints saml connections are managed with
+func (h *ConnectionHandler) Register(router *httputil.Router) {
+	router.Handle(CreateConnectionPath, h.Create, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(GetConnectionPath, h.Get, httputil.WithScope(authenticate.ScopeRead))
+	router.Handle(DeleteConnectionPath, h.Delete, httputil.WithScope(authenticate.ScopeWrite))
+	router.Handle(ListConnectionsPath, h.List, httputil.WithScope(authenticate.ScopeAdmin))
+}
+
+type connectionResponse struct {
+	ID               string                        `json:"id"`
+	OrgID            string                        `json:"org_id"`
+	EntityID         string                        `json:"entity_id"`
+	MetadataURL      string                        `json:"metadata_url,omitempty"`
+	AttributeMapping frontiersaml.AttributeMapping `json:"attribute_mapping"`
+	CreatedAt        time.Time                     `json:"created_at"`
+}
+
+func toConnectionResponse(c frontiersaml.Connection) connectionResponse {
+	return connectionResponse{
+		ID:               c.ID,
+		OrgID:            c.OrgID,
+		EntityID:         c.EntityID,
+		MetadataURL:      c.MetadataURL,
+		AttributeMapping: c.AttributeMapping,
+		CreatedAt:        c.CreatedAt,
+	}
+}
+
+type createConnectionRequest struct {
+	// Metadata is the identity provider metadata document, it's fetched from
+	// MetadataURL if empty
+	Metadata         string                        `json:"metadata"`
+	MetadataURL      string                        `json:"metadata_url"`
+	AttributeMapping frontiersaml.AttributeMapping `json:"attribute_mapping"`
+}
+
+// Create registers the identity provider of the organization
+func (h *ConnectionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req createConnectionRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxConnectionBodySize)).Decode(&req); err != nil ||
+		(req.Metadata == "") == (req.MetadataURL == "") {
+		httputil.WriteMessage(w, http.StatusBadRequest, "either metadata or metadata_url is required")
+		return
+	}
+	orgID, err := h.authorize(ctx, principal, r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	connection, err := h.connectionService.Create(ctx, frontiersaml.Connection{
+		OrgID:            orgID,
+		Metadata:         req.Metadata,
+		MetadataURL:      req.MetadataURL,
+		AttributeMapping: req.AttributeMapping,
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, connection.OrgID).
+		LogWithAttrs(audit.OrgSAMLConnectionCreatedEvent, audit.OrgTarget(connection.OrgID), map[string]string{
+			"connection_id": connection.ID,
+			"entity_id":     connection.EntityID,
+		})
+	httputil.WriteJSON(w, http.StatusCreated, toConnectionResponse(connection))
+}
+
+// Get returns the connection of the organization
+func (h *ConnectionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	orgID, err := h.authorize(ctx, principal, r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	connection, err := h.connectionService.Get(ctx, orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toConnectionResponse(connection))
+}
+
+// Delete removes the connection of the organization, its members can't log
+// in with the identity provider anymore. Existing sessions stay valid until
+// they expire.
+func (h *ConnectionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	orgID, err := h.authorize(ctx, principal, r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	connection, err := h.connectionService.Get(ctx, orgID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	if err := h.connectionService.Delete(ctx, connection.ID); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	_ = audit.GetAuditor(ctx, connection.OrgID).
+		LogWithAttrs(audit.OrgSAMLConnectionDeletedEvent, audit.OrgTarget(connection.OrgID), map[string]string{
+			"connection_id": connection.ID,
+			"entity_id":     connection.EntityID,
+		})
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type listConnectionsResponse struct {
+	Connections []connectionResponse `json:"connections"`
+}
+
+// List returns the connections of every organization
+func (h *ConnectionHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	connections, err := h.connectionService.List(ctx)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := listConnectionsResponse{Connections: make([]connectionResponse, 0, len(connections))}
+	for _, c := range connections {
+		response.Connections = append(response.Connections, toConnectionResponse(c))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+// authorize resolves the organization addressed by its id or name and checks
+// the principal can update it
+func (h *ConnectionHandler) authorize(ctx context.Context, principal authenticate.Principal, orgIDOrName string) (string, error) {
+	org, err := h.orgService.Get(ctx, orgIDOrName)
+	if err != nil {
+		return "", err
+	}
+	if err := httputil.Authorize(ctx, h.resourceService, principal, relation.Object{
+		Namespace: schema.OrganizationNamespace,
+		ID:        org.ID,
+	}, schema.UpdatePermission); err != nil {
+		return "", err
+	}
+	return org.ID, nil
+}
+
+func (h *ConnectionHandler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontiersaml.ErrInvalidDetail), errors.Is(err, organization.ErrInvalidUUID):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, frontiersaml.ErrNotExist), errors.Is(err, organization.ErrNotExist),
+		errors.Is(err, organization.ErrDisabled):
+		status = http.StatusNotFound
+	case errors.Is(err, frontiersaml.ErrConflict):
+		status = http.StatusConflict
+	default:
+		h.log.Error("saml connection request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
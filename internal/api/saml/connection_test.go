@@ -0,0 +1,169 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	frontiersaml "github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/saml/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	orgAdmin = authenticate.Principal{ID: "user-id", Type: schema.UserPrincipal, User: &user.User{ID: "user-id"}}
+	testOrg  = organization.Organization{ID: "org-id", Name: "acme"}
+)
+
+type connectionMocks struct {
+	authn        *httpmocks.AuthnService
+	decoder      *httpmocks.SessionDecoder
+	connections  *mocks.ConnectionService
+	orgs         *mocks.OrgService
+	resources    *mocks.ResourceService
+	users        *httpmocks.SudoService
+	serviceUsers *httpmocks.SudoService
+	auditRepo    *auditmocks.Repository
+}
+
+func newTestConnectionHandler(t *testing.T) (*http.ServeMux, connectionMocks) {
+	m := connectionMocks{
+		authn:        httpmocks.NewAuthnService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+		connections:  mocks.NewConnectionService(t),
+		orgs:         mocks.NewOrgService(t),
+		resources:    mocks.NewResourceService(t),
+		users:        httpmocks.NewSudoService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		auditRepo:    auditmocks.NewRepository(t),
+	}
+	handler := NewConnectionHandler(log.NewNoop(), m.authn, m.connections, m.orgs, m.resources, m.users,
+		m.serviceUsers, m.decoder)
+	mux := http.NewServeMux()
+	handler.Register(httputil.NewRouter(mux))
+
+	ctx := audit.SetContextWithService(context.Background(), audit.NewService("frontier", m.auditRepo, audit.NewNoopWebhookService()))
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(ctx)
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(orgAdmin, nil)
+	return mux, m
+}
+
+func expectOrgUpdate(m connectionMocks, allowed bool) {
+	m.orgs.EXPECT().Get(mock.Anything, testOrg.Name).Return(testOrg, nil)
+	m.resources.EXPECT().CheckAuthz(mock.Anything, resource.Check{
+		Object:     relation.Object{Namespace: schema.OrganizationNamespace, ID: testOrg.ID},
+		Subject:    relation.Subject{ID: orgAdmin.ID, Namespace: orgAdmin.Type},
+		Permission: schema.UpdatePermission,
+	}).Return(allowed, nil)
+}
+
+func TestConnectionHandler_Create(t *testing.T) {
+	t.Run("should register the identity provider of the organization", func(t *testing.T) {
+		mux, m := newTestConnectionHandler(t)
+		expectOrgUpdate(m, true)
+		m.connections.EXPECT().Create(mock.Anything, frontiersaml.Connection{
+			OrgID:            testOrg.ID,
+			MetadataURL:      "https://idp.acme.org/metadata",
+			AttributeMapping: frontiersaml.AttributeMapping{Email: "upn"},
+		}).Return(frontiersaml.Connection{
+			ID:               "connection-id",
+			OrgID:            testOrg.ID,
+			EntityID:         "https://idp.acme.org",
+			MetadataURL:      "https://idp.acme.org/metadata",
+			AttributeMapping: frontiersaml.AttributeMapping{Email: "upn"},
+			CreatedAt:        time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		}, nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == testOrg.ID && l.Action == audit.OrgSAMLConnectionCreatedEvent.String() &&
+				l.Actor.ID == orgAdmin.ID && l.Metadata["connection_id"] == "connection-id"
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/organizations/acme/saml", strings.NewReader(`{
+			"metadata_url": "https://idp.acme.org/metadata",
+			"attribute_mapping": {"email": "upn"}
+		}`)))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.JSONEq(t, `{
+			"id": "connection-id",
+			"org_id": "org-id",
+			"entity_id": "https://idp.acme.org",
+			"metadata_url": "https://idp.acme.org/metadata",
+			"attribute_mapping": {"email": "upn"},
+			"created_at": "2026-10-18T12:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject callers who can't update the organization", func(t *testing.T) {
+		mux, m := newTestConnectionHandler(t)
+		expectOrgUpdate(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/organizations/acme/saml",
+			strings.NewReader(`{"metadata": "<EntityDescriptor/>"}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject a second connection", func(t *testing.T) {
+		mux, m := newTestConnectionHandler(t)
+		expectOrgUpdate(m, true)
+		m.connections.EXPECT().Create(mock.Anything, mock.Anything).Return(frontiersaml.Connection{}, frontiersaml.ErrConflict)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1beta1/organizations/acme/saml",
+			strings.NewReader(`{"metadata": "<EntityDescriptor/>"}`)))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestConnectionHandler_Delete(t *testing.T) {
+	t.Run("should delete the connection of the organization", func(t *testing.T) {
+		mux, m := newTestConnectionHandler(t)
+		expectOrgUpdate(m, true)
+		m.connections.EXPECT().Get(mock.Anything, testOrg.ID).Return(frontiersaml.Connection{ID: "connection-id", OrgID: testOrg.ID}, nil)
+		m.connections.EXPECT().Delete(mock.Anything, "connection-id").Return(nil)
+		m.auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+			return l.OrgID == testOrg.ID && l.Action == audit.OrgSAMLConnectionDeletedEvent.String()
+		})).Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1beta1/organizations/acme/saml", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return not found if the organization has no connection", func(t *testing.T) {
+		mux, m := newTestConnectionHandler(t)
+		expectOrgUpdate(m, true)
+		m.connections.EXPECT().Get(mock.Anything, testOrg.ID).Return(frontiersaml.Connection{}, frontiersaml.ErrNotExist)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/v1beta1/organizations/acme/saml", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestConnectionHandler_List(t *testing.T) {
+	t.Run("should only list the connections to superusers", func(t *testing.T) {
+		mux, m := newTestConnectionHandler(t)
+		m.users.EXPECT().IsSudo(mock.Anything, orgAdmin.ID, schema.PlatformSudoPermission).Return(false, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/saml/connections", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
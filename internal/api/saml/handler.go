@@ -0,0 +1,154 @@
+package saml
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/organization"
+	frontiersaml "github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/raystack/frontier/pkg/utils"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	MetadataPath = "GET /saml/{org}/metadata"
+	LoginPath    = "GET /saml/{org}/login"
+	ACSPath      = "POST /saml/{org}/acs"
+)
+
+type SAMLService interface {
+	Metadata(ctx context.Context, orgIDOrName string) ([]byte, error)
+	StartLogin(ctx context.Context, orgIDOrName, returnTo string) (string, error)
+	FinishLogin(ctx context.Context, orgIDOrName, samlResponse, relayState string) (user.User, *authenticate.Flow, error)
+}
+
+type AuthnService interface {
+	SanitizeReturnToURL(url string) string
+}
+
+// SessionService creates the session of a logged in user, it stays pending
+// if the user has to verify a second factor
+type SessionService interface {
+	CreateSession(ctx context.Context, userID string, metadata metadata.Metadata) (*frontiersession.Session, error)
+}
+
+// SessionCookie writes the session cookie of a plain http response
+type SessionCookie interface {
+	SetSessionCookie(w http.ResponseWriter, sessionID string) error
+}
+
+// Handler serves the saml service provider endpoints of organizations. They
+// are plain http handlers as identity providers post responses as forms
+type Handler struct {
+	log            log.Logger
+	samlService    SAMLService
+	authnService   AuthnService
+	sessionService SessionService
+	sessionCookie  SessionCookie
+	// proxies are trusted to forward the address of the client
+	proxies utils.Proxies
+}
+
+func NewHandler(logger log.Logger, samlService SAMLService, authnService AuthnService,
+	sessionService SessionService, sessionCookie SessionCookie, proxies utils.Proxies) *Handler {
+	return &Handler{
+		log:            logger,
+		samlService:    samlService,
+		authnService:   authnService,
+		sessionService: sessionService,
+		sessionCookie:  sessionCookie,
+		proxies:        proxies,
+	}
+}
+
+// Register mounts the endpoints on the mux, wrapper sets up the audit service
+// of the requests so the users created on login are recorded
+func (h *Handler) Register(mux *http.ServeMux, wrapper func(http.Handler) http.Handler) {
+	mux.Handle(MetadataPath, wrapper(http.HandlerFunc(h.Metadata)))
+	mux.Handle(LoginPath, wrapper(http.HandlerFunc(h.Login)))
+	mux.Handle(ACSPath, wrapper(http.HandlerFunc(h.ACS)))
+}
+
+// Metadata serves the service provider metadata to register at the identity provider
+func (h *Handler) Metadata(w http.ResponseWriter, r *http.Request) {
+	metadata, err := h.samlService.Metadata(r.Context(), r.PathValue("org"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(metadata)
+}
+
+// Login redirects the user to the identity provider of the organization, the
+// user is sent back to the return_to url once logged in
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	returnTo := h.authnService.SanitizeReturnToURL(r.URL.Query().Get("return_to"))
+	authURL, err := h.samlService.StartLogin(r.Context(), r.PathValue("org"), returnTo)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// ACS is the assertion consumer service receiving the identity provider response
+// with the HTTP-POST binding, a session is created for the authenticated user
+func (h *Handler) ACS(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	loggedInUser, flow, err := h.samlService.FinishLogin(r.Context(), r.PathValue("org"),
+		r.PostForm.Get("SAMLResponse"), r.PostForm.Get("RelayState"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	session, err := h.sessionService.CreateSession(r.Context(), loggedInUser.ID, metadata.Metadata{
+		frontiersession.MetadataAuthMethod: strategy.SAMLAuthMethod,
+		frontiersession.MetadataUserAgent:  r.UserAgent(),
+		frontiersession.MetadataIPAddress:  h.proxies.ClientIP(strings.Join(r.Header.Values("X-Forwarded-For"), ","), r.RemoteAddr),
+	})
+	if err != nil {
+		h.log.Error("failed to create session", "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if err = h.sessionCookie.SetSessionCookie(w, session.ID.String()); err != nil {
+		h.log.Error("failed to set session cookie", "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if flow.FinishURL != "" {
+		http.Redirect(w, r, flow.FinishURL, http.StatusSeeOther)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, frontiersaml.ErrNotExist), errors.Is(err, frontiersaml.ErrNotConfigured),
+		errors.Is(err, organization.ErrNotExist), errors.Is(err, organization.ErrDisabled),
+		errors.Is(err, organization.ErrInvalidUUID):
+		http.Error(w, "saml login is not available for the organization", http.StatusNotFound)
+	case errors.Is(err, frontiersaml.ErrInvalidFlow), errors.Is(err, strategy.ErrInvalidSAMLResponse),
+		errors.Is(err, frontiersaml.ErrMissingEmail), errors.Is(err, frontiersaml.ErrAssertionReplay):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, frontiersaml.ErrDomainNotAllowed), errors.Is(err, frontiersaml.ErrUserDisabled):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	default:
+		h.log.Error("saml login failed", "err", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
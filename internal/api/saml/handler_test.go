@@ -0,0 +1,208 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/authenticate/strategy"
+	"github.com/raystack/frontier/core/organization"
+	frontiersaml "github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/saml/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testSessionID = uuid.MustParse("6b8b4567-327b-4f6e-9a3c-2f1e5d4c3b2a")
+
+type handlerMocks struct {
+	saml     *mocks.SAMLService
+	authn    *mocks.AuthnService
+	sessions *mocks.SessionService
+	cookie   *mocks.SessionCookie
+	audit    *auditmocks.Repository
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		saml:     mocks.NewSAMLService(t),
+		authn:    mocks.NewAuthnService(t),
+		sessions: mocks.NewSessionService(t),
+		cookie:   mocks.NewSessionCookie(t),
+		audit:    auditmocks.NewRepository(t),
+	}
+	auditService := audit.NewService("frontier", m.audit, audit.NewNoopWebhookService())
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.saml, m.authn, m.sessions, m.cookie, nil).Register(mux, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(audit.SetContextWithService(r.Context(), auditService)))
+		})
+	})
+	return mux, m
+}
+
+func TestHandler_Metadata(t *testing.T) {
+	mux, m := newTestHandler(t)
+	m.saml.EXPECT().Metadata(mock.Anything, "acme").Return([]byte("<md:EntityDescriptor/>"), nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/saml/acme/metadata", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/samlmetadata+xml", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "<md:EntityDescriptor/>", rec.Body.String())
+}
+
+func TestHandler_Login(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(m handlerMocks)
+		status   int
+		location string
+	}{
+		{
+			name: "should redirect to the identity provider",
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().SanitizeReturnToURL("https://app.acme.org").Return("https://app.acme.org")
+				m.saml.EXPECT().StartLogin(mock.Anything, "acme", "https://app.acme.org").Return("https://idp.acme.org/sso?SAMLRequest=x", nil)
+			},
+			status:   http.StatusFound,
+			location: "https://idp.acme.org/sso?SAMLRequest=x",
+		},
+		{
+			name: "should return not found if the organization has no connection",
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().SanitizeReturnToURL("https://app.acme.org").Return("")
+				m.saml.EXPECT().StartLogin(mock.Anything, "acme", "").Return("", frontiersaml.ErrNotExist)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "should return not found if the organization is disabled",
+			setup: func(m handlerMocks) {
+				m.authn.EXPECT().SanitizeReturnToURL(mock.Anything).Return("")
+				m.saml.EXPECT().StartLogin(mock.Anything, "acme", "").Return("", organization.ErrDisabled)
+			},
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			tt.setup(m)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/saml/acme/login?return_to="+url.QueryEscape("https://app.acme.org"), nil))
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.location, rec.Header().Get("Location"))
+		})
+	}
+}
+
+func TestHandler_ACS(t *testing.T) {
+	form := url.Values{
+		"SAMLResponse": {"encoded-response"},
+		"RelayState":   {"flow-id"},
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		setup    func(m handlerMocks)
+		status   int
+		location string
+	}{
+		{
+			name:   "should create a session and redirect to the return url",
+			method: http.MethodPost,
+			setup: func(m handlerMocks) {
+				m.saml.EXPECT().FinishLogin(mock.Anything, "acme", "encoded-response", "flow-id").
+					Return(user.User{ID: "user-id"}, &authenticate.Flow{FinishURL: "https://app.acme.org"}, nil)
+				m.sessions.EXPECT().CreateSession(mock.Anything, "user-id", metadata.Metadata{
+					frontiersession.MetadataAuthMethod: strategy.SAMLAuthMethod,
+					frontiersession.MetadataUserAgent:  "",
+					frontiersession.MetadataIPAddress:  "192.0.2.1",
+				}).Return(&frontiersession.Session{ID: testSessionID}, nil)
+				m.cookie.EXPECT().SetSessionCookie(mock.Anything, testSessionID.String()).Return(nil)
+			},
+			status:   http.StatusSeeOther,
+			location: "https://app.acme.org",
+		},
+		{
+			name:   "should create a session without a return url",
+			method: http.MethodPost,
+			setup: func(m handlerMocks) {
+				m.saml.EXPECT().FinishLogin(mock.Anything, "acme", "encoded-response", "flow-id").
+					Return(user.User{ID: "user-id"}, &authenticate.Flow{}, nil)
+				m.sessions.EXPECT().CreateSession(mock.Anything, "user-id", mock.Anything).Return(&frontiersession.Session{ID: testSessionID}, nil)
+				m.cookie.EXPECT().SetSessionCookie(mock.Anything, testSessionID.String()).Return(nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "should audit the users created on login",
+			method: http.MethodPost,
+			setup: func(m handlerMocks) {
+				m.saml.EXPECT().FinishLogin(mock.Anything, "acme", "encoded-response", "flow-id").
+					RunAndReturn(func(ctx context.Context, orgIDOrName, samlResponse, relayState string) (user.User, *authenticate.Flow, error) {
+						err := audit.GetAuditor(ctx, schema.PlatformOrgID.String()).Log(audit.UserCreatedEvent, audit.UserTarget("user-id"))
+						return user.User{ID: "user-id"}, &authenticate.Flow{}, err
+					})
+				m.audit.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+					return l.Action == audit.UserCreatedEvent.String() && l.Target.ID == "user-id"
+				})).Return(nil)
+				m.sessions.EXPECT().CreateSession(mock.Anything, "user-id", mock.Anything).Return(&frontiersession.Session{ID: testSessionID}, nil)
+				m.cookie.EXPECT().SetSessionCookie(mock.Anything, testSessionID.String()).Return(nil)
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "should return bad request for an invalid response",
+			method: http.MethodPost,
+			setup: func(m handlerMocks) {
+				m.saml.EXPECT().FinishLogin(mock.Anything, "acme", "encoded-response", "flow-id").
+					Return(user.User{}, nil, strategy.ErrInvalidSAMLResponse)
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "should return forbidden for users outside the organization domains",
+			method: http.MethodPost,
+			setup: func(m handlerMocks) {
+				m.saml.EXPECT().FinishLogin(mock.Anything, "acme", "encoded-response", "flow-id").
+					Return(user.User{}, nil, frontiersaml.ErrDomainNotAllowed)
+			},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "should only accept posted responses",
+			method: http.MethodGet,
+			setup:  func(m handlerMocks) {},
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			tt.setup(m)
+
+			req := httptest.NewRequest(tt.method, "/saml/acme/acs", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.location, rec.Header().Get("Location"))
+		})
+	}
+}
@@ -0,0 +1,586 @@
+package scim
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/organization"
+	frontierscim "github.com/raystack/frontier/core/scim"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	BasePath = "/scim/v2/{org}"
+
+	ServiceProviderConfigPath = "GET " + BasePath + "/ServiceProviderConfig"
+	ResourceTypesPath         = "GET " + BasePath + "/ResourceTypes"
+	ListUsersPath             = "GET " + BasePath + "/Users"
+	CreateUserPath            = "POST " + BasePath + "/Users"
+	GetUserPath               = "GET " + BasePath + "/Users/{id}"
+	ReplaceUserPath           = "PUT " + BasePath + "/Users/{id}"
+	PatchUserPath             = "PATCH " + BasePath + "/Users/{id}"
+	DeleteUserPath            = "DELETE " + BasePath + "/Users/{id}"
+	ListGroupsPath            = "GET " + BasePath + "/Groups"
+	CreateGroupPath           = "POST " + BasePath + "/Groups"
+	GetGroupPath              = "GET " + BasePath + "/Groups/{id}"
+	ReplaceGroupPath          = "PUT " + BasePath + "/Groups/{id}"
+	PatchGroupPath            = "PATCH " + BasePath + "/Groups/{id}"
+	DeleteGroupPath           = "DELETE " + BasePath + "/Groups/{id}"
+
+	contentType = "application/scim+json"
+	// maxBodySize bounds the resources and patch requests sent by identity providers
+	maxBodySize = 1 << 20
+)
+
+type SCIMService interface {
+	Authenticate(ctx context.Context, orgIDOrName, tokenID, token string) (organization.Organization, authenticate.Principal, error)
+	ListUsers(ctx context.Context, orgID, filter string, page frontierscim.Page) (frontierscim.ListResult[frontierscim.User], error)
+	GetUser(ctx context.Context, orgID, id string) (frontierscim.User, error)
+	CreateUser(ctx context.Context, orgID string, u frontierscim.User) (frontierscim.User, error)
+	ReplaceUser(ctx context.Context, orgID, id string, u frontierscim.User) (frontierscim.User, error)
+	PatchUser(ctx context.Context, orgID, id string, ops []frontierscim.PatchOperation) (frontierscim.User, error)
+	DeleteUser(ctx context.Context, orgID, id string) error
+	ListGroups(ctx context.Context, orgID, filter string, page frontierscim.Page) (frontierscim.ListResult[frontierscim.Group], error)
+	GetGroup(ctx context.Context, orgID, id string) (frontierscim.Group, error)
+	CreateGroup(ctx context.Context, orgID string, g frontierscim.Group) (frontierscim.Group, error)
+	ReplaceGroup(ctx context.Context, orgID, id string, g frontierscim.Group) (frontierscim.Group, error)
+	PatchGroup(ctx context.Context, orgID, id string, ops []frontierscim.PatchOperation) (frontierscim.Group, error)
+	DeleteGroup(ctx context.Context, orgID, id string) error
+}
+
+// Handler serves the scim 2.0 provisioning endpoints of organizations. They are
+// plain http handlers as identity providers speak scim over json rather than
+// the frontier api, each request is authenticated with a service user token
+type Handler struct {
+	log         log.Logger
+	scimService SCIMService
+}
+
+func NewHandler(logger log.Logger, scimService SCIMService) *Handler {
+	return &Handler{
+		log:         logger,
+		scimService: scimService,
+	}
+}
+
+// Register mounts the endpoints on the mux, wrapper sets up the audit service
+// and the cors headers of the requests like for the other plain http handlers
+func (h *Handler) Register(mux *http.ServeMux, wrapper func(http.Handler) http.Handler) {
+	mux.Handle(ServiceProviderConfigPath, wrapper(h.authenticated(h.ServiceProviderConfig)))
+	mux.Handle(ResourceTypesPath, wrapper(h.authenticated(h.ResourceTypes)))
+	mux.Handle(ListUsersPath, wrapper(h.authenticated(h.ListUsers)))
+	mux.Handle(CreateUserPath, wrapper(h.authenticated(h.CreateUser)))
+	mux.Handle(GetUserPath, wrapper(h.authenticated(h.GetUser)))
+	mux.Handle(ReplaceUserPath, wrapper(h.authenticated(h.ReplaceUser)))
+	mux.Handle(PatchUserPath, wrapper(h.authenticated(h.PatchUser)))
+	mux.Handle(DeleteUserPath, wrapper(h.authenticated(h.DeleteUser)))
+	mux.Handle(ListGroupsPath, wrapper(h.authenticated(h.ListGroups)))
+	mux.Handle(CreateGroupPath, wrapper(h.authenticated(h.CreateGroup)))
+	mux.Handle(GetGroupPath, wrapper(h.authenticated(h.GetGroup)))
+	mux.Handle(ReplaceGroupPath, wrapper(h.authenticated(h.ReplaceGroup)))
+	mux.Handle(PatchGroupPath, wrapper(h.authenticated(h.PatchGroup)))
+	mux.Handle(DeleteGroupPath, wrapper(h.authenticated(h.DeleteGroup)))
+}
+
+// authenticated verifies the bearer token of the request, it is the base64
+// encoded "<token id>:<token>" of an opaque token of a service user of the
+// organization, the same value is accepted as basic credentials as well. The
+// service user is the actor of the audit logs of the provisioned changes
+func (h *Handler) authenticated(next func(w http.ResponseWriter, r *http.Request, orgID string)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenID, token := bearerToken(r.Header.Get("Authorization"))
+		org, principal, err := h.scimService.Authenticate(r.Context(), r.PathValue("org"), tokenID, token)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		ctx := authenticate.SetContextWithPrincipal(r.Context(), &principal)
+		ctx = httputil.ContextWithActor(ctx, principal)
+		next(w, r.WithContext(ctx), org.ID)
+	}
+}
+
+func bearerToken(header string) (string, string) {
+	scheme, credentials, _ := strings.Cut(header, " ")
+	if !strings.EqualFold(scheme, "Bearer") && !strings.EqualFold(scheme, "Basic") {
+		return "", ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(credentials))
+	if err != nil {
+		return "", ""
+	}
+	tokenID, token, _ := strings.Cut(string(decoded), ":")
+	return tokenID, token
+}
+
+// ServiceProviderConfig advertises the supported features as per RFC 7643 section 5
+func (h *Handler) ServiceProviderConfig(w http.ResponseWriter, r *http.Request, orgID string) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": frontierscim.MaxPageSize},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Service user token",
+			"description": "Base64 encoded <token id>:<token> of an opaque token of a service user of the organization",
+			"primary":     true,
+		}},
+	})
+}
+
+// ResourceTypes lists the provisioned resources as per RFC 7643 section 6
+func (h *Handler) ResourceTypes(w http.ResponseWriter, r *http.Request, orgID string) {
+	resourceTypes := []map[string]any{
+		{
+			"schemas":  []string{"urn:ietf:params:scim:schemas:core:2.0:ResourceType"},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   frontierscim.UserSchema,
+		},
+		{
+			"schemas":  []string{"urn:ietf:params:scim:schemas:core:2.0:ResourceType"},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   frontierscim.GroupSchema,
+		},
+	}
+	writeJSON(w, http.StatusOK, listResponse[map[string]any]{
+		Schemas:      []string{frontierscim.ListResponseSchema},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+}
+
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request, orgID string) {
+	page, err := parsePage(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	result, err := h.scimService.ListUsers(r.Context(), orgID, r.URL.Query().Get("filter"), page)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resources := make([]userResource, 0, len(result.Resources))
+	for _, u := range result.Resources {
+		resources = append(resources, toUserResource(r, u))
+	}
+	writeJSON(w, http.StatusOK, listResponse[userResource]{
+		Schemas:      []string{frontierscim.ListResponseSchema},
+		TotalResults: result.TotalResults,
+		StartIndex:   result.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request, orgID string) {
+	u, err := h.scimService.GetUser(r.Context(), orgID, r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUserResource(r, u))
+}
+
+func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request, orgID string) {
+	var body userResource
+	if err := decodeBody(w, r, &body); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	u, err := h.scimService.CreateUser(r.Context(), orgID, body.toUser())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resource := toUserResource(r, u)
+	w.Header().Set("Location", resource.Meta.Location)
+	writeJSON(w, http.StatusCreated, resource)
+}
+
+func (h *Handler) ReplaceUser(w http.ResponseWriter, r *http.Request, orgID string) {
+	var body userResource
+	if err := decodeBody(w, r, &body); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	u, err := h.scimService.ReplaceUser(r.Context(), orgID, r.PathValue("id"), body.toUser())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUserResource(r, u))
+}
+
+func (h *Handler) PatchUser(w http.ResponseWriter, r *http.Request, orgID string) {
+	ops, err := decodePatch(w, r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	u, err := h.scimService.PatchUser(r.Context(), orgID, r.PathValue("id"), ops)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUserResource(r, u))
+}
+
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request, orgID string) {
+	if err := h.scimService.DeleteUser(r.Context(), orgID, r.PathValue("id")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListGroups(w http.ResponseWriter, r *http.Request, orgID string) {
+	page, err := parsePage(r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	result, err := h.scimService.ListGroups(r.Context(), orgID, r.URL.Query().Get("filter"), page)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resources := make([]groupResource, 0, len(result.Resources))
+	for _, g := range result.Resources {
+		resources = append(resources, toGroupResource(r, g))
+	}
+	writeJSON(w, http.StatusOK, listResponse[groupResource]{
+		Schemas:      []string{frontierscim.ListResponseSchema},
+		TotalResults: result.TotalResults,
+		StartIndex:   result.StartIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) GetGroup(w http.ResponseWriter, r *http.Request, orgID string) {
+	g, err := h.scimService.GetGroup(r.Context(), orgID, r.PathValue("id"))
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toGroupResource(r, g))
+}
+
+func (h *Handler) CreateGroup(w http.ResponseWriter, r *http.Request, orgID string) {
+	var body groupResource
+	if err := decodeBody(w, r, &body); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	g, err := h.scimService.CreateGroup(r.Context(), orgID, body.toGroup())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	resource := toGroupResource(r, g)
+	w.Header().Set("Location", resource.Meta.Location)
+	writeJSON(w, http.StatusCreated, resource)
+}
+
+func (h *Handler) ReplaceGroup(w http.ResponseWriter, r *http.Request, orgID string) {
+	var body groupResource
+	if err := decodeBody(w, r, &body); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	g, err := h.scimService.ReplaceGroup(r.Context(), orgID, r.PathValue("id"), body.toGroup())
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toGroupResource(r, g))
+}
+
+func (h *Handler) PatchGroup(w http.ResponseWriter, r *http.Request, orgID string) {
+	ops, err := decodePatch(w, r)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	g, err := h.scimService.PatchGroup(r.Context(), orgID, r.PathValue("id"), ops)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toGroupResource(r, g))
+}
+
+func (h *Handler) DeleteGroup(w http.ResponseWriter, r *http.Request, orgID string) {
+	if err := h.scimService.DeleteGroup(r.Context(), orgID, r.PathValue("id")); err != nil {
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type listResponse[T any] struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []T      `json:"Resources"`
+}
+
+type meta struct {
+	ResourceType string     `json:"resourceType"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastModified *time.Time `json:"lastModified,omitempty"`
+	Location     string     `json:"location"`
+}
+
+type userName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+type userEmail struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type userResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id,omitempty"`
+	UserName    string      `json:"userName"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Name        *userName   `json:"name,omitempty"`
+	Emails      []userEmail `json:"emails,omitempty"`
+	Active      *bool       `json:"active,omitempty"`
+	Meta        *meta       `json:"meta,omitempty"`
+}
+
+// toUser maps a user sent by the identity provider, the primary email is used
+// when there is no user name and users are active unless stated otherwise
+func (u userResource) toUser() frontierscim.User {
+	user := frontierscim.User{
+		UserName:    u.UserName,
+		DisplayName: u.DisplayName,
+		Active:      u.Active == nil || *u.Active,
+	}
+	if user.UserName == "" {
+		for _, email := range u.Emails {
+			if email.Primary || user.UserName == "" {
+				user.UserName = email.Value
+			}
+		}
+	}
+	if u.Name != nil {
+		if user.DisplayName == "" {
+			user.DisplayName = u.Name.Formatted
+		}
+		user.GivenName = u.Name.GivenName
+		user.FamilyName = u.Name.FamilyName
+	}
+	return user
+}
+
+func toUserResource(r *http.Request, u frontierscim.User) userResource {
+	active := u.Active
+	return userResource{
+		Schemas:     []string{frontierscim.UserSchema},
+		ID:          u.ID,
+		UserName:    u.UserName,
+		DisplayName: u.Title(),
+		Name: &userName{
+			Formatted:  u.Title(),
+			GivenName:  u.GivenName,
+			FamilyName: u.FamilyName,
+		},
+		Emails: []userEmail{{
+			Value:   u.UserName,
+			Type:    "work",
+			Primary: true,
+		}},
+		Active: &active,
+		Meta:   newMeta(r, "User", "/Users/"+u.ID, u.CreatedAt, u.UpdatedAt),
+	}
+}
+
+type groupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type groupResource struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id,omitempty"`
+	DisplayName string        `json:"displayName"`
+	Members     []groupMember `json:"members"`
+	Meta        *meta         `json:"meta,omitempty"`
+}
+
+func (g groupResource) toGroup() frontierscim.Group {
+	group := frontierscim.Group{
+		DisplayName: g.DisplayName,
+	}
+	for _, m := range g.Members {
+		group.Members = append(group.Members, frontierscim.Member{Value: m.Value, Display: m.Display})
+	}
+	return group
+}
+
+func toGroupResource(r *http.Request, g frontierscim.Group) groupResource {
+	resource := groupResource{
+		Schemas:     []string{frontierscim.GroupSchema},
+		ID:          g.ID,
+		DisplayName: g.DisplayName,
+		Members:     []groupMember{},
+		Meta:        newMeta(r, "Group", "/Groups/"+g.ID, g.CreatedAt, g.UpdatedAt),
+	}
+	// azure ad skips the members of large groups when reading them
+	if strings.Contains(r.URL.Query().Get("excludedAttributes"), "members") {
+		return resource
+	}
+	for _, m := range g.Members {
+		resource.Members = append(resource.Members, groupMember{Value: m.Value, Display: m.Display})
+	}
+	return resource
+}
+
+func newMeta(r *http.Request, resourceType, path string, created, updated time.Time) *meta {
+	m := &meta{
+		ResourceType: resourceType,
+		Location:     baseURL(r) + path,
+	}
+	if !created.IsZero() {
+		m.Created = &created
+	}
+	if !updated.IsZero() {
+		m.LastModified = &updated
+	}
+	return m
+}
+
+// baseURL is the scim root of the organization of the request as seen by the client
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + "/scim/v2/" + r.PathValue("org")
+}
+
+func parsePage(r *http.Request) (frontierscim.Page, error) {
+	page := frontierscim.Page{StartIndex: 1, Count: frontierscim.MaxPageSize}
+	query := r.URL.Query()
+	if v := query.Get("startIndex"); v != "" {
+		startIndex, err := strconv.Atoi(v)
+		if err != nil {
+			return frontierscim.Page{}, errInvalidSyntax
+		}
+		page.StartIndex = startIndex
+	}
+	if v := query.Get("count"); v != "" {
+		count, err := strconv.Atoi(v)
+		if err != nil {
+			return frontierscim.Page{}, errInvalidSyntax
+		}
+		page.Count = count
+	}
+	return page, nil
+}
+
+var errInvalidSyntax = errors.New("malformed scim request")
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(v); err != nil {
+		return errInvalidSyntax
+	}
+	return nil
+}
+
+func decodePatch(w http.ResponseWriter, r *http.Request) ([]frontierscim.PatchOperation, error) {
+	var body struct {
+		Operations []struct {
+			Op    string `json:"op"`
+			Path  string `json:"path"`
+			Value any    `json:"value"`
+		} `json:"Operations"`
+	}
+	if err := decodeBody(w, r, &body); err != nil {
+		return nil, err
+	}
+	ops := make([]frontierscim.PatchOperation, 0, len(body.Operations))
+	for _, op := range body.Operations {
+		ops = append(ops, frontierscim.PatchOperation{Op: op.Op, Path: op.Path, Value: op.Value})
+	}
+	return ops, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with the error format of RFC 7644 section 3.12
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	var status int
+	var scimType string
+	switch {
+	case errors.Is(err, frontierscim.ErrUnauthenticated):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+		status = http.StatusUnauthorized
+	case errors.Is(err, frontierscim.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, frontierscim.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontierscim.ErrConflict):
+		status, scimType = http.StatusConflict, "uniqueness"
+	case errors.Is(err, frontierscim.ErrLastAdmin):
+		status = http.StatusConflict
+	case errors.Is(err, frontierscim.ErrInvalidFilter):
+		status, scimType = http.StatusBadRequest, "invalidFilter"
+	case errors.Is(err, frontierscim.ErrInvalidPath):
+		status, scimType = http.StatusBadRequest, "invalidPath"
+	case errors.Is(err, frontierscim.ErrInvalidValue):
+		status, scimType = http.StatusBadRequest, "invalidValue"
+	case errors.Is(err, frontierscim.ErrMutability):
+		status, scimType = http.StatusBadRequest, "mutability"
+	case errors.Is(err, errInvalidSyntax):
+		status, scimType = http.StatusBadRequest, "invalidSyntax"
+	default:
+		h.log.Error("scim request failed", "err", err)
+		err = errors.New("internal error")
+		status = http.StatusInternalServerError
+	}
+
+	body := map[string]any{
+		"schemas": []string{frontierscim.ErrorSchema},
+		"status":  strconv.Itoa(status),
+		"detail":  err.Error(),
+	}
+	if scimType != "" {
+		body["scimType"] = scimType
+	}
+	writeJSON(w, status, body)
+}
@@ -0,0 +1,198 @@
+package scim
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raystack/frontier/core/audit"
+	auditmocks "github.com/raystack/frontier/core/audit/mocks"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/organization"
+	frontierscim "github.com/raystack/frontier/core/scim"
+	"github.com/raystack/frontier/internal/api/scim/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testOrgID = "9f256f86-31a3-11ec-8d3d-0242ac130003"
+
+var testToken = "Bearer " + base64.StdEncoding.EncodeToString([]byte("token-id:secret"))
+
+func newTestHandler(t *testing.T) (*http.ServeMux, *mocks.SCIMService) {
+	mux, s, _ := newAuditedTestHandler(t)
+	return mux, s
+}
+
+func newAuditedTestHandler(t *testing.T) (*http.ServeMux, *mocks.SCIMService, *auditmocks.Repository) {
+	s := mocks.NewSCIMService(t)
+	auditRepo := auditmocks.NewRepository(t)
+	auditService := audit.NewService("frontier", auditRepo, audit.NewNoopWebhookService())
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), s).Register(mux, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r.WithContext(audit.SetContextWithService(r.Context(), auditService)))
+		})
+	})
+	return mux, s, auditRepo
+}
+
+func expectAuthenticated(s *mocks.SCIMService) {
+	s.EXPECT().Authenticate(mock.Anything, "acme", "token-id", "secret").Return(
+		organization.Organization{ID: testOrgID},
+		authenticate.Principal{ID: "su-id", Type: schema.ServiceUserPrincipal}, nil)
+}
+
+func TestHandler_Authentication(t *testing.T) {
+	mux, s := newTestHandler(t)
+	s.EXPECT().Authenticate(mock.Anything, "acme", "", "").Return(
+		organization.Organization{}, authenticate.Principal{}, frontierscim.ErrUnauthenticated)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/scim/v2/acme/Users", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer realm="scim"`, rec.Header().Get("WWW-Authenticate"))
+	assert.JSONEq(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"],
+		"status": "401",
+		"detail": "invalid or missing scim bearer token"
+	}`, rec.Body.String())
+}
+
+func TestHandler_ListUsers(t *testing.T) {
+	t.Run("should list the users matching the filter", func(t *testing.T) {
+		mux, s := newTestHandler(t)
+		expectAuthenticated(s)
+		s.EXPECT().ListUsers(mock.Anything, testOrgID, `userName eq "john@acme.org"`, frontierscim.Page{StartIndex: 1, Count: 10}).
+			Return(frontierscim.ListResult[frontierscim.User]{
+				Resources:    []frontierscim.User{{ID: "user-id", UserName: "john@acme.org", DisplayName: "John", Active: true}},
+				TotalResults: 1,
+				StartIndex:   1,
+			}, nil)
+
+		r := httptest.NewRequest(http.MethodGet, "https://frontier.example.com/scim/v2/acme/Users?count=10&filter="+
+			"userName%20eq%20%22john%40acme.org%22", nil)
+		r.Header.Set("Authorization", testToken)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, r)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/scim+json", rec.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:ListResponse"],
+			"totalResults": 1,
+			"startIndex": 1,
+			"itemsPerPage": 1,
+			"Resources": [{
+				"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+				"id": "user-id",
+				"userName": "john@acme.org",
+				"displayName": "John",
+				"name": {"formatted": "John"},
+				"emails": [{"value": "john@acme.org", "type": "work", "primary": true}],
+				"active": true,
+				"meta": {"resourceType": "User", "location": "https://frontier.example.com/scim/v2/acme/Users/user-id"}
+			}]
+		}`, rec.Body.String())
+	})
+
+	t.Run("should return filter errors", func(t *testing.T) {
+		mux, s := newTestHandler(t)
+		expectAuthenticated(s)
+		s.EXPECT().ListUsers(mock.Anything, testOrgID, "userName", frontierscim.Page{StartIndex: 1, Count: frontierscim.MaxPageSize}).
+			Return(frontierscim.ListResult[frontierscim.User]{}, frontierscim.ErrInvalidFilter)
+
+		r := httptest.NewRequest(http.MethodGet, "/scim/v2/acme/Users?filter=userName", nil)
+		r.Header.Set("Authorization", testToken)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, r)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"scimType":"invalidFilter"`)
+	})
+}
+
+func TestHandler_CreateUser(t *testing.T) {
+	mux, s, auditRepo := newAuditedTestHandler(t)
+	expectAuthenticated(s)
+	s.EXPECT().CreateUser(mock.Anything, testOrgID, frontierscim.User{
+		UserName:   "john@acme.org",
+		GivenName:  "John",
+		FamilyName: "Doe",
+		Active:     true,
+	}).RunAndReturn(func(ctx context.Context, orgID string, u frontierscim.User) (frontierscim.User, error) {
+		// the service audits the change with the service user as the actor
+		err := audit.GetAuditor(ctx, orgID).Log(audit.UserCreatedEvent, audit.UserTarget("user-id"))
+		return frontierscim.User{ID: "user-id", UserName: "john@acme.org", GivenName: "John", FamilyName: "Doe", Active: true}, err
+	})
+	auditRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(l *audit.Log) bool {
+		return l.OrgID == testOrgID && l.Action == audit.UserCreatedEvent.String() &&
+			l.Actor.ID == "su-id" && l.Actor.Type == schema.ServiceUserPrincipal
+	})).Return(nil)
+
+	r := httptest.NewRequest(http.MethodPost, "http://frontier.example.com/scim/v2/acme/Users", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"externalId": "00u1",
+		"name": {"givenName": "John", "familyName": "Doe"},
+		"emails": [{"value": "john@acme.org", "primary": true}]
+	}`))
+	r.Header.Set("Authorization", testToken)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, r)
+
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://frontier.example.com/scim/v2/acme/Users/user-id", rec.Header().Get("Location"))
+	assert.Contains(t, rec.Body.String(), `"displayName":"John Doe"`)
+}
+
+func TestHandler_PatchGroup(t *testing.T) {
+	t.Run("should pass the operations to the service", func(t *testing.T) {
+		mux, s := newTestHandler(t)
+		expectAuthenticated(s)
+		s.EXPECT().PatchGroup(mock.Anything, testOrgID, "group-id", []frontierscim.PatchOperation{
+			{Op: "add", Path: "members", Value: []any{map[string]any{"value": "user-id"}}},
+		}).Return(frontierscim.Group{ID: "group-id", DisplayName: "Engineers", Members: []frontierscim.Member{{Value: "user-id"}}}, nil)
+
+		r := httptest.NewRequest(http.MethodPatch, "/scim/v2/acme/Groups/group-id?excludedAttributes=members", strings.NewReader(`{
+			"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+			"Operations": [{"op": "add", "path": "members", "value": [{"value": "user-id"}]}]
+		}`))
+		r.Header.Set("Authorization", testToken)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, r)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"members":[]`)
+	})
+
+	t.Run("should reject malformed requests", func(t *testing.T) {
+		mux, s := newTestHandler(t)
+		expectAuthenticated(s)
+
+		r := httptest.NewRequest(http.MethodPatch, "/scim/v2/acme/Groups/group-id", strings.NewReader(`{`))
+		r.Header.Set("Authorization", testToken)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, r)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"scimType":"invalidSyntax"`)
+	})
+}
+
+func TestHandler_DeleteUser(t *testing.T) {
+	mux, s := newTestHandler(t)
+	expectAuthenticated(s)
+	s.EXPECT().DeleteUser(mock.Anything, testOrgID, "user-id").Return(frontierscim.ErrLastAdmin)
+
+	r := httptest.NewRequest(http.MethodDelete, "/scim/v2/acme/Users/user-id", nil)
+	r.Header.Set("Authorization", testToken)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, r)
+
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
@@ -0,0 +1,254 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	ListPath      = "GET /sessions"
+	UpdatePath    = "PATCH /sessions/{id}"
+	RevokePath    = "DELETE /sessions/{id}"
+	RevokeAllPath = "DELETE /admin/users/{id}/sessions"
+
+	maxBodySize = 16 << 10
+)
+
+type SessionService interface {
+	ExtractFromContext(ctx context.Context) (*frontiersession.Session, error)
+	ListByUser(ctx context.Context, userID string) ([]*frontiersession.Session, error)
+	SetLabel(ctx context.Context, userID string, sessionID uuid.UUID, label string) (*frontiersession.Session, error)
+	DeleteForUser(ctx context.Context, userID string, sessionID uuid.UUID) error
+	RevokeAll(ctx context.Context, userID string) error
+}
+
+type UserService interface {
+	httputil.SudoService
+	GetByID(ctx context.Context, id string) (user.User, error)
+}
+
+// Handler serves the endpoints users see, label and revoke the sessions they
+// are logged in with on other devices, the admin endpoint logging a user out
+// everywhere and the tokens bound to sessions
+type Handler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	sessionService     SessionService
+	tokenService       TokenService
+	userService        UserService
+	serviceUserService httputil.SudoService
+	sessionDecoder     httputil.SessionDecoder
+	Now                func() time.Time
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, sessionService SessionService,
+	tokenService TokenService, userService UserService, serviceUserService httputil.SudoService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		sessionService:     sessionService,
+		tokenService:       tokenService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+		sessionDecoder:     sessionDecoder,
+		Now: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+}
+
+// Register mounts the endpoints users manage their sessions and tokens with
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(ListPath, h.List)
+	router.Handle(UpdatePath, h.Update)
+	router.Handle(RevokePath, h.Revoke)
+	router.Handle(RevokeAllPath, h.RevokeAll, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(TokenPath, h.Token)
+	router.Handle(RefreshTokenPath, h.RefreshToken)
+	router.Handle(RevokeTokenPath, h.RevokeToken)
+}
+
+type sessionResponse struct {
+	ID         string    `json:"id"`
+	Label      string    `json:"label,omitempty"`
+	Current    bool      `json:"current"`
+	State      string    `json:"state"`
+	AuthMethod string    `json:"auth_method"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type listResponse struct {
+	Sessions []sessionResponse `json:"sessions"`
+}
+
+// List returns the sessions of the logged in user which are not expired
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	ctx, current, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	sessions, err := h.sessionService.ListByUser(ctx, current.UserID)
+	if err != nil {
+		h.log.Error("failed to list sessions", "err", err)
+		httputil.WriteMessage(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	response := listResponse{Sessions: make([]sessionResponse, 0, len(sessions))}
+	for _, sess := range sessions {
+		response.Sessions = append(response.Sessions, toSessionResponse(sess, current))
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+func toSessionResponse(sess, current *frontiersession.Session) sessionResponse {
+	lastSeenAt := sess.LastSeenAt
+	if lastSeenAt.IsZero() {
+		lastSeenAt = sess.AuthenticatedAt
+	}
+	return sessionResponse{
+		ID:         sess.ID.String(),
+		Label:      sess.Label(),
+		Current:    sess.ID == current.ID,
+		State:      string(sess.State),
+		AuthMethod: sess.AuthMethod(),
+		UserAgent:  sess.UserAgent(),
+		IPAddress:  sess.IPAddress(),
+		CreatedAt:  sess.CreatedAt,
+		LastSeenAt: lastSeenAt,
+		ExpiresAt:  sess.ExpiresAt,
+	}
+}
+
+type updateRequest struct {
+	// Label names the session, an empty label removes the name
+	Label *string `json:"label"`
+}
+
+// Update names one of the sessions of the user so it can tell its devices
+// apart
+func (h *Handler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx, current, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusNotFound, frontiersession.ErrNoSession.Error())
+		return
+	}
+	var req updateRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.Label == nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "label is required")
+		return
+	}
+
+	sess, err := h.sessionService.SetLabel(ctx, current.UserID, sessionID, *req.Label)
+	if err != nil {
+		switch {
+		case errors.Is(err, frontiersession.ErrInvalidLabel):
+			httputil.WriteMessage(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, frontiersession.ErrNoSession):
+			httputil.WriteMessage(w, http.StatusNotFound, frontiersession.ErrNoSession.Error())
+		default:
+			h.log.Error("failed to label session", "err", err)
+			httputil.WriteMessage(w, http.StatusInternalServerError, "internal error")
+		}
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toSessionResponse(sess, current))
+}
+
+// Revoke logs the user out of one of its sessions
+func (h *Handler) Revoke(w http.ResponseWriter, r *http.Request) {
+	ctx, current, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		httputil.WriteMessage(w, http.StatusNotFound, frontiersession.ErrNoSession.Error())
+		return
+	}
+	if err = h.sessionService.DeleteForUser(ctx, current.UserID, sessionID); err != nil {
+		if errors.Is(err, frontiersession.ErrNoSession) {
+			httputil.WriteMessage(w, http.StatusNotFound, frontiersession.ErrNoSession.Error())
+			return
+		}
+		h.log.Error("failed to revoke session", "err", err)
+		httputil.WriteMessage(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAll logs a user out everywhere by deleting all of its sessions, it
+// is only available to superusers
+func (h *Handler) RevokeAll(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeAdminError(w, err)
+		return
+	}
+
+	// the user is looked up by id, email or name
+	sessionUser, err := h.userService.GetByID(ctx, r.PathValue("id"))
+	if err != nil {
+		h.writeAdminError(w, err)
+		return
+	}
+	if err := h.sessionService.RevokeAll(ctx, sessionUser.ID); err != nil {
+		h.writeAdminError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) writeAdminError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, user.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	default:
+		h.log.Error("failed to revoke sessions", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
+
+// session returns the active session of the request
+func (h *Handler) session(w http.ResponseWriter, r *http.Request) (context.Context, *frontiersession.Session, bool) {
+	ctx := h.sessionDecoder.RequestContext(r)
+	session, err := h.sessionService.ExtractFromContext(ctx)
+	if err != nil || !session.IsValid(h.Now()) {
+		if err != nil && !errors.Is(err, frontiersession.ErrNoSession) {
+			h.log.Error("failed to get session", "err", err)
+		}
+		httputil.WriteMessage(w, http.StatusUnauthorized, "not logged in")
+		return nil, nil, false
+	}
+	return ctx, session, true
+}
@@ -0,0 +1,281 @@
+package session
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/raystack/frontier/core/authenticate"
+	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/session/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/pkg/metadata"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	handlerNow       = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	currentSessionID = uuid.MustParse("6b8b4567-327b-4f6e-9a3c-2f1e5d4c3b2a")
+	otherSessionID   = uuid.MustParse("2e73f4a2-3763-4fc7-a8ad-d5e1d0b1a7e0")
+)
+
+type handlerMocks struct {
+	authn        *httpmocks.AuthnService
+	sessions     *mocks.SessionService
+	tokens       *mocks.TokenService
+	users        *mocks.UserService
+	serviceUsers *httpmocks.SudoService
+	decoder      *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:        httpmocks.NewAuthnService(t),
+		sessions:     mocks.NewSessionService(t),
+		tokens:       mocks.NewTokenService(t),
+		users:        mocks.NewUserService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+	}
+	h := NewHandler(log.NewNoop(), m.authn, m.sessions, m.tokens, m.users, m.serviceUsers, m.decoder)
+	h.Now = func() time.Time {
+		return handlerNow
+	}
+	mux := http.NewServeMux()
+	h.Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func currentSession() *frontiersession.Session {
+	return &frontiersession.Session{
+		ID:              currentSessionID,
+		UserID:          "user-id",
+		State:           frontiersession.StateActive,
+		AuthenticatedAt: handlerNow.Add(-time.Hour),
+		CreatedAt:       handlerNow.Add(-time.Hour),
+		LastSeenAt:      handlerNow.Add(-time.Minute),
+		ExpiresAt:       handlerNow.Add(time.Hour),
+		Metadata: metadata.Metadata{
+			frontiersession.MetadataAuthMethod: "mailotp",
+			frontiersession.MetadataUserAgent:  "Mozilla/5.0",
+			frontiersession.MetadataIPAddress:  "203.0.113.7",
+		},
+	}
+}
+
+func expectSession(m handlerMocks, session *frontiersession.Session) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.sessions.EXPECT().ExtractFromContext(mock.Anything).Return(session, nil)
+}
+
+func TestHandler_List(t *testing.T) {
+	t.Run("should list the sessions of the user", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		current := currentSession()
+		expectSession(m, current)
+		m.sessions.EXPECT().ListByUser(mock.Anything, "user-id").Return([]*frontiersession.Session{
+			current,
+			{
+				ID:              otherSessionID,
+				UserID:          "user-id",
+				State:           frontiersession.StateActive,
+				AuthenticatedAt: handlerNow.Add(-2 * time.Hour),
+				CreatedAt:       handlerNow.Add(-2 * time.Hour),
+				ExpiresAt:       handlerNow.Add(time.Hour),
+			},
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"sessions": [
+			{
+				"id": "6b8b4567-327b-4f6e-9a3c-2f1e5d4c3b2a",
+				"current": true,
+				"state": "active",
+				"auth_method": "mailotp",
+				"user_agent": "Mozilla/5.0",
+				"ip_address": "203.0.113.7",
+				"created_at": "2026-10-18T11:00:00Z",
+				"last_seen_at": "2026-10-18T11:59:00Z",
+				"expires_at": "2026-10-18T13:00:00Z"
+			},
+			{
+				"id": "2e73f4a2-3763-4fc7-a8ad-d5e1d0b1a7e0",
+				"current": false,
+				"state": "active",
+				"auth_method": "",
+				"user_agent": "",
+				"ip_address": "",
+				"created_at": "2026-10-18T10:00:00Z",
+				"last_seen_at": "2026-10-18T10:00:00Z",
+				"expires_at": "2026-10-18T13:00:00Z"
+			}
+		]}`, rec.Body.String())
+	})
+
+	t.Run("should reject sessions pending mfa", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		pending := currentSession()
+		pending.State = frontiersession.StateMFAPending
+		expectSession(m, pending)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_Revoke(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		setup  func(m handlerMocks)
+		status int
+	}{
+		{
+			name: "should revoke a session of the user",
+			path: "/sessions/" + otherSessionID.String(),
+			setup: func(m handlerMocks) {
+				expectSession(m, currentSession())
+				m.sessions.EXPECT().DeleteForUser(mock.Anything, "user-id", otherSessionID).Return(nil)
+			},
+			status: http.StatusNoContent,
+		},
+		{
+			name: "should return not found for sessions of other users",
+			path: "/sessions/" + otherSessionID.String(),
+			setup: func(m handlerMocks) {
+				expectSession(m, currentSession())
+				m.sessions.EXPECT().DeleteForUser(mock.Anything, "user-id", otherSessionID).Return(frontiersession.ErrNoSession)
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name: "should return not found for malformed ids",
+			path: "/sessions/not-a-uuid",
+			setup: func(m handlerMocks) {
+				expectSession(m, currentSession())
+			},
+			status: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux, m := newTestHandler(t)
+			tt.setup(m)
+
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+			assert.Equal(t, tt.status, rec.Code)
+		})
+	}
+}
+
+func TestHandler_Update(t *testing.T) {
+	t.Run("should label a session of the user", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		current := currentSession()
+		expectSession(m, current)
+		labeled := currentSession()
+		labeled.Metadata[frontiersession.MetadataLabel] = "work laptop"
+		m.sessions.EXPECT().SetLabel(mock.Anything, "user-id", currentSessionID, "work laptop").Return(labeled, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/sessions/"+currentSessionID.String(),
+			strings.NewReader(`{"label": "work laptop"}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"id": "6b8b4567-327b-4f6e-9a3c-2f1e5d4c3b2a",
+			"label": "work laptop",
+			"current": true,
+			"state": "active",
+			"auth_method": "mailotp",
+			"user_agent": "Mozilla/5.0",
+			"ip_address": "203.0.113.7",
+			"created_at": "2026-10-18T11:00:00Z",
+			"last_seen_at": "2026-10-18T11:59:00Z",
+			"expires_at": "2026-10-18T13:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should require the label", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectSession(m, currentSession())
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/sessions/"+currentSessionID.String(),
+			strings.NewReader(`{}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should reject labels which are too long", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectSession(m, currentSession())
+		m.sessions.EXPECT().SetLabel(mock.Anything, "user-id", otherSessionID, mock.Anything).Return(nil, frontiersession.ErrInvalidLabel)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/sessions/"+otherSessionID.String(),
+			strings.NewReader(`{"label": "`+strings.Repeat("a", 65)+`"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return not found for sessions of other users", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectSession(m, currentSession())
+		m.sessions.EXPECT().SetLabel(mock.Anything, "user-id", otherSessionID, "").Return(nil, frontiersession.ErrNoSession)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/sessions/"+otherSessionID.String(),
+			strings.NewReader(`{"label": ""}`)))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestHandler_RevokeAll(t *testing.T) {
+	admin := authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal}
+	expectAdmin := func(m handlerMocks, sudo bool) {
+		m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		m.authn.EXPECT().GetPrincipal(mock.Anything).Return(admin, nil)
+		m.users.EXPECT().IsSudo(mock.Anything, admin.ID, schema.PlatformSudoPermission).Return(sudo, nil)
+	}
+
+	t.Run("should revoke all the sessions of the user", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.users.EXPECT().GetByID(mock.Anything, "john@example.com").Return(user.User{ID: "user-id"}, nil)
+		m.sessions.EXPECT().RevokeAll(mock.Anything, "user-id").Return(nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/users/john@example.com/sessions", nil))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("should return not found for unknown users", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.users.EXPECT().GetByID(mock.Anything, "john").Return(user.User{}, user.ErrNotExist)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/users/john/sessions", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should forbid callers which are not superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/users/john/sessions", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
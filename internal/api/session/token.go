@@ -0,0 +1,112 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/internal/api/httputil"
+)
+
+const (
+	TokenPath        = "POST /sessions/token"
+	RefreshTokenPath = "POST /sessions/token/refresh"
+	RevokeTokenPath  = "POST /sessions/token/revoke"
+)
+
+// TokenService issues the access tokens of sessions along with refresh tokens
+// bound to them
+type TokenService interface {
+	IssueSessionToken(ctx context.Context, sessionID uuid.UUID) (frontieroauth2.Token, error)
+	RefreshSessionToken(ctx context.Context, refreshToken string) (frontieroauth2.Token, error)
+	RevokeSessionToken(ctx context.Context, token string) error
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type revokeTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// Token issues an access token to the user of the current session with a
+// refresh token, the refresh token keeps working as long as the session does
+func (h *Handler) Token(w http.ResponseWriter, r *http.Request) {
+	ctx, current, ok := h.session(w, r)
+	if !ok {
+		return
+	}
+	token, err := h.tokenService.IssueSessionToken(ctx, current.ID)
+	if err != nil {
+		h.writeTokenError(w, err)
+		return
+	}
+	writeToken(w, token)
+}
+
+// RefreshToken rotates a refresh token issued by Token, it needs no other
+// credentials
+func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	var req refreshTokenRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.RefreshToken == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "refresh_token is required")
+		return
+	}
+	token, err := h.tokenService.RefreshSessionToken(r.Context(), req.RefreshToken)
+	if err != nil {
+		h.writeTokenError(w, err)
+		return
+	}
+	writeToken(w, token)
+}
+
+// RevokeToken revokes a refresh token along with the tokens refreshed with
+// it, or an access token. Unknown tokens are ignored as per RFC 7009
+func (h *Handler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var req revokeTokenRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil || req.Token == "" {
+		httputil.WriteMessage(w, http.StatusBadRequest, "token is required")
+		return
+	}
+	if err := h.tokenService.RevokeSessionToken(r.Context(), req.Token); err != nil {
+		h.writeTokenError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeToken(w http.ResponseWriter, token frontieroauth2.Token) {
+	w.Header().Set("Cache-Control", "no-store")
+	httputil.WriteJSON(w, http.StatusOK, tokenResponse{
+		AccessToken:  token.AccessToken,
+		TokenType:    token.TokenType,
+		ExpiresIn:    int64(token.ExpiresIn.Seconds()),
+		RefreshToken: token.RefreshToken,
+	})
+}
+
+func (h *Handler) writeTokenError(w http.ResponseWriter, err error) {
+	var oauthErr *frontieroauth2.Error
+	if errors.As(err, &oauthErr) {
+		status := http.StatusBadRequest
+		if oauthErr.Code == frontieroauth2.ErrorCodeInvalidGrant {
+			status = http.StatusUnauthorized
+		}
+		httputil.WriteMessage(w, status, oauthErr.Description)
+		return
+	}
+	h.log.Error("failed to issue session token", "err", err)
+	httputil.WriteMessage(w, http.StatusInternalServerError, "internal error")
+}
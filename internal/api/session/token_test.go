@@ -0,0 +1,88 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	frontieroauth2 "github.com/raystack/frontier/core/oauth2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandler_Token(t *testing.T) {
+	t.Run("should issue a token with a refresh token bound to the session", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectSession(m, currentSession())
+		m.tokens.EXPECT().IssueSessionToken(mock.Anything, currentSessionID).Return(frontieroauth2.Token{
+			AccessToken:  "jwt",
+			TokenType:    frontieroauth2.TokenTypeBearer,
+			ExpiresIn:    time.Hour,
+			RefreshToken: "refresh-token",
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/token", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		assert.JSONEq(t, `{
+			"access_token": "jwt",
+			"token_type": "Bearer",
+			"expires_in": 3600,
+			"refresh_token": "refresh-token"
+		}`, rec.Body.String())
+	})
+
+	t.Run("should reject requests without a session", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expired := currentSession()
+		expired.ExpiresAt = handlerNow.Add(-time.Minute)
+		expectSession(m, expired)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/token", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_RefreshToken(t *testing.T) {
+	t.Run("should rotate the refresh token", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		m.tokens.EXPECT().RefreshSessionToken(mock.Anything, "refresh-token").Return(frontieroauth2.Token{
+			AccessToken:  "jwt",
+			TokenType:    frontieroauth2.TokenTypeBearer,
+			ExpiresIn:    time.Hour,
+			RefreshToken: "next-refresh-token",
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/token/refresh",
+			strings.NewReader(`{"refresh_token": "refresh-token"}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"refresh_token":"next-refresh-token"`)
+	})
+
+	t.Run("should reject invalid refresh tokens", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		m.tokens.EXPECT().RefreshSessionToken(mock.Anything, "reused").Return(frontieroauth2.Token{},
+			frontieroauth2.NewError(frontieroauth2.ErrorCodeInvalidGrant, "refresh token is invalid"))
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/token/refresh",
+			strings.NewReader(`{"refresh_token": "reused"}`)))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.JSONEq(t, `{"message": "refresh token is invalid"}`, rec.Body.String())
+	})
+}
+
+func TestHandler_RevokeToken(t *testing.T) {
+	mux, m := newTestHandler(t)
+	m.tokens.EXPECT().RevokeSessionToken(mock.Anything, "refresh-token").Return(nil)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/token/revoke",
+		strings.NewReader(`{"token": "refresh-token"}`)))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
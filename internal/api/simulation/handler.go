@@ -0,0 +1,266 @@
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/core/group"
+	"github.com/raystack/frontier/core/permission"
+	frontierpolicy "github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/role"
+	frontiersimulation "github.com/raystack/frontier/core/simulation"
+	"github.com/raystack/frontier/internal/api/httputil"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	SimulatePath = "POST /permissions/simulate"
+
+	maxBodySize = 64 << 10
+)
+
+type SimulationService interface {
+	Simulate(ctx context.Context, scope frontiersimulation.Scope, change frontiersimulation.Change) ([]frontiersimulation.AccessChange, error)
+}
+
+type PolicyService interface {
+	Get(ctx context.Context, id string) (frontierpolicy.Policy, error)
+}
+
+type RoleService interface {
+	Get(ctx context.Context, id string) (role.Role, error)
+}
+
+type ResourceService interface {
+	CheckAuthz(ctx context.Context, check resource.Check) (bool, error)
+}
+
+// Handler serves the endpoint simulating changes to policies and roles. The
+// caller needs the permissions the change itself would require on top of
+// managing the policies of the simulated resources.
+type Handler struct {
+	log               log.Logger
+	authenticator     *httputil.Authenticator
+	simulationService SimulationService
+	policyService     PolicyService
+	roleService       RoleService
+	resourceService   ResourceService
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, simulationService SimulationService,
+	policyService PolicyService, roleService RoleService, resourceService ResourceService,
+	sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:               logger,
+		authenticator:     httputil.NewAuthenticator(authnService, sessionDecoder),
+		simulationService: simulationService,
+		policyService:     policyService,
+		roleService:       roleService,
+		resourceService:   resourceService,
+	}
+}
+
+// Register mounts the access change simulation endpoint
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(SimulatePath, h.Simulate, httputil.WithScope(authenticate.ScopeRead))
+}
+
+type policyRequest struct {
+	RoleID    string    `json:"role_id"`
+	Resource  string    `json:"resource"`
+	Principal string    `json:"principal"`
+	StartsAt  time.Time `json:"starts_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type roleChangeRequest struct {
+	RoleID            string   `json:"role_id"`
+	AddPermissions    []string `json:"add_permissions"`
+	RemovePermissions []string `json:"remove_permissions"`
+}
+
+type simulateRequest struct {
+	Resources      []string            `json:"resources"`
+	Permissions    []string            `json:"permissions"`
+	AddPolicies    []policyRequest     `json:"add_policies"`
+	RemovePolicies []string            `json:"remove_policies"`
+	RoleChanges    []roleChangeRequest `json:"role_changes"`
+}
+
+type accessChangeResponse struct {
+	Principal string   `json:"principal"`
+	Resource  string   `json:"resource"`
+	Before    []string `json:"before"`
+	After     []string `json:"after"`
+	Gained    []string `json:"gained"`
+	Lost      []string `json:"lost"`
+}
+
+type simulateResponse struct {
+	Changes []accessChangeResponse `json:"changes"`
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// Simulate returns how the permissions of principals on the resources would
+// change if the policies and roles were changed, nothing is changed
+func (h *Handler) Simulate(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	var req simulateRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil {
+		httputil.WriteMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(req.Resources) == 0 || len(req.Resources) > frontiersimulation.MaxResources {
+		httputil.WriteMessage(w, http.StatusBadRequest,
+			fmt.Sprintf("between 1 and %d resources are required", frontiersimulation.MaxResources))
+		return
+	}
+
+	scope := frontiersimulation.Scope{Permissions: req.Permissions}
+	for _, raw := range req.Resources {
+		namespace, id, err := schema.SplitNamespaceAndResourceID(raw)
+		if err != nil {
+			httputil.WriteMessage(w, http.StatusBadRequest, "resources must be namespaced ids, e.g. app/project:<id>")
+			return
+		}
+		object := relation.Object{ID: id, Namespace: namespace}
+		if err := httputil.Authorize(ctx, h.resourceService, principal, object, policyPermission(namespace)); err != nil {
+			h.writeError(w, err)
+			return
+		}
+		scope.Resources = append(scope.Resources, object)
+	}
+
+	change := frontiersimulation.Change{RemovePolicies: req.RemovePolicies}
+	for _, p := range req.AddPolicies {
+		resourceType, resourceID, err := schema.SplitNamespaceAndResourceID(p.Resource)
+		if err != nil {
+			httputil.WriteMessage(w, http.StatusBadRequest, "policy resource must be a namespaced id")
+			return
+		}
+		principalType, principalID, err := schema.SplitNamespaceAndResourceID(p.Principal)
+		if err != nil {
+			httputil.WriteMessage(w, http.StatusBadRequest, "policy principal must be a namespaced id")
+			return
+		}
+		if err := httputil.Authorize(ctx, h.resourceService, principal, relation.Object{ID: resourceID, Namespace: resourceType}, policyPermission(resourceType)); err != nil {
+			h.writeError(w, err)
+			return
+		}
+		change.AddPolicies = append(change.AddPolicies, frontierpolicy.Policy{
+			RoleID:        p.RoleID,
+			ResourceID:    resourceID,
+			ResourceType:  resourceType,
+			PrincipalID:   principalID,
+			PrincipalType: principalType,
+			StartsAt:      p.StartsAt,
+			ExpiresAt:     p.ExpiresAt,
+		})
+	}
+	for _, id := range req.RemovePolicies {
+		pol, err := h.policyService.Get(ctx, id)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		if err := httputil.Authorize(ctx, h.resourceService, principal, relation.Object{ID: pol.ResourceID, Namespace: pol.ResourceType}, policyPermission(pol.ResourceType)); err != nil {
+			h.writeError(w, err)
+			return
+		}
+	}
+	for _, rc := range req.RoleChanges {
+		changedRole, err := h.roleService.Get(ctx, rc.RoleID)
+		if err != nil {
+			h.writeError(w, err)
+			return
+		}
+		// only superusers manage the roles shared by every organization
+		object := relation.Object{ID: changedRole.OrgID, Namespace: schema.OrganizationNamespace}
+		rolePermission := schema.RoleManagePermission
+		if changedRole.OrgID == "" || changedRole.OrgID == schema.PlatformOrgID.String() {
+			object = relation.Object{ID: schema.PlatformID, Namespace: schema.PlatformNamespace}
+			rolePermission = schema.PlatformSudoPermission
+		}
+		if err := httputil.Authorize(ctx, h.resourceService, principal, object, rolePermission); err != nil {
+			h.writeError(w, err)
+			return
+		}
+		change.Roles = append(change.Roles, frontiersimulation.RoleChange{
+			RoleID:            changedRole.ID,
+			AddPermissions:    rc.AddPermissions,
+			RemovePermissions: rc.RemovePermissions,
+		})
+	}
+
+	changes, err := h.simulationService.Simulate(ctx, scope, change)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	response := simulateResponse{Changes: make([]accessChangeResponse, 0, len(changes))}
+	for _, c := range changes {
+		response.Changes = append(response.Changes, accessChangeResponse{
+			Principal: schema.JoinNamespaceAndResourceID(c.Principal.Namespace, c.Principal.ID),
+			Resource:  schema.JoinNamespaceAndResourceID(c.Resource.Namespace, c.Resource.ID),
+			Before:    nonNil(c.Before),
+			After:     nonNil(c.After),
+			Gained:    nonNil(c.Gained),
+			Lost:      nonNil(c.Lost),
+		})
+	}
+	httputil.WriteJSON(w, http.StatusOK, response)
+}
+
+// policyPermission is the permission managing the policies of a resource,
+// like in the policy apis
+func policyPermission(namespace string) string {
+	switch namespace {
+	case schema.OrganizationNamespace, schema.ProjectNamespace:
+		return schema.PolicyManagePermission
+	case schema.GroupNamespace:
+		return group.AdminPermission
+	}
+	return schema.DeletePermission
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontiersimulation.ErrInvalidDetail), errors.Is(err, relation.ErrInvalidDetail),
+		errors.Is(err, frontierpolicy.ErrInvalidDetail), errors.Is(err, role.ErrInvalidID),
+		errors.Is(err, frontierpolicy.ErrInvalidUUID), errors.Is(err, frontierpolicy.ErrInvalidID),
+		errors.Is(err, httputil.ErrResourceRequired):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, frontierpolicy.ErrNotExist), errors.Is(err, role.ErrNotExist),
+		errors.Is(err, permission.ErrNotExist):
+		status = http.StatusNotFound
+	case errors.Is(err, frontiersimulation.ErrTooLarge), errors.Is(err, relation.ErrSandboxTooLarge):
+		status = http.StatusUnprocessableEntity
+	default:
+		h.log.Error("access change simulation failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,161 @@
+package simulation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontierpolicy "github.com/raystack/frontier/core/policy"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/core/resource"
+	"github.com/raystack/frontier/core/role"
+	frontiersimulation "github.com/raystack/frontier/core/simulation"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/simulation/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	admin   = authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal}
+	project = relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}
+)
+
+type handlerMocks struct {
+	authn       *httpmocks.AuthnService
+	simulations *mocks.SimulationService
+	policies    *mocks.PolicyService
+	roles       *mocks.RoleService
+	resources   *mocks.ResourceService
+	decoder     *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:       httpmocks.NewAuthnService(t),
+		simulations: mocks.NewSimulationService(t),
+		policies:    mocks.NewPolicyService(t),
+		roles:       mocks.NewRoleService(t),
+		resources:   mocks.NewResourceService(t),
+		decoder:     httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.simulations, m.policies, m.roles, m.resources, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectPrincipal(m handlerMocks, principal authenticate.Principal, err error) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(principal, err)
+}
+
+func expectAuthz(m handlerMocks, object relation.Object, permission string, allowed bool) {
+	m.resources.EXPECT().CheckAuthz(mock.Anything, resource.Check{
+		Object:     object,
+		Subject:    relation.Subject{ID: admin.ID, Namespace: admin.Type},
+		Permission: permission,
+	}).Return(allowed, nil)
+}
+
+func TestHandler_Simulate(t *testing.T) {
+	t.Run("should simulate deleting a policy of the resource", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		expectAuthz(m, project, schema.PolicyManagePermission, true)
+		m.policies.EXPECT().Get(mock.Anything, "policy-id").Return(frontierpolicy.Policy{
+			ID:           "policy-id",
+			ResourceID:   "project-id",
+			ResourceType: schema.ProjectNamespace,
+		}, nil)
+		m.simulations.EXPECT().Simulate(mock.Anything, frontiersimulation.Scope{
+			Resources:   []relation.Object{project},
+			Permissions: []string{"get"},
+		}, frontiersimulation.Change{RemovePolicies: []string{"policy-id"}}).Return([]frontiersimulation.AccessChange{{
+			Principal: relation.Subject{ID: "user-id", Namespace: schema.UserPrincipal},
+			Resource:  project,
+			Before:    []string{"get"},
+			Lost:      []string{"get"},
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/permissions/simulate", strings.NewReader(`{
+			"resources": ["app/project:project-id"],
+			"permissions": ["get"],
+			"remove_policies": ["policy-id"]
+		}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"changes": [{
+			"principal": "app/user:user-id",
+			"resource": "app/project:project-id",
+			"before": ["get"],
+			"after": [],
+			"gained": [],
+			"lost": ["get"]
+		}]}`, rec.Body.String())
+	})
+
+	t.Run("should require managing the policies of the resources", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		expectAuthz(m, project, schema.PolicyManagePermission, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/permissions/simulate", strings.NewReader(`{
+			"resources": ["app/project:project-id"]
+		}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should require superusers to change platform roles", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		expectAuthz(m, project, schema.PolicyManagePermission, true)
+		m.roles.EXPECT().Get(mock.Anything, schema.RoleProjectViewer).Return(role.Role{
+			ID:    "role-id",
+			OrgID: schema.PlatformOrgID.String(),
+		}, nil)
+		expectAuthz(m, relation.Object{ID: schema.PlatformID, Namespace: schema.PlatformNamespace}, schema.PlatformSudoPermission, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/permissions/simulate", strings.NewReader(`{
+			"resources": ["app/project:project-id"],
+			"role_changes": [{"role_id": "app_project_viewer", "add_permissions": ["app_project_delete"]}]
+		}`)))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should check the organization of custom roles", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+		expectAuthz(m, project, schema.PolicyManagePermission, true)
+		m.roles.EXPECT().Get(mock.Anything, "role-id").Return(role.Role{ID: "role-id", OrgID: "org-id"}, nil)
+		expectAuthz(m, relation.Object{ID: "org-id", Namespace: schema.OrganizationNamespace}, schema.RoleManagePermission, true)
+		m.simulations.EXPECT().Simulate(mock.Anything, mock.Anything, frontiersimulation.Change{
+			Roles: []frontiersimulation.RoleChange{{RoleID: "role-id", AddPermissions: []string{"app_project_delete"}}},
+		}).Return(nil, frontiersimulation.ErrTooLarge)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/permissions/simulate", strings.NewReader(`{
+			"resources": ["app/project:project-id"],
+			"role_changes": [{"role_id": "role-id", "add_permissions": ["app_project_delete"]}]
+		}`)))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	})
+
+	t.Run("should reject malformed resources", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectPrincipal(m, admin, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/permissions/simulate", strings.NewReader(`{
+			"resources": ["project-id"]
+		}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/raystack/frontier/core/authenticate/token"
 
+	"github.com/raystack/frontier/core/idp"
 	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/passkey"
 
 	"github.com/raystack/frontier/core/relation"
 
@@ -26,12 +29,15 @@ import (
 	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/raystack/frontier/core/authenticate"
 	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/ratelimit"
 	"github.com/raystack/frontier/core/user"
 	"github.com/raystack/frontier/pkg/errors"
+	pkgMetadata "github.com/raystack/frontier/pkg/metadata"
 	frontierv1beta1 "github.com/raystack/frontier/proto/v1beta1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -47,9 +53,13 @@ type AuthnService interface {
 	SanitizeCallbackURL(url string) string
 }
 
+type MFAService interface {
+	CreateSession(ctx context.Context, userID string, metadata pkgMetadata.Metadata) (*frontiersession.Session, error)
+}
+
 type SessionService interface {
 	ExtractFromContext(ctx context.Context) (*frontiersession.Session, error)
-	Create(ctx context.Context, userID string) (*frontiersession.Session, error)
+	Create(ctx context.Context, userID string, metadata pkgMetadata.Metadata) (*frontiersession.Session, error)
 	Delete(ctx context.Context, sessionID uuid.UUID) error
 	Refresh(ctx context.Context, sessionID uuid.UUID) error
 }
@@ -58,9 +68,10 @@ func (h Handler) Authenticate(ctx context.Context, request *frontierv1beta1.Auth
 	returnToURL := h.authnService.SanitizeReturnToURL(request.GetReturnTo())
 	callbackURL := h.authnService.SanitizeCallbackURL(request.GetCallbackUrl())
 
-	// check if user is already logged in
+	// check if user is already logged in, logged in users naming a strategy
+	// authenticate again e.g. to run operations asking for a recent login
 	session, err := h.sessionService.ExtractFromContext(ctx)
-	if err == nil && session.IsValid(time.Now().UTC()) {
+	if err == nil && session.IsValid(time.Now().UTC()) && request.GetStrategyName() == "" {
 		// already logged in, set location header for return to?
 		if len(returnToURL) != 0 {
 			if err = setRedirectHeaders(ctx, returnToURL); err != nil {
@@ -72,7 +83,8 @@ func (h Handler) Authenticate(ctx context.Context, request *frontierv1beta1.Auth
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	if (request.GetStrategyName() == authenticate.MailLinkAuthMethod.String() || request.GetStrategyName() == authenticate.MailOTPAuthMethod.String()) && !isValidEmail(request.GetEmail()) {
+	if (request.GetStrategyName() == authenticate.MailLinkAuthMethod.String() || request.GetStrategyName() == authenticate.MailOTPAuthMethod.String() ||
+		request.GetStrategyName() == authenticate.SSOAuthMethod.String()) && !isValidEmail(request.GetEmail()) {
 		return nil, status.Error(codes.InvalidArgument, "Invalid email")
 	}
 
@@ -82,8 +94,17 @@ func (h Handler) Authenticate(ctx context.Context, request *frontierv1beta1.Auth
 		ReturnToURL: returnToURL,
 		CallbackUrl: callbackURL,
 		Email:       request.GetEmail(),
+		ClientIP:    h.clientIP(ctx),
 	})
 	if err != nil {
+		var limitErr ratelimit.LimitError
+		if errors.As(err, &limitErr) {
+			return nil, limitExceededError(ctx, limitErr)
+		}
+		if errors.Is(err, passkey.ErrNotRegistered) || errors.Is(err, authenticate.ErrSSORequired) ||
+			errors.Is(err, authenticate.ErrSSONotConfigured) || errors.Is(err, idp.ErrAmbiguousDomain) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -137,16 +158,30 @@ func (h Handler) AuthCallback(ctx context.Context, request *frontierv1beta1.Auth
 		Code:        request.GetCode(),
 		State:       request.GetState(),
 		StateConfig: request.GetStateOptions().AsMap(),
+		ClientIP:    h.clientIP(ctx),
 	})
 	if err != nil {
-		if errors.Is(err, authenticate.ErrInvalidMailOTP) || errors.Is(err, authenticate.ErrMissingOIDCCode) || errors.Is(err, authenticate.ErrInvalidOIDCState) {
+		var limitErr ratelimit.LimitError
+		if errors.As(err, &limitErr) {
+			return nil, limitExceededError(ctx, limitErr)
+		}
+		if errors.Is(err, authenticate.ErrInvalidMailOTP) || errors.Is(err, authenticate.ErrMissingOIDCCode) || errors.Is(err, authenticate.ErrInvalidOIDCState) ||
+			errors.Is(err, authenticate.ErrInvalidPassword) {
 			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
+		if errors.Is(err, passkey.ErrNotRegistered) || errors.Is(err, passkey.ErrCloned) ||
+			errors.Is(err, authenticate.ErrSSORequired) || errors.Is(err, authenticate.ErrSSONotConfigured) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		if errors.Is(err, authenticate.ErrSSODomainMismatch) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
-	// registration/login complete, build a session
-	session, err := h.sessionService.Create(ctx, response.User.ID)
+	// registration/login complete, build a session, it stays pending if the
+	// user has to verify a second factor
+	session, err := h.mfaService.CreateSession(ctx, response.User.ID, h.sessionMetadata(ctx, request.GetStrategyName()))
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -154,6 +189,12 @@ func (h Handler) AuthCallback(ctx context.Context, request *frontierv1beta1.Auth
 	if err = setCookieHeaders(ctx, session.ID.String()); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
+	// the session of a user who logged in again is replaced by the new one
+	if previous, err := h.sessionService.ExtractFromContext(ctx); err == nil && previous.UserID == response.User.ID {
+		if err = h.sessionService.Delete(ctx, previous.ID); err != nil {
+			logger.Warn("failed to delete replaced session", zap.Error(err))
+		}
+	}
 
 	// set location header for redirect to finish auth and send client to origin
 	if len(response.Flow.FinishURL) > 0 {
@@ -259,6 +300,20 @@ func (h Handler) getLoggedInSessionID(ctx context.Context) (uuid.UUID, error) {
 	return uuid.Nil, err
 }
 
+// CheckRecentAuthentication asks the user to log in again if it did so longer
+// than maxAge ago
+func (h Handler) CheckRecentAuthentication(ctx context.Context, principal authenticate.Principal, maxAge time.Duration) error {
+	err := principal.CheckRecentAuthentication(maxAge, time.Now())
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, authenticate.ErrReauthRequired):
+		return reauthRequiredError(maxAge, h.authnService.SupportedStrategies())
+	default:
+		return grpcPermissionDenied
+	}
+}
+
 func (h Handler) GetLoggedInPrincipal(ctx context.Context, via ...authenticate.ClientAssertion) (authenticate.Principal, error) {
 	principal, err := h.authnService.GetPrincipal(ctx, via...)
 	if err != nil {
@@ -267,6 +322,8 @@ func (h Handler) GetLoggedInPrincipal(ctx context.Context, via ...authenticate.C
 			return principal, grpcUserNotFoundError
 		case errors.Is(err, errors.ErrUnauthenticated):
 			return principal, grpcUnauthenticated
+		case errors.Is(err, authenticate.ErrMFARequired):
+			return principal, grpcMFARequiredError
 		default:
 			return principal, err
 		}
@@ -317,6 +374,47 @@ func (h Handler) getAccessToken(ctx context.Context, principal authenticate.Prin
 	return h.authnService.BuildToken(ctx, principal, customClaims)
 }
 
+// sessionMetadata describes the client a user logs in from
+func (h Handler) sessionMetadata(ctx context.Context, authMethod string) pkgMetadata.Metadata {
+	sessionMetadata := pkgMetadata.Metadata{
+		frontiersession.MetadataAuthMethod: authMethod,
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	// requests served by the gateway carry the user agent of the browser
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if userAgent := md.Get(key); len(userAgent) > 0 {
+			sessionMetadata[frontiersession.MetadataUserAgent] = userAgent[0]
+			break
+		}
+	}
+
+	if ip := h.clientIP(ctx); ip != "" {
+		sessionMetadata[frontiersession.MetadataIPAddress] = ip
+	}
+	return sessionMetadata
+}
+
+// clientIP is the address of the client the request came from, through
+// the gateway or trusted proxies
+func (h Handler) clientIP(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok {
+		remoteAddr = p.Addr.String()
+	}
+	return h.proxies.ClientIP(strings.Join(md.Get("x-forwarded-for"), ","), remoteAddr)
+}
+
+// limitExceededError tells the client when to retry in the retry-after header,
+// the status is returned even if the header can't be set
+func limitExceededError(ctx context.Context, limitErr ratelimit.LimitError) error {
+	retryAfter := strconv.Itoa(int(limitErr.RetryAfter.Seconds()))
+	if err := grpc.SetHeader(ctx, metadata.Pairs(consts.RetryAfterRequestKey, retryAfter)); err != nil {
+		grpczap.Extract(ctx).Warn("failed to set retry-after header", zap.Error(err))
+	}
+	return status.Error(codes.ResourceExhausted, limitErr.Error())
+}
+
 func setRedirectHeaders(ctx context.Context, url string) error {
 	return grpc.SetHeader(ctx, metadata.Pairs(consts.LocationGatewayKey, url))
 }
@@ -7,11 +7,14 @@ import (
 
 	"github.com/raystack/frontier/core/authenticate"
 	frontiersession "github.com/raystack/frontier/core/authenticate/session"
+	"github.com/raystack/frontier/core/ratelimit"
 	"github.com/raystack/frontier/internal/api/v1beta1/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
 	"github.com/raystack/frontier/pkg/errors"
 	frontierv1beta1 "github.com/raystack/frontier/proto/v1beta1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -89,6 +92,29 @@ func TestHandler_Authenticate(t *testing.T) {
 				State:    "",
 			},
 		},
+		{
+			name: "should start a flow to log in again if a strategy is named",
+			setup: func(authn *mocks.AuthnService, session *mocks.SessionService) {
+				authn.EXPECT().SanitizeReturnToURL("").Return("")
+				authn.EXPECT().SanitizeCallbackURL("").Return("")
+				session.EXPECT().ExtractFromContext(mock.AnythingOfType("context.backgroundCtx")).Return(&frontiersession.Session{
+					ExpiresAt:       time.Now().Add(1 * time.Hour),
+					AuthenticatedAt: time.Now().Add(-24 * time.Hour),
+				}, nil)
+				authn.EXPECT().StartFlow(mock.AnythingOfType("context.backgroundCtx"), authenticate.RegistrationStartRequest{
+					Email:  "frontier@raystack.org",
+					Method: authenticate.MailOTPAuthMethod.String(),
+				}).Return(&authenticate.RegistrationStartResponse{
+					Flow: &authenticate.Flow{},
+				}, nil)
+			},
+			request: &frontierv1beta1.AuthenticateRequest{
+				StrategyName: authenticate.MailOTPAuthMethod.String(),
+				Email:        "frontier@raystack.org",
+			},
+			wantErr: nil,
+			want:    &frontierv1beta1.AuthenticateResponse{},
+		},
 		{
 			name: "should throw error if email is invalid in mailotp",
 			setup: func(authn *mocks.AuthnService, session *mocks.SessionService) {
@@ -114,6 +140,27 @@ func TestHandler_Authenticate(t *testing.T) {
 			wantErr: status.Error(codes.InvalidArgument, "Invalid email"),
 			want:    nil,
 		},
+		{
+			name: "should return resource exhausted if the flow is rate limited",
+			setup: func(authn *mocks.AuthnService, session *mocks.SessionService) {
+				authn.EXPECT().SanitizeReturnToURL("").Return("")
+				authn.EXPECT().SanitizeCallbackURL("").Return("")
+				session.EXPECT().ExtractFromContext(mock.AnythingOfType("context.backgroundCtx")).Return(nil, frontiersession.ErrNoSession)
+				authn.EXPECT().StartFlow(mock.AnythingOfType("context.backgroundCtx"), authenticate.RegistrationStartRequest{
+					Email:  "frontier@raystack.org",
+					Method: authenticate.MailOTPAuthMethod.String(),
+				}).Return(nil, ratelimit.LimitError{
+					Key:        "start:email:frontier@raystack.org",
+					RetryAfter: time.Minute,
+				})
+			},
+			request: &frontierv1beta1.AuthenticateRequest{
+				StrategyName: authenticate.MailOTPAuthMethod.String(),
+				Email:        "frontier@raystack.org",
+			},
+			wantErr: status.Error(codes.ResourceExhausted, "rate limit exceeded, retry after 1m0s"),
+			want:    nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,3 +177,82 @@ func TestHandler_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_CheckRecentAuthentication(t *testing.T) {
+	tests := []struct {
+		name       string
+		principal  authenticate.Principal
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{
+			name: "should pass users who logged in recently",
+			principal: authenticate.Principal{
+				ID:              "user-id",
+				Type:            schema.UserPrincipal,
+				AuthenticatedAt: time.Now().Add(-time.Minute),
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "should pass service users",
+			principal: authenticate.Principal{
+				ID:   "serviceuser-id",
+				Type: schema.ServiceUserPrincipal,
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "should ask users who logged in earlier to log in again",
+			principal: authenticate.Principal{
+				ID:              "user-id",
+				Type:            schema.UserPrincipal,
+				AuthenticatedAt: time.Now().Add(-time.Hour),
+			},
+			wantCode:   codes.Unauthenticated,
+			wantReason: ReauthRequiredReason,
+		},
+		{
+			name: "should ask users without a login time to log in again",
+			principal: authenticate.Principal{
+				ID:   "user-id",
+				Type: schema.UserPrincipal,
+			},
+			wantCode:   codes.Unauthenticated,
+			wantReason: ReauthRequiredReason,
+		},
+		{
+			name: "should reject impersonated users",
+			principal: authenticate.Principal{
+				ID:              "user-id",
+				Type:            schema.UserPrincipal,
+				AuthenticatedAt: time.Now(),
+				ImpersonatedBy:  &authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal},
+			},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthnSrv := mocks.NewAuthnService(t)
+			if tt.wantReason != "" {
+				mockAuthnSrv.EXPECT().SupportedStrategies().Return([]string{"google", "mailotp"})
+			}
+			h := Handler{authnService: mockAuthnSrv}
+
+			err := h.CheckRecentAuthentication(context.Background(), tt.principal, 15*time.Minute)
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+			if tt.wantReason != "" {
+				assert.Len(t, st.Details(), 1)
+				info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+				assert.True(t, ok)
+				assert.Equal(t, tt.wantReason, info.GetReason())
+				assert.Equal(t, map[string]string{
+					"max_auth_age": "900",
+					"strategies":   "google,mailotp",
+				}, info.GetMetadata())
+			}
+		})
+	}
+}
@@ -1,11 +1,24 @@
 package v1beta1
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
 	"github.com/raystack/frontier/pkg/errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// ReauthRequiredReason tells clients to run a login flow again with any
+	// of the listed strategies before retrying the request
+	ReauthRequiredReason = "REAUTHENTICATION_REQUIRED"
+	errorDomain          = "frontier"
+)
+
 // HTTP Codes defined here:
 // https://github.com/grpc-ecosystem/grpc-gateway/blob/master/runtime/errors.go#L36
 var (
@@ -21,6 +34,7 @@ var (
 	grpcBadBodyError           = status.Error(codes.InvalidArgument, ErrBadRequest.Error())
 	grpcBadBodyMetaSchemaError = status.Error(codes.InvalidArgument, ErrBadRequest.Error()+" : "+ErrInvalidMetadata.Error())
 	grpcUnauthenticated        = status.Error(codes.Unauthenticated, errors.ErrUnauthenticated.Error())
+	grpcMFARequiredError       = status.Error(codes.Unauthenticated, authenticate.ErrMFARequired.Error())
 	grpcPermissionDenied       = status.Error(codes.PermissionDenied, errors.ErrForbidden.Error())
 	grpcOperationUnsupported   = status.Error(codes.Unavailable, ErrOperationUnsupported.Error()) //nolint:unused
 )
@@ -28,3 +42,21 @@ var (
 func ErrInvalidInput(err string) error {
 	return status.Errorf(codes.InvalidArgument, err)
 }
+
+// reauthRequiredError is returned for operations asking for a login more
+// recent than maxAge, the details hold what the client needs to log in again
+func reauthRequiredError(maxAge time.Duration, strategies []string) error {
+	st := status.New(codes.Unauthenticated, authenticate.ErrReauthRequired.Error())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: ReauthRequiredReason,
+		Domain: errorDomain,
+		Metadata: map[string]string{
+			"max_auth_age": strconv.Itoa(int(maxAge.Seconds())),
+			"strategies":   strings.Join(strategies, ","),
+		},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -3,6 +3,7 @@ package v1beta1
 import (
 	"github.com/raystack/frontier/core/authenticate"
 	"github.com/raystack/frontier/internal/api"
+	"github.com/raystack/frontier/pkg/utils"
 	frontierv1beta1 "github.com/raystack/frontier/proto/v1beta1"
 	"google.golang.org/grpc"
 )
@@ -12,6 +13,7 @@ type Handler struct {
 	frontierv1beta1.UnimplementedAdminServiceServer
 
 	authConfig          authenticate.Config
+	proxies             utils.Proxies
 	orgService          OrganizationService
 	orgKycService       KycService
 	projectService      ProjectService
@@ -25,6 +27,7 @@ type Handler struct {
 	resourceService     ResourceService
 	sessionService      SessionService
 	authnService        AuthnService
+	mfaService          MFAService
 	deleterService      CascadeDeleter
 	metaSchemaService   MetaSchemaService
 	bootstrapService    BootstrapService
@@ -46,9 +49,12 @@ type Handler struct {
 	eventService        EventService
 }
 
-func Register(s *grpc.Server, deps api.Deps, authConf authenticate.Config) {
+// Register serves the handler on the grpc server, the proxies are trusted to
+// forward the address of clients
+func Register(s *grpc.Server, deps api.Deps, authConf authenticate.Config, proxies utils.Proxies) {
 	handler := &Handler{
 		authConfig:          authConf,
+		proxies:             proxies,
 		orgService:          deps.OrgService,
 		orgKycService:       deps.OrgKycService,
 		projectService:      deps.ProjectService,
@@ -62,6 +68,7 @@ func Register(s *grpc.Server, deps api.Deps, authConf authenticate.Config) {
 		resourceService:     deps.ResourceService,
 		sessionService:      deps.SessionService,
 		authnService:        deps.AuthnService,
+		mfaService:          deps.MFAService,
 		deleterService:      deps.DeleterService,
 		metaSchemaService:   deps.MetaSchemaService,
 		bootstrapService:    deps.BootstrapService,
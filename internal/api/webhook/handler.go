@@ -0,0 +1,272 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontierwebhook "github.com/raystack/frontier/core/webhook"
+	"github.com/raystack/frontier/internal/api/httputil"
+	frontiererrors "github.com/raystack/frontier/pkg/errors"
+	"github.com/raystack/frontier/pkg/utils"
+	"github.com/raystack/salt/log"
+)
+
+const (
+	ListDeliveriesPath = "GET /admin/webhooks/deliveries"
+	RedeliverPath      = "POST /admin/webhooks/events/{id}/redeliver"
+	RotateSecretPath   = "POST /admin/webhooks/{id}/secrets/rotate"
+
+	maxBodySize = 16 << 10
+)
+
+type WebhookService interface {
+	ListDeliveries(ctx context.Context, filter frontierwebhook.DeliveryFilter) ([]frontierwebhook.Delivery, error)
+	Redeliver(ctx context.Context, eventID string) ([]frontierwebhook.Delivery, error)
+	RotateSecret(ctx context.Context, id string, overlap time.Duration) (frontierwebhook.Secret, error)
+}
+
+// Handler serves the admin endpoints inspecting and retrying the deliveries
+// of webhook events and rotating the secrets of endpoints, they are only
+// available to superusers
+type Handler struct {
+	log                log.Logger
+	authenticator      *httputil.Authenticator
+	webhookService     WebhookService
+	userService        httputil.SudoService
+	serviceUserService httputil.SudoService
+}
+
+func NewHandler(logger log.Logger, authnService httputil.AuthnService, webhookService WebhookService,
+	userService httputil.SudoService, serviceUserService httputil.SudoService, sessionDecoder httputil.SessionDecoder) *Handler {
+	return &Handler{
+		log:                logger,
+		authenticator:      httputil.NewAuthenticator(authnService, sessionDecoder),
+		webhookService:     webhookService,
+		userService:        userService,
+		serviceUserService: serviceUserService,
+	}
+}
+
+// Register mounts the webhook delivery and secret rotation endpoints
+func (h *Handler) Register(router *httputil.Router) {
+	router.Handle(ListDeliveriesPath, h.ListDeliveries, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(RedeliverPath, h.Redeliver, httputil.WithScope(authenticate.ScopeAdmin))
+	router.Handle(RotateSecretPath, h.RotateSecret, httputil.WithScope(authenticate.ScopeAdmin))
+}
+
+type attemptResponse struct {
+	ID         string    `json:"id"`
+	StatusCode int       `json:"status_code"`
+	LatencyMs  int64     `json:"latency_ms"`
+	Response   string    `json:"response,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type deliveryResponse struct {
+	ID            string            `json:"id"`
+	EventID       string            `json:"event_id"`
+	EndpointID    string            `json:"endpoint_id"`
+	Action        string            `json:"action"`
+	State         string            `json:"state"`
+	Attempts      int               `json:"attempts"`
+	NextAttemptAt time.Time         `json:"next_attempt_at"`
+	LastError     string            `json:"last_error,omitempty"`
+	RequestID     string            `json:"request_id,omitempty"`
+	History       []attemptResponse `json:"history"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+type deliveriesResponse struct {
+	Deliveries []deliveryResponse `json:"deliveries"`
+}
+
+func toDeliveriesResponse(deliveries []frontierwebhook.Delivery) deliveriesResponse {
+	response := deliveriesResponse{Deliveries: make([]deliveryResponse, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		history := make([]attemptResponse, 0, len(delivery.History))
+		for _, attempt := range delivery.History {
+			history = append(history, attemptResponse{
+				ID:         attempt.ID,
+				StatusCode: attempt.StatusCode,
+				LatencyMs:  attempt.Latency.Milliseconds(),
+				Response:   attempt.Response,
+				Error:      attempt.Error,
+				CreatedAt:  attempt.CreatedAt,
+			})
+		}
+		response.Deliveries = append(response.Deliveries, deliveryResponse{
+			ID:            delivery.ID,
+			EventID:       delivery.EventID,
+			EndpointID:    delivery.EndpointID,
+			Action:        delivery.Action,
+			State:         string(delivery.State),
+			Attempts:      delivery.Attempts,
+			NextAttemptAt: delivery.NextAttemptAt,
+			LastError:     delivery.LastError,
+			RequestID:     delivery.RequestID,
+			History:       history,
+			CreatedAt:     delivery.CreatedAt,
+			UpdatedAt:     delivery.UpdatedAt,
+		})
+	}
+	return response
+}
+
+// ListDeliveries returns the deliveries of webhook events along with their
+// attempts, filtered by event, endpoint and state
+func (h *Handler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := frontierwebhook.DeliveryFilter{
+		EventID:    query.Get("event_id"),
+		EndpointID: query.Get("endpoint_id"),
+		State:      frontierwebhook.DeliveryState(query.Get("state")),
+	}
+	for _, id := range []string{filter.EventID, filter.EndpointID} {
+		if id != "" && !utils.IsValidUUID(id) {
+			h.writeError(w, frontierwebhook.ErrInvalidUUID)
+			return
+		}
+	}
+	switch filter.State {
+	case "", frontierwebhook.DeliveryPending, frontierwebhook.DeliverySucceeded, frontierwebhook.DeliveryDead:
+	default:
+		httputil.WriteMessage(w, http.StatusBadRequest, "state must be one of pending, succeeded or dead")
+		return
+	}
+	if limit := query.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			httputil.WriteMessage(w, http.StatusBadRequest, "limit must be a positive number")
+			return
+		}
+		filter.Limit = n
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(ctx, filter)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, toDeliveriesResponse(deliveries))
+}
+
+// Redeliver schedules every delivery of the event for an immediate retry,
+// including the dead-lettered ones
+func (h *Handler) Redeliver(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	eventID := r.PathValue("id")
+	if !utils.IsValidUUID(eventID) {
+		h.writeError(w, frontierwebhook.ErrInvalidUUID)
+		return
+	}
+	deliveries, err := h.webhookService.Redeliver(ctx, eventID)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusAccepted, toDeliveriesResponse(deliveries))
+}
+
+type rotateSecretRequest struct {
+	// Overlap is how long the current secrets keep signing the payloads, a
+	// duration like "1h", the configured default is used if it's empty
+	Overlap string `json:"overlap"`
+}
+
+type secretResponse struct {
+	ID        string    `json:"id"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type rotateSecretResponse struct {
+	Secret secretResponse `json:"secret"`
+}
+
+// RotateSecret generates a new secret for the endpoint, the current secrets
+// keep signing the payloads until the overlap elapses
+func (h *Handler) RotateSecret(w http.ResponseWriter, r *http.Request) {
+	ctx, principal, ok := h.authenticator.Principal(w, r)
+	if !ok {
+		return
+	}
+	if err := httputil.CheckSudo(ctx, h.userService, h.serviceUserService, principal); err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	id := r.PathValue("id")
+	if !utils.IsValidUUID(id) {
+		h.writeError(w, frontierwebhook.ErrInvalidUUID)
+		return
+	}
+	// the body is optional
+	var req rotateSecretRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		httputil.WriteMessage(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	var overlap time.Duration
+	if req.Overlap != "" {
+		var err error
+		if overlap, err = time.ParseDuration(req.Overlap); err != nil || overlap <= 0 {
+			httputil.WriteMessage(w, http.StatusBadRequest, "overlap must be a positive duration, e.g. 1h")
+			return
+		}
+	}
+
+	secret, err := h.webhookService.RotateSecret(ctx, id, overlap)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, rotateSecretResponse{
+		Secret: secretResponse{
+			ID:        secret.ID,
+			Value:     secret.Value,
+			CreatedAt: secret.CreatedAt,
+		},
+	})
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, frontierwebhook.ErrInvalidUUID), errors.Is(err, frontierwebhook.ErrInvalidDetail):
+		status = http.StatusBadRequest
+	case errors.Is(err, frontierwebhook.ErrEventNotFound), errors.Is(err, frontierwebhook.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, frontiererrors.ErrForbidden):
+		status = http.StatusForbidden
+	default:
+		h.log.Error("webhook admin request failed", "err", err)
+		httputil.WriteMessage(w, status, "internal error")
+		return
+	}
+	httputil.WriteMessage(w, status, err.Error())
+}
@@ -0,0 +1,243 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontierwebhook "github.com/raystack/frontier/core/webhook"
+	"github.com/raystack/frontier/internal/api/httputil"
+	httpmocks "github.com/raystack/frontier/internal/api/httputil/mocks"
+	"github.com/raystack/frontier/internal/api/webhook/mocks"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const eventID = "7f4c3b1e-5d2a-4c8e-9b6f-2a1d3e4f5a6b"
+
+var admin = authenticate.Principal{ID: "admin-id", Type: schema.UserPrincipal}
+
+type handlerMocks struct {
+	authn        *httpmocks.AuthnService
+	webhooks     *mocks.WebhookService
+	users        *httpmocks.SudoService
+	serviceUsers *httpmocks.SudoService
+	decoder      *httpmocks.SessionDecoder
+}
+
+func newTestHandler(t *testing.T) (*http.ServeMux, handlerMocks) {
+	m := handlerMocks{
+		authn:        httpmocks.NewAuthnService(t),
+		webhooks:     mocks.NewWebhookService(t),
+		users:        httpmocks.NewSudoService(t),
+		serviceUsers: httpmocks.NewSudoService(t),
+		decoder:      httpmocks.NewSessionDecoder(t),
+	}
+	mux := http.NewServeMux()
+	NewHandler(log.NewNoop(), m.authn, m.webhooks, m.users, m.serviceUsers, m.decoder).Register(httputil.NewRouter(mux))
+	return mux, m
+}
+
+func expectAdmin(m handlerMocks, sudo bool) {
+	m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+	m.authn.EXPECT().GetPrincipal(mock.Anything).Return(admin, nil)
+	m.users.EXPECT().IsSudo(mock.Anything, admin.ID, schema.PlatformSudoPermission).Return(sudo, nil)
+}
+
+func TestHandler_ListDeliveries(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should list the deliveries with their attempts", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.webhooks.EXPECT().ListDeliveries(mock.Anything, frontierwebhook.DeliveryFilter{
+			EventID: eventID,
+			State:   frontierwebhook.DeliveryDead,
+			Limit:   10,
+		}).Return([]frontierwebhook.Delivery{{
+			ID:            "delivery-id",
+			EventID:       eventID,
+			EndpointID:    "endpoint-id",
+			Action:        "app.user.created",
+			State:         frontierwebhook.DeliveryDead,
+			Attempts:      1,
+			NextAttemptAt: createdAt,
+			LastError:     "status 500",
+			History: []frontierwebhook.DeliveryAttempt{{
+				ID:         "attempt-id",
+				StatusCode: http.StatusInternalServerError,
+				Latency:    120 * time.Millisecond,
+				Response:   "oops",
+				Error:      "status 500",
+				CreatedAt:  createdAt,
+			}},
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+			"/admin/webhooks/deliveries?event_id="+eventID+"&state=dead&limit=10", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"deliveries": [{
+			"id": "delivery-id",
+			"event_id": "`+eventID+`",
+			"endpoint_id": "endpoint-id",
+			"action": "app.user.created",
+			"state": "dead",
+			"attempts": 1,
+			"next_attempt_at": "2024-01-01T00:00:00Z",
+			"last_error": "status 500",
+			"history": [{
+				"id": "attempt-id",
+				"status_code": 500,
+				"latency_ms": 120,
+				"response": "oops",
+				"error": "status 500",
+				"created_at": "2024-01-01T00:00:00Z"
+			}],
+			"created_at": "2024-01-01T00:00:00Z",
+			"updated_at": "2024-01-01T00:00:00Z"
+		}]}`, rec.Body.String())
+	})
+
+	t.Run("should reject unknown states", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/webhooks/deliveries?state=lost", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should reject event ids which are not uuids", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/webhooks/deliveries?event_id=evt-1", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should forbid callers which are not superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/webhooks/deliveries", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("should reject unauthenticated callers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		m.decoder.EXPECT().RequestContext(mock.Anything).Return(context.Background())
+		m.authn.EXPECT().GetPrincipal(mock.Anything).Return(authenticate.Principal{}, errors.New("unauthenticated"))
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/webhooks/deliveries", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
+func TestHandler_Redeliver(t *testing.T) {
+	t.Run("should requeue the deliveries of the event", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.webhooks.EXPECT().Redeliver(mock.Anything, eventID).Return([]frontierwebhook.Delivery{{
+			ID:      "delivery-id",
+			EventID: eventID,
+			State:   frontierwebhook.DeliveryPending,
+		}}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/events/"+eventID+"/redeliver", nil))
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"state":"pending"`)
+	})
+
+	t.Run("should return not found for unknown events", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.webhooks.EXPECT().Redeliver(mock.Anything, eventID).Return(nil, frontierwebhook.ErrEventNotFound)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/events/"+eventID+"/redeliver", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should forbid callers which are not superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/events/"+eventID+"/redeliver", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestHandler_RotateSecret(t *testing.T) {
+	const endpointID = "3c2b1a09-8f7e-4d6c-b5a4-9382716f5e4d"
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should rotate the secret with the requested overlap", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.webhooks.EXPECT().RotateSecret(mock.Anything, endpointID, time.Hour).Return(frontierwebhook.Secret{
+			ID:        "2",
+			Value:     "c2VjcmV0",
+			CreatedAt: createdAt,
+		}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/"+endpointID+"/secrets/rotate",
+			strings.NewReader(`{"overlap": "1h"}`)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"secret": {"id": "2", "value": "c2VjcmV0", "created_at": "2024-01-01T00:00:00Z"}}`, rec.Body.String())
+	})
+
+	t.Run("should use the default overlap without a body", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.webhooks.EXPECT().RotateSecret(mock.Anything, endpointID, time.Duration(0)).Return(frontierwebhook.Secret{ID: "2"}, nil)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/"+endpointID+"/secrets/rotate", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("should reject invalid overlaps", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/"+endpointID+"/secrets/rotate",
+			strings.NewReader(`{"overlap": "-1h"}`)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("should return not found for unknown webhooks", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, true)
+		m.webhooks.EXPECT().RotateSecret(mock.Anything, endpointID, time.Duration(0)).Return(frontierwebhook.Secret{}, frontierwebhook.ErrNotFound)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/"+endpointID+"/secrets/rotate", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("should forbid callers which are not superusers", func(t *testing.T) {
+		mux, m := newTestHandler(t)
+		expectAdmin(m, false)
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/webhooks/"+endpointID+"/secrets/rotate", nil))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
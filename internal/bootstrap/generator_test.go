@@ -59,7 +59,7 @@ func TestAddServiceToSchema(t *testing.T) {
 		return spiceDBDefinitions[i].GetName() < spiceDBDefinitions[j].GetName()
 	})
 
-	authzedSchemaSource, err := bootstrap.PrepareSchemaAsAZSource(spiceDBDefinitions)
+	authzedSchemaSource, err := bootstrap.PrepareSchemaAsAZSource(spiceDBDefinitions, existingSchema.CaveatDefinitions...)
 	assert.NoError(t, err)
 
 	// compile and validate generated schema
@@ -39,6 +39,9 @@ const (
 	RoleGrantRelationName    = "granted"
 	RoleBearerRelationName   = "bearer"
 
+	// caveats
+	ValidWindowCaveatName = "app/valid_window"
+
 	// permissions
 	ListPermission              = "list"
 	GetPermission               = "get"
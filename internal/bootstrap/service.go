@@ -151,7 +151,7 @@ func (s Service) applySchema(ctx context.Context, customServiceDefinition *schem
 	}
 
 	// validate prepared az schema
-	authzedSchemaSource, err := PrepareSchemaAsAZSource(authzedDefinitions)
+	authzedSchemaSource, err := PrepareSchemaAsAZSource(authzedDefinitions, GetBaseAZCaveats()...)
 	if err != nil {
 		return fmt.Errorf("PrepareSchemaAsAZSource: %w", err)
 	}
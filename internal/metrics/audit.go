@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var AuditSinkEvents *prometheus.CounterVec
+var AuditSinkQueueLength *prometheus.GaugeVec
+var AuditSinkWriteLatency HistogramFunc
+
+func initAudit() {
+	AuditSinkEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "audit_sink_events_total",
+		Help: "Number of audit logs processed by an export sink partitioned by outcome",
+	}, []string{"sink", "status"})
+	AuditSinkQueueLength = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "audit_sink_queue_length",
+		Help: "Number of audit logs buffered in an export sink waiting to be written",
+	}, []string{"sink"})
+	AuditSinkWriteLatency = createMeasureTime(promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "audit_sink_write_latency",
+		Help:    "Time taken by an export sink to write a batch of audit logs",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"sink"}))
+}
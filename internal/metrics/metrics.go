@@ -10,6 +10,8 @@ func Init() {
 	initStripe()
 	initDB()
 	initService()
+	initAudit()
+	initSpiceDB()
 }
 
 type HistogramFunc func(labelValue ...string) func()
@@ -0,0 +1,20 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var SpiceDBCheckCacheLookups *prometheus.CounterVec
+var SpiceDBCheckCacheInvalidations *prometheus.CounterVec
+
+func initSpiceDB() {
+	SpiceDBCheckCacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "spicedb_check_cache_lookups_total",
+		Help: "Number of permission checks looked up in the check cache partitioned by hit or miss",
+	}, []string{"result"})
+	SpiceDBCheckCacheInvalidations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "spicedb_check_cache_invalidations_total",
+		Help: "Number of check cache invalidations partitioned by the entries dropped, those of a subject or all",
+	}, []string{"scope"})
+}
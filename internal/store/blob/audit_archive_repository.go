@@ -0,0 +1,77 @@
+package blob
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/raystack/frontier/core/audit"
+)
+
+// auditArchiveLine is a single line of an archive object
+type auditArchiveLine struct {
+	Sequence int64 `json:"seq"`
+	audit.Log
+}
+
+// AuditArchiveRepository stores archived audit logs as gzip compressed
+// newline delimited json objects
+type AuditArchiveRepository struct {
+	bucket Bucket
+}
+
+func NewAuditArchiveRepository(b Bucket) *AuditArchiveRepository {
+	return &AuditArchiveRepository{bucket: b}
+}
+
+func (r AuditArchiveRepository) Write(ctx context.Context, key string, links []audit.ChainLink) error {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	encoder := json.NewEncoder(zw)
+	for _, link := range links {
+		if err := encoder.Encode(auditArchiveLine{
+			Sequence: link.Sequence,
+			Log:      link.Log,
+		}); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return r.bucket.WriteAll(ctx, key, buf.Bytes(), nil)
+}
+
+func (r AuditArchiveRepository) Read(ctx context.Context, key string) ([]audit.ChainLink, error) {
+	data, err := r.bucket.ReadAll(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	defer zr.Close()
+
+	var links []audit.ChainLink
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var line auditArchiveLine
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		links = append(links, audit.ChainLink{
+			Sequence: line.Sequence,
+			Log:      line.Log,
+		})
+	}
+	return links, scanner.Err()
+}
+
+func (r AuditArchiveRepository) Close() error {
+	return r.bucket.Close()
+}
@@ -0,0 +1,53 @@
+package blob
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/raystack/frontier/core/audit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditArchiveRepository(t *testing.T) {
+	bucket, err := NewStore(context.Background(), "file://"+t.TempDir(), "")
+	assert.NoError(t, err)
+	repo := NewAuditArchiveRepository(bucket)
+	defer repo.Close()
+
+	links := []audit.ChainLink{
+		{
+			Sequence: 7,
+			Log: audit.Log{
+				ID:        "9f2c1a6e-7b0e-4a55-8f3b-1f0e2d3c4b5a",
+				OrgID:     "2a1b3c4d-5e6f-4a1b-8c2d-3e4f5a6b7c8d",
+				Source:    "frontier",
+				Action:    audit.UserCreatedEvent.String(),
+				Actor:     audit.Actor{ID: "user-1", Type: "app/user"},
+				Metadata:  map[string]string{"ip": "10.0.0.1"},
+				PrevHash:  "prev",
+				Hash:      "hash",
+				CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			Sequence: 8,
+			Log: audit.Log{
+				ID:        "1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+				OrgID:     "2a1b3c4d-5e6f-4a1b-8c2d-3e4f5a6b7c8d",
+				Source:    "frontier",
+				Action:    audit.UserDeletedEvent.String(),
+				CreatedAt: time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	key := "2a1b3c4d-5e6f-4a1b-8c2d-3e4f5a6b7c8d/2026/01/01/7-8.ndjson.gz"
+	assert.NoError(t, repo.Write(context.Background(), key, links))
+
+	got, err := repo.Read(context.Background(), key)
+	assert.NoError(t, err)
+	assert.Equal(t, links, got)
+
+	_, err = repo.Read(context.Background(), "missing.ndjson.gz")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/raystack/frontier/core/accessrequest"
+)
+
+type AccessRequest struct {
+	ID              string         `db:"id"`
+	RoleID          string         `db:"role_id"`
+	ResourceID      string         `db:"resource_id"`
+	ResourceType    string         `db:"resource_type"`
+	PrincipalID     string         `db:"principal_id"`
+	PrincipalType   string         `db:"principal_type"`
+	Justification   string         `db:"justification"`
+	DurationSeconds int64          `db:"duration_seconds"`
+	State           string         `db:"state"`
+	ReviewerID      sql.NullString `db:"reviewer_id"`
+	ReviewNote      string         `db:"review_note"`
+	ReviewedAt      sql.NullTime   `db:"reviewed_at"`
+	PolicyID        sql.NullString `db:"policy_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (r AccessRequest) transform() accessrequest.Request {
+	transformed := accessrequest.Request{
+		ID:            r.ID,
+		RoleID:        r.RoleID,
+		ResourceID:    r.ResourceID,
+		ResourceType:  r.ResourceType,
+		PrincipalID:   r.PrincipalID,
+		PrincipalType: r.PrincipalType,
+		Justification: r.Justification,
+		Duration:      time.Duration(r.DurationSeconds) * time.Second,
+		State:         accessrequest.State(r.State),
+		ReviewerID:    r.ReviewerID.String,
+		ReviewNote:    r.ReviewNote,
+		PolicyID:      r.PolicyID.String,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+	}
+	if r.ReviewedAt.Valid {
+		transformed.ReviewedAt = r.ReviewedAt.Time
+	}
+	return transformed
+}
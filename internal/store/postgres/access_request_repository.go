@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/accessrequest"
+	"github.com/raystack/frontier/core/role"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// AccessRequestRepository stores the requests of principals for temporary
+// roles and their reviews
+type AccessRequestRepository struct {
+	dbc *db.Client
+}
+
+func NewAccessRequestRepository(dbc *db.Client) *AccessRequestRepository {
+	return &AccessRequestRepository{
+		dbc: dbc,
+	}
+}
+
+func (r AccessRequestRepository) Create(ctx context.Context, toCreate accessrequest.Request) (accessrequest.Request, error) {
+	query, params, err := dialect.Insert(TABLE_ACCESS_REQUESTS).Rows(goqu.Record{
+		"role_id":          toCreate.RoleID,
+		"resource_id":      toCreate.ResourceID,
+		"resource_type":    toCreate.ResourceType,
+		"principal_id":     toCreate.PrincipalID,
+		"principal_type":   toCreate.PrincipalType,
+		"justification":    toCreate.Justification,
+		"duration_seconds": int64(toCreate.Duration.Seconds()),
+		"state":            toCreate.State.String(),
+	}).Returning(&AccessRequest{}).ToSQL()
+	if err != nil {
+		return accessrequest.Request{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var requestModel AccessRequest
+	if err = r.dbc.WithTimeout(ctx, TABLE_ACCESS_REQUESTS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&requestModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, ErrForeignKeyViolation):
+			return accessrequest.Request{}, role.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return accessrequest.Request{}, accessrequest.ErrInvalidDetail
+		default:
+			return accessrequest.Request{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return requestModel.transform(), nil
+}
+
+func (r AccessRequestRepository) Get(ctx context.Context, id string) (accessrequest.Request, error) {
+	query, params, err := dialect.From(TABLE_ACCESS_REQUESTS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return accessrequest.Request{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var requestModel AccessRequest
+	if err = r.dbc.WithTimeout(ctx, TABLE_ACCESS_REQUESTS, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &requestModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return accessrequest.Request{}, accessrequest.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return accessrequest.Request{}, accessrequest.ErrNotExist
+		default:
+			return accessrequest.Request{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return requestModel.transform(), nil
+}
+
+func (r AccessRequestRepository) List(ctx context.Context, flt accessrequest.Filter) ([]accessrequest.Request, error) {
+	where := goqu.Ex{}
+	if flt.ResourceID != "" {
+		where["resource_id"] = flt.ResourceID
+	}
+	if flt.ResourceType != "" {
+		where["resource_type"] = flt.ResourceType
+	}
+	if flt.PrincipalID != "" {
+		where["principal_id"] = flt.PrincipalID
+	}
+	if flt.PrincipalType != "" {
+		where["principal_type"] = flt.PrincipalType
+	}
+	if flt.State != "" {
+		where["state"] = flt.State.String()
+	}
+	query, params, err := dialect.From(TABLE_ACCESS_REQUESTS).Where(where).
+		Order(goqu.I("created_at").Desc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var requestModels []AccessRequest
+	if err = r.dbc.WithTimeout(ctx, TABLE_ACCESS_REQUESTS, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &requestModels, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return []accessrequest.Request{}, nil
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return []accessrequest.Request{}, nil
+		default:
+			return nil, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+
+	requests := make([]accessrequest.Request, 0, len(requestModels))
+	for _, m := range requestModels {
+		requests = append(requests, m.transform())
+	}
+	return requests, nil
+}
+
+// UpdateState only updates pending requests so concurrent reviews of a
+// request can't both succeed
+func (r AccessRequestRepository) UpdateState(ctx context.Context, toUpdate accessrequest.Request) (accessrequest.Request, error) {
+	query, params, err := dialect.Update(TABLE_ACCESS_REQUESTS).Set(goqu.Record{
+		"state":       toUpdate.State.String(),
+		"reviewer_id": sql.NullString{String: toUpdate.ReviewerID, Valid: toUpdate.ReviewerID != ""},
+		"review_note": toUpdate.ReviewNote,
+		"reviewed_at": sql.NullTime{Time: toUpdate.ReviewedAt, Valid: !toUpdate.ReviewedAt.IsZero()},
+		"policy_id":   sql.NullString{String: toUpdate.PolicyID, Valid: toUpdate.PolicyID != ""},
+		"updated_at":  goqu.L("now()"),
+	}).Where(goqu.Ex{
+		"id":    toUpdate.ID,
+		"state": accessrequest.StatePending.String(),
+	}).Returning(&AccessRequest{}).ToSQL()
+	if err != nil {
+		return accessrequest.Request{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var requestModel AccessRequest
+	if err = r.dbc.WithTimeout(ctx, TABLE_ACCESS_REQUESTS, "UpdateState", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&requestModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return accessrequest.Request{}, accessrequest.ErrNotPending
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return accessrequest.Request{}, accessrequest.ErrNotExist
+		default:
+			return accessrequest.Request{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return requestModel.transform(), nil
+}
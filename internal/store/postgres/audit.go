@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/jmoiron/sqlx/types"
@@ -17,6 +18,12 @@ type Audit struct {
 	Target   types.NullJSONText `db:"target"`
 	Metadata types.NullJSONText `db:"metadata"`
 
+	Seq      int64          `db:"seq"`
+	PrevHash sql.NullString `db:"prev_hash"`
+	Hash     sql.NullString `db:"hash"`
+
+	RestoredAt sql.NullTime `db:"restored_at"`
+
 	CreatedAt time.Time `db:"created_at"`
 }
 
@@ -48,5 +55,56 @@ func (a Audit) transform() (audit.Log, error) {
 		Actor:     actor,
 		Target:    target,
 		Metadata:  unmarshalledMetadata,
+		PrevHash:  a.PrevHash.String,
+		Hash:      a.Hash.String,
 	}, nil
 }
+
+type AuditFacet struct {
+	Action string `db:"action"`
+	Count  int    `db:"count"`
+}
+
+type AuditRetentionPolicy struct {
+	OrgID            string    `db:"org_id"`
+	HotPeriodSeconds int64     `db:"hot_period_seconds"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+func (p AuditRetentionPolicy) transform() audit.RetentionPolicy {
+	return audit.RetentionPolicy{
+		OrgID:     p.OrgID,
+		HotPeriod: time.Duration(p.HotPeriodSeconds) * time.Second,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+type AuditArchive struct {
+	ID        string    `db:"id"`
+	OrgID     string    `db:"org_id"`
+	FromSeq   int64     `db:"from_seq"`
+	ToSeq     int64     `db:"to_seq"`
+	StartTime time.Time `db:"start_time"`
+	EndTime   time.Time `db:"end_time"`
+	LogCount  int       `db:"log_count"`
+	LastHash  string    `db:"last_hash"`
+	ObjectKey string    `db:"object_key"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (a AuditArchive) transform() audit.Archive {
+	return audit.Archive{
+		ID:           a.ID,
+		OrgID:        a.OrgID,
+		FromSequence: a.FromSeq,
+		ToSequence:   a.ToSeq,
+		StartTime:    a.StartTime,
+		EndTime:      a.EndTime,
+		Count:        a.LogCount,
+		LastHash:     a.LastHash,
+		ObjectKey:    a.ObjectKey,
+		CreatedAt:    a.CreatedAt,
+	}
+}
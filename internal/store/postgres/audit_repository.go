@@ -7,9 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"github.com/raystack/frontier/core/audit"
 	"github.com/raystack/frontier/core/group"
 	"github.com/raystack/frontier/pkg/db"
@@ -35,65 +38,138 @@ func (a AuditRepository) Create(ctx context.Context, l *audit.Log) error {
 	if l.OrgID == "" {
 		l.OrgID = uuid.Nil.String()
 	}
-
-	marshaledActor, err := json.Marshal(l.Actor)
-	if err != nil {
-		return fmt.Errorf("%w: %w", err, parseErr)
+	// ids are hashed as stored by the db, in lower case canonical form
+	if id, err := uuid.Parse(l.ID); err == nil {
+		l.ID = id.String()
 	}
-	marshaledTarget, err := json.Marshal(l.Target)
-	if err != nil {
-		return fmt.Errorf("%w: %w", err, parseErr)
+	if id, err := uuid.Parse(l.OrgID); err == nil {
+		l.OrgID = id.String()
 	}
-	marshaledMetadata, err := json.Marshal(l.Metadata)
-	if err != nil {
-		return fmt.Errorf("%w: %w", err, parseErr)
-	}
-
-	query, params, err := dialect.Insert(TABLE_AUDITLOGS).Rows(
-		goqu.Record{
-			"id":       l.ID,
-			"org_id":   l.OrgID,
-			"source":   l.Source,
-			"action":   l.Action,
-			"actor":    marshaledActor,
-			"target":   marshaledTarget,
-			"metadata": marshaledMetadata,
-		}).Returning(&Audit{}).ToSQL()
+	// the time of the event is kept as given, normalized to the precision the
+	// db stores so the hash covers the stored value
+	if l.CreatedAt.IsZero() {
+		l.CreatedAt = time.Now()
+	}
+	l.CreatedAt = l.CreatedAt.UTC().Truncate(time.Microsecond)
+
+	// the chain continues from the latest live log or the last archived one
+	liveChain := dialect.From(TABLE_AUDITLOGS).Select("seq", "hash").Where(
+		goqu.Ex{
+			"org_id":      l.OrgID,
+			"hash":        goqu.Op{"isNot": nil},
+			"restored_at": nil,
+		})
+	archivedChain := dialect.From(TABLE_AUDIT_ARCHIVES).Select(
+		goqu.C("to_seq").As("seq"), goqu.C("last_hash").As("hash"),
+	).Where(
+		goqu.Ex{
+			"org_id":    l.OrgID,
+			"last_hash": goqu.Op{"neq": ""},
+		})
+	lastHashQuery, lastHashParams, err := dialect.From(liveChain.UnionAll(archivedChain).As("chain")).
+		Select("hash").Order(goqu.C("seq").Desc()).Limit(1).ToSQL()
 	if err != nil {
 		return fmt.Errorf("%w: %w", err, queryErr)
 	}
 
-	var auditModel Audit
-	if err = a.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "Create", func(ctx context.Context) error {
-		return a.dbc.QueryRowxContext(ctx, query, params...).StructScan(&auditModel)
+	if err = a.dbc.WithTxn(ctx, sql.TxOptions{}, func(tx *sqlx.Tx) error {
+		return a.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "Create", func(ctx context.Context) error {
+			// serialize writers of the same org so every log links to the latest
+			// one. Logs of an org are written one at a time for the duration of
+			// this transaction, two short queries and an insert, while writers of
+			// other orgs run concurrently. The chain follows the insertion order
+			// (seq), not created_at, so logs don't have to arrive in time order.
+			if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", TABLE_AUDITLOGS+l.OrgID); err != nil {
+				return err
+			}
+			var prevHash string
+			if err := tx.GetContext(ctx, &prevHash, lastHashQuery, lastHashParams...); err != nil && !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			hash, err := audit.ChainHash(prevHash, *l)
+			if err != nil {
+				return fmt.Errorf("%w: %w", err, parseErr)
+			}
+			l.PrevHash, l.Hash = prevHash, hash
+
+			record, err := auditLogRecord(*l)
+			if err != nil {
+				return err
+			}
+			query, params, err := dialect.Insert(TABLE_AUDITLOGS).Rows(record).ToSQL()
+			if err != nil {
+				return fmt.Errorf("%w: %w", err, queryErr)
+			}
+			_, err = tx.ExecContext(ctx, query, params...)
+			return err
+		})
 	}); err != nil {
-		return fmt.Errorf("failed to insert audit in pg repo: %w", err)
+		return fmt.Errorf("failed to insert audit in pg repo: %w", checkPostgresError(err))
 	}
 	return nil
 }
 
-func (a AuditRepository) List(ctx context.Context, flt audit.Filter) ([]audit.Log, error) {
-	if flt.OrgID == "" {
-		return nil, errors.New("org id is required")
-	}
-
-	sqlStatement := dialect.From(TABLE_AUDITLOGS)
-	sqlStatement = sqlStatement.Where(goqu.Ex{"org_id": flt.OrgID})
+// auditSearchDocument is the text matched by free text search,
+// it must stay the same as the expression of auditlogs_search_idx
+const auditSearchDocument = "to_tsvector('simple', action || ' ' || source || ' ' || actor::text || ' ' || target::text || ' ' || metadata::text)"
 
+func auditFilterExpressions(flt audit.Filter) ([]exp.Expression, error) {
+	exprs := []exp.Expression{goqu.Ex{"org_id": flt.OrgID}}
 	if flt.Source != "" {
-		sqlStatement = sqlStatement.Where(goqu.Ex{"source": flt.Source})
+		exprs = append(exprs, goqu.Ex{"source": flt.Source})
 	}
 	if flt.Action != "" {
-		sqlStatement = sqlStatement.Where(goqu.Ex{"action": flt.Action})
+		exprs = append(exprs, goqu.Ex{"action": flt.Action})
+	}
+	if flt.ActorID != "" {
+		exprs = append(exprs, goqu.L("actor->>'ID'").Eq(flt.ActorID))
+	}
+	if flt.ActorType != "" {
+		exprs = append(exprs, goqu.L("actor->>'Type'").Eq(flt.ActorType))
+	}
+	if flt.TargetID != "" {
+		exprs = append(exprs, goqu.L("target->>'ID'").Eq(flt.TargetID))
+	}
+	if flt.TargetType != "" {
+		exprs = append(exprs, goqu.L("target->>'Type'").Eq(flt.TargetType))
+	}
+	if len(flt.Metadata) > 0 {
+		marshaledMetadata, err := json.Marshal(flt.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		exprs = append(exprs, goqu.L("metadata @> ?::jsonb", string(marshaledMetadata)))
+	}
+	if strings.TrimSpace(flt.Query) != "" {
+		exprs = append(exprs, goqu.L(auditSearchDocument+" @@ websearch_to_tsquery('simple', ?)", flt.Query))
 	}
 	if flt.StartTime.UnixNano() > 0 {
-		sqlStatement = sqlStatement.Where(goqu.Ex{"created_at": goqu.Op{"gte": flt.StartTime}})
+		exprs = append(exprs, goqu.Ex{"created_at": goqu.Op{"gte": flt.StartTime}})
 	}
 	if flt.EndTime.UnixNano() > 0 {
-		sqlStatement = sqlStatement.Where(goqu.Ex{"created_at": goqu.Op{"lte": flt.EndTime}})
+		exprs = append(exprs, goqu.Ex{"created_at": goqu.Op{"lte": flt.EndTime}})
+	}
+	if flt.IgnoreSystem {
+		var systemEvents []string
+		for _, e := range audit.SystemEvents() {
+			systemEvents = append(systemEvents, e.String())
+		}
+		exprs = append(exprs, goqu.C("action").NotIn(systemEvents))
 	}
+	return exprs, nil
+}
 
-	query, params, err := sqlStatement.Order(goqu.C("created_at").Desc()).ToSQL()
+func (a AuditRepository) List(ctx context.Context, flt audit.Filter) ([]audit.Log, error) {
+	if flt.OrgID == "" {
+		return nil, errors.New("org id is required")
+	}
+
+	exprs, err := auditFilterExpressions(flt)
+	if err != nil {
+		return nil, err
+	}
+	query, params, err := dialect.From(TABLE_AUDITLOGS).Where(exprs...).
+		Order(goqu.C("created_at").Desc()).ToSQL()
 	if err != nil {
 		return nil, fmt.Errorf("%w: %w", queryErr, err)
 	}
@@ -111,17 +187,6 @@ func (a AuditRepository) List(ctx context.Context, flt audit.Filter) ([]audit.Lo
 		}
 	}
 
-	// filter system events if needed
-	if flt.IgnoreSystem {
-		var filtered []Audit
-		for _, v := range fetched {
-			if !audit.IsSystemEvent(audit.EventName(v.Action)) {
-				filtered = append(filtered, v)
-			}
-		}
-		fetched = filtered
-	}
-
 	transformedLogs := make([]audit.Log, 0, len(fetched))
 	for _, v := range fetched {
 		transformedGroup, err := v.transform()
@@ -134,6 +199,70 @@ func (a AuditRepository) List(ctx context.Context, flt audit.Filter) ([]audit.Lo
 	return transformedLogs, nil
 }
 
+func (a AuditRepository) Search(ctx context.Context, flt audit.Filter) (audit.SearchResult, error) {
+	if flt.OrgID == "" {
+		return audit.SearchResult{}, errors.New("org id is required")
+	}
+
+	exprs, err := auditFilterExpressions(flt)
+	if err != nil {
+		return audit.SearchResult{}, err
+	}
+	pageSize := flt.NormalizedPageSize()
+	pageStatement := dialect.From(TABLE_AUDITLOGS).Where(exprs...)
+	if flt.Cursor != "" {
+		cursor, err := audit.DecodeCursor(flt.Cursor)
+		if err != nil {
+			return audit.SearchResult{}, err
+		}
+		pageStatement = pageStatement.Where(goqu.L("(created_at, id) < (?, ?::uuid)", cursor.CreatedAt, cursor.ID))
+	}
+	// fetch one extra log to know if there is a next page
+	pageQuery, pageParams, err := pageStatement.Order(goqu.C("created_at").Desc(), goqu.C("id").Desc()).
+		Limit(uint(pageSize + 1)).ToSQL()
+	if err != nil {
+		return audit.SearchResult{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+	facetQuery, facetParams, err := dialect.From(TABLE_AUDITLOGS).Select(
+		goqu.C("action"), goqu.COUNT(goqu.Star()).As("count"),
+	).Where(exprs...).GroupBy(goqu.C("action")).ToSQL()
+	if err != nil {
+		return audit.SearchResult{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var fetched []Audit
+	var facets []AuditFacet
+	if err = a.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "Search", func(ctx context.Context) error {
+		if err := a.dbc.SelectContext(ctx, &fetched, pageQuery, pageParams...); err != nil {
+			return err
+		}
+		return a.dbc.SelectContext(ctx, &facets, facetQuery, facetParams...)
+	}); err != nil {
+		return audit.SearchResult{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	result := audit.SearchResult{
+		Logs:         make([]audit.Log, 0, len(fetched)),
+		ActionFacets: make(map[string]int, len(facets)),
+	}
+	if len(fetched) > pageSize {
+		fetched = fetched[:pageSize]
+		last := fetched[len(fetched)-1]
+		result.NextCursor = audit.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	for _, v := range fetched {
+		l, err := v.transform()
+		if err != nil {
+			return audit.SearchResult{}, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		result.Logs = append(result.Logs, l)
+	}
+	for _, f := range facets {
+		result.ActionFacets[f.Action] = f.Count
+	}
+	return result, nil
+}
+
 func (a AuditRepository) GetByID(ctx context.Context, id string) (audit.Log, error) {
 	if strings.TrimSpace(id) == "" {
 		return audit.Log{}, audit.ErrInvalidID
@@ -163,3 +292,88 @@ func (a AuditRepository) GetByID(ctx context.Context, id string) (audit.Log, err
 	}
 	return logModel.transform()
 }
+
+func (a AuditRepository) ListChain(ctx context.Context, orgID string, afterSequence int64, limit int) ([]audit.ChainLink, error) {
+	query, params, err := dialect.From(TABLE_AUDITLOGS).Where(
+		goqu.Ex{
+			"org_id":      orgID,
+			"seq":         goqu.Op{"gt": afterSequence},
+			"restored_at": nil,
+		}).Order(goqu.I("seq").Asc()).Limit(uint(limit)).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var fetched []Audit
+	if err = a.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "ListChain", func(ctx context.Context) error {
+		return a.dbc.SelectContext(ctx, &fetched, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	links := make([]audit.ChainLink, 0, len(fetched))
+	for _, v := range fetched {
+		l, err := v.transform()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		links = append(links, audit.ChainLink{
+			Sequence: v.Seq,
+			Log:      l,
+		})
+	}
+	return links, nil
+}
+
+func (a AuditRepository) ChainAnchor(ctx context.Context, orgID string) (int64, string, error) {
+	query, params, err := dialect.From(TABLE_AUDIT_ARCHIVES).Select("to_seq", "last_hash").Where(
+		goqu.Ex{
+			"org_id": orgID,
+		}).Order(goqu.I("to_seq").Desc()).Limit(1).ToSQL()
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var anchor AuditArchive
+	if err = a.dbc.WithTimeout(ctx, TABLE_AUDIT_ARCHIVES, "ChainAnchor", func(ctx context.Context) error {
+		return a.dbc.GetContext(ctx, &anchor, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, "", nil
+		}
+		return 0, "", fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return anchor.ToSeq, anchor.LastHash, nil
+}
+
+// auditLogRecord builds the row of a log including its position in the chain
+func auditLogRecord(l audit.Log) (goqu.Record, error) {
+	marshaledActor, err := json.Marshal(l.Actor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", err, parseErr)
+	}
+	marshaledTarget, err := json.Marshal(l.Target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", err, parseErr)
+	}
+	marshaledMetadata, err := json.Marshal(l.Metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", err, parseErr)
+	}
+	record := goqu.Record{
+		"id":         l.ID,
+		"org_id":     l.OrgID,
+		"source":     l.Source,
+		"action":     l.Action,
+		"actor":      marshaledActor,
+		"target":     marshaledTarget,
+		"metadata":   marshaledMetadata,
+		"created_at": l.CreatedAt,
+	}
+	if l.Hash != "" {
+		record["prev_hash"] = l.PrevHash
+		record["hash"] = l.Hash
+	}
+	return record, nil
+}
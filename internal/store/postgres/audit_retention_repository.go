@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+	"github.com/raystack/frontier/core/audit"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type AuditRetentionRepository struct {
+	dbc *db.Client
+}
+
+func NewAuditRetentionRepository(dbc *db.Client) *AuditRetentionRepository {
+	return &AuditRetentionRepository{
+		dbc: dbc,
+	}
+}
+
+func (r AuditRetentionRepository) UpsertPolicy(ctx context.Context, policy audit.RetentionPolicy) (audit.RetentionPolicy, error) {
+	query, params, err := dialect.Insert(TABLE_AUDIT_RETENTION).Rows(
+		goqu.Record{
+			"org_id":             policy.OrgID,
+			"hot_period_seconds": int64(policy.HotPeriod.Seconds()),
+		}).OnConflict(goqu.DoUpdate("org_id", goqu.Record{
+		"hot_period_seconds": int64(policy.HotPeriod.Seconds()),
+		"updated_at":         goqu.L("now()"),
+	})).Returning(&AuditRetentionPolicy{}).ToSQL()
+	if err != nil {
+		return audit.RetentionPolicy{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var policyModel AuditRetentionPolicy
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDIT_RETENTION, "Upsert", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&policyModel)
+	}); err != nil {
+		return audit.RetentionPolicy{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return policyModel.transform(), nil
+}
+
+func (r AuditRetentionRepository) GetPolicy(ctx context.Context, orgID string) (audit.RetentionPolicy, error) {
+	query, params, err := dialect.From(TABLE_AUDIT_RETENTION).Where(
+		goqu.Ex{
+			"org_id": orgID,
+		}).ToSQL()
+	if err != nil {
+		return audit.RetentionPolicy{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var policyModel AuditRetentionPolicy
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDIT_RETENTION, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &policyModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return audit.RetentionPolicy{}, audit.ErrRetentionPolicyNotFound
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return audit.RetentionPolicy{}, audit.ErrRetentionPolicyNotFound
+		default:
+			return audit.RetentionPolicy{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return policyModel.transform(), nil
+}
+
+func (r AuditRetentionRepository) ListPolicies(ctx context.Context) ([]audit.RetentionPolicy, error) {
+	query, params, err := dialect.From(TABLE_AUDIT_RETENTION).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var policyModels []AuditRetentionPolicy
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDIT_RETENTION, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &policyModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	policies := make([]audit.RetentionPolicy, 0, len(policyModels))
+	for _, p := range policyModels {
+		policies = append(policies, p.transform())
+	}
+	return policies, nil
+}
+
+func (r AuditRetentionRepository) DeletePolicy(ctx context.Context, orgID string) error {
+	query, params, err := dialect.Delete(TABLE_AUDIT_RETENTION).Where(
+		goqu.Ex{
+			"org_id": orgID,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_AUDIT_RETENTION, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return audit.ErrRetentionPolicyNotFound
+		}
+		return nil
+	})
+}
+
+func (r AuditRetentionRepository) ListArchivableOrgs(ctx context.Context, before time.Time) ([]string, error) {
+	query, params, err := dialect.From(TABLE_AUDITLOGS).Select("org_id").Distinct().Where(
+		goqu.Ex{
+			"created_at":  goqu.Op{"lt": before},
+			"restored_at": nil,
+		}).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var orgIDs []string
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "ListArchivableOrgs", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &orgIDs, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return orgIDs, nil
+}
+
+func (r AuditRetentionRepository) ListArchivable(ctx context.Context, orgID string, before time.Time, limit int) ([]audit.ChainLink, error) {
+	query, params, err := dialect.From(TABLE_AUDITLOGS).Where(
+		goqu.Ex{
+			"org_id":      orgID,
+			"created_at":  goqu.Op{"lt": before},
+			"restored_at": nil,
+		}).Order(goqu.I("seq").Asc()).Limit(uint(limit)).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var fetched []Audit
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "ListArchivable", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &fetched, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	links := make([]audit.ChainLink, 0, len(fetched))
+	for _, v := range fetched {
+		l, err := v.transform()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		links = append(links, audit.ChainLink{
+			Sequence: v.Seq,
+			Log:      l,
+		})
+	}
+	return links, nil
+}
+
+func (r AuditRetentionRepository) CompleteArchive(ctx context.Context, archive audit.Archive) (audit.Archive, error) {
+	insertQuery, insertParams, err := dialect.Insert(TABLE_AUDIT_ARCHIVES).Rows(
+		goqu.Record{
+			"org_id":     archive.OrgID,
+			"from_seq":   archive.FromSequence,
+			"to_seq":     archive.ToSequence,
+			"start_time": archive.StartTime,
+			"end_time":   archive.EndTime,
+			"log_count":  archive.Count,
+			"last_hash":  archive.LastHash,
+			"object_key": archive.ObjectKey,
+		}).OnConflict(goqu.DoUpdate("object_key", goqu.Record{
+		// a retried run archives the same range again
+		"log_count": archive.Count,
+	})).Returning(&AuditArchive{}).ToSQL()
+	if err != nil {
+		return audit.Archive{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+	deleteQuery, deleteParams, err := dialect.Delete(TABLE_AUDITLOGS).Where(
+		goqu.Ex{
+			"org_id":      archive.OrgID,
+			"seq":         goqu.Op{"between": goqu.Range(archive.FromSequence, archive.ToSequence)},
+			"created_at":  goqu.Op{"lte": archive.EndTime},
+			"restored_at": nil,
+		}).ToSQL()
+	if err != nil {
+		return audit.Archive{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var archiveModel AuditArchive
+	if err = r.dbc.WithTxn(ctx, sql.TxOptions{}, func(tx *sqlx.Tx) error {
+		return r.dbc.WithTimeout(ctx, TABLE_AUDIT_ARCHIVES, "Complete", func(ctx context.Context) error {
+			if err := tx.QueryRowxContext(ctx, insertQuery, insertParams...).StructScan(&archiveModel); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, deleteQuery, deleteParams...)
+			return err
+		})
+	}); err != nil {
+		return audit.Archive{}, fmt.Errorf("%w: %w", txnErr, checkPostgresError(err))
+	}
+	return archiveModel.transform(), nil
+}
+
+func (r AuditRetentionRepository) ListArchives(ctx context.Context, orgID string, start, end time.Time) ([]audit.Archive, error) {
+	query, params, err := dialect.From(TABLE_AUDIT_ARCHIVES).Where(
+		goqu.Ex{
+			"org_id":     orgID,
+			"start_time": goqu.Op{"lte": end},
+			"end_time":   goqu.Op{"gte": start},
+		}).Order(goqu.I("from_seq").Asc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var archiveModels []AuditArchive
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDIT_ARCHIVES, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &archiveModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	archives := make([]audit.Archive, 0, len(archiveModels))
+	for _, a := range archiveModels {
+		archives = append(archives, a.transform())
+	}
+	return archives, nil
+}
+
+func (r AuditRetentionRepository) Restore(ctx context.Context, links []audit.ChainLink) (int, error) {
+	if len(links) == 0 {
+		return 0, nil
+	}
+	rows := make([]interface{}, 0, len(links))
+	for _, link := range links {
+		record, err := auditLogRecord(link.Log)
+		if err != nil {
+			return 0, err
+		}
+		record["seq"] = link.Sequence
+		record["restored_at"] = goqu.L("now()")
+		rows = append(rows, record)
+	}
+	query, params, err := dialect.Insert(TABLE_AUDITLOGS).Rows(rows...).
+		OnConflict(goqu.DoNothing()).ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var restored int64
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "Restore", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return err
+		}
+		restored, err = result.RowsAffected()
+		return err
+	}); err != nil {
+		return 0, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return int(restored), nil
+}
+
+func (r AuditRetentionRepository) PurgeRestored(ctx context.Context, before time.Time) (int, error) {
+	query, params, err := dialect.Delete(TABLE_AUDITLOGS).Where(
+		goqu.Ex{
+			"restored_at": goqu.Op{"lt": before},
+		}).ToSQL()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var purged int64
+	if err = r.dbc.WithTimeout(ctx, TABLE_AUDITLOGS, "PurgeRestored", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return err
+		}
+		purged, err = result.RowsAffected()
+		return err
+	}); err != nil {
+		return 0, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return int(purged), nil
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/raystack/frontier/core/mfa"
+	"github.com/raystack/frontier/pkg/crypt"
+)
+
+type MFATOTP struct {
+	UserID        string         `db:"user_id"`
+	Secret        string         `db:"secret"`
+	RecoveryCodes types.JSONText `db:"recovery_codes"`
+	LastUsedStep  int64          `db:"last_used_step"`
+	ConfirmedAt   sql.NullTime   `db:"confirmed_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (t MFATOTP) transform(encryptionKey []byte) (mfa.TOTP, error) {
+	encryptedSecret, err := base64.RawStdEncoding.DecodeString(t.Secret)
+	if err != nil {
+		return mfa.TOTP{}, err
+	}
+	secret, err := crypt.Decrypt(encryptedSecret, encryptionKey)
+	if err != nil {
+		return mfa.TOTP{}, err
+	}
+	var recoveryCodes []string
+	if err := t.RecoveryCodes.Unmarshal(&recoveryCodes); err != nil {
+		return mfa.TOTP{}, err
+	}
+	totp := mfa.TOTP{
+		UserID:        t.UserID,
+		Secret:        secret,
+		RecoveryCodes: recoveryCodes,
+		LastUsedStep:  t.LastUsedStep,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+	if t.ConfirmedAt.Valid {
+		totp.ConfirmedAt = t.ConfirmedAt.Time
+	}
+	return totp, nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/mfa"
+	"github.com/raystack/frontier/pkg/crypt"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// MFATOTPRepository stores the authenticator apps of users, the secrets are
+// encrypted at rest as they are needed to verify codes
+type MFATOTPRepository struct {
+	dbc           *db.Client
+	encryptionKey []byte
+}
+
+func NewMFATOTPRepository(dbc *db.Client, encryptionKey []byte) *MFATOTPRepository {
+	return &MFATOTPRepository{
+		dbc:           dbc,
+		encryptionKey: encryptionKey,
+	}
+}
+
+func (r MFATOTPRepository) Get(ctx context.Context, userID string) (mfa.TOTP, error) {
+	query, params, err := dialect.From(TABLE_MFA_TOTP).Where(
+		goqu.Ex{
+			"user_id": userID,
+		}).ToSQL()
+	if err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var totpModel MFATOTP
+	if err = r.dbc.WithTimeout(ctx, TABLE_MFA_TOTP, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &totpModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return mfa.TOTP{}, mfa.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return mfa.TOTP{}, mfa.ErrNotExist
+		default:
+			return mfa.TOTP{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+
+	totp, err := totpModel.transform(r.encryptionKey)
+	if err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	return totp, nil
+}
+
+func (r MFATOTPRepository) Upsert(ctx context.Context, totp mfa.TOTP) (mfa.TOTP, error) {
+	encryptedSecret, err := crypt.Encrypt(totp.Secret, r.encryptionKey)
+	if err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	recoveryCodes := totp.RecoveryCodes
+	if recoveryCodes == nil {
+		recoveryCodes = []string{}
+	}
+	marshaledRecoveryCodes, err := json.Marshal(recoveryCodes)
+	if err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	var confirmedAt sql.NullTime
+	if totp.IsConfirmed() {
+		confirmedAt = sql.NullTime{Time: totp.ConfirmedAt, Valid: true}
+	}
+
+	record := goqu.Record{
+		"user_id":        totp.UserID,
+		"secret":         base64.RawStdEncoding.EncodeToString(encryptedSecret),
+		"recovery_codes": marshaledRecoveryCodes,
+		"last_used_step": totp.LastUsedStep,
+		"confirmed_at":   confirmedAt,
+	}
+	query, params, err := dialect.Insert(TABLE_MFA_TOTP).Rows(record).OnConflict(
+		goqu.DoUpdate("user_id", goqu.Record{
+			"secret":         goqu.L("EXCLUDED.secret"),
+			"recovery_codes": goqu.L("EXCLUDED.recovery_codes"),
+			"last_used_step": goqu.L("EXCLUDED.last_used_step"),
+			"confirmed_at":   goqu.L("EXCLUDED.confirmed_at"),
+			"updated_at":     goqu.L("now()"),
+		})).Returning(&MFATOTP{}).ToSQL()
+	if err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var totpModel MFATOTP
+	if err = r.dbc.WithTimeout(ctx, TABLE_MFA_TOTP, "Upsert", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&totpModel)
+	}); err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	result, err := totpModel.transform(r.encryptionKey)
+	if err != nil {
+		return mfa.TOTP{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	return result, nil
+}
+
+func (r MFATOTPRepository) Delete(ctx context.Context, userID string) error {
+	query, params, err := dialect.Delete(TABLE_MFA_TOTP).Where(
+		goqu.Ex{
+			"user_id": userID,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_MFA_TOTP, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return mfa.ErrNotExist
+		}
+		return nil
+	})
+}
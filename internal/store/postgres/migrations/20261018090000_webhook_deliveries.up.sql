@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id uuid PRIMARY KEY,
+    action text NOT NULL,
+    payload jsonb NOT NULL,
+    request_id text,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id uuid NOT NULL REFERENCES webhook_events (id) ON DELETE CASCADE,
+    endpoint_id uuid NOT NULL REFERENCES webhook_endpoints (id) ON DELETE CASCADE,
+    state text NOT NULL DEFAULT 'pending',
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT NOW(),
+    last_error text,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW(),
+    UNIQUE (event_id, endpoint_id)
+);
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE state = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_endpoint_id_idx ON webhook_deliveries (endpoint_id);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts (
+    id uuid PRIMARY KEY DEFAULT uuid_generate_v4(),
+    delivery_id uuid NOT NULL REFERENCES webhook_deliveries (id) ON DELETE CASCADE,
+    status_code integer NOT NULL DEFAULT 0,
+    latency_ms bigint NOT NULL DEFAULT 0,
+    response text,
+    error text,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempts_delivery_id_idx ON webhook_delivery_attempts (delivery_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS auditlogs_org_id_seq_idx;
+ALTER TABLE auditlogs DROP COLUMN IF EXISTS hash;
+ALTER TABLE auditlogs DROP COLUMN IF EXISTS prev_hash;
+ALTER TABLE auditlogs DROP COLUMN IF EXISTS seq;
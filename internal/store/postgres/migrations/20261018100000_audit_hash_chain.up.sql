@@ -0,0 +1,4 @@
+ALTER TABLE auditlogs ADD COLUMN IF NOT EXISTS seq BIGSERIAL;
+ALTER TABLE auditlogs ADD COLUMN IF NOT EXISTS prev_hash TEXT;
+ALTER TABLE auditlogs ADD COLUMN IF NOT EXISTS hash TEXT;
+CREATE INDEX IF NOT EXISTS auditlogs_org_id_seq_idx ON auditlogs (org_id, seq);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS auditlog_archives;
+DROP TABLE IF EXISTS audit_retention_policies;
+DROP INDEX IF EXISTS auditlogs_restored_at_idx;
+ALTER TABLE auditlogs DROP COLUMN IF EXISTS restored_at;
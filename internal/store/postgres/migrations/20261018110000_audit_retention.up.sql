@@ -0,0 +1,24 @@
+ALTER TABLE auditlogs ADD COLUMN IF NOT EXISTS restored_at timestamptz;
+CREATE INDEX IF NOT EXISTS auditlogs_restored_at_idx ON auditlogs (restored_at) WHERE restored_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS audit_retention_policies (
+    org_id UUID PRIMARY KEY,
+    hot_period_seconds BIGINT NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS auditlog_archives (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    org_id UUID NOT NULL,
+    from_seq BIGINT NOT NULL,
+    to_seq BIGINT NOT NULL,
+    start_time timestamptz NOT NULL,
+    end_time timestamptz NOT NULL,
+    log_count INTEGER NOT NULL,
+    last_hash TEXT NOT NULL DEFAULT '',
+    object_key TEXT NOT NULL UNIQUE,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS auditlog_archives_org_id_to_seq_idx ON auditlog_archives (org_id, to_seq);
+CREATE INDEX IF NOT EXISTS auditlog_archives_org_id_time_idx ON auditlog_archives (org_id, start_time, end_time);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS auditlogs_search_idx;
+DROP INDEX IF EXISTS auditlogs_metadata_idx;
+DROP INDEX IF EXISTS auditlogs_target_id_idx;
+DROP INDEX IF EXISTS auditlogs_actor_id_idx;
+DROP INDEX IF EXISTS auditlogs_org_id_created_at_id_idx;
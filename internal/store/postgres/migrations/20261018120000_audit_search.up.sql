@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS auditlogs_org_id_created_at_id_idx ON auditlogs (org_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS auditlogs_actor_id_idx ON auditlogs ((actor->>'ID'));
+CREATE INDEX IF NOT EXISTS auditlogs_target_id_idx ON auditlogs ((target->>'ID'));
+CREATE INDEX IF NOT EXISTS auditlogs_metadata_idx ON auditlogs USING GIN (metadata jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS auditlogs_search_idx ON auditlogs USING GIN (
+    to_tsvector('simple', action || ' ' || source || ' ' || actor::text || ' ' || target::text || ' ' || metadata::text)
+);
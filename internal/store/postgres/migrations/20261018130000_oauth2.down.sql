@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS oauth2_consents;
+DROP TABLE IF EXISTS oauth2_authorizations;
+DROP TABLE IF EXISTS oauth2_clients;
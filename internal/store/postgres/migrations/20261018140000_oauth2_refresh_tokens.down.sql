@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS token_denylist;
+DROP TABLE IF EXISTS oauth2_refresh_tokens;
+ALTER TABLE oauth2_authorizations DROP COLUMN IF EXISTS session_id;
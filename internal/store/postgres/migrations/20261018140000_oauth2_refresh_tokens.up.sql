@@ -0,0 +1,26 @@
+ALTER TABLE oauth2_authorizations ADD COLUMN IF NOT EXISTS session_id UUID;
+
+CREATE TABLE IF NOT EXISTS oauth2_refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    family_id UUID NOT NULL,
+    client_id UUID NOT NULL REFERENCES oauth2_clients(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    token_hash TEXT NOT NULL UNIQUE,
+    access_token_id TEXT NOT NULL DEFAULT '',
+    access_token_expires_at timestamptz,
+    expires_at timestamptz NOT NULL,
+    used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS oauth2_refresh_tokens_family_id_idx ON oauth2_refresh_tokens (family_id);
+CREATE INDEX IF NOT EXISTS oauth2_refresh_tokens_expires_at_idx ON oauth2_refresh_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS token_denylist (
+    jti TEXT PRIMARY KEY,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS token_denylist_expires_at_idx ON token_denylist (expires_at);
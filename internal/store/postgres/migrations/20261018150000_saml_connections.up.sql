@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS saml_connections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    org_id UUID NOT NULL UNIQUE REFERENCES organizations(id) ON DELETE CASCADE,
+    entity_id TEXT NOT NULL,
+    metadata_url TEXT NOT NULL DEFAULT '',
+    metadata TEXT NOT NULL,
+    attribute_mapping JSONB,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
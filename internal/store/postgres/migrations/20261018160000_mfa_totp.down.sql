@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mfa_totp;
+ALTER TABLE sessions DROP COLUMN IF EXISTS state;
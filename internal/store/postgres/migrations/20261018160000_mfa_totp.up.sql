@@ -0,0 +1,11 @@
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS state TEXT NOT NULL DEFAULT 'active';
+
+CREATE TABLE IF NOT EXISTS mfa_totp (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    recovery_codes JSONB NOT NULL DEFAULT '[]'::jsonb,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    confirmed_at timestamptz,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key TEXT NOT NULL,
+    window_start timestamptz NOT NULL,
+    hits BIGINT NOT NULL DEFAULT 0,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (key, window_start)
+);
+CREATE INDEX IF NOT EXISTS rate_limits_expires_at_idx ON rate_limits(expires_at);
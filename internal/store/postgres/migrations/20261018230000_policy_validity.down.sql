@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS policies_expires_at_idx;
+ALTER TABLE policies DROP COLUMN IF EXISTS starts_at;
+ALTER TABLE policies DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,3 @@
+ALTER TABLE policies ADD COLUMN IF NOT EXISTS starts_at timestamptz;
+ALTER TABLE policies ADD COLUMN IF NOT EXISTS expires_at timestamptz;
+CREATE INDEX IF NOT EXISTS policies_expires_at_idx ON policies(expires_at) WHERE expires_at IS NOT NULL;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS access_requests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    role_id UUID NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    resource_id UUID NOT NULL,
+    resource_type TEXT NOT NULL REFERENCES namespaces(name),
+    principal_id UUID NOT NULL,
+    principal_type TEXT NOT NULL REFERENCES namespaces(name),
+    justification TEXT NOT NULL,
+    duration_seconds BIGINT NOT NULL,
+    state TEXT NOT NULL DEFAULT 'pending',
+    reviewer_id UUID,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at timestamptz,
+    policy_id UUID,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    updated_at timestamptz NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS access_requests_resource_idx ON access_requests(resource_type, resource_id, state);
+CREATE INDEX IF NOT EXISTS access_requests_principal_idx ON access_requests(principal_id);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS saml_assertions (
+    issuer TEXT NOT NULL,
+    id TEXT NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (issuer, id)
+);
+CREATE INDEX IF NOT EXISTS saml_assertions_expires_at_idx ON saml_assertions(expires_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS oauth2_refresh_tokens_user_id_idx;
+DROP INDEX IF EXISTS oauth2_refresh_tokens_session_id_idx;
+DELETE FROM oauth2_refresh_tokens WHERE client_id IS NULL;
+ALTER TABLE oauth2_refresh_tokens ALTER COLUMN client_id SET NOT NULL;
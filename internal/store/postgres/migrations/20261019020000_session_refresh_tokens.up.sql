@@ -0,0 +1,4 @@
+-- refresh tokens of sessions aren't issued to an oauth2 client
+ALTER TABLE oauth2_refresh_tokens ALTER COLUMN client_id DROP NOT NULL;
+CREATE INDEX IF NOT EXISTS oauth2_refresh_tokens_session_id_idx ON oauth2_refresh_tokens (session_id);
+CREATE INDEX IF NOT EXISTS oauth2_refresh_tokens_user_id_idx ON oauth2_refresh_tokens (user_id);
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/pkg/metadata"
+)
+
+type OAuth2Client struct {
+	ID           string             `db:"id"`
+	Name         string             `db:"name"`
+	SecretHash   string             `db:"secret_hash"`
+	RedirectURIs pq.StringArray     `db:"redirect_uris"`
+	Scopes       pq.StringArray     `db:"scopes"`
+	Public       bool               `db:"public"`
+	Metadata     types.NullJSONText `db:"metadata"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (c OAuth2Client) transform() (oauth2.Client, error) {
+	var unmarshalledMetadata metadata.Metadata
+	if c.Metadata.Valid {
+		if err := c.Metadata.Unmarshal(&unmarshalledMetadata); err != nil {
+			return oauth2.Client{}, err
+		}
+	}
+	return oauth2.Client{
+		ID:           c.ID,
+		Name:         c.Name,
+		SecretHash:   c.SecretHash,
+		RedirectURIs: c.RedirectURIs,
+		Scopes:       c.Scopes,
+		Public:       c.Public,
+		Metadata:     unmarshalledMetadata,
+		CreatedAt:    c.CreatedAt,
+		UpdatedAt:    c.UpdatedAt,
+	}, nil
+}
+
+type OAuth2Authorization struct {
+	ID                  string         `db:"id"`
+	ClientID            string         `db:"client_id"`
+	UserID              string         `db:"user_id"`
+	RedirectURI         string         `db:"redirect_uri"`
+	Scopes              pq.StringArray `db:"scopes"`
+	State               string         `db:"state"`
+	CodeChallenge       string         `db:"code_challenge"`
+	CodeChallengeMethod string         `db:"code_challenge_method"`
+	Nonce               string         `db:"nonce"`
+	CodeHash            sql.NullString `db:"code_hash"`
+	SessionID           sql.NullString `db:"session_id"`
+
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (a OAuth2Authorization) transform() oauth2.Authorization {
+	return oauth2.Authorization{
+		ID:                  a.ID,
+		ClientID:            a.ClientID,
+		UserID:              a.UserID,
+		RedirectURI:         a.RedirectURI,
+		Scopes:              a.Scopes,
+		State:               a.State,
+		CodeChallenge:       a.CodeChallenge,
+		CodeChallengeMethod: a.CodeChallengeMethod,
+		Nonce:               a.Nonce,
+		CodeHash:            a.CodeHash.String,
+		SessionID:           a.SessionID.String,
+		ExpiresAt:           a.ExpiresAt,
+		CreatedAt:           a.CreatedAt,
+	}
+}
+
+type OAuth2Consent struct {
+	ClientID string         `db:"client_id"`
+	UserID   string         `db:"user_id"`
+	Scopes   pq.StringArray `db:"scopes"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (c OAuth2Consent) transform() oauth2.Consent {
+	return oauth2.Consent{
+		ClientID:  c.ClientID,
+		UserID:    c.UserID,
+		Scopes:    c.Scopes,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+type OAuth2RefreshToken struct {
+	ID                   string         `db:"id"`
+	FamilyID             string         `db:"family_id"`
+	ClientID             sql.NullString `db:"client_id"`
+	UserID               string         `db:"user_id"`
+	SessionID            string         `db:"session_id"`
+	Scopes               pq.StringArray `db:"scopes"`
+	TokenHash            string         `db:"token_hash"`
+	AccessTokenID        string         `db:"access_token_id"`
+	AccessTokenExpiresAt sql.NullTime   `db:"access_token_expires_at"`
+
+	ExpiresAt time.Time    `db:"expires_at"`
+	UsedAt    sql.NullTime `db:"used_at"`
+	RevokedAt sql.NullTime `db:"revoked_at"`
+	CreatedAt time.Time    `db:"created_at"`
+}
+
+func (t OAuth2RefreshToken) transform() oauth2.RefreshToken {
+	return oauth2.RefreshToken{
+		ID:                   t.ID,
+		FamilyID:             t.FamilyID,
+		ClientID:             t.ClientID.String,
+		UserID:               t.UserID,
+		SessionID:            t.SessionID,
+		Scopes:               t.Scopes,
+		TokenHash:            t.TokenHash,
+		AccessTokenID:        t.AccessTokenID,
+		AccessTokenExpiresAt: t.AccessTokenExpiresAt.Time,
+		ExpiresAt:            t.ExpiresAt,
+		UsedAt:               t.UsedAt.Time,
+		RevokedAt:            t.RevokedAt.Time,
+		CreatedAt:            t.CreatedAt,
+	}
+}
+
+type OAuth2Device struct {
+	ID                  string         `db:"id"`
+	ClientID            string         `db:"client_id"`
+	Scopes              pq.StringArray `db:"scopes"`
+	DeviceCodeHash      string         `db:"device_code_hash"`
+	UserCode            string         `db:"user_code"`
+	Status              string         `db:"status"`
+	UserID              sql.NullString `db:"user_id"`
+	SessionID           sql.NullString `db:"session_id"`
+	PollIntervalSeconds int64          `db:"poll_interval_seconds"`
+	PolledAt            sql.NullTime   `db:"polled_at"`
+
+	ExpiresAt time.Time `db:"expires_at"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (d OAuth2Device) transform() oauth2.DeviceAuthorization {
+	return oauth2.DeviceAuthorization{
+		ID:             d.ID,
+		ClientID:       d.ClientID,
+		Scopes:         d.Scopes,
+		DeviceCodeHash: d.DeviceCodeHash,
+		UserCode:       d.UserCode,
+		Status:         oauth2.DeviceStatus(d.Status),
+		UserID:         d.UserID.String,
+		SessionID:      d.SessionID.String,
+		Interval:       time.Duration(d.PollIntervalSeconds) * time.Second,
+		PolledAt:       d.PolledAt.Time,
+		ExpiresAt:      d.ExpiresAt,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+type DeniedToken struct {
+	JTI       string    `db:"jti"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type OAuth2AuthorizationRepository struct {
+	dbc *db.Client
+}
+
+func NewOAuth2AuthorizationRepository(dbc *db.Client) *OAuth2AuthorizationRepository {
+	return &OAuth2AuthorizationRepository{
+		dbc: dbc,
+	}
+}
+
+func (r OAuth2AuthorizationRepository) Create(ctx context.Context, toCreate oauth2.Authorization) (oauth2.Authorization, error) {
+	record := goqu.Record{
+		"client_id":             toCreate.ClientID,
+		"user_id":               toCreate.UserID,
+		"redirect_uri":          toCreate.RedirectURI,
+		"scopes":                pq.StringArray(toCreate.Scopes),
+		"state":                 toCreate.State,
+		"code_challenge":        toCreate.CodeChallenge,
+		"code_challenge_method": toCreate.CodeChallengeMethod,
+		"nonce":                 toCreate.Nonce,
+		"expires_at":            toCreate.ExpiresAt,
+	}
+	if toCreate.Scopes == nil {
+		record["scopes"] = pq.StringArray{}
+	}
+	if toCreate.CodeHash != "" {
+		record["code_hash"] = toCreate.CodeHash
+	}
+	if toCreate.SessionID != "" {
+		record["session_id"] = toCreate.SessionID
+	}
+	query, params, err := dialect.Insert(TABLE_OAUTH2_AUTHORIZATIONS).Rows(record).
+		Returning(&OAuth2Authorization{}).ToSQL()
+	if err != nil {
+		return oauth2.Authorization{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var authModel OAuth2Authorization
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_AUTHORIZATIONS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&authModel)
+	}); err != nil {
+		return oauth2.Authorization{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return authModel.transform(), nil
+}
+
+func (r OAuth2AuthorizationRepository) GetByID(ctx context.Context, id string) (oauth2.Authorization, error) {
+	query, params, err := dialect.From(TABLE_OAUTH2_AUTHORIZATIONS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return oauth2.Authorization{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var authModel OAuth2Authorization
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_AUTHORIZATIONS, "GetByID", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &authModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return oauth2.Authorization{}, oauth2.ErrAuthorizationNotFound
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return oauth2.Authorization{}, oauth2.ErrAuthorizationNotFound
+		default:
+			return oauth2.Authorization{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return authModel.transform(), nil
+}
+
+func (r OAuth2AuthorizationRepository) SetCode(ctx context.Context, id, codeHash string, expiresAt time.Time) error {
+	query, params, err := dialect.Update(TABLE_OAUTH2_AUTHORIZATIONS).Set(
+		goqu.Record{
+			"code_hash":  codeHash,
+			"expires_at": expiresAt,
+		}).Where(
+		goqu.Ex{
+			"id":        id,
+			"code_hash": nil,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_AUTHORIZATIONS, "SetCode", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		// a concurrent consent could have issued the code already
+		if count, _ := result.RowsAffected(); count == 0 {
+			return oauth2.ErrAuthorizationNotFound
+		}
+		return nil
+	})
+}
+
+func (r OAuth2AuthorizationRepository) Consume(ctx context.Context, codeHash string) (oauth2.Authorization, error) {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_AUTHORIZATIONS).Where(
+		goqu.Ex{
+			"code_hash": codeHash,
+		}).Returning(&OAuth2Authorization{}).ToSQL()
+	if err != nil {
+		return oauth2.Authorization{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var authModel OAuth2Authorization
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_AUTHORIZATIONS, "Consume", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&authModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return oauth2.Authorization{}, oauth2.ErrAuthorizationNotFound
+		}
+		return oauth2.Authorization{}, fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return authModel.transform(), nil
+}
+
+func (r OAuth2AuthorizationRepository) Delete(ctx context.Context, id string) error {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_AUTHORIZATIONS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_AUTHORIZATIONS, "Delete", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
+
+func (r OAuth2AuthorizationRepository) DeleteExpired(ctx context.Context) error {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_AUTHORIZATIONS).Where(
+		goqu.Ex{
+			"expires_at": goqu.Op{"lte": goqu.L("now()")},
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_AUTHORIZATIONS, "DeleteExpired", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,165 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type OAuth2ClientRepository struct {
+	dbc *db.Client
+}
+
+func NewOAuth2ClientRepository(dbc *db.Client) *OAuth2ClientRepository {
+	return &OAuth2ClientRepository{
+		dbc: dbc,
+	}
+}
+
+func (r OAuth2ClientRepository) Create(ctx context.Context, toCreate oauth2.Client) (oauth2.Client, error) {
+	if toCreate.Metadata == nil {
+		toCreate.Metadata = make(map[string]any)
+	}
+	marshaledMetadata, err := json.Marshal(toCreate.Metadata)
+	if err != nil {
+		return oauth2.Client{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	if toCreate.Scopes == nil {
+		toCreate.Scopes = []string{}
+	}
+	if toCreate.RedirectURIs == nil {
+		toCreate.RedirectURIs = []string{}
+	}
+
+	query, params, err := dialect.Insert(TABLE_OAUTH2_CLIENTS).Rows(
+		goqu.Record{
+			"name":          toCreate.Name,
+			"secret_hash":   toCreate.SecretHash,
+			"redirect_uris": pq.StringArray(toCreate.RedirectURIs),
+			"scopes":        pq.StringArray(toCreate.Scopes),
+			"public":        toCreate.Public,
+			"metadata":      marshaledMetadata,
+		}).Returning(&OAuth2Client{}).ToSQL()
+	if err != nil {
+		return oauth2.Client{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var clientModel OAuth2Client
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CLIENTS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&clientModel)
+	}); err != nil {
+		return oauth2.Client{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return clientModel.transform()
+}
+
+func (r OAuth2ClientRepository) GetByID(ctx context.Context, id string) (oauth2.Client, error) {
+	query, params, err := dialect.From(TABLE_OAUTH2_CLIENTS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return oauth2.Client{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var clientModel OAuth2Client
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CLIENTS, "GetByID", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &clientModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return oauth2.Client{}, oauth2.ErrClientNotFound
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return oauth2.Client{}, oauth2.ErrClientNotFound
+		default:
+			return oauth2.Client{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return clientModel.transform()
+}
+
+func (r OAuth2ClientRepository) List(ctx context.Context) ([]oauth2.Client, error) {
+	query, params, err := dialect.From(TABLE_OAUTH2_CLIENTS).Order(goqu.I("created_at").Desc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var clientModels []OAuth2Client
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CLIENTS, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &clientModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	clients := make([]oauth2.Client, 0, len(clientModels))
+	for _, c := range clientModels {
+		client, err := c.transform()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func (r OAuth2ClientRepository) UpdateSecret(ctx context.Context, id, secretHash string) error {
+	query, params, err := dialect.Update(TABLE_OAUTH2_CLIENTS).Set(
+		goqu.Record{
+			"secret_hash": secretHash,
+			"updated_at":  goqu.L("now()"),
+		}).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CLIENTS, "UpdateSecret", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			if errors.Is(err, ErrInvalidTextRepresentation) {
+				return oauth2.ErrClientNotFound
+			}
+			return fmt.Errorf("%w: %w", dbErr, err)
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return oauth2.ErrClientNotFound
+		}
+		return nil
+	})
+}
+
+func (r OAuth2ClientRepository) Delete(ctx context.Context, id string) error {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_CLIENTS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CLIENTS, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			if errors.Is(err, ErrInvalidTextRepresentation) {
+				return oauth2.ErrClientNotFound
+			}
+			return fmt.Errorf("%w: %w", dbErr, err)
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return oauth2.ErrClientNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type OAuth2ConsentRepository struct {
+	dbc *db.Client
+}
+
+func NewOAuth2ConsentRepository(dbc *db.Client) *OAuth2ConsentRepository {
+	return &OAuth2ConsentRepository{
+		dbc: dbc,
+	}
+}
+
+func (r OAuth2ConsentRepository) Get(ctx context.Context, clientID, userID string) (oauth2.Consent, error) {
+	query, params, err := dialect.From(TABLE_OAUTH2_CONSENTS).Where(
+		goqu.Ex{
+			"client_id": clientID,
+			"user_id":   userID,
+		}).ToSQL()
+	if err != nil {
+		return oauth2.Consent{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var consentModel OAuth2Consent
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CONSENTS, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &consentModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return oauth2.Consent{}, oauth2.ErrConsentNotFound
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return oauth2.Consent{}, oauth2.ErrConsentNotFound
+		default:
+			return oauth2.Consent{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return consentModel.transform(), nil
+}
+
+func (r OAuth2ConsentRepository) Upsert(ctx context.Context, consent oauth2.Consent) (oauth2.Consent, error) {
+	query, params, err := dialect.Insert(TABLE_OAUTH2_CONSENTS).Rows(
+		goqu.Record{
+			"client_id": consent.ClientID,
+			"user_id":   consent.UserID,
+			"scopes":    pq.StringArray(consent.Scopes),
+		}).OnConflict(goqu.DoUpdate("client_id, user_id", goqu.Record{
+		"scopes":     pq.StringArray(consent.Scopes),
+		"updated_at": goqu.L("now()"),
+	})).Returning(&OAuth2Consent{}).ToSQL()
+	if err != nil {
+		return oauth2.Consent{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var consentModel OAuth2Consent
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_CONSENTS, "Upsert", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&consentModel)
+	}); err != nil {
+		return oauth2.Consent{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return consentModel.transform(), nil
+}
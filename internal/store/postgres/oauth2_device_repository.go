@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type OAuth2DeviceRepository struct {
+	dbc *db.Client
+}
+
+func NewOAuth2DeviceRepository(dbc *db.Client) *OAuth2DeviceRepository {
+	return &OAuth2DeviceRepository{
+		dbc: dbc,
+	}
+}
+
+func (r OAuth2DeviceRepository) Create(ctx context.Context, toCreate oauth2.DeviceAuthorization) (oauth2.DeviceAuthorization, error) {
+	if toCreate.Scopes == nil {
+		toCreate.Scopes = []string{}
+	}
+	query, params, err := dialect.Insert(TABLE_OAUTH2_DEVICES).Rows(
+		goqu.Record{
+			"client_id":             toCreate.ClientID,
+			"scopes":                pq.StringArray(toCreate.Scopes),
+			"device_code_hash":      toCreate.DeviceCodeHash,
+			"user_code":             toCreate.UserCode,
+			"status":                string(toCreate.Status),
+			"poll_interval_seconds": int64(toCreate.Interval.Seconds()),
+			"expires_at":            toCreate.ExpiresAt,
+		}).Returning(&OAuth2Device{}).ToSQL()
+	if err != nil {
+		return oauth2.DeviceAuthorization{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var deviceModel OAuth2Device
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&deviceModel)
+	}); err != nil {
+		return oauth2.DeviceAuthorization{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return deviceModel.transform(), nil
+}
+
+func (r OAuth2DeviceRepository) GetByUserCode(ctx context.Context, userCode string) (oauth2.DeviceAuthorization, error) {
+	return r.get(ctx, "GetByUserCode", goqu.Ex{
+		"user_code": userCode,
+	})
+}
+
+func (r OAuth2DeviceRepository) GetByDeviceCode(ctx context.Context, deviceCodeHash string) (oauth2.DeviceAuthorization, error) {
+	return r.get(ctx, "GetByDeviceCode", goqu.Ex{
+		"device_code_hash": deviceCodeHash,
+	})
+}
+
+func (r OAuth2DeviceRepository) get(ctx context.Context, operation string, filter goqu.Ex) (oauth2.DeviceAuthorization, error) {
+	query, params, err := dialect.From(TABLE_OAUTH2_DEVICES).Where(filter).ToSQL()
+	if err != nil {
+		return oauth2.DeviceAuthorization{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var deviceModel OAuth2Device
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, operation, func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &deviceModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return oauth2.DeviceAuthorization{}, oauth2.ErrDeviceNotFound
+		}
+		return oauth2.DeviceAuthorization{}, fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return deviceModel.transform(), nil
+}
+
+func (r OAuth2DeviceRepository) SetPolledAt(ctx context.Context, id string, at time.Time) error {
+	query, params, err := dialect.Update(TABLE_OAUTH2_DEVICES).Set(
+		goqu.Record{
+			"polled_at": at,
+		}).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, "SetPolledAt", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
+
+func (r OAuth2DeviceRepository) Decide(ctx context.Context, id string, status oauth2.DeviceStatus, userID, sessionID string) error {
+	record := goqu.Record{
+		"status": string(status),
+	}
+	if userID != "" {
+		record["user_id"] = userID
+	}
+	if sessionID != "" {
+		record["session_id"] = sessionID
+	}
+	query, params, err := dialect.Update(TABLE_OAUTH2_DEVICES).Set(record).Where(
+		goqu.Ex{
+			"id":     id,
+			"status": string(oauth2.DeviceStatusPending),
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, "Decide", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		// another user could have decided already
+		if count, _ := result.RowsAffected(); count == 0 {
+			return oauth2.ErrDeviceNotFound
+		}
+		return nil
+	})
+}
+
+func (r OAuth2DeviceRepository) Consume(ctx context.Context, id string) (oauth2.DeviceAuthorization, error) {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_DEVICES).Where(
+		goqu.Ex{
+			"id":     id,
+			"status": string(oauth2.DeviceStatusApproved),
+		}).Returning(&OAuth2Device{}).ToSQL()
+	if err != nil {
+		return oauth2.DeviceAuthorization{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var deviceModel OAuth2Device
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, "Consume", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&deviceModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return oauth2.DeviceAuthorization{}, oauth2.ErrDeviceNotFound
+		}
+		return oauth2.DeviceAuthorization{}, fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return deviceModel.transform(), nil
+}
+
+func (r OAuth2DeviceRepository) Delete(ctx context.Context, id string) error {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_DEVICES).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, "Delete", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
+
+func (r OAuth2DeviceRepository) DeleteExpired(ctx context.Context) error {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_DEVICES).Where(
+		goqu.Ex{
+			"expires_at": goqu.Op{"lte": goqu.L("now()")},
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_DEVICES, "DeleteExpired", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
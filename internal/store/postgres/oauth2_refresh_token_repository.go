@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/oauth2"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type OAuth2RefreshTokenRepository struct {
+	dbc *db.Client
+}
+
+func NewOAuth2RefreshTokenRepository(dbc *db.Client) *OAuth2RefreshTokenRepository {
+	return &OAuth2RefreshTokenRepository{
+		dbc: dbc,
+	}
+}
+
+func (r OAuth2RefreshTokenRepository) Create(ctx context.Context, toCreate oauth2.RefreshToken) (oauth2.RefreshToken, error) {
+	record := goqu.Record{
+		"family_id":       toCreate.FamilyID,
+		"user_id":         toCreate.UserID,
+		"session_id":      toCreate.SessionID,
+		"scopes":          pq.StringArray(toCreate.Scopes),
+		"token_hash":      toCreate.TokenHash,
+		"access_token_id": toCreate.AccessTokenID,
+		"expires_at":      toCreate.ExpiresAt,
+	}
+	if toCreate.ClientID != "" {
+		record["client_id"] = toCreate.ClientID
+	}
+	if toCreate.Scopes == nil {
+		record["scopes"] = pq.StringArray{}
+	}
+	if !toCreate.AccessTokenExpiresAt.IsZero() {
+		record["access_token_expires_at"] = toCreate.AccessTokenExpiresAt
+	}
+	query, params, err := dialect.Insert(TABLE_OAUTH2_REFRESH_TOKENS).Rows(record).
+		Returning(&OAuth2RefreshToken{}).ToSQL()
+	if err != nil {
+		return oauth2.RefreshToken{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var tokenModel OAuth2RefreshToken
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_REFRESH_TOKENS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&tokenModel)
+	}); err != nil {
+		return oauth2.RefreshToken{}, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	return tokenModel.transform(), nil
+}
+
+func (r OAuth2RefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (oauth2.RefreshToken, error) {
+	query, params, err := dialect.From(TABLE_OAUTH2_REFRESH_TOKENS).Where(
+		goqu.Ex{
+			"token_hash": tokenHash,
+		}).ToSQL()
+	if err != nil {
+		return oauth2.RefreshToken{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var tokenModel OAuth2RefreshToken
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_REFRESH_TOKENS, "GetByHash", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &tokenModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return oauth2.RefreshToken{}, oauth2.ErrRefreshTokenNotFound
+		}
+		return oauth2.RefreshToken{}, fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return tokenModel.transform(), nil
+}
+
+func (r OAuth2RefreshTokenRepository) Use(ctx context.Context, id string) error {
+	query, params, err := dialect.Update(TABLE_OAUTH2_REFRESH_TOKENS).Set(
+		goqu.Record{
+			"used_at": goqu.L("now()"),
+		}).Where(
+		goqu.Ex{
+			"id":         id,
+			"used_at":    nil,
+			"revoked_at": nil,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_REFRESH_TOKENS, "Use", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return oauth2.ErrRefreshTokenNotFound
+		}
+		return nil
+	})
+}
+
+func (r OAuth2RefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string) ([]oauth2.RefreshToken, error) {
+	return r.revoke(ctx, "RevokeFamily", goqu.Ex{"family_id": familyID})
+}
+
+func (r OAuth2RefreshTokenRepository) RevokeSession(ctx context.Context, sessionID string) ([]oauth2.RefreshToken, error) {
+	return r.revoke(ctx, "RevokeSession", goqu.Ex{"session_id": sessionID})
+}
+
+func (r OAuth2RefreshTokenRepository) RevokeUser(ctx context.Context, userID string) ([]oauth2.RefreshToken, error) {
+	return r.revoke(ctx, "RevokeUser", goqu.Ex{"user_id": userID})
+}
+
+// revoke revokes the tokens matching the condition which aren't revoked yet
+// and returns them
+func (r OAuth2RefreshTokenRepository) revoke(ctx context.Context, operation string, where goqu.Ex) ([]oauth2.RefreshToken, error) {
+	where["revoked_at"] = nil
+	query, params, err := dialect.Update(TABLE_OAUTH2_REFRESH_TOKENS).Set(
+		goqu.Record{
+			"revoked_at": goqu.L("now()"),
+		}).Where(where).Returning(&OAuth2RefreshToken{}).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var tokenModels []OAuth2RefreshToken
+	if err = r.dbc.WithTimeout(ctx, TABLE_OAUTH2_REFRESH_TOKENS, operation, func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &tokenModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	tokens := make([]oauth2.RefreshToken, 0, len(tokenModels))
+	for _, t := range tokenModels {
+		tokens = append(tokens, t.transform())
+	}
+	return tokens, nil
+}
+
+func (r OAuth2RefreshTokenRepository) DeleteExpired(ctx context.Context) error {
+	query, params, err := dialect.Delete(TABLE_OAUTH2_REFRESH_TOKENS).Where(
+		goqu.Ex{
+			"expires_at": goqu.Op{"lte": goqu.L("now()")},
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OAUTH2_REFRESH_TOKENS, "DeleteExpired", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/pkg/crypt"
+)
+
+type OIDCProvider struct {
+	ID           string `db:"id"`
+	OrgID        string `db:"org_id"`
+	IssuerURL    string `db:"issuer_url"`
+	ClientID     string `db:"client_id"`
+	ClientSecret string `db:"client_secret"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (p OIDCProvider) transform(encryptionKey []byte) (idp.Provider, error) {
+	encryptedSecret, err := base64.RawStdEncoding.DecodeString(p.ClientSecret)
+	if err != nil {
+		return idp.Provider{}, err
+	}
+	secret, err := crypt.Decrypt(encryptedSecret, encryptionKey)
+	if err != nil {
+		return idp.Provider{}, err
+	}
+	return idp.Provider{
+		ID:           p.ID,
+		OrgID:        p.OrgID,
+		IssuerURL:    p.IssuerURL,
+		ClientID:     p.ClientID,
+		ClientSecret: string(secret),
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/idp"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/pkg/crypt"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// OIDCProviderRepository stores the identity providers of organizations, the
+// client secrets are encrypted at rest
+type OIDCProviderRepository struct {
+	dbc           *db.Client
+	encryptionKey []byte
+}
+
+func NewOIDCProviderRepository(dbc *db.Client, encryptionKey []byte) *OIDCProviderRepository {
+	return &OIDCProviderRepository{
+		dbc:           dbc,
+		encryptionKey: encryptionKey,
+	}
+}
+
+func (r OIDCProviderRepository) Create(ctx context.Context, toCreate idp.Provider) (idp.Provider, error) {
+	encryptedSecret, err := crypt.Encrypt([]byte(toCreate.ClientSecret), r.encryptionKey)
+	if err != nil {
+		return idp.Provider{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+
+	query, params, err := dialect.Insert(TABLE_OIDC_PROVIDERS).Rows(
+		goqu.Record{
+			"org_id":        toCreate.OrgID,
+			"issuer_url":    toCreate.IssuerURL,
+			"client_id":     toCreate.ClientID,
+			"client_secret": base64.RawStdEncoding.EncodeToString(encryptedSecret),
+		}).Returning(&OIDCProvider{}).ToSQL()
+	if err != nil {
+		return idp.Provider{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var providerModel OIDCProvider
+	if err = r.dbc.WithTimeout(ctx, TABLE_OIDC_PROVIDERS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&providerModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, ErrDuplicateKey):
+			return idp.Provider{}, idp.ErrConflict
+		case errors.Is(err, ErrForeignKeyViolation):
+			return idp.Provider{}, organization.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return idp.Provider{}, organization.ErrInvalidUUID
+		default:
+			return idp.Provider{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+
+	provider, err := providerModel.transform(r.encryptionKey)
+	if err != nil {
+		return idp.Provider{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	return provider, nil
+}
+
+func (r OIDCProviderRepository) GetByOrgID(ctx context.Context, orgID string) (idp.Provider, error) {
+	query, params, err := dialect.From(TABLE_OIDC_PROVIDERS).Where(
+		goqu.Ex{
+			"org_id": orgID,
+		}).ToSQL()
+	if err != nil {
+		return idp.Provider{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var providerModel OIDCProvider
+	if err = r.dbc.WithTimeout(ctx, TABLE_OIDC_PROVIDERS, "GetByOrgID", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &providerModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return idp.Provider{}, idp.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return idp.Provider{}, idp.ErrNotExist
+		default:
+			return idp.Provider{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+
+	provider, err := providerModel.transform(r.encryptionKey)
+	if err != nil {
+		return idp.Provider{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+	return provider, nil
+}
+
+func (r OIDCProviderRepository) List(ctx context.Context) ([]idp.Provider, error) {
+	query, params, err := dialect.From(TABLE_OIDC_PROVIDERS).Order(goqu.I("created_at").Desc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var providerModels []OIDCProvider
+	if err = r.dbc.WithTimeout(ctx, TABLE_OIDC_PROVIDERS, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &providerModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	providers := make([]idp.Provider, 0, len(providerModels))
+	for _, p := range providerModels {
+		provider, err := p.transform(r.encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
+func (r OIDCProviderRepository) Delete(ctx context.Context, id string) error {
+	query, params, err := dialect.Delete(TABLE_OIDC_PROVIDERS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_OIDC_PROVIDERS, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			if errors.Is(err, ErrInvalidTextRepresentation) {
+				return idp.ErrNotExist
+			}
+			return fmt.Errorf("%w: %w", dbErr, err)
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return idp.ErrNotExist
+		}
+		return nil
+	})
+}
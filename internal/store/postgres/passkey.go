@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/passkey"
+)
+
+type Passkey struct {
+	ID              string         `db:"id"`
+	UserID          string         `db:"user_id"`
+	CredentialID    []byte         `db:"credential_id"`
+	PublicKey       []byte         `db:"public_key"`
+	AttestationType string         `db:"attestation_type"`
+	Transports      pq.StringArray `db:"transports"`
+	AAGUID          uuid.UUID      `db:"aaguid"`
+	SignCount       int64          `db:"sign_count"`
+	BackupEligible  bool           `db:"backup_eligible"`
+	BackupState     bool           `db:"backup_state"`
+	Nickname        string         `db:"nickname"`
+	LastUsedAt      sql.NullTime   `db:"last_used_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (p Passkey) transform() passkey.Passkey {
+	transformed := passkey.Passkey{
+		ID:              p.ID,
+		UserID:          p.UserID,
+		CredentialID:    p.CredentialID,
+		PublicKey:       p.PublicKey,
+		AttestationType: p.AttestationType,
+		Transports:      p.Transports,
+		AAGUID:          p.AAGUID,
+		SignCount:       uint32(p.SignCount),
+		BackupEligible:  p.BackupEligible,
+		BackupState:     p.BackupState,
+		Nickname:        p.Nickname,
+		CreatedAt:       p.CreatedAt,
+		UpdatedAt:       p.UpdatedAt,
+	}
+	if p.LastUsedAt.Valid {
+		transformed.LastUsedAt = p.LastUsedAt.Time
+	}
+	return transformed
+}
@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/lib/pq"
+	"github.com/raystack/frontier/core/passkey"
+	"github.com/raystack/frontier/core/user"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// PasskeyRepository stores the webauthn credentials registered by users
+type PasskeyRepository struct {
+	dbc *db.Client
+}
+
+func NewPasskeyRepository(dbc *db.Client) *PasskeyRepository {
+	return &PasskeyRepository{
+		dbc: dbc,
+	}
+}
+
+func (r PasskeyRepository) Create(ctx context.Context, toCreate passkey.Passkey) (passkey.Passkey, error) {
+	query, params, err := dialect.Insert(TABLE_PASSKEYS).Rows(goqu.Record{
+		"user_id":          toCreate.UserID,
+		"credential_id":    toCreate.CredentialID,
+		"public_key":       toCreate.PublicKey,
+		"attestation_type": toCreate.AttestationType,
+		"transports":       pq.StringArray(toCreate.Transports),
+		"aaguid":           toCreate.AAGUID,
+		"sign_count":       int64(toCreate.SignCount),
+		"backup_eligible":  toCreate.BackupEligible,
+		"backup_state":     toCreate.BackupState,
+		"nickname":         toCreate.Nickname,
+	}).Returning(&Passkey{}).ToSQL()
+	if err != nil {
+		return passkey.Passkey{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var passkeyModel Passkey
+	if err = r.dbc.WithTimeout(ctx, TABLE_PASSKEYS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&passkeyModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, ErrDuplicateKey):
+			return passkey.Passkey{}, passkey.ErrConflict
+		case errors.Is(err, ErrForeignKeyViolation):
+			return passkey.Passkey{}, user.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return passkey.Passkey{}, user.ErrInvalidUUID
+		default:
+			return passkey.Passkey{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return passkeyModel.transform(), nil
+}
+
+func (r PasskeyRepository) Get(ctx context.Context, id string) (passkey.Passkey, error) {
+	query, params, err := dialect.From(TABLE_PASSKEYS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return passkey.Passkey{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var passkeyModel Passkey
+	if err = r.dbc.WithTimeout(ctx, TABLE_PASSKEYS, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &passkeyModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return passkey.Passkey{}, passkey.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return passkey.Passkey{}, passkey.ErrNotExist
+		default:
+			return passkey.Passkey{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return passkeyModel.transform(), nil
+}
+
+func (r PasskeyRepository) ListByUser(ctx context.Context, userID string) ([]passkey.Passkey, error) {
+	query, params, err := dialect.From(TABLE_PASSKEYS).Where(
+		goqu.Ex{
+			"user_id": userID,
+		}).Order(goqu.I("created_at").Asc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var passkeyModels []Passkey
+	if err = r.dbc.WithTimeout(ctx, TABLE_PASSKEYS, "ListByUser", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &passkeyModels, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return []passkey.Passkey{}, nil
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return []passkey.Passkey{}, nil
+		default:
+			return nil, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+
+	passkeys := make([]passkey.Passkey, 0, len(passkeyModels))
+	for _, p := range passkeyModels {
+		passkeys = append(passkeys, p.transform())
+	}
+	return passkeys, nil
+}
+
+func (r PasskeyRepository) UpdateNickname(ctx context.Context, id, nickname string) (passkey.Passkey, error) {
+	query, params, err := dialect.Update(TABLE_PASSKEYS).Set(goqu.Record{
+		"nickname":   nickname,
+		"updated_at": goqu.L("now()"),
+	}).Where(goqu.Ex{
+		"id": id,
+	}).Returning(&Passkey{}).ToSQL()
+	if err != nil {
+		return passkey.Passkey{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var passkeyModel Passkey
+	if err = r.dbc.WithTimeout(ctx, TABLE_PASSKEYS, "UpdateNickname", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&passkeyModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return passkey.Passkey{}, passkey.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return passkey.Passkey{}, passkey.ErrNotExist
+		default:
+			return passkey.Passkey{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return passkeyModel.transform(), nil
+}
+
+func (r PasskeyRepository) UpdateUsage(ctx context.Context, id string, signCount uint32, backupState bool, usedAt time.Time) error {
+	query, params, err := dialect.Update(TABLE_PASSKEYS).Set(goqu.Record{
+		"sign_count":   int64(signCount),
+		"backup_state": backupState,
+		"last_used_at": usedAt,
+		"updated_at":   goqu.L("now()"),
+	}).Where(goqu.Ex{
+		"id": id,
+	}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_PASSKEYS, "UpdateUsage", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
+
+func (r PasskeyRepository) Delete(ctx context.Context, id string) error {
+	query, params, err := dialect.Delete(TABLE_PASSKEYS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_PASSKEYS, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			if errors.Is(err, ErrInvalidTextRepresentation) {
+				return passkey.ErrNotExist
+			}
+			return fmt.Errorf("%w: %w", dbErr, err)
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return passkey.ErrNotExist
+		}
+		return nil
+	})
+}
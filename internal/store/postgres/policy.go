@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 
@@ -10,26 +11,30 @@ import (
 type Policy struct {
 	ID            string `db:"id"`
 	Role          Role
-	RoleID        string    `db:"role_id"`
-	ResourceID    string    `db:"resource_id"`
-	ResourceType  string    `db:"resource_type"`
-	PrincipalID   string    `db:"principal_id"`
-	PrincipalType string    `db:"principal_type"`
-	Metadata      []byte    `db:"metadata"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at"`
+	RoleID        string       `db:"role_id"`
+	ResourceID    string       `db:"resource_id"`
+	ResourceType  string       `db:"resource_type"`
+	PrincipalID   string       `db:"principal_id"`
+	PrincipalType string       `db:"principal_type"`
+	Metadata      []byte       `db:"metadata"`
+	StartsAt      sql.NullTime `db:"starts_at"`
+	ExpiresAt     sql.NullTime `db:"expires_at"`
+	CreatedAt     time.Time    `db:"created_at"`
+	UpdatedAt     time.Time    `db:"updated_at"`
 }
 
 type PolicyCols struct {
-	ID            string    `db:"id"`
-	RoleID        string    `db:"role_id"`
-	ResourceType  string    `db:"resource_type"`
-	ResourceID    string    `db:"resource_id"`
-	PrincipalID   string    `db:"principal_id"`
-	PrincipalType string    `db:"principal_type"`
-	Metadata      []byte    `db:"metadata"`
-	CreatedAt     time.Time `db:"created_at"`
-	UpdatedAt     time.Time `db:"updated_at"`
+	ID            string       `db:"id"`
+	RoleID        string       `db:"role_id"`
+	ResourceType  string       `db:"resource_type"`
+	ResourceID    string       `db:"resource_id"`
+	PrincipalID   string       `db:"principal_id"`
+	PrincipalType string       `db:"principal_type"`
+	Metadata      []byte       `db:"metadata"`
+	StartsAt      sql.NullTime `db:"starts_at"`
+	ExpiresAt     sql.NullTime `db:"expires_at"`
+	CreatedAt     time.Time    `db:"created_at"`
+	UpdatedAt     time.Time    `db:"updated_at"`
 }
 
 func (from Policy) transformToPolicy() (policy.Policy, error) {
@@ -40,7 +45,7 @@ func (from Policy) transformToPolicy() (policy.Policy, error) {
 		}
 	}
 
-	return policy.Policy{
+	transformed := policy.Policy{
 		ID:            from.ID,
 		RoleID:        from.RoleID,
 		ResourceID:    from.ResourceID,
@@ -50,5 +55,12 @@ func (from Policy) transformToPolicy() (policy.Policy, error) {
 		Metadata:      unmarshalledMetadata,
 		CreatedAt:     from.CreatedAt,
 		UpdatedAt:     from.UpdatedAt,
-	}, nil
+	}
+	if from.StartsAt.Valid {
+		transformed.StartsAt = from.StartsAt.Time
+	}
+	if from.ExpiresAt.Valid {
+		transformed.ExpiresAt = from.ExpiresAt.Time
+	}
+	return transformed, nil
 }
@@ -34,6 +34,8 @@ func (r PolicyRepository) buildListQuery() *goqu.SelectDataset {
 		"p.principal_id",
 		"p.principal_type",
 		"p.role_id",
+		"p.starts_at",
+		"p.expires_at",
 	).From(goqu.T(TABLE_POLICIES).As("p"))
 }
 
@@ -123,6 +125,12 @@ func applyListFilter(stmt *goqu.SelectDataset, flt policy.Filter) *goqu.SelectDa
 			"role_id": flt.RoleIDs,
 		})
 	}
+	if !flt.ExpiresBefore.IsZero() {
+		stmt = stmt.Where(goqu.C("expires_at").Lte(flt.ExpiresBefore))
+	}
+	if !flt.ExpiresAfter.IsZero() {
+		stmt = stmt.Where(goqu.C("expires_at").Gt(flt.ExpiresAfter))
+	}
 	return stmt
 }
 
@@ -198,8 +206,12 @@ func (r PolicyRepository) Upsert(ctx context.Context, pol policy.Policy) (policy
 			"principal_id":   pol.PrincipalID,
 			"principal_type": pol.PrincipalType,
 			"metadata":       marshaledMetadata,
+			"starts_at":      sql.NullTime{Time: pol.StartsAt, Valid: !pol.StartsAt.IsZero()},
+			"expires_at":     sql.NullTime{Time: pol.ExpiresAt, Valid: !pol.ExpiresAt.IsZero()},
 		}).OnConflict(goqu.DoUpdate("role_id, resource_id, resource_type, principal_id, principal_type", goqu.Record{
-		"metadata": marshaledMetadata,
+		"metadata":   marshaledMetadata,
+		"starts_at":  sql.NullTime{Time: pol.StartsAt, Valid: !pol.StartsAt.IsZero()},
+		"expires_at": sql.NullTime{Time: pol.ExpiresAt, Valid: !pol.ExpiresAt.IsZero()},
 	})).Returning(&PolicyCols{}).ToSQL()
 	if err != nil {
 		return policy.Policy{}, fmt.Errorf("%w: %w", queryErr, err)
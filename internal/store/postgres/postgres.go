@@ -38,6 +38,8 @@ const (
 	TABLE_SERVICEUSER            = "serviceusers"
 	TABLE_SERVICEUSERCREDENTIALS = "serviceuser_credentials"
 	TABLE_AUDITLOGS              = "auditlogs"
+	TABLE_AUDIT_RETENTION        = "audit_retention_policies"
+	TABLE_AUDIT_ARCHIVES         = "auditlog_archives"
 	TABLE_DOMAINS                = "domains"
 	TABLE_PREFERENCES            = "preferences"
 	TABLE_BILLING_CUSTOMERS      = "billing_customers"
@@ -50,6 +52,24 @@ const (
 	TABLE_BILLING_TRANSACTIONS   = "billing_transactions"
 	TABLE_BILLING_INVOICES       = "billing_invoices"
 	TABLE_WEBHOOK_ENDPOINTS      = "webhook_endpoints"
+	TABLE_WEBHOOK_EVENTS         = "webhook_events"
+	TABLE_WEBHOOK_DELIVERIES     = "webhook_deliveries"
+	TABLE_WEBHOOK_ATTEMPTS       = "webhook_delivery_attempts"
+	TABLE_OAUTH2_CLIENTS         = "oauth2_clients"
+	TABLE_OAUTH2_AUTHORIZATIONS  = "oauth2_authorizations"
+	TABLE_OAUTH2_CONSENTS        = "oauth2_consents"
+	TABLE_OAUTH2_REFRESH_TOKENS  = "oauth2_refresh_tokens"
+	TABLE_OAUTH2_DEVICES         = "oauth2_devices"
+	TABLE_TOKEN_DENYLIST         = "token_denylist"
+	TABLE_SAML_CONNECTIONS       = "saml_connections"
+	TABLE_SAML_ASSERTIONS        = "saml_assertions"
+	TABLE_MFA_TOTP               = "mfa_totp"
+	TABLE_RATE_LIMITS            = "rate_limits"
+	TABLE_USER_PASSWORDS         = "user_passwords"
+	TABLE_PASSKEYS               = "passkeys"
+	TABLE_OIDC_PROVIDERS         = "oidc_providers"
+	TABLE_ACCESS_REQUESTS        = "access_requests"
+	TABLE_SCIM_PROFILES          = "scim_profiles"
 )
 
 func checkPostgresError(err error) error {
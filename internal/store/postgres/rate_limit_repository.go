@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// RateLimitRepository counts the hits of rate limited keys in the database,
+// limits are shared by all replicas of the server
+type RateLimitRepository struct {
+	dbc *db.Client
+}
+
+func NewRateLimitRepository(dbc *db.Client) *RateLimitRepository {
+	return &RateLimitRepository{
+		dbc: dbc,
+	}
+}
+
+func (r RateLimitRepository) Incr(ctx context.Context, key string, windowStart time.Time, window time.Duration) (int64, int64, error) {
+	query, params, err := dialect.Insert(TABLE_RATE_LIMITS).Rows(
+		goqu.Record{
+			"key":          key,
+			"window_start": windowStart,
+			"hits":         1,
+			"expires_at":   windowStart.Add(2 * window),
+		}).OnConflict(goqu.DoUpdate("key, window_start", goqu.Record{
+		"hits": goqu.L(TABLE_RATE_LIMITS + ".hits + 1"),
+	})).Returning("hits").ToSQL()
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var current int64
+	if err = r.dbc.WithTimeout(ctx, TABLE_RATE_LIMITS, "Incr", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).Scan(&current)
+	}); err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	query, params, err = dialect.From(TABLE_RATE_LIMITS).Select("hits").Where(
+		goqu.Ex{
+			"key":          key,
+			"window_start": windowStart.Add(-window),
+		}).ToSQL()
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var previous int64
+	if err = r.dbc.WithTimeout(ctx, TABLE_RATE_LIMITS, "Get", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).Scan(&previous)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return current, previous, nil
+}
+
+func (r RateLimitRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query, params, err := dialect.Delete(TABLE_RATE_LIMITS).Where(
+		goqu.Ex{
+			"expires_at": goqu.Op{"lte": now},
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_RATE_LIMITS, "DeleteExpired", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
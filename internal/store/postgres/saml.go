@@ -0,0 +1,39 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/raystack/frontier/core/saml"
+)
+
+type SAMLConnection struct {
+	ID               string             `db:"id"`
+	OrgID            string             `db:"org_id"`
+	EntityID         string             `db:"entity_id"`
+	MetadataURL      string             `db:"metadata_url"`
+	Metadata         string             `db:"metadata"`
+	AttributeMapping types.NullJSONText `db:"attribute_mapping"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (c SAMLConnection) transform() (saml.Connection, error) {
+	var mapping saml.AttributeMapping
+	if c.AttributeMapping.Valid {
+		if err := c.AttributeMapping.Unmarshal(&mapping); err != nil {
+			return saml.Connection{}, err
+		}
+	}
+	return saml.Connection{
+		ID:               c.ID,
+		OrgID:            c.OrgID,
+		EntityID:         c.EntityID,
+		MetadataURL:      c.MetadataURL,
+		Metadata:         c.Metadata,
+		AttributeMapping: mapping,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// SAMLAssertionRepository records the ids of consumed saml assertions until
+// they expire
+type SAMLAssertionRepository struct {
+	dbc *db.Client
+}
+
+func NewSAMLAssertionRepository(dbc *db.Client) *SAMLAssertionRepository {
+	return &SAMLAssertionRepository{
+		dbc: dbc,
+	}
+}
+
+// Consume records the assertion, expired assertions are dropped first as they
+// are rejected before reaching the repository anyway
+func (r SAMLAssertionRepository) Consume(ctx context.Context, issuer, id string, expiresAt time.Time) error {
+	deleteQuery, deleteParams, err := dialect.Delete(TABLE_SAML_ASSERTIONS).Where(
+		goqu.C("expires_at").Lt(goqu.L("now()")),
+	).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+	insertQuery, insertParams, err := dialect.Insert(TABLE_SAML_ASSERTIONS).Rows(
+		goqu.Record{
+			"issuer":     issuer,
+			"id":         id,
+			"expires_at": expiresAt,
+		}).OnConflict(goqu.DoNothing()).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_SAML_ASSERTIONS, "Consume", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, deleteQuery, deleteParams...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		result, err := r.dbc.ExecContext(ctx, insertQuery, insertParams...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return saml.ErrAssertionReplay
+		}
+		return nil
+	})
+}
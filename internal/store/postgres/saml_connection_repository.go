@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/saml"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type SAMLConnectionRepository struct {
+	dbc *db.Client
+}
+
+func NewSAMLConnectionRepository(dbc *db.Client) *SAMLConnectionRepository {
+	return &SAMLConnectionRepository{
+		dbc: dbc,
+	}
+}
+
+func (r SAMLConnectionRepository) Create(ctx context.Context, toCreate saml.Connection) (saml.Connection, error) {
+	marshaledMapping, err := json.Marshal(toCreate.AttributeMapping)
+	if err != nil {
+		return saml.Connection{}, fmt.Errorf("%w: %w", parseErr, err)
+	}
+
+	query, params, err := dialect.Insert(TABLE_SAML_CONNECTIONS).Rows(
+		goqu.Record{
+			"org_id":            toCreate.OrgID,
+			"entity_id":         toCreate.EntityID,
+			"metadata_url":      toCreate.MetadataURL,
+			"metadata":          toCreate.Metadata,
+			"attribute_mapping": marshaledMapping,
+		}).Returning(&SAMLConnection{}).ToSQL()
+	if err != nil {
+		return saml.Connection{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var connectionModel SAMLConnection
+	if err = r.dbc.WithTimeout(ctx, TABLE_SAML_CONNECTIONS, "Create", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&connectionModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		if errors.Is(err, ErrDuplicateKey) {
+			return saml.Connection{}, saml.ErrConflict
+		}
+		return saml.Connection{}, fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return connectionModel.transform()
+}
+
+func (r SAMLConnectionRepository) GetByOrgID(ctx context.Context, orgID string) (saml.Connection, error) {
+	query, params, err := dialect.From(TABLE_SAML_CONNECTIONS).Where(
+		goqu.Ex{
+			"org_id": orgID,
+		}).ToSQL()
+	if err != nil {
+		return saml.Connection{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var connectionModel SAMLConnection
+	if err = r.dbc.WithTimeout(ctx, TABLE_SAML_CONNECTIONS, "GetByOrgID", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &connectionModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return saml.Connection{}, saml.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return saml.Connection{}, saml.ErrNotExist
+		default:
+			return saml.Connection{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return connectionModel.transform()
+}
+
+func (r SAMLConnectionRepository) List(ctx context.Context) ([]saml.Connection, error) {
+	query, params, err := dialect.From(TABLE_SAML_CONNECTIONS).Order(goqu.I("created_at").Desc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var connectionModels []SAMLConnection
+	if err = r.dbc.WithTimeout(ctx, TABLE_SAML_CONNECTIONS, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &connectionModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+
+	connections := make([]saml.Connection, 0, len(connectionModels))
+	for _, c := range connectionModels {
+		connection, err := c.transform()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		connections = append(connections, connection)
+	}
+	return connections, nil
+}
+
+func (r SAMLConnectionRepository) Delete(ctx context.Context, id string) error {
+	query, params, err := dialect.Delete(TABLE_SAML_CONNECTIONS).Where(
+		goqu.Ex{
+			"id": id,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_SAML_CONNECTIONS, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			if errors.Is(err, ErrInvalidTextRepresentation) {
+				return saml.ErrNotExist
+			}
+			return fmt.Errorf("%w: %w", dbErr, err)
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return saml.ErrNotExist
+		}
+		return nil
+	})
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/raystack/frontier/core/scim"
+)
+
+type SCIMProfile struct {
+	OrgID       string    `db:"org_id"`
+	UserID      string    `db:"user_id"`
+	DisplayName string    `db:"display_name"`
+	CreatedAt   time.Time `db:"created_at"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (p SCIMProfile) transform() scim.Profile {
+	return scim.Profile{
+		OrgID:       p.OrgID,
+		UserID:      p.UserID,
+		DisplayName: p.DisplayName,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
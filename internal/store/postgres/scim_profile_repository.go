@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/organization"
+	"github.com/raystack/frontier/core/scim"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// SCIMProfileRepository stores the display names identity providers set for
+// members their organization doesn't own
+type SCIMProfileRepository struct {
+	dbc *db.Client
+}
+
+func NewSCIMProfileRepository(dbc *db.Client) *SCIMProfileRepository {
+	return &SCIMProfileRepository{
+		dbc: dbc,
+	}
+}
+
+func (r SCIMProfileRepository) Get(ctx context.Context, orgID, userID string) (scim.Profile, error) {
+	query, params, err := dialect.From(TABLE_SCIM_PROFILES).Where(
+		goqu.Ex{
+			"org_id":  orgID,
+			"user_id": userID,
+		}).ToSQL()
+	if err != nil {
+		return scim.Profile{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var profileModel SCIMProfile
+	if err = r.dbc.WithTimeout(ctx, TABLE_SCIM_PROFILES, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &profileModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return scim.Profile{}, scim.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return scim.Profile{}, scim.ErrNotExist
+		default:
+			return scim.Profile{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return profileModel.transform(), nil
+}
+
+func (r SCIMProfileRepository) List(ctx context.Context, orgID string) ([]scim.Profile, error) {
+	query, params, err := dialect.From(TABLE_SCIM_PROFILES).Where(
+		goqu.Ex{
+			"org_id": orgID,
+		}).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var profileModels []SCIMProfile
+	if err = r.dbc.WithTimeout(ctx, TABLE_SCIM_PROFILES, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &profileModels, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return []scim.Profile{}, nil
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return []scim.Profile{}, nil
+		default:
+			return nil, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+
+	profiles := make([]scim.Profile, 0, len(profileModels))
+	for _, p := range profileModels {
+		profiles = append(profiles, p.transform())
+	}
+	return profiles, nil
+}
+
+func (r SCIMProfileRepository) Upsert(ctx context.Context, profile scim.Profile) (scim.Profile, error) {
+	query, params, err := dialect.Insert(TABLE_SCIM_PROFILES).Rows(
+		goqu.Record{
+			"org_id":       profile.OrgID,
+			"user_id":      profile.UserID,
+			"display_name": profile.DisplayName,
+		}).OnConflict(goqu.DoUpdate("org_id, user_id", goqu.Record{
+		"display_name": profile.DisplayName,
+		"updated_at":   goqu.L("now()"),
+	})).Returning(&SCIMProfile{}).ToSQL()
+	if err != nil {
+		return scim.Profile{}, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var profileModel SCIMProfile
+	if err = r.dbc.WithTimeout(ctx, TABLE_SCIM_PROFILES, "Upsert", func(ctx context.Context) error {
+		return r.dbc.QueryRowxContext(ctx, query, params...).StructScan(&profileModel)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, ErrForeignKeyViolation):
+			return scim.Profile{}, organization.ErrNotExist
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return scim.Profile{}, scim.ErrNotExist
+		default:
+			return scim.Profile{}, fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return profileModel.transform(), nil
+}
+
+func (r SCIMProfileRepository) Delete(ctx context.Context, orgID, userID string) error {
+	query, params, err := dialect.Delete(TABLE_SCIM_PROFILES).Where(
+		goqu.Ex{
+			"org_id":  orgID,
+			"user_id": userID,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_SCIM_PROFILES, "Delete", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			if errors.Is(err, ErrInvalidTextRepresentation) {
+				return scim.ErrNotExist
+			}
+			return fmt.Errorf("%w: %w", dbErr, err)
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return scim.ErrNotExist
+		}
+		return nil
+	})
+}
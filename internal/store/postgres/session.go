@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -11,12 +12,14 @@ import (
 )
 
 type Session struct {
-	ID              uuid.UUID `db:"id"`
-	UserID          uuid.UUID `db:"user_id"`
-	AuthenticatedAt time.Time `db:"authenticated_at"`
-	ExpiresAt       time.Time `db:"expires_at"`
-	Metadata        []byte    `db:"metadata"`
-	CreatedAt       time.Time `db:"created_at"`
+	ID              uuid.UUID    `db:"id"`
+	UserID          uuid.UUID    `db:"user_id"`
+	AuthenticatedAt time.Time    `db:"authenticated_at"`
+	ExpiresAt       time.Time    `db:"expires_at"`
+	Metadata        []byte       `db:"metadata"`
+	CreatedAt       time.Time    `db:"created_at"`
+	State           string       `db:"state"`
+	LastSeenAt      sql.NullTime `db:"last_seen_at"`
 }
 
 func (s *Session) transformToSession() (*session.Session, error) {
@@ -32,5 +35,7 @@ func (s *Session) transformToSession() (*session.Session, error) {
 		ExpiresAt:       s.ExpiresAt,
 		Metadata:        unmarshalledMetadata,
 		CreatedAt:       s.CreatedAt,
+		State:           session.State(s.State),
+		LastSeenAt:      s.LastSeenAt.Time,
 	}, nil
 }
@@ -43,6 +43,11 @@ func (s *SessionRepository) Set(ctx context.Context, session *frontiersession.Se
 		return fmt.Errorf("%w: %s", parseErr, err)
 	}
 
+	state := session.State
+	if state == "" {
+		state = frontiersession.StateActive
+	}
+
 	query, params, err := dialect.Insert(TABLE_SESSIONS).Rows(
 		goqu.Record{
 			"id":               session.ID,
@@ -51,6 +56,8 @@ func (s *SessionRepository) Set(ctx context.Context, session *frontiersession.Se
 			"expires_at":       session.ExpiresAt,
 			"created_at":       session.CreatedAt,
 			"metadata":         marshaledMetadata,
+			"state":            state,
+			"last_seen_at":     sql.NullTime{Time: session.LastSeenAt, Valid: !session.LastSeenAt.IsZero()},
 		}).Returning(&Session{}).ToSQL()
 	if err != nil {
 		return fmt.Errorf("%w: %s", queryErr, err)
@@ -148,12 +155,16 @@ func (s *SessionRepository) DeleteExpiredSessions(ctx context.Context) error {
 	})
 }
 
+// UpdateValidity extends a fully authenticated session, sessions pending a
+// second factor are left untouched
 func (s *SessionRepository) UpdateValidity(ctx context.Context, id uuid.UUID, validity time.Duration) error {
 	query, params, err := dialect.Update(TABLE_SESSIONS).Set(
 		goqu.Record{
-			"expires_at": goqu.L("expires_at + INTERVAL '? hours'", validity.Hours()),
+			"expires_at":   goqu.L("expires_at + INTERVAL '? hours'", validity.Hours()),
+			"last_seen_at": s.Now(),
 		}).Where(goqu.Ex{
-		"id": id,
+		"id":    id,
+		"state": frontiersession.StateActive,
 	}).ToSQL()
 
 	if err != nil {
@@ -174,3 +185,103 @@ func (s *SessionRepository) UpdateValidity(ctx context.Context, id uuid.UUID, va
 		return fmt.Errorf("error updating session validity")
 	})
 }
+
+// Update sets the state, validity and metadata of a session
+func (s *SessionRepository) Update(ctx context.Context, session *frontiersession.Session) error {
+	marshaledMetadata, err := json.Marshal(session.Metadata)
+	if err != nil {
+		return fmt.Errorf("%w: %s", parseErr, err)
+	}
+
+	query, params, err := dialect.Update(TABLE_SESSIONS).Set(
+		goqu.Record{
+			"state":            session.State,
+			"authenticated_at": session.AuthenticatedAt,
+			"expires_at":       session.ExpiresAt,
+			"metadata":         marshaledMetadata,
+		}).Where(goqu.Ex{
+		"id": session.ID,
+	}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	return s.dbc.WithTimeout(ctx, TABLE_SESSIONS, "Update", func(ctx context.Context) error {
+		result, err := s.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			return fmt.Errorf("%w: %s", dbErr, err)
+		}
+
+		if count, _ := result.RowsAffected(); count > 0 {
+			return nil
+		}
+		return fmt.Errorf("%w: %w", dbErr, frontiersession.ErrNoSession)
+	})
+}
+
+// ListByUser returns the sessions of a user which are not expired, the most
+// recent first
+func (s *SessionRepository) ListByUser(ctx context.Context, userID string) ([]*frontiersession.Session, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing user id: %w", err)
+	}
+
+	query, params, err := dialect.From(TABLE_SESSIONS).Where(
+		goqu.Ex{
+			"user_id":    userUUID,
+			"expires_at": goqu.Op{"gt": s.Now()},
+		}).Order(goqu.I("created_at").Desc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	var sessionModels []Session
+	if err = s.dbc.WithTimeout(ctx, TABLE_SESSIONS, "ListByUser", func(ctx context.Context) error {
+		return s.dbc.SelectContext(ctx, &sessionModels, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		return nil, fmt.Errorf("%w: %w", dbErr, err)
+	}
+
+	sessions := make([]*frontiersession.Session, 0, len(sessionModels))
+	for _, sessionModel := range sessionModels {
+		session, err := sessionModel.transformToSession()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", parseErr, err)
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// DeleteByUser deletes all the sessions of a user
+func (s *SessionRepository) DeleteByUser(ctx context.Context, userID string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return fmt.Errorf("error parsing user id: %w", err)
+	}
+
+	query, params, err := dialect.Delete(TABLE_SESSIONS).
+		Where(
+			goqu.Ex{
+				"user_id": userUUID,
+			},
+		).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	return s.dbc.WithTimeout(ctx, TABLE_SESSIONS, "DeleteByUser", func(ctx context.Context) error {
+		result, err := s.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			err = checkPostgresError(err)
+			return fmt.Errorf("%w: %s", dbErr, err)
+		}
+
+		count, _ := result.RowsAffected()
+		s.log.Debug("deleted sessions of user", "user_id", userID, "session_count", count)
+		return nil
+	})
+}
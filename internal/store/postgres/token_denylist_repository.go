@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+type TokenDenylistRepository struct {
+	dbc *db.Client
+}
+
+func NewTokenDenylistRepository(dbc *db.Client) *TokenDenylistRepository {
+	return &TokenDenylistRepository{
+		dbc: dbc,
+	}
+}
+
+func (r TokenDenylistRepository) Add(ctx context.Context, jti string, expiresAt time.Time) error {
+	query, params, err := dialect.Insert(TABLE_TOKEN_DENYLIST).Rows(
+		goqu.Record{
+			"jti":        jti,
+			"expires_at": expiresAt,
+		}).OnConflict(goqu.DoNothing()).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_TOKEN_DENYLIST, "Add", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
+
+func (r TokenDenylistRepository) List(ctx context.Context) (map[string]time.Time, error) {
+	query, params, err := dialect.Select("jti", "expires_at").From(TABLE_TOKEN_DENYLIST).Where(
+		goqu.Ex{
+			"expires_at": goqu.Op{"gt": goqu.L("now()")},
+		}).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var rows []DeniedToken
+	if err = r.dbc.WithTimeout(ctx, TABLE_TOKEN_DENYLIST, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &rows, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+	}
+	jtis := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		jtis[row.JTI] = row.ExpiresAt
+	}
+	return jtis, nil
+}
+
+func (r TokenDenylistRepository) DeleteExpired(ctx context.Context) error {
+	query, params, err := dialect.Delete(TABLE_TOKEN_DENYLIST).Where(
+		goqu.Ex{
+			"expires_at": goqu.Op{"lte": goqu.L("now()")},
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_TOKEN_DENYLIST, "DeleteExpired", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
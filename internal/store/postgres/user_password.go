@@ -0,0 +1,11 @@
+package postgres
+
+import "time"
+
+type UserPassword struct {
+	UserID string `db:"user_id"`
+	Hash   string `db:"hash"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/raystack/frontier/core/authenticate"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+// UserPasswordRepository stores the argon2id hashes of user passwords
+type UserPasswordRepository struct {
+	dbc *db.Client
+}
+
+func NewUserPasswordRepository(dbc *db.Client) *UserPasswordRepository {
+	return &UserPasswordRepository{
+		dbc: dbc,
+	}
+}
+
+func (r UserPasswordRepository) Get(ctx context.Context, userID string) (string, error) {
+	query, params, err := dialect.From(TABLE_USER_PASSWORDS).Where(
+		goqu.Ex{
+			"user_id": userID,
+		}).ToSQL()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	var passwordModel UserPassword
+	if err = r.dbc.WithTimeout(ctx, TABLE_USER_PASSWORDS, "Get", func(ctx context.Context) error {
+		return r.dbc.GetContext(ctx, &passwordModel, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return "", authenticate.ErrPasswordNotSet
+		case errors.Is(err, ErrInvalidTextRepresentation):
+			return "", authenticate.ErrPasswordNotSet
+		default:
+			return "", fmt.Errorf("%w: %w", dbErr, err)
+		}
+	}
+	return passwordModel.Hash, nil
+}
+
+func (r UserPasswordRepository) Set(ctx context.Context, userID string, hash string) error {
+	query, params, err := dialect.Insert(TABLE_USER_PASSWORDS).Rows(goqu.Record{
+		"user_id": userID,
+		"hash":    hash,
+	}).OnConflict(
+		goqu.DoUpdate("user_id", goqu.Record{
+			"hash":       goqu.L("EXCLUDED.hash"),
+			"updated_at": goqu.L("now()"),
+		})).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %w", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_USER_PASSWORDS, "Set", func(ctx context.Context) error {
+		if _, err := r.dbc.ExecContext(ctx, query, params...); err != nil {
+			return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+		}
+		return nil
+	})
+}
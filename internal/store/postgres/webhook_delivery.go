@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jmoiron/sqlx/types"
+	"github.com/raystack/frontier/core/webhook"
+)
+
+type WebhookDelivery struct {
+	ID            string         `db:"id"`
+	EventID       string         `db:"event_id"`
+	EndpointID    string         `db:"endpoint_id"`
+	State         string         `db:"state"`
+	Attempts      int            `db:"attempts"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	LastError     sql.NullString `db:"last_error"`
+
+	Action    string         `db:"action"`
+	Payload   types.JSONText `db:"payload"`
+	RequestID sql.NullString `db:"request_id"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (d WebhookDelivery) transform() webhook.Delivery {
+	return webhook.Delivery{
+		ID:            d.ID,
+		EventID:       d.EventID,
+		EndpointID:    d.EndpointID,
+		Action:        d.Action,
+		Payload:       d.Payload,
+		State:         webhook.DeliveryState(d.State),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastError:     d.LastError.String,
+		RequestID:     d.RequestID.String,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+type WebhookDeliveryAttempt struct {
+	ID         string         `db:"id"`
+	DeliveryID string         `db:"delivery_id"`
+	StatusCode int            `db:"status_code"`
+	LatencyMS  int64          `db:"latency_ms"`
+	Response   sql.NullString `db:"response"`
+	Error      sql.NullString `db:"error"`
+	CreatedAt  time.Time      `db:"created_at"`
+}
+
+func (a WebhookDeliveryAttempt) transform() webhook.DeliveryAttempt {
+	return webhook.DeliveryAttempt{
+		ID:         a.ID,
+		DeliveryID: a.DeliveryID,
+		StatusCode: a.StatusCode,
+		Latency:    time.Duration(a.LatencyMS) * time.Millisecond,
+		Response:   a.Response.String,
+		Error:      a.Error.String,
+		CreatedAt:  a.CreatedAt,
+	}
+}
@@ -0,0 +1,257 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"github.com/raystack/frontier/core/webhook"
+	"github.com/raystack/frontier/pkg/db"
+)
+
+const defaultWebhookDeliveryListLimit = 100
+
+type WebhookDeliveryRepository struct {
+	dbc *db.Client
+}
+
+func NewWebhookDeliveryRepository(dbc *db.Client) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		dbc: dbc,
+	}
+}
+
+func (r WebhookDeliveryRepository) Enqueue(ctx context.Context, deliveries []webhook.Delivery) ([]webhook.Delivery, error) {
+	if len(deliveries) == 0 {
+		return nil, nil
+	}
+	evt := deliveries[0]
+	eventQuery, eventParams, err := dialect.Insert(TABLE_WEBHOOK_EVENTS).Rows(
+		goqu.Record{
+			"id":         evt.EventID,
+			"action":     evt.Action,
+			"payload":    string(evt.Payload),
+			"request_id": evt.RequestID,
+			"created_at": goqu.L("now()"),
+		}).OnConflict(goqu.DoNothing()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	var rows []interface{}
+	for _, d := range deliveries {
+		rows = append(rows, goqu.Record{
+			"id":              d.ID,
+			"event_id":        d.EventID,
+			"endpoint_id":     d.EndpointID,
+			"state":           d.State,
+			"next_attempt_at": d.NextAttemptAt,
+			"created_at":      goqu.L("now()"),
+			"updated_at":      goqu.L("now()"),
+		})
+	}
+	deliveryQuery, deliveryParams, err := dialect.Insert(TABLE_WEBHOOK_DELIVERIES).Rows(rows...).
+		OnConflict(goqu.DoNothing()).Returning(goqu.Star()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	var deliveryModels []WebhookDelivery
+	if err = r.dbc.WithTxn(ctx, sql.TxOptions{}, func(tx *sqlx.Tx) error {
+		return r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_DELIVERIES, "Enqueue", func(ctx context.Context) error {
+			if _, err := tx.ExecContext(ctx, eventQuery, eventParams...); err != nil {
+				return err
+			}
+			return tx.SelectContext(ctx, &deliveryModels, deliveryQuery, deliveryParams...)
+		})
+	}); err != nil {
+		err = checkPostgresError(err)
+		return nil, fmt.Errorf("%w: %w", txnErr, err)
+	}
+
+	result := make([]webhook.Delivery, 0, len(deliveryModels))
+	for _, m := range deliveryModels {
+		d := m.transform()
+		d.Action, d.Payload, d.RequestID = evt.Action, evt.Payload, evt.RequestID
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func (r WebhookDeliveryRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]webhook.Delivery, error) {
+	due := dialect.From(TABLE_WEBHOOK_DELIVERIES).Select("id").Where(
+		goqu.Ex{
+			"state":           webhook.DeliveryPending,
+			"next_attempt_at": goqu.Op{"lte": goqu.L("now()")},
+		}).Order(goqu.I("next_attempt_at").Asc()).Limit(uint(limit)).ForUpdate(exp.SkipLocked)
+	claimed := dialect.Update(TABLE_WEBHOOK_DELIVERIES).Set(
+		goqu.Record{
+			"next_attempt_at": goqu.L("now() + ?::interval", fmt.Sprintf("%d milliseconds", lease.Milliseconds())),
+			"updated_at":      goqu.L("now()"),
+		}).Where(goqu.I("id").In(due)).Returning(goqu.Star())
+
+	query, params, err := r.withEvent("claimed", claimed).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	var deliveryModels []WebhookDelivery
+	if err = r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_DELIVERIES, "Claim", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &deliveryModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", dbErr, err)
+	}
+	return transformWebhookDeliveries(deliveryModels), nil
+}
+
+func (r WebhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery webhook.Delivery, attempt webhook.DeliveryAttempt) error {
+	attemptQuery, attemptParams, err := dialect.Insert(TABLE_WEBHOOK_ATTEMPTS).Rows(
+		goqu.Record{
+			"id":          attempt.ID,
+			"delivery_id": delivery.ID,
+			"status_code": attempt.StatusCode,
+			"latency_ms":  attempt.Latency.Milliseconds(),
+			"response":    attempt.Response,
+			"error":       attempt.Error,
+			"created_at":  attempt.CreatedAt,
+		}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %s", queryErr, err)
+	}
+	updateQuery, updateParams, err := dialect.Update(TABLE_WEBHOOK_DELIVERIES).Set(
+		goqu.Record{
+			"state":           delivery.State,
+			"attempts":        delivery.Attempts,
+			"next_attempt_at": delivery.NextAttemptAt,
+			"last_error":      delivery.LastError,
+			"updated_at":      goqu.L("now()"),
+		}).Where(goqu.Ex{
+		"id": delivery.ID,
+	}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	return r.dbc.WithTxn(ctx, sql.TxOptions{}, func(tx *sqlx.Tx) error {
+		return r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_ATTEMPTS, "RecordAttempt", func(ctx context.Context) error {
+			if _, err := tx.ExecContext(ctx, attemptQuery, attemptParams...); err != nil {
+				return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+			}
+			result, err := tx.ExecContext(ctx, updateQuery, updateParams...)
+			if err != nil {
+				return fmt.Errorf("%w: %w", dbErr, checkPostgresError(err))
+			}
+			if count, _ := result.RowsAffected(); count == 0 {
+				return webhook.ErrDeliveryNotFound
+			}
+			return nil
+		})
+	})
+}
+
+func (r WebhookDeliveryRepository) List(ctx context.Context, flt webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	stmt := dialect.From(goqu.T(TABLE_WEBHOOK_DELIVERIES).As("d")).
+		Join(goqu.T(TABLE_WEBHOOK_EVENTS).As("e"), goqu.On(goqu.I("e.id").Eq(goqu.I("d.event_id")))).
+		Select(goqu.T("d").All(), goqu.I("e.action"), goqu.I("e.payload"), goqu.I("e.request_id")).
+		Order(goqu.I("d.created_at").Desc())
+	if flt.EventID != "" {
+		stmt = stmt.Where(goqu.Ex{"d.event_id": flt.EventID})
+	}
+	if flt.EndpointID != "" {
+		stmt = stmt.Where(goqu.Ex{"d.endpoint_id": flt.EndpointID})
+	}
+	if flt.State != "" {
+		stmt = stmt.Where(goqu.Ex{"d.state": flt.State})
+	}
+	limit := flt.Limit
+	if limit <= 0 {
+		limit = defaultWebhookDeliveryListLimit
+	}
+	query, params, err := stmt.Limit(uint(limit)).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	var deliveryModels []WebhookDelivery
+	if err = r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_DELIVERIES, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &deliveryModels, query, params...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", dbErr, err)
+	}
+	deliveries := transformWebhookDeliveries(deliveryModels)
+	if len(deliveries) == 0 {
+		return deliveries, nil
+	}
+
+	deliveryIDs := make([]string, 0, len(deliveries))
+	for _, d := range deliveries {
+		deliveryIDs = append(deliveryIDs, d.ID)
+	}
+	attemptQuery, attemptParams, err := dialect.From(TABLE_WEBHOOK_ATTEMPTS).Where(
+		goqu.Ex{
+			"delivery_id": goqu.Op{"in": deliveryIDs},
+		}).Order(goqu.I("created_at").Asc()).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+	var attemptModels []WebhookDeliveryAttempt
+	if err = r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_ATTEMPTS, "List", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &attemptModels, attemptQuery, attemptParams...)
+	}); err != nil {
+		return nil, fmt.Errorf("%w: %s", dbErr, err)
+	}
+	attemptsByDelivery := make(map[string][]webhook.DeliveryAttempt)
+	for _, a := range attemptModels {
+		attemptsByDelivery[a.DeliveryID] = append(attemptsByDelivery[a.DeliveryID], a.transform())
+	}
+	for i := range deliveries {
+		deliveries[i].History = attemptsByDelivery[deliveries[i].ID]
+	}
+	return deliveries, nil
+}
+
+func (r WebhookDeliveryRepository) Requeue(ctx context.Context, eventID string) ([]webhook.Delivery, error) {
+	requeued := dialect.Update(TABLE_WEBHOOK_DELIVERIES).Set(
+		goqu.Record{
+			"state":           webhook.DeliveryPending,
+			"attempts":        0,
+			"next_attempt_at": goqu.L("now()"),
+			"updated_at":      goqu.L("now()"),
+		}).Where(goqu.Ex{
+		"event_id": eventID,
+	}).Returning(goqu.Star())
+
+	query, params, err := r.withEvent("requeued", requeued).ToSQL()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	var deliveryModels []WebhookDelivery
+	if err = r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_DELIVERIES, "Requeue", func(ctx context.Context) error {
+		return r.dbc.SelectContext(ctx, &deliveryModels, query, params...)
+	}); err != nil {
+		err = checkPostgresError(err)
+		return nil, fmt.Errorf("%w: %w", dbErr, err)
+	}
+	return transformWebhookDeliveries(deliveryModels), nil
+}
+
+// withEvent wraps a data modifying statement over deliveries in a CTE
+// and joins the event details to its returned rows
+func (r WebhookDeliveryRepository) withEvent(alias string, stmt exp.Expression) *goqu.SelectDataset {
+	return dialect.From(alias).With(alias, stmt).
+		Join(goqu.T(TABLE_WEBHOOK_EVENTS).As("e"), goqu.On(goqu.I("e.id").Eq(goqu.I(alias+".event_id")))).
+		Select(goqu.T(alias).All(), goqu.I("e.action"), goqu.I("e.payload"), goqu.I("e.request_id"))
+}
+
+func transformWebhookDeliveries(models []WebhookDelivery) []webhook.Delivery {
+	deliveries := make([]webhook.Delivery, 0, len(models))
+	for _, m := range models {
+		deliveries = append(deliveries, m.transform())
+	}
+	return deliveries
+}
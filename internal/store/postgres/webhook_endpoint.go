@@ -39,8 +39,10 @@ func toDBWebHookHeaders(headers map[string]string) WebhookHeaders {
 }
 
 type WebhookSecret struct {
-	ID    string `json:"id"`
-	Value string `json:"value"`
+	ID        string     `json:"id"`
+	Value     string     `json:"value"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 }
 
 type WebhookSecrets struct {
@@ -50,10 +52,17 @@ type WebhookSecrets struct {
 func toDBWebHookSecrets(secrets []webhook.Secret, encryptionKey []byte) (string, error) {
 	var items []WebhookSecret
 	for _, secret := range secrets {
-		items = append(items, WebhookSecret{
+		item := WebhookSecret{
 			ID:    secret.ID,
 			Value: secret.Value,
-		})
+		}
+		if !secret.CreatedAt.IsZero() {
+			item.CreatedAt = &secret.CreatedAt
+		}
+		if !secret.ExpiresAt.IsZero() {
+			item.ExpiresAt = &secret.ExpiresAt
+		}
+		items = append(items, item)
 	}
 	dbSecretRawBytes, err := json.Marshal(WebhookSecrets{Items: items})
 	if err != nil {
@@ -83,10 +92,17 @@ func fromDBWebHookSecrets(secrets string, encryptionKey []byte) ([]webhook.Secre
 	}
 	var secretsList []webhook.Secret
 	for _, secret := range dbSecrets.Items {
-		secretsList = append(secretsList, webhook.Secret{
+		item := webhook.Secret{
 			ID:    secret.ID,
 			Value: secret.Value,
-		})
+		}
+		if secret.CreatedAt != nil {
+			item.CreatedAt = *secret.CreatedAt
+		}
+		if secret.ExpiresAt != nil {
+			item.ExpiresAt = *secret.ExpiresAt
+		}
+		secretsList = append(secretsList, item)
 	}
 	return secretsList, nil
 }
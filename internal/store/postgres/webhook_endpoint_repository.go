@@ -173,6 +173,37 @@ func (r WebhookEndpointRepository) UpdateByID(ctx context.Context, toUpdate webh
 	return endpointModel.transform(r.encryptionKey)
 }
 
+func (r WebhookEndpointRepository) UpdateSecrets(ctx context.Context, id string, secrets []webhook.Secret) error {
+	if strings.TrimSpace(id) == "" {
+		return webhook.ErrInvalidDetail
+	}
+	secretString, err := toDBWebHookSecrets(secrets, r.encryptionKey)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt webhook secrets: %w", err)
+	}
+
+	query, params, err := dialect.Update(TABLE_WEBHOOK_ENDPOINTS).Set(goqu.Record{
+		"secrets":    secretString,
+		"updated_at": goqu.L("now()"),
+	}).Where(goqu.Ex{
+		"id": id,
+	}).ToSQL()
+	if err != nil {
+		return fmt.Errorf("%w: %s", queryErr, err)
+	}
+
+	return r.dbc.WithTimeout(ctx, TABLE_WEBHOOK_ENDPOINTS, "UpdateSecrets", func(ctx context.Context) error {
+		result, err := r.dbc.ExecContext(ctx, query, params...)
+		if err != nil {
+			return fmt.Errorf("%w: %w", txnErr, checkPostgresError(err))
+		}
+		if count, _ := result.RowsAffected(); count == 0 {
+			return webhook.ErrNotFound
+		}
+		return nil
+	})
+}
+
 func (r WebhookEndpointRepository) Delete(ctx context.Context, id string) error {
 	query, params, err := dialect.Delete(TABLE_WEBHOOK_ENDPOINTS).Where(goqu.Ex{
 		"id": id,
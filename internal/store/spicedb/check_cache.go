@@ -0,0 +1,333 @@
+package spicedb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	authzedpb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/frontier/internal/metrics"
+	"github.com/raystack/salt/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	watchMinBackoff = 100 * time.Millisecond
+	watchMaxBackoff = 30 * time.Second
+)
+
+// checkKey identifies a permission check, caveat context isn't part of it as
+// it only holds the time of the check
+type checkKey struct {
+	objectNamespace  string
+	objectID         string
+	permission       string
+	subjectNamespace string
+	subjectID        string
+	subjectRelation  string
+}
+
+func checkKeyOf(rel relation.Relation) checkKey {
+	return checkKey{
+		objectNamespace:  rel.Object.Namespace,
+		objectID:         rel.Object.ID,
+		permission:       rel.RelationName,
+		subjectNamespace: rel.Subject.Namespace,
+		subjectID:        rel.Subject.ID,
+		subjectRelation:  rel.Subject.SubRelationName,
+	}
+}
+
+type checkEntry struct {
+	allowed   bool
+	expiresAt time.Time
+}
+
+// checkLookup is what a check missing the cache is made with
+type checkLookup struct {
+	// generation is the one to cache the result of the check with
+	generation uint64
+	// cursor is the ZedToken of the last change the cache was invalidated for
+	cursor *authzedpb.ZedToken
+}
+
+// consistency is the one a check missing the cache must be read at for its
+// result to be cached. It is at least as fresh as the last change the cache
+// was invalidated for, a result read from an older revision would miss that
+// change and no invalidation would ever drop it
+func (l checkLookup) consistency() *authzedpb.Consistency {
+	if l.cursor == nil {
+		return &authzedpb.Consistency{Requirement: &authzedpb.Consistency_FullyConsistent{FullyConsistent: true}}
+	}
+	return &authzedpb.Consistency{
+		Requirement: &authzedpb.Consistency_AtLeastAsFresh{
+			AtLeastAsFresh: l.cursor,
+		},
+	}
+}
+
+// CheckCache is an in memory LRU cache of permission check results. It
+// consumes the watch stream of spicedb and drops the results a relationship
+// change may have altered:
+//   - a relationship with a user or service user as its subject only changes
+//     the permissions of that principal, its results are dropped
+//   - any other relationship, like a role binding, a role permission or a
+//     group, may change the permissions of anyone on anything through the
+//     schema, all results are dropped
+//
+// Results are only served while the stream is connected. The stream resumes
+// from the ZedToken of the last change it delivered so no change is missed
+// across reconnects, if the token can't be resumed from the cache is emptied.
+// Results of checks that raced with an invalidation are never cached, nor
+// results read from a revision older than the last change delivered.
+//
+// Results depending on a caveat, like the validity window of a time bound
+// policy, change with time without any relationship change and are never
+// cached.
+type CheckCache struct {
+	log         log.Logger
+	watchClient authzedpb.WatchServiceClient
+	ttl         time.Duration
+	now         func() time.Time
+
+	mu  sync.Mutex
+	lru *simplelru.LRU[checkKey, checkEntry]
+	// bySubject indexes the cached checks by their principal
+	bySubject map[string]map[checkKey]struct{}
+	// generation changes on every invalidation, a check result is only
+	// cached if no invalidation happened while it was being checked
+	generation uint64
+
+	// connected is set while the watch stream is open
+	connected atomic.Bool
+	// cursor is the ZedToken of the last change delivered by the stream, it
+	// is updated along with the invalidations of the change
+	cursor *authzedpb.ZedToken
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewCheckCache(logger log.Logger, spiceDB *SpiceDB, config CheckCacheConfig) (*CheckCache, error) {
+	return newCheckCache(logger, spiceDB.client, config)
+}
+
+func newCheckCache(logger log.Logger, watchClient authzedpb.WatchServiceClient, config CheckCacheConfig) (*CheckCache, error) {
+	if config.Size <= 0 {
+		return nil, errors.New("check cache size must be positive")
+	}
+	if config.TTL <= 0 {
+		return nil, errors.New("check cache ttl must be positive")
+	}
+	c := &CheckCache{
+		log:         logger,
+		watchClient: watchClient,
+		ttl:         config.TTL,
+		now:         time.Now,
+		bySubject:   map[string]map[checkKey]struct{}{},
+	}
+	lru, err := simplelru.NewLRU[checkKey, checkEntry](config.Size, c.onEvict)
+	if err != nil {
+		return nil, err
+	}
+	c.lru = lru
+	return c, nil
+}
+
+// Init starts consuming the watch stream of spicedb in the background
+func (c *CheckCache) Init(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go func() {
+		defer close(c.done)
+		c.watch(ctx)
+	}()
+	return nil
+}
+
+// Close stops consuming the watch stream, results aren't served after it
+func (c *CheckCache) Close() error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+	<-c.done
+	return nil
+}
+
+// get returns the cached result of the check if there is one along with what
+// to make the check with if there isn't
+func (c *CheckCache) get(rel relation.Relation) (allowed bool, found bool, lookup checkLookup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.connected.Load() {
+		key := checkKeyOf(rel)
+		if entry, ok := c.lru.Get(key); ok {
+			if c.now().Before(entry.expiresAt) {
+				allowed, found = entry.allowed, true
+			} else {
+				c.lru.Remove(key)
+			}
+		}
+	}
+	if metrics.SpiceDBCheckCacheLookups != nil {
+		result := "miss"
+		if found {
+			result = "hit"
+		}
+		metrics.SpiceDBCheckCacheLookups.WithLabelValues(result).Inc()
+	}
+	return allowed, found, checkLookup{generation: c.generation, cursor: c.cursor}
+}
+
+// add caches the result of the check unless the cache was invalidated since
+// the lookup, the check must have been read at the consistency of the lookup
+func (c *CheckCache) add(rel relation.Relation, allowed bool, lookup checkLookup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if lookup.generation != c.generation || !c.connected.Load() {
+		return
+	}
+	key := checkKeyOf(rel)
+	c.lru.Add(key, checkEntry{allowed: allowed, expiresAt: c.now().Add(c.ttl)})
+	subject := key.subjectNamespace + ":" + key.subjectID
+	if c.bySubject[subject] == nil {
+		c.bySubject[subject] = map[checkKey]struct{}{}
+	}
+	c.bySubject[subject][key] = struct{}{}
+}
+
+func (c *CheckCache) onEvict(key checkKey, _ checkEntry) {
+	subject := key.subjectNamespace + ":" + key.subjectID
+	delete(c.bySubject[subject], key)
+	if len(c.bySubject[subject]) == 0 {
+		delete(c.bySubject, subject)
+	}
+}
+
+// invalidate drops the results the relationship may have changed, a subject
+// without a namespace stands for relationships of any subject
+func (c *CheckCache) invalidate(subject relation.Subject) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidateLocked(subject)
+}
+
+// advance drops the results the changes delivered by the stream may have
+// changed and moves the cursor past them at once, a check is never made at
+// the new cursor with a generation from before the changes
+func (c *CheckCache) advance(subjects []relation.Subject, cursor *authzedpb.ZedToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, subject := range subjects {
+		c.invalidateLocked(subject)
+	}
+	if cursor != nil {
+		c.cursor = cursor
+	}
+}
+
+func (c *CheckCache) invalidateLocked(subject relation.Subject) {
+	c.generation++
+	scope := "all"
+	if (subject.Namespace == schema.UserPrincipal || subject.Namespace == schema.ServiceUserPrincipal) &&
+		subject.ID != "" && subject.ID != "*" && subject.SubRelationName == "" {
+		scope = "subject"
+		for key := range c.bySubject[subject.Namespace+":"+subject.ID] {
+			c.lru.Remove(key)
+		}
+	} else {
+		c.lru.Purge()
+	}
+	if metrics.SpiceDBCheckCacheInvalidations != nil {
+		metrics.SpiceDBCheckCacheInvalidations.WithLabelValues(scope).Inc()
+	}
+}
+
+// watch consumes the watch stream until the context is done, reconnecting
+// with a backoff when it breaks
+func (c *CheckCache) watch(ctx context.Context) {
+	backoff := watchMinBackoff
+	for {
+		delivered, err := c.consume(ctx)
+		c.connected.Store(false)
+		if ctx.Err() != nil {
+			return
+		}
+		if delivered {
+			backoff = watchMinBackoff
+		}
+		if !resumable(err) {
+			// the cursor may be garbage collected already, changes since it
+			// can't be replayed so start over from the latest revision
+			c.mu.Lock()
+			c.cursor = nil
+			c.invalidateLocked(relation.Subject{})
+			c.mu.Unlock()
+		}
+		c.log.Warn("spicedb watch stream broke, check cache disabled until it reconnects", "err", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, watchMaxBackoff)
+	}
+}
+
+// resumable reports if the stream broke for reasons unrelated to the cursor
+func resumable(err error) bool {
+	if errors.Is(err, io.EOF) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+		return true
+	}
+	return false
+}
+
+// consume reads the changes of a watch stream, it reports if any change was
+// delivered before the stream broke
+func (c *CheckCache) consume(ctx context.Context) (bool, error) {
+	c.mu.Lock()
+	cursor := c.cursor
+	c.mu.Unlock()
+	stream, err := c.watchClient.Watch(ctx, &authzedpb.WatchRequest{OptionalStartCursor: cursor})
+	if err != nil {
+		return false, err
+	}
+	if cursor == nil {
+		// results cached before the stream started may miss changes the
+		// stream will never deliver
+		c.invalidate(relation.Subject{})
+	}
+	c.connected.Store(true)
+	delivered := false
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return delivered, err
+		}
+		delivered = true
+		subjects := make([]relation.Subject, 0, len(response.GetUpdates()))
+		for _, update := range response.GetUpdates() {
+			subject := update.GetRelationship().GetSubject()
+			subjects = append(subjects, relation.Subject{
+				ID:              subject.GetObject().GetObjectId(),
+				Namespace:       subject.GetObject().GetObjectType(),
+				SubRelationName: subject.GetOptionalRelation(),
+			})
+		}
+		c.advance(subjects, response.GetChangesThrough())
+	}
+}
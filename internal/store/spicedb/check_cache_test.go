@@ -0,0 +1,204 @@
+package spicedb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authzedpb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/raystack/salt/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeWatchClient streams the responses sent on its channel, a stream is
+// opened per call and breaks with the errors sent on errs
+type fakeWatchClient struct {
+	responses chan *authzedpb.WatchResponse
+	errs      chan error
+	requests  chan *authzedpb.WatchRequest
+}
+
+func newFakeWatchClient() *fakeWatchClient {
+	return &fakeWatchClient{
+		responses: make(chan *authzedpb.WatchResponse),
+		errs:      make(chan error),
+		requests:  make(chan *authzedpb.WatchRequest, 10),
+	}
+}
+
+func (f *fakeWatchClient) Watch(ctx context.Context, in *authzedpb.WatchRequest, _ ...grpc.CallOption) (authzedpb.WatchService_WatchClient, error) {
+	f.requests <- in
+	return &fakeWatchStream{ctx: ctx, client: f}, nil
+}
+
+type fakeWatchStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	client *fakeWatchClient
+}
+
+func (s *fakeWatchStream) Recv() (*authzedpb.WatchResponse, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case err := <-s.client.errs:
+		return nil, err
+	case response := <-s.client.responses:
+		return response, nil
+	}
+}
+
+func update(object relation.Object, name string, subject relation.Subject, token string) *authzedpb.WatchResponse {
+	return &authzedpb.WatchResponse{
+		Updates: []*authzedpb.RelationshipUpdate{{
+			Operation: authzedpb.RelationshipUpdate_OPERATION_TOUCH,
+			Relationship: &authzedpb.Relationship{
+				Resource: &authzedpb.ObjectReference{ObjectType: object.Namespace, ObjectId: object.ID},
+				Relation: name,
+				Subject: &authzedpb.SubjectReference{
+					Object:           &authzedpb.ObjectReference{ObjectType: subject.Namespace, ObjectId: subject.ID},
+					OptionalRelation: subject.SubRelationName,
+				},
+			},
+		}},
+		ChangesThrough: &authzedpb.ZedToken{Token: token},
+	}
+}
+
+func newTestCheckCache(t *testing.T) (*CheckCache, *fakeWatchClient) {
+	client := newFakeWatchClient()
+	cache, err := newCheckCache(log.NewNoop(), client, CheckCacheConfig{Size: 10, TTL: time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, cache.Init(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, cache.Close())
+	})
+	<-client.requests
+	require.Eventually(t, cache.connected.Load, time.Second, time.Millisecond)
+	return cache, client
+}
+
+func cached(cache *CheckCache, rel relation.Relation) bool {
+	_, found, _ := cache.get(rel)
+	return found
+}
+
+func TestCheckCache(t *testing.T) {
+	otherUser := relation.Subject{ID: "other-user-id", Namespace: schema.UserPrincipal}
+	projectGet := relation.Relation{Object: project, Subject: projectUser, RelationName: schema.GetPermission}
+	otherProjectGet := relation.Relation{Object: project, Subject: otherUser, RelationName: schema.GetPermission}
+	group := relation.Object{ID: "group-id", Namespace: schema.GroupNamespace}
+
+	t.Run("should serve cached results", func(t *testing.T) {
+		cache, _ := newTestCheckCache(t)
+		_, found, lookup := cache.get(projectGet)
+		assert.False(t, found)
+		cache.add(projectGet, true, lookup)
+
+		allowed, found, _ := cache.get(projectGet)
+		assert.True(t, found)
+		assert.True(t, allowed)
+	})
+
+	t.Run("should drop the results of the principal of a changed relationship", func(t *testing.T) {
+		cache, client := newTestCheckCache(t)
+		_, _, lookup := cache.get(projectGet)
+		cache.add(projectGet, false, lookup)
+		cache.add(otherProjectGet, false, lookup)
+
+		client.responses <- update(group, schema.MemberRelationName, projectUser, "token-1")
+		assert.Eventually(t, func() bool {
+			return !cached(cache, projectGet)
+		}, time.Second, time.Millisecond)
+		assert.True(t, cached(cache, otherProjectGet))
+	})
+
+	t.Run("should drop all results when a relationship between resources changes", func(t *testing.T) {
+		cache, client := newTestCheckCache(t)
+		_, _, lookup := cache.get(projectGet)
+		cache.add(projectGet, false, lookup)
+		cache.add(otherProjectGet, false, lookup)
+
+		client.responses <- update(project, schema.RoleGrantRelationName,
+			relation.Subject{ID: "policy-id", Namespace: schema.RoleBindingNamespace}, "token-1")
+		assert.Eventually(t, func() bool {
+			return !cached(cache, projectGet) && !cached(cache, otherProjectGet)
+		}, time.Second, time.Millisecond)
+	})
+
+	t.Run("should not cache results checked while the cache was invalidated", func(t *testing.T) {
+		cache, _ := newTestCheckCache(t)
+		_, _, lookup := cache.get(projectGet)
+		cache.invalidate(otherUser)
+		cache.add(projectGet, true, lookup)
+		assert.False(t, cached(cache, projectGet))
+	})
+
+	t.Run("should expire results after the ttl", func(t *testing.T) {
+		cache, _ := newTestCheckCache(t)
+		_, _, lookup := cache.get(projectGet)
+		cache.add(projectGet, true, lookup)
+		cache.now = func() time.Time {
+			return time.Now().Add(2 * time.Minute)
+		}
+		assert.False(t, cached(cache, projectGet))
+	})
+
+	t.Run("should check misses at least as fresh as the last change delivered", func(t *testing.T) {
+		cache, client := newTestCheckCache(t)
+		_, _, lookup := cache.get(projectGet)
+		assert.True(t, lookup.consistency().GetFullyConsistent())
+
+		client.responses <- update(group, schema.MemberRelationName, otherUser, "token-1")
+		// the stream only reads the next response once done with the update
+		client.responses <- &authzedpb.WatchResponse{}
+		_, _, lookup = cache.get(projectGet)
+		assert.Equal(t, "token-1", lookup.consistency().GetAtLeastAsFresh().GetToken())
+	})
+
+	t.Run("should not cache results looked up before a change was delivered", func(t *testing.T) {
+		cache, client := newTestCheckCache(t)
+		_, _, lookup := cache.get(projectGet)
+		client.responses <- update(group, schema.MemberRelationName, otherUser, "token-1")
+		client.responses <- &authzedpb.WatchResponse{}
+		cache.add(projectGet, true, lookup)
+		assert.False(t, cached(cache, projectGet))
+	})
+
+	t.Run("should resume from the last change delivered", func(t *testing.T) {
+		cache, client := newTestCheckCache(t)
+		client.responses <- update(group, schema.MemberRelationName, otherUser, "token-1")
+		// the stream only reads the next response once done with the update
+		client.responses <- &authzedpb.WatchResponse{}
+		_, _, lookup := cache.get(projectGet)
+		cache.add(projectGet, true, lookup)
+		require.True(t, cached(cache, projectGet))
+
+		client.errs <- status.Error(codes.Unavailable, "connection lost")
+		request := <-client.requests
+		assert.Equal(t, "token-1", request.GetOptionalStartCursor().GetToken())
+		require.Eventually(t, cache.connected.Load, time.Second, time.Millisecond)
+		assert.True(t, cached(cache, projectGet))
+	})
+
+	t.Run("should start over when the stream can't be resumed", func(t *testing.T) {
+		cache, client := newTestCheckCache(t)
+		client.responses <- update(group, schema.MemberRelationName, otherUser, "token-1")
+		// the stream only reads the next response once done with the update
+		client.responses <- &authzedpb.WatchResponse{}
+		_, _, lookup := cache.get(projectGet)
+		cache.add(projectGet, true, lookup)
+
+		client.errs <- status.Error(codes.FailedPrecondition, "revision was garbage collected")
+		request := <-client.requests
+		assert.Nil(t, request.GetOptionalStartCursor())
+		require.Eventually(t, cache.connected.Load, time.Second, time.Millisecond)
+		assert.False(t, cached(cache, projectGet))
+	})
+}
@@ -1,5 +1,7 @@
 package spicedb
 
+import "time"
+
 type Config struct {
 	Host         string `yaml:"host"`
 	Port         string `yaml:"port" default:"50051"`
@@ -19,4 +21,21 @@ type Config struct {
 	// CheckTrace enables tracing in check api for spicedb, it adds considerable
 	// latency to the check calls and shouldn't be enabled in production
 	CheckTrace bool `yaml:"check_trace" mapstructure:"check_trace" default:"false"`
+
+	// CheckCache caches the results of permission checks in memory, the
+	// results are invalidated as spicedb reports relationship changes
+	CheckCache CheckCacheConfig `yaml:"check_cache" mapstructure:"check_cache"`
+}
+
+type CheckCacheConfig struct {
+	// Enabled requires the datastore of spicedb to support the watch api, for
+	// postgres track_commit_timestamp has to be on
+	Enabled bool `yaml:"enabled" mapstructure:"enabled" default:"false"`
+
+	// Size is the max number of cached check results
+	Size int `yaml:"size" mapstructure:"size" default:"100000"`
+
+	// TTL bounds how long a result is cached, results depending on time bound
+	// policies are never cached
+	TTL time.Duration `yaml:"ttl" mapstructure:"ttl" default:"30s"`
 }
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"io"
 	"sync/atomic"
+	"time"
 
 	grpczap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	authzedpb "github.com/authzed/authzed-go/proto/authzed/api/v1"
 	newrelic "github.com/newrelic/go-agent"
@@ -36,6 +38,19 @@ type RelationRepository struct {
 	// We can also store multiple tokens in the cache based on what kind of resource we are dealing with
 	// but that adds complexity.
 	lastToken atomic.Pointer[authzedpb.ZedToken]
+
+	// checkCache serves the results of checks without calling spicedb when set
+	checkCache *CheckCache
+}
+
+type RelationRepositoryOption func(*RelationRepository)
+
+// WithCheckCache serves the results of permission checks from the cache,
+// relations written by the repository invalidate it right away
+func WithCheckCache(cache *CheckCache) RelationRepositoryOption {
+	return func(r *RelationRepository) {
+		r.checkCache = cache
+	}
 }
 
 type ConsistencyLevel string
@@ -52,28 +67,22 @@ const (
 
 const nrProductName = "spicedb"
 
-func NewRelationRepository(spiceDB *SpiceDB, consistency ConsistencyLevel, tracing bool) *RelationRepository {
-	return &RelationRepository{
+func NewRelationRepository(spiceDB *SpiceDB, consistency ConsistencyLevel, tracing bool, opts ...RelationRepositoryOption) *RelationRepository {
+	r := &RelationRepository{
 		spiceDB:     spiceDB,
 		consistency: consistency,
 		tracing:     tracing,
 	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *RelationRepository) Add(ctx context.Context, rel relation.Relation) error {
-	relationship := &authzedpb.Relationship{
-		Resource: &authzedpb.ObjectReference{
-			ObjectType: rel.Object.Namespace,
-			ObjectId:   rel.Object.ID,
-		},
-		Relation: rel.RelationName,
-		Subject: &authzedpb.SubjectReference{
-			Object: &authzedpb.ObjectReference{
-				ObjectType: rel.Subject.Namespace,
-				ObjectId:   rel.Subject.ID,
-			},
-			OptionalRelation: rel.Subject.SubRelationName,
-		},
+	relationship, err := toRelationship(rel)
+	if err != nil {
+		return err
 	}
 	request := &authzedpb.WriteRelationshipsRequest{
 		Updates: []*authzedpb.RelationshipUpdate{
@@ -105,12 +114,58 @@ func (r *RelationRepository) Add(ctx context.Context, rel relation.Relation) err
 	}
 
 	r.lastToken.Store(resp.GetWrittenAt())
+	if r.checkCache != nil {
+		r.checkCache.invalidate(rel.Subject)
+	}
 	return nil
 }
 
+// toRelationship converts the relation to the relationship written to spicedb
+func toRelationship(rel relation.Relation) (*authzedpb.Relationship, error) {
+	relationship := &authzedpb.Relationship{
+		Resource: &authzedpb.ObjectReference{
+			ObjectType: rel.Object.Namespace,
+			ObjectId:   rel.Object.ID,
+		},
+		Relation: rel.RelationName,
+		Subject: &authzedpb.SubjectReference{
+			Object: &authzedpb.ObjectReference{
+				ObjectType: rel.Subject.Namespace,
+				ObjectId:   rel.Subject.ID,
+			},
+			OptionalRelation: rel.Subject.SubRelationName,
+		},
+	}
+	if rel.Caveat != nil {
+		caveatContext, err := structpb.NewStruct(rel.Caveat.Context)
+		if err != nil {
+			return nil, fmt.Errorf("invalid caveat context: %w", err)
+		}
+		relationship.OptionalCaveat = &authzedpb.ContextualizedCaveat{
+			CaveatName: rel.Caveat.Name,
+			Context:    caveatContext,
+		}
+	}
+	return relationship, nil
+}
+
 func (r *RelationRepository) Check(ctx context.Context, rel relation.Relation) (bool, error) {
+	consistency := r.getConsistencyForCheck()
+	caveatContext := checkContext()
+	var lookup checkLookup
+	if r.checkCache != nil {
+		allowed, found, l := r.checkCache.get(rel)
+		if found {
+			return allowed, nil
+		}
+		lookup = l
+		consistency = r.getConsistencyForCachedCheck(lookup)
+		// caveats are left unevaluated to tell the results depending on them
+		caveatContext = nil
+	}
+
 	request := &authzedpb.CheckPermissionRequest{
-		Consistency: r.getConsistencyForCheck(),
+		Consistency: consistency,
 		Resource: &authzedpb.ObjectReference{
 			ObjectId:   rel.Object.ID,
 			ObjectType: rel.Object.Namespace,
@@ -123,6 +178,7 @@ func (r *RelationRepository) Check(ctx context.Context, rel relation.Relation) (
 			OptionalRelation: rel.Subject.SubRelationName,
 		},
 		Permission:  rel.RelationName,
+		Context:     caveatContext,
 		WithTracing: r.tracing,
 	}
 
@@ -141,13 +197,93 @@ func (r *RelationRepository) Check(ctx context.Context, rel relation.Relation) (
 	if err != nil {
 		return false, err
 	}
+	cacheable := r.checkCache != nil
+	if response.GetPermissionship() == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_CONDITIONAL_PERMISSION && request.Context == nil {
+		// the result depends on a caveat and may change with time, it is
+		// checked with the time of the check and isn't cached
+		cacheable = false
+		request.Context = checkContext()
+		response, err = r.spiceDB.client.CheckPermission(ctx, request)
+		if err != nil {
+			return false, err
+		}
+	}
 	if response.GetDebugTrace() != nil {
 		str, _ := json.Marshal(response.GetDebugTrace())
 		grpczap.Extract(ctx).Info("CheckPermission", zap.String("trace", string(str)))
 	}
 
 	r.lastToken.Store(response.GetCheckedAt())
-	return response.GetPermissionship() == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION, nil
+	allowed := response.GetPermissionship() == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
+	if cacheable {
+		r.checkCache.add(rel, allowed, lookup)
+	}
+	return allowed, nil
+}
+
+// CheckWithTrace checks the permission at full consistency with tracing
+// enabled, whatever the configured level is, so the trace is of the latest
+// relations
+func (r *RelationRepository) CheckWithTrace(ctx context.Context, rel relation.Relation) (bool, relation.CheckTrace, error) {
+	request := &authzedpb.CheckPermissionRequest{
+		Consistency: &authzedpb.Consistency{Requirement: &authzedpb.Consistency_FullyConsistent{FullyConsistent: true}},
+		Resource: &authzedpb.ObjectReference{
+			ObjectId:   rel.Object.ID,
+			ObjectType: rel.Object.Namespace,
+		},
+		Subject: &authzedpb.SubjectReference{
+			Object: &authzedpb.ObjectReference{
+				ObjectId:   rel.Subject.ID,
+				ObjectType: rel.Subject.Namespace,
+			},
+			OptionalRelation: rel.Subject.SubRelationName,
+		},
+		Permission:  rel.RelationName,
+		Context:     checkContext(),
+		WithTracing: true,
+	}
+
+	response, err := r.spiceDB.client.CheckPermission(ctx, request)
+	if err != nil {
+		return false, relation.CheckTrace{}, err
+	}
+	r.lastToken.Store(response.GetCheckedAt())
+	allowed := response.GetPermissionship() == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
+	return allowed, transformCheckTrace(response.GetDebugTrace().GetCheck()), nil
+}
+
+func transformCheckTrace(trace *authzedpb.CheckDebugTrace) relation.CheckTrace {
+	if trace == nil {
+		return relation.CheckTrace{Result: relation.TraceResultUnspecified}
+	}
+	transformed := relation.CheckTrace{
+		Object: relation.Object{
+			ID:        trace.GetResource().GetObjectId(),
+			Namespace: trace.GetResource().GetObjectType(),
+		},
+		Subject: relation.Subject{
+			ID:              trace.GetSubject().GetObject().GetObjectId(),
+			Namespace:       trace.GetSubject().GetObject().GetObjectType(),
+			SubRelationName: trace.GetSubject().GetOptionalRelation(),
+		},
+		RelationName: trace.GetPermission(),
+		IsPermission: trace.GetPermissionType() == authzedpb.CheckDebugTrace_PERMISSION_TYPE_PERMISSION,
+		Cached:       trace.GetWasCachedResult(),
+	}
+	switch trace.GetResult() {
+	case authzedpb.CheckDebugTrace_PERMISSIONSHIP_HAS_PERMISSION:
+		transformed.Result = relation.TraceResultAllowed
+	case authzedpb.CheckDebugTrace_PERMISSIONSHIP_NO_PERMISSION:
+		transformed.Result = relation.TraceResultDenied
+	case authzedpb.CheckDebugTrace_PERMISSIONSHIP_CONDITIONAL_PERMISSION:
+		transformed.Result = relation.TraceResultConditional
+	default:
+		transformed.Result = relation.TraceResultUnspecified
+	}
+	for _, child := range trace.GetSubProblems().GetTraces() {
+		transformed.Children = append(transformed.Children, transformCheckTrace(child))
+	}
+	return transformed
 }
 
 func (r *RelationRepository) Delete(ctx context.Context, rel relation.Relation) error {
@@ -189,6 +325,9 @@ func (r *RelationRepository) Delete(ctx context.Context, rel relation.Relation)
 	}
 
 	r.lastToken.Store(resp.GetDeletedAt())
+	if r.checkCache != nil {
+		r.checkCache.invalidate(rel.Subject)
+	}
 	return nil
 }
 
@@ -201,6 +340,7 @@ func (r *RelationRepository) LookupSubjects(ctx context.Context, rel relation.Re
 		},
 		Permission:        rel.RelationName,
 		SubjectObjectType: rel.Subject.Namespace,
+		Context:           checkContext(),
 	})
 	if err != nil {
 		return nil, err
@@ -214,6 +354,9 @@ func (r *RelationRepository) LookupSubjects(ctx context.Context, rel relation.Re
 		if err != nil {
 			return nil, err
 		}
+		if item.GetSubject().GetPermissionship() != authzedpb.LookupPermissionship_LOOKUP_PERMISSIONSHIP_HAS_PERMISSION {
+			continue
+		}
 		subjects = append(subjects, item.GetSubject().GetSubjectObjectId())
 	}
 	return subjects, nil
@@ -231,6 +374,7 @@ func (r *RelationRepository) LookupResources(ctx context.Context, rel relation.R
 			},
 			OptionalRelation: rel.Subject.SubRelationName,
 		},
+		Context: checkContext(),
 	})
 	if err != nil {
 		return nil, err
@@ -244,6 +388,9 @@ func (r *RelationRepository) LookupResources(ctx context.Context, rel relation.R
 		if err != nil {
 			return nil, err
 		}
+		if item.GetPermissionship() != authzedpb.LookupPermissionship_LOOKUP_PERMISSIONSHIP_HAS_PERMISSION {
+			continue
+		}
 		subjects = append(subjects, item.GetResourceObjectId())
 	}
 	return subjects, nil
@@ -294,8 +441,67 @@ func (r *RelationRepository) ListRelations(ctx context.Context, rel relation.Rel
 
 func (r *RelationRepository) BatchCheck(ctx context.Context, relations []relation.Relation) ([]relation.CheckPair, error) {
 	result := make([]relation.CheckPair, len(relations))
-	items := make([]*authzedpb.BulkCheckPermissionRequestItem, 0, len(relations))
-	for _, rel := range relations {
+	// pending are the indexes of the relations whose results aren't cached
+	pending := make([]int, 0, len(relations))
+	var lookup checkLookup
+	for idx, rel := range relations {
+		if r.checkCache != nil {
+			allowed, found, l := r.checkCache.get(rel)
+			if found {
+				result[idx] = relation.CheckPair{
+					Relation: relation.Relation{
+						Object:       rel.Object,
+						Subject:      rel.Subject,
+						RelationName: rel.RelationName,
+					},
+					Status: allowed,
+				}
+				continue
+			}
+			lookup = l
+		}
+		pending = append(pending, idx)
+	}
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	consistency := r.getConsistencyForCheck()
+	caveatContext := checkContext()
+	if r.checkCache != nil {
+		consistency = r.getConsistencyForCachedCheck(lookup)
+		// caveats are left unevaluated to tell the results depending on them
+		caveatContext = nil
+	}
+	response, err := r.bulkCheck(ctx, relations, pending, consistency, caveatContext)
+	if err != nil {
+		return result, err
+	}
+	var cacheLookup *checkLookup
+	if r.checkCache != nil {
+		cacheLookup = &lookup
+	}
+	conditional, respErr := r.collectBulkCheck(response, relations, pending, result, cacheLookup)
+	if len(conditional) > 0 {
+		// the results depend on a caveat and may change with time, they are
+		// checked with the time of the check and aren't cached
+		response, err = r.bulkCheck(ctx, relations, conditional, consistency, checkContext())
+		if err != nil {
+			return result, errors.Join(respErr, err)
+		}
+		_, err = r.collectBulkCheck(response, relations, conditional, result, nil)
+		respErr = errors.Join(respErr, err)
+	}
+	return result, respErr
+}
+
+// bulkCheck checks the relations at the indexes, caveats are evaluated with
+// the context when it is set
+func (r *RelationRepository) bulkCheck(ctx context.Context, relations []relation.Relation, indexes []int,
+	consistency *authzedpb.Consistency, caveatContext *structpb.Struct) (*authzedpb.BulkCheckPermissionResponse, error) {
+	items := make([]*authzedpb.BulkCheckPermissionRequestItem, 0, len(indexes))
+	for _, idx := range indexes {
+		rel := relations[idx]
 		items = append(items, &authzedpb.BulkCheckPermissionRequestItem{
 			Resource: &authzedpb.ObjectReference{
 				ObjectId:   rel.Object.ID,
@@ -309,21 +515,33 @@ func (r *RelationRepository) BatchCheck(ctx context.Context, relations []relatio
 				OptionalRelation: rel.Subject.SubRelationName,
 			},
 			Permission: rel.RelationName,
+			Context:    caveatContext,
 		})
 	}
-	request := &authzedpb.BulkCheckPermissionRequest{
-		Consistency: r.getConsistencyForCheck(),
+	response, err := r.spiceDB.client.BulkCheckPermission(ctx, &authzedpb.BulkCheckPermissionRequest{
+		Consistency: consistency,
 		Items:       items,
-	}
-
-	response, err := r.spiceDB.client.BulkCheckPermission(ctx, request)
+	})
 	if err != nil {
-		return result, err
+		return nil, err
 	}
+	r.lastToken.Store(response.GetCheckedAt())
+	return response, nil
+}
 
+// collectBulkCheck sets the results of the relations at the indexes. With a
+// lookup, caveats were left unevaluated: the results are cached with it
+// except the ones depending on a caveat, whose indexes are returned
+func (r *RelationRepository) collectBulkCheck(response *authzedpb.BulkCheckPermissionResponse, relations []relation.Relation,
+	indexes []int, result []relation.CheckPair, lookup *checkLookup) ([]int, error) {
+	var conditional []int
 	var respErr error = nil
 	for itemIdx, item := range response.GetPairs() {
-		result[itemIdx] = relation.CheckPair{
+		if itemIdx >= len(indexes) {
+			break
+		}
+		resultIdx := indexes[itemIdx]
+		result[resultIdx] = relation.CheckPair{
 			Relation: relation.Relation{
 				Object: relation.Object{
 					ID:        item.GetRequest().GetResource().GetObjectId(),
@@ -343,12 +561,18 @@ func (r *RelationRepository) BatchCheck(ctx context.Context, relations []relatio
 			continue
 		}
 		if item.GetItem() != nil {
-			result[itemIdx].Status = item.GetItem().GetPermissionship() == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
+			permissionship := item.GetItem().GetPermissionship()
+			if permissionship == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_CONDITIONAL_PERMISSION && lookup != nil {
+				conditional = append(conditional, resultIdx)
+				continue
+			}
+			result[resultIdx].Status = permissionship == authzedpb.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
+			if lookup != nil {
+				r.checkCache.add(relations[resultIdx], result[resultIdx].Status, *lookup)
+			}
 		}
 	}
-
-	r.lastToken.Store(response.GetCheckedAt())
-	return result, respErr
+	return conditional, respErr
 }
 
 func (r *RelationRepository) getConsistency() *authzedpb.Consistency {
@@ -376,3 +600,21 @@ func (r *RelationRepository) getConsistencyForCheck() *authzedpb.Consistency {
 	}
 	return &authzedpb.Consistency{Requirement: &authzedpb.Consistency_FullyConsistent{FullyConsistent: true}}
 }
+
+// getConsistencyForCachedCheck is the consistency of checks whose results are
+// cached, minimize latency reads are made at least as fresh as the changes
+// the cache was invalidated for
+func (r *RelationRepository) getConsistencyForCachedCheck(lookup checkLookup) *authzedpb.Consistency {
+	if r.consistency == ConsistencyLevelMinimizeLatency {
+		return lookup.consistency()
+	}
+	return r.getConsistencyForCheck()
+}
+
+// checkContext is the context caveats of relations are evaluated with, role
+// bindings of time bound policies only hold within their validity window
+func checkContext() *structpb.Struct {
+	return &structpb.Struct{Fields: map[string]*structpb.Value{
+		"now": structpb.NewStringValue(time.Now().UTC().Format(time.RFC3339)),
+	}}
+}
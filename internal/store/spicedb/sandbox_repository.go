@@ -0,0 +1,222 @@
+package spicedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	authzedpb "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/spicedb/pkg/development"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	v1dispatch "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+)
+
+// defaultSandboxMaxRelations bounds the relations copied to a sandbox
+const defaultSandboxMaxRelations = 50000
+
+// SandboxRepository evaluates checks on copies of the relations of spicedb,
+// the copies are loaded in an in memory spicedb datastore so changes made
+// to them are never written to spicedb
+type SandboxRepository struct {
+	spiceDB      *SpiceDB
+	maxRelations int
+}
+
+func NewSandboxRepository(spiceDB *SpiceDB) *SandboxRepository {
+	return &SandboxRepository{
+		spiceDB:      spiceDB,
+		maxRelations: defaultSandboxMaxRelations,
+	}
+}
+
+// Relations copies the relations the permissions of the objects are computed
+// from, it follows the subjects of the relations of the objects down to the
+// principals. It fails with relation.ErrSandboxTooLarge if there are more
+// relations than a sandbox holds.
+func (r *SandboxRepository) Relations(ctx context.Context, objects []relation.Object) ([]relation.Relation, error) {
+	var relations []relation.Relation
+	visited := map[relation.Object]bool{}
+	queue := append([]relation.Object{}, objects...)
+	for len(queue) > 0 {
+		object := queue[0]
+		queue = queue[1:]
+		if visited[object] {
+			continue
+		}
+		visited[object] = true
+
+		objectRelations, err := r.read(ctx, object)
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, objectRelations...)
+		if len(relations) > r.maxRelations {
+			return nil, relation.ErrSandboxTooLarge
+		}
+		for _, rel := range objectRelations {
+			// principals hold no relation the permissions of others
+			// depend on
+			if rel.Subject.ID == "*" ||
+				rel.Subject.Namespace == schema.UserPrincipal ||
+				rel.Subject.Namespace == schema.ServiceUserPrincipal {
+				continue
+			}
+			queue = append(queue, relation.Object{
+				ID:        rel.Subject.ID,
+				Namespace: rel.Subject.Namespace,
+			})
+		}
+	}
+	return relations, nil
+}
+
+func (r *SandboxRepository) read(ctx context.Context, object relation.Object) ([]relation.Relation, error) {
+	resp, err := r.spiceDB.client.ReadRelationships(ctx, &authzedpb.ReadRelationshipsRequest{
+		Consistency: &authzedpb.Consistency{Requirement: &authzedpb.Consistency_FullyConsistent{FullyConsistent: true}},
+		RelationshipFilter: &authzedpb.RelationshipFilter{
+			ResourceType:       object.Namespace,
+			OptionalResourceId: object.ID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var rels []relation.Relation
+	for {
+		item, err := resp.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		pbRel := item.GetRelationship()
+		rel := relation.Relation{
+			Object: relation.Object{
+				ID:        pbRel.GetResource().GetObjectId(),
+				Namespace: pbRel.GetResource().GetObjectType(),
+			},
+			Subject: relation.Subject{
+				ID:              pbRel.GetSubject().GetObject().GetObjectId(),
+				Namespace:       pbRel.GetSubject().GetObject().GetObjectType(),
+				SubRelationName: pbRel.GetSubject().GetOptionalRelation(),
+			},
+			RelationName: pbRel.GetRelation(),
+		}
+		if caveat := pbRel.GetOptionalCaveat(); caveat != nil {
+			rel.Caveat = &relation.Caveat{
+				Name:    caveat.GetCaveatName(),
+				Context: caveat.GetContext().AsMap(),
+			}
+		}
+		rels = append(rels, rel)
+	}
+	return rels, nil
+}
+
+// Permissions lists the permissions the schema defines on the namespace
+func (r *SandboxRepository) Permissions(ctx context.Context, namespace string) ([]string, error) {
+	definitions, err := r.definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return namespacePermissions(definitions, namespace), nil
+}
+
+// BatchCheck loads the relations in a sandbox and checks them, subjects only
+// have the permissions which don't depend on a caveat missing from the check
+// context
+func (r *SandboxRepository) BatchCheck(ctx context.Context, relations []relation.Relation, checks []relation.Relation) ([]relation.CheckPair, error) {
+	resp, err := r.spiceDB.client.ReadSchema(ctx, &authzedpb.ReadSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return sandboxCheck(ctx, resp.GetSchemaText(), relations, checks)
+}
+
+// definitions compiles the schema of spicedb to its object definitions
+func (r *SandboxRepository) definitions(ctx context.Context) ([]*core.NamespaceDefinition, error) {
+	resp, err := r.spiceDB.client.ReadSchema(ctx, &authzedpb.ReadSchemaRequest{})
+	if err != nil {
+		return nil, err
+	}
+	compiled, devErr, err := development.CompileSchema(resp.GetSchemaText())
+	if err != nil {
+		return nil, err
+	}
+	if devErr != nil {
+		return nil, fmt.Errorf("invalid schema: %s", devErr.GetMessage())
+	}
+	return compiled.ObjectDefinitions, nil
+}
+
+func namespacePermissions(definitions []*core.NamespaceDefinition, namespace string) []string {
+	var permissions []string
+	for _, definition := range definitions {
+		if definition.GetName() != namespace {
+			continue
+		}
+		for _, rel := range definition.GetRelation() {
+			// permissions are the relations computed from others
+			if rel.GetUsersetRewrite() != nil {
+				permissions = append(permissions, rel.GetName())
+			}
+		}
+	}
+	return permissions
+}
+
+// sandboxCheck checks the relations in an in memory datastore holding the
+// schema and the relations
+func sandboxCheck(ctx context.Context, schemaText string, relations []relation.Relation, checks []relation.Relation) ([]relation.CheckPair, error) {
+	tuples := make([]*core.RelationTuple, 0, len(relations))
+	for _, rel := range relations {
+		relationship, err := toRelationship(rel)
+		if err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, tuple.FromRelationship[*authzedpb.ObjectReference, *authzedpb.SubjectReference, *authzedpb.ContextualizedCaveat](relationship))
+	}
+	devContext, devErrs, err := development.NewDevContext(ctx, &devinterface.RequestContext{
+		Schema:        schemaText,
+		Relationships: tuples,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if devErrs != nil {
+		var errs error
+		for _, devErr := range devErrs.GetInputErrors() {
+			errs = errors.Join(errs, errors.New(devErr.GetMessage()))
+		}
+		return nil, fmt.Errorf("%w: %w", relation.ErrInvalidDetail, errs)
+	}
+	defer devContext.Dispose()
+
+	caveatContext := checkContext().AsMap()
+	result := make([]relation.CheckPair, 0, len(checks))
+	for _, check := range checks {
+		subjectRelation := check.Subject.SubRelationName
+		if subjectRelation == "" {
+			subjectRelation = tuple.Ellipsis
+		}
+		checkResult, err := development.RunCheck(devContext,
+			tuple.ObjectAndRelation(check.Object.Namespace, check.Object.ID, check.RelationName),
+			tuple.ObjectAndRelation(check.Subject.Namespace, check.Subject.ID, subjectRelation),
+			caveatContext)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, relation.CheckPair{
+			Relation: check,
+			Status:   checkResult.Permissionship == v1dispatch.ResourceCheckResult_MEMBER,
+		})
+	}
+	return result, nil
+}
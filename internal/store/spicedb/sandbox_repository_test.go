@@ -0,0 +1,93 @@
+package spicedb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/authzed/spicedb/pkg/development"
+	"github.com/raystack/frontier/core/relation"
+	"github.com/raystack/frontier/internal/bootstrap/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	project     = relation.Object{ID: "project-id", Namespace: schema.ProjectNamespace}
+	projectUser = relation.Subject{ID: "user-id", Namespace: schema.UserPrincipal}
+)
+
+// projectViewerRelations bind the viewer role of the project to the members
+// of a group the user is a member of
+func projectViewerRelations(bearerCaveat *relation.Caveat) []relation.Relation {
+	return []relation.Relation{
+		{
+			Object:       project,
+			Subject:      relation.Subject{ID: "policy-id", Namespace: schema.RoleBindingNamespace},
+			RelationName: schema.RoleGrantRelationName,
+		},
+		{
+			Object:       relation.Object{ID: "policy-id", Namespace: schema.RoleBindingNamespace},
+			Subject:      relation.Subject{ID: "role-id", Namespace: schema.RoleNamespace},
+			RelationName: schema.RoleRelationName,
+		},
+		{
+			Object:       relation.Object{ID: "policy-id", Namespace: schema.RoleBindingNamespace},
+			Subject:      relation.Subject{ID: "group-id", Namespace: schema.GroupNamespace, SubRelationName: schema.MemberRelationName},
+			RelationName: schema.RoleBearerRelationName,
+			Caveat:       bearerCaveat,
+		},
+		{
+			Object:       relation.Object{ID: "group-id", Namespace: schema.GroupNamespace},
+			Subject:      projectUser,
+			RelationName: schema.MemberRelationName,
+		},
+		{
+			Object:       relation.Object{ID: "role-id", Namespace: schema.RoleNamespace},
+			Subject:      relation.Subject{ID: "*", Namespace: schema.UserPrincipal},
+			RelationName: "app_project_get",
+		},
+	}
+}
+
+func TestSandboxCheck(t *testing.T) {
+	checks := []relation.Relation{
+		{Object: project, Subject: projectUser, RelationName: schema.GetPermission},
+		{Object: project, Subject: projectUser, RelationName: schema.DeletePermission},
+	}
+
+	t.Run("should check the relations in memory", func(t *testing.T) {
+		got, err := sandboxCheck(context.Background(), schema.BaseSchemaZed, projectViewerRelations(nil), checks)
+		require.NoError(t, err)
+		assert.True(t, got[0].Status)
+		assert.False(t, got[1].Status)
+	})
+
+	t.Run("should evaluate the validity window of role bindings", func(t *testing.T) {
+		got, err := sandboxCheck(context.Background(), schema.BaseSchemaZed, projectViewerRelations(&relation.Caveat{
+			Name: schema.ValidWindowCaveatName,
+			Context: map[string]any{
+				"starts_at":  "2020-01-01T00:00:00Z",
+				"expires_at": "2021-01-01T00:00:00Z",
+			},
+		}), checks)
+		require.NoError(t, err)
+		assert.False(t, got[0].Status)
+	})
+
+	t.Run("should reject relations the schema doesn't allow", func(t *testing.T) {
+		_, err := sandboxCheck(context.Background(), schema.BaseSchemaZed, []relation.Relation{{
+			Object:       project,
+			Subject:      projectUser,
+			RelationName: "unknown",
+		}}, checks)
+		assert.ErrorIs(t, err, relation.ErrInvalidDetail)
+	})
+}
+
+func TestNamespacePermissions(t *testing.T) {
+	compiled, devErr, err := development.CompileSchema(schema.BaseSchemaZed)
+	require.NoError(t, err)
+	require.Nil(t, devErr)
+	assert.ElementsMatch(t, []string{"delete", "update", "get", "policymanage", "resourcelist"},
+		namespacePermissions(compiled.ObjectDefinitions, schema.ProjectNamespace))
+}
@@ -1,5 +1,7 @@
 package logger
 
+import "github.com/raystack/frontier/core/audit"
+
 type Config struct {
 	// log level - debug, info, warning, error, fatal
 	Level string `yaml:"level" mapstructure:"level" default:"info" json:"level,omitempty"`
@@ -12,4 +14,10 @@ type Config struct {
 
 	// IgnoredAuditEvents contains list of events which should be ignored in audit logs
 	IgnoredAuditEvents []string `yaml:"ignored_audit_events" mapstructure:"ignored_audit_events" json:"ignored_audit_events,omitempty"`
+
+	// AuditSinks exports a copy of every audit log to external systems like files, collectors or brokers
+	AuditSinks []audit.SinkConfig `yaml:"audit_sinks" mapstructure:"audit_sinks" json:"audit_sinks,omitempty"`
+
+	// AuditRetention moves logs stored in db past their retention to an archive bucket
+	AuditRetention audit.RetentionConfig `yaml:"audit_retention" mapstructure:"audit_retention" json:"audit_retention,omitempty"`
 }
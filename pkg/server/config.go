@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 
+	"github.com/raystack/frontier/core/accessrequest"
 	"github.com/raystack/frontier/core/webhook"
 
 	"github.com/raystack/frontier/pkg/server/interceptors"
@@ -51,6 +52,11 @@ type Config struct {
 	// Headers which will have user's email id
 	IdentityProxyHeader string `yaml:"identity_proxy_header" mapstructure:"identity_proxy_header" default:""`
 
+	// TrustedProxies are the ip addresses or cidrs of the proxies in front of
+	// the server, the X-Forwarded-For header is only read from them to find
+	// the address of clients
+	TrustedProxies []string `yaml:"trusted_proxies" mapstructure:"trusted_proxies"`
+
 	// ResourcesPath is a directory path where resources is defined
 	// that this service should implement
 	ResourcesConfigPath string `yaml:"resources_config_path" mapstructure:"resources_config_path"`
@@ -72,4 +78,6 @@ type Config struct {
 	Mailer mailer.Config `yaml:"mailer" mapstructure:"mailer"`
 
 	Webhook webhook.Config `yaml:"webhook" mapstructure:"webhook"`
+
+	AccessRequest accessrequest.Config `yaml:"access_request" mapstructure:"access_request"`
 }
@@ -58,6 +58,10 @@ const (
 
 	// RequestIDHeader is the key to store request id from http headers
 	RequestIDHeader = "x-request-id"
+
+	// RetryAfterRequestKey is returned with rate limited requests containing
+	// the seconds until the client can retry
+	RetryAfterRequestKey = "retry-after"
 )
 
 func GetRequestIDFromCtx(ctx context.Context) (string, bool) {
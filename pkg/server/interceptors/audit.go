@@ -2,6 +2,7 @@ package interceptors
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/raystack/frontier/core/audit"
 	"google.golang.org/grpc"
@@ -13,3 +14,11 @@ func UnaryCtxWithAudit(service *audit.Service) grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// WithAudit sets the audit service in the context of http requests served
+// outside the grpc gateway
+func WithAudit(h http.Handler, service *audit.Service) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(audit.SetContextWithService(r.Context(), service)))
+	})
+}
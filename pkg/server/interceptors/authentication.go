@@ -3,6 +3,7 @@ package interceptors
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/raystack/frontier/core/audit"
 
@@ -14,7 +15,11 @@ import (
 	"google.golang.org/grpc"
 )
 
-func UnaryAuthenticationCheck() grpc.UnaryServerInterceptor {
+// UnaryAuthenticationCheck authenticates the caller of every rpc outside of
+// the skip list, oauth2 clients must have been granted the scope of the rpc
+// and callers of the rpcs with a recent authentication rule must have logged
+// in within its max age
+func UnaryAuthenticationCheck(recentAuthenticationRules map[string]time.Duration) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if _, ok := info.Server.(*health.Handler); ok {
 			// pass through health handler
@@ -34,11 +39,27 @@ func UnaryAuthenticationCheck() grpc.UnaryServerInterceptor {
 		if err != nil {
 			return nil, err
 		}
+		if !principal.HasScope(methodScope(info.FullMethod)) {
+			return nil, ErrInsufficientScope
+		}
 		ctx = authenticate.SetContextWithPrincipal(ctx, &principal)
-		ctx = audit.SetContextWithActor(ctx, audit.Actor{
+		actor := audit.Actor{
 			ID:   principal.ID,
 			Type: principal.Type,
-		})
+		}
+		if principal.ImpersonatedBy != nil {
+			actor.ImpersonatedBy = &audit.Actor{
+				ID:   principal.ImpersonatedBy.ID,
+				Type: principal.ImpersonatedBy.Type,
+			}
+		}
+		ctx = audit.SetContextWithActor(ctx, actor)
+
+		if maxAge, ok := recentAuthenticationRules[info.FullMethod]; ok {
+			if err := serverHandler.CheckRecentAuthentication(ctx, principal, maxAge); err != nil {
+				return nil, err
+			}
+		}
 		return handler(ctx, req)
 	}
 }
@@ -1,6 +1,7 @@
 package interceptors
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -15,3 +16,14 @@ func GatewayHeaderMatcherFunc(headerKeys map[string]bool) func(key string) (stri
 		return runtime.DefaultHeaderMatcher(key)
 	}
 }
+
+// GatewayOutgoingHeaderMatcherFunc allows sending headers set by grpc methods as is instead of
+// prefixing them with `Grpc-Metadata-`
+func GatewayOutgoingHeaderMatcherFunc(headerKeys map[string]bool) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		if _, ok := headerKeys[strings.ToLower(key)]; ok {
+			return key, true
+		}
+		return fmt.Sprintf("%s%s", runtime.MetadataHeaderPrefix, key), true
+	}
+}
@@ -0,0 +1,46 @@
+package interceptors
+
+import (
+	"strings"
+
+	"github.com/raystack/frontier/core/authenticate"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrInsufficientScope = status.Error(codes.PermissionDenied, "access token wasn't granted the scope of the operation")
+
+const (
+	frontierServicePrefix = "/raystack.frontier.v1beta1.FrontierService/"
+	adminServicePrefix    = "/raystack.frontier.v1beta1.AdminService/"
+)
+
+// readMethodPrefixes name the rpcs of the frontier service which only read
+var readMethodPrefixes = []string{"Get", "List", "Check", "BatchCheck", "Describe", "Search"}
+
+// methodScopes overrides the scope of the rpcs not named after what they do
+var methodScopes = map[string]string{
+	frontierServicePrefix + "HasTrialed": authenticate.ScopeRead,
+}
+
+// methodScope returns the scope an oauth2 client needs to call the rpc, rpcs
+// outside of the frontier and admin services have none and are never
+// allowed to clients
+func methodScope(fullMethod string) string {
+	if scope, ok := methodScopes[fullMethod]; ok {
+		return scope
+	}
+	if strings.HasPrefix(fullMethod, adminServicePrefix) {
+		return authenticate.ScopeAdmin
+	}
+	name, ok := strings.CutPrefix(fullMethod, frontierServicePrefix)
+	if !ok {
+		return ""
+	}
+	for _, prefix := range readMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return authenticate.ScopeRead
+		}
+	}
+	return authenticate.ScopeWrite
+}
@@ -0,0 +1,53 @@
+package interceptors
+
+import (
+	"testing"
+
+	"github.com/raystack/frontier/core/authenticate"
+	frontierv1beta1 "github.com/raystack/frontier/proto/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestMethodScope(t *testing.T) {
+	tests := []struct {
+		method string
+		want   string
+	}{
+		{method: frontierv1beta1.FrontierService_GetCurrentUser_FullMethodName, want: authenticate.ScopeRead},
+		{method: frontierv1beta1.FrontierService_ListOrganizationsByCurrentUser_FullMethodName, want: authenticate.ScopeRead},
+		{method: frontierv1beta1.FrontierService_CheckResourcePermission_FullMethodName, want: authenticate.ScopeRead},
+		{method: frontierv1beta1.FrontierService_HasTrialed_FullMethodName, want: authenticate.ScopeRead},
+		{method: frontierv1beta1.FrontierService_CreateProject_FullMethodName, want: authenticate.ScopeWrite},
+		{method: frontierv1beta1.FrontierService_DeleteUser_FullMethodName, want: authenticate.ScopeWrite},
+		{method: frontierv1beta1.AdminService_ListAllUsers_FullMethodName, want: authenticate.ScopeAdmin},
+		{method: "/grpc.health.v1.Health/Check", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			assert.Equal(t, tt.want, methodScope(tt.method))
+		})
+	}
+
+	t.Run("should cover every rpc of the api", func(t *testing.T) {
+		for _, desc := range []struct {
+			name    string
+			methods []string
+		}{
+			{name: frontierv1beta1.FrontierService_ServiceDesc.ServiceName, methods: methodNames(frontierv1beta1.FrontierService_ServiceDesc.Methods)},
+			{name: frontierv1beta1.AdminService_ServiceDesc.ServiceName, methods: methodNames(frontierv1beta1.AdminService_ServiceDesc.Methods)},
+		} {
+			for _, method := range desc.methods {
+				assert.NotEmpty(t, methodScope("/"+desc.name+"/"+method), method)
+			}
+		}
+	})
+}
+
+func methodNames(methods []grpc.MethodDesc) []string {
+	names := make([]string, 0, len(methods))
+	for _, method := range methods {
+		names = append(names, method.MethodName)
+	}
+	return names
+}
@@ -68,19 +68,7 @@ func (h Session) GatewayResponseModifier(ctx context.Context, w http.ResponseWri
 			w.Header().Del("grpc-metadata-" + consts.SessionIDGatewayKey)
 
 			// put session id in request cookies
-			if encoded, err := h.cookieCodec.Encode(consts.SessionRequestKey, sessionIDFromGateway); err == nil {
-				http.SetCookie(w, &http.Cookie{
-					Domain:   h.conf.Domain,
-					Name:     consts.SessionRequestKey,
-					Value:    encoded,
-					Path:     "/",
-					Expires:  time.Now().UTC().Add(h.conf.Validity),
-					MaxAge:   int(h.conf.Validity.Seconds()),
-					HttpOnly: true,
-					SameSite: CookieSameSite(h.conf.SameSite),
-					Secure:   h.conf.Secure,
-				})
-			}
+			_ = h.SetSessionCookie(w, sessionIDFromGateway)
 		}
 	}
 
@@ -127,6 +115,63 @@ func (h Session) GatewayResponseModifier(ctx context.Context, w http.ResponseWri
 	return nil
 }
 
+// SetSessionCookie sets the encoded session id as cookie of a plain http response
+func (h Session) SetSessionCookie(w http.ResponseWriter, sessionID string) error {
+	if h.cookieCodec == nil {
+		return fmt.Errorf("session cookie codec is not configured")
+	}
+	encoded, err := h.cookieCodec.Encode(consts.SessionRequestKey, sessionID)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Domain:   h.conf.Domain,
+		Name:     consts.SessionRequestKey,
+		Value:    encoded,
+		Path:     "/",
+		Expires:  time.Now().UTC().Add(h.conf.Validity),
+		MaxAge:   int(h.conf.Validity.Seconds()),
+		HttpOnly: true,
+		SameSite: CookieSameSite(h.conf.SameSite),
+		Secure:   h.conf.Secure,
+	})
+	return nil
+}
+
+// RequestContext decodes the session cookie and the Authorization header of a
+// plain http request and passes them as incoming metadata, same as it is done
+// for requests served over grpc
+func (h Session) RequestContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	if h.cookieCodec != nil {
+		if requestCookie, err := r.Cookie(consts.SessionRequestKey); err == nil {
+			var sessionID string
+			if err := h.cookieCodec.Decode(requestCookie.Name, requestCookie.Value, &sessionID); err == nil {
+				md.Set(consts.SessionIDGatewayKey, strings.TrimSpace(sessionID))
+			}
+		}
+	}
+	if authHeader := r.Header.Get("Authorization"); authHeader != "" {
+		setAuthorizationMetadata(md, authHeader)
+	}
+	return metadata.NewIncomingContext(r.Context(), md)
+}
+
+// setAuthorizationMetadata passes the access token or the client credentials
+// of the Authorization header as gateway context
+func setAuthorizationMetadata(md metadata.MD, authHeader string) {
+	tokenVal := strings.TrimSpace(strings.TrimPrefix(authHeader, "Bearer "))
+	if token, err := jwt.ParseInsecure([]byte(tokenVal)); err == nil {
+		if token.JwtID() != "" && token.Expiration().After(time.Now().UTC()) {
+			md.Set(consts.UserTokenGatewayKey, tokenVal)
+		}
+	}
+	secretVal := strings.TrimSpace(strings.TrimPrefix(authHeader, "Basic "))
+	if len(secretVal) > 0 {
+		md.Set(consts.UserSecretGatewayKey, secretVal)
+	}
+}
+
 // UnaryGRPCRequestHeadersAnnotator converts session cookies set in grpc metadata to context
 // this requires decrypting the cookie and setting it as context
 func (h Session) UnaryGRPCRequestHeadersAnnotator() grpc.UnaryServerInterceptor {
@@ -158,16 +203,7 @@ func (h Session) UnaryGRPCRequestHeadersAnnotator() grpc.UnaryServerInterceptor
 			}
 			// check if the same token is part of Authorization header
 			if authHeader := incomingMD.Get("authorization"); len(authHeader) > 0 {
-				tokenVal := strings.TrimSpace(strings.TrimPrefix(authHeader[0], "Bearer "))
-				if token, err := jwt.ParseInsecure([]byte(tokenVal)); err == nil {
-					if token.JwtID() != "" && token.Expiration().After(time.Now().UTC()) {
-						incomingMD.Set(consts.UserTokenGatewayKey, tokenVal)
-					}
-				}
-				secretVal := strings.TrimSpace(strings.TrimPrefix(authHeader[0], "Basic "))
-				if len(secretVal) > 0 {
-					incomingMD.Set(consts.UserSecretGatewayKey, secretVal)
-				}
+				setAuthorizationMetadata(incomingMD, authHeader[0])
 			}
 
 			ctx = metadata.NewIncomingContext(ctx, incomingMD)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -41,7 +42,24 @@ import (
 	newrelic "github.com/newrelic/go-agent"
 	"github.com/newrelic/go-agent/_integrations/nrgrpc"
 	"github.com/raystack/frontier/internal/api"
+	accessrequestapi "github.com/raystack/frontier/internal/api/accessrequest"
+	auditapi "github.com/raystack/frontier/internal/api/audit"
+	explainapi "github.com/raystack/frontier/internal/api/explain"
+	httputilapi "github.com/raystack/frontier/internal/api/httputil"
+	idpapi "github.com/raystack/frontier/internal/api/idp"
+	impersonationapi "github.com/raystack/frontier/internal/api/impersonation"
+	mfaapi "github.com/raystack/frontier/internal/api/mfa"
+	oauth2api "github.com/raystack/frontier/internal/api/oauth2"
+	passkeyapi "github.com/raystack/frontier/internal/api/passkey"
+	passwordapi "github.com/raystack/frontier/internal/api/password"
+	policyapi "github.com/raystack/frontier/internal/api/policy"
+	samlapi "github.com/raystack/frontier/internal/api/saml"
+	scimapi "github.com/raystack/frontier/internal/api/scim"
+	sessionapi "github.com/raystack/frontier/internal/api/session"
+	simulationapi "github.com/raystack/frontier/internal/api/simulation"
 	"github.com/raystack/frontier/internal/api/v1beta1"
+	webhookapi "github.com/raystack/frontier/internal/api/webhook"
+	"github.com/raystack/frontier/pkg/utils"
 	frontierv1beta1 "github.com/raystack/frontier/proto/v1beta1"
 	"github.com/raystack/salt/log"
 	"github.com/raystack/salt/mux"
@@ -109,6 +127,17 @@ func ServeUI(ctx context.Context, logger log.Logger, uiConfig UIConfig, apiServe
 	}
 }
 
+// gatewayProxies are the addresses the grpc gateway connects to the grpc
+// server from, the gateway dials the host the server listens on
+func gatewayProxies(host string) utils.Proxies {
+	hosts := []string{"127.0.0.0/8", "::1"}
+	if ip := net.ParseIP(host); ip != nil && !ip.IsUnspecified() {
+		hosts = append(hosts, ip.String())
+	}
+	proxies, _ := utils.ParseProxies(hosts)
+	return proxies
+}
+
 func Serve(
 	ctx context.Context,
 	logger log.Logger,
@@ -117,6 +146,11 @@ func Serve(
 	deps api.Deps,
 	promRegistry *prometheus.Registry,
 ) error {
+	proxies, err := utils.ParseProxies(cfg.TrustedProxies)
+	if err != nil {
+		return err
+	}
+
 	httpMux := http.NewServeMux()
 	grpcDialCtx, grpcDialCancel := context.WithTimeout(ctx, grpcDialTimeout)
 	defer grpcDialCancel()
@@ -180,6 +214,13 @@ func Serve(
 				},
 			),
 		),
+		runtime.WithOutgoingHeaderMatcher(
+			interceptors.GatewayOutgoingHeaderMatcherFunc(
+				map[string]bool{
+					consts.RetryAfterRequestKey: true,
+				},
+			),
+		),
 		runtime.WithForwardResponseOption(sessionMiddleware.GatewayResponseModifier),
 		runtime.WithMarshalerOption(runtime.MIMEWildcard, defaultMimeMarshaler),
 		runtime.WithMarshalerOption(interceptors.RawBytesMIME, &interceptors.RawJSONPb{
@@ -196,6 +237,39 @@ func Serve(
 	rootHandler = interceptors.ByteMimeWrapper(rootHandler)
 
 	httpMux.Handle("/", rootHandler)
+	corsWrapper := func(h http.Handler) http.Handler {
+		h = interceptors.WithAudit(h, deps.AuditService)
+		if len(cfg.Cors.AllowedOrigins) > 0 {
+			return interceptors.WithCors(h, cfg.Cors)
+		}
+		return h
+	}
+	oauth2Handler := oauth2api.NewHandler(logger, deps.OAuth2Service, deps.AuthnService, deps.SessionService, sessionMiddleware, cfg.Authentication)
+	oauth2Handler.Register(httpMux, corsWrapper)
+	router := httputilapi.NewRouter(httpMux, httputilapi.Wrap(corsWrapper),
+		httputilapi.ResolvePrincipal(deps.AuthnService, sessionMiddleware),
+		httputilapi.ClientScopes(),
+		httputilapi.RecentAuthentication(deps.AuthnService, cfg.Authentication.RecentAuthenticationRules()))
+	samlapi.NewHandler(logger, deps.SAMLService, deps.AuthnService, deps.MFAService, sessionMiddleware, proxies).Register(httpMux, corsWrapper)
+	samlapi.NewConnectionHandler(logger, deps.AuthnService, deps.SAMLService, deps.OrgService, deps.ResourceService,
+		deps.UserService, deps.ServiceUserService, sessionMiddleware).Register(router)
+	idpapi.NewHandler(logger, deps.AuthnService, deps.IDPService, deps.OrgService, deps.ResourceService,
+		deps.UserService, deps.ServiceUserService, sessionMiddleware).Register(router)
+	scimapi.NewHandler(logger, deps.SCIMService).Register(httpMux, corsWrapper)
+	mfaapi.NewHandler(logger, deps.MFAService, deps.SessionService, sessionMiddleware).Register(router)
+	sessionapi.NewHandler(logger, deps.AuthnService, deps.SessionService, deps.OAuth2Service, deps.UserService,
+		deps.ServiceUserService, sessionMiddleware).Register(router)
+	impersonationapi.NewHandler(logger, deps.ImpersonationService, deps.AuthnService, sessionMiddleware).Register(router)
+	passwordapi.NewHandler(logger, deps.AuthnService, deps.UserService, deps.ServiceUserService, sessionMiddleware, proxies).Register(router)
+	passkeyapi.NewHandler(logger, deps.AuthnService, deps.PasskeyService, sessionMiddleware).Register(router)
+	policyapi.NewHandler(logger, deps.AuthnService, deps.PolicyService, deps.ResourceService, sessionMiddleware).Register(router)
+	accessrequestapi.NewHandler(logger, deps.AuthnService, deps.AccessRequestService, sessionMiddleware).Register(router)
+	explainapi.NewHandler(logger, deps.AuthnService, deps.ExplainService, deps.UserService, deps.ServiceUserService, sessionMiddleware).Register(router)
+	simulationapi.NewHandler(logger, deps.AuthnService, deps.SimulationService, deps.PolicyService, deps.RoleService, deps.ResourceService, sessionMiddleware).Register(router)
+	auditapi.NewHandler(logger, deps.AuthnService, deps.AuditService, deps.AuditRetention, deps.ResourceService, deps.UserService, deps.ServiceUserService, sessionMiddleware).Register(router)
+	webhookapi.NewHandler(logger, deps.AuthnService, deps.WebhookService, deps.UserService, deps.ServiceUserService, sessionMiddleware).Register(router)
+	oauth2api.NewClientHandler(logger, deps.AuthnService, deps.OAuth2Service, deps.UserService, deps.ServiceUserService,
+		sessionMiddleware).Register(router)
 	if err := frontierv1beta1.RegisterAdminServiceHandler(ctx, grpcGateway, grpcConn); err != nil {
 		return err
 	}
@@ -210,7 +284,8 @@ func Serve(
 		),
 	)
 
-	grpcMiddleware := getGRPCMiddleware(logger, cfg.IdentityProxyHeader, nrApp, sessionMiddleware, srvMetrics, deps)
+	grpcMiddleware := getGRPCMiddleware(logger, cfg.IdentityProxyHeader, nrApp, sessionMiddleware, srvMetrics, deps,
+		cfg.Authentication.RecentAuthenticationRules())
 	grpcServerOpts := []grpc.ServerOption{
 		grpcMiddleware,
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
@@ -226,7 +301,9 @@ func Serve(
 	reflection.Register(grpcServer)
 	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewHandler())
 
-	v1beta1.Register(grpcServer, deps, cfg.Authentication)
+	// the gateway calls the grpc server on behalf of http clients, adding their
+	// address to the x-forwarded-for metadata
+	v1beta1.Register(grpcServer, deps, cfg.Authentication, append(gatewayProxies(cfg.Host), proxies...))
 
 	var metricsOps = []mux.Option{
 		mux.WithHTTPTarget(fmt.Sprintf(":%d", cfg.Port), &http.Server{
@@ -273,6 +350,7 @@ func Serve(
 // REVISIT: passing config.Frontier as reference
 func getGRPCMiddleware(logger log.Logger, identityProxyHeader string, nrApp newrelic.Application,
 	sessionMiddleware *interceptors.Session, srvMetrics *grpcprom.ServerMetrics, deps api.Deps,
+	recentAuthentication map[string]time.Duration,
 ) grpc.ServerOption {
 	grpcZapLogger := zap.NewExample().Sugar()
 	loggerZap, ok := logger.(*log.Zap)
@@ -309,7 +387,7 @@ func getGRPCMiddleware(logger log.Logger, identityProxyHeader string, nrApp newr
 			grpc_validator.UnaryServerInterceptor(),
 			sessionMiddleware.UnaryGRPCRequestHeadersAnnotator(),
 			interceptors.UnaryErrorHandler(),
-			interceptors.UnaryAuthenticationCheck(),
+			interceptors.UnaryAuthenticationCheck(recentAuthentication),
 			interceptors.UnaryAPIRequestEnrich(),
 			interceptors.UnaryAuthorizationCheck(),
 			interceptors.UnaryCtxWithAudit(deps.AuditService),
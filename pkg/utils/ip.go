@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// Proxies are the networks of the proxies in front of the server trusted to
+// forward the address of clients in the X-Forwarded-For header
+type Proxies []*net.IPNet
+
+// ParseProxies parses the cidrs of trusted proxies, a plain ip address
+// trusts that address only
+func ParseProxies(cidrs []string) (Proxies, error) {
+	var proxies Proxies
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", cidr)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		proxies = append(proxies, network)
+	}
+	return proxies, nil
+}
+
+// Trusts tells if the ip address belongs to a trusted proxy
+func (p Proxies) Trusts(ip net.IP) bool {
+	for _, network := range p {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the ip address of the client. The X-Forwarded-For header
+// is only honoured when the connection comes from a trusted proxy, its
+// entries are read right to left skipping the trusted proxies as the ones on
+// the left are set by the client and can't be trusted.
+func (p Proxies) ClientIP(forwardedFor, remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	client := net.ParseIP(host)
+	if client == nil {
+		return ""
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0 && p.Trusts(client); i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// the header is malformed from here, the last proxy is the
+			// furthest address known
+			break
+		}
+		client = hop
+	}
+	return client.String()
+}
@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProxies(t *testing.T) {
+	proxies, err := ParseProxies([]string{"10.0.0.0/8", "192.0.2.1", "::1", " "})
+	require.NoError(t, err)
+	assert.Len(t, proxies, 3)
+	assert.True(t, proxies.Trusts([]byte{10, 1, 2, 3}))
+	assert.True(t, proxies.Trusts([]byte{192, 0, 2, 1}))
+	assert.False(t, proxies.Trusts([]byte{192, 0, 2, 2}))
+
+	_, err = ParseProxies([]string{"not-an-ip"})
+	assert.Error(t, err)
+	_, err = ParseProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	// the header of untrusted callers is ignored
+	assert.Equal(t, "198.51.100.4", proxies.ClientIP("203.0.113.7", "198.51.100.4:51234"))
+	assert.Equal(t, "198.51.100.4", Proxies(nil).ClientIP("203.0.113.7", "198.51.100.4:51234"))
+	// the entries are read right to left skipping the trusted proxies
+	assert.Equal(t, "203.0.113.7", proxies.ClientIP("203.0.113.7, 10.0.0.1", "10.0.0.2:51234"))
+	assert.Equal(t, "203.0.113.7", proxies.ClientIP("192.0.2.66, 203.0.113.7, 10.0.0.1", "10.0.0.2:51234"))
+	// a malformed entry stops the walk at the last known hop
+	assert.Equal(t, "10.0.0.1", proxies.ClientIP("not-an-ip, 10.0.0.1", "10.0.0.2:51234"))
+	assert.Equal(t, "10.0.0.2", proxies.ClientIP("", "10.0.0.2:51234"))
+	assert.Equal(t, "2001:db8::1", proxies.ClientIP("", "[2001:db8::1]:443"))
+	assert.Equal(t, "", proxies.ClientIP("", "pipe"))
+}
@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raystack/frontier/pkg/crypt"
+)
+
+// SignatureTimestampKey is the key used in the signature header for
+// the unix timestamp at which the payload was signed
+const SignatureTimestampKey = "t"
+
+var ErrInvalidSignatureHeader = fmt.Errorf("invalid signature header")
+
+// SignLegacy generates the deprecated signature of the payload with the hex
+// encoded secret, formatted as <secret id>=<hex hmac> without a timestamp.
+// Receivers verify it with ParseAndValidateEvent
+func SignLegacy(payload []byte, id, hexKey string) (string, error) {
+	mac, err := crypt.GenerateHMACFromHex(payload, hexKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate HMAC: %w", err)
+	}
+	return fmt.Sprintf("%s=%s", id, mac), nil
+}
+
+// Signature is the parsed form of the signature header
+// formatted as: t=<unix seconds>,<secret id>=<hex hmac>[,<secret id>=<hex hmac>...]
+type Signature struct {
+	Timestamp time.Time
+	Values    []SignatureValue
+}
+
+type SignatureValue struct {
+	// SecretID is the id of the secret used to sign the payload
+	SecretID string
+	// MAC is the hex encoded hmac of the signed payload
+	MAC string
+}
+
+func (s Signature) String() string {
+	parts := []string{fmt.Sprintf("%s=%d", SignatureTimestampKey, s.Timestamp.Unix())}
+	for _, v := range s.Values {
+		parts = append(parts, fmt.Sprintf("%s=%s", v.SecretID, v.MAC))
+	}
+	return strings.Join(parts, ",")
+}
+
+// SignedPayload binds the payload to the signing time so that a captured
+// request can't be replayed once the timestamp falls out of tolerance
+func SignedPayload(timestamp time.Time, payload []byte) []byte {
+	return []byte(fmt.Sprintf("%d.%s", timestamp.Unix(), payload))
+}
+
+// Sign generates a signature of the payload with each of the provided hex
+// encoded secrets keyed by their id, in the order of ids
+func Sign(timestamp time.Time, payload []byte, ids []string, hexKeys map[string]string) (Signature, error) {
+	sig := Signature{Timestamp: timestamp}
+	signedPayload := SignedPayload(timestamp, payload)
+	for _, id := range ids {
+		mac, err := crypt.GenerateHMACFromHex(signedPayload, hexKeys[id])
+		if err != nil {
+			return Signature{}, fmt.Errorf("failed to generate HMAC: %w", err)
+		}
+		sig.Values = append(sig.Values, SignatureValue{
+			SecretID: id,
+			MAC:      mac,
+		})
+	}
+	return sig, nil
+}
+
+func ParseSignature(header string) (Signature, error) {
+	var sig Signature
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || key == "" || value == "" {
+			return Signature{}, ErrInvalidSignatureHeader
+		}
+		if key == SignatureTimestampKey {
+			unix, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return Signature{}, ErrInvalidSignatureHeader
+			}
+			sig.Timestamp = time.Unix(unix, 0).UTC()
+			continue
+		}
+		sig.Values = append(sig.Values, SignatureValue{
+			SecretID: key,
+			MAC:      value,
+		})
+	}
+	if sig.Timestamp.IsZero() || len(sig.Values) == 0 {
+		return Signature{}, ErrInvalidSignatureHeader
+	}
+	return sig, nil
+}
+
+// Verify checks if any of the signature values matches the payload signed with
+// any of the provided hex encoded keys and that the signature is not older than
+// tolerance nor signed further than SignatureClockSkew in the future
+func (s Signature) Verify(payload []byte, tolerance time.Duration, hexKeys ...string) error {
+	now := time.Now()
+	if s.Timestamp.Before(now.Add(-tolerance)) {
+		return ErrTimestampExpired
+	}
+	if s.Timestamp.After(now.Add(SignatureClockSkew)) {
+		return ErrTimestampInFuture
+	}
+	signedPayload := SignedPayload(s.Timestamp, payload)
+	for _, key := range hexKeys {
+		for _, v := range s.Values {
+			valid, err := crypt.VerifyHMACFromHex(signedPayload, key, v.MAC)
+			if err != nil {
+				return fmt.Errorf("%s: %w", err.Error(), ErrVerificationFailed)
+			}
+			if valid {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSignature
+}
@@ -0,0 +1,109 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignature_Verify(t *testing.T) {
+	oldKey, newKey := "0a0b0c0d", "01020304"
+	payload := []byte(`{"id":"evt-1"}`)
+	tests := []struct {
+		name      string
+		signedAt  time.Time
+		ids       []string
+		verifyKey string
+		payload   []byte
+		wantErr   error
+	}{
+		{
+			name:      "should verify with the old key during overlap",
+			signedAt:  time.Now(),
+			ids:       []string{"2", "1"},
+			verifyKey: oldKey,
+			payload:   payload,
+		},
+		{
+			name:      "should verify with the new key",
+			signedAt:  time.Now(),
+			ids:       []string{"2", "1"},
+			verifyKey: newKey,
+			payload:   payload,
+		},
+		{
+			name:      "should fail once the old key is retired",
+			signedAt:  time.Now(),
+			ids:       []string{"2"},
+			verifyKey: oldKey,
+			payload:   payload,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "should fail for a tampered payload",
+			signedAt:  time.Now(),
+			ids:       []string{"2"},
+			verifyKey: newKey,
+			payload:   []byte(`{"id":"evt-2"}`),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "should fail for a replayed signature",
+			signedAt:  time.Now().Add(-time.Hour),
+			ids:       []string{"2"},
+			verifyKey: newKey,
+			payload:   payload,
+			wantErr:   ErrTimestampExpired,
+		},
+		{
+			name:      "should fail for a timestamp too far in the future",
+			signedAt:  time.Now().Add(time.Hour),
+			ids:       []string{"2"},
+			verifyKey: newKey,
+			payload:   payload,
+			wantErr:   ErrTimestampInFuture,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sig, err := Sign(tt.signedAt, payload, tt.ids, map[string]string{"1": oldKey, "2": newKey})
+			assert.NoError(t, err)
+
+			parsed, err := ParseSignature(sig.String())
+			assert.NoError(t, err)
+			assert.Equal(t, sig.Values, parsed.Values)
+
+			err = parsed.Verify(tt.payload, EventExpiryDuration, tt.verifyKey)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSignLegacy(t *testing.T) {
+	key := "0a0b0c0d"
+	payload := []byte(`{"id":"evt-1","action":"app.user.created","createdAt":"` + time.Now().UTC().Format(time.RFC3339) + `"}`)
+	header, err := SignLegacy(payload, "1", key)
+	assert.NoError(t, err)
+
+	id, mac, ok := strings.Cut(header, "=")
+	assert.True(t, ok)
+	assert.Equal(t, "1", id)
+	event, err := ParseAndValidateEvent(payload, key, mac)
+	assert.NoError(t, err)
+	assert.Equal(t, "evt-1", event.GetId())
+}
+
+func TestParseSignature(t *testing.T) {
+	_, err := ParseSignature("1=abcd")
+	assert.ErrorIs(t, err, ErrInvalidSignatureHeader)
+
+	_, err = ParseSignature("t=1700000000")
+	assert.ErrorIs(t, err, ErrInvalidSignatureHeader)
+
+	sig, err := ParseSignature("t=1700000000,2=abcd,1=ef01")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1700000000), sig.Timestamp.Unix())
+	assert.Equal(t, []SignatureValue{{SecretID: "2", MAC: "abcd"}, {SecretID: "1", MAC: "ef01"}}, sig.Values)
+}
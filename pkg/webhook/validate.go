@@ -14,10 +14,17 @@ var (
 	ErrInvalidSignature   = fmt.Errorf("invalid signature")
 	ErrVerificationFailed = fmt.Errorf("verification failed")
 	ErrTimestampExpired   = fmt.Errorf("timestamp expired")
+	ErrTimestampInFuture  = fmt.Errorf("timestamp in the future")
 
 	EventExpiryDuration = time.Minute * 10
+	// SignatureClockSkew is how far in the future a signature timestamp is
+	// accepted to allow for clock drift between frontier and the receiver
+	SignatureClockSkew = time.Minute
 )
 
+// ParseAndValidateEvent verifies an event with the signature of the legacy
+// X-Signature header, which is signed without a timestamp.
+// Deprecated: use ParseAndValidateSignedEvent with the X-Webhook-Signature header
 func ParseAndValidateEvent(data []byte, hexKey string, suppliedHexMAC string) (*frontierv1beta1.WebhookEvent, error) {
 	validHmac, err := crypt.VerifyHMACFromHex(data, hexKey, suppliedHexMAC)
 	if err != nil {
@@ -37,3 +44,21 @@ func ParseAndValidateEvent(data []byte, hexKey string, suppliedHexMAC string) (*
 	}
 	return &event, nil
 }
+
+// ParseAndValidateSignedEvent verifies the X-Webhook-Signature header of a delivery and parses
+// the event. More than one key can be provided while the secret is being rotated,
+// the event is valid if it is signed with any one of them.
+func ParseAndValidateSignedEvent(data []byte, signatureHeader string, hexKeys ...string) (*frontierv1beta1.WebhookEvent, error) {
+	sig, err := ParseSignature(signatureHeader)
+	if err != nil {
+		return nil, err
+	}
+	if err := sig.Verify(data, EventExpiryDuration, hexKeys...); err != nil {
+		return nil, err
+	}
+	var event frontierv1beta1.WebhookEvent
+	if err := protojson.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidEvent)
+	}
+	return &event, nil
+}
@@ -2621,6 +2621,7 @@ func (s *APIRegressionTestSuite) TestWebhookAPI() {
 		var body frontierv1beta1.WebhookEvent
 		var authHeader string
 		var signatureHeader string
+		var timestampedSignatureHeader string
 
 		// create a test http server and use the generated endpoint to pass as webhook url
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -2631,6 +2632,7 @@ func (s *APIRegressionTestSuite) TestWebhookAPI() {
 
 				authHeader = r.Header.Get("Authorization")
 				signatureHeader = r.Header.Get("X-Signature")
+				timestampedSignatureHeader = r.Header.Get("X-Webhook-Signature")
 
 				w.WriteHeader(http.StatusOK)
 				return
@@ -2678,6 +2680,10 @@ func (s *APIRegressionTestSuite) TestWebhookAPI() {
 		parsedEvent, err := webhook.ParseAndValidateEvent(rawBody, createWebhookResp.GetWebhook().GetSecrets()[0].GetValue(), signatureHash[1])
 		s.Assert().NoError(err)
 		s.Assert().NotNil(parsedEvent)
+
+		parsedSignedEvent, err := webhook.ParseAndValidateSignedEvent(rawBody, timestampedSignatureHeader, createWebhookResp.GetWebhook().GetSecrets()[0].GetValue())
+		s.Assert().NoError(err)
+		s.Assert().NotNil(parsedSignedEvent)
 	})
 }
 